@@ -32,6 +32,9 @@ type Accountant struct {
 
 	// number of bins in the circular accounting, restricted by minNumBins which is 3
 	numBins uint32
+
+	// optional persistence for the local accounting, shared by all clients paying from the same account
+	store AccountantStore
 }
 
 type PeriodRecord struct {
@@ -59,6 +62,16 @@ func NewAccountant(accountID gethcommon.Address, reservation *core.ReservedPayme
 	return &a
 }
 
+// SetStore makes the accountant persist its local accounting (period records and cumulative payment) in the given
+// store. Every payment is then accounted against the persisted state, so the accountant picks up where it left off
+// after a restart, and clients that share the store (e.g. replicas of a batcher paying from the same account) never
+// produce conflicting payments. Must be called before the accountant is used.
+func (a *Accountant) SetStore(store AccountantStore) {
+	a.usageLock.Lock()
+	defer a.usageLock.Unlock()
+	a.store = store
+}
+
 // BlobPaymentInfo calculates and records payment information. The accountant
 // will attempt to use the active reservation first and check for quorum settings,
 // then on-demand if the reservation is not available. It takes in a timestamp at
@@ -74,11 +87,37 @@ func (a *Accountant) BlobPaymentInfo(
 	quorumNumbers []uint8,
 	timestamp int64) (*big.Int, error) {
 
+	a.usageLock.Lock()
+	defer a.usageLock.Unlock()
+
+	if a.store == nil {
+		return a.blobPaymentInfo(numSymbols, quorumNumbers, timestamp)
+	}
+
+	var payment *big.Int
+	err := a.store.Update(ctx, a.accountID, func(state *AccountantState) error {
+		// Other clients sharing the store may have used the account since the last update.
+		a.mergeState(state.PeriodRecords, state.CumulativePayment)
+
+		var err error
+		payment, err = a.blobPaymentInfo(numSymbols, quorumNumbers, timestamp)
+		if err != nil {
+			return err
+		}
+		*state = a.localState()
+		return nil
+	})
+	if err != nil {
+		return big.NewInt(0), err
+	}
+	return payment, nil
+}
+
+// blobPaymentInfo implements BlobPaymentInfo against the in-memory state. The caller must hold usageLock.
+func (a *Accountant) blobPaymentInfo(numSymbols uint64, quorumNumbers []uint8, timestamp int64) (*big.Int, error) {
 	currentReservationPeriod := meterer.GetReservationPeriodByNanosecond(timestamp, a.reservationWindow)
 	symbolUsage := a.SymbolsCharged(numSymbols)
 
-	a.usageLock.Lock()
-	defer a.usageLock.Unlock()
 	relativePeriodRecord := a.GetRelativePeriodRecord(currentReservationPeriod)
 	relativePeriodRecord.Usage += symbolUsage
 
@@ -243,6 +282,65 @@ func (a *Accountant) SetPaymentState(paymentState *disperser_rpc.GetPaymentState
 	return nil
 }
 
+// ReconcilePaymentState updates the accountant from the disperser's response like SetPaymentState, except that the
+// off-chain state (period records and cumulative payment) is merged with the local and persisted state instead of
+// replacing it. For each reservation period the larger usage is kept, and the larger of the cumulative payments is
+// kept, so that a stale view on either side never causes the account to reuse a payment or overrun its reservation.
+// It is safe to call periodically, and with other clients using the same account and store.
+func (a *Accountant) ReconcilePaymentState(ctx context.Context, paymentState *disperser_rpc.GetPaymentStateReply) error {
+	a.usageLock.Lock()
+	defer a.usageLock.Unlock()
+
+	localRecords := a.periodRecords
+	localPayment := a.cumulativePayment
+	if err := a.SetPaymentState(paymentState); err != nil {
+		return err
+	}
+	remoteRecords := a.periodRecords
+	remotePayment := a.cumulativePayment
+
+	a.periodRecords = make([]PeriodRecord, a.numBins)
+	for i := range a.periodRecords {
+		a.periodRecords[i] = PeriodRecord{Index: uint32(i), Usage: 0}
+	}
+	a.cumulativePayment = big.NewInt(0)
+	a.mergeState(localRecords, localPayment)
+	a.mergeState(remoteRecords, remotePayment)
+
+	if a.store == nil {
+		return nil
+	}
+	return a.store.Update(ctx, a.accountID, func(state *AccountantState) error {
+		a.mergeState(state.PeriodRecords, state.CumulativePayment)
+		*state = a.localState()
+		return nil
+	})
+}
+
+// mergeState merges period records and a cumulative payment into the in-memory state, keeping the most recent
+// reservation period for each bin, the larger usage within a period, and the larger cumulative payment. The caller
+// must hold usageLock.
+func (a *Accountant) mergeState(periodRecords []PeriodRecord, cumulativePayment *big.Int) {
+	for _, record := range periodRecords {
+		relativeIndex := record.Index % a.numBins
+		current := &a.periodRecords[relativeIndex]
+		if current.Index < record.Index || (current.Index == record.Index && current.Usage < record.Usage) {
+			*current = record
+		}
+	}
+	if cumulativePayment != nil && cumulativePayment.Cmp(a.cumulativePayment) > 0 {
+		a.cumulativePayment = new(big.Int).Set(cumulativePayment)
+	}
+}
+
+// localState returns a copy of the in-memory local accounting state. The caller must hold usageLock.
+func (a *Accountant) localState() AccountantState {
+	return AccountantState{
+		PeriodRecords:     slices.Clone(a.periodRecords),
+		CumulativePayment: new(big.Int).Set(a.cumulativePayment),
+	}
+}
+
 // QuorumCheck eagerly returns error if the check finds a quorum number not an element of the allowed quorum numbers
 func QuorumCheck(quorumNumbers []uint8, allowedNumbers []uint8) error {
 	if len(quorumNumbers) == 0 {
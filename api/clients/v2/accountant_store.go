@@ -0,0 +1,173 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/gofrs/flock"
+)
+
+// AccountantState is the local accounting state of an Accountant, i.e. the part of its state that is not read from
+// the chain.
+type AccountantState struct {
+	// PeriodRecords are the reservation usages of the most recent reservation periods.
+	PeriodRecords []PeriodRecord `json:"periodRecords"`
+	// CumulativePayment is the largest cumulative on-demand payment used by the account.
+	CumulativePayment *big.Int `json:"cumulativePayment"`
+}
+
+// AccountantStore persists the local accounting state of an Accountant, so that it survives restarts and can be shared
+// by several client processes that pay from the same account.
+type AccountantStore interface {
+	// Update atomically reads the state of the account, passes it to update, and writes it back if update returns
+	// nil. The state passed to update is empty if nothing has been stored for the account yet. Updates of the same
+	// account are serialized across every user of the store, including other processes if the implementation
+	// supports it.
+	Update(ctx context.Context, accountID gethcommon.Address, update func(state *AccountantState) error) error
+}
+
+// FileAccountantStore is an AccountantStore that keeps the state of each account in a JSON file in a directory.
+// Updates are serialized with an advisory file lock, so a single directory may be shared by several processes on the
+// same host (or on a file system with working flock support).
+type FileAccountantStore struct {
+	directory string
+}
+
+var _ AccountantStore = &FileAccountantStore{}
+
+// fileLockRetryDelay is the interval at which a FileAccountantStore retries to acquire a lock held by another process.
+const fileLockRetryDelay = 5 * time.Millisecond
+
+// NewFileAccountantStore creates a FileAccountantStore in the given directory, creating the directory if needed.
+func NewFileAccountantStore(directory string) (*FileAccountantStore, error) {
+	if err := os.MkdirAll(directory, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create accountant state directory %s: %w", directory, err)
+	}
+	return &FileAccountantStore{directory: directory}, nil
+}
+
+func (s *FileAccountantStore) Update(
+	ctx context.Context,
+	accountID gethcommon.Address,
+	update func(state *AccountantState) error) error {
+
+	path := filepath.Join(s.directory, accountID.Hex()+".json")
+
+	lock := flock.New(path + ".lock")
+	locked, err := lock.TryLockContext(ctx, fileLockRetryDelay)
+	if err != nil {
+		return fmt.Errorf("failed to lock accountant state %s: %w", path, err)
+	}
+	if !locked {
+		return fmt.Errorf("failed to lock accountant state %s", path)
+	}
+	defer func() {
+		_ = lock.Unlock()
+	}()
+
+	state := &AccountantState{}
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read accountant state %s: %w", path, err)
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, state); err != nil {
+			return fmt.Errorf("failed to parse accountant state %s: %w", path, err)
+		}
+	}
+
+	if err := update(state); err != nil {
+		return err
+	}
+
+	data, err = json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to serialize accountant state: %w", err)
+	}
+
+	// Write to a temporary file and rename it, so that a crash never leaves a partially written state behind.
+	tmpPath := path + ".tmp"
+	if err := writeFileSync(tmpPath, data); err != nil {
+		return fmt.Errorf("failed to write accountant state %s: %w", tmpPath, err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to replace accountant state %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// writeFileSync writes data to the file at path and flushes it to disk.
+func writeFileSync(path string, data []byte) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// KVAccountantStore is an AccountantStore on top of a kvstore.Store, e.g. a LevelDB database. Updates are serialized
+// within the process; the underlying store must not be written to by other processes.
+type KVAccountantStore struct {
+	store kvstore.Store[[]byte]
+	lock  sync.Mutex
+}
+
+var _ AccountantStore = &KVAccountantStore{}
+
+// NewKVAccountantStore creates a KVAccountantStore that keeps accountant state in the given store, keyed by account.
+func NewKVAccountantStore(store kvstore.Store[[]byte]) *KVAccountantStore {
+	return &KVAccountantStore{store: store}
+}
+
+func (s *KVAccountantStore) Update(
+	ctx context.Context,
+	accountID gethcommon.Address,
+	update func(state *AccountantState) error) error {
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	key := accountID.Bytes()
+	state := &AccountantState{}
+	data, err := s.store.Get(key)
+	if err != nil && !errors.Is(err, kvstore.ErrNotFound) {
+		return fmt.Errorf("failed to read accountant state: %w", err)
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, state); err != nil {
+			return fmt.Errorf("failed to parse accountant state: %w", err)
+		}
+	}
+
+	if err := update(state); err != nil {
+		return err
+	}
+
+	data, err = json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to serialize accountant state: %w", err)
+	}
+	if err := s.store.Put(key, data); err != nil {
+		return fmt.Errorf("failed to write accountant state: %w", err)
+	}
+
+	return nil
+}
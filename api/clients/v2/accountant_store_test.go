@@ -0,0 +1,179 @@
+package clients
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/common/kvstore/mapstore"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/meterer"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+var testAccountID = gethcommon.HexToAddress("0x1234567890123456789012345678901234567890")
+
+func newTestAccountantStores(t *testing.T) map[string]func() AccountantStore {
+	directory := t.TempDir()
+	kvStore := NewKVAccountantStore(mapstore.NewStore())
+	return map[string]func() AccountantStore{
+		"file": func() AccountantStore {
+			store, err := NewFileAccountantStore(directory)
+			require.NoError(t, err)
+			return store
+		},
+		"kvstore": func() AccountantStore {
+			return kvStore
+		},
+	}
+}
+
+func newStoredAccountant(store AccountantStore) *Accountant {
+	reservation := &core.ReservedPayment{
+		SymbolsPerSecond: 200,
+		StartTimestamp:   100,
+		EndTimestamp:     200,
+		QuorumSplits:     []byte{50, 50},
+		QuorumNumbers:    []uint8{0, 1},
+	}
+	onDemand := &core.OnDemandPayment{
+		CumulativePayment: big.NewInt(1_000_000),
+	}
+	accountant := NewAccountant(testAccountID, reservation, onDemand, 5, 1, 100, numBins)
+	accountant.SetStore(store)
+	return accountant
+}
+
+func TestAccountantStoreRestart(t *testing.T) {
+	for name, newStore := range newTestAccountantStores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			quorums := []uint8{0, 1}
+			now := time.Now().UnixNano()
+
+			accountant := newStoredAccountant(newStore())
+			// Use up the reservation, then pay on demand.
+			_, err := accountant.AccountBlob(ctx, now, 1000, quorums)
+			require.NoError(t, err)
+			payment, err := accountant.AccountBlob(ctx, now, 1000, quorums)
+			require.NoError(t, err)
+			require.Equal(t, big.NewInt(1000), payment.CumulativePayment)
+
+			// A restarted accountant continues from the persisted state.
+			restarted := newStoredAccountant(newStore())
+			payment, err = restarted.AccountBlob(ctx, now, 200, quorums)
+			require.NoError(t, err)
+			require.Equal(t, big.NewInt(1200), payment.CumulativePayment)
+			record := restarted.GetRelativePeriodRecord(meterer.GetReservationPeriodByNanosecond(now, 5))
+			require.Equal(t, uint64(1000), record.Usage)
+		})
+	}
+}
+
+func TestAccountantStoreSharedAccount(t *testing.T) {
+	for name, newStore := range newTestAccountantStores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			quorums := []uint8{0, 1}
+			now := time.Now().UnixNano()
+
+			// Several clients pay from the same account. The reservation allows 1000 symbols per period, so the
+			// first payments use the reservation and the rest are paid on demand.
+			accountants := make([]*Accountant, 4)
+			for i := range accountants {
+				accountants[i] = newStoredAccountant(newStore())
+			}
+
+			var lock sync.Mutex
+			payments := make(map[string]struct{})
+			reservationPayments := 0
+
+			var wg sync.WaitGroup
+			for _, accountant := range accountants {
+				wg.Add(1)
+				go func(accountant *Accountant) {
+					defer wg.Done()
+					for i := 0; i < 10; i++ {
+						payment, err := accountant.AccountBlob(ctx, now, 100, quorums)
+						require.NoError(t, err)
+
+						lock.Lock()
+						if payment.CumulativePayment.Sign() == 0 {
+							reservationPayments++
+						} else {
+							// Every on-demand payment is unique.
+							_, ok := payments[payment.CumulativePayment.String()]
+							require.False(t, ok, "duplicate cumulative payment %s", payment.CumulativePayment)
+							payments[payment.CumulativePayment.String()] = struct{}{}
+						}
+						lock.Unlock()
+					}
+				}(accountant)
+			}
+			wg.Wait()
+
+			// Exactly 10 blobs fit in the reservation.
+			require.Equal(t, 10, reservationPayments)
+			require.Len(t, payments, 30)
+			for i := 1; i <= 30; i++ {
+				require.Contains(t, payments, big.NewInt(int64(i*100)).String())
+			}
+		})
+	}
+}
+
+func TestReconcilePaymentState(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewFileAccountantStore(t.TempDir())
+	require.NoError(t, err)
+	accountant := newStoredAccountant(store)
+
+	now := time.Now().UnixNano()
+	period := uint32(meterer.GetReservationPeriodByNanosecond(now, 5))
+	_, err = accountant.AccountBlob(ctx, now, 300, []uint8{0, 1})
+	require.NoError(t, err)
+
+	paymentState := &disperser_rpc.GetPaymentStateReply{
+		PaymentGlobalParams: &disperser_rpc.PaymentGlobalParams{
+			MinNumSymbols:     100,
+			PricePerSymbol:    1,
+			ReservationWindow: 5,
+		},
+		PeriodRecords: []*disperser_rpc.PeriodRecord{
+			{Index: period, Usage: 200},
+			{Index: period + 1, Usage: 50},
+		},
+		Reservation: &disperser_rpc.Reservation{
+			SymbolsPerSecond: 200,
+			StartTimestamp:   100,
+			EndTimestamp:     200,
+			QuorumNumbers:    []uint32{0, 1},
+			QuorumSplits:     []uint32{50, 50},
+		},
+		CumulativePayment:        big.NewInt(700).Bytes(),
+		OnchainCumulativePayment: big.NewInt(1_000_000).Bytes(),
+	}
+
+	// The larger usage and cumulative payment win on each side.
+	require.NoError(t, accountant.ReconcilePaymentState(ctx, paymentState))
+	require.Equal(t, uint64(300), accountant.GetRelativePeriodRecord(uint64(period)).Usage)
+	require.Equal(t, uint64(50), accountant.GetRelativePeriodRecord(uint64(period+1)).Usage)
+	require.Equal(t, big.NewInt(700), accountant.cumulativePayment)
+
+	// The reconciled state is persisted.
+	restarted := newStoredAccountant(store)
+	payment, err := restarted.AccountBlob(ctx, now, 2000, []uint8{0, 1})
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(2700), payment.CumulativePayment)
+
+	// A stale disperser view does not roll the local state back.
+	paymentState.CumulativePayment = big.NewInt(100).Bytes()
+	paymentState.PeriodRecords = nil
+	require.NoError(t, restarted.ReconcilePaymentState(ctx, paymentState))
+	require.Equal(t, big.NewInt(2700), restarted.cumulativePayment)
+	require.Equal(t, uint64(300), restarted.GetRelativePeriodRecord(uint64(period)).Usage)
+}
@@ -7,10 +7,14 @@ import (
 	"path/filepath"
 	"runtime"
 	"testing"
+	"time"
 
+	disperserpb "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
 	"github.com/Layr-Labs/eigenda/api/hashing"
+	"github.com/Layr-Labs/eigenda/common"
 	aws2 "github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/testutils/random"
+	authv2 "github.com/Layr-Labs/eigenda/core/auth/v2"
 	"github.com/Layr-Labs/eigenda/inabox/deploy"
 	"github.com/Layr-Labs/eigenda/node/auth"
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -134,3 +138,56 @@ func TestRequestSigning(t *testing.T) {
 		}
 	}
 }
+
+func TestKMSBlobRequestSigning(t *testing.T) {
+	setup(t)
+	defer teardown()
+
+	keyManager := kms.New(kms.Options{
+		Region:       region,
+		BaseEndpoint: aws.String(localstackHost),
+	})
+
+	createKeyOutput, err := keyManager.CreateKey(context.Background(), &kms.CreateKeyInput{
+		KeySpec:  types.KeySpecEccSecgP256k1,
+		KeyUsage: types.KeyUsageTypeSignVerify,
+	})
+	require.NoError(t, err)
+	keyID := *createKeyOutput.KeyMetadata.KeyId
+
+	key, err := aws2.LoadPublicKeyKMS(context.Background(), keyManager, keyID)
+	require.NoError(t, err)
+	publicAddress := crypto.PubkeyToAddress(*key)
+
+	signer, err := authv2.NewBlobRequestSigner(context.Background(), authv2.BlobRequestSignerConfig{
+		KMS:         common.KMSKeyConfig{KeyID: keyID, Region: region},
+		KMSEndpoint: localstackHost,
+	})
+	require.NoError(t, err)
+
+	accountID, err := signer.GetAccountID()
+	require.NoError(t, err)
+	require.Equal(t, publicAddress, accountID)
+
+	for i := 0; i < 10; i++ {
+		timestamp := uint64(time.Now().UnixNano())
+		signature, err := signer.SignPaymentStateRequest(timestamp)
+		require.NoError(t, err)
+
+		authenticator := authv2.NewPaymentStateAuthenticator(time.Minute, time.Minute)
+		err = authenticator.AuthenticatePaymentStateRequest(accountID, &disperserpb.GetPaymentStateRequest{
+			AccountId: accountID.Hex(),
+			Signature: signature,
+			Timestamp: timestamp,
+		})
+		require.NoError(t, err)
+
+		// A signature for a different timestamp must not verify.
+		err = authenticator.AuthenticatePaymentStateRequest(accountID, &disperserpb.GetPaymentStateRequest{
+			AccountId: accountID.Hex(),
+			Signature: signature,
+			Timestamp: timestamp + 1,
+		})
+		require.Error(t, err)
+	}
+}
@@ -24,6 +24,10 @@ type DisperserClientConfig struct {
 	UseSecureGrpcFlag bool
 	NtpServer         string
 	NtpSyncInterval   time.Duration
+	// AccountantStateDirectory, if set, is the directory in which the accountant created by the client persists its
+	// local accounting (see FileAccountantStore). Clients paying from the same account may share the directory.
+	// Ignored if an accountant is passed to NewDisperserClient.
+	AccountantStateDirectory string
 }
 
 // DisperserClient manages communication with the disperser server.
@@ -46,6 +50,12 @@ type DisperserClient interface {
 		probe *common.SequenceProbe) (*dispv2.BlobStatus, corev2.BlobKey, error)
 	// GetBlobStatus returns the status of a blob with the given blob key.
 	GetBlobStatus(ctx context.Context, blobKey corev2.BlobKey) (*disperser_rpc.BlobStatusReply, error)
+	// SubscribeBlobStatus opens a stream on which the disperser sends the status of a blob each time it changes,
+	// until the blob reaches a terminal status. Disperser versions that don't support subscriptions fail the first
+	// Recv on the stream with codes.Unimplemented.
+	SubscribeBlobStatus(
+		ctx context.Context,
+		blobKey corev2.BlobKey) (disperser_rpc.Disperser_SubscribeBlobStatusClient, error)
 	// GetBlobCommitment returns the blob commitment for a given blob payload.
 	GetBlobCommitment(ctx context.Context, data []byte) (*disperser_rpc.BlobCommitmentReply, error)
 }
@@ -129,7 +139,9 @@ func NewDisperserClient(config *DisperserClientConfig, signer corev2.BlobRequest
 	}, nil
 }
 
-// PopulateAccountant populates the accountant with the payment state from the disperser.
+// PopulateAccountant populates the accountant with the payment state from the disperser. The disperser's view of the
+// account is merged with the accountant's local state (see Accountant.ReconcilePaymentState), so this may also be
+// called periodically to reconcile the two.
 func (c *disperserClient) PopulateAccountant(ctx context.Context) error {
 	if c.accountant == nil {
 		accountId, err := c.signer.GetAccountID()
@@ -137,6 +149,14 @@ func (c *disperserClient) PopulateAccountant(ctx context.Context) error {
 			return fmt.Errorf("error getting account ID: %w", err)
 		}
 		c.accountant = NewAccountant(accountId, nil, nil, 0, 0, 0, 0)
+
+		if c.config.AccountantStateDirectory != "" {
+			store, err := NewFileAccountantStore(c.config.AccountantStateDirectory)
+			if err != nil {
+				return fmt.Errorf("error creating accountant store: %w", err)
+			}
+			c.accountant.SetStore(store)
+		}
 	}
 
 	paymentState, err := c.GetPaymentState(ctx)
@@ -144,7 +164,7 @@ func (c *disperserClient) PopulateAccountant(ctx context.Context) error {
 		return fmt.Errorf("error getting payment state for initializing accountant: %w", err)
 	}
 
-	err = c.accountant.SetPaymentState(paymentState)
+	err = c.accountant.ReconcilePaymentState(ctx, paymentState)
 	if err != nil {
 		return fmt.Errorf("error setting payment state for accountant: %w", err)
 	}
@@ -362,6 +382,22 @@ func (c *disperserClient) GetBlobStatus(ctx context.Context, blobKey corev2.Blob
 	return c.client.GetBlobStatus(ctx, request)
 }
 
+// SubscribeBlobStatus opens a stream of status updates for the blob with the given blob key.
+func (c *disperserClient) SubscribeBlobStatus(
+	ctx context.Context,
+	blobKey corev2.BlobKey,
+) (disperser_rpc.Disperser_SubscribeBlobStatusClient, error) {
+	err := c.initOnceGrpcConnection()
+	if err != nil {
+		return nil, api.NewErrorInternal(err.Error())
+	}
+
+	request := &disperser_rpc.SubscribeBlobStatusRequest{
+		BlobKey: blobKey[:],
+	}
+	return c.client.SubscribeBlobStatus(ctx, request)
+}
+
 // GetPaymentState returns the payment state of the disperser client
 func (c *disperserClient) GetPaymentState(ctx context.Context) (*disperser_rpc.GetPaymentStateReply, error) {
 	err := c.initOnceGrpcConnection()
@@ -1,6 +1,7 @@
 package examples
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"math/rand"
@@ -36,7 +37,16 @@ const (
 	eigenDAServiceManagerAddress     = "0xD4A7E1Bd8015057293f0D0A557088c286942e84b"
 )
 
-func createPayloadDisperser(privateKey string) (*payloaddispersal.PayloadDisperser, error) {
+// createPayloadDisperser creates a PayloadDisperser that signs dispersal requests with the key described by
+// signerConfig. Production deployments should prefer a KMS key or a remote signer over a raw private key, e.g.:
+//
+//	auth.BlobRequestSignerConfig{KMS: common.KMSKeyConfig{KeyID: "<key id>", Region: "us-east-1"}}
+//	auth.BlobRequestSignerConfig{
+//		RemoteSignerURL:     "http://signer:9000",
+//		RemoteSignerAddress: "0x...",
+//		RemoteSignerMethod:  "<method signing raw digests>",
+//	}
+func createPayloadDisperser(signerConfig auth.BlobRequestSignerConfig) (*payloaddispersal.PayloadDisperser, error) {
 	logger, err := createLogger()
 	if err != nil {
 		panic(fmt.Sprintf("create logger: %v", err))
@@ -47,7 +57,7 @@ func createPayloadDisperser(privateKey string) (*payloaddispersal.PayloadDispers
 		return nil, fmt.Errorf("create kzg prover: %v", err)
 	}
 
-	disperserClient, err := createDisperserClient(privateKey, kzgProver)
+	disperserClient, err := createDisperserClient(signerConfig, kzgProver)
 	if err != nil {
 		return nil, fmt.Errorf("create disperser client: %w", err)
 	}
@@ -195,8 +205,11 @@ func createRelayClient(
 		relayUrlProvider)
 }
 
-func createDisperserClient(privateKey string, kzgProver *prover.Prover) (clients.DisperserClient, error) {
-	signer, err := auth.NewLocalBlobRequestSigner(privateKey)
+func createDisperserClient(
+	signerConfig auth.BlobRequestSignerConfig,
+	kzgProver *prover.Prover,
+) (clients.DisperserClient, error) {
+	signer, err := auth.NewBlobRequestSigner(context.Background(), signerConfig)
 	if err != nil {
 		return nil, fmt.Errorf("create blob request signer: %w", err)
 	}
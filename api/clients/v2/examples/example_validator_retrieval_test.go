@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	auth "github.com/Layr-Labs/eigenda/core/auth/v2"
 )
 
 // This example demonstrates how to use the ValidatorPayloadRetriever to retrieve a payload from EigenDA, running on
@@ -26,7 +27,7 @@ func Example_validatorPayloadRetrieval() {
 
 	// Create a payload disperser and disperse a sample payload to EigenDA
 	// This will be the payload we will later retrieve
-	payloadDisperser, err := createPayloadDisperser(privateKey)
+	payloadDisperser, err := createPayloadDisperser(auth.BlobRequestSignerConfig{PrivateKeyHex: privateKey})
 	if err != nil {
 		panic(fmt.Sprintf("create payload disperser: %v", err))
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/Layr-Labs/eigenda/api/clients/v2/relay"
+	relaygrpc "github.com/Layr-Labs/eigenda/api/grpc/relay"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/stretchr/testify/mock"
 )
@@ -42,6 +43,40 @@ func (c *MockRelayClient) GetChunksByIndex(ctx context.Context, relayKey corev2.
 	return args.Get(0).([][]byte), args.Error(1)
 }
 
+// StreamChunksByRange calls the handler for each bundle returned by the mock, in order.
+func (c *MockRelayClient) StreamChunksByRange(ctx context.Context, relayKey corev2.RelayKey, requests []*relay.ChunkRequestByRange, handler relay.ChunkBundleHandler) error {
+	args := c.Called(ctx, relayKey, requests)
+	return streamBundles(args, handler)
+}
+
+// StreamChunksByIndex calls the handler for each bundle returned by the mock, in order.
+func (c *MockRelayClient) StreamChunksByIndex(ctx context.Context, relayKey corev2.RelayKey, requests []*relay.ChunkRequestByIndex, handler relay.ChunkBundleHandler) error {
+	args := c.Called(ctx, relayKey, requests)
+	return streamBundles(args, handler)
+}
+
+// streamBundles passes the bundles returned by a mocked streaming call to the handler. The mock is expected to
+// return ([][]byte, error); bundles are delivered even if an error is also returned, to simulate a stream that fails
+// part way through.
+func streamBundles(args mock.Arguments, handler relay.ChunkBundleHandler) error {
+	if args.Get(0) != nil {
+		for i, bundle := range args.Get(0).([][]byte) {
+			if err := handler(i, bundle); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+func (c *MockRelayClient) GetPointProof(ctx context.Context, relayKey corev2.RelayKey, blobKey corev2.BlobKey, points []*relaygrpc.PointToOpen) (*relaygrpc.GetPointProofReply, error) {
+	args := c.Called(ctx, relayKey, blobKey, points)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*relaygrpc.GetPointProofReply), args.Error(1)
+}
+
 func (c *MockRelayClient) GetSockets() map[corev2.RelayKey]string {
 	args := c.Called()
 	if args.Get(0) == nil {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
@@ -15,6 +16,8 @@ import (
 	core "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // PayloadDisperser provides the ability to disperse payloads to EigenDA via a Disperser grpc service.
@@ -72,7 +75,8 @@ func NewPayloadDisperser(
 //
 //  1. Encode payload into a blob
 //  2. Disperse the blob
-//  3. Poll the disperser with GetBlobStatus until a terminal status is reached, or until the polling timeout is reached
+//  3. Subscribe to the status of the blob until a terminal status is reached, or until the timeout is reached. If the
+//     disperser doesn't support subscriptions, the status is polled with GetBlobStatus instead
 //  4. Construct an EigenDACert if dispersal is successful
 //  5. Verify the constructed cert via an eth_call to the EigenDACertVerifier contract
 //  6. Return the valid cert
@@ -127,13 +131,13 @@ func (pd *PayloadDisperser) SendPayload(
 
 	probe.SetStage("QUEUED")
 
-	// poll the disperser for the status of the blob until it's received adequate signatures in regards to
+	// wait for the status of the blob to show that it's received adequate signatures in regards to
 	// confirmation thresholds, a terminal error, or a timeout
 	timeoutCtx, cancel = context.WithTimeout(ctx, pd.config.BlobCompleteTimeout)
 	defer cancel()
-	blobStatusReply, err := pd.pollBlobStatusUntilSigned(timeoutCtx, blobKey, blobStatus.ToProfobuf(), probe)
+	blobStatusReply, err := pd.waitForBlobSigned(timeoutCtx, blobKey, blobStatus.ToProfobuf(), probe)
 	if err != nil {
-		return nil, fmt.Errorf("poll blob status until signed: %w", err)
+		return nil, fmt.Errorf("wait for blob signed: %w", err)
 	}
 
 	pd.logSigningPercentages(blobKey, blobStatusReply)
@@ -216,6 +220,92 @@ func (pd *PayloadDisperser) Close() error {
 	return nil
 }
 
+// blobStatusSubscriptionError is returned by subscribeBlobStatusUntilSigned when the subscription itself fails, as
+// opposed to the blob failing to be signed. The status of the blob is then polled instead.
+type blobStatusSubscriptionError struct {
+	err error
+}
+
+func (e *blobStatusSubscriptionError) Error() string {
+	return fmt.Sprintf("blob status subscription: %v", e.err)
+}
+
+func (e *blobStatusSubscriptionError) Unwrap() error {
+	return e.err
+}
+
+// waitForBlobSigned waits for a blob that has been dispersed to be signed, by subscribing to the status of the blob.
+// If the disperser doesn't support subscriptions, or the subscription fails before the blob is signed, then the
+// status is polled with GetBlobStatus for the remainder of the wait.
+//
+// This method will only return a non-nil BlobStatusReply if all quorums meet the required confirmation threshold prior
+// to timeout. In all other cases, this method will return a nil BlobStatusReply, along with an error describing the
+// failure.
+func (pd *PayloadDisperser) waitForBlobSigned(
+	ctx context.Context,
+	blobKey core.BlobKey,
+	initialStatus dispgrpc.BlobStatus,
+	probe *common.SequenceProbe,
+) (*dispgrpc.BlobStatusReply, error) {
+	previousStatus := initialStatus
+	blobStatusReply, err := pd.subscribeBlobStatusUntilSigned(ctx, blobKey, &previousStatus, probe)
+
+	var subscriptionErr *blobStatusSubscriptionError
+	if !errors.As(err, &subscriptionErr) {
+		return blobStatusReply, err
+	}
+
+	if status.Code(subscriptionErr.err) == codes.Unimplemented {
+		pd.logger.Debug("disperser doesn't support blob status subscriptions, polling instead", "blobKey", blobKey.Hex())
+	} else {
+		pd.logger.Warn("blob status subscription failed, polling instead", "err", err, "blobKey", blobKey.Hex())
+	}
+
+	return pd.pollBlobStatusUntilSigned(ctx, blobKey, previousStatus, probe)
+}
+
+// subscribeBlobStatusUntilSigned subscribes to the status of a blob that has been dispersed, and processes each status
+// sent by the disperser until the blob is signed or fails. previousStatus is updated with each status received.
+//
+// If the subscription fails before the blob is signed, a blobStatusSubscriptionError is returned.
+func (pd *PayloadDisperser) subscribeBlobStatusUntilSigned(
+	ctx context.Context,
+	blobKey core.BlobKey,
+	previousStatus *dispgrpc.BlobStatus,
+	probe *common.SequenceProbe,
+) (*dispgrpc.BlobStatusReply, error) {
+
+	// cancel the subscription once we stop reading from it
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := pd.disperserClient.SubscribeBlobStatus(ctx, blobKey)
+	if err != nil {
+		return nil, &blobStatusSubscriptionError{err: err}
+	}
+
+	for {
+		blobStatusReply, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, pd.blobStatusTimeoutError(ctx, *previousStatus)
+			}
+			if errors.Is(err, io.EOF) {
+				err = errors.New("stream ended before the blob was signed")
+			}
+			return nil, &blobStatusSubscriptionError{err: err}
+		}
+
+		signed, err := pd.processBlobStatus(ctx, blobKey, blobStatusReply, previousStatus, probe)
+		if err != nil {
+			return nil, err
+		}
+		if signed {
+			return blobStatusReply, nil
+		}
+	}
+}
+
 // pollBlobStatusUntilSigned polls the disperser for the status of a blob that has been dispersed
 //
 // This method will only return a non-nil BlobStatusReply if all quorums meet the required confirmation threshold prior
@@ -236,11 +326,7 @@ func (pd *PayloadDisperser) pollBlobStatusUntilSigned(
 	for {
 		select {
 		case <-ctx.Done():
-			return nil, fmt.Errorf(
-				"timed out waiting for %v blob status, final status was %v: %w",
-				dispgrpc.BlobStatus_COMPLETE.String(),
-				previousStatus.String(),
-				ctx.Err())
+			return nil, pd.blobStatusTimeoutError(ctx, previousStatus)
 		case <-ticker.C:
 			// This call to the disperser doesn't have a dedicated timeout configured.
 			// If this call fails to return in a timely fashion, the timeout configured for the poll loop will trigger
@@ -251,54 +337,83 @@ func (pd *PayloadDisperser) pollBlobStatusUntilSigned(
 				continue
 			}
 
-			newStatus := blobStatusReply.Status
-			if newStatus != previousStatus {
-				pd.logger.Debug(
-					"Blob status changed",
-					"blob key", blobKey.Hex(),
-					"previous status", previousStatus.String(),
-					"new status", newStatus.String())
-				previousStatus = newStatus
+			signed, err := pd.processBlobStatus(ctx, blobKey, blobStatusReply, &previousStatus, probe)
+			if err != nil {
+				return nil, err
 			}
-
-			// TODO: we'll need to add more in-depth response status processing to derive failover errors
-			switch newStatus {
-			case dispgrpc.BlobStatus_COMPLETE:
-				err := checkThresholds(ctx, pd.certVerifier, blobStatusReply, blobKey.Hex())
-				if err != nil {
-					// returned error is verbose enough, no need to wrap it with additional context
-					return nil, err
-				}
-
+			if signed {
 				return blobStatusReply, nil
-			case dispgrpc.BlobStatus_QUEUED, dispgrpc.BlobStatus_ENCODED:
-				// Report all non-terminal statuses to the probe. Repeat reports are no-ops.
-				probe.SetStage(newStatus.String())
-				continue
-			case dispgrpc.BlobStatus_GATHERING_SIGNATURES:
-				// Report all non-terminal statuses to the probe. Repeat reports are no-ops.
-				probe.SetStage(newStatus.String())
-
-				err := checkThresholds(ctx, pd.certVerifier, blobStatusReply, blobKey.Hex())
-				if err == nil {
-					// If there's no error, then all thresholds are met, so we can stop polling
-					return blobStatusReply, nil
-				}
-
-				var thresholdNotMetErr *thresholdNotMetError
-				if !errors.As(err, &thresholdNotMetErr) {
-					// an error occurred which was unrelated to an unmet threshold: something went wrong while checking!
-					pd.logger.Warnf("error checking thresholds: %v", err)
-				}
-
-				// thresholds weren't met yet. that's ok, since signature gathering is still in progress
-				continue
-			default:
-				return nil, fmt.Errorf(
-					"terminal dispersal failure for blobKey %v. blob status: %v",
-					blobKey.Hex(),
-					newStatus.String())
 			}
 		}
 	}
 }
+
+// processBlobStatus handles a status of a blob received from the disperser, and returns true if the blob has been
+// signed with all required confirmation thresholds met. An error is returned if the blob reached a terminal failure.
+// previousStatus is updated to the received status.
+func (pd *PayloadDisperser) processBlobStatus(
+	ctx context.Context,
+	blobKey core.BlobKey,
+	blobStatusReply *dispgrpc.BlobStatusReply,
+	previousStatus *dispgrpc.BlobStatus,
+	probe *common.SequenceProbe,
+) (bool, error) {
+
+	newStatus := blobStatusReply.Status
+	if newStatus != *previousStatus {
+		pd.logger.Debug(
+			"Blob status changed",
+			"blob key", blobKey.Hex(),
+			"previous status", previousStatus.String(),
+			"new status", newStatus.String())
+		*previousStatus = newStatus
+	}
+
+	// TODO: we'll need to add more in-depth response status processing to derive failover errors
+	switch newStatus {
+	case dispgrpc.BlobStatus_COMPLETE:
+		err := checkThresholds(ctx, pd.certVerifier, blobStatusReply, blobKey.Hex())
+		if err != nil {
+			// returned error is verbose enough, no need to wrap it with additional context
+			return false, err
+		}
+
+		return true, nil
+	case dispgrpc.BlobStatus_QUEUED, dispgrpc.BlobStatus_ENCODED:
+		// Report all non-terminal statuses to the probe. Repeat reports are no-ops.
+		probe.SetStage(newStatus.String())
+		return false, nil
+	case dispgrpc.BlobStatus_GATHERING_SIGNATURES:
+		// Report all non-terminal statuses to the probe. Repeat reports are no-ops.
+		probe.SetStage(newStatus.String())
+
+		err := checkThresholds(ctx, pd.certVerifier, blobStatusReply, blobKey.Hex())
+		if err == nil {
+			// If there's no error, then all thresholds are met, so we can stop waiting
+			return true, nil
+		}
+
+		var thresholdNotMetErr *thresholdNotMetError
+		if !errors.As(err, &thresholdNotMetErr) {
+			// an error occurred which was unrelated to an unmet threshold: something went wrong while checking!
+			pd.logger.Warnf("error checking thresholds: %v", err)
+		}
+
+		// thresholds weren't met yet. that's ok, since signature gathering is still in progress
+		return false, nil
+	default:
+		return false, fmt.Errorf(
+			"terminal dispersal failure for blobKey %v. blob status: %v",
+			blobKey.Hex(),
+			newStatus.String())
+	}
+}
+
+// blobStatusTimeoutError returns the error for a blob that wasn't signed before the context expired
+func (pd *PayloadDisperser) blobStatusTimeoutError(ctx context.Context, finalStatus dispgrpc.BlobStatus) error {
+	return fmt.Errorf(
+		"timed out waiting for %v blob status, final status was %v: %w",
+		dispgrpc.BlobStatus_COMPLETE.String(),
+		finalStatus.String(),
+		ctx.Err())
+}
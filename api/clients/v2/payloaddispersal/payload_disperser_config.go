@@ -15,12 +15,12 @@ type PayloadDisperserConfig struct {
 	// blob
 	DisperseBlobTimeout time.Duration
 
-	// BlobCompleteTimeout is the duration after which the PayloadDisperser will time out, while polling
-	// the disperser for blob status, waiting for BlobStatus_COMPLETE
+	// BlobCompleteTimeout is the duration after which the PayloadDisperser will time out, while waiting for the
+	// disperser to report BlobStatus_COMPLETE
 	BlobCompleteTimeout time.Duration
 
 	// BlobStatusPollInterval is the tick rate for the PayloadDisperser to use, while polling the disperser with
-	// GetBlobStatus.
+	// GetBlobStatus. Polling is only used when the disperser doesn't support blob status subscriptions.
 	BlobStatusPollInterval time.Duration
 
 	// The timeout duration for contract calls
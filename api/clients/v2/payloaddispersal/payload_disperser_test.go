@@ -0,0 +1,231 @@
+package payloaddispersal
+
+import (
+	"context"
+	"io"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/verification"
+	commonv2 "github.com/Layr-Labs/eigenda/api/grpc/common/v2"
+	dispgrpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	commonmock "github.com/Layr-Labs/eigenda/common/mock"
+	"github.com/Layr-Labs/eigenda/common/testutils"
+	core "github.com/Layr-Labs/eigenda/core/v2"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// confirmationThreshold is the confirmation threshold returned by the mocked cert verifier
+const confirmationThreshold = 55
+
+// blobStatusItem is a reply or an error returned by the disperser
+type blobStatusItem struct {
+	reply *dispgrpc.BlobStatusReply
+	err   error
+}
+
+// fakeBlobStatusStream returns its items in order, then blocks until its context is done
+type fakeBlobStatusStream struct {
+	grpc.ClientStream
+	ctx   context.Context
+	items []blobStatusItem
+}
+
+func (s *fakeBlobStatusStream) Recv() (*dispgrpc.BlobStatusReply, error) {
+	if len(s.items) > 0 {
+		item := s.items[0]
+		s.items = s.items[1:]
+		return item.reply, item.err
+	}
+	<-s.ctx.Done()
+	return nil, status.FromContextError(s.ctx.Err()).Err()
+}
+
+// fakeDisperserClient serves blob statuses on subscriptions, and to GetBlobStatus calls once the subscription fails
+type fakeDisperserClient struct {
+	clients.DisperserClient
+
+	subscribeErr  error
+	subscription  []blobStatusItem
+	polledReplies []blobStatusItem
+
+	mu              sync.Mutex
+	numStatusPolled int
+}
+
+func (c *fakeDisperserClient) SubscribeBlobStatus(
+	ctx context.Context,
+	_ core.BlobKey,
+) (dispgrpc.Disperser_SubscribeBlobStatusClient, error) {
+	if c.subscribeErr != nil {
+		return nil, c.subscribeErr
+	}
+	return &fakeBlobStatusStream{ctx: ctx, items: c.subscription}, nil
+}
+
+func (c *fakeDisperserClient) GetBlobStatus(_ context.Context, _ core.BlobKey) (*dispgrpc.BlobStatusReply, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item := c.polledReplies[min(c.numStatusPolled, len(c.polledReplies)-1)]
+	c.numStatusPolled++
+	return item.reply, item.err
+}
+
+func newBlobStatusReply(blobStatus dispgrpc.BlobStatus, signedPercentage uint8) *dispgrpc.BlobStatusReply {
+	return &dispgrpc.BlobStatusReply{
+		Status: blobStatus,
+		SignedBatch: &dispgrpc.SignedBatch{
+			Header: &commonv2.BatchHeader{ReferenceBlockNumber: 100},
+			Attestation: &dispgrpc.Attestation{
+				QuorumNumbers:           []uint32{0},
+				QuorumSignedPercentages: []byte{signedPercentage},
+			},
+		},
+		BlobInclusionInfo: &dispgrpc.BlobInclusionInfo{
+			BlobCertificate: &commonv2.BlobCertificate{
+				BlobHeader: &commonv2.BlobHeader{QuorumNumbers: []uint32{0}},
+			},
+		},
+	}
+}
+
+func newTestPayloadDisperser(t *testing.T, disperserClient clients.DisperserClient) *PayloadDisperser {
+	logger := testutils.GetLogger()
+
+	// The securityThresholds call of the cert verifier returns the ABI encoded (confirmation, adversary) thresholds
+	ethClient := &commonmock.MockEthClient{}
+	thresholds := make([]byte, 64)
+	new(big.Int).SetUint64(confirmationThreshold).FillBytes(thresholds[:32])
+	new(big.Int).SetUint64(33).FillBytes(thresholds[32:])
+	ethClient.On("CallContract").Return(thresholds, nil)
+	certVerifier, err := verification.NewCertVerifier(
+		logger,
+		ethClient,
+		verification.NewStaticCertVerifierAddressProvider(gethcommon.HexToAddress("0x1234")))
+	require.NoError(t, err)
+
+	payloadDisperser, err := NewPayloadDisperser(
+		logger,
+		PayloadDisperserConfig{BlobStatusPollInterval: 10 * time.Millisecond},
+		disperserClient,
+		nil,
+		nil,
+		certVerifier,
+		nil)
+	require.NoError(t, err)
+	return payloadDisperser
+}
+
+func TestWaitForBlobSignedWithSubscription(t *testing.T) {
+	signedReply := newBlobStatusReply(dispgrpc.BlobStatus_GATHERING_SIGNATURES, confirmationThreshold)
+	disperserClient := &fakeDisperserClient{
+		subscription: []blobStatusItem{
+			{reply: &dispgrpc.BlobStatusReply{Status: dispgrpc.BlobStatus_ENCODED}},
+			{reply: newBlobStatusReply(dispgrpc.BlobStatus_GATHERING_SIGNATURES, confirmationThreshold-1)},
+			{reply: signedReply},
+		},
+	}
+	payloadDisperser := newTestPayloadDisperser(t, disperserClient)
+
+	// The blob is signed as soon as the thresholds are met, without waiting for it to be complete
+	reply, err := payloadDisperser.waitForBlobSigned(
+		context.Background(), core.BlobKey{1}, dispgrpc.BlobStatus_QUEUED, nil)
+	require.NoError(t, err)
+	require.Equal(t, signedReply, reply)
+	require.Zero(t, disperserClient.numStatusPolled)
+}
+
+func TestWaitForBlobSignedFallsBackToPolling(t *testing.T) {
+	completeReply := newBlobStatusReply(dispgrpc.BlobStatus_COMPLETE, 100)
+	polledReplies := []blobStatusItem{
+		{err: status.Error(codes.Unavailable, "unavailable")},
+		{reply: &dispgrpc.BlobStatusReply{Status: dispgrpc.BlobStatus_ENCODED}},
+		{reply: completeReply},
+	}
+
+	tests := []struct {
+		name            string
+		disperserClient *fakeDisperserClient
+	}{
+		{
+			name: "subscriptions not supported",
+			disperserClient: &fakeDisperserClient{
+				subscription:  []blobStatusItem{{err: status.Error(codes.Unimplemented, "unimplemented")}},
+				polledReplies: polledReplies,
+			},
+		},
+		{
+			name: "subscription fails to open",
+			disperserClient: &fakeDisperserClient{
+				subscribeErr:  status.Error(codes.ResourceExhausted, "too many blob status subscriptions"),
+				polledReplies: polledReplies,
+			},
+		},
+		{
+			name: "stream ends before the blob is signed",
+			disperserClient: &fakeDisperserClient{
+				subscription: []blobStatusItem{
+					{reply: &dispgrpc.BlobStatusReply{Status: dispgrpc.BlobStatus_ENCODED}},
+					{err: io.EOF},
+				},
+				polledReplies: polledReplies,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payloadDisperser := newTestPayloadDisperser(t, tt.disperserClient)
+
+			// Polling errors are retried until the blob is signed
+			reply, err := payloadDisperser.waitForBlobSigned(
+				context.Background(), core.BlobKey{1}, dispgrpc.BlobStatus_QUEUED, nil)
+			require.NoError(t, err)
+			require.Equal(t, completeReply, reply)
+			require.Equal(t, len(polledReplies), tt.disperserClient.numStatusPolled)
+		})
+	}
+}
+
+func TestWaitForBlobSignedFailure(t *testing.T) {
+	disperserClient := &fakeDisperserClient{
+		subscription: []blobStatusItem{
+			{reply: &dispgrpc.BlobStatusReply{Status: dispgrpc.BlobStatus_ENCODED}},
+			{reply: &dispgrpc.BlobStatusReply{Status: dispgrpc.BlobStatus_FAILED}},
+		},
+	}
+	payloadDisperser := newTestPayloadDisperser(t, disperserClient)
+
+	// A terminal failure received on the subscription is returned without falling back to polling
+	reply, err := payloadDisperser.waitForBlobSigned(
+		context.Background(), core.BlobKey{1}, dispgrpc.BlobStatus_QUEUED, nil)
+	require.ErrorContains(t, err, "terminal dispersal failure")
+	require.Nil(t, reply)
+	require.Zero(t, disperserClient.numStatusPolled)
+}
+
+func TestWaitForBlobSignedTimeout(t *testing.T) {
+	disperserClient := &fakeDisperserClient{
+		subscription: []blobStatusItem{
+			{reply: newBlobStatusReply(dispgrpc.BlobStatus_GATHERING_SIGNATURES, confirmationThreshold-1)},
+		},
+	}
+	payloadDisperser := newTestPayloadDisperser(t, disperserClient)
+
+	// The wait times out with the last status received on the subscription, without falling back to polling
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	reply, err := payloadDisperser.waitForBlobSigned(ctx, core.BlobKey{1}, dispgrpc.BlobStatus_QUEUED, nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.ErrorContains(t, err, dispgrpc.BlobStatus_GATHERING_SIGNATURES.String())
+	require.Nil(t, reply)
+	require.Zero(t, disperserClient.numStatusPolled)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"sync"
 	"time"
 
@@ -25,6 +26,9 @@ type RelayClientConfig struct {
 	MaxGRPCMessageSize uint
 	OperatorID         *core.OperatorID
 	MessageSigner      MessageSigner
+	// The format to request bundles of chunks in. Relays that don't support the format reply in the GNARK format,
+	// so bundles must be deserialized according to their header (e.g. with core.Bundle.Deserialize()).
+	BundleEncodingFormat relaygrpc.BundleEncodingFormat
 }
 
 type ChunkRequestByRange struct {
@@ -38,6 +42,12 @@ type ChunkRequestByIndex struct {
 	Indices []uint32
 }
 
+// ChunkBundleHandler is called once for each bundle received by a streaming chunk request. The requestIndex is the
+// position of the corresponding chunk request in the slice passed to the streaming method, and bundle is a sequence
+// of frames in raw form (i.e., serialized core.Bundle bytearray). If the handler returns an error, the stream is
+// aborted and the error is returned to the caller.
+type ChunkBundleHandler func(requestIndex int, bundle []byte) error
+
 type RelayClient interface {
 	// GetBlob retrieves a blob from a relay
 	GetBlob(ctx context.Context, relayKey corev2.RelayKey, blobKey corev2.BlobKey) ([]byte, error)
@@ -49,6 +59,30 @@ type RelayClient interface {
 	// The returned slice has the same length and ordering as the input slice, and the i-th element is the bundle for the i-th request.
 	// Each bundle is a sequence of frames in raw form (i.e., serialized core.Bundle bytearray).
 	GetChunksByIndex(ctx context.Context, relayKey corev2.RelayKey, requests []*ChunkRequestByIndex) ([][]byte, error)
+	// StreamChunksByRange retrieves blob chunks from a relay by chunk index range, like GetChunksByRange. Instead of
+	// returning all bundles at once, the handler is called for each bundle as soon as it is received from the relay.
+	// Bundles may arrive in any order. If no error is returned, the handler has been called exactly once per request.
+	StreamChunksByRange(
+		ctx context.Context,
+		relayKey corev2.RelayKey,
+		requests []*ChunkRequestByRange,
+		handler ChunkBundleHandler) error
+	// StreamChunksByIndex retrieves blob chunks from a relay by index, like GetChunksByIndex. Instead of returning
+	// all bundles at once, the handler is called for each bundle as soon as it is received from the relay.
+	// Bundles may arrive in any order. If no error is returned, the handler has been called exactly once per request.
+	StreamChunksByIndex(
+		ctx context.Context,
+		relayKey corev2.RelayKey,
+		requests []*ChunkRequestByIndex,
+		handler ChunkBundleHandler) error
+	// GetPointProof retrieves a KZG opening of the polynomial of a blob at the given points from a relay. The reply
+	// holds one evaluation per point, in the same order as the input slice, and a single proof for all of them. The
+	// proof is not verified.
+	GetPointProof(
+		ctx context.Context,
+		relayKey corev2.RelayKey,
+		blobKey corev2.BlobKey,
+		points []*relaygrpc.PointToOpen) (*relaygrpc.GetPointProofReply, error)
 	Close() error
 }
 
@@ -117,6 +151,37 @@ func (c *relayClient) GetBlob(ctx context.Context, relayKey corev2.RelayKey, blo
 	return res.GetBlob(), nil
 }
 
+func (c *relayClient) GetPointProof(
+	ctx context.Context,
+	relayKey corev2.RelayKey,
+	blobKey corev2.BlobKey,
+	points []*relaygrpc.PointToOpen) (*relaygrpc.GetPointProofReply, error) {
+
+	if len(points) == 0 {
+		return nil, fmt.Errorf("no points")
+	}
+
+	client, err := c.getClient(ctx, relayKey)
+	if err != nil {
+		return nil, fmt.Errorf("get grpc relay client for key %d: %w", relayKey, err)
+	}
+
+	res, err := client.GetPointProof(ctx, &relaygrpc.GetPointProofRequest{
+		BlobKey: blobKey[:],
+		Points:  points,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.GetEvaluations()) != len(points) {
+		return nil, fmt.Errorf(
+			"relay %d returned %d evaluations for %d points", relayKey, len(res.GetEvaluations()), len(points))
+	}
+
+	return res, nil
+}
+
 // signGetChunksRequest signs the GetChunksRequest with the operator's private key
 // and sets the signature in the request.
 func (c *relayClient) signGetChunksRequest(ctx context.Context, request *relaygrpc.GetChunksRequest) error {
@@ -170,9 +235,10 @@ func (c *relayClient) GetChunksByRange(
 	}
 
 	request := &relaygrpc.GetChunksRequest{
-		ChunkRequests: grpcRequests,
-		OperatorId:    c.config.OperatorID[:],
-		Timestamp:     uint32(time.Now().Unix()),
+		ChunkRequests:        grpcRequests,
+		OperatorId:           c.config.OperatorID[:],
+		Timestamp:            uint32(time.Now().Unix()),
+		BundleEncodingFormat: c.config.BundleEncodingFormat,
 	}
 	err = c.signGetChunksRequest(ctx, request)
 	if err != nil {
@@ -214,9 +280,10 @@ func (c *relayClient) GetChunksByIndex(
 	}
 
 	request := &relaygrpc.GetChunksRequest{
-		ChunkRequests: grpcRequests,
-		OperatorId:    c.config.OperatorID[:],
-		Timestamp:     uint32(time.Now().Unix()),
+		ChunkRequests:        grpcRequests,
+		OperatorId:           c.config.OperatorID[:],
+		Timestamp:            uint32(time.Now().Unix()),
+		BundleEncodingFormat: c.config.BundleEncodingFormat,
 	}
 	err = c.signGetChunksRequest(ctx, request)
 	if err != nil {
@@ -232,6 +299,123 @@ func (c *relayClient) GetChunksByIndex(
 	return res.GetData(), nil
 }
 
+func (c *relayClient) StreamChunksByRange(
+	ctx context.Context,
+	relayKey corev2.RelayKey,
+	requests []*ChunkRequestByRange,
+	handler ChunkBundleHandler) error {
+
+	grpcRequests := make([]*relaygrpc.ChunkRequest, len(requests))
+	for i, req := range requests {
+		grpcRequests[i] = &relaygrpc.ChunkRequest{
+			Request: &relaygrpc.ChunkRequest_ByRange{
+				ByRange: &relaygrpc.ChunkRequestByRange{
+					BlobKey:    req.BlobKey[:],
+					StartIndex: req.Start,
+					EndIndex:   req.End,
+				},
+			},
+		}
+	}
+
+	return c.streamChunks(ctx, relayKey, grpcRequests, handler)
+}
+
+func (c *relayClient) StreamChunksByIndex(
+	ctx context.Context,
+	relayKey corev2.RelayKey,
+	requests []*ChunkRequestByIndex,
+	handler ChunkBundleHandler) error {
+
+	grpcRequests := make([]*relaygrpc.ChunkRequest, len(requests))
+	for i, req := range requests {
+		grpcRequests[i] = &relaygrpc.ChunkRequest{
+			Request: &relaygrpc.ChunkRequest_ByIndex{
+				ByIndex: &relaygrpc.ChunkRequestByIndex{
+					BlobKey:      req.BlobKey[:],
+					ChunkIndices: req.Indices,
+				},
+			},
+		}
+	}
+
+	return c.streamChunks(ctx, relayKey, grpcRequests, handler)
+}
+
+// streamChunks sends a signed StreamChunks request to a relay, and passes each bundle in the reply to the handler.
+func (c *relayClient) streamChunks(
+	ctx context.Context,
+	relayKey corev2.RelayKey,
+	grpcRequests []*relaygrpc.ChunkRequest,
+	handler ChunkBundleHandler) error {
+
+	if len(grpcRequests) == 0 {
+		return fmt.Errorf("no requests")
+	}
+	if c.config.OperatorID == nil {
+		return errors.New("no operator ID provided in config, cannot sign get chunks request")
+	}
+
+	client, err := c.getClient(ctx, relayKey)
+	if err != nil {
+		return fmt.Errorf("get grpc relay client for key %d: %w", relayKey, err)
+	}
+
+	request := &relaygrpc.GetChunksRequest{
+		ChunkRequests:        grpcRequests,
+		OperatorId:           c.config.OperatorID[:],
+		Timestamp:            uint32(time.Now().Unix()),
+		BundleEncodingFormat: c.config.BundleEncodingFormat,
+	}
+	err = c.signGetChunksRequest(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	// Cancelling the context releases the stream if we stop reading before the relay is done sending.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := client.StreamChunks(ctx, &relaygrpc.StreamChunksRequest{Request: request})
+	if err != nil {
+		return err
+	}
+
+	received := make([]bool, len(grpcRequests))
+	receivedCount := 0
+	for {
+		reply, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		requestIndex := int(reply.GetRequestIndex())
+		if requestIndex >= len(grpcRequests) {
+			return fmt.Errorf("relay %d sent a bundle for request index %d, but only %d requests were made",
+				relayKey, requestIndex, len(grpcRequests))
+		}
+		if received[requestIndex] {
+			return fmt.Errorf("relay %d sent more than one bundle for request index %d", relayKey, requestIndex)
+		}
+		received[requestIndex] = true
+		receivedCount++
+
+		err = handler(requestIndex, reply.GetData())
+		if err != nil {
+			return fmt.Errorf("handle bundle for request index %d: %w", requestIndex, err)
+		}
+	}
+
+	if receivedCount != len(grpcRequests) {
+		return fmt.Errorf("relay %d sent %d bundles, expected %d", relayKey, receivedCount, len(grpcRequests))
+	}
+
+	return nil
+}
+
 // getClient gets the grpc relay client, which has a connection to a given relay
 func (c *relayClient) getClient(ctx context.Context, key corev2.RelayKey) (relaygrpc.RelayClient, error) {
 	if err := c.initOnceGrpcConnection(ctx, key); err != nil {
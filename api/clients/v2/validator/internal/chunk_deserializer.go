@@ -57,9 +57,20 @@ func (d *chunkDeserializer) DeserializeAndVerify(
 	encodingParams *encoding.EncodingParams,
 ) ([]*encoding.Frame, error) {
 
+	var deserialize func(data []byte) (*encoding.Frame, error)
+	switch getChunksReply.GetChunkEncodingFormat() {
+	case grpcnode.ChunkEncodingFormat_GNARK:
+		deserialize = new(encoding.Frame).DeserializeGnark
+	case grpcnode.ChunkEncodingFormat_GNARK_COMPRESSED:
+		deserialize = new(encoding.Frame).DeserializeGnarkCompressed
+	default:
+		return nil, fmt.Errorf("unsupported chunk encoding format from operator %s: %v",
+			operatorID.Hex(), getChunksReply.GetChunkEncodingFormat())
+	}
+
 	chunks := make([]*encoding.Frame, len(getChunksReply.GetChunks()))
 	for i, data := range getChunksReply.GetChunks() {
-		chunk, err := new(encoding.Frame).DeserializeGnark(data)
+		chunk, err := deserialize(data)
 		if err != nil {
 			return nil, fmt.Errorf("failed to deserialize chunk from operator %s: %w", operatorID.Hex(), err)
 		}
@@ -86,8 +86,10 @@ func (m *validatorGRPCManager) DownloadChunks(
 	}
 
 	client := grpcnode.NewRetrievalClient(conn)
+	// Chunks in the compressed format are smaller, and the reply says which format the chunks are actually in
 	request := &grpcnode.GetChunksRequest{
-		BlobKey: key[:],
+		BlobKey:             key[:],
+		ChunkEncodingFormat: grpcnode.ChunkEncodingFormat_GNARK_COMPRESSED,
 	}
 
 	reply, err := client.GetChunks(ctx, request)
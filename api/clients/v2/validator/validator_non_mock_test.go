@@ -297,7 +297,8 @@ func (m *customValidatorGRPCManager) DownloadChunks(
 	}
 
 	return &grpcnode.GetChunksReply{
-		Chunks: chunks,
+		Chunks:              chunks,
+		ChunkEncodingFormat: grpcnode.ChunkEncodingFormat_GNARK,
 	}, nil
 }
 
@@ -0,0 +1,293 @@
+package verification
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/relay"
+	relaygrpc "github.com/Layr-Labs/eigenda/api/grpc/relay"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/utils/openCommitment"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// PointEvaluation is the evaluation of the polynomial of a blob at a single point. The coefficients of the polynomial
+// are the symbols of the blob, so the evaluation at the evaluation point of a symbol index is the value of that symbol
+// in the evaluation form of the blob, e.g. a symbol of a payload that was IFFTed into the blob.
+type PointEvaluation struct {
+	// Z is the point the polynomial is evaluated at
+	Z fr.Element
+	// Value is the evaluation of the polynomial at Z
+	Value fr.Element
+}
+
+// PointOpening is a KZG multi-point opening of the polynomial of a blob, which proves all of its evaluations at once.
+type PointOpening struct {
+	// Evaluations are the evaluations of the polynomial at distinct points
+	Evaluations []PointEvaluation
+	// Proof is the commitment to the quotient of the polynomial and the vanishing polynomial of the points
+	Proof bn254.G1Affine
+}
+
+// PointAtIndex returns a point to open, which is the evaluation point of the symbol at the given index of the blob in
+// evaluation form.
+func PointAtIndex(index uint32) *relaygrpc.PointToOpen {
+	return &relaygrpc.PointToOpen{
+		Point: &relaygrpc.PointToOpen_Index{Index: index},
+	}
+}
+
+// PointAtZ returns a point to open, which is an arbitrary field element.
+func PointAtZ(z fr.Element) *relaygrpc.PointToOpen {
+	zBytes := z.Bytes()
+	return &relaygrpc.PointToOpen{
+		Point: &relaygrpc.PointToOpen_Z{Z: zBytes[:]},
+	}
+}
+
+// GetPointOpening fetches an opening of a blob at the given points from a relay, and verifies it against the blob
+// commitment. The evaluations are returned in the order of the requested points.
+//
+// Opening k points requires the first k powers of tau in G1 and the first k+1 powers of tau in G2.
+func GetPointOpening(
+	ctx context.Context,
+	relayClient relay.RelayClient,
+	relayKey corev2.RelayKey,
+	blobKey corev2.BlobKey,
+	blobCommitments *encoding.BlobCommitments,
+	g1SRS []bn254.G1Affine,
+	g2SRS []bn254.G2Affine,
+	points []*relaygrpc.PointToOpen,
+) (*PointOpening, error) {
+	if blobCommitments == nil || blobCommitments.Commitment == nil {
+		return nil, fmt.Errorf("blob commitment is nil")
+	}
+
+	reply, err := relayClient.GetPointProof(ctx, relayKey, blobKey, points)
+	if err != nil {
+		return nil, fmt.Errorf("get point proof from relay %d: %w", relayKey, err)
+	}
+	opening, err := decodePointOpening(reply)
+	if err != nil {
+		return nil, fmt.Errorf("decode point proof from relay %d: %w", relayKey, err)
+	}
+	if len(opening.Evaluations) != len(points) {
+		return nil, fmt.Errorf(
+			"relay %d returned %d evaluations, expected %d", relayKey, len(opening.Evaluations), len(points))
+	}
+
+	// the relay must open the blob at the requested points, and not any points of its choosing
+	for i, evaluation := range opening.Evaluations {
+		var expectedZ fr.Element
+		switch point := points[i].GetPoint().(type) {
+		case *relaygrpc.PointToOpen_Index:
+			expectedZ, err = openCommitment.SymbolEvaluationPoint(point.Index, uint32(blobCommitments.Length))
+			if err != nil {
+				return nil, fmt.Errorf("point %d: %w", i, err)
+			}
+		case *relaygrpc.PointToOpen_Z:
+			err = expectedZ.SetBytesCanonical(point.Z)
+			if err != nil {
+				return nil, fmt.Errorf("point %d is not a canonical field element: %w", i, err)
+			}
+		default:
+			return nil, fmt.Errorf("point %d has neither an index nor a field element", i)
+		}
+		if !evaluation.Z.Equal(&expectedZ) {
+			return nil, fmt.Errorf("evaluation %d is at the wrong point", i)
+		}
+	}
+
+	err = VerifyPointOpening(blobCommitments.Commitment, g1SRS, g2SRS, opening)
+	if err != nil {
+		return nil, fmt.Errorf("verify point opening from relay %d: %w", relayKey, err)
+	}
+
+	return opening, nil
+}
+
+// decodePointOpening decodes an opening returned by the relay. Field elements must be canonical, and the proof must be
+// a compressed G1 point on the curve and in the correct subgroup.
+func decodePointOpening(reply *relaygrpc.GetPointProofReply) (*PointOpening, error) {
+	opening := &PointOpening{
+		Evaluations: make([]PointEvaluation, len(reply.GetEvaluations())),
+	}
+	for i, evaluation := range reply.GetEvaluations() {
+		if len(evaluation.GetZ()) != fr.Bytes {
+			return nil, fmt.Errorf("point %d must be %d bytes, got %d", i, fr.Bytes, len(evaluation.GetZ()))
+		}
+		err := opening.Evaluations[i].Z.SetBytesCanonical(evaluation.GetZ())
+		if err != nil {
+			return nil, fmt.Errorf("point %d is not a canonical field element: %w", i, err)
+		}
+		if len(evaluation.GetValue()) != fr.Bytes {
+			return nil, fmt.Errorf("value %d must be %d bytes, got %d", i, fr.Bytes, len(evaluation.GetValue()))
+		}
+		err = opening.Evaluations[i].Value.SetBytesCanonical(evaluation.GetValue())
+		if err != nil {
+			return nil, fmt.Errorf("value %d is not a canonical field element: %w", i, err)
+		}
+	}
+	_, err := opening.Proof.SetBytes(reply.GetProof())
+	if err != nil {
+		return nil, fmt.Errorf("invalid proof: %w", err)
+	}
+	return opening, nil
+}
+
+// VerifyPointOpening verifies an opening of a blob against the blob commitment, with a single pairing check for all of
+// the evaluations.
+func VerifyPointOpening(
+	commitment *encoding.G1Commitment,
+	g1SRS []bn254.G1Affine,
+	g2SRS []bn254.G2Affine,
+	opening *PointOpening,
+) error {
+	values := make([]fr.Element, len(opening.Evaluations))
+	zs := make([]fr.Element, len(opening.Evaluations))
+	for i, evaluation := range opening.Evaluations {
+		values[i] = evaluation.Value
+		zs[i] = evaluation.Z
+	}
+
+	return openCommitment.VerifyMultiPointKzgProof(
+		bn254.G1Affine(*commitment), opening.Proof, values, zs, g1SRS, g2SRS)
+}
+
+// g1Point mirrors the BN254.G1Point struct of the EigenDA contracts, for ABI encoding
+type g1Point struct {
+	X *big.Int
+	Y *big.Int
+}
+
+// pointOpeningCalldataArguments returns the arguments of an on-chain verifier of a point opening:
+// (BN254.G1Point commitment, uint256[] zs, uint256[] values, BN254.G1Point proof)
+func pointOpeningCalldataArguments() (abi.Arguments, error) {
+	g1PointType, err := abi.NewType("tuple", "BN254.G1Point", []abi.ArgumentMarshaling{
+		{Name: "X", Type: "uint256"},
+		{Name: "Y", Type: "uint256"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create G1Point type: %w", err)
+	}
+	uint256ArrayType, err := abi.NewType("uint256[]", "", nil)
+	if err != nil {
+		return nil, fmt.Errorf("create uint256[] type: %w", err)
+	}
+	return abi.Arguments{
+		{Name: "commitment", Type: g1PointType},
+		{Name: "zs", Type: uint256ArrayType},
+		{Name: "values", Type: uint256ArrayType},
+		{Name: "proof", Type: g1PointType},
+	}, nil
+}
+
+// PointOpeningCalldata ABI encodes an opening of a blob as the arguments
+// (BN254.G1Point commitment, uint256[] zs, uint256[] values, BN254.G1Point proof) of an on-chain verifier. Points are
+// encoded as uncompressed affine coordinates, as expected by the BN254 precompiles, with the point at infinity
+// encoded as (0, 0). The verifier checks e(C - [I(tau)]G1, G2) == e(proof, [Z(tau)]G2), as VerifyPointOpening does.
+func PointOpeningCalldata(commitment *encoding.G1Commitment, opening *PointOpening) ([]byte, error) {
+	arguments, err := pointOpeningCalldataArguments()
+	if err != nil {
+		return nil, err
+	}
+
+	zs := make([]*big.Int, len(opening.Evaluations))
+	values := make([]*big.Int, len(opening.Evaluations))
+	for i, evaluation := range opening.Evaluations {
+		zs[i] = evaluation.Z.BigInt(new(big.Int))
+		values[i] = evaluation.Value.BigInt(new(big.Int))
+	}
+
+	calldata, err := arguments.Pack(
+		toG1Point((*bn254.G1Affine)(commitment)),
+		zs,
+		values,
+		toG1Point(&opening.Proof),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("pack point opening: %w", err)
+	}
+	return calldata, nil
+}
+
+// DecodePointOpeningCalldata decodes calldata encoded by PointOpeningCalldata into the blob commitment and the
+// opening. Field elements must be canonical, and points must be on the curve and in the correct subgroup.
+func DecodePointOpeningCalldata(calldata []byte) (*encoding.G1Commitment, *PointOpening, error) {
+	arguments, err := pointOpeningCalldataArguments()
+	if err != nil {
+		return nil, nil, err
+	}
+	unpacked, err := arguments.Unpack(calldata)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unpack point opening: %w", err)
+	}
+
+	commitment, err := fromG1Point(*abi.ConvertType(unpacked[0], new(g1Point)).(*g1Point))
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid commitment: %w", err)
+	}
+	proof, err := fromG1Point(*abi.ConvertType(unpacked[3], new(g1Point)).(*g1Point))
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid proof: %w", err)
+	}
+	zs := unpacked[1].([]*big.Int)
+	values := unpacked[2].([]*big.Int)
+	if len(zs) != len(values) {
+		return nil, nil, fmt.Errorf("inconsistent number of points (%d) and values (%d)", len(zs), len(values))
+	}
+
+	opening := &PointOpening{
+		Evaluations: make([]PointEvaluation, len(zs)),
+		Proof:       *proof,
+	}
+	for i := range zs {
+		err = setCanonical(&opening.Evaluations[i].Z, zs[i])
+		if err != nil {
+			return nil, nil, fmt.Errorf("point %d: %w", i, err)
+		}
+		err = setCanonical(&opening.Evaluations[i].Value, values[i])
+		if err != nil {
+			return nil, nil, fmt.Errorf("value %d: %w", i, err)
+		}
+	}
+
+	return (*encoding.G1Commitment)(commitment), opening, nil
+}
+
+// toG1Point converts a G1 point to its ABI representation
+func toG1Point(point *bn254.G1Affine) g1Point {
+	return g1Point{
+		X: point.X.BigInt(new(big.Int)),
+		Y: point.Y.BigInt(new(big.Int)),
+	}
+}
+
+// fromG1Point converts the ABI representation of a G1 point back to a point, which must be on the curve and in the
+// correct subgroup
+func fromG1Point(point g1Point) (*bn254.G1Affine, error) {
+	if point.X.Cmp(fp.Modulus()) >= 0 || point.Y.Cmp(fp.Modulus()) >= 0 {
+		return nil, fmt.Errorf("coordinates are not canonical field elements")
+	}
+	var affine bn254.G1Affine
+	affine.X.SetBigInt(point.X)
+	affine.Y.SetBigInt(point.Y)
+	if !affine.IsInSubGroup() {
+		return nil, fmt.Errorf("point is not on the curve or not in the correct subgroup")
+	}
+	return &affine, nil
+}
+
+// setCanonical sets a field element to the given integer, which must be less than the modulus of the field
+func setCanonical(element *fr.Element, value *big.Int) error {
+	if value.Sign() < 0 || value.Cmp(fr.Modulus()) >= 0 {
+		return fmt.Errorf("%s is not a canonical field element", value)
+	}
+	element.SetBigInt(value)
+	return nil
+}
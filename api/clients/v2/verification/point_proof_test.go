@@ -0,0 +1,177 @@
+package verification
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/clients/codecs"
+	clientsmock "github.com/Layr-Labs/eigenda/api/clients/v2/mock"
+	relaygrpc "github.com/Layr-Labs/eigenda/api/grpc/relay"
+	"github.com/Layr-Labs/eigenda/common/testutils/random"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/rs"
+	"github.com/Layr-Labs/eigenda/encoding/utils/codec"
+	"github.com/Layr-Labs/eigenda/encoding/utils/openCommitment"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// generateTestSRS generates an SRS with a random secret, so that tests don't need to load the SRS files
+func generateTestSRS(t *testing.T, numPoints int) ([]bn254.G1Affine, []bn254.G2Affine) {
+	var tau fr.Element
+	_, err := tau.SetRandom()
+	require.NoError(t, err)
+
+	_, _, g1Gen, g2Gen := bn254.Generators()
+	g1Srs := make([]bn254.G1Affine, numPoints)
+	g2Srs := make([]bn254.G2Affine, numPoints)
+	var power fr.Element
+	var powerBig big.Int
+	power.SetOne()
+	for i := range g1Srs {
+		g1Srs[i].ScalarMultiplication(&g1Gen, power.BigInt(&powerBig))
+		g2Srs[i].ScalarMultiplication(&g2Gen, power.BigInt(&powerBig))
+		power.Mul(&power, &tau)
+	}
+
+	return g1Srs, g2Srs
+}
+
+// relayPointProof computes the reply the relay would return for the given points
+func relayPointProof(
+	t *testing.T,
+	blob []byte,
+	blobLength uint32,
+	g1Srs []bn254.G1Affine,
+	points []*relaygrpc.PointToOpen,
+) *relaygrpc.GetPointProofReply {
+	coeffs, err := rs.ToFrArray(blob)
+	require.NoError(t, err)
+
+	zs := make([]fr.Element, len(points))
+	for i, point := range points {
+		if point.GetZ() != nil {
+			require.NoError(t, zs[i].SetBytesCanonical(point.GetZ()))
+		} else {
+			zs[i], err = openCommitment.SymbolEvaluationPoint(point.GetIndex(), blobLength)
+			require.NoError(t, err)
+		}
+	}
+
+	proof, values, err := openCommitment.ComputeMultiPointKzgProof(coeffs, zs, g1Srs)
+	require.NoError(t, err)
+	proofBytes := proof.Bytes()
+	reply := &relaygrpc.GetPointProofReply{
+		Evaluations: make([]*relaygrpc.PointEvaluation, len(points)),
+		Proof:       proofBytes[:],
+	}
+	for i := range zs {
+		zBytes := zs[i].Bytes()
+		valueBytes := values[i].Bytes()
+		reply.Evaluations[i] = &relaygrpc.PointEvaluation{Z: zBytes[:], Value: valueBytes[:]}
+	}
+	return reply
+}
+
+func TestGetPointOpening(t *testing.T) {
+	testRandom := random.NewTestRandom()
+	ctx := context.Background()
+
+	blobLength := uint32(16)
+	g1Srs, g2Srs := generateTestSRS(t, int(blobLength))
+	// the blob is the IFFT of the data, so that the data is the evaluation form of the blob
+	data := codec.ConvertByPaddingEmptyByte(testRandom.Bytes(int(blobLength) * 31))
+	symbols, err := rs.ToFrArray(data)
+	require.NoError(t, err)
+	blob, err := codecs.IFFT(data)
+	require.NoError(t, err)
+	commitment, err := GenerateBlobCommitment(g1Srs, blob)
+	require.NoError(t, err)
+	blobCommitments := &encoding.BlobCommitments{Commitment: commitment, Length: uint(blobLength)}
+
+	relayKey := corev2.RelayKey(1)
+	blobKey := corev2.BlobKey(testRandom.Bytes(32))
+	var z fr.Element
+	_, err = z.SetRandom()
+	require.NoError(t, err)
+	points := []*relaygrpc.PointToOpen{PointAtIndex(0), PointAtIndex(5), PointAtZ(z)}
+	reply := relayPointProof(t, blob, blobLength, g1Srs, points)
+
+	relayClient := clientsmock.NewRelayClient()
+	relayClient.On("GetPointProof", mock.Anything, relayKey, blobKey, points).Return(reply, nil).Once()
+	opening, err := GetPointOpening(ctx, relayClient, relayKey, blobKey, blobCommitments, g1Srs, g2Srs, points)
+	require.NoError(t, err)
+	require.Len(t, opening.Evaluations, len(points))
+
+	// the evaluation at the evaluation point of a symbol is the value of the symbol in evaluation form
+	require.Equal(t, symbols[0], opening.Evaluations[0].Value)
+	require.Equal(t, symbols[5], opening.Evaluations[1].Value)
+	require.Equal(t, z, opening.Evaluations[2].Z)
+
+	// the calldata for the on-chain verifier round trips, and the decoded opening verifies against the decoded commitment
+	calldata, err := PointOpeningCalldata(commitment, opening)
+	require.NoError(t, err)
+	// the static commitment, 2 offsets and static proof, followed by the length and elements of each of the 2 arrays
+	require.Len(t, calldata, 32*((2+2+2)+(1+3)+(1+3)))
+	decodedCommitment, decodedOpening, err := DecodePointOpeningCalldata(calldata)
+	require.NoError(t, err)
+	require.Equal(t, commitment, decodedCommitment)
+	require.Equal(t, opening, decodedOpening)
+	require.NoError(t, VerifyPointOpening(decodedCommitment, g1Srs, g2Srs, decodedOpening))
+
+	// a tampered value in the calldata fails verification
+	tamperedCalldata := make([]byte, len(calldata))
+	copy(tamperedCalldata, calldata)
+	// the last word of the calldata is the last value
+	tamperedCalldata[len(tamperedCalldata)-1] ^= 1
+	decodedCommitment, decodedOpening, err = DecodePointOpeningCalldata(tamperedCalldata)
+	require.NoError(t, err)
+	require.Error(t, VerifyPointOpening(decodedCommitment, g1Srs, g2Srs, decodedOpening))
+
+	// a point that is not on the curve is rejected
+	tamperedCalldata = make([]byte, len(calldata))
+	copy(tamperedCalldata, calldata)
+	// the second word of the calldata is the Y coordinate of the commitment
+	tamperedCalldata[63] ^= 1
+	_, _, err = DecodePointOpeningCalldata(tamperedCalldata)
+	require.Error(t, err)
+
+	// an opening at the wrong points is rejected, even if it is a valid opening
+	wrongPointReply := relayPointProof(t, blob, blobLength, g1Srs,
+		[]*relaygrpc.PointToOpen{PointAtIndex(0), PointAtIndex(6), PointAtZ(z)})
+	relayClient.On("GetPointProof", mock.Anything, relayKey, blobKey, points).Return(wrongPointReply, nil).Once()
+	_, err = GetPointOpening(ctx, relayClient, relayKey, blobKey, blobCommitments, g1Srs, g2Srs, points)
+	require.Error(t, err)
+
+	// a wrong value is rejected
+	var one fr.Element
+	one.SetOne()
+	var wrongValue fr.Element
+	wrongValue.Add(&symbols[5], &one)
+	wrongValueBytes := wrongValue.Bytes()
+	wrongValueReply := relayPointProof(t, blob, blobLength, g1Srs, points)
+	wrongValueReply.Evaluations[1].Value = wrongValueBytes[:]
+	relayClient.On("GetPointProof", mock.Anything, relayKey, blobKey, points).Return(wrongValueReply, nil).Once()
+	_, err = GetPointOpening(ctx, relayClient, relayKey, blobKey, blobCommitments, g1Srs, g2Srs, points)
+	require.Error(t, err)
+
+	// a missing evaluation is rejected
+	missingEvaluationReply := relayPointProof(t, blob, blobLength, g1Srs, points)
+	missingEvaluationReply.Evaluations = missingEvaluationReply.Evaluations[:2]
+	relayClient.On("GetPointProof", mock.Anything, relayKey, blobKey, points).Return(missingEvaluationReply, nil).Once()
+	_, err = GetPointOpening(ctx, relayClient, relayKey, blobKey, blobCommitments, g1Srs, g2Srs, points)
+	require.Error(t, err)
+
+	// a missing proof is rejected
+	missingProofReply := relayPointProof(t, blob, blobLength, g1Srs, points)
+	missingProofReply.Proof = nil
+	relayClient.On("GetPointProof", mock.Anything, relayKey, blobKey, points).Return(missingProofReply, nil).Once()
+	_, err = GetPointOpening(ctx, relayClient, relayKey, blobKey, blobCommitments, g1Srs, g2Srs, points)
+	require.Error(t, err)
+
+	relayClient.AssertExpectations(t)
+}
@@ -19,10 +19,12 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Disperser_DisperseBlob_FullMethodName      = "/disperser.v2.Disperser/DisperseBlob"
-	Disperser_GetBlobStatus_FullMethodName     = "/disperser.v2.Disperser/GetBlobStatus"
-	Disperser_GetBlobCommitment_FullMethodName = "/disperser.v2.Disperser/GetBlobCommitment"
-	Disperser_GetPaymentState_FullMethodName   = "/disperser.v2.Disperser/GetPaymentState"
+	Disperser_DisperseBlob_FullMethodName        = "/disperser.v2.Disperser/DisperseBlob"
+	Disperser_GetBlobStatus_FullMethodName       = "/disperser.v2.Disperser/GetBlobStatus"
+	Disperser_GetBlobCommitment_FullMethodName   = "/disperser.v2.Disperser/GetBlobCommitment"
+	Disperser_GetPaymentState_FullMethodName     = "/disperser.v2.Disperser/GetPaymentState"
+	Disperser_GetUsageHistory_FullMethodName     = "/disperser.v2.Disperser/GetUsageHistory"
+	Disperser_SubscribeBlobStatus_FullMethodName = "/disperser.v2.Disperser/SubscribeBlobStatus"
 )
 
 // DisperserClient is the client API for Disperser service.
@@ -52,6 +54,15 @@ type DisperserClient interface {
 	// For an example usage, see how our disperser_client makes a call to this endpoint to populate its local accountant struct:
 	// https://github.com/Layr-Labs/eigenda/blob/6059c6a068298d11c41e50f5bcd208d0da44906a/api/clients/v2/disperser_client.go#L298
 	GetPaymentState(ctx context.Context, in *GetPaymentStateRequest, opts ...grpc.CallOption) (*GetPaymentStateReply, error)
+	// GetUsageHistory returns the reservation usage and the charges of the dispersals of an account over a time range,
+	// as accounted by this disperser. Results are paginated by time: if the reply has a non-zero next_start_timestamp,
+	// the rest of the range can be fetched with a request that starts at that timestamp.
+	GetUsageHistory(ctx context.Context, in *GetUsageHistoryRequest, opts ...grpc.CallOption) (*GetUsageHistoryReply, error)
+	// SubscribeBlobStatus streams the status of a blob, as an alternative to polling GetBlobStatus. The current status is
+	// sent as soon as the subscription starts, followed by a new reply each time the status changes or the attestation
+	// of the blob is updated while it is gathering signatures. The stream ends once the blob reaches a terminal status
+	// (COMPLETE or FAILED).
+	SubscribeBlobStatus(ctx context.Context, in *SubscribeBlobStatusRequest, opts ...grpc.CallOption) (Disperser_SubscribeBlobStatusClient, error)
 }
 
 type disperserClient struct {
@@ -98,6 +109,47 @@ func (c *disperserClient) GetPaymentState(ctx context.Context, in *GetPaymentSta
 	return out, nil
 }
 
+func (c *disperserClient) GetUsageHistory(ctx context.Context, in *GetUsageHistoryRequest, opts ...grpc.CallOption) (*GetUsageHistoryReply, error) {
+	out := new(GetUsageHistoryReply)
+	err := c.cc.Invoke(ctx, Disperser_GetUsageHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disperserClient) SubscribeBlobStatus(ctx context.Context, in *SubscribeBlobStatusRequest, opts ...grpc.CallOption) (Disperser_SubscribeBlobStatusClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Disperser_ServiceDesc.Streams[0], Disperser_SubscribeBlobStatus_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &disperserSubscribeBlobStatusClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Disperser_SubscribeBlobStatusClient interface {
+	Recv() (*BlobStatusReply, error)
+	grpc.ClientStream
+}
+
+type disperserSubscribeBlobStatusClient struct {
+	grpc.ClientStream
+}
+
+func (x *disperserSubscribeBlobStatusClient) Recv() (*BlobStatusReply, error) {
+	m := new(BlobStatusReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // DisperserServer is the server API for Disperser service.
 // All implementations must embed UnimplementedDisperserServer
 // for forward compatibility
@@ -125,6 +177,15 @@ type DisperserServer interface {
 	// For an example usage, see how our disperser_client makes a call to this endpoint to populate its local accountant struct:
 	// https://github.com/Layr-Labs/eigenda/blob/6059c6a068298d11c41e50f5bcd208d0da44906a/api/clients/v2/disperser_client.go#L298
 	GetPaymentState(context.Context, *GetPaymentStateRequest) (*GetPaymentStateReply, error)
+	// GetUsageHistory returns the reservation usage and the charges of the dispersals of an account over a time range,
+	// as accounted by this disperser. Results are paginated by time: if the reply has a non-zero next_start_timestamp,
+	// the rest of the range can be fetched with a request that starts at that timestamp.
+	GetUsageHistory(context.Context, *GetUsageHistoryRequest) (*GetUsageHistoryReply, error)
+	// SubscribeBlobStatus streams the status of a blob, as an alternative to polling GetBlobStatus. The current status is
+	// sent as soon as the subscription starts, followed by a new reply each time the status changes or the attestation
+	// of the blob is updated while it is gathering signatures. The stream ends once the blob reaches a terminal status
+	// (COMPLETE or FAILED).
+	SubscribeBlobStatus(*SubscribeBlobStatusRequest, Disperser_SubscribeBlobStatusServer) error
 	mustEmbedUnimplementedDisperserServer()
 }
 
@@ -144,6 +205,12 @@ func (UnimplementedDisperserServer) GetBlobCommitment(context.Context, *BlobComm
 func (UnimplementedDisperserServer) GetPaymentState(context.Context, *GetPaymentStateRequest) (*GetPaymentStateReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPaymentState not implemented")
 }
+func (UnimplementedDisperserServer) GetUsageHistory(context.Context, *GetUsageHistoryRequest) (*GetUsageHistoryReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUsageHistory not implemented")
+}
+func (UnimplementedDisperserServer) SubscribeBlobStatus(*SubscribeBlobStatusRequest, Disperser_SubscribeBlobStatusServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeBlobStatus not implemented")
+}
 func (UnimplementedDisperserServer) mustEmbedUnimplementedDisperserServer() {}
 
 // UnsafeDisperserServer may be embedded to opt out of forward compatibility for this service.
@@ -229,6 +296,45 @@ func _Disperser_GetPaymentState_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Disperser_GetUsageHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUsageHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisperserServer).GetUsageHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Disperser_GetUsageHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisperserServer).GetUsageHistory(ctx, req.(*GetUsageHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Disperser_SubscribeBlobStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeBlobStatusRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DisperserServer).SubscribeBlobStatus(m, &disperserSubscribeBlobStatusServer{stream})
+}
+
+type Disperser_SubscribeBlobStatusServer interface {
+	Send(*BlobStatusReply) error
+	grpc.ServerStream
+}
+
+type disperserSubscribeBlobStatusServer struct {
+	grpc.ServerStream
+}
+
+func (x *disperserSubscribeBlobStatusServer) Send(m *BlobStatusReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // Disperser_ServiceDesc is the grpc.ServiceDesc for Disperser service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -252,7 +358,17 @@ var Disperser_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPaymentState",
 			Handler:    _Disperser_GetPaymentState_Handler,
 		},
+		{
+			MethodName: "GetUsageHistory",
+			Handler:    _Disperser_GetUsageHistory_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeBlobStatus",
+			Handler:       _Disperser_SubscribeBlobStatus_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "disperser/v2/disperser_v2.proto",
 }
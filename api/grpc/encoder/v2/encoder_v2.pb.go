@@ -245,6 +245,102 @@ func (x *EncodeBlobReply) GetFragmentInfo() *FragmentInfo {
 	return nil
 }
 
+// EncodeBlobsRequest contains the references to several blobs to be encoded, each with its own encoding parameters.
+type EncodeBlobsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Blobs []*EncodeBlobRequest `protobuf:"bytes,1,rep,name=blobs,proto3" json:"blobs,omitempty"`
+}
+
+func (x *EncodeBlobsRequest) Reset() {
+	*x = EncodeBlobsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_encoder_v2_encoder_v2_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EncodeBlobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EncodeBlobsRequest) ProtoMessage() {}
+
+func (x *EncodeBlobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encoder_v2_encoder_v2_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EncodeBlobsRequest.ProtoReflect.Descriptor instead.
+func (*EncodeBlobsRequest) Descriptor() ([]byte, []int) {
+	return file_encoder_v2_encoder_v2_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *EncodeBlobsRequest) GetBlobs() []*EncodeBlobRequest {
+	if x != nil {
+		return x.Blobs
+	}
+	return nil
+}
+
+// EncodeBlobsReply contains metadata about the encoded chunks of each blob, in the same order as the request
+type EncodeBlobsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Replies []*EncodeBlobReply `protobuf:"bytes,1,rep,name=replies,proto3" json:"replies,omitempty"`
+}
+
+func (x *EncodeBlobsReply) Reset() {
+	*x = EncodeBlobsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_encoder_v2_encoder_v2_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EncodeBlobsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EncodeBlobsReply) ProtoMessage() {}
+
+func (x *EncodeBlobsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_encoder_v2_encoder_v2_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EncodeBlobsReply.ProtoReflect.Descriptor instead.
+func (*EncodeBlobsReply) Descriptor() ([]byte, []int) {
+	return file_encoder_v2_encoder_v2_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *EncodeBlobsReply) GetReplies() []*EncodeBlobReply {
+	if x != nil {
+		return x.Replies
+	}
+	return nil
+}
+
 var File_encoder_v2_encoder_v2_proto protoreflect.FileDescriptor
 
 var file_encoder_v2_encoder_v2_proto_rawDesc = []byte{
@@ -277,16 +373,30 @@ var file_encoder_v2_encoder_v2_proto_rawDesc = []byte{
 	0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
 	0x18, 0x2e, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x32, 0x2e, 0x46, 0x72, 0x61,
 	0x67, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0c, 0x66, 0x72, 0x61, 0x67, 0x6d,
-	0x65, 0x6e, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x32, 0x55, 0x0a, 0x07, 0x45, 0x6e, 0x63, 0x6f, 0x64,
-	0x65, 0x72, 0x12, 0x4a, 0x0a, 0x0a, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x42, 0x6c, 0x6f, 0x62,
-	0x12, 0x1d, 0x2e, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x32, 0x2e, 0x45, 0x6e,
-	0x63, 0x6f, 0x64, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x1b, 0x2e, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x32, 0x2e, 0x45, 0x6e, 0x63,
-	0x6f, 0x64, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x42, 0x32,
-	0x5a, 0x30, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x4c, 0x61, 0x79,
-	0x72, 0x2d, 0x4c, 0x61, 0x62, 0x73, 0x2f, 0x65, 0x69, 0x67, 0x65, 0x6e, 0x64, 0x61, 0x2f, 0x61,
-	0x70, 0x69, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2f,
-	0x76, 0x32, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x6e, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x49, 0x0a, 0x12, 0x45, 0x6e, 0x63, 0x6f, 0x64,
+	0x65, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x33, 0x0a,
+	0x05, 0x62, 0x6c, 0x6f, 0x62, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x65,
+	0x6e, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x32, 0x2e, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65,
+	0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x05, 0x62, 0x6c, 0x6f,
+	0x62, 0x73, 0x22, 0x49, 0x0a, 0x10, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x42, 0x6c, 0x6f, 0x62,
+	0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x35, 0x0a, 0x07, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65,
+	0x72, 0x2e, 0x76, 0x32, 0x2e, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x52, 0x07, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x65, 0x73, 0x32, 0xa4, 0x01,
+	0x0a, 0x07, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x12, 0x4a, 0x0a, 0x0a, 0x45, 0x6e, 0x63,
+	0x6f, 0x64, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x12, 0x1d, 0x2e, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65,
+	0x72, 0x2e, 0x76, 0x32, 0x2e, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x72,
+	0x2e, 0x76, 0x32, 0x2e, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x4d, 0x0a, 0x0b, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x42,
+	0x6c, 0x6f, 0x62, 0x73, 0x12, 0x1e, 0x2e, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x76,
+	0x32, 0x2e, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x2e, 0x76,
+	0x32, 0x2e, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x22, 0x00, 0x42, 0x32, 0x5a, 0x30, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x4c, 0x61, 0x79, 0x72, 0x2d, 0x4c, 0x61, 0x62, 0x73, 0x2f, 0x65, 0x69, 0x67,
+	0x65, 0x6e, 0x64, 0x61, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x65, 0x6e,
+	0x63, 0x6f, 0x64, 0x65, 0x72, 0x2f, 0x76, 0x32, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -301,23 +411,29 @@ func file_encoder_v2_encoder_v2_proto_rawDescGZIP() []byte {
 	return file_encoder_v2_encoder_v2_proto_rawDescData
 }
 
-var file_encoder_v2_encoder_v2_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_encoder_v2_encoder_v2_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_encoder_v2_encoder_v2_proto_goTypes = []interface{}{
-	(*EncodeBlobRequest)(nil), // 0: encoder.v2.EncodeBlobRequest
-	(*EncodingParams)(nil),    // 1: encoder.v2.EncodingParams
-	(*FragmentInfo)(nil),      // 2: encoder.v2.FragmentInfo
-	(*EncodeBlobReply)(nil),   // 3: encoder.v2.EncodeBlobReply
+	(*EncodeBlobRequest)(nil),  // 0: encoder.v2.EncodeBlobRequest
+	(*EncodingParams)(nil),     // 1: encoder.v2.EncodingParams
+	(*FragmentInfo)(nil),       // 2: encoder.v2.FragmentInfo
+	(*EncodeBlobReply)(nil),    // 3: encoder.v2.EncodeBlobReply
+	(*EncodeBlobsRequest)(nil), // 4: encoder.v2.EncodeBlobsRequest
+	(*EncodeBlobsReply)(nil),   // 5: encoder.v2.EncodeBlobsReply
 }
 var file_encoder_v2_encoder_v2_proto_depIdxs = []int32{
 	1, // 0: encoder.v2.EncodeBlobRequest.encoding_params:type_name -> encoder.v2.EncodingParams
 	2, // 1: encoder.v2.EncodeBlobReply.fragment_info:type_name -> encoder.v2.FragmentInfo
-	0, // 2: encoder.v2.Encoder.EncodeBlob:input_type -> encoder.v2.EncodeBlobRequest
-	3, // 3: encoder.v2.Encoder.EncodeBlob:output_type -> encoder.v2.EncodeBlobReply
-	3, // [3:4] is the sub-list for method output_type
-	2, // [2:3] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	0, // 2: encoder.v2.EncodeBlobsRequest.blobs:type_name -> encoder.v2.EncodeBlobRequest
+	3, // 3: encoder.v2.EncodeBlobsReply.replies:type_name -> encoder.v2.EncodeBlobReply
+	0, // 4: encoder.v2.Encoder.EncodeBlob:input_type -> encoder.v2.EncodeBlobRequest
+	4, // 5: encoder.v2.Encoder.EncodeBlobs:input_type -> encoder.v2.EncodeBlobsRequest
+	3, // 6: encoder.v2.Encoder.EncodeBlob:output_type -> encoder.v2.EncodeBlobReply
+	5, // 7: encoder.v2.Encoder.EncodeBlobs:output_type -> encoder.v2.EncodeBlobsReply
+	6, // [6:8] is the sub-list for method output_type
+	4, // [4:6] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_encoder_v2_encoder_v2_proto_init() }
@@ -374,6 +490,30 @@ func file_encoder_v2_encoder_v2_proto_init() {
 				return nil
 			}
 		}
+		file_encoder_v2_encoder_v2_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EncodeBlobsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_encoder_v2_encoder_v2_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EncodeBlobsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -381,7 +521,7 @@ func file_encoder_v2_encoder_v2_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_encoder_v2_encoder_v2_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
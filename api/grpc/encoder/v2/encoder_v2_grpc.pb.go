@@ -19,7 +19,8 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Encoder_EncodeBlob_FullMethodName = "/encoder.v2.Encoder/EncodeBlob"
+	Encoder_EncodeBlob_FullMethodName  = "/encoder.v2.Encoder/EncodeBlob"
+	Encoder_EncodeBlobs_FullMethodName = "/encoder.v2.Encoder/EncodeBlobs"
 )
 
 // EncoderClient is the client API for Encoder service.
@@ -30,6 +31,11 @@ type EncoderClient interface {
 	// The blob is retrieved using the provided blob key and the encoded chunks
 	// are persisted for later retrieval.
 	EncodeBlob(ctx context.Context, in *EncodeBlobRequest, opts ...grpc.CallOption) (*EncodeBlobReply, error)
+	// EncodeBlobs encodes several blobs in a single request. Blobs with the same encoding parameters are encoded
+	// together, which amortizes the encoding setup and keeps the encoder busy when blobs are small. The encoded
+	// chunks of all blobs are persisted before the reply is sent. The request fails as a whole if any blob fails to
+	// be encoded.
+	EncodeBlobs(ctx context.Context, in *EncodeBlobsRequest, opts ...grpc.CallOption) (*EncodeBlobsReply, error)
 }
 
 type encoderClient struct {
@@ -49,6 +55,15 @@ func (c *encoderClient) EncodeBlob(ctx context.Context, in *EncodeBlobRequest, o
 	return out, nil
 }
 
+func (c *encoderClient) EncodeBlobs(ctx context.Context, in *EncodeBlobsRequest, opts ...grpc.CallOption) (*EncodeBlobsReply, error) {
+	out := new(EncodeBlobsReply)
+	err := c.cc.Invoke(ctx, Encoder_EncodeBlobs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // EncoderServer is the server API for Encoder service.
 // All implementations must embed UnimplementedEncoderServer
 // for forward compatibility
@@ -57,6 +72,11 @@ type EncoderServer interface {
 	// The blob is retrieved using the provided blob key and the encoded chunks
 	// are persisted for later retrieval.
 	EncodeBlob(context.Context, *EncodeBlobRequest) (*EncodeBlobReply, error)
+	// EncodeBlobs encodes several blobs in a single request. Blobs with the same encoding parameters are encoded
+	// together, which amortizes the encoding setup and keeps the encoder busy when blobs are small. The encoded
+	// chunks of all blobs are persisted before the reply is sent. The request fails as a whole if any blob fails to
+	// be encoded.
+	EncodeBlobs(context.Context, *EncodeBlobsRequest) (*EncodeBlobsReply, error)
 	mustEmbedUnimplementedEncoderServer()
 }
 
@@ -67,6 +87,9 @@ type UnimplementedEncoderServer struct {
 func (UnimplementedEncoderServer) EncodeBlob(context.Context, *EncodeBlobRequest) (*EncodeBlobReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method EncodeBlob not implemented")
 }
+func (UnimplementedEncoderServer) EncodeBlobs(context.Context, *EncodeBlobsRequest) (*EncodeBlobsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EncodeBlobs not implemented")
+}
 func (UnimplementedEncoderServer) mustEmbedUnimplementedEncoderServer() {}
 
 // UnsafeEncoderServer may be embedded to opt out of forward compatibility for this service.
@@ -98,6 +121,24 @@ func _Encoder_EncodeBlob_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Encoder_EncodeBlobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EncodeBlobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EncoderServer).EncodeBlobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Encoder_EncodeBlobs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EncoderServer).EncodeBlobs(ctx, req.(*EncodeBlobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Encoder_ServiceDesc is the grpc.ServiceDesc for Encoder service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -109,6 +150,10 @@ var Encoder_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "EncodeBlob",
 			Handler:    _Encoder_EncodeBlob_Handler,
 		},
+		{
+			MethodName: "EncodeBlobs",
+			Handler:    _Encoder_EncodeBlobs_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "encoder/v2/encoder_v2.proto",
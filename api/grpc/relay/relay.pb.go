@@ -20,6 +20,63 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// This describes how the chunks of a bundle are encoded. Every bundle starts with an 8 byte little endian header,
+// whose most significant byte is the format (i.e. the enum value below), and whose remaining 7 bytes are the number
+// of coefficients per chunk. All chunks of a bundle have the same size, which is determined by the header.
+type BundleEncodingFormat int32
+
+const (
+	// The relay chooses the format, which is currently always GNARK.
+	BundleEncodingFormat_DEFAULT BundleEncodingFormat = 0
+	// Each chunk is a 32 byte compressed KZG proof, followed by the coefficients as 32 byte big endian field
+	// elements. See validator.ChunkEncodingFormat.GNARK.
+	BundleEncodingFormat_GNARK BundleEncodingFormat = 1
+	// Each chunk is a 32 byte compressed KZG proof, followed by the coefficients packed into 254 bits each.
+	// See validator.ChunkEncodingFormat.GNARK_COMPRESSED.
+	BundleEncodingFormat_GNARK_COMPRESSED BundleEncodingFormat = 2
+)
+
+// Enum value maps for BundleEncodingFormat.
+var (
+	BundleEncodingFormat_name = map[int32]string{
+		0: "DEFAULT",
+		1: "GNARK",
+		2: "GNARK_COMPRESSED",
+	}
+	BundleEncodingFormat_value = map[string]int32{
+		"DEFAULT":          0,
+		"GNARK":            1,
+		"GNARK_COMPRESSED": 2,
+	}
+)
+
+func (x BundleEncodingFormat) Enum() *BundleEncodingFormat {
+	p := new(BundleEncodingFormat)
+	*p = x
+	return p
+}
+
+func (x BundleEncodingFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BundleEncodingFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_relay_relay_proto_enumTypes[0].Descriptor()
+}
+
+func (BundleEncodingFormat) Type() protoreflect.EnumType {
+	return &file_relay_relay_proto_enumTypes[0]
+}
+
+func (x BundleEncodingFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BundleEncodingFormat.Descriptor instead.
+func (BundleEncodingFormat) EnumDescriptor() ([]byte, []int) {
+	return file_relay_relay_proto_rawDescGZIP(), []int{0}
+}
+
 // A request to fetch one or more blobs.
 type GetBlobRequest struct {
 	state         protoimpl.MessageState
@@ -142,23 +199,30 @@ type GetChunksRequest struct {
 	// All integers are encoded as unsigned 4 byte big endian values.
 	//
 	// Perform a keccak256 hash on the following data in the following order:
-	//  1. the length of the operator ID in bytes
-	//  2. the operator id
-	//  3. the number of chunk requests
-	//  4. for each chunk request:
-	//     a. if the chunk request is a request by index:
-	//     i.   a one byte ASCII representation of the character "i" (aka Ox69)
-	//     ii.  the length blob key in bytes
-	//     iii. the blob key
-	//     iv.  the start index
-	//     v.   the end index
-	//     b. if the chunk request is a request by range:
-	//     i.   a one byte ASCII representation of the character "r" (aka Ox72)
-	//     ii.  the length of the blob key in bytes
-	//     iii. the blob key
-	//     iv.  each requested chunk index, in order
-	//  5. the timestamp (seconds since the Unix epoch encoded as a 4 byte big endian value)
+	// 1. the length of the operator ID in bytes
+	// 2. the operator id
+	// 3. the number of chunk requests
+	// 4. for each chunk request:
+	//    a. if the chunk request is a request by index:
+	//       i.   a one byte ASCII representation of the character "i" (aka Ox69)
+	//       ii.  the length blob key in bytes
+	//       iii. the blob key
+	//       iv.  the start index
+	//       v.   the end index
+	//    b. if the chunk request is a request by range:
+	//       i.   a one byte ASCII representation of the character "r" (aka Ox72)
+	//       ii.  the length of the blob key in bytes
+	//       iii. the blob key
+	//       iv.  each requested chunk index, in order
+	// 5. the timestamp (seconds since the Unix epoch encoded as a 4 byte big endian value)
+	//
+	// The bundle_encoding_format is not part of the hash, so that requests which set it can be authenticated by
+	// relays that don't know about it.
 	OperatorSignature []byte `protobuf:"bytes,4,opt,name=operator_signature,json=operatorSignature,proto3" json:"operator_signature,omitempty"`
+	// The format the client would like the bundles in the reply to be encoded in. A relay that does not support
+	// the requested format replies in GNARK. Clients should decode bundles based on the format in the bundle header,
+	// and not assume that the requested format was used.
+	BundleEncodingFormat BundleEncodingFormat `protobuf:"varint,5,opt,name=bundle_encoding_format,json=bundleEncodingFormat,proto3,enum=relay.BundleEncodingFormat" json:"bundle_encoding_format,omitempty"`
 }
 
 func (x *GetChunksRequest) Reset() {
@@ -221,6 +285,13 @@ func (x *GetChunksRequest) GetOperatorSignature() []byte {
 	return nil
 }
 
+func (x *GetChunksRequest) GetBundleEncodingFormat() BundleEncodingFormat {
+	if x != nil {
+		return x.BundleEncodingFormat
+	}
+	return BundleEncodingFormat_DEFAULT
+}
+
 // A request for chunks within a specific blob. Each chunk is requested individually by its index.
 type ChunkRequestByIndex struct {
 	state         protoimpl.MessageState
@@ -481,6 +552,513 @@ func (x *GetChunksReply) GetData() [][]byte {
 	return nil
 }
 
+// A request to stream chunks from blobs stored by this relay.
+type StreamChunksRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The chunks to fetch. This request is authenticated exactly like a GetChunks request, i.e. the operator
+	// signature is computed over this inner request using relay.auth.HashGetChunksRequest().
+	Request *GetChunksRequest `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+}
+
+func (x *StreamChunksRequest) Reset() {
+	*x = StreamChunksRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_relay_relay_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamChunksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamChunksRequest) ProtoMessage() {}
+
+func (x *StreamChunksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_relay_relay_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamChunksRequest.ProtoReflect.Descriptor instead.
+func (*StreamChunksRequest) Descriptor() ([]byte, []int) {
+	return file_relay_relay_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *StreamChunksRequest) GetRequest() *GetChunksRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+// A single message in the reply to a StreamChunks request. The relay sends exactly one of these messages for each
+// chunk request, in the order in which the chunks become available (which is not necessarily the order in which
+// they were requested).
+type StreamChunksReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The index of the chunk request (within StreamChunksRequest.request.chunk_requests) that this bundle fulfills.
+	RequestIndex uint32 `protobuf:"varint,1,opt,name=request_index,json=requestIndex,proto3" json:"request_index,omitempty"`
+	// The raw data of the bundle (i.e. serialized byte array of the frames), in the same format as the entries of
+	// GetChunksReply.data.
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *StreamChunksReply) Reset() {
+	*x = StreamChunksReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_relay_relay_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamChunksReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamChunksReply) ProtoMessage() {}
+
+func (x *StreamChunksReply) ProtoReflect() protoreflect.Message {
+	mi := &file_relay_relay_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamChunksReply.ProtoReflect.Descriptor instead.
+func (*StreamChunksReply) Descriptor() ([]byte, []int) {
+	return file_relay_relay_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *StreamChunksReply) GetRequestIndex() uint32 {
+	if x != nil {
+		return x.RequestIndex
+	}
+	return 0
+}
+
+func (x *StreamChunksReply) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// A request for the status of a relay.
+type GetRelayStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetRelayStatusRequest) Reset() {
+	*x = GetRelayStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_relay_relay_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRelayStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRelayStatusRequest) ProtoMessage() {}
+
+func (x *GetRelayStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_relay_relay_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRelayStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetRelayStatusRequest) Descriptor() ([]byte, []int) {
+	return file_relay_relay_proto_rawDescGZIP(), []int{9}
+}
+
+// The reply to a GetRelayStatus request.
+type GetRelayStatusReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The number of GetBlob operations in flight, as a fraction of the maximum number of concurrent GetBlob operations.
+	BlobOperationUtilization float64 `protobuf:"fixed64,1,opt,name=blob_operation_utilization,json=blobOperationUtilization,proto3" json:"blob_operation_utilization,omitempty"`
+	// The number of GetChunks operations in flight, as a fraction of the maximum number of concurrent GetChunks
+	// operations.
+	ChunkOperationUtilization float64 `protobuf:"fixed64,2,opt,name=chunk_operation_utilization,json=chunkOperationUtilization,proto3" json:"chunk_operation_utilization,omitempty"`
+	// The number of blob lookups served by the blob cache since the relay started.
+	BlobCacheHits uint64 `protobuf:"varint,3,opt,name=blob_cache_hits,json=blobCacheHits,proto3" json:"blob_cache_hits,omitempty"`
+	// The number of blob lookups that had to fetch the blob from storage since the relay started.
+	BlobCacheMisses uint64 `protobuf:"varint,4,opt,name=blob_cache_misses,json=blobCacheMisses,proto3" json:"blob_cache_misses,omitempty"`
+	// The number of chunk lookups served by the chunk cache since the relay started.
+	ChunkCacheHits uint64 `protobuf:"varint,5,opt,name=chunk_cache_hits,json=chunkCacheHits,proto3" json:"chunk_cache_hits,omitempty"`
+	// The number of chunk lookups that had to fetch the chunks from storage since the relay started.
+	ChunkCacheMisses uint64 `protobuf:"varint,6,opt,name=chunk_cache_misses,json=chunkCacheMisses,proto3" json:"chunk_cache_misses,omitempty"`
+}
+
+func (x *GetRelayStatusReply) Reset() {
+	*x = GetRelayStatusReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_relay_relay_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRelayStatusReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRelayStatusReply) ProtoMessage() {}
+
+func (x *GetRelayStatusReply) ProtoReflect() protoreflect.Message {
+	mi := &file_relay_relay_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRelayStatusReply.ProtoReflect.Descriptor instead.
+func (*GetRelayStatusReply) Descriptor() ([]byte, []int) {
+	return file_relay_relay_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetRelayStatusReply) GetBlobOperationUtilization() float64 {
+	if x != nil {
+		return x.BlobOperationUtilization
+	}
+	return 0
+}
+
+func (x *GetRelayStatusReply) GetChunkOperationUtilization() float64 {
+	if x != nil {
+		return x.ChunkOperationUtilization
+	}
+	return 0
+}
+
+func (x *GetRelayStatusReply) GetBlobCacheHits() uint64 {
+	if x != nil {
+		return x.BlobCacheHits
+	}
+	return 0
+}
+
+func (x *GetRelayStatusReply) GetBlobCacheMisses() uint64 {
+	if x != nil {
+		return x.BlobCacheMisses
+	}
+	return 0
+}
+
+func (x *GetRelayStatusReply) GetChunkCacheHits() uint64 {
+	if x != nil {
+		return x.ChunkCacheHits
+	}
+	return 0
+}
+
+func (x *GetRelayStatusReply) GetChunkCacheMisses() uint64 {
+	if x != nil {
+		return x.ChunkCacheMisses
+	}
+	return 0
+}
+
+// A request for a KZG opening proof of the polynomial of a blob.
+type GetPointProofRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The key of the blob to open.
+	BlobKey []byte `protobuf:"bytes,1,opt,name=blob_key,json=blobKey,proto3" json:"blob_key,omitempty"`
+	// The points at which the blob polynomial is opened. The points must be distinct. Evaluations are returned in the
+	// same order as the points.
+	Points []*PointToOpen `protobuf:"bytes,2,rep,name=points,proto3" json:"points,omitempty"`
+}
+
+func (x *GetPointProofRequest) Reset() {
+	*x = GetPointProofRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_relay_relay_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPointProofRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPointProofRequest) ProtoMessage() {}
+
+func (x *GetPointProofRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_relay_relay_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPointProofRequest.ProtoReflect.Descriptor instead.
+func (*GetPointProofRequest) Descriptor() ([]byte, []int) {
+	return file_relay_relay_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetPointProofRequest) GetBlobKey() []byte {
+	if x != nil {
+		return x.BlobKey
+	}
+	return nil
+}
+
+func (x *GetPointProofRequest) GetPoints() []*PointToOpen {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+// A point at which the polynomial of a blob is opened.
+type PointToOpen struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Point:
+	//	*PointToOpen_Index
+	//	*PointToOpen_Z
+	Point isPointToOpen_Point `protobuf_oneof:"point"`
+}
+
+func (x *PointToOpen) Reset() {
+	*x = PointToOpen{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_relay_relay_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PointToOpen) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PointToOpen) ProtoMessage() {}
+
+func (x *PointToOpen) ProtoReflect() protoreflect.Message {
+	mi := &file_relay_relay_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PointToOpen.ProtoReflect.Descriptor instead.
+func (*PointToOpen) Descriptor() ([]byte, []int) {
+	return file_relay_relay_proto_rawDescGZIP(), []int{12}
+}
+
+func (m *PointToOpen) GetPoint() isPointToOpen_Point {
+	if m != nil {
+		return m.Point
+	}
+	return nil
+}
+
+func (x *PointToOpen) GetIndex() uint32 {
+	if x, ok := x.GetPoint().(*PointToOpen_Index); ok {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *PointToOpen) GetZ() []byte {
+	if x, ok := x.GetPoint().(*PointToOpen_Z); ok {
+		return x.Z
+	}
+	return nil
+}
+
+type isPointToOpen_Point interface {
+	isPointToOpen_Point()
+}
+
+type PointToOpen_Index struct {
+	// The index of a symbol of the blob. The polynomial is opened at the index-th power of the primitive root of
+	// unity of order blob_length (in symbols), i.e. at the evaluation point of the index-th symbol of the blob in
+	// evaluation form.
+	Index uint32 `protobuf:"varint,1,opt,name=index,proto3,oneof"`
+}
+
+type PointToOpen_Z struct {
+	// A field element, as 32 big-endian bytes.
+	Z []byte `protobuf:"bytes,2,opt,name=z,proto3,oneof"`
+}
+
+func (*PointToOpen_Index) isPointToOpen_Point() {}
+
+func (*PointToOpen_Z) isPointToOpen_Point() {}
+
+// The reply to a GetPointProof request.
+type GetPointProofReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The evaluations of the blob polynomial p, in the same order as the requested points.
+	Evaluations []*PointEvaluation `protobuf:"bytes,1,rep,name=evaluations,proto3" json:"evaluations,omitempty"`
+	// The multi-point opening proof for all of the evaluations, as a compressed G1 point. This is the commitment to the
+	// quotient polynomial (p(X) - I(X)) / Z(X), where I is the polynomial of degree less than the number of points that
+	// interpolates the evaluations, and Z is the polynomial that vanishes at each of the points.
+	Proof []byte `protobuf:"bytes,2,opt,name=proof,proto3" json:"proof,omitempty"`
+}
+
+func (x *GetPointProofReply) Reset() {
+	*x = GetPointProofReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_relay_relay_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPointProofReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPointProofReply) ProtoMessage() {}
+
+func (x *GetPointProofReply) ProtoReflect() protoreflect.Message {
+	mi := &file_relay_relay_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPointProofReply.ProtoReflect.Descriptor instead.
+func (*GetPointProofReply) Descriptor() ([]byte, []int) {
+	return file_relay_relay_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetPointProofReply) GetEvaluations() []*PointEvaluation {
+	if x != nil {
+		return x.Evaluations
+	}
+	return nil
+}
+
+func (x *GetPointProofReply) GetProof() []byte {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+// An evaluation of the polynomial p of a blob at a point z.
+type PointEvaluation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The point z, as 32 big-endian bytes. This is set for points requested by index too.
+	Z []byte `protobuf:"bytes,1,opt,name=z,proto3" json:"z,omitempty"`
+	// The value p(z), as 32 big-endian bytes.
+	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *PointEvaluation) Reset() {
+	*x = PointEvaluation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_relay_relay_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PointEvaluation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PointEvaluation) ProtoMessage() {}
+
+func (x *PointEvaluation) ProtoReflect() protoreflect.Message {
+	mi := &file_relay_relay_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PointEvaluation.ProtoReflect.Descriptor instead.
+func (*PointEvaluation) Descriptor() ([]byte, []int) {
+	return file_relay_relay_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PointEvaluation) GetZ() []byte {
+	if x != nil {
+		return x.Z
+	}
+	return nil
+}
+
+func (x *PointEvaluation) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
 var File_relay_relay_proto protoreflect.FileDescriptor
 
 var file_relay_relay_proto_rawDesc = []byte{
@@ -490,7 +1068,7 @@ var file_relay_relay_proto_rawDesc = []byte{
 	0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07,
 	0x62, 0x6c, 0x6f, 0x62, 0x4b, 0x65, 0x79, 0x22, 0x22, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x42, 0x6c,
 	0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x6c, 0x6f, 0x62, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x62, 0x6c, 0x6f, 0x62, 0x22, 0xbc, 0x01, 0x0a, 0x10,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x62, 0x6c, 0x6f, 0x62, 0x22, 0x8f, 0x02, 0x0a, 0x10,
 	0x47, 0x65, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
 	0x12, 0x3a, 0x0a, 0x0e, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
 	0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x72, 0x65, 0x6c, 0x61, 0x79,
@@ -502,42 +1080,117 @@ var file_relay_relay_proto_rawDesc = []byte{
 	0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x2d, 0x0a, 0x12, 0x6f,
 	0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
 	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x11, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f,
-	0x72, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x55, 0x0a, 0x13, 0x43, 0x68,
-	0x75, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x79, 0x49, 0x6e, 0x64, 0x65,
-	0x78, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x62, 0x4b, 0x65, 0x79, 0x12, 0x23, 0x0a, 0x0d,
-	0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f, 0x69, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20,
-	0x03, 0x28, 0x0d, 0x52, 0x0c, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x49, 0x6e, 0x64, 0x69, 0x63, 0x65,
-	0x73, 0x22, 0x6e, 0x0a, 0x13, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x42, 0x79, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x6c, 0x6f, 0x62,
-	0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x62,
-	0x4b, 0x65, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x69, 0x6e, 0x64,
-	0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x49,
-	0x6e, 0x64, 0x65, 0x78, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x6e, 0x64, 0x5f, 0x69, 0x6e, 0x64, 0x65,
-	0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x65, 0x6e, 0x64, 0x49, 0x6e, 0x64, 0x65,
-	0x78, 0x22, 0x8b, 0x01, 0x0a, 0x0c, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x37, 0x0a, 0x08, 0x62, 0x79, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x43, 0x68, 0x75,
-	0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x79, 0x49, 0x6e, 0x64, 0x65, 0x78,
-	0x48, 0x00, 0x52, 0x07, 0x62, 0x79, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x37, 0x0a, 0x08, 0x62,
-	0x79, 0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
-	0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x42, 0x79, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x48, 0x00, 0x52, 0x07, 0x62, 0x79, 0x52,
-	0x61, 0x6e, 0x67, 0x65, 0x42, 0x09, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22,
-	0x24, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52,
-	0x04, 0x64, 0x61, 0x74, 0x61, 0x32, 0x7f, 0x0a, 0x05, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x12, 0x37,
-	0x0a, 0x07, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x62, 0x12, 0x15, 0x2e, 0x72, 0x65, 0x6c, 0x61,
-	0x79, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x13, 0x2e, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x62,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x3d, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x43, 0x68,
-	0x75, 0x6e, 0x6b, 0x73, 0x12, 0x17, 0x2e, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x47, 0x65, 0x74,
-	0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e,
+	0x72, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x51, 0x0a, 0x16, 0x62, 0x75,
+	0x6e, 0x64, 0x6c, 0x65, 0x5f, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x66, 0x6f,
+	0x72, 0x6d, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x72, 0x65, 0x6c,
+	0x61, 0x79, 0x2e, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e,
+	0x67, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x14, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x45,
+	0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x22, 0x55, 0x0a,
+	0x13, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x79, 0x49,
+	0x6e, 0x64, 0x65, 0x78, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x62, 0x4b, 0x65, 0x79, 0x12,
+	0x23, 0x0a, 0x0d, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f, 0x69, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x0c, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x49, 0x6e, 0x64,
+	0x69, 0x63, 0x65, 0x73, 0x22, 0x6e, 0x0a, 0x13, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x42, 0x79, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x62,
+	0x6c, 0x6f, 0x62, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x62,
+	0x6c, 0x6f, 0x62, 0x4b, 0x65, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f,
+	0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x6e, 0x64, 0x5f, 0x69,
+	0x6e, 0x64, 0x65, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x65, 0x6e, 0x64, 0x49,
+	0x6e, 0x64, 0x65, 0x78, 0x22, 0x8b, 0x01, 0x0a, 0x0c, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x37, 0x0a, 0x08, 0x62, 0x79, 0x5f, 0x69, 0x6e, 0x64, 0x65,
+	0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e,
+	0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x79, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x48, 0x00, 0x52, 0x07, 0x62, 0x79, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x37,
+	0x0a, 0x08, 0x62, 0x79, 0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x79, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x48, 0x00, 0x52, 0x07,
+	0x62, 0x79, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x42, 0x09, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x22, 0x24, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x48, 0x0a, 0x13, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x31, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x68, 0x75, 0x6e,
+	0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x22, 0x4c, 0x0a, 0x11, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x43, 0x68, 0x75, 0x6e,
+	0x6b, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x12, 0x0a, 0x04,
+	0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x22, 0x17, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xbf, 0x02, 0x0a, 0x13, 0x47, 0x65,
+	0x74, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x12, 0x3c, 0x0a, 0x1a, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x75, 0x74, 0x69, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x18, 0x62, 0x6c, 0x6f, 0x62, 0x4f, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x55, 0x74, 0x69, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x3e, 0x0a, 0x1b, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x75, 0x74, 0x69, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x19, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x4f, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x55, 0x74, 0x69, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x26, 0x0a, 0x0f, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x63, 0x61, 0x63, 0x68, 0x65, 0x5f, 0x68, 0x69,
+	0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x62, 0x6c, 0x6f, 0x62, 0x43, 0x61,
+	0x63, 0x68, 0x65, 0x48, 0x69, 0x74, 0x73, 0x12, 0x2a, 0x0a, 0x11, 0x62, 0x6c, 0x6f, 0x62, 0x5f,
+	0x63, 0x61, 0x63, 0x68, 0x65, 0x5f, 0x6d, 0x69, 0x73, 0x73, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0f, 0x62, 0x6c, 0x6f, 0x62, 0x43, 0x61, 0x63, 0x68, 0x65, 0x4d, 0x69, 0x73,
+	0x73, 0x65, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f, 0x63, 0x61, 0x63,
+	0x68, 0x65, 0x5f, 0x68, 0x69, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x63,
+	0x68, 0x75, 0x6e, 0x6b, 0x43, 0x61, 0x63, 0x68, 0x65, 0x48, 0x69, 0x74, 0x73, 0x12, 0x2c, 0x0a,
+	0x12, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f, 0x63, 0x61, 0x63, 0x68, 0x65, 0x5f, 0x6d, 0x69, 0x73,
+	0x73, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x63, 0x68, 0x75, 0x6e, 0x6b,
+	0x43, 0x61, 0x63, 0x68, 0x65, 0x4d, 0x69, 0x73, 0x73, 0x65, 0x73, 0x22, 0x5d, 0x0a, 0x14, 0x47,
+	0x65, 0x74, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x62, 0x4b, 0x65, 0x79, 0x12, 0x2a,
+	0x0a, 0x06, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12,
+	0x2e, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x54, 0x6f, 0x4f, 0x70,
+	0x65, 0x6e, 0x52, 0x06, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x22, 0x3e, 0x0a, 0x0b, 0x50, 0x6f,
+	0x69, 0x6e, 0x74, 0x54, 0x6f, 0x4f, 0x70, 0x65, 0x6e, 0x12, 0x16, 0x0a, 0x05, 0x69, 0x6e, 0x64,
+	0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65,
+	0x78, 0x12, 0x0e, 0x0a, 0x01, 0x7a, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x01,
+	0x7a, 0x42, 0x07, 0x0a, 0x05, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x22, 0x64, 0x0a, 0x12, 0x47, 0x65,
+	0x74, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x12, 0x38, 0x0a, 0x0b, 0x65, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x50, 0x6f,
+	0x69, 0x6e, 0x74, 0x45, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x65,
+	0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72,
+	0x6f, 0x6f, 0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66,
+	0x22, 0x35, 0x0a, 0x0f, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x45, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x0c, 0x0a, 0x01, 0x7a, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01,
+	0x7a, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x2a, 0x44, 0x0a, 0x14, 0x42, 0x75, 0x6e, 0x64, 0x6c,
+	0x65, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12,
+	0x0b, 0x0a, 0x07, 0x44, 0x45, 0x46, 0x41, 0x55, 0x4c, 0x54, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05,
+	0x47, 0x4e, 0x41, 0x52, 0x4b, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x47, 0x4e, 0x41, 0x52, 0x4b,
+	0x5f, 0x43, 0x4f, 0x4d, 0x50, 0x52, 0x45, 0x53, 0x53, 0x45, 0x44, 0x10, 0x02, 0x32, 0xe2, 0x02,
+	0x0a, 0x05, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x12, 0x37, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x42, 0x6c,
+	0x6f, 0x62, 0x12, 0x15, 0x2e, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x6c,
+	0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x72, 0x65, 0x6c, 0x61,
+	0x79, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
+	0x12, 0x3d, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x12, 0x17, 0x2e,
 	0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x52,
-	0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x42, 0x2d, 0x5a, 0x2b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
-	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x4c, 0x61, 0x79, 0x72, 0x2d, 0x4c, 0x61, 0x62, 0x73, 0x2f, 0x65,
-	0x69, 0x67, 0x65, 0x6e, 0x64, 0x61, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f,
-	0x72, 0x65, 0x6c, 0x61, 0x79, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x47,
+	0x65, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12,
+	0x48, 0x0a, 0x0c, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x12,
+	0x1a, 0x2e, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x43, 0x68,
+	0x75, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x72, 0x65,
+	0x6c, 0x61, 0x79, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x30, 0x01, 0x12, 0x4c, 0x0a, 0x0e, 0x47, 0x65, 0x74,
+	0x52, 0x65, 0x6c, 0x61, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x2e, 0x72, 0x65,
+	0x6c, 0x61, 0x79, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x72, 0x65, 0x6c, 0x61,
+	0x79, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x50, 0x6f,
+	0x69, 0x6e, 0x74, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x1b, 0x2e, 0x72, 0x65, 0x6c, 0x61, 0x79,
+	0x2e, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x47, 0x65,
+	0x74, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x00, 0x42, 0x2d, 0x5a, 0x2b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x4c, 0x61, 0x79, 0x72, 0x2d, 0x4c, 0x61, 0x62, 0x73, 0x2f, 0x65, 0x69, 0x67, 0x65, 0x6e,
+	0x64, 0x61, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x72, 0x65, 0x6c, 0x61,
+	0x79, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -552,29 +1205,49 @@ func file_relay_relay_proto_rawDescGZIP() []byte {
 	return file_relay_relay_proto_rawDescData
 }
 
-var file_relay_relay_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_relay_relay_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_relay_relay_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_relay_relay_proto_goTypes = []interface{}{
-	(*GetBlobRequest)(nil),      // 0: relay.GetBlobRequest
-	(*GetBlobReply)(nil),        // 1: relay.GetBlobReply
-	(*GetChunksRequest)(nil),    // 2: relay.GetChunksRequest
-	(*ChunkRequestByIndex)(nil), // 3: relay.ChunkRequestByIndex
-	(*ChunkRequestByRange)(nil), // 4: relay.ChunkRequestByRange
-	(*ChunkRequest)(nil),        // 5: relay.ChunkRequest
-	(*GetChunksReply)(nil),      // 6: relay.GetChunksReply
+	(BundleEncodingFormat)(0),     // 0: relay.BundleEncodingFormat
+	(*GetBlobRequest)(nil),        // 1: relay.GetBlobRequest
+	(*GetBlobReply)(nil),          // 2: relay.GetBlobReply
+	(*GetChunksRequest)(nil),      // 3: relay.GetChunksRequest
+	(*ChunkRequestByIndex)(nil),   // 4: relay.ChunkRequestByIndex
+	(*ChunkRequestByRange)(nil),   // 5: relay.ChunkRequestByRange
+	(*ChunkRequest)(nil),          // 6: relay.ChunkRequest
+	(*GetChunksReply)(nil),        // 7: relay.GetChunksReply
+	(*StreamChunksRequest)(nil),   // 8: relay.StreamChunksRequest
+	(*StreamChunksReply)(nil),     // 9: relay.StreamChunksReply
+	(*GetRelayStatusRequest)(nil), // 10: relay.GetRelayStatusRequest
+	(*GetRelayStatusReply)(nil),   // 11: relay.GetRelayStatusReply
+	(*GetPointProofRequest)(nil),  // 12: relay.GetPointProofRequest
+	(*PointToOpen)(nil),           // 13: relay.PointToOpen
+	(*GetPointProofReply)(nil),    // 14: relay.GetPointProofReply
+	(*PointEvaluation)(nil),       // 15: relay.PointEvaluation
 }
 var file_relay_relay_proto_depIdxs = []int32{
-	5, // 0: relay.GetChunksRequest.chunk_requests:type_name -> relay.ChunkRequest
-	3, // 1: relay.ChunkRequest.by_index:type_name -> relay.ChunkRequestByIndex
-	4, // 2: relay.ChunkRequest.by_range:type_name -> relay.ChunkRequestByRange
-	0, // 3: relay.Relay.GetBlob:input_type -> relay.GetBlobRequest
-	2, // 4: relay.Relay.GetChunks:input_type -> relay.GetChunksRequest
-	1, // 5: relay.Relay.GetBlob:output_type -> relay.GetBlobReply
-	6, // 6: relay.Relay.GetChunks:output_type -> relay.GetChunksReply
-	5, // [5:7] is the sub-list for method output_type
-	3, // [3:5] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	6,  // 0: relay.GetChunksRequest.chunk_requests:type_name -> relay.ChunkRequest
+	0,  // 1: relay.GetChunksRequest.bundle_encoding_format:type_name -> relay.BundleEncodingFormat
+	4,  // 2: relay.ChunkRequest.by_index:type_name -> relay.ChunkRequestByIndex
+	5,  // 3: relay.ChunkRequest.by_range:type_name -> relay.ChunkRequestByRange
+	3,  // 4: relay.StreamChunksRequest.request:type_name -> relay.GetChunksRequest
+	13, // 5: relay.GetPointProofRequest.points:type_name -> relay.PointToOpen
+	15, // 6: relay.GetPointProofReply.evaluations:type_name -> relay.PointEvaluation
+	1,  // 7: relay.Relay.GetBlob:input_type -> relay.GetBlobRequest
+	3,  // 8: relay.Relay.GetChunks:input_type -> relay.GetChunksRequest
+	8,  // 9: relay.Relay.StreamChunks:input_type -> relay.StreamChunksRequest
+	10, // 10: relay.Relay.GetRelayStatus:input_type -> relay.GetRelayStatusRequest
+	12, // 11: relay.Relay.GetPointProof:input_type -> relay.GetPointProofRequest
+	2,  // 12: relay.Relay.GetBlob:output_type -> relay.GetBlobReply
+	7,  // 13: relay.Relay.GetChunks:output_type -> relay.GetChunksReply
+	9,  // 14: relay.Relay.StreamChunks:output_type -> relay.StreamChunksReply
+	11, // 15: relay.Relay.GetRelayStatus:output_type -> relay.GetRelayStatusReply
+	14, // 16: relay.Relay.GetPointProof:output_type -> relay.GetPointProofReply
+	12, // [12:17] is the sub-list for method output_type
+	7,  // [7:12] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_relay_relay_proto_init() }
@@ -667,23 +1340,124 @@ func file_relay_relay_proto_init() {
 				return nil
 			}
 		}
+		file_relay_relay_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamChunksRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_relay_relay_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamChunksReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_relay_relay_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRelayStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_relay_relay_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRelayStatusReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_relay_relay_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPointProofRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_relay_relay_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PointToOpen); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_relay_relay_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPointProofReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_relay_relay_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PointEvaluation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_relay_relay_proto_msgTypes[5].OneofWrappers = []interface{}{
 		(*ChunkRequest_ByIndex)(nil),
 		(*ChunkRequest_ByRange)(nil),
 	}
+	file_relay_relay_proto_msgTypes[12].OneofWrappers = []interface{}{
+		(*PointToOpen_Index)(nil),
+		(*PointToOpen_Z)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_relay_relay_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   7,
+			NumEnums:      1,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_relay_relay_proto_goTypes,
 		DependencyIndexes: file_relay_relay_proto_depIdxs,
+		EnumInfos:         file_relay_relay_proto_enumTypes,
 		MessageInfos:      file_relay_relay_proto_msgTypes,
 	}.Build()
 	File_relay_relay_proto = out.File
@@ -19,8 +19,11 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Relay_GetBlob_FullMethodName   = "/relay.Relay/GetBlob"
-	Relay_GetChunks_FullMethodName = "/relay.Relay/GetChunks"
+	Relay_GetBlob_FullMethodName        = "/relay.Relay/GetBlob"
+	Relay_GetChunks_FullMethodName      = "/relay.Relay/GetChunks"
+	Relay_StreamChunks_FullMethodName   = "/relay.Relay/StreamChunks"
+	Relay_GetRelayStatus_FullMethodName = "/relay.Relay/GetRelayStatus"
+	Relay_GetPointProof_FullMethodName  = "/relay.Relay/GetPointProof"
 )
 
 // RelayClient is the client API for Relay service.
@@ -31,6 +34,19 @@ type RelayClient interface {
 	GetBlob(ctx context.Context, in *GetBlobRequest, opts ...grpc.CallOption) (*GetBlobReply, error)
 	// GetChunks retrieves chunks from blobs stored by the relay.
 	GetChunks(ctx context.Context, in *GetChunksRequest, opts ...grpc.CallOption) (*GetChunksReply, error)
+	// StreamChunks retrieves chunks from blobs stored by the relay. Unlike GetChunks, the reply is streamed back one
+	// bundle at a time, as soon as the chunks for each blob become available. This avoids building a single large
+	// message for the entire request, and allows the caller to start processing bundles before all have arrived.
+	// Authentication and rate limiting are identical to GetChunks.
+	StreamChunks(ctx context.Context, in *StreamChunksRequest, opts ...grpc.CallOption) (Relay_StreamChunksClient, error)
+	// GetRelayStatus returns the current load of the relay. It is used by the disperser to decide which relays new
+	// blobs are assigned to.
+	GetRelayStatus(ctx context.Context, in *GetRelayStatusRequest, opts ...grpc.CallOption) (*GetRelayStatusReply, error)
+	// GetPointProof computes a KZG multi-point opening proof of the polynomial of a blob stored by the relay at one or
+	// more points. The proof can be verified against the blob commitment with a single pairing check, e.g. by a rollup in
+	// a fraud or validity proof. A single proof is computed for all points of a request, at a cost that grows with the
+	// size of the blob, so each request is charged against the relay's GetBlob rate limits like a GetBlob request.
+	GetPointProof(ctx context.Context, in *GetPointProofRequest, opts ...grpc.CallOption) (*GetPointProofReply, error)
 }
 
 type relayClient struct {
@@ -59,6 +75,56 @@ func (c *relayClient) GetChunks(ctx context.Context, in *GetChunksRequest, opts
 	return out, nil
 }
 
+func (c *relayClient) StreamChunks(ctx context.Context, in *StreamChunksRequest, opts ...grpc.CallOption) (Relay_StreamChunksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Relay_ServiceDesc.Streams[0], Relay_StreamChunks_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &relayStreamChunksClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Relay_StreamChunksClient interface {
+	Recv() (*StreamChunksReply, error)
+	grpc.ClientStream
+}
+
+type relayStreamChunksClient struct {
+	grpc.ClientStream
+}
+
+func (x *relayStreamChunksClient) Recv() (*StreamChunksReply, error) {
+	m := new(StreamChunksReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *relayClient) GetRelayStatus(ctx context.Context, in *GetRelayStatusRequest, opts ...grpc.CallOption) (*GetRelayStatusReply, error) {
+	out := new(GetRelayStatusReply)
+	err := c.cc.Invoke(ctx, Relay_GetRelayStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *relayClient) GetPointProof(ctx context.Context, in *GetPointProofRequest, opts ...grpc.CallOption) (*GetPointProofReply, error) {
+	out := new(GetPointProofReply)
+	err := c.cc.Invoke(ctx, Relay_GetPointProof_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RelayServer is the server API for Relay service.
 // All implementations must embed UnimplementedRelayServer
 // for forward compatibility
@@ -67,6 +133,19 @@ type RelayServer interface {
 	GetBlob(context.Context, *GetBlobRequest) (*GetBlobReply, error)
 	// GetChunks retrieves chunks from blobs stored by the relay.
 	GetChunks(context.Context, *GetChunksRequest) (*GetChunksReply, error)
+	// StreamChunks retrieves chunks from blobs stored by the relay. Unlike GetChunks, the reply is streamed back one
+	// bundle at a time, as soon as the chunks for each blob become available. This avoids building a single large
+	// message for the entire request, and allows the caller to start processing bundles before all have arrived.
+	// Authentication and rate limiting are identical to GetChunks.
+	StreamChunks(*StreamChunksRequest, Relay_StreamChunksServer) error
+	// GetRelayStatus returns the current load of the relay. It is used by the disperser to decide which relays new
+	// blobs are assigned to.
+	GetRelayStatus(context.Context, *GetRelayStatusRequest) (*GetRelayStatusReply, error)
+	// GetPointProof computes a KZG multi-point opening proof of the polynomial of a blob stored by the relay at one or
+	// more points. The proof can be verified against the blob commitment with a single pairing check, e.g. by a rollup in
+	// a fraud or validity proof. A single proof is computed for all points of a request, at a cost that grows with the
+	// size of the blob, so each request is charged against the relay's GetBlob rate limits like a GetBlob request.
+	GetPointProof(context.Context, *GetPointProofRequest) (*GetPointProofReply, error)
 	mustEmbedUnimplementedRelayServer()
 }
 
@@ -80,6 +159,15 @@ func (UnimplementedRelayServer) GetBlob(context.Context, *GetBlobRequest) (*GetB
 func (UnimplementedRelayServer) GetChunks(context.Context, *GetChunksRequest) (*GetChunksReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetChunks not implemented")
 }
+func (UnimplementedRelayServer) StreamChunks(*StreamChunksRequest, Relay_StreamChunksServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamChunks not implemented")
+}
+func (UnimplementedRelayServer) GetRelayStatus(context.Context, *GetRelayStatusRequest) (*GetRelayStatusReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRelayStatus not implemented")
+}
+func (UnimplementedRelayServer) GetPointProof(context.Context, *GetPointProofRequest) (*GetPointProofReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPointProof not implemented")
+}
 func (UnimplementedRelayServer) mustEmbedUnimplementedRelayServer() {}
 
 // UnsafeRelayServer may be embedded to opt out of forward compatibility for this service.
@@ -129,6 +217,63 @@ func _Relay_GetChunks_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Relay_StreamChunks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamChunksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RelayServer).StreamChunks(m, &relayStreamChunksServer{stream})
+}
+
+type Relay_StreamChunksServer interface {
+	Send(*StreamChunksReply) error
+	grpc.ServerStream
+}
+
+type relayStreamChunksServer struct {
+	grpc.ServerStream
+}
+
+func (x *relayStreamChunksServer) Send(m *StreamChunksReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Relay_GetRelayStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRelayStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RelayServer).GetRelayStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Relay_GetRelayStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RelayServer).GetRelayStatus(ctx, req.(*GetRelayStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Relay_GetPointProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPointProofRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RelayServer).GetPointProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Relay_GetPointProof_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RelayServer).GetPointProof(ctx, req.(*GetPointProofRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Relay_ServiceDesc is the grpc.ServiceDesc for Relay service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -144,7 +289,21 @@ var Relay_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetChunks",
 			Handler:    _Relay_GetChunks_Handler,
 		},
+		{
+			MethodName: "GetRelayStatus",
+			Handler:    _Relay_GetRelayStatus_Handler,
+		},
+		{
+			MethodName: "GetPointProof",
+			Handler:    _Relay_GetPointProof_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamChunks",
+			Handler:       _Relay_StreamChunks_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "relay/relay.proto",
 }
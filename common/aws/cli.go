@@ -17,6 +17,8 @@ var (
 	FragmentParallelismConstantFlagName = "aws.fragment-parallelism-constant"
 	FragmentReadTimeoutFlagName         = "aws.fragment-read-timeout"
 	FragmentWriteTimeoutFlagName        = "aws.fragment-write-timeout"
+	LocalStoragePathFlagName            = "aws.local-storage-path"
+	LocalStorageShardingDepthFlagName   = "aws.local-storage-sharding-depth"
 )
 
 type ClientConfig struct {
@@ -37,6 +39,16 @@ type ClientConfig struct {
 	// FragmentParallelismConstant helps determine the size of the pool of workers to help upload/download files.
 	// A non-zero value for this parameter adds a constant number of workers. Default is 0.
 	FragmentParallelismConstant int
+
+	// LocalStoragePath, if set, causes the S3 client to store objects in this directory on the local filesystem
+	// instead of talking to S3. Each bucket is a subdirectory of this path. Intended for single-box deployments
+	// and tests. Region, credentials, and the endpoint URL are ignored when this is set.
+	LocalStoragePath string
+	// LocalStorageShardingDepth is the number of directory levels used to shard objects stored on the local
+	// filesystem. Each level is named with two hex characters derived from a hash of the object key, so a depth of
+	// 1 yields 256 directories per bucket, a depth of 2 yields 65536, and so on. A value of 0 stores all objects
+	// of a bucket in a single directory. Only used when LocalStoragePath is set.
+	LocalStorageShardingDepth int
 }
 
 func ClientFlags(envPrefix string, flagPrefix string) []cli.Flag {
@@ -103,6 +115,22 @@ func ClientFlags(envPrefix string, flagPrefix string) []cli.Flag {
 			Value:    30 * time.Second,
 			EnvVar:   common.PrefixEnvVar(envPrefix, "FRAGMENT_WRITE_TIMEOUT"),
 		},
+		cli.StringFlag{
+			Name: common.PrefixFlag(flagPrefix, LocalStoragePathFlagName),
+			Usage: "If set, store objects in this directory on the local filesystem instead of in S3. " +
+				"Intended for single-box deployments and tests.",
+			Required: false,
+			Value:    "",
+			EnvVar:   common.PrefixEnvVar(envPrefix, "AWS_LOCAL_STORAGE_PATH"),
+		},
+		cli.IntFlag{
+			Name: common.PrefixFlag(flagPrefix, LocalStorageShardingDepthFlagName),
+			Usage: "The number of directory levels used to shard objects stored on the local filesystem. " +
+				"Each level adds 256 directories per parent. Only used if the local storage path is set.",
+			Required: false,
+			Value:    1,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "AWS_LOCAL_STORAGE_SHARDING_DEPTH"),
+		},
 	}
 }
 
@@ -114,6 +142,8 @@ func ReadClientConfig(ctx *cli.Context, flagPrefix string) ClientConfig {
 		EndpointURL:                 ctx.GlobalString(common.PrefixFlag(flagPrefix, EndpointURLFlagName)),
 		FragmentParallelismFactor:   ctx.GlobalInt(common.PrefixFlag(flagPrefix, FragmentParallelismFactorFlagName)),
 		FragmentParallelismConstant: ctx.GlobalInt(common.PrefixFlag(flagPrefix, FragmentParallelismConstantFlagName)),
+		LocalStoragePath:            ctx.GlobalString(common.PrefixFlag(flagPrefix, LocalStoragePathFlagName)),
+		LocalStorageShardingDepth:   ctx.GlobalInt(common.PrefixFlag(flagPrefix, LocalStorageShardingDepthFlagName)),
 	}
 }
 
@@ -123,5 +153,6 @@ func DefaultClientConfig() *ClientConfig {
 		Region:                      "us-east-2",
 		FragmentParallelismFactor:   8,
 		FragmentParallelismConstant: 0,
+		LocalStorageShardingDepth:   1,
 	}
 }
@@ -88,31 +88,7 @@ var _ Client = (*client)(nil)
 func NewClient(cfg commonaws.ClientConfig, logger logging.Logger) (*client, error) {
 	var err error
 	once.Do(func() {
-		createClient := func(service, region string, options ...interface{}) (aws.Endpoint, error) {
-			if cfg.EndpointURL != "" {
-				return aws.Endpoint{
-					PartitionID:   "aws",
-					URL:           cfg.EndpointURL,
-					SigningRegion: cfg.Region,
-				}, nil
-			}
-
-			// returning EndpointNotFoundError will allow the service to fallback to its default resolution
-			return aws.Endpoint{}, &aws.EndpointNotFoundError{}
-		}
-		customResolver := aws.EndpointResolverWithOptionsFunc(createClient)
-
-		options := [](func(*config.LoadOptions) error){
-			config.WithRegion(cfg.Region),
-			config.WithEndpointResolverWithOptions(customResolver),
-			config.WithRetryMode(aws.RetryModeStandard),
-		}
-		// If access key and secret access key are not provided, use the default credential provider
-		if len(cfg.AccessKey) > 0 && len(cfg.SecretAccessKey) > 0 {
-			options = append(options, config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(cfg.AccessKey, cfg.SecretAccessKey, "")))
-		}
-		awsConfig, errCfg := config.LoadDefaultConfig(context.Background(), options...)
-
+		awsConfig, errCfg := loadAWSConfig(cfg)
 		if errCfg != nil {
 			err = errCfg
 			return
@@ -123,6 +99,34 @@ func NewClient(cfg commonaws.ClientConfig, logger logging.Logger) (*client, erro
 	return clientRef, err
 }
 
+// loadAWSConfig loads the AWS configuration of the DynamoDB clients
+func loadAWSConfig(cfg commonaws.ClientConfig) (aws.Config, error) {
+	createClient := func(service, region string, options ...interface{}) (aws.Endpoint, error) {
+		if cfg.EndpointURL != "" {
+			return aws.Endpoint{
+				PartitionID:   "aws",
+				URL:           cfg.EndpointURL,
+				SigningRegion: cfg.Region,
+			}, nil
+		}
+
+		// returning EndpointNotFoundError will allow the service to fallback to its default resolution
+		return aws.Endpoint{}, &aws.EndpointNotFoundError{}
+	}
+	customResolver := aws.EndpointResolverWithOptionsFunc(createClient)
+
+	options := [](func(*config.LoadOptions) error){
+		config.WithRegion(cfg.Region),
+		config.WithEndpointResolverWithOptions(customResolver),
+		config.WithRetryMode(aws.RetryModeStandard),
+	}
+	// If access key and secret access key are not provided, use the default credential provider
+	if len(cfg.AccessKey) > 0 && len(cfg.SecretAccessKey) > 0 {
+		options = append(options, config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(cfg.AccessKey, cfg.SecretAccessKey, "")))
+	}
+	return config.LoadDefaultConfig(context.Background(), options...)
+}
+
 func (c *client) DeleteTable(ctx context.Context, tableName string) error {
 	_, err := c.dynamoClient.DeleteTable(ctx, &dynamodb.DeleteTableInput{
 		TableName: aws.String(tableName)})
@@ -0,0 +1,56 @@
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+
+	commonaws "github.com/Layr-Labs/eigenda/common/aws"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodbstreams"
+)
+
+// NewStreamsClient creates a client of the DynamoDB Streams API, which is used to consume the changes made to a table.
+func NewStreamsClient(cfg commonaws.ClientConfig) (*dynamodbstreams.Client, error) {
+	awsConfig, err := loadAWSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return dynamodbstreams.NewFromConfig(awsConfig), nil
+}
+
+// EnableStream enables the stream of an existing table with the given view type, unless a stream is already enabled
+// on the table. Tables created before streams were needed do not have one, and the stream specification of a table
+// can only be changed with an update of the table.
+func EnableStream(ctx context.Context, cfg commonaws.ClientConfig, tableName string, viewType types.StreamViewType) error {
+	awsConfig, err := loadAWSConfig(cfg)
+	if err != nil {
+		return err
+	}
+	dynamoClient := dynamodb.NewFromConfig(awsConfig)
+
+	table, err := dynamoClient.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(tableName)})
+	if err != nil {
+		return fmt.Errorf("failed to describe table %s: %w", tableName, err)
+	}
+	spec := table.Table.StreamSpecification
+	if spec != nil && aws.ToBool(spec.StreamEnabled) {
+		if spec.StreamViewType != viewType {
+			return fmt.Errorf("stream of table %s has view type %s instead of %s", tableName, spec.StreamViewType, viewType)
+		}
+		return nil
+	}
+
+	_, err = dynamoClient.UpdateTable(ctx, &dynamodb.UpdateTableInput{
+		TableName: aws.String(tableName),
+		StreamSpecification: &types.StreamSpecification{
+			StreamEnabled:  aws.Bool(true),
+			StreamViewType: viewType,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to enable the stream of table %s: %w", tableName, err)
+	}
+	return nil
+}
@@ -4,11 +4,15 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"sync"
 
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
 )
 
+// S3Client is an in-memory s3.Client. It is safe to use concurrently, but Called must only be read while no
+// requests are in flight.
 type S3Client struct {
+	mu     sync.Mutex
 	bucket map[string][]byte
 	Called map[string]int
 }
@@ -32,6 +36,8 @@ func NewS3Client() *S3Client {
 }
 
 func (s *S3Client) DownloadObject(ctx context.Context, bucket string, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.Called["DownloadObject"]++
 	data, ok := s.bucket[key]
 	if !ok {
@@ -41,6 +47,8 @@ func (s *S3Client) DownloadObject(ctx context.Context, bucket string, key string
 }
 
 func (s *S3Client) HeadObject(ctx context.Context, bucket string, key string) (*int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.Called["HeadObject"]++
 	data, ok := s.bucket[key]
 	if !ok {
@@ -51,18 +59,24 @@ func (s *S3Client) HeadObject(ctx context.Context, bucket string, key string) (*
 }
 
 func (s *S3Client) UploadObject(ctx context.Context, bucket string, key string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.Called["UploadObject"]++
 	s.bucket[key] = data
 	return nil
 }
 
 func (s *S3Client) DeleteObject(ctx context.Context, bucket string, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.Called["DeleteObject"]++
 	delete(s.bucket, key)
 	return nil
 }
 
 func (s *S3Client) ListObjects(ctx context.Context, bucket string, prefix string) ([]s3.Object, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.Called["ListObjects"]++
 	objects := make([]s3.Object, 0, 1000)
 	for k, v := range s.bucket {
@@ -74,6 +88,8 @@ func (s *S3Client) ListObjects(ctx context.Context, bucket string, prefix string
 }
 
 func (s *S3Client) CreateBucket(ctx context.Context, bucket string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.Called["CreateBucket"]++
 	return nil
 }
@@ -84,6 +100,8 @@ func (s *S3Client) FragmentedUploadObject(
 	key string,
 	data []byte,
 	fragmentSize int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.Called["FragmentedUploadObject"]++
 	fragments, err := s3.BreakIntoFragments(key, data, fragmentSize)
 	if err != nil {
@@ -101,6 +119,8 @@ func (s *S3Client) FragmentedDownloadObject(
 	key string,
 	fileSize int,
 	fragmentSize int) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.Called["FragmentedDownloadObject"]++
 	if fileSize <= 0 {
 		return nil, errors.New("fileSize must be greater than 0")
@@ -45,7 +45,13 @@ type client struct {
 
 var _ Client = (*client)(nil)
 
+// NewClient creates a new S3 client. If cfg.LocalStoragePath is set then the returned client stores objects on the
+// local filesystem instead of in S3 (see NewLocalClient).
 func NewClient(ctx context.Context, cfg commonaws.ClientConfig, logger logging.Logger) (Client, error) {
+	if cfg.LocalStoragePath != "" {
+		return NewLocalClient(cfg, logger)
+	}
+
 	var err error
 	once.Do(func() {
 		customResolver := aws.EndpointResolverWithOptionsFunc(
@@ -84,17 +90,7 @@ func NewClient(ctx context.Context, cfg commonaws.ClientConfig, logger logging.L
 			o.UsePathStyle = true
 		})
 
-		workers := 0
-		if cfg.FragmentParallelismConstant > 0 {
-			workers = cfg.FragmentParallelismConstant
-		}
-		if cfg.FragmentParallelismFactor > 0 {
-			workers = cfg.FragmentParallelismFactor * runtime.NumCPU()
-		}
-
-		if workers == 0 {
-			workers = 1
-		}
+		workers := fragmentWorkerCount(&cfg)
 
 		pool := &errgroup.Group{}
 		pool.SetLimit(workers)
@@ -109,6 +105,22 @@ func NewClient(ctx context.Context, cfg commonaws.ClientConfig, logger logging.L
 	return ref, err
 }
 
+// fragmentWorkerCount returns the number of workers to use for fragmented uploads and downloads.
+func fragmentWorkerCount(cfg *commonaws.ClientConfig) int {
+	workers := 0
+	if cfg.FragmentParallelismConstant > 0 {
+		workers = cfg.FragmentParallelismConstant
+	}
+	if cfg.FragmentParallelismFactor > 0 {
+		workers = cfg.FragmentParallelismFactor * runtime.NumCPU()
+	}
+
+	if workers == 0 {
+		workers = 1
+	}
+	return workers
+}
+
 func (s *client) DownloadObject(ctx context.Context, bucket string, key string) ([]byte, error) {
 	objectSize := defaultBlobBufferSizeByte
 	size, err := s.HeadObject(ctx, bucket, key)
@@ -0,0 +1,452 @@
+package s3
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	commonaws "github.com/Layr-Labs/eigenda/common/aws"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+const (
+	// localTempFilePrefix is the prefix of files that are being written and have not yet been renamed into place.
+	// Escaped object keys never start with a ".", so temporary files can never be mistaken for objects.
+	localTempFilePrefix = ".tmp-"
+
+	// maxLocalFileNameLength is the longest file name permitted by most filesystems.
+	maxLocalFileNameLength = 255
+
+	// maxListedObjects mirrors the number of objects returned by a single S3 ListObjectsV2 call.
+	maxListedObjects = 1000
+)
+
+// localClient is an implementation of Client that stores objects on the local filesystem.
+//
+// Each bucket is a directory inside the root directory. Each object is a single file in its bucket directory (or in
+// a shard directory inside the bucket directory, if sharding is enabled). Object keys are escaped so that any key,
+// including keys that contain "/", maps to exactly one file name. Writes are made atomic by writing to a temporary
+// file and renaming it into place, so readers never observe a partially written object, even when the directory is
+// shared by several processes.
+type localClient struct {
+	// root is the directory that contains all buckets.
+	root string
+
+	// shardingDepth is the number of shard directory levels between a bucket directory and its objects.
+	shardingDepth int
+
+	// concurrencyLimiter is a channel that limits the number of concurrent operations.
+	concurrencyLimiter chan struct{}
+
+	logger logging.Logger
+}
+
+var _ Client = (*localClient)(nil)
+
+// NewLocalClient creates a Client that stores objects in cfg.LocalStoragePath on the local filesystem.
+// Buckets are created on demand, so calling CreateBucket is optional.
+func NewLocalClient(cfg commonaws.ClientConfig, logger logging.Logger) (Client, error) {
+	if cfg.LocalStoragePath == "" {
+		return nil, errors.New("local storage path must be set")
+	}
+	if cfg.LocalStorageShardingDepth < 0 {
+		return nil, fmt.Errorf("local storage sharding depth must not be negative, got %d",
+			cfg.LocalStorageShardingDepth)
+	}
+	if cfg.LocalStorageShardingDepth > sha256.Size {
+		return nil, fmt.Errorf("local storage sharding depth must be at most %d, got %d",
+			sha256.Size, cfg.LocalStorageShardingDepth)
+	}
+
+	root, err := filepath.Abs(cfg.LocalStoragePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve local storage path %s: %w", cfg.LocalStoragePath, err)
+	}
+	err = os.MkdirAll(root, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create local storage directory %s: %w", root, err)
+	}
+
+	logger = logger.With("component", "LocalS3Client")
+	logger.Info("Using local filesystem for object storage", "path", root,
+		"shardingDepth", cfg.LocalStorageShardingDepth)
+
+	return &localClient{
+		root:               root,
+		shardingDepth:      cfg.LocalStorageShardingDepth,
+		concurrencyLimiter: make(chan struct{}, fragmentWorkerCount(&cfg)),
+		logger:             logger,
+	}, nil
+}
+
+func (c *localClient) DownloadObject(ctx context.Context, bucket string, key string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	path, err := c.objectPath(bucket, key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("failed to read object %s from bucket %s: %w", key, bucket, err)
+	}
+	return data, nil
+}
+
+func (c *localClient) HeadObject(ctx context.Context, bucket string, key string) (*int64, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	path, err := c.objectPath(bucket, key)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("failed to stat object %s in bucket %s: %w", key, bucket, err)
+	}
+	size := info.Size()
+	return &size, nil
+}
+
+func (c *localClient) UploadObject(ctx context.Context, bucket string, key string, data []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	path, err := c.objectPath(bucket, key)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomically(path, data)
+}
+
+// DeleteObject deletes an object. Like S3, deleting an object that does not exist is not an error.
+func (c *localClient) DeleteObject(ctx context.Context, bucket string, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	path, err := c.objectPath(bucket, key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete object %s from bucket %s: %w", key, bucket, err)
+	}
+	return nil
+}
+
+// ListObjects lists up to 1000 objects in a bucket with the given prefix, in lexicographical key order.
+//
+// Since keys are hashed into shard directories, this method walks the entire bucket. It is intended for the
+// occasional listing done by tests and tooling, not for use on a hot path.
+func (c *localClient) ListObjects(ctx context.Context, bucket string, prefix string) ([]Object, error) {
+	bucketPath, err := c.bucketPath(bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make([]Object, 0)
+	err = filepath.WalkDir(bucketPath, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				// The bucket does not exist, or a directory was removed while we were walking it.
+				return nil
+			}
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if entry.IsDir() || !entry.Type().IsRegular() || strings.HasPrefix(entry.Name(), localTempFilePrefix) {
+			return nil
+		}
+
+		key, err := unescapeLocalKey(entry.Name())
+		if err != nil {
+			c.logger.Warn("Ignoring unrecognized file in local object storage", "path", path, "error", err)
+			return nil
+		}
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				// The object was deleted while we were walking the bucket.
+				return nil
+			}
+			return err
+		}
+		objects = append(objects, Object{
+			Key:  key,
+			Size: info.Size(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list objects in bucket %s: %w", bucket, err)
+	}
+
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].Key < objects[j].Key
+	})
+	if len(objects) > maxListedObjects {
+		objects = objects[:maxListedObjects]
+	}
+	return objects, nil
+}
+
+func (c *localClient) CreateBucket(ctx context.Context, bucket string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	bucketPath, err := c.bucketPath(bucket)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(bucketPath, 0755)
+	if err != nil {
+		return fmt.Errorf("failed to create bucket %s: %w", bucket, err)
+	}
+	return nil
+}
+
+func (c *localClient) FragmentedUploadObject(
+	ctx context.Context,
+	bucket string,
+	key string,
+	data []byte,
+	fragmentSize int) error {
+
+	fragments, err := BreakIntoFragments(key, data, fragmentSize)
+	if err != nil {
+		return err
+	}
+	resultChannel := make(chan error, len(fragments))
+
+	for _, fragment := range fragments {
+		fragmentCapture := fragment
+		c.concurrencyLimiter <- struct{}{}
+		go func() {
+			defer func() {
+				<-c.concurrencyLimiter
+			}()
+			resultChannel <- c.UploadObject(ctx, bucket, fragmentCapture.FragmentKey, fragmentCapture.Data)
+		}()
+	}
+
+	for range fragments {
+		err = <-resultChannel
+		if err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+func (c *localClient) FragmentedDownloadObject(
+	ctx context.Context,
+	bucket string,
+	key string,
+	fileSize int,
+	fragmentSize int) ([]byte, error) {
+	if fileSize <= 0 {
+		return nil, errors.New("fileSize must be greater than 0")
+	}
+
+	if fragmentSize <= 0 {
+		return nil, errors.New("fragmentSize must be greater than 0")
+	}
+
+	fragmentKeys, err := GetFragmentKeys(key, getFragmentCount(fileSize, fragmentSize))
+	if err != nil {
+		return nil, err
+	}
+	resultChannel := make(chan *readResult, len(fragmentKeys))
+
+	for i, fragmentKey := range fragmentKeys {
+		boundFragmentKey := fragmentKey
+		boundI := i
+		c.concurrencyLimiter <- struct{}{}
+		go func() {
+			defer func() {
+				<-c.concurrencyLimiter
+			}()
+			result := &readResult{}
+			data, err := c.DownloadObject(ctx, bucket, boundFragmentKey)
+			if err != nil {
+				result.err = err
+			} else {
+				result.fragment = &Fragment{
+					FragmentKey: boundFragmentKey,
+					Data:        data,
+					Index:       boundI,
+				}
+			}
+			resultChannel <- result
+		}()
+	}
+
+	fragments := make([]*Fragment, len(fragmentKeys))
+	for i := 0; i < len(fragmentKeys); i++ {
+		result := <-resultChannel
+		if result.err != nil {
+			return nil, result.err
+		}
+		fragments[result.fragment.Index] = result.fragment
+	}
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	return recombineFragments(fragments)
+}
+
+// bucketPath returns the directory that holds the objects of a bucket.
+func (c *localClient) bucketPath(bucket string) (string, error) {
+	if bucket == "" || bucket == "." || bucket == ".." || strings.ContainsAny(bucket, `/\`) {
+		return "", fmt.Errorf("invalid bucket name %q", bucket)
+	}
+	return filepath.Join(c.root, bucket), nil
+}
+
+// objectPath returns the path of the file that holds an object.
+func (c *localClient) objectPath(bucket string, key string) (string, error) {
+	bucketPath, err := c.bucketPath(bucket)
+	if err != nil {
+		return "", err
+	}
+	if key == "" {
+		return "", errors.New("object key must not be empty")
+	}
+
+	fileName := escapeLocalKey(key)
+	if len(fileName) > maxLocalFileNameLength {
+		return "", fmt.Errorf("object key %q is too long to be stored on the local filesystem", key)
+	}
+
+	elements := make([]string, 0, c.shardingDepth+2)
+	elements = append(elements, bucketPath)
+	if c.shardingDepth > 0 {
+		hash := sha256.Sum256([]byte(key))
+		for i := 0; i < c.shardingDepth; i++ {
+			elements = append(elements, hex.EncodeToString(hash[i:i+1]))
+		}
+	}
+	elements = append(elements, fileName)
+
+	return filepath.Join(elements...), nil
+}
+
+// escapeLocalKey converts an object key into a file name. Letters, digits, "-" and "_" are kept as is, and all other
+// bytes are percent-encoded. In particular "/" and "." are always encoded, so the resulting name never contains a
+// path separator and is never "." or "..".
+func escapeLocalKey(key string) string {
+	var builder strings.Builder
+	builder.Grow(len(key))
+	for i := 0; i < len(key); i++ {
+		b := key[i]
+		if ('a' <= b && b <= 'z') || ('A' <= b && b <= 'Z') || ('0' <= b && b <= '9') || b == '-' || b == '_' {
+			builder.WriteByte(b)
+		} else {
+			builder.WriteString(fmt.Sprintf("%%%02X", b))
+		}
+	}
+	return builder.String()
+}
+
+// unescapeLocalKey is the inverse of escapeLocalKey.
+func unescapeLocalKey(fileName string) (string, error) {
+	key, err := url.PathUnescape(fileName)
+	if err != nil {
+		return "", err
+	}
+	if escapeLocalKey(key) != fileName {
+		return "", fmt.Errorf("%s is not an escaped object key", fileName)
+	}
+	return key, nil
+}
+
+// writeFileAtomically writes data to a temporary file in the destination directory, flushes it to disk, and then
+// renames it to path. Readers observe either the previous contents of path or the new contents, never a mix.
+func writeFileAtomically(path string, data []byte) error {
+	directory := filepath.Dir(path)
+	err := os.MkdirAll(directory, 0755)
+	if err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", directory, err)
+	}
+
+	file, err := os.CreateTemp(directory, localTempFilePrefix+"*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file in %s: %w", directory, err)
+	}
+	tempPath := file.Name()
+
+	// Make a best effort to clean up the temporary file if anything goes wrong before the rename.
+	renamed := false
+	defer func() {
+		if !renamed {
+			_ = file.Close()
+			_ = os.Remove(tempPath)
+		}
+	}()
+
+	_, err = file.Write(data)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", tempPath, err)
+	}
+	err = file.Sync()
+	if err != nil {
+		return fmt.Errorf("failed to sync %s: %w", tempPath, err)
+	}
+	err = file.Chmod(0644)
+	if err != nil {
+		return fmt.Errorf("failed to set permissions of %s: %w", tempPath, err)
+	}
+	err = file.Close()
+	if err != nil {
+		return fmt.Errorf("failed to close %s: %w", tempPath, err)
+	}
+
+	err = os.Rename(tempPath, path)
+	if err != nil {
+		return fmt.Errorf("failed to rename %s to %s: %w", tempPath, path, err)
+	}
+	renamed = true
+
+	// Sync the directory so that the rename itself survives a crash.
+	dir, err := os.Open(directory)
+	if err != nil {
+		return fmt.Errorf("failed to open directory %s: %w", directory, err)
+	}
+	defer func() {
+		_ = dir.Close()
+	}()
+	err = dir.Sync()
+	if err != nil {
+		return fmt.Errorf("failed to sync directory %s: %w", directory, err)
+	}
+	return nil
+}
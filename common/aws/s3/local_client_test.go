@@ -0,0 +1,179 @@
+package s3
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/common"
+	commonaws "github.com/Layr-Labs/eigenda/common/aws"
+	tu "github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLocalClient(t *testing.T, shardingDepth int) (*localClient, string) {
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	require.NoError(t, err)
+
+	root := t.TempDir()
+	cfg := commonaws.DefaultClientConfig()
+	cfg.LocalStoragePath = root
+	cfg.LocalStorageShardingDepth = shardingDepth
+
+	client, err := NewClient(context.Background(), *cfg, logger)
+	require.NoError(t, err)
+	return client.(*localClient), root
+}
+
+func TestLocalKeyEscaping(t *testing.T) {
+	keys := []string{"abc", "a/b/c", ".", "..", "../../etc/passwd", "with space", "100%", "a-b_c.d", "日本"}
+	for _, key := range keys {
+		escaped := escapeLocalKey(key)
+		require.NotContains(t, escaped, "/")
+		require.NotContains(t, escaped, ".")
+
+		unescaped, err := unescapeLocalKey(escaped)
+		require.NoError(t, err)
+		require.Equal(t, key, unescaped)
+	}
+
+	// Names that escapeLocalKey would never produce are rejected.
+	_, err := unescapeLocalKey("a.b")
+	require.Error(t, err)
+	_, err = unescapeLocalKey("%2f")
+	require.Error(t, err)
+}
+
+func TestLocalClientOperations(t *testing.T) {
+	tu.InitializeRandom()
+	ctx := context.Background()
+
+	for _, shardingDepth := range []int{0, 1, 2} {
+		client, root := newTestLocalClient(t, shardingDepth)
+		bucket := "test-bucket"
+
+		require.NoError(t, client.CreateBucket(ctx, bucket))
+
+		expected := make(map[string][]byte)
+		for i := 0; i < 20; i++ {
+			key := ScopedKey(blobNamespace, tu.RandomString(32), prefixLength)
+			data := tu.RandomBytes(100 + i)
+			expected[key] = data
+			require.NoError(t, client.UploadObject(ctx, bucket, key, data))
+		}
+
+		for key, data := range expected {
+			size, err := client.HeadObject(ctx, bucket, key)
+			require.NoError(t, err)
+			require.Equal(t, int64(len(data)), *size)
+
+			readData, err := client.DownloadObject(ctx, bucket, key)
+			require.NoError(t, err)
+			require.Equal(t, data, readData)
+
+			// The object must be stored at the expected depth inside the bucket.
+			path, err := client.objectPath(bucket, key)
+			require.NoError(t, err)
+			relativePath, err := filepath.Rel(filepath.Join(root, bucket), path)
+			require.NoError(t, err)
+			require.Equal(t, shardingDepth+1, len(strings.Split(relativePath, string(filepath.Separator))))
+		}
+
+		// Overwrite an object.
+		var overwrittenKey string
+		for key := range expected {
+			overwrittenKey = key
+			break
+		}
+		expected[overwrittenKey] = tu.RandomBytes(10)
+		require.NoError(t, client.UploadObject(ctx, bucket, overwrittenKey, expected[overwrittenKey]))
+		readData, err := client.DownloadObject(ctx, bucket, overwrittenKey)
+		require.NoError(t, err)
+		require.Equal(t, expected[overwrittenKey], readData)
+
+		// List all objects.
+		objects, err := client.ListObjects(ctx, bucket, "")
+		require.NoError(t, err)
+		require.Len(t, objects, len(expected))
+		for i, object := range objects {
+			require.Equal(t, int64(len(expected[object.Key])), object.Size)
+			if i > 0 {
+				require.Less(t, objects[i-1].Key, object.Key)
+			}
+		}
+
+		// List with a prefix that contains a "/".
+		objects, err = client.ListObjects(ctx, bucket, overwrittenKey[:len(overwrittenKey)-5])
+		require.NoError(t, err)
+		require.Len(t, objects, 1)
+		require.Equal(t, overwrittenKey, objects[0].Key)
+
+		// Leftover temporary files are not objects.
+		path, err := client.objectPath(bucket, overwrittenKey)
+		require.NoError(t, err)
+		err = os.WriteFile(filepath.Join(filepath.Dir(path), localTempFilePrefix+"12345"), []byte("junk"), 0644)
+		require.NoError(t, err)
+		objects, err = client.ListObjects(ctx, bucket, "")
+		require.NoError(t, err)
+		require.Len(t, objects, len(expected))
+
+		// Delete objects.
+		for key := range expected {
+			require.NoError(t, client.DeleteObject(ctx, bucket, key))
+			_, err = client.HeadObject(ctx, bucket, key)
+			require.ErrorIs(t, err, ErrObjectNotFound)
+			_, err = client.DownloadObject(ctx, bucket, key)
+			require.ErrorIs(t, err, ErrObjectNotFound)
+		}
+		objects, err = client.ListObjects(ctx, bucket, "")
+		require.NoError(t, err)
+		require.Empty(t, objects)
+
+		// Deleting a missing object is not an error.
+		require.NoError(t, client.DeleteObject(ctx, bucket, "missing"))
+	}
+}
+
+func TestLocalClientFragmentedOperations(t *testing.T) {
+	tu.InitializeRandom()
+	ctx := context.Background()
+	client, _ := newTestLocalClient(t, 1)
+	bucket := "test-bucket"
+
+	fragmentSize := 100
+	for _, dataSize := range []int{1, 99, 100, 101, 1000, 1234} {
+		key := tu.RandomString(10)
+		data := tu.RandomBytes(dataSize)
+		require.NoError(t, client.FragmentedUploadObject(ctx, bucket, key, data, fragmentSize))
+
+		readData, err := client.FragmentedDownloadObject(ctx, bucket, key, dataSize, fragmentSize)
+		require.NoError(t, err)
+		require.Equal(t, data, readData)
+
+		objects, err := client.ListObjects(ctx, bucket, key)
+		require.NoError(t, err)
+		require.Len(t, objects, getFragmentCount(dataSize, fragmentSize))
+	}
+
+	_, err := client.FragmentedDownloadObject(ctx, bucket, "missing", 1000, fragmentSize)
+	require.ErrorIs(t, err, ErrObjectNotFound)
+}
+
+func TestLocalClientInvalidNames(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newTestLocalClient(t, 1)
+
+	for _, bucket := range []string{"", ".", "..", "a/b"} {
+		require.Error(t, client.UploadObject(ctx, bucket, "key", []byte("data")))
+		require.Error(t, client.CreateBucket(ctx, bucket))
+	}
+	require.Error(t, client.UploadObject(ctx, "bucket", "", []byte("data")))
+	require.Error(t, client.UploadObject(ctx, "bucket", strings.Repeat("/", 100), []byte("data")))
+
+	// Listing a bucket that was never written to returns nothing.
+	objects, err := client.ListObjects(ctx, "never-written", "")
+	require.NoError(t, err)
+	require.Empty(t, objects)
+}
@@ -20,6 +20,7 @@ import (
 var (
 	dockertestPool     *dockertest.Pool
 	dockertestResource *dockertest.Resource
+	localStoragePath   string
 )
 
 const (
@@ -49,6 +50,37 @@ var clientBuilders = []*clientBuilder{
 			return nil
 		},
 	},
+	{
+		start: func() error {
+			var err error
+			localStoragePath, err = os.MkdirTemp("", "eigenda-local-s3")
+			return err
+		},
+		build: func() (s3.Client, error) {
+			logger, err := common.NewLogger(common.DefaultLoggerConfig())
+			if err != nil {
+				return nil, err
+			}
+
+			config := aws.DefaultClientConfig()
+			config.LocalStoragePath = localStoragePath
+
+			client, err := s3.NewClient(context.Background(), *config, logger)
+			if err != nil {
+				return nil, err
+			}
+
+			err = client.CreateBucket(context.Background(), bucket)
+			if err != nil {
+				return nil, err
+			}
+
+			return client, nil
+		},
+		finish: func() error {
+			return os.RemoveAll(localStoragePath)
+		},
+	},
 	{
 		start: func() error {
 			return setupLocalstack()
@@ -0,0 +1,115 @@
+package v2
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/hashing"
+	aws2 "github.com/Layr-Labs/eigenda/common/aws"
+	core "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// defaultSigningTimeout is the time allowed for a single call to a KMS or remote signer, if none is configured.
+const defaultSigningTimeout = 10 * time.Second
+
+// KMSBlobRequestSigner signs blob requests with a secp256k1 key (KeySpecEccSecgP256k1) held in AWS KMS. The private
+// key never leaves KMS.
+type KMSBlobRequestSigner struct {
+	keyID      string
+	publicKey  *ecdsa.PublicKey
+	accountID  gethcommon.Address
+	keyManager *kms.Client
+	timeout    time.Duration
+}
+
+var _ core.BlobRequestSigner = &KMSBlobRequestSigner{}
+
+// NewKMSBlobRequestSigner creates a new KMSBlobRequestSigner. If endpoint is empty then the default AWS endpoint
+// for the region is used. If timeout is zero then a default timeout is used for each signing call.
+func NewKMSBlobRequestSigner(
+	ctx context.Context,
+	region string,
+	endpoint string,
+	keyID string,
+	timeout time.Duration) (*KMSBlobRequestSigner, error) {
+
+	// Load the AWS SDK configuration, which will automatically detect credentials
+	// from environment variables, IAM roles, or AWS config files
+	cfg, err := config.LoadDefaultConfig(ctx,
+		config.WithRegion(region),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	var keyManager *kms.Client
+	if endpoint != "" {
+		keyManager = kms.New(kms.Options{
+			Region:       region,
+			BaseEndpoint: aws.String(endpoint),
+		})
+	} else {
+		keyManager = kms.NewFromConfig(cfg)
+	}
+
+	publicKey, err := aws2.LoadPublicKeyKMS(ctx, keyManager, keyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ecdsa public key: %w", err)
+	}
+
+	if timeout == 0 {
+		timeout = defaultSigningTimeout
+	}
+
+	return &KMSBlobRequestSigner{
+		keyID:      keyID,
+		publicKey:  publicKey,
+		accountID:  crypto.PubkeyToAddress(*publicKey),
+		keyManager: keyManager,
+		timeout:    timeout,
+	}, nil
+}
+
+func (s *KMSBlobRequestSigner) SignBlobRequest(header *core.BlobHeader) ([]byte, error) {
+	blobKey, err := header.BlobKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob key: %v", err)
+	}
+
+	return s.sign(blobKey[:])
+}
+
+func (s *KMSBlobRequestSigner) SignPaymentStateRequest(timestamp uint64) ([]byte, error) {
+	requestHash, err := hashing.HashGetPaymentStateRequest(s.accountID, timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash request: %w", err)
+	}
+
+	hash := sha256.Sum256(requestHash)
+	return s.sign(hash[:])
+}
+
+func (s *KMSBlobRequestSigner) GetAccountID() (gethcommon.Address, error) {
+	return s.accountID, nil
+}
+
+// sign signs a 32 byte digest with the KMS key.
+func (s *KMSBlobRequestSigner) sign(hash []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	sig, err := aws2.SignKMS(ctx, s.keyManager, s.keyID, s.publicKey, hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign hash with KMS key %s: %w", s.keyID, err)
+	}
+
+	return sig, nil
+}
@@ -0,0 +1,147 @@
+package v2
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/hashing"
+	core "github.com/Layr-Labs/eigenda/core/v2"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// eip191SigningMethods are the standard JSON-RPC signing methods, which apply the EIP-191 "\x19Ethereum Signed Message"
+// prefix to the data they sign. The disperser verifies signatures over the raw digest, so these methods can't be used
+// by RemoteBlobRequestSigner.
+var eip191SigningMethods = map[string]struct{}{
+	"eth_sign":      {},
+	"personal_sign": {},
+}
+
+// RemoteBlobRequestSigner signs blob requests by calling out to a remote signing service over JSON-RPC. The private
+// key never enters this process.
+//
+// The configured method is called with the parameters [address, digest], where digest is the 0x prefixed hex encoding
+// of a 32 byte hash. The service must return the 65 byte [R || S || V] secp256k1 signature over exactly that digest,
+// i.e. without applying the EIP-191 "\x19Ethereum Signed Message" prefix. Standard methods such as eth_sign always
+// apply the prefix, so the service must expose a method that signs raw digests. Signatures are checked against the
+// configured address before they are returned, so a misconfigured signer fails loudly instead of producing requests
+// that the disperser rejects.
+type RemoteBlobRequestSigner struct {
+	client    *rpc.Client
+	method    string
+	accountID gethcommon.Address
+	timeout   time.Duration
+}
+
+var _ core.BlobRequestSigner = &RemoteBlobRequestSigner{}
+
+// NewRemoteBlobRequestSigner creates a new RemoteBlobRequestSigner for the given account, which signs digests with
+// the given JSON-RPC method. If timeout is zero then a default timeout is used for each signing call. The headers are
+// added to every request, e.g. to carry an authorization token.
+func NewRemoteBlobRequestSigner(
+	ctx context.Context,
+	url string,
+	method string,
+	accountID gethcommon.Address,
+	headers map[string]string,
+	timeout time.Duration) (*RemoteBlobRequestSigner, error) {
+
+	if accountID == (gethcommon.Address{}) {
+		return nil, fmt.Errorf("remote signer account address must be set")
+	}
+	if method == "" {
+		return nil, fmt.Errorf("remote signer method must be set to a method that signs raw digests")
+	}
+	if _, ok := eip191SigningMethods[method]; ok {
+		return nil, fmt.Errorf("remote signer method %s applies the EIP-191 prefix, "+
+			"use a method that signs raw digests", method)
+	}
+
+	options := make([]rpc.ClientOption, 0, len(headers))
+	for key, value := range headers {
+		options = append(options, rpc.WithHeader(key, value))
+	}
+
+	client, err := rpc.DialOptions(ctx, url, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial remote signer %s: %w", url, err)
+	}
+
+	if timeout == 0 {
+		timeout = defaultSigningTimeout
+	}
+
+	return &RemoteBlobRequestSigner{
+		client:    client,
+		method:    method,
+		accountID: accountID,
+		timeout:   timeout,
+	}, nil
+}
+
+func (s *RemoteBlobRequestSigner) SignBlobRequest(header *core.BlobHeader) ([]byte, error) {
+	blobKey, err := header.BlobKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob key: %v", err)
+	}
+
+	return s.sign(blobKey[:])
+}
+
+func (s *RemoteBlobRequestSigner) SignPaymentStateRequest(timestamp uint64) ([]byte, error) {
+	requestHash, err := hashing.HashGetPaymentStateRequest(s.accountID, timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash request: %w", err)
+	}
+
+	hash := sha256.Sum256(requestHash)
+	return s.sign(hash[:])
+}
+
+func (s *RemoteBlobRequestSigner) GetAccountID() (gethcommon.Address, error) {
+	return s.accountID, nil
+}
+
+// Close releases the connection to the remote signer.
+func (s *RemoteBlobRequestSigner) Close() {
+	s.client.Close()
+}
+
+// sign asks the remote signer to sign a 32 byte digest and verifies the result.
+func (s *RemoteBlobRequestSigner) sign(hash []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	var sig hexutil.Bytes
+	err := s.client.CallContext(ctx, &sig, s.method, s.accountID, hexutil.Bytes(hash))
+	if err != nil {
+		return nil, fmt.Errorf("remote signer call %s failed: %w", s.method, err)
+	}
+
+	if len(sig) != 65 {
+		return nil, fmt.Errorf("remote signer returned a signature of unexpected length: %d", len(sig))
+	}
+
+	// Some signers return the recovery ID in the legacy Ethereum form (27 or 28) rather than 0 or 1.
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+
+	publicKey, err := crypto.SigToPub(hash, sig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recover public key from remote signature: %w", err)
+	}
+	signerAddress := crypto.PubkeyToAddress(*publicKey)
+	if signerAddress != s.accountID {
+		return nil, fmt.Errorf("remote signature recovers to %s instead of %s, "+
+			"check that the signer signs the raw digest without an EIP-191 prefix",
+			signerAddress.Hex(), s.accountID.Hex())
+	}
+
+	return sig, nil
+}
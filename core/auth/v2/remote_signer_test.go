@@ -0,0 +1,170 @@
+package v2_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/common"
+	auth "github.com/Layr-Labs/eigenda/core/auth/v2"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+// testRemoteSignerMethod is the JSON-RPC method of testRemoteSigner that signs digests.
+const testRemoteSignerMethod = "signer_signDigest"
+
+// testRemoteSigner is a JSON-RPC service that signs digests with a local key, standing in for e.g. web3signer.
+type testRemoteSigner struct {
+	key *ecdsa.PrivateKey
+	// legacyRecoveryID causes the signer to return V as 27 or 28.
+	legacyRecoveryID bool
+	// eip191 causes the signer to apply the EIP-191 message prefix before signing.
+	eip191 bool
+}
+
+// SignDigest is exposed as testRemoteSignerMethod.
+func (s *testRemoteSigner) SignDigest(address gethcommon.Address, data hexutil.Bytes) (hexutil.Bytes, error) {
+	if address != crypto.PubkeyToAddress(s.key.PublicKey) {
+		return nil, errors.New("unknown account")
+	}
+	digest := []byte(data)
+	if s.eip191 {
+		digest = crypto.Keccak256([]byte("\x19Ethereum Signed Message:\n32"), digest)
+	}
+	sig, err := crypto.Sign(digest, s.key)
+	if err != nil {
+		return nil, err
+	}
+	if s.legacyRecoveryID {
+		sig[64] += 27
+	}
+	return sig, nil
+}
+
+func startTestRemoteSigner(t *testing.T, service *testRemoteSigner) string {
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("signer", service))
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(func() {
+		httpServer.Close()
+		server.Stop()
+	})
+	return httpServer.URL
+}
+
+func TestRemoteSigner(t *testing.T) {
+	privateKey := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcded"
+	key, err := crypto.HexToECDSA(privateKey)
+	require.NoError(t, err)
+	expectedAccountID := gethcommon.HexToAddress("0x1aa8226f6d354380dDE75eE6B634875c4203e522")
+
+	for _, legacyRecoveryID := range []bool{false, true} {
+		url := startTestRemoteSigner(t, &testRemoteSigner{key: key, legacyRecoveryID: legacyRecoveryID})
+
+		signer, err := auth.NewBlobRequestSigner(context.Background(), auth.BlobRequestSignerConfig{
+			RemoteSignerURL:     url,
+			RemoteSignerAddress: expectedAccountID.Hex(),
+			RemoteSignerMethod:  testRemoteSignerMethod,
+		})
+		require.NoError(t, err)
+
+		accountID, err := signer.GetAccountID()
+		require.NoError(t, err)
+		require.Equal(t, expectedAccountID, accountID)
+
+		// Blob request signatures are accepted by the authenticator.
+		header := testHeader(t, accountID)
+		signature, err := signer.SignBlobRequest(header)
+		require.NoError(t, err)
+		require.NoError(t, auth.NewBlobRequestAuthenticator().AuthenticateBlobRequest(header, signature))
+
+		// Payment state request signatures are accepted by the authenticator.
+		timestamp := uint64(1609459200000000000)
+		signature, err = signer.SignPaymentStateRequest(timestamp)
+		require.NoError(t, err)
+		authenticator := auth.NewPaymentStateAuthenticator(1<<62, 1<<62)
+		err = authenticator.AuthenticatePaymentStateRequest(accountID, &pb.GetPaymentStateRequest{
+			AccountId: accountID.Hex(),
+			Signature: signature,
+			Timestamp: timestamp,
+		})
+		require.NoError(t, err)
+
+		// The signature matches the one produced by a local signer with the same key.
+		localSigner, err := auth.NewLocalBlobRequestSigner(privateKey)
+		require.NoError(t, err)
+		localSignature, err := localSigner.SignPaymentStateRequest(timestamp)
+		require.NoError(t, err)
+		require.Equal(t, localSignature, signature)
+	}
+}
+
+func TestRemoteSignerRejectsBadSignatures(t *testing.T) {
+	key, err := crypto.HexToECDSA("0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcded")
+	require.NoError(t, err)
+	accountID := crypto.PubkeyToAddress(key.PublicKey)
+
+	// A signer that applies the EIP-191 prefix produces signatures the disperser would reject.
+	url := startTestRemoteSigner(t, &testRemoteSigner{key: key, eip191: true})
+	signer, err := auth.NewRemoteBlobRequestSigner(context.Background(), url, testRemoteSignerMethod, accountID, nil, 0)
+	require.NoError(t, err)
+	defer signer.Close()
+	_, err = signer.SignPaymentStateRequest(1)
+	require.ErrorContains(t, err, "EIP-191")
+
+	// The remote signer does not hold the key for the requested account.
+	url = startTestRemoteSigner(t, &testRemoteSigner{key: key})
+	otherAccount := gethcommon.HexToAddress("0x0000000000000000000000000000000000000001")
+	signer, err = auth.NewRemoteBlobRequestSigner(context.Background(), url, testRemoteSignerMethod, otherAccount, nil, 0)
+	require.NoError(t, err)
+	defer signer.Close()
+	_, err = signer.SignPaymentStateRequest(1)
+	require.Error(t, err)
+
+	// Methods that apply the EIP-191 prefix, and a missing method, are rejected up front.
+	for _, method := range []string{"", "eth_sign", "personal_sign"} {
+		_, err = auth.NewRemoteBlobRequestSigner(context.Background(), url, method, accountID, nil, 0)
+		require.Error(t, err)
+	}
+
+	// An unknown method is reported as an error.
+	signer, err = auth.NewRemoteBlobRequestSigner(context.Background(), url, "eth_doesNotExist", accountID, nil, 0)
+	require.NoError(t, err)
+	defer signer.Close()
+	_, err = signer.SignPaymentStateRequest(1)
+	require.Error(t, err)
+}
+
+func TestNewBlobRequestSignerValidation(t *testing.T) {
+	privateKey := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcded"
+
+	_, err := auth.NewBlobRequestSigner(context.Background(), auth.BlobRequestSignerConfig{})
+	require.Error(t, err)
+
+	_, err = auth.NewBlobRequestSigner(context.Background(), auth.BlobRequestSignerConfig{
+		PrivateKeyHex:   privateKey,
+		RemoteSignerURL: "http://localhost:9000",
+	})
+	require.Error(t, err)
+
+	_, err = auth.NewBlobRequestSigner(context.Background(), auth.BlobRequestSignerConfig{
+		RemoteSignerURL:     "http://localhost:9000",
+		RemoteSignerAddress: "not an address",
+	})
+	require.Error(t, err)
+
+	// A disabled KMS key does not count as a configured signer.
+	signer, err := auth.NewBlobRequestSigner(context.Background(), auth.BlobRequestSignerConfig{
+		PrivateKeyHex: privateKey,
+		KMS:           common.KMSKeyConfig{KeyID: "key", Region: "us-east-1", Disable: true},
+	})
+	require.NoError(t, err)
+	require.IsType(t, &auth.LocalBlobRequestSigner{}, signer)
+}
@@ -0,0 +1,90 @@
+package v2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	core "github.com/Layr-Labs/eigenda/core/v2"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// BlobRequestSignerConfig describes where the key used to sign blob requests lives. Exactly one of PrivateKeyHex,
+// KMS, or RemoteSignerURL must be set.
+type BlobRequestSignerConfig struct {
+	// PrivateKeyHex is the hex encoded private key, for signing in process. Prefer KMS or a remote signer in
+	// production so that the payment key is never held in memory or on disk.
+	PrivateKeyHex string
+
+	// KMS describes a secp256k1 key held in AWS KMS. Used if KMS.KeyID is set and KMS.Disable is false.
+	KMS common.KMSKeyConfig
+	// KMSEndpoint overrides the AWS KMS endpoint, e.g. to point at localstack. Optional.
+	KMSEndpoint string
+
+	// RemoteSignerURL is the JSON-RPC endpoint of a remote signer (see RemoteBlobRequestSigner).
+	RemoteSignerURL string
+	// RemoteSignerAddress is the account whose key the remote signer uses. Required with RemoteSignerURL.
+	RemoteSignerAddress string
+	// RemoteSignerMethod is the JSON-RPC method to call, which must sign raw digests (see RemoteBlobRequestSigner).
+	// Required with RemoteSignerURL.
+	RemoteSignerMethod string
+	// RemoteSignerHeaders are added to every request to the remote signer, e.g. for authorization.
+	RemoteSignerHeaders map[string]string
+
+	// SigningTimeout bounds each call to KMS or the remote signer. Defaults to 10 seconds.
+	SigningTimeout time.Duration
+}
+
+// NewBlobRequestSigner creates the BlobRequestSigner described by the config.
+func NewBlobRequestSigner(ctx context.Context, config BlobRequestSignerConfig) (core.BlobRequestSigner, error) {
+	useKMS := config.KMS.KeyID != "" && !config.KMS.Disable
+	useRemote := config.RemoteSignerURL != ""
+	useLocal := config.PrivateKeyHex != ""
+
+	configured := 0
+	for _, enabled := range []bool{useKMS, useRemote, useLocal} {
+		if enabled {
+			configured++
+		}
+	}
+	if configured == 0 {
+		return nil, errors.New("no blob request signer configured: set a private key, a KMS key, or a remote signer")
+	}
+	if configured > 1 {
+		return nil, errors.New("multiple blob request signers configured: set only one of " +
+			"a private key, a KMS key, or a remote signer")
+	}
+
+	switch {
+	case useKMS:
+		signer, err := NewKMSBlobRequestSigner(
+			ctx, config.KMS.Region, config.KMSEndpoint, config.KMS.KeyID, config.SigningTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("create KMS blob request signer: %w", err)
+		}
+		return signer, nil
+	case useRemote:
+		if !gethcommon.IsHexAddress(config.RemoteSignerAddress) {
+			return nil, fmt.Errorf("invalid remote signer address %q", config.RemoteSignerAddress)
+		}
+		signer, err := NewRemoteBlobRequestSigner(
+			ctx,
+			config.RemoteSignerURL,
+			config.RemoteSignerMethod,
+			gethcommon.HexToAddress(config.RemoteSignerAddress),
+			config.RemoteSignerHeaders,
+			config.SigningTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("create remote blob request signer: %w", err)
+		}
+		return signer, nil
+	default:
+		signer, err := NewLocalBlobRequestSigner(config.PrivateKeyHex)
+		if err != nil {
+			return nil, fmt.Errorf("create local blob request signer: %w", err)
+		}
+		return signer, nil
+	}
+}
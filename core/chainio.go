@@ -139,6 +139,15 @@ type Reader interface {
 	// GetOnDemandPaymentByAccount returns on-demand payment of an account
 	GetOnDemandPaymentByAccount(ctx context.Context, accountID gethcommon.Address) (*OnDemandPayment, error)
 
+	// GetMinNumSymbols returns the minimum number of symbols charged for a dispersal at the given block number.
+	GetMinNumSymbols(ctx context.Context, blockNumber uint32) (uint64, error)
+
+	// GetPricePerSymbol returns the price per symbol of on-demand dispersals at the given block number.
+	GetPricePerSymbol(ctx context.Context, blockNumber uint32) (uint64, error)
+
+	// GetReservationWindow returns the length of a reservation period in seconds at the given block number.
+	GetReservationWindow(ctx context.Context, blockNumber uint32) (uint64, error)
+
 	// GetDisperserAddress returns the disperser address with the given ID.
 	GetDisperserAddress(ctx context.Context, disperserID uint32) (gethcommon.Address, error)
 
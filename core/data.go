@@ -61,12 +61,16 @@ const (
 	// in protobuf, UNKNOWN as 0 is a convention).
 	GobBundleEncodingFormat   BundleEncodingFormat = 0
 	GnarkBundleEncodingFormat BundleEncodingFormat = 1
+	// Like GnarkBundleEncodingFormat, but the chunks are in GnarkCompressedChunkEncodingFormat.
+	GnarkCompressedBundleEncodingFormat BundleEncodingFormat = 2
 
 	// Similar to bundle encoding format, this describes the encoding format of chunks.
 	// The difference is ChunkEncodingFormat is just about chunks, whereas BundleEncodingFormat
 	// is also about how multiple chunks of the same bundle are packed into a single byte array.
 	GobChunkEncodingFormat   ChunkEncodingFormat = 0
 	GnarkChunkEncodingFormat ChunkEncodingFormat = 1
+	// The chunk format of encoding.Frame.SerializeGnarkCompressed(), which packs each coefficient into 254 bits.
+	GnarkCompressedChunkEncodingFormat ChunkEncodingFormat = 2
 )
 
 type ChunksData struct {
@@ -83,8 +87,8 @@ func (cd *ChunksData) Size() uint64 {
 	if len(cd.Chunks) == 0 {
 		return 0
 	}
-	// GnarkChunkEncoding will create chunks of equal size.
-	if cd.Format == GnarkChunkEncodingFormat {
+	// GnarkChunkEncoding and GnarkCompressedChunkEncoding will create chunks of equal size.
+	if cd.Format == GnarkChunkEncodingFormat || cd.Format == GnarkCompressedChunkEncodingFormat {
 		return uint64(len(cd.Chunks)) * uint64(len(cd.Chunks[0]))
 	}
 	// GobChunkEncoding can create chunks of different sizes.
@@ -132,6 +136,14 @@ func (cd *ChunksData) ToFrames() ([]*encoding.Frame, error) {
 			}
 			frames = append(frames, fr)
 		}
+	case GnarkCompressedChunkEncodingFormat:
+		for _, data := range cd.Chunks {
+			fr, err := new(encoding.Frame).DeserializeGnarkCompressed(data)
+			if err != nil {
+				return nil, err
+			}
+			frames = append(frames, fr)
+		}
 	default:
 		return nil, fmt.Errorf("invalid chunk encoding format: %v", cd.Format)
 	}
@@ -159,6 +171,31 @@ func (cd *ChunksData) FlattenToBundle() ([]byte, error) {
 	return result, nil
 }
 
+// FlattenToCompressedBundle is like FlattenToBundle, but packs the chunks into a bundle in the
+// GnarkCompressedBundleEncodingFormat. The chunks are converted without deserializing them.
+func (cd *ChunksData) FlattenToCompressedBundle() ([]byte, error) {
+	if cd.Format != GnarkChunkEncodingFormat {
+		return nil, fmt.Errorf("unsupported chunk encoding format to flatten: %v", cd.Format)
+	}
+	chunkSize := encoding.GnarkCompressedChunkSize(cd.ChunkLen)
+	result := make([]byte, len(cd.Chunks)*chunkSize+8)
+	buf := result
+	metadata := BinaryBundleHeaderWithFormat(GnarkCompressedBundleEncodingFormat, uint64(cd.ChunkLen))
+	binary.LittleEndian.PutUint64(buf, metadata)
+	buf = buf[8:]
+	for _, c := range cd.Chunks {
+		if len(c) != len(cd.Chunks[0]) {
+			return nil, errors.New("all chunks must be of same size")
+		}
+		err := encoding.CompressGnarkChunk(c, buf)
+		if err != nil {
+			return nil, err
+		}
+		buf = buf[chunkSize:]
+	}
+	return result, nil
+}
+
 func (cd *ChunksData) ToGobFormat() (*ChunksData, error) {
 	if cd.Format == GobChunkEncodingFormat {
 		return cd, nil
@@ -364,7 +401,12 @@ func (b Bundle) Size() uint64 {
 
 // BinaryBundleHeader returns the header of a bundle in binary format.
 func BinaryBundleHeader(elementCount uint64) uint64 {
-	header := uint64(GnarkBundleEncodingFormat) << (NumBundleHeaderBits - NumBundleEncodingFormatBits)
+	return BinaryBundleHeaderWithFormat(GnarkBundleEncodingFormat, elementCount)
+}
+
+// BinaryBundleHeaderWithFormat returns the header of a bundle in the given encoding format in binary format.
+func BinaryBundleHeaderWithFormat(format BundleEncodingFormat, elementCount uint64) uint64 {
+	header := uint64(format) << (NumBundleHeaderBits - NumBundleEncodingFormatBits)
 	header |= elementCount
 	return header
 }
@@ -375,13 +417,16 @@ func BinaryBundleHeader(elementCount uint64) uint64 {
 // <8 bytes header><chunk 1 bytes>chunk 2 bytes>...
 //
 // The header format:
-//   - First byte: describes the encoding format. Currently, only GnarkBundleEncodingFormat (1)
-//     is supported.
+//   - First byte: describes the encoding format. Bundles are serialized in GnarkBundleEncodingFormat (1).
+//     Deserialize also supports GnarkCompressedBundleEncodingFormat (2).
 //   - Remaining 7 bytes: describes the information about chunks.
 //
 // The chunk format will depend on the encoding format. With the GnarkBundleEncodingFormat,
 // each chunk is formated as <32 bytes proof><32 bytes coeff>...<32 bytes coefff>, where the
-// proof and coeffs are all encoded with Gnark.
+// proof and coeffs are all encoded with Gnark. With the GnarkCompressedBundleEncodingFormat, each chunk
+// is formatted as <32 bytes proof><254 bits coeff>...<254 bits coeff><zero padding to a whole byte>.
+// Either way all chunks have the same size, which is determined by the number of coeffs in the header,
+// so individual chunks can be sliced out of the bundle without copying them.
 func (b Bundle) Serialize() ([]byte, error) {
 	if len(b) == 0 {
 		return []byte{}, nil
@@ -418,7 +463,8 @@ func (b Bundle) Deserialize(data []byte) (Bundle, error) {
 	}
 	// Parse metadata
 	meta := binary.LittleEndian.Uint64(data)
-	if (meta >> (NumBundleHeaderBits - NumBundleEncodingFormatBits)) != uint64(GnarkBundleEncodingFormat) {
+	format := BundleEncodingFormat(meta >> (NumBundleHeaderBits - NumBundleEncodingFormatBits))
+	if format != GnarkBundleEncodingFormat && format != GnarkCompressedBundleEncodingFormat {
 		return nil, errors.New("invalid bundle data encoding format")
 	}
 	chunkLen := (meta << NumBundleEncodingFormatBits) >> NumBundleEncodingFormatBits
@@ -426,6 +472,9 @@ func (b Bundle) Deserialize(data []byte) (Bundle, error) {
 		return nil, errors.New("chunk length must be greater than zero")
 	}
 	chunkSize := bn254.SizeOfG1AffineCompressed + encoding.BYTES_PER_SYMBOL*int(chunkLen)
+	if format == GnarkCompressedBundleEncodingFormat {
+		chunkSize = encoding.GnarkCompressedChunkSize(int(chunkLen))
+	}
 	if (len(data)-8)%chunkSize != 0 {
 		return nil, errors.New("bundle data is invalid")
 	}
@@ -436,7 +485,13 @@ func (b Bundle) Deserialize(data []byte) (Bundle, error) {
 		if len(buf) < chunkSize {
 			return nil, errors.New("bundle data is invalid")
 		}
-		f, err := new(encoding.Frame).DeserializeGnark(buf[:chunkSize])
+		var f *encoding.Frame
+		var err error
+		if format == GnarkCompressedBundleEncodingFormat {
+			f, err = new(encoding.Frame).DeserializeGnarkCompressed(buf[:chunkSize])
+		} else {
+			f, err = new(encoding.Frame).DeserializeGnark(buf[:chunkSize])
+		}
 		if err != nil {
 			return nil, err
 		}
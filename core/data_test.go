@@ -193,6 +193,13 @@ func TestChunksData(t *testing.T) {
 		bytesFromBundle, err := bundle.Serialize()
 		assert.Nil(t, err)
 		assert.True(t, bytes.Equal(bytesFromChunksData, bytesFromBundle))
+		// FlattenToCompressedBundle
+		compressedBundle, err := gnark.FlattenToCompressedBundle()
+		assert.Nil(t, err)
+		assert.Equal(t, 8+64*(32+64*254/8), len(compressedBundle))
+		decodedBundle, err := new(core.Bundle).Deserialize(compressedBundle)
+		assert.Nil(t, err)
+		checkBundleEquivalence(t, bundle, decodedBundle)
 		// FromFrames
 		cd, err := new(core.ChunksData).FromFrames(bundle)
 		assert.Nil(t, err)
@@ -210,6 +217,8 @@ func TestChunksData(t *testing.T) {
 		assert.EqualError(t, err, "all chunks must be of same size")
 		_, err = gob.FlattenToBundle()
 		assert.EqualError(t, err, "unsupported chunk encoding format to flatten: 0")
+		_, err = gob.FlattenToCompressedBundle()
+		assert.EqualError(t, err, "unsupported chunk encoding format to flatten: 0")
 		gob.Format = core.ChunkEncodingFormat(3)
 		_, err = gob.ToGobFormat()
 		assert.EqualError(t, err, "unsupported chunk encoding format: 3")
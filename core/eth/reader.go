@@ -38,6 +38,7 @@ type ContractBindings struct {
 	RegCoordinatorAddr    gethcommon.Address
 	ServiceManagerAddr    gethcommon.Address
 	RelayRegistryAddress  gethcommon.Address
+	PaymentVaultAddress   gethcommon.Address
 	DelegationManager     *delegationmgr.ContractDelegationManager
 	OpStateRetriever      *opstateretriever.ContractOperatorStateRetriever
 	BLSApkRegistry        *blsapkreg.ContractBLSApkRegistry
@@ -244,6 +245,7 @@ func (t *Reader) updateContractBindings(blsOperatorStateRetrieverAddr, eigenDASe
 		ServiceManagerAddr:    eigenDAServiceManagerAddr,
 		RegCoordinatorAddr:    registryCoordinatorAddr,
 		RelayRegistryAddress:  relayRegistryAddress,
+		PaymentVaultAddress:   paymentVaultAddr,
 		AVSDirectory:          contractAVSDirectory,
 		SocketRegistry:        contractSocketRegistry,
 		OpStateRetriever:      contractBLSOpStateRetr,
@@ -1021,3 +1023,11 @@ func (t *Reader) GetDisperserAddress(ctx context.Context, disperserID uint32) (g
 func (t *Reader) GetRelayRegistryAddress() gethcommon.Address {
 	return t.bindings.RelayRegistryAddress
 }
+
+// GetPaymentVaultAddress returns the address of the PaymentVault contract, or the zero address if it is not deployed.
+func (t *Reader) GetPaymentVaultAddress() gethcommon.Address {
+	if t.bindings.PaymentVault == nil {
+		return gethcommon.Address{}
+	}
+	return t.bindings.PaymentVaultAddress
+}
@@ -129,7 +129,9 @@ func bitmapToBytesArray(bitmap *big.Int) []byte {
 	return bytesArray
 }
 
-func isZeroValuedReservation(reservation paymentvault.IPaymentVaultReservation) bool {
+// IsZeroValuedReservation returns true if the reservation is unset, i.e. the account has no reservation or its
+// reservation has been cleared.
+func IsZeroValuedReservation(reservation paymentvault.IPaymentVaultReservation) bool {
 	return reservation.SymbolsPerSecond == 0 &&
 		reservation.StartTimestamp == 0 &&
 		reservation.EndTimestamp == 0
@@ -138,7 +140,7 @@ func isZeroValuedReservation(reservation paymentvault.IPaymentVaultReservation)
 // ConvertToReservedPayments converts a upstream binding data structure to local definition.
 // Returns an error if the input reservation is zero-valued.
 func ConvertToReservedPayments(reservation paymentvault.IPaymentVaultReservation) (map[core.QuorumID]*core.ReservedPayment, error) {
-	if isZeroValuedReservation(reservation) {
+	if IsZeroValuedReservation(reservation) {
 		return nil, fmt.Errorf("reservation is not a valid active reservation")
 	}
 
@@ -0,0 +1,343 @@
+package meterer
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+	"github.com/Layr-Labs/eigenda/common/kvstore/tablestore"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	embeddedReservationTableName = "reservation_bins"
+	embeddedGlobalBinTableName   = "global_bins"
+	embeddedOnDemandTableName    = "on_demand_payments"
+
+	// binValueLength is the length of an encoded bin: the usage followed by the expiration time in unix nanoseconds.
+	binValueLength = 16
+)
+
+// EmbeddedMeteringStore implements the MeteringStore interface on top of an embedded kvstore.TableStore (LevelDB, or
+// an in-memory map). It is intended for single-instance dispersers and tests that should not depend on DynamoDB.
+//
+// The store provides the same atomicity guarantees as DynamoDBMeteringStore within a single process: each
+// multi-quorum bin update is written in one batch, and each read-modify-write is serialized by a lock. The data must
+// not be shared between processes.
+//
+// Reservation and global bins expire after the configured retention, using the TTL support of the table store. The
+// expiration time of a bin is fixed when it is first written, so that updates to a bin never move its expiration.
+type EmbeddedMeteringStore struct {
+	store  kvstore.TableStore
+	logger logging.Logger
+
+	reservationTable kvstore.KeyBuilder
+	globalBinTable   kvstore.KeyBuilder
+	onDemandTable    kvstore.KeyBuilder
+
+	// binRetention is how long reservation and global bins are kept after they are first written.
+	binRetention time.Duration
+
+	// lock serializes read-modify-write operations.
+	lock sync.Mutex
+}
+
+var _ MeteringStore = &EmbeddedMeteringStore{}
+
+// NewEmbeddedMeteringStore creates a metering store backed by a table store with the given configuration, e.g.
+// tablestore.DefaultLevelDBConfig(path) or tablestore.DefaultMapStoreConfig(). The store owns the tables in the
+// underlying database; tables not used by the metering store are dropped. Reservation and global bins are garbage
+// collected binRetention after they are first written, which must be longer than the reservation window.
+func NewEmbeddedMeteringStore(
+	config *tablestore.Config,
+	binRetention time.Duration,
+	logger logging.Logger,
+) (*EmbeddedMeteringStore, error) {
+	if config == nil {
+		return nil, errors.New("table store config is required")
+	}
+	if binRetention <= 0 {
+		return nil, fmt.Errorf("bin retention must be positive, got %v", binRetention)
+	}
+
+	storeConfig := *config
+	storeConfig.Schema = []string{embeddedReservationTableName, embeddedGlobalBinTableName, embeddedOnDemandTableName}
+	store, err := tablestore.Start(logger, &storeConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start table store: %w", err)
+	}
+
+	reservationTable, err := store.GetKeyBuilder(embeddedReservationTableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reservation table: %w", err)
+	}
+	globalBinTable, err := store.GetKeyBuilder(embeddedGlobalBinTableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get global bin table: %w", err)
+	}
+	onDemandTable, err := store.GetKeyBuilder(embeddedOnDemandTableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get on-demand table: %w", err)
+	}
+
+	return &EmbeddedMeteringStore{
+		store:            store,
+		logger:           logger,
+		reservationTable: reservationTable,
+		globalBinTable:   globalBinTable,
+		onDemandTable:    onDemandTable,
+		binRetention:     binRetention,
+	}, nil
+}
+
+// Shutdown flushes and closes the underlying store.
+func (s *EmbeddedMeteringStore) Shutdown() error {
+	return s.store.Shutdown()
+}
+
+// IncrementBinUsages atomically increments the bin usage for each quorum in quorumNumbers for a specific account and
+// reservation period, and returns the new usages.
+func (s *EmbeddedMeteringStore) IncrementBinUsages(ctx context.Context, accountID gethcommon.Address, quorumNumbers []core.QuorumID, reservationPeriods map[core.QuorumID]uint64, sizes map[core.QuorumID]uint64) (map[core.QuorumID]uint64, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	binUsages := make(map[core.QuorumID]uint64, len(quorumNumbers))
+	batch := s.store.NewTTLBatch()
+	for _, quorumNumber := range quorumNumbers {
+		key := s.reservationBinKey(accountID, reservationPeriods[quorumNumber], quorumNumber)
+		usage, expiration, err := s.getBin(key)
+		if err != nil {
+			return nil, err
+		}
+
+		newUsage := usage + sizes[quorumNumber]
+		if newUsage < usage {
+			return nil, fmt.Errorf("bin usage overflow for account %s quorum %d", accountID.Hex(), quorumNumber)
+		}
+		batch.PutWithExpiration(key, encodeBin(newUsage, expiration), expiration)
+		binUsages[quorumNumber] = newUsage
+	}
+
+	if err := batch.Apply(); err != nil {
+		return nil, fmt.Errorf("failed to update bin usages: %w", err)
+	}
+
+	return binUsages, nil
+}
+
+// DecrementBinUsages atomically decrements the bin usage for each quorum in quorumNumbers for a specific account and
+// reservation period. Usages do not go below zero.
+func (s *EmbeddedMeteringStore) DecrementBinUsages(ctx context.Context, accountID gethcommon.Address, quorumNumbers []core.QuorumID, reservationPeriods map[core.QuorumID]uint64, sizes map[core.QuorumID]uint64) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	batch := s.store.NewTTLBatch()
+	for _, quorumNumber := range quorumNumbers {
+		key := s.reservationBinKey(accountID, reservationPeriods[quorumNumber], quorumNumber)
+		usage, expiration, err := s.getBin(key)
+		if err != nil {
+			return err
+		}
+
+		newUsage := uint64(0)
+		if usage > sizes[quorumNumber] {
+			newUsage = usage - sizes[quorumNumber]
+		}
+		batch.PutWithExpiration(key, encodeBin(newUsage, expiration), expiration)
+	}
+
+	if err := batch.Apply(); err != nil {
+		return fmt.Errorf("failed to update bin usages: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateGlobalBin atomically increments the usage for a global bin and returns the new value.
+func (s *EmbeddedMeteringStore) UpdateGlobalBin(ctx context.Context, reservationPeriod uint64, size uint64) (uint64, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	key := s.globalBinTable.Key(binary.BigEndian.AppendUint64(nil, reservationPeriod))
+	usage, expiration, err := s.getBin(key)
+	if err != nil {
+		return 0, err
+	}
+
+	newUsage := usage + size
+	if newUsage < usage {
+		return 0, fmt.Errorf("global bin usage overflow for reservation period %d", reservationPeriod)
+	}
+	if err := s.store.PutWithExpiration(key, encodeBin(newUsage, expiration), expiration); err != nil {
+		return 0, fmt.Errorf("failed to update global bin: %w", err)
+	}
+
+	return newUsage, nil
+}
+
+// AddOnDemandPayment records a new cumulative payment for the account and returns the previous one. The payment is
+// only accepted if there is no previous payment, or if it exceeds the previous payment by at least paymentCharged.
+func (s *EmbeddedMeteringStore) AddOnDemandPayment(ctx context.Context, paymentMetadata core.PaymentMetadata, paymentCharged *big.Int) (*big.Int, error) {
+	paymentCheckpoint := big.NewInt(0).Sub(paymentMetadata.CumulativePayment, paymentCharged)
+	if paymentCheckpoint.Sign() < 0 {
+		return nil, fmt.Errorf("payment validation failed: payment charged is greater than cumulative payment")
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	key := s.onDemandTable.Key(paymentMetadata.AccountID.Bytes())
+	oldPayment, found, err := s.getOnDemandPayment(key)
+	if err != nil {
+		return nil, err
+	}
+	if found && oldPayment.Cmp(paymentCheckpoint) > 0 {
+		return nil, fmt.Errorf("insufficient cumulative payment increment: previous payment %s, new payment %s, charge %s",
+			oldPayment, paymentMetadata.CumulativePayment, paymentCharged)
+	}
+
+	if err := s.store.Put(key, paymentMetadata.CumulativePayment.Bytes()); err != nil {
+		return nil, fmt.Errorf("failed to add on-demand payment: %w", err)
+	}
+
+	return oldPayment, nil
+}
+
+// RollbackOnDemandPayment rolls back a payment to the previous value. The rollback only happens if the current value
+// matches newPayment (or if there is no current value); otherwise it is skipped without an error.
+func (s *EmbeddedMeteringStore) RollbackOnDemandPayment(ctx context.Context, accountID gethcommon.Address, newPayment, oldPayment *big.Int) error {
+	if oldPayment == nil {
+		oldPayment = big.NewInt(0)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	key := s.onDemandTable.Key(accountID.Bytes())
+	currentPayment, found, err := s.getOnDemandPayment(key)
+	if err != nil {
+		return err
+	}
+	if found && currentPayment.Cmp(newPayment) != 0 {
+		if s.logger != nil {
+			s.logger.Debug("Skipping rollback as current payment doesn't match the expected value",
+				"accountID", accountID.Hex(),
+				"expectedPayment", newPayment.String())
+		}
+		return nil
+	}
+
+	if err := s.store.Put(key, oldPayment.Bytes()); err != nil {
+		return fmt.Errorf("failed to rollback payment: %w", err)
+	}
+
+	if s.logger != nil {
+		s.logger.Debug("Successfully rolled back payment to previous value",
+			"accountID", accountID.Hex(),
+			"rolledBackFrom", newPayment.String(),
+			"rolledBackTo", oldPayment.String())
+	}
+
+	return nil
+}
+
+// GetPeriodRecords returns up to MinNumBins reservation bins of the account, starting at the given reservation
+// period. Bins are ordered by reservation period, then by quorum.
+func (s *EmbeddedMeteringStore) GetPeriodRecords(ctx context.Context, accountID gethcommon.Address, reservationPeriod uint64) ([MinNumBins]*pb.PeriodRecord, error) {
+	records := [MinNumBins]*pb.PeriodRecord{}
+
+	it, err := s.store.NewIterator(s.reservationTable.Key(accountID.Bytes()))
+	if err != nil {
+		return records, fmt.Errorf("failed to query bins for account: %w", err)
+	}
+	defer it.Release()
+
+	// The bins of an account are scanned from the start rather than with Seek, since not all table stores support
+	// seeking to a key that does not exist. Old bins are pruned, so each account only has a handful of them.
+	count := 0
+	for count < int(MinNumBins) && it.Next() {
+		key := it.Key()
+		if len(key) != gethcommon.AddressLength+9 || len(it.Value()) != binValueLength {
+			return [MinNumBins]*pb.PeriodRecord{}, fmt.Errorf("malformed reservation bin %x", key)
+		}
+		period := binary.BigEndian.Uint64(key[gethcommon.AddressLength:])
+		if period < reservationPeriod {
+			continue
+		}
+		usage, _ := decodeBin(it.Value())
+		records[count] = &pb.PeriodRecord{
+			Index: uint32(period),
+			Usage: usage,
+		}
+		count++
+	}
+	if err := it.Error(); err != nil {
+		return [MinNumBins]*pb.PeriodRecord{}, fmt.Errorf("failed to query bins for account: %w", err)
+	}
+
+	return records, nil
+}
+
+// GetLargestCumulativePayment returns the largest cumulative payment for the given account, or zero if none exists.
+func (s *EmbeddedMeteringStore) GetLargestCumulativePayment(ctx context.Context, accountID gethcommon.Address) (*big.Int, error) {
+	payment, _, err := s.getOnDemandPayment(s.onDemandTable.Key(accountID.Bytes()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment for account: %w", err)
+	}
+	return payment, nil
+}
+
+// reservationBinKey returns the key of a reservation bin. Keys are ordered by account, then by reservation period,
+// then by quorum, so that the bins of an account can be scanned in period order.
+func (s *EmbeddedMeteringStore) reservationBinKey(accountID gethcommon.Address, reservationPeriod uint64, quorumNumber core.QuorumID) kvstore.Key {
+	key := binary.BigEndian.AppendUint64(accountID.Bytes(), reservationPeriod)
+	return s.reservationTable.Key(append(key, quorumNumber))
+}
+
+// getBin returns the usage and expiration time of a bin. If the bin does not exist, the usage is zero and the
+// expiration time is binRetention from now.
+func (s *EmbeddedMeteringStore) getBin(key kvstore.Key) (uint64, time.Time, error) {
+	value, err := s.store.Get(key)
+	if errors.Is(err, kvstore.ErrNotFound) {
+		return 0, time.Now().Add(s.binRetention), nil
+	}
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("failed to get bin: %w", err)
+	}
+	if len(value) != binValueLength {
+		return 0, time.Time{}, fmt.Errorf("malformed bin of length %d", len(value))
+	}
+	usage, expiration := decodeBin(value)
+	return usage, expiration, nil
+}
+
+// getOnDemandPayment returns the cumulative payment stored at the key, and whether one was found.
+func (s *EmbeddedMeteringStore) getOnDemandPayment(key kvstore.Key) (*big.Int, bool, error) {
+	value, err := s.store.Get(key)
+	if errors.Is(err, kvstore.ErrNotFound) {
+		return big.NewInt(0), false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get on-demand payment: %w", err)
+	}
+	return new(big.Int).SetBytes(value), true, nil
+}
+
+func encodeBin(usage uint64, expiration time.Time) []byte {
+	value := binary.BigEndian.AppendUint64(make([]byte, 0, binValueLength), usage)
+	return binary.BigEndian.AppendUint64(value, uint64(expiration.UnixNano()))
+}
+
+func decodeBin(value []byte) (uint64, time.Time) {
+	usage := binary.BigEndian.Uint64(value[:8])
+	expiration := time.Unix(0, int64(binary.BigEndian.Uint64(value[8:16])))
+	return usage, expiration
+}
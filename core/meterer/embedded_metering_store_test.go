@@ -0,0 +1,172 @@
+package meterer_test
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/kvstore/tablestore"
+	"github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/meterer"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newEmbeddedMeteringStore(t *testing.T, config *tablestore.Config) *meterer.EmbeddedMeteringStore {
+	store, err := meterer.NewEmbeddedMeteringStore(config, time.Hour, testutils.GetLogger())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = store.Shutdown()
+	})
+	return store
+}
+
+// The behaviour shared with the DynamoDB store is covered by the tests in metering_store_test.go, which run against
+// both stores. The tests below cover the behaviour specific to the embedded store.
+
+func TestEmbeddedDecrementBinUsagesClampsAtZero(t *testing.T) {
+	ctx := context.Background()
+	store := newEmbeddedMeteringStore(t, tablestore.DefaultMapStoreConfig())
+	accountID := gethcommon.HexToAddress("0xabcdefabcdefabcdefabcdefabcdefabcdefabcd")
+	quorum := core.QuorumID(0)
+	periods := map[core.QuorumID]uint64{quorum: 42}
+
+	_, err := store.IncrementBinUsages(ctx, accountID, []core.QuorumID{quorum}, periods, map[core.QuorumID]uint64{quorum: 100})
+	require.NoError(t, err)
+	err = store.DecrementBinUsages(ctx, accountID, []core.QuorumID{quorum}, periods, map[core.QuorumID]uint64{quorum: 300})
+	require.NoError(t, err)
+	binUsages, err := store.IncrementBinUsages(ctx, accountID, []core.QuorumID{quorum}, periods, map[core.QuorumID]uint64{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), binUsages[quorum])
+}
+
+func TestEmbeddedConcurrentIncrementBinUsages(t *testing.T) {
+	ctx := context.Background()
+	store := newEmbeddedMeteringStore(t, tablestore.DefaultMapStoreConfig())
+	accountID := gethcommon.HexToAddress("0xabcdefabcdefabcdefabcdefabcdefabcdefabcd")
+	quorums := []core.QuorumID{0, 1}
+	periods := map[core.QuorumID]uint64{0: 42, 1: 42}
+	sizes := map[core.QuorumID]uint64{0: 1, 1: 2}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := store.IncrementBinUsages(ctx, accountID, quorums, periods, sizes)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	binUsages, err := store.IncrementBinUsages(ctx, accountID, quorums, periods, map[core.QuorumID]uint64{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), binUsages[0])
+	assert.Equal(t, uint64(200), binUsages[1])
+}
+
+func TestEmbeddedGetPeriodRecords(t *testing.T) {
+	ctx := context.Background()
+	store := newEmbeddedMeteringStore(t, tablestore.DefaultMapStoreConfig())
+	accountID := gethcommon.HexToAddress("0x1234567890123456789012345678901234567890")
+	otherAccountID := gethcommon.HexToAddress("0x1234567890123456789012345678901234567891")
+	quorum := core.QuorumID(0)
+
+	for _, period := range []uint64{9, 10, 11, 12, 13} {
+		_, err := store.IncrementBinUsages(ctx, accountID, []core.QuorumID{quorum},
+			map[core.QuorumID]uint64{quorum: period}, map[core.QuorumID]uint64{quorum: period * 10})
+		require.NoError(t, err)
+	}
+	_, err := store.IncrementBinUsages(ctx, otherAccountID, []core.QuorumID{quorum},
+		map[core.QuorumID]uint64{quorum: 10}, map[core.QuorumID]uint64{quorum: 1})
+	require.NoError(t, err)
+
+	records, err := store.GetPeriodRecords(ctx, accountID, 10)
+	require.NoError(t, err)
+	for i, record := range records {
+		require.NotNil(t, record)
+		assert.Equal(t, uint32(10+i), record.Index)
+		assert.Equal(t, uint64(10+i)*10, record.Usage)
+	}
+
+	// Fewer records than MinNumBins, and no records from other accounts.
+	records, err = store.GetPeriodRecords(ctx, accountID, 13)
+	require.NoError(t, err)
+	require.NotNil(t, records[0])
+	assert.Equal(t, uint32(13), records[0].Index)
+	assert.Nil(t, records[1])
+	assert.Nil(t, records[2])
+}
+
+func TestEmbeddedMeteringStorePersistence(t *testing.T) {
+	ctx := context.Background()
+	path := t.TempDir()
+	accountID := gethcommon.HexToAddress("0x1234567890123456789012345678901234567890")
+	quorum := core.QuorumID(0)
+	periods := map[core.QuorumID]uint64{quorum: 42}
+	sizes := map[core.QuorumID]uint64{quorum: 100}
+
+	store, err := meterer.NewEmbeddedMeteringStore(tablestore.DefaultLevelDBConfig(path), time.Hour, testutils.GetLogger())
+	require.NoError(t, err)
+	_, err = store.IncrementBinUsages(ctx, accountID, []core.QuorumID{quorum}, periods, sizes)
+	require.NoError(t, err)
+	_, err = store.AddOnDemandPayment(ctx, core.PaymentMetadata{AccountID: accountID, CumulativePayment: big.NewInt(100)}, big.NewInt(100))
+	require.NoError(t, err)
+	require.NoError(t, store.Shutdown())
+
+	store = newEmbeddedMeteringStore(t, tablestore.DefaultLevelDBConfig(path))
+	binUsages, err := store.IncrementBinUsages(ctx, accountID, []core.QuorumID{quorum}, periods, sizes)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(200), binUsages[quorum])
+	largest, err := store.GetLargestCumulativePayment(ctx, accountID)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(100), largest)
+}
+
+func TestEmbeddedMeteringStoreBinExpiration(t *testing.T) {
+	ctx := context.Background()
+	config := tablestore.DefaultMapStoreConfig()
+	config.GarbageCollectionInterval = 10 * time.Millisecond
+	store, err := meterer.NewEmbeddedMeteringStore(config, 100*time.Millisecond, testutils.GetLogger())
+	require.NoError(t, err)
+	defer func() {
+		_ = store.Shutdown()
+	}()
+
+	accountID := gethcommon.HexToAddress("0x1234567890123456789012345678901234567890")
+	quorum := core.QuorumID(0)
+	_, err = store.IncrementBinUsages(ctx, accountID, []core.QuorumID{quorum},
+		map[core.QuorumID]uint64{quorum: 1}, map[core.QuorumID]uint64{quorum: 100})
+	require.NoError(t, err)
+	_, err = store.UpdateGlobalBin(ctx, 1, 100)
+	require.NoError(t, err)
+	_, err = store.AddOnDemandPayment(ctx, core.PaymentMetadata{AccountID: accountID, CumulativePayment: big.NewInt(100)}, big.NewInt(100))
+	require.NoError(t, err)
+
+	// Updating a bin does not extend its lifetime.
+	time.Sleep(50 * time.Millisecond)
+	_, err = store.IncrementBinUsages(ctx, accountID, []core.QuorumID{quorum},
+		map[core.QuorumID]uint64{quorum: 1}, map[core.QuorumID]uint64{quorum: 100})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		records, err := store.GetPeriodRecords(ctx, accountID, 0)
+		require.NoError(t, err)
+		return records[0] == nil
+	}, time.Second, 10*time.Millisecond)
+
+	require.Eventually(t, func() bool {
+		binUsage, err := store.UpdateGlobalBin(ctx, 1, 0)
+		require.NoError(t, err)
+		return binUsage == 0
+	}, time.Second, 10*time.Millisecond)
+
+	// On-demand payments never expire.
+	largest, err := store.GetLargestCumulativePayment(ctx, accountID)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(100), largest)
+}
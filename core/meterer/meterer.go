@@ -295,7 +295,11 @@ func PaymentCharged(numSymbols, pricePerSymbol uint64) *big.Int {
 // SymbolsCharged returns the number of symbols charged for a given data length
 // being at least MinNumSymbols or the nearest rounded-up multiple of MinNumSymbols.
 func (m *Meterer) SymbolsCharged(numSymbols uint64) uint64 {
-	minSymbols := uint64(m.ChainPaymentState.GetMinNumSymbols())
+	return SymbolsCharged(numSymbols, m.ChainPaymentState.GetMinNumSymbols())
+}
+
+// SymbolsCharged returns the number of symbols charged for a given data length with the given MinNumSymbols.
+func SymbolsCharged(numSymbols uint64, minSymbols uint64) uint64 {
 	if numSymbols <= minSymbols {
 		return minSymbols
 	}
@@ -0,0 +1,445 @@
+package meterer_test
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"testing"
+	"time"
+
+	commondynamodb "github.com/Layr-Labs/eigenda/common/aws/dynamodb"
+	"github.com/Layr-Labs/eigenda/common/kvstore/tablestore"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/meterer"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type meteringStoreTestContext struct {
+	ctx   context.Context
+	store meterer.MeteringStore
+	// backend is the name of the store implementation under test
+	backend string
+}
+
+// meteringStoreBackends are the MeteringStore implementations that the shared tests in this file run against. Each
+// setup function returns a new, empty store that is cleaned up after the test. Tests specific to one backend live in
+// that backend's own test file.
+var meteringStoreBackends = []struct {
+	name  string
+	setup func(t *testing.T) *meteringStoreTestContext
+}{
+	{name: "dynamodb", setup: setupDynamoDBMeteringStore},
+	{name: "embedded", setup: setupEmbeddedMeteringStore},
+}
+
+// forEachMeteringStore runs the test as a subtest against a new store of every backend
+func forEachMeteringStore(t *testing.T, test func(t *testing.T, tc *meteringStoreTestContext)) {
+	for _, backend := range meteringStoreBackends {
+		t.Run(backend.name, func(t *testing.T) {
+			test(t, backend.setup(t))
+		})
+	}
+}
+
+// setupDynamoDBMeteringStore creates a test context with tables created and cleaned up after the test
+func setupDynamoDBMeteringStore(t *testing.T) *meteringStoreTestContext {
+	ctx := context.Background()
+	reservationTable := fmt.Sprintf("reservation_test_%d", rand.Int())
+	onDemandTable := fmt.Sprintf("ondemand_test_%d", rand.Int())
+	globalBinTable := fmt.Sprintf("global_bin_test_%d", rand.Int())
+
+	// Create the tables
+	err := meterer.CreateReservationTable(clientConfig, reservationTable)
+	require.NoError(t, err)
+
+	err = meterer.CreateOnDemandTable(clientConfig, onDemandTable)
+	require.NoError(t, err)
+
+	err = meterer.CreateGlobalReservationTable(clientConfig, globalBinTable)
+	require.NoError(t, err)
+
+	// Register cleanup to remove tables after test completes
+	t.Cleanup(func() {
+		_ = dynamoClient.DeleteTable(ctx, reservationTable)
+		_ = dynamoClient.DeleteTable(ctx, onDemandTable)
+		_ = dynamoClient.DeleteTable(ctx, globalBinTable)
+	})
+
+	store, err := meterer.NewDynamoDBMeteringStore(
+		clientConfig,
+		reservationTable,
+		onDemandTable,
+		globalBinTable,
+		nil, // Logger not needed for test
+	)
+	require.NoError(t, err)
+
+	return &meteringStoreTestContext{ctx: ctx, store: store, backend: "dynamodb"}
+}
+
+// setupEmbeddedMeteringStore creates a test context with an in-memory embedded store
+func setupEmbeddedMeteringStore(t *testing.T) *meteringStoreTestContext {
+	return &meteringStoreTestContext{
+		ctx:     context.Background(),
+		store:   newEmbeddedMeteringStore(t, tablestore.DefaultMapStoreConfig()),
+		backend: "embedded",
+	}
+}
+
+// TestMeteringStoreIncrementBinUsages tests the IncrementBinUsages function with edge cases
+func TestMeteringStoreIncrementBinUsages(t *testing.T) {
+	forEachMeteringStore(t, func(t *testing.T, tc *meteringStoreTestContext) {
+		accountID := gethcommon.HexToAddress("0xabcdefabcdefabcdefabcdefabcdefabcdefabcd")
+
+		t.Run("empty input", func(t *testing.T) {
+			binUsages, errs := tc.store.IncrementBinUsages(tc.ctx, accountID, []core.QuorumID{}, map[core.QuorumID]uint64{}, map[core.QuorumID]uint64{})
+			assert.Empty(t, binUsages)
+			assert.Empty(t, errs)
+		})
+
+		t.Run("existing bin (increment)", func(t *testing.T) {
+			reservationPeriod := uint64(42)
+			size := uint64(100)
+			quorum := core.QuorumID(1)
+			periods := map[core.QuorumID]uint64{quorum: reservationPeriod}
+			// First increment
+			_, _ = tc.store.IncrementBinUsages(tc.ctx, accountID, []core.QuorumID{quorum}, periods, map[core.QuorumID]uint64{quorum: size})
+			// Second increment
+			binUsages, err := tc.store.IncrementBinUsages(tc.ctx, accountID, []core.QuorumID{quorum}, periods, map[core.QuorumID]uint64{quorum: size})
+			assert.NoError(t, err)
+			assert.Equal(t, size*2, binUsages[quorum])
+		})
+
+		t.Run("nonexistent bin (first write)", func(t *testing.T) {
+			size := uint64(100)
+			quorums := []core.QuorumID{10, 11}
+			periods := map[core.QuorumID]uint64{10: 42, 11: 42}
+			binUsages, err := tc.store.IncrementBinUsages(tc.ctx, accountID, quorums, periods, map[core.QuorumID]uint64{10: size, 11: size})
+			require.NoError(t, err)
+			for _, quorum := range quorums {
+				assert.Equal(t, size, binUsages[quorum])
+			}
+		})
+
+		t.Run("decrement", func(t *testing.T) {
+			quorums := []core.QuorumID{20, 21}
+			periods := map[core.QuorumID]uint64{20: 42, 21: 43}
+			_, err := tc.store.IncrementBinUsages(tc.ctx, accountID, quorums, periods, map[core.QuorumID]uint64{20: 100, 21: 100})
+			require.NoError(t, err)
+
+			err = tc.store.DecrementBinUsages(tc.ctx, accountID, quorums, periods, map[core.QuorumID]uint64{20: 30, 21: 100})
+			require.NoError(t, err)
+			binUsages, err := tc.store.IncrementBinUsages(tc.ctx, accountID, quorums, periods, map[core.QuorumID]uint64{})
+			require.NoError(t, err)
+			assert.Equal(t, uint64(70), binUsages[20])
+			assert.Equal(t, uint64(0), binUsages[21])
+		})
+
+		t.Run("exceed transaction limit", func(t *testing.T) {
+			if tc.backend != "dynamodb" {
+				t.Skip("only DynamoDB limits the number of items written in a transaction")
+			}
+			reservationPeriod := uint64(42)
+			sizes := make(map[core.QuorumID]uint64)
+			var quorums []core.QuorumID
+			periods := make(map[core.QuorumID]uint64)
+			for i := 0; i < commondynamodb.DynamoBatchWriteLimit+1; i++ { // 26 > DynamoDB batch limit
+				quorums = append(quorums, core.QuorumID(i))
+				periods[core.QuorumID(i)] = reservationPeriod
+				sizes[core.QuorumID(i)] = uint64(i)
+			}
+			binUsages, err := tc.store.IncrementBinUsages(tc.ctx, accountID, quorums, periods, sizes)
+			assert.Empty(t, binUsages)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), fmt.Sprintf("limit is %d", commondynamodb.DynamoBatchWriteLimit))
+		})
+	})
+}
+
+// TestMeteringStoreUpdateGlobalBin tests the UpdateGlobalBin function
+func TestMeteringStoreUpdateGlobalBin(t *testing.T) {
+	forEachMeteringStore(t, func(t *testing.T, tc *meteringStoreTestContext) {
+		// Test updating global bin that doesn't exist yet (should create it)
+		reservationPeriod := uint64(1)
+		size := uint64(2000)
+
+		binUsage, err := tc.store.UpdateGlobalBin(tc.ctx, reservationPeriod, size)
+		require.NoError(t, err)
+		assert.Equal(t, size, binUsage)
+
+		// Test updating existing bin
+		additionalSize := uint64(1000)
+		binUsage, err = tc.store.UpdateGlobalBin(tc.ctx, reservationPeriod, additionalSize)
+		require.NoError(t, err)
+		assert.Equal(t, size+additionalSize, binUsage)
+
+		// Verify the bin was persisted
+		binUsage, err = tc.store.UpdateGlobalBin(tc.ctx, reservationPeriod, 0)
+		require.NoError(t, err)
+		assert.Equal(t, size+additionalSize, binUsage)
+
+		// Other periods are independent
+		binUsage, err = tc.store.UpdateGlobalBin(tc.ctx, reservationPeriod+1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(10), binUsage)
+	})
+}
+
+// TestMeteringStoreAddOnDemandPayment tests the AddOnDemandPayment function
+func TestMeteringStoreAddOnDemandPayment(t *testing.T) {
+	forEachMeteringStore(t, func(t *testing.T, tc *meteringStoreTestContext) {
+		accountID := gethcommon.HexToAddress("0x1234567890123456789012345678901234567890")
+		payment1 := core.PaymentMetadata{
+			AccountID:         accountID,
+			Timestamp:         time.Now().Unix(),
+			CumulativePayment: big.NewInt(100),
+		}
+		charge1 := big.NewInt(100)
+
+		// Add the payment
+		oldPayment, err := tc.store.AddOnDemandPayment(tc.ctx, payment1, charge1)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(0), oldPayment, "Old payment should be 0 for first payment")
+
+		// Verify the payment was added
+		largest, err := tc.store.GetLargestCumulativePayment(tc.ctx, accountID)
+		require.NoError(t, err)
+		assert.Equal(t, payment1.CumulativePayment, largest)
+
+		// Test case: Add a larger payment with sufficient increment
+		payment2 := core.PaymentMetadata{
+			AccountID:         accountID,
+			Timestamp:         time.Now().Unix(),
+			CumulativePayment: big.NewInt(200),
+		}
+		charge2 := big.NewInt(100) // The same charge is fine because 200-100=100 >= 100
+
+		oldPayment, err = tc.store.AddOnDemandPayment(tc.ctx, payment2, charge2)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(100), oldPayment, "Old payment should be 100")
+
+		// Verify the payment was updated
+		largest, err = tc.store.GetLargestCumulativePayment(tc.ctx, accountID)
+		require.NoError(t, err)
+		assert.Equal(t, payment2.CumulativePayment, largest)
+
+		// Test case: Add a larger payment but with insufficient increment
+		payment3 := core.PaymentMetadata{
+			AccountID:         accountID,
+			Timestamp:         time.Now().Unix(),
+			CumulativePayment: big.NewInt(250), // Only 50 more than previous 200
+		}
+		charge3 := big.NewInt(100) // But we need a minimum increment of 100
+
+		oldPayment, err = tc.store.AddOnDemandPayment(tc.ctx, payment3, charge3)
+		require.Error(t, err) // Should fail due to insufficient increment
+		assert.Contains(t, err.Error(), "insufficient cumulative payment increment")
+		require.Nil(t, oldPayment, "Old payment should be nil on error")
+
+		// Verify the payment wasn't updated
+		largest, err = tc.store.GetLargestCumulativePayment(tc.ctx, accountID)
+		require.NoError(t, err)
+		assert.Equal(t, payment2.CumulativePayment, largest, "Payment should not have been updated")
+
+		// Test case: Add a smaller payment (should fail)
+		payment4 := core.PaymentMetadata{
+			AccountID:         accountID,
+			Timestamp:         time.Now().Unix(),
+			CumulativePayment: big.NewInt(150),
+		}
+		charge4 := big.NewInt(50)
+
+		oldPayment, err = tc.store.AddOnDemandPayment(tc.ctx, payment4, charge4)
+		require.Error(t, err) // Should fail since payment is smaller than current
+		assert.Contains(t, err.Error(), "insufficient cumulative payment increment")
+		require.Nil(t, oldPayment, "Old payment should be nil on error")
+
+		// Test case: Charge larger than the cumulative payment (should fail)
+		payment5 := core.PaymentMetadata{
+			AccountID:         accountID,
+			Timestamp:         time.Now().Unix(),
+			CumulativePayment: big.NewInt(50),
+		}
+		oldPayment, err = tc.store.AddOnDemandPayment(tc.ctx, payment5, big.NewInt(100))
+		require.ErrorContains(t, err, "payment validation failed")
+		require.Nil(t, oldPayment, "Old payment should be nil on error")
+
+		// Verify the payment wasn't updated
+		largest, err = tc.store.GetLargestCumulativePayment(tc.ctx, accountID)
+		require.NoError(t, err)
+		assert.Equal(t, payment2.CumulativePayment, largest, "Payment should not have been updated")
+	})
+}
+
+// TestMeteringStoreRollbackOnDemandPayment tests the RollbackOnDemandPayment function
+func TestMeteringStoreRollbackOnDemandPayment(t *testing.T) {
+	forEachMeteringStore(t, func(t *testing.T, tc *meteringStoreTestContext) {
+		// Create and add a payment
+		accountID := gethcommon.HexToAddress("0x1234567890123456789012345678901234567890")
+		cumulativePayment := big.NewInt(1000)
+		paymentCharged := big.NewInt(500)
+
+		paymentMetadata := core.PaymentMetadata{
+			AccountID:         accountID,
+			Timestamp:         time.Now().Unix(),
+			CumulativePayment: cumulativePayment,
+		}
+
+		oldPayment, err := tc.store.AddOnDemandPayment(tc.ctx, paymentMetadata, paymentCharged)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(0), oldPayment, "Old payment should be 0 for first payment")
+
+		// Add another payment
+		newCumulativePayment := big.NewInt(2000)
+		newPaymentMetadata := core.PaymentMetadata{
+			AccountID:         accountID,
+			Timestamp:         time.Now().Unix(),
+			CumulativePayment: newCumulativePayment,
+		}
+		newPaymentCharged := big.NewInt(1000)
+
+		oldPayment, err = tc.store.AddOnDemandPayment(tc.ctx, newPaymentMetadata, newPaymentCharged)
+		require.NoError(t, err)
+		require.Equal(t, cumulativePayment, oldPayment, "Old payment should be 1000 for second payment")
+
+		// Test case 1: Rollback to previous payment
+		err = tc.store.RollbackOnDemandPayment(tc.ctx, accountID, newCumulativePayment, oldPayment)
+		require.NoError(t, err)
+
+		largest, err := tc.store.GetLargestCumulativePayment(tc.ctx, accountID)
+		require.NoError(t, err)
+		assert.Equal(t, oldPayment, largest, "Payment should be rolled back to 1000")
+
+		// Test case 2: Rollback to a different value directly
+		err = tc.store.RollbackOnDemandPayment(tc.ctx, accountID, big.NewInt(1000), big.NewInt(500))
+		require.NoError(t, err)
+
+		largest, err = tc.store.GetLargestCumulativePayment(tc.ctx, accountID)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(500), largest, "Payment should be set to 500")
+
+		// Test case 3: Trying to rollback non-matching payment should not cause an error, and is skipped
+		err = tc.store.RollbackOnDemandPayment(tc.ctx, accountID, big.NewInt(9999), big.NewInt(100))
+		require.NoError(t, err)
+
+		largest, err = tc.store.GetLargestCumulativePayment(tc.ctx, accountID)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(500), largest, "Payment should not have been rolled back")
+
+		// Test case 4: Rollback to zero
+		err = tc.store.RollbackOnDemandPayment(tc.ctx, accountID, big.NewInt(500), nil)
+		require.NoError(t, err)
+
+		// payment is set back to 0
+		largest, err = tc.store.GetLargestCumulativePayment(tc.ctx, accountID)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(0), largest, "Payment should be set to 0")
+
+		// Test case 5: The next payment only needs to cover its own charge
+		oldPayment, err = tc.store.AddOnDemandPayment(tc.ctx, core.PaymentMetadata{
+			AccountID:         accountID,
+			Timestamp:         time.Now().Unix(),
+			CumulativePayment: big.NewInt(300),
+		}, big.NewInt(300))
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(0), oldPayment)
+	})
+}
+
+// TestMeteringStoreGetLargestCumulativePayment tests the GetLargestCumulativePayment function
+func TestMeteringStoreGetLargestCumulativePayment(t *testing.T) {
+	forEachMeteringStore(t, func(t *testing.T, tc *meteringStoreTestContext) {
+		// Create an account to test with
+		accountID := gethcommon.HexToAddress("0x1234567890123456789012345678901234567890")
+
+		// Test case 1: No payment exists yet
+		largest, err := tc.store.GetLargestCumulativePayment(tc.ctx, accountID)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(0), largest, "Initial largest payment should be 0")
+
+		// Test case 2: Add first payment of 100 with charge of 100
+		payment1 := core.PaymentMetadata{
+			AccountID:         accountID,
+			Timestamp:         time.Now().Unix(),
+			CumulativePayment: big.NewInt(100),
+		}
+		oldPayment, err := tc.store.AddOnDemandPayment(tc.ctx, payment1, big.NewInt(100))
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(0), oldPayment, "Old payment should be 0 for first payment")
+
+		largest, err = tc.store.GetLargestCumulativePayment(tc.ctx, accountID)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(100), largest, "Largest payment should be 100")
+
+		// Test case 3: Add second payment of 300 with charge of 200 (cumulative)
+		payment2 := core.PaymentMetadata{
+			AccountID:         accountID,
+			Timestamp:         time.Now().Unix(),
+			CumulativePayment: big.NewInt(300),
+		}
+		oldPayment, err = tc.store.AddOnDemandPayment(tc.ctx, payment2, big.NewInt(200))
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(100), oldPayment, "Old payment should be 100")
+
+		largest, err = tc.store.GetLargestCumulativePayment(tc.ctx, accountID)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(300), largest, "Largest payment should be 300")
+
+		// Test case 4: Try to add payment of 200 with charge of 100 - should fail since cumulative is less than previous
+		payment3 := core.PaymentMetadata{
+			AccountID:         accountID,
+			Timestamp:         time.Now().Unix(),
+			CumulativePayment: big.NewInt(200),
+		}
+		oldPayment, err = tc.store.AddOnDemandPayment(tc.ctx, payment3, big.NewInt(100))
+		require.Error(t, err)
+		require.Nil(t, oldPayment, "Old payment should be nil on error")
+
+		largest, err = tc.store.GetLargestCumulativePayment(tc.ctx, accountID)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(300), largest, "Largest payment should still be 300")
+
+		// Test case 5: Add payment of 500 with insufficient charge (250) - should fail
+		payment4 := core.PaymentMetadata{
+			AccountID:         accountID,
+			Timestamp:         time.Now().Unix(),
+			CumulativePayment: big.NewInt(500),
+		}
+		oldPayment, err = tc.store.AddOnDemandPayment(tc.ctx, payment4, big.NewInt(250))
+		require.Error(t, err)
+		require.Nil(t, oldPayment, "Old payment should be nil on error")
+
+		largest, err = tc.store.GetLargestCumulativePayment(tc.ctx, accountID)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(300), largest, "Largest payment should still be 300")
+
+		// Test case 6: Add valid payment of 500 with sufficient charge (200)
+		payment5 := core.PaymentMetadata{
+			AccountID:         accountID,
+			Timestamp:         time.Now().Unix(),
+			CumulativePayment: big.NewInt(500),
+		}
+		oldPayment, err = tc.store.AddOnDemandPayment(tc.ctx, payment5, big.NewInt(200))
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(300), oldPayment, "Old payment should be 300")
+
+		largest, err = tc.store.GetLargestCumulativePayment(tc.ctx, accountID)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(500), largest, "Largest payment should be 500")
+
+		// Test case 7: Roll back the payment
+		err = tc.store.RollbackOnDemandPayment(tc.ctx, accountID, big.NewInt(500), big.NewInt(300))
+		require.NoError(t, err)
+
+		largest, err = tc.store.GetLargestCumulativePayment(tc.ctx, accountID)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(300), largest, "After rollback, largest payment should be 300")
+
+		// Test case 8: Verify rolling back a non-existent payment has no effect
+		err = tc.store.RollbackOnDemandPayment(tc.ctx, accountID, big.NewInt(9999), big.NewInt(500))
+		require.NoError(t, err)
+	})
+}
@@ -186,6 +186,47 @@ func (pcs *OnchainPaymentState) refreshOnDemandPayments(ctx context.Context) err
 	return nil
 }
 
+// setReservedPayments replaces the cached reservations of an account. A nil map removes the account from the cache.
+func (pcs *OnchainPaymentState) setReservedPayments(accountID gethcommon.Address, reservations map[core.QuorumID]*core.ReservedPayment) {
+	pcs.ReservationsLock.Lock()
+	defer pcs.ReservationsLock.Unlock()
+
+	if reservations == nil {
+		delete(pcs.ReservedPayments, accountID)
+		return
+	}
+	pcs.ReservedPayments[accountID] = reservations
+}
+
+// setOnDemandPayment replaces the cached on-demand payment of an account. A zero deposit removes the account from the
+// cache, consistent with how deposits are read from the chain.
+func (pcs *OnchainPaymentState) setOnDemandPayment(accountID gethcommon.Address, payment *core.OnDemandPayment) {
+	pcs.OnDemandLocks.Lock()
+	defer pcs.OnDemandLocks.Unlock()
+
+	if payment == nil || payment.CumulativePayment == nil || payment.CumulativePayment.Sign() == 0 {
+		delete(pcs.OnDemandPayments, accountID)
+		return
+	}
+	pcs.OnDemandPayments[accountID] = payment
+}
+
+// updatePaymentVaultParams atomically replaces the payment vault parameters with a modified copy. It does nothing
+// if the parameters have not been loaded yet.
+func (pcs *OnchainPaymentState) updatePaymentVaultParams(update func(params *PaymentVaultParams)) {
+	for {
+		current := pcs.PaymentVaultParams.Load()
+		if current == nil {
+			return
+		}
+		updated := *current
+		update(&updated)
+		if pcs.PaymentVaultParams.CompareAndSwap(current, &updated) {
+			return
+		}
+	}
+}
+
 // GetReservedPaymentByAccountAndQuorums returns a pointer to the active reservation for the given account ID; no writes will be made to the reservation
 func (pcs *OnchainPaymentState) GetReservedPaymentByAccountAndQuorums(ctx context.Context, accountID gethcommon.Address, quorumNumbers []core.QuorumID) (map[core.QuorumID]*core.ReservedPayment, error) {
 	pcs.ReservationsLock.RLock()
@@ -0,0 +1,290 @@
+package meterer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	paymentvault "github.com/Layr-Labs/eigenda/contracts/bindings/PaymentVault"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/eth"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// PaymentVaultLogReader is the subset of an Ethereum client needed to follow PaymentVault events.
+type PaymentVaultLogReader interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+}
+
+// PaymentVaultSyncerConfig configures a PaymentVaultSyncer.
+type PaymentVaultSyncerConfig struct {
+	// ConfirmationDepth is the number of blocks that must be built on top of a block before the PaymentVault events
+	// in it are applied. Reorgs shallower than this depth never reach the cached state; deeper reorgs are corrected
+	// by the next full refresh of the on-chain state.
+	ConfirmationDepth uint64
+
+	// PollInterval is the interval at which new PaymentVault events are fetched.
+	PollInterval time.Duration
+
+	// MaxBlockRange is the maximum number of blocks covered by a single log query. Defaults to 10,000.
+	MaxBlockRange uint64
+
+	// ChainReadTimeout is the timeout for each periodic sync against the chain. Defaults to 10 seconds.
+	ChainReadTimeout time.Duration
+}
+
+const (
+	defaultPaymentVaultSyncerMaxBlockRange    = 10_000
+	defaultPaymentVaultSyncerChainReadTimeout = 10 * time.Second
+)
+
+// PaymentVaultSyncer keeps an OnchainPaymentState up to date by applying PaymentVault events (reservation updates,
+// on-demand deposits, and global parameter changes) as they are confirmed on chain. Only the accounts and parameters
+// touched by an event are updated, so the cost of staying current does not grow with the number of cached accounts.
+//
+// All PaymentVault events carry the new absolute value of whatever they update, so applying an event that is older
+// than the state it is applied to only causes a temporary regression that is undone by the newer events. The periodic
+// full refresh of OnchainPaymentState should still be kept as a consistency check.
+type PaymentVaultSyncer struct {
+	config   PaymentVaultSyncerConfig
+	state    *OnchainPaymentState
+	client   PaymentVaultLogReader
+	address  gethcommon.Address
+	filterer *paymentvault.ContractPaymentVaultFilterer
+	logger   logging.Logger
+
+	// eventIDs maps the topic of each handled event to its name.
+	eventIDs map[gethcommon.Hash]string
+
+	// lock serializes calls to Sync.
+	lock sync.Mutex
+	// lastSyncedBlock is the last block whose events have been applied.
+	lastSyncedBlock uint64
+}
+
+// NewPaymentVaultSyncer creates a PaymentVaultSyncer for the PaymentVault contract at the given address. Events in
+// blocks after the current head are applied to the state, so the state should be loaded from the chain after the
+// syncer has been created.
+func NewPaymentVaultSyncer(
+	ctx context.Context,
+	config PaymentVaultSyncerConfig,
+	state *OnchainPaymentState,
+	client PaymentVaultLogReader,
+	paymentVaultAddress gethcommon.Address,
+	logger logging.Logger,
+) (*PaymentVaultSyncer, error) {
+
+	if state == nil {
+		return nil, errors.New("onchain payment state is required")
+	}
+	if config.PollInterval <= 0 {
+		return nil, fmt.Errorf("poll interval must be positive, got %v", config.PollInterval)
+	}
+	if config.MaxBlockRange == 0 {
+		config.MaxBlockRange = defaultPaymentVaultSyncerMaxBlockRange
+	}
+	if config.ChainReadTimeout == 0 {
+		config.ChainReadTimeout = defaultPaymentVaultSyncerChainReadTimeout
+	}
+
+	// The filterer is only used to parse logs, so it does not need a backend.
+	filterer, err := paymentvault.NewContractPaymentVaultFilterer(paymentVaultAddress, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create PaymentVault filterer: %w", err)
+	}
+
+	contractAbi, err := paymentvault.ContractPaymentVaultMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse PaymentVault ABI: %w", err)
+	}
+	eventIDs := make(map[gethcommon.Hash]string)
+	for _, name := range []string{
+		"ReservationUpdated",
+		"OnDemandPaymentUpdated",
+		"GlobalSymbolsPerPeriodUpdated",
+		"GlobalRatePeriodIntervalUpdated",
+		"ReservationPeriodIntervalUpdated",
+		"PriceParamsUpdated",
+	} {
+		event, ok := contractAbi.Events[name]
+		if !ok {
+			return nil, fmt.Errorf("PaymentVault ABI has no event %s", name)
+		}
+		eventIDs[event.ID] = name
+	}
+
+	head, err := client.BlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current block number: %w", err)
+	}
+
+	return &PaymentVaultSyncer{
+		config:          config,
+		state:           state,
+		client:          client,
+		address:         paymentVaultAddress,
+		filterer:        filterer,
+		logger:          logger.With("component", "PaymentVaultSyncer"),
+		eventIDs:        eventIDs,
+		lastSyncedBlock: head,
+	}, nil
+}
+
+// Start periodically applies new PaymentVault events until the context is cancelled.
+func (s *PaymentVaultSyncer) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.config.PollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				syncCtx, cancel := context.WithTimeout(ctx, s.config.ChainReadTimeout)
+				if err := s.Sync(syncCtx); err != nil {
+					s.logger.Error("Failed to sync PaymentVault events", "error", err)
+				}
+				cancel()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Sync applies the events in all blocks that have reached the confirmation depth since the last sync. If an event
+// can't be applied, the events from its block onwards are left for the next call to Sync, and an error is returned.
+func (s *PaymentVaultSyncer) Sync(ctx context.Context) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	head, err := s.client.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current block number: %w", err)
+	}
+	if head < s.config.ConfirmationDepth {
+		return nil
+	}
+	confirmedBlock := head - s.config.ConfirmationDepth
+
+	for s.lastSyncedBlock < confirmedBlock {
+		fromBlock := s.lastSyncedBlock + 1
+		toBlock := min(confirmedBlock, fromBlock+s.config.MaxBlockRange-1)
+
+		logs, err := s.client.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(fromBlock),
+			ToBlock:   new(big.Int).SetUint64(toBlock),
+			Addresses: []gethcommon.Address{s.address},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to filter PaymentVault logs in blocks [%d, %d]: %w", fromBlock, toBlock, err)
+		}
+
+		for _, log := range logs {
+			if log.Removed {
+				continue
+			}
+			if err := s.applyLog(log); err != nil {
+				// The block of the event is synced again by the next call, so that the event is retried. The events
+				// of the block that were already applied are applied again, which is harmless since each event
+				// carries the new absolute value of what it updates.
+				s.lastSyncedBlock = log.BlockNumber - 1
+				return fmt.Errorf("failed to apply PaymentVault event in block %d (tx %s): %w",
+					log.BlockNumber, log.TxHash.Hex(), err)
+			}
+		}
+
+		s.lastSyncedBlock = toBlock
+	}
+
+	return nil
+}
+
+// LastSyncedBlock returns the last block whose events have been applied.
+func (s *PaymentVaultSyncer) LastSyncedBlock() uint64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.lastSyncedBlock
+}
+
+// applyLog applies a single PaymentVault event to the state. Events that do not affect payments are ignored.
+func (s *PaymentVaultSyncer) applyLog(log types.Log) error {
+	if len(log.Topics) == 0 {
+		return nil
+	}
+	name, ok := s.eventIDs[log.Topics[0]]
+	if !ok {
+		return nil
+	}
+
+	switch name {
+	case "ReservationUpdated":
+		event, err := s.filterer.ParseReservationUpdated(log)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s event: %w", name, err)
+		}
+		if eth.IsZeroValuedReservation(event.Reservation) {
+			// The reservation was cleared.
+			s.state.setReservedPayments(event.Account, nil)
+			s.logger.Debug("Applied reservation removal", "account", event.Account.Hex())
+			break
+		}
+		reservations, err := eth.ConvertToReservedPayments(event.Reservation)
+		if err != nil {
+			return fmt.Errorf("failed to convert reservation of account %s: %w", event.Account.Hex(), err)
+		}
+		s.state.setReservedPayments(event.Account, reservations)
+		s.logger.Debug("Applied reservation update", "account", event.Account.Hex())
+	case "OnDemandPaymentUpdated":
+		event, err := s.filterer.ParseOnDemandPaymentUpdated(log)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s event: %w", name, err)
+		}
+		s.state.setOnDemandPayment(event.Account, &core.OnDemandPayment{
+			CumulativePayment: event.TotalDeposit,
+		})
+		s.logger.Debug("Applied on-demand deposit", "account", event.Account.Hex(),
+			"totalDeposit", event.TotalDeposit)
+	case "GlobalSymbolsPerPeriodUpdated":
+		event, err := s.filterer.ParseGlobalSymbolsPerPeriodUpdated(log)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s event: %w", name, err)
+		}
+		s.state.updatePaymentVaultParams(func(params *PaymentVaultParams) {
+			params.GlobalSymbolsPerSecond = event.NewValue
+		})
+	case "GlobalRatePeriodIntervalUpdated":
+		event, err := s.filterer.ParseGlobalRatePeriodIntervalUpdated(log)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s event: %w", name, err)
+		}
+		s.state.updatePaymentVaultParams(func(params *PaymentVaultParams) {
+			params.GlobalRatePeriodInterval = event.NewValue
+		})
+	case "ReservationPeriodIntervalUpdated":
+		event, err := s.filterer.ParseReservationPeriodIntervalUpdated(log)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s event: %w", name, err)
+		}
+		s.state.updatePaymentVaultParams(func(params *PaymentVaultParams) {
+			params.ReservationWindow = event.NewValue
+		})
+	case "PriceParamsUpdated":
+		event, err := s.filterer.ParsePriceParamsUpdated(log)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s event: %w", name, err)
+		}
+		s.state.updatePaymentVaultParams(func(params *PaymentVaultParams) {
+			params.MinNumSymbols = event.NewMinNumSymbols
+			params.PricePerSymbol = event.NewPricePerSymbol
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,356 @@
+package meterer_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	paymentvault "github.com/Layr-Labs/eigenda/contracts/bindings/PaymentVault"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/meterer"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/stretchr/testify/require"
+)
+
+var simulatedChainID = big.NewInt(1337)
+
+type simulatedPaymentVault struct {
+	backend *simulated.Backend
+	vault   *paymentvault.ContractPaymentVault
+	address gethcommon.Address
+	owner   *bind.TransactOpts
+}
+
+// newSimulatedPaymentVault deploys an initialized PaymentVault to a simulated chain. The PaymentVault constructor
+// disables initialization (it is meant to sit behind a proxy), so the deployed runtime code is copied into the
+// genesis of a fresh chain, where initialize() can then be called directly.
+func newSimulatedPaymentVault(t *testing.T) *simulatedPaymentVault {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	owner, err := bind.NewKeyedTransactorWithChainID(key, simulatedChainID)
+	require.NoError(t, err)
+
+	balance, _ := new(big.Int).SetString("1000000000000000000000", 10)
+	alloc := types.GenesisAlloc{owner.From: {Balance: balance}}
+
+	deployBackend := simulated.NewBackend(alloc)
+	address, _, _, err := paymentvault.DeployContractPaymentVault(owner, deployBackend.Client())
+	require.NoError(t, err)
+	deployBackend.Commit()
+	code, err := deployBackend.Client().CodeAt(context.Background(), address, nil)
+	require.NoError(t, err)
+	require.NoError(t, deployBackend.Close())
+
+	alloc[address] = types.Account{Code: code}
+	backend := simulated.NewBackend(alloc)
+	t.Cleanup(func() {
+		_ = backend.Close()
+	})
+
+	vault, err := paymentvault.NewContractPaymentVault(address, backend.Client())
+	require.NoError(t, err)
+	_, err = vault.Initialize(owner, owner.From, 4096, 2, 0, 1000, 300, 30)
+	require.NoError(t, err)
+	backend.Commit()
+
+	return &simulatedPaymentVault{
+		backend: backend,
+		vault:   vault,
+		address: address,
+		owner:   owner,
+	}
+}
+
+// mine mines the given number of blocks. Pending transactions are included in the first one.
+func (s *simulatedPaymentVault) mine(blocks int) {
+	for i := 0; i < blocks; i++ {
+		s.backend.Commit()
+	}
+}
+
+func newTestPaymentState() *meterer.OnchainPaymentState {
+	state := &meterer.OnchainPaymentState{
+		ReservedPayments: make(map[gethcommon.Address]map[core.QuorumID]*core.ReservedPayment),
+		OnDemandPayments: make(map[gethcommon.Address]*core.OnDemandPayment),
+	}
+	state.PaymentVaultParams.Store(&meterer.PaymentVaultParams{
+		GlobalSymbolsPerSecond:   1000,
+		GlobalRatePeriodInterval: 30,
+		MinNumSymbols:            4096,
+		PricePerSymbol:           2,
+		ReservationWindow:        300,
+		OnDemandQuorumNumbers:    []uint8{0, 1},
+	})
+	return state
+}
+
+func newTestAccount(t *testing.T) gethcommon.Address {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return crypto.PubkeyToAddress(key.PublicKey)
+}
+
+func newTestSyncer(
+	t *testing.T,
+	chain *simulatedPaymentVault,
+	state *meterer.OnchainPaymentState,
+	confirmationDepth uint64) *meterer.PaymentVaultSyncer {
+
+	return newTestSyncerWithClient(t, chain, chain.backend.Client(), state, confirmationDepth)
+}
+
+func newTestSyncerWithClient(
+	t *testing.T,
+	chain *simulatedPaymentVault,
+	client meterer.PaymentVaultLogReader,
+	state *meterer.OnchainPaymentState,
+	confirmationDepth uint64) *meterer.PaymentVaultSyncer {
+
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	require.NoError(t, err)
+
+	syncer, err := meterer.NewPaymentVaultSyncer(
+		context.Background(),
+		meterer.PaymentVaultSyncerConfig{
+			ConfirmationDepth: confirmationDepth,
+			PollInterval:      time.Second,
+			MaxBlockRange:     3,
+		},
+		state,
+		client,
+		chain.address,
+		logger)
+	require.NoError(t, err)
+	return syncer
+}
+
+func TestPaymentVaultSyncerAppliesEvents(t *testing.T) {
+	ctx := context.Background()
+	chain := newSimulatedPaymentVault(t)
+	state := newTestPaymentState()
+	confirmationDepth := uint64(2)
+	syncer := newTestSyncer(t, chain, state, confirmationDepth)
+
+	account := newTestAccount(t)
+	// An account that is already cached, and that has no events, must be left alone.
+	untouchedAccount := newTestAccount(t)
+	state.ReservedPayments[untouchedAccount] = map[core.QuorumID]*core.ReservedPayment{0: dummyReservedPayment}
+	state.OnDemandPayments[untouchedAccount] = dummyOnDemandPayment
+
+	reservation := paymentvault.IPaymentVaultReservation{
+		SymbolsPerSecond: 100,
+		StartTimestamp:   1000,
+		EndTimestamp:     2000,
+		QuorumNumbers:    []byte{0, 1},
+		QuorumSplits:     []byte{50, 50},
+	}
+	_, err := chain.vault.SetReservation(chain.owner, account, reservation)
+	require.NoError(t, err)
+	chain.mine(1)
+
+	// The event is not applied until it reaches the confirmation depth.
+	require.NoError(t, syncer.Sync(ctx))
+	require.NotContains(t, state.ReservedPayments, account)
+	chain.mine(int(confirmationDepth) - 1)
+	require.NoError(t, syncer.Sync(ctx))
+	require.NotContains(t, state.ReservedPayments, account)
+	chain.mine(1)
+	require.NoError(t, syncer.Sync(ctx))
+	require.Contains(t, state.ReservedPayments, account)
+	require.Len(t, state.ReservedPayments[account], 2)
+	for _, quorumID := range []core.QuorumID{0, 1} {
+		require.Equal(t, reservation.SymbolsPerSecond, state.ReservedPayments[account][quorumID].SymbolsPerSecond)
+		require.Equal(t, reservation.StartTimestamp, state.ReservedPayments[account][quorumID].StartTimestamp)
+		require.Equal(t, reservation.EndTimestamp, state.ReservedPayments[account][quorumID].EndTimestamp)
+	}
+
+	// Two deposits; the state tracks the total.
+	for _, amount := range []int64{1000, 500} {
+		opts := *chain.owner
+		opts.Value = big.NewInt(amount)
+		_, err = chain.vault.DepositOnDemand(&opts, account)
+		require.NoError(t, err)
+		chain.mine(1)
+	}
+
+	// Global parameter updates.
+	_, err = chain.vault.SetGlobalSymbolsPerPeriod(chain.owner, 2000)
+	require.NoError(t, err)
+	_, err = chain.vault.SetGlobalRatePeriodInterval(chain.owner, 60)
+	require.NoError(t, err)
+	_, err = chain.vault.SetReservationPeriodInterval(chain.owner, 600)
+	require.NoError(t, err)
+	_, err = chain.vault.SetPriceParams(chain.owner, 8192, 3, 0)
+	require.NoError(t, err)
+
+	// Enough blocks for the events to be confirmed, spanning several log queries.
+	chain.mine(int(confirmationDepth) + 6)
+	require.NoError(t, syncer.Sync(ctx))
+
+	require.Contains(t, state.OnDemandPayments, account)
+	require.Equal(t, big.NewInt(1500), state.OnDemandPayments[account].CumulativePayment)
+
+	require.Equal(t, uint64(2000), state.GetGlobalSymbolsPerSecond())
+	require.Equal(t, uint64(60), state.GetGlobalRatePeriodInterval())
+	require.Equal(t, uint64(600), state.GetReservationWindow())
+	require.Equal(t, uint64(8192), state.GetMinNumSymbols())
+	require.Equal(t, uint64(3), state.GetPricePerSymbol())
+	require.Equal(t, []uint8{0, 1}, state.PaymentVaultParams.Load().OnDemandQuorumNumbers)
+
+	require.Equal(t, dummyReservedPayment, state.ReservedPayments[untouchedAccount][0])
+	require.Equal(t, dummyOnDemandPayment, state.OnDemandPayments[untouchedAccount])
+
+	head, err := chain.backend.Client().BlockNumber(ctx)
+	require.NoError(t, err)
+	require.Equal(t, head-confirmationDepth, syncer.LastSyncedBlock())
+}
+
+func TestPaymentVaultSyncerIgnoresEventsBeforeStart(t *testing.T) {
+	ctx := context.Background()
+	chain := newSimulatedPaymentVault(t)
+	state := newTestPaymentState()
+
+	// This deposit happens before the syncer is created, so it must be picked up by the initial read of the state.
+	account := newTestAccount(t)
+	opts := *chain.owner
+	opts.Value = big.NewInt(1000)
+	_, err := chain.vault.DepositOnDemand(&opts, account)
+	require.NoError(t, err)
+	chain.mine(1)
+
+	syncer := newTestSyncer(t, chain, state, 0)
+	chain.mine(3)
+	require.NoError(t, syncer.Sync(ctx))
+	require.NotContains(t, state.OnDemandPayments, account)
+}
+
+func TestPaymentVaultSyncerReorg(t *testing.T) {
+	ctx := context.Background()
+	chain := newSimulatedPaymentVault(t)
+	state := newTestPaymentState()
+	confirmationDepth := uint64(3)
+	syncer := newTestSyncer(t, chain, state, confirmationDepth)
+
+	account := newTestAccount(t)
+	depositor := newFundedTransactor(t, chain)
+	forkPoint, err := chain.backend.Client().HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+
+	// A deposit is mined, and then reorged out before it reaches the confirmation depth.
+	depositor.Value = big.NewInt(1000)
+	_, err = chain.vault.DepositOnDemand(depositor, account)
+	require.NoError(t, err)
+	chain.mine(2)
+	require.NoError(t, syncer.Sync(ctx))
+	require.NotContains(t, state.OnDemandPayments, account)
+
+	require.NoError(t, chain.backend.Fork(forkPoint.Hash()))
+	// The replacement chain contains a different deposit from the same sender, which invalidates the original one.
+	depositor.Value = big.NewInt(7)
+	_, err = chain.vault.DepositOnDemand(depositor, account)
+	require.NoError(t, err)
+	chain.mine(int(confirmationDepth) + 3)
+
+	require.NoError(t, syncer.Sync(ctx))
+	require.Contains(t, state.OnDemandPayments, account)
+	require.Equal(t, big.NewInt(7), state.OnDemandPayments[account].CumulativePayment)
+
+	// The state matches the canonical chain.
+	deposit, err := chain.vault.GetOnDemandTotalDeposit(&bind.CallOpts{}, account)
+	require.NoError(t, err)
+	require.Equal(t, deposit, state.OnDemandPayments[account].CumulativePayment)
+}
+
+// truncatingLogReader truncates the data of the logs it returns while truncate is set, so that they can't be parsed.
+type truncatingLogReader struct {
+	meterer.PaymentVaultLogReader
+	truncate bool
+}
+
+func (r *truncatingLogReader) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	logs, err := r.PaymentVaultLogReader.FilterLogs(ctx, q)
+	if err != nil || !r.truncate {
+		return logs, err
+	}
+	for i := range logs {
+		logs[i].Data = logs[i].Data[:len(logs[i].Data)/2]
+	}
+	return logs, nil
+}
+
+func TestPaymentVaultSyncerRetriesFailedEvents(t *testing.T) {
+	ctx := context.Background()
+	chain := newSimulatedPaymentVault(t)
+	state := newTestPaymentState()
+	client := &truncatingLogReader{PaymentVaultLogReader: chain.backend.Client(), truncate: true}
+	syncer := newTestSyncerWithClient(t, chain, client, state, 0)
+	startBlock := syncer.LastSyncedBlock()
+
+	account := newTestAccount(t)
+	reservation := paymentvault.IPaymentVaultReservation{
+		SymbolsPerSecond: 100,
+		StartTimestamp:   1000,
+		EndTimestamp:     2000,
+		QuorumNumbers:    []byte{0},
+		QuorumSplits:     []byte{100},
+	}
+	_, err := chain.vault.SetReservation(chain.owner, account, reservation)
+	require.NoError(t, err)
+	chain.mine(3)
+
+	// An event that can't be applied is not skipped, the syncer stops before its block.
+	require.Error(t, syncer.Sync(ctx))
+	require.NotContains(t, state.ReservedPayments, account)
+	require.Equal(t, startBlock, syncer.LastSyncedBlock())
+	require.Error(t, syncer.Sync(ctx))
+	require.Equal(t, startBlock, syncer.LastSyncedBlock())
+
+	// Once the event can be applied, it is.
+	client.truncate = false
+	require.NoError(t, syncer.Sync(ctx))
+	require.Contains(t, state.ReservedPayments, account)
+	require.Equal(t, reservation.SymbolsPerSecond, state.ReservedPayments[account][0].SymbolsPerSecond)
+	head, err := chain.backend.Client().BlockNumber(ctx)
+	require.NoError(t, err)
+	require.Equal(t, head, syncer.LastSyncedBlock())
+}
+
+// newFundedTransactor creates a new account funded by the owner, and returns a transactor for it with an explicit
+// nonce, so that a transaction can be replaced on a forked chain.
+func newFundedTransactor(t *testing.T, chain *simulatedPaymentVault) *bind.TransactOpts {
+	ctx := context.Background()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	transactor, err := bind.NewKeyedTransactorWithChainID(key, simulatedChainID)
+	require.NoError(t, err)
+
+	fundAccount(t, chain, key, big.NewInt(1_000_000_000_000_000_000))
+	chain.mine(1)
+
+	nonce, err := chain.backend.Client().PendingNonceAt(ctx, transactor.From)
+	require.NoError(t, err)
+	transactor.Nonce = new(big.Int).SetUint64(nonce)
+	return transactor
+}
+
+func fundAccount(t *testing.T, chain *simulatedPaymentVault, key *ecdsa.PrivateKey, amount *big.Int) {
+	ctx := context.Background()
+	client := chain.backend.Client()
+
+	nonce, err := client.PendingNonceAt(ctx, chain.owner.From)
+	require.NoError(t, err)
+	gasPrice, err := client.SuggestGasPrice(ctx)
+	require.NoError(t, err)
+	tx := types.NewTransaction(nonce, crypto.PubkeyToAddress(key.PublicKey), amount, 21000, gasPrice, nil)
+	signedTx, err := chain.owner.Signer(chain.owner.From, tx)
+	require.NoError(t, err)
+	require.NoError(t, client.SendTransaction(ctx, signedTx))
+}
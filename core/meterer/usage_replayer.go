@@ -0,0 +1,90 @@
+package meterer
+
+import (
+	"math/big"
+
+	"github.com/Layr-Labs/eigenda/core"
+)
+
+// BlobCharge is what an account was charged for a single dispersal.
+type BlobCharge struct {
+	// SymbolsCharged is the number of symbols charged, after rounding up to the minimum number of symbols
+	SymbolsCharged uint64
+	// ReservationPeriod is the reservation period the dispersal was charged against; zero if it was paid on demand
+	ReservationPeriod uint64
+	// OverflowSymbols is the number of symbols charged to the overflow bin of the reservation, taking the largest
+	// overflow across the reserved quorums
+	OverflowSymbols uint64
+	// PaymentCharged is the on-demand payment charged for the dispersal; zero if it was paid for by reservation
+	PaymentCharged *big.Int
+}
+
+// UsageReplayer recomputes the charges of the dispersals of an account from their payment metadata, following the
+// same rules as the Meterer. Dispersals must be replayed in the order they were metered, and the replay must start at
+// the beginning of the reservation period of the first dispersal of interest, so that the bin usages it tracks match
+// the ones recorded by the Meterer.
+type UsageReplayer struct {
+	reservations      map[core.QuorumID]*core.ReservedPayment
+	minNumSymbols     uint64
+	pricePerSymbol    uint64
+	reservationWindow uint64
+
+	// binUsages is the replayed usage of the reservation bins, by quorum and reservation period
+	binUsages map[core.QuorumID]map[uint64]uint64
+}
+
+// NewUsageReplayer creates a UsageReplayer for an account with the given reservations and payment vault parameters.
+func NewUsageReplayer(
+	reservations map[core.QuorumID]*core.ReservedPayment,
+	minNumSymbols uint64,
+	pricePerSymbol uint64,
+	reservationWindow uint64,
+) *UsageReplayer {
+	return &UsageReplayer{
+		reservations:      reservations,
+		minNumSymbols:     minNumSymbols,
+		pricePerSymbol:    pricePerSymbol,
+		reservationWindow: reservationWindow,
+		binUsages:         make(map[core.QuorumID]map[uint64]uint64),
+	}
+}
+
+// Charge replays the metering of a dispersal of numSymbols symbols with the given payment metadata and returns what
+// the account was charged for it.
+func (r *UsageReplayer) Charge(header core.PaymentMetadata, numSymbols uint64) *BlobCharge {
+	charge := &BlobCharge{
+		SymbolsCharged: SymbolsCharged(numSymbols, r.minNumSymbols),
+		PaymentCharged: big.NewInt(0),
+	}
+
+	if header.CumulativePayment != nil && header.CumulativePayment.Sign() > 0 {
+		charge.PaymentCharged = PaymentCharged(charge.SymbolsCharged, r.pricePerSymbol)
+		return charge
+	}
+
+	// A reservation dispersal is charged against every reserved quorum, see Meterer.IncrementBinUsage
+	charge.ReservationPeriod = GetReservationPeriodByNanosecond(header.Timestamp, r.reservationWindow)
+	for quorumID, reservation := range r.reservations {
+		bins, ok := r.binUsages[quorumID]
+		if !ok {
+			bins = make(map[uint64]uint64)
+			r.binUsages[quorumID] = bins
+		}
+
+		prevUsage := bins[charge.ReservationPeriod]
+		newUsage := prevUsage + charge.SymbolsCharged
+		bins[charge.ReservationPeriod] = newUsage
+
+		usageLimit := reservation.SymbolsPerSecond * r.reservationWindow
+		if newUsage <= usageLimit || prevUsage >= usageLimit {
+			continue
+		}
+		overflow := newUsage - usageLimit
+		bins[charge.ReservationPeriod+2] += overflow
+		if overflow > charge.OverflowSymbols {
+			charge.OverflowSymbols = overflow
+		}
+	}
+
+	return charge
+}
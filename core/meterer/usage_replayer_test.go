@@ -0,0 +1,60 @@
+package meterer_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/meterer"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsageReplayer(t *testing.T) {
+	accountID := gethcommon.HexToAddress("0x1234567890123456789012345678901234567890")
+	reservations := map[core.QuorumID]*core.ReservedPayment{
+		// 500 symbols per period
+		0: {SymbolsPerSecond: 100, StartTimestamp: 0, EndTimestamp: 1000, QuorumNumbers: []uint8{0, 1}},
+		// 1000 symbols per period
+		1: {SymbolsPerSecond: 200, StartTimestamp: 0, EndTimestamp: 1000, QuorumNumbers: []uint8{0, 1}},
+	}
+	replayer := meterer.NewUsageReplayer(reservations, 100, 2, 5)
+
+	reservationHeader := func(second int64) core.PaymentMetadata {
+		return core.PaymentMetadata{
+			AccountID:         accountID,
+			Timestamp:         second * 1e9,
+			CumulativePayment: big.NewInt(0),
+		}
+	}
+
+	// Charged the minimum number of symbols
+	charge := replayer.Charge(reservationHeader(10), 1)
+	require.Equal(t, uint64(100), charge.SymbolsCharged)
+	require.Equal(t, uint64(10), charge.ReservationPeriod)
+	require.Equal(t, uint64(0), charge.OverflowSymbols)
+	require.Equal(t, big.NewInt(0), charge.PaymentCharged)
+
+	// Rounded up to a multiple of the minimum number of symbols, and overflows the bin of quorum 0 but not the bin of
+	// quorum 1
+	charge = replayer.Charge(reservationHeader(12), 450)
+	require.Equal(t, uint64(500), charge.SymbolsCharged)
+	require.Equal(t, uint64(10), charge.ReservationPeriod)
+	require.Equal(t, uint64(100), charge.OverflowSymbols)
+
+	// A new reservation period starts with an empty bin
+	charge = replayer.Charge(reservationHeader(15), 200)
+	require.Equal(t, uint64(15), charge.ReservationPeriod)
+	require.Equal(t, uint64(0), charge.OverflowSymbols)
+
+	// On-demand dispersals are charged by price, not against the reservation
+	charge = replayer.Charge(core.PaymentMetadata{
+		AccountID:         accountID,
+		Timestamp:         16 * 1e9,
+		CumulativePayment: big.NewInt(1000),
+	}, 300)
+	require.Equal(t, uint64(300), charge.SymbolsCharged)
+	require.Equal(t, uint64(0), charge.ReservationPeriod)
+	require.Equal(t, uint64(0), charge.OverflowSymbols)
+	require.Equal(t, big.NewInt(600), charge.PaymentCharged)
+}
@@ -0,0 +1,149 @@
+package apiserver
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+)
+
+// maxConcurrentBlobStatusReads is the maximum number of blob statuses read concurrently by a poll of the
+// blobStatusPoller
+const maxConcurrentBlobStatusReads = 16
+
+var errTooManyBlobStatusSubscriptions = errors.New("too many blob status subscriptions")
+
+// blobStatusUpdate is the result of reading the status of a blob
+type blobStatusUpdate struct {
+	reply *pb.BlobStatusReply
+	err   error
+}
+
+// blobStatusSubscription receives the status of a blob read by the blobStatusPoller
+type blobStatusSubscription struct {
+	// updates holds the latest status read since the subscriber last received one. An update not yet received by
+	// the subscriber is replaced by the next one.
+	updates chan blobStatusUpdate
+}
+
+// blobStatusPoller reads the status of the blobs with open subscriptions at a fixed interval, and fans it out to
+// their subscribers. The status of a blob is read once per poll however many subscribers it has, and the number of
+// subscriptions is capped, so the load on the metadata store is bounded regardless of the number of clients.
+type blobStatusPoller struct {
+	getBlobStatus    func(ctx context.Context, blobKey corev2.BlobKey) (*pb.BlobStatusReply, error)
+	pollInterval     time.Duration
+	maxSubscriptions int
+
+	startOnce sync.Once
+
+	mu sync.Mutex
+	// subscriptions are the open subscriptions, by blob key
+	subscriptions    map[corev2.BlobKey]map[*blobStatusSubscription]struct{}
+	numSubscriptions int
+}
+
+func newBlobStatusPoller(
+	getBlobStatus func(ctx context.Context, blobKey corev2.BlobKey) (*pb.BlobStatusReply, error),
+	pollInterval time.Duration,
+	maxSubscriptions int,
+) *blobStatusPoller {
+	return &blobStatusPoller{
+		getBlobStatus:    getBlobStatus,
+		pollInterval:     pollInterval,
+		maxSubscriptions: maxSubscriptions,
+		subscriptions:    make(map[corev2.BlobKey]map[*blobStatusSubscription]struct{}),
+	}
+}
+
+// subscribe opens a subscription to the status of a blob, which must be closed with unsubscribe. It fails with
+// errTooManyBlobStatusSubscriptions if the maximum number of subscriptions are already open.
+func (p *blobStatusPoller) subscribe(blobKey corev2.BlobKey) (*blobStatusSubscription, error) {
+	p.startOnce.Do(func() {
+		go p.run()
+	})
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.numSubscriptions >= p.maxSubscriptions {
+		return nil, errTooManyBlobStatusSubscriptions
+	}
+
+	subscription := &blobStatusSubscription{
+		updates: make(chan blobStatusUpdate, 1),
+	}
+	if p.subscriptions[blobKey] == nil {
+		p.subscriptions[blobKey] = make(map[*blobStatusSubscription]struct{})
+	}
+	p.subscriptions[blobKey][subscription] = struct{}{}
+	p.numSubscriptions++
+	return subscription, nil
+}
+
+func (p *blobStatusPoller) unsubscribe(blobKey corev2.BlobKey, subscription *blobStatusSubscription) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.subscriptions[blobKey][subscription]; !ok {
+		return
+	}
+	delete(p.subscriptions[blobKey], subscription)
+	if len(p.subscriptions[blobKey]) == 0 {
+		delete(p.subscriptions, blobKey)
+	}
+	p.numSubscriptions--
+}
+
+func (p *blobStatusPoller) run() {
+	ticker := time.NewTicker(p.pollInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		p.poll(context.Background())
+	}
+}
+
+// poll reads the status of every blob with an open subscription, and sends it to the subscribers of the blob
+func (p *blobStatusPoller) poll(ctx context.Context) {
+	p.mu.Lock()
+	blobKeys := make([]corev2.BlobKey, 0, len(p.subscriptions))
+	for blobKey := range p.subscriptions {
+		blobKeys = append(blobKeys, blobKey)
+	}
+	p.mu.Unlock()
+
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, maxConcurrentBlobStatusReads)
+	for _, blobKey := range blobKeys {
+		blobKey := blobKey
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func() {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+
+			reply, err := p.getBlobStatus(ctx, blobKey)
+			p.publish(blobKey, blobStatusUpdate{reply: reply, err: err})
+		}()
+	}
+	wg.Wait()
+}
+
+func (p *blobStatusPoller) publish(blobKey corev2.BlobKey, update blobStatusUpdate) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for subscription := range p.subscriptions[blobKey] {
+		// Only the poller sends updates, while holding the lock, so the buffer is free once the stale update is
+		// discarded
+		select {
+		case <-subscription.updates:
+		default:
+		}
+		subscription.updates <- update
+	}
+}
@@ -90,6 +90,7 @@ func (s *DispersalServerV2) StoreBlob(ctx context.Context, data []byte, blobHead
 		BlobSize:    uint64(len(data)),
 		RequestedAt: uint64(requestedAt.UnixNano()),
 		UpdatedAt:   uint64(requestedAt.UnixNano()),
+		Priority:    s.getPriorityClass(blobHeader.PaymentMetadata),
 	}
 	err = s.blobMetadataStore.PutBlobMetadata(ctx, blobMetadata)
 	if err != nil {
@@ -100,9 +101,25 @@ func (s *DispersalServerV2) StoreBlob(ctx context.Context, data []byte, blobHead
 
 		return corev2.BlobKey{}, api.NewErrorInternal(fmt.Sprintf("failed to store blob metadata: %v", err))
 	}
+
+	if s.blobNotifier != nil {
+		s.blobNotifier.Notify(dispv2.Queued)
+	}
 	return blobKey, err
 }
 
+// getPriorityClass returns the priority class of a blob with the given payment metadata. Blobs from premium accounts
+// get the premium class, and otherwise blobs paid for by a reservation are preferred over blobs paid for on demand.
+func (s *DispersalServerV2) getPriorityClass(paymentMetadata core.PaymentMetadata) dispv2.PriorityClass {
+	if _, ok := s.premiumAccounts[paymentMetadata.AccountID]; ok {
+		return dispv2.PriorityPremium
+	}
+	if paymentMetadata.CumulativePayment == nil || paymentMetadata.CumulativePayment.Sign() == 0 {
+		return dispv2.PriorityReserved
+	}
+	return dispv2.PriorityStandard
+}
+
 func (s *DispersalServerV2) checkPaymentMeter(ctx context.Context, req *pb.DisperseBlobRequest, receivedAt time.Time) error {
 	blobHeaderProto := req.GetBlobHeader()
 	blobHeader, err := corev2.BlobHeaderFromProtobuf(blobHeaderProto)
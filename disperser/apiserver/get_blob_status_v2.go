@@ -29,6 +29,12 @@ func (s *DispersalServerV2) GetBlobStatus(ctx context.Context, req *pb.BlobStatu
 		return nil, api.NewErrorInvalidArg(fmt.Sprintf("failed to parse the blob key bytes: %v", err))
 	}
 
+	return s.getBlobStatus(ctx, blobKey)
+}
+
+// getBlobStatus returns the status of the blob with the given key, along with its signed batch and inclusion info
+// once the blob is gathering signatures or complete.
+func (s *DispersalServerV2) getBlobStatus(ctx context.Context, blobKey corev2.BlobKey) (*pb.BlobStatusReply, error) {
 	metadata, err := s.blobMetadataStore.GetBlobMetadata(ctx, blobKey)
 	if err != nil {
 		if strings.Contains(err.Error(), "metadata not found") {
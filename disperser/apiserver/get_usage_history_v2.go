@@ -0,0 +1,195 @@
+package apiserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/core/meterer"
+	dispv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// maxNumBlobUsagesPerPage is the maximum number of blob usages returned by a single GetUsageHistory call.
+const maxNumBlobUsagesPerPage = 1000
+
+func (s *DispersalServerV2) GetUsageHistory(ctx context.Context, req *pb.GetUsageHistoryRequest) (*pb.GetUsageHistoryReply, error) {
+	if s.meterer == nil {
+		return nil, errors.New("payment meterer is not enabled")
+	}
+	start := time.Now()
+	defer func() {
+		s.metrics.reportGetUsageHistoryLatency(time.Since(start))
+	}()
+
+	if !gethcommon.IsHexAddress(req.GetAccountId()) {
+		return nil, api.NewErrorInvalidArg("invalid account ID")
+	}
+	accountID := gethcommon.HexToAddress(req.GetAccountId())
+
+	// The request is signed the same way as a payment state request
+	paymentStateRequest := &pb.GetPaymentStateRequest{
+		AccountId: req.GetAccountId(),
+		Signature: req.GetSignature(),
+		Timestamp: req.GetTimestamp(),
+	}
+	if err := s.blobRequestAuthenticator.AuthenticatePaymentStateRequest(accountID, paymentStateRequest); err != nil {
+		s.logger.Debug("failed to validate signature", "err", err, "accountID", accountID)
+		return nil, api.NewErrorInvalidArg(fmt.Sprintf("authentication failed: %s", err.Error()))
+	}
+
+	startTimestamp := req.GetStartTimestamp()
+	endTimestamp := req.GetEndTimestamp()
+	if endTimestamp == 0 {
+		endTimestamp = uint64(time.Now().UnixNano())
+	}
+	if startTimestamp >= endTimestamp {
+		return nil, api.NewErrorInvalidArg(
+			fmt.Sprintf("start timestamp %d must be smaller than end timestamp %d", startTimestamp, endTimestamp))
+	}
+	limit := int(req.GetLimit())
+	if limit <= 0 || limit > maxNumBlobUsagesPerPage {
+		limit = maxNumBlobUsagesPerPage
+	}
+
+	// Fetch one more blob than requested to find out if there is another page
+	blobs, err := s.getAccountBlobs(ctx, accountID, startTimestamp, endTimestamp, limit+1)
+	if err != nil {
+		s.logger.Warn("failed to get blob metadata", "err", err, "accountID", accountID)
+		return nil, api.NewErrorInternal(fmt.Sprintf("failed to get blob metadata: %s", err.Error()))
+	}
+	var nextStartTimestamp uint64
+	if len(blobs) > limit {
+		nextStartTimestamp = blobs[limit].RequestedAt
+		blobs = blobs[:limit]
+	}
+
+	reservationWindow := s.meterer.ChainPaymentState.GetReservationWindow()
+	reservations, err := s.meterer.ChainPaymentState.GetReservedPaymentByAccount(ctx, accountID)
+	if err != nil {
+		s.logger.Debug("failed to get onchain reservation, ignore reservation overflows", "err", err, "accountID", accountID)
+	}
+	replayer := meterer.NewUsageReplayer(
+		reservations,
+		s.meterer.ChainPaymentState.GetMinNumSymbols(),
+		s.meterer.ChainPaymentState.GetPricePerSymbol(),
+		reservationWindow,
+	)
+
+	// Replay the blobs requested earlier in the first reservation period, so that the reservation usage of the blobs
+	// in the page is accounted from the start of the period
+	periodStartTimestamp := meterer.GetReservationPeriodByNanosecond(int64(startTimestamp), reservationWindow) *
+		uint64(time.Second)
+	if periodStartTimestamp < startTimestamp {
+		earlierBlobs, err := s.getAccountBlobs(ctx, accountID, periodStartTimestamp, startTimestamp, 0)
+		if err != nil {
+			s.logger.Warn("failed to get blob metadata", "err", err, "accountID", accountID)
+			return nil, api.NewErrorInternal(fmt.Sprintf("failed to get blob metadata: %s", err.Error()))
+		}
+		for _, blob := range earlierBlobs {
+			replayer.Charge(blob.BlobHeader.PaymentMetadata, uint64(encoding.GetBlobLengthPowerOf2(uint(blob.BlobSize))))
+		}
+	}
+
+	blobUsages := make([]*pb.BlobUsage, 0, len(blobs))
+	for _, blob := range blobs {
+		blobKey, err := blob.BlobHeader.BlobKey()
+		if err != nil {
+			return nil, api.NewErrorInternal(fmt.Sprintf("failed to get blob key: %s", err.Error()))
+		}
+		paymentMetadata := blob.BlobHeader.PaymentMetadata
+		charge := replayer.Charge(paymentMetadata, uint64(encoding.GetBlobLengthPowerOf2(uint(blob.BlobSize))))
+
+		quorumNumbers := make([]uint32, len(blob.BlobHeader.QuorumNumbers))
+		for i, quorumNumber := range blob.BlobHeader.QuorumNumbers {
+			quorumNumbers[i] = uint32(quorumNumber)
+		}
+		blobUsage := &pb.BlobUsage{
+			BlobKey:           blobKey[:],
+			RequestedAt:       blob.RequestedAt,
+			QuorumNumbers:     quorumNumbers,
+			SymbolsCharged:    charge.SymbolsCharged,
+			ReservationPeriod: uint32(charge.ReservationPeriod),
+			OverflowSymbols:   charge.OverflowSymbols,
+		}
+		if charge.PaymentCharged.Sign() > 0 {
+			blobUsage.CumulativePayment = paymentMetadata.CumulativePayment.Bytes()
+			blobUsage.PaymentCharged = charge.PaymentCharged.Bytes()
+		}
+		blobUsages = append(blobUsages, blobUsage)
+	}
+
+	pageEndTimestamp := endTimestamp
+	if nextStartTimestamp != 0 {
+		pageEndTimestamp = nextStartTimestamp
+	}
+	periodRecords, err := s.getPeriodRecords(
+		ctx,
+		accountID,
+		meterer.GetReservationPeriodByNanosecond(int64(startTimestamp), reservationWindow),
+		meterer.GetReservationPeriodByNanosecond(int64(pageEndTimestamp-1), reservationWindow),
+	)
+	if err != nil {
+		s.logger.Warn("failed to get reservation records", "err", err, "accountID", accountID)
+		return nil, api.NewErrorInternal(fmt.Sprintf("failed to get reservation records: %s", err.Error()))
+	}
+
+	return &pb.GetUsageHistoryReply{
+		PeriodRecords:      periodRecords,
+		BlobUsages:         blobUsages,
+		NextStartTimestamp: nextStartTimestamp,
+	}, nil
+}
+
+// getAccountBlobs returns up to limit blobs of the account requested in [startTimestamp, endTimestamp), in ascending
+// order of request time. All blobs are returned if limit is 0.
+func (s *DispersalServerV2) getAccountBlobs(
+	ctx context.Context,
+	accountID gethcommon.Address,
+	startTimestamp uint64,
+	endTimestamp uint64,
+	limit int,
+) ([]*dispv2.BlobMetadata, error) {
+	// The time range of the metadata store is exclusive at both ends
+	exclusiveStart := uint64(0)
+	if startTimestamp > 0 {
+		exclusiveStart = startTimestamp - 1
+	}
+	if exclusiveStart+1 >= endTimestamp {
+		return nil, nil
+	}
+	return s.blobMetadataStore.GetBlobMetadataByAccountID(ctx, accountID, exclusiveStart, endTimestamp, limit, true)
+}
+
+// getPeriodRecords returns the reservation usage records of the account from startPeriod to endPeriod, inclusive.
+func (s *DispersalServerV2) getPeriodRecords(
+	ctx context.Context,
+	accountID gethcommon.Address,
+	startPeriod uint64,
+	endPeriod uint64,
+) ([]*pb.PeriodRecord, error) {
+	records := make([]*pb.PeriodRecord, 0)
+	period := startPeriod
+	for period <= endPeriod {
+		batch, err := s.meterer.MeteringStore.GetPeriodRecords(ctx, accountID, period)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range batch {
+			if record == nil {
+				// No more records for the account
+				return records, nil
+			}
+			if uint64(record.Index) > endPeriod {
+				return records, nil
+			}
+			records = append(records, record)
+			period = uint64(record.Index) + 1
+		}
+	}
+	return records, nil
+}
@@ -25,12 +25,14 @@ type metricsV2 struct {
 
 	getBlobCommitmentLatency        *prometheus.SummaryVec
 	getPaymentStateLatency          *prometheus.SummaryVec
+	getUsageHistoryLatency          *prometheus.SummaryVec
 	disperseBlobLatency             *prometheus.SummaryVec
 	disperseBlobSize                *prometheus.CounterVec
 	disperseBlobMeteredBytes        *prometheus.CounterVec
 	validateDispersalRequestLatency *prometheus.SummaryVec
 	storeBlobLatency                *prometheus.SummaryVec
 	getBlobStatusLatency            *prometheus.SummaryVec
+	blobStatusSubscriptions         *prometheus.GaugeVec
 
 	registry *prometheus.Registry
 	httpPort string
@@ -70,6 +72,16 @@ func newAPIServerV2Metrics(registry *prometheus.Registry, metricsConfig disperse
 		[]string{},
 	)
 
+	getUsageHistoryLatency := promauto.With(registry).NewSummaryVec(
+		prometheus.SummaryOpts{
+			Namespace:  namespace,
+			Name:       "get_usage_history_latency_ms",
+			Help:       "The time required to get the usage history.",
+			Objectives: objectives,
+		},
+		[]string{},
+	)
+
 	disperseBlobLatency := promauto.With(registry).NewSummaryVec(
 		prometheus.SummaryOpts{
 			Namespace:  namespace,
@@ -128,16 +140,27 @@ func newAPIServerV2Metrics(registry *prometheus.Registry, metricsConfig disperse
 		[]string{},
 	)
 
+	blobStatusSubscriptions := promauto.With(registry).NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "blob_status_subscriptions",
+			Help:      "The number of open blob status subscriptions.",
+		},
+		[]string{},
+	)
+
 	return &metricsV2{
 		grpcServerOption:                grpcServerOption,
 		getBlobCommitmentLatency:        getBlobCommitmentLatency,
 		getPaymentStateLatency:          getPaymentStateLatency,
+		getUsageHistoryLatency:          getUsageHistoryLatency,
 		disperseBlobLatency:             disperseBlobLatency,
 		disperseBlobSize:                disperseBlobSize,
 		disperseBlobMeteredBytes:        disperseBlobMeteredBytes,
 		validateDispersalRequestLatency: validateDispersalRequestLatency,
 		storeBlobLatency:                storeBlobLatency,
 		getBlobStatusLatency:            getBlobStatusLatency,
+		blobStatusSubscriptions:         blobStatusSubscriptions,
 		registry:                        registry,
 		httpPort:                        metricsConfig.HTTPPort,
 		logger:                          logger.With("component", "DisperserV2Metrics"),
@@ -168,6 +191,10 @@ func (m *metricsV2) reportGetPaymentStateLatency(duration time.Duration) {
 	m.getPaymentStateLatency.WithLabelValues().Observe(common.ToMilliseconds(duration))
 }
 
+func (m *metricsV2) reportGetUsageHistoryLatency(duration time.Duration) {
+	m.getUsageHistoryLatency.WithLabelValues().Observe(common.ToMilliseconds(duration))
+}
+
 func (m *metricsV2) reportDisperseBlobLatency(duration time.Duration) {
 	m.disperseBlobLatency.WithLabelValues().Observe(common.ToMilliseconds(duration))
 }
@@ -192,3 +219,11 @@ func (m *metricsV2) reportStoreBlobLatency(duration time.Duration) {
 func (m *metricsV2) reportGetBlobStatusLatency(duration time.Duration) {
 	m.getBlobStatusLatency.WithLabelValues().Observe(common.ToMilliseconds(duration))
 }
+
+func (m *metricsV2) reportBlobStatusSubscriptionStarted() {
+	m.blobStatusSubscriptions.WithLabelValues().Inc()
+}
+
+func (m *metricsV2) reportBlobStatusSubscriptionEnded() {
+	m.blobStatusSubscriptions.WithLabelValues().Dec()
+}
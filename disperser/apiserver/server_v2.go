@@ -21,6 +21,7 @@ import (
 	v2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/notifier"
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	gethcommon "github.com/ethereum/go-ethereum/common"
@@ -28,6 +29,16 @@ import (
 	"google.golang.org/grpc/reflection"
 )
 
+const (
+	// defaultBlobStatusPollInterval is used when the server config does not set BlobStatusPollInterval
+	defaultBlobStatusPollInterval = time.Second
+	// defaultMaxBlobStatusSubscriptions is used when the server config does not set MaxBlobStatusSubscriptions
+	defaultMaxBlobStatusSubscriptions = 1000
+	// defaultMaxBlobStatusSubscriptionLifetime is used when the server config does not set
+	// MaxBlobStatusSubscriptionLifetime
+	defaultMaxBlobStatusSubscriptionLifetime = 5 * time.Minute
+)
+
 type OnchainState struct {
 	QuorumCount           uint8
 	RequiredQuorums       []core.QuorumID
@@ -46,7 +57,12 @@ type DispersalServerV2 struct {
 	serverConfig      disperser.ServerConfig
 	blobStore         *blobstore.BlobStore
 	blobMetadataStore blobstore.MetadataStore
-	meterer           *meterer.Meterer
+	// blobNotifier is signaled when blobs are queued, so that the controller encodes them without waiting for its
+	// next poll of the metadata store. It may be nil.
+	blobNotifier notifier.Publisher
+	meterer      *meterer.Meterer
+	// blobStatusPoller reads the status of the blobs with open subscriptions
+	blobStatusPoller *blobStatusPoller
 
 	chainReader              core.Reader
 	blobRequestAuthenticator corev2.BlobRequestAuthenticator
@@ -65,6 +81,8 @@ type DispersalServerV2 struct {
 	// ReservedOnly mode doesn't support on-demand payments
 	// This would be removed with decentralized ratelimiting
 	ReservedOnly bool
+	// premiumAccounts are the accounts whose blobs are given the premium priority class
+	premiumAccounts map[gethcommon.Address]struct{}
 }
 
 // NewDispersalServerV2 creates a new Server struct with the provided parameters.
@@ -72,6 +90,7 @@ func NewDispersalServerV2(
 	serverConfig disperser.ServerConfig,
 	blobStore *blobstore.BlobStore,
 	blobMetadataStore blobstore.MetadataStore,
+	blobNotifier notifier.Publisher,
 	chainReader core.Reader,
 	meterer *meterer.Meterer,
 	blobRequestAuthenticator corev2.BlobRequestAuthenticator,
@@ -83,10 +102,20 @@ func NewDispersalServerV2(
 	metricsConfig disperser.MetricsConfig,
 	ntpClock *core.NTPSyncedClock,
 	ReservedOnly bool,
+	premiumAccounts []gethcommon.Address,
 ) (*DispersalServerV2, error) {
 	if serverConfig.GrpcPort == "" {
 		return nil, errors.New("grpc port is required")
 	}
+	if serverConfig.BlobStatusPollInterval <= 0 {
+		serverConfig.BlobStatusPollInterval = defaultBlobStatusPollInterval
+	}
+	if serverConfig.MaxBlobStatusSubscriptions <= 0 {
+		serverConfig.MaxBlobStatusSubscriptions = defaultMaxBlobStatusSubscriptions
+	}
+	if serverConfig.MaxBlobStatusSubscriptionLifetime <= 0 {
+		serverConfig.MaxBlobStatusSubscriptionLifetime = defaultMaxBlobStatusSubscriptionLifetime
+	}
 	if blobStore == nil {
 		return nil, errors.New("blob store is required")
 	}
@@ -111,10 +140,16 @@ func NewDispersalServerV2(
 
 	logger := _logger.With("component", "DispersalServerV2")
 
-	return &DispersalServerV2{
+	premiumAccountSet := make(map[gethcommon.Address]struct{}, len(premiumAccounts))
+	for _, account := range premiumAccounts {
+		premiumAccountSet[account] = struct{}{}
+	}
+
+	s := &DispersalServerV2{
 		serverConfig:      serverConfig,
 		blobStore:         blobStore,
 		blobMetadataStore: blobMetadataStore,
+		blobNotifier:      blobNotifier,
 
 		chainReader:              chainReader,
 		blobRequestAuthenticator: blobRequestAuthenticator,
@@ -128,9 +163,13 @@ func NewDispersalServerV2(
 		metricsConfig: metricsConfig,
 		metrics:       newAPIServerV2Metrics(registry, metricsConfig, logger),
 
-		ntpClock:     ntpClock,
-		ReservedOnly: ReservedOnly,
-	}, nil
+		ntpClock:        ntpClock,
+		ReservedOnly:    ReservedOnly,
+		premiumAccounts: premiumAccountSet,
+	}
+	s.blobStatusPoller = newBlobStatusPoller(
+		s.getBlobStatus, serverConfig.BlobStatusPollInterval, serverConfig.MaxBlobStatusSubscriptions)
+	return s, nil
 }
 
 func (s *DispersalServerV2) Start(ctx context.Context) error {
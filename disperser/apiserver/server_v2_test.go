@@ -27,10 +27,14 @@ import (
 	"github.com/Layr-Labs/eigenda/disperser/apiserver"
 	dispv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
 	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/notifier"
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigenda/encoding/utils/codec"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 
 	pbcommonv2 "github.com/Layr-Labs/eigenda/api/grpc/common/v2"
 	pbv2 "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
@@ -48,6 +52,8 @@ type testComponents struct {
 	ChainReader       *mock.MockWriter
 	Signer            *auth.LocalBlobRequestSigner
 	Peer              *peer.Peer
+	// QueuedNotifications receives the notifications of queued blobs sent by the server
+	QueuedNotifications <-chan struct{}
 }
 
 func TestV2DisperseBlob(t *testing.T) {
@@ -105,6 +111,8 @@ func TestV2DisperseBlob(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, dispv2.Queued, blobMetadata.BlobStatus)
 	assert.Equal(t, blobHeader, blobMetadata.BlobHeader)
+	// Check if the queued blob is notified
+	assert.Len(t, c.QueuedNotifications, 1)
 	assert.Equal(t, uint64(len(data)), blobMetadata.BlobSize)
 	assert.Equal(t, uint(0), blobMetadata.NumRetries)
 	assert.Greater(t, blobMetadata.Expiry, uint64(now.Unix()))
@@ -467,6 +475,189 @@ func TestV2GetBlobStatus(t *testing.T) {
 	require.Equal(t, attestationProto, reply.GetSignedBatch().GetAttestation())
 }
 
+// testBlobStatusStream collects the replies sent on a blob status subscription
+type testBlobStatusStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	replies chan *pbv2.BlobStatusReply
+}
+
+func (s *testBlobStatusStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *testBlobStatusStream) Send(reply *pbv2.BlobStatusReply) error {
+	s.replies <- reply
+	return nil
+}
+
+func TestV2SubscribeBlobStatus(t *testing.T) {
+	c := newTestServerV2(t)
+	ctx := peer.NewContext(context.Background(), c.Peer)
+
+	blobHeader := &corev2.BlobHeader{
+		BlobVersion:     0,
+		BlobCommitments: mockCommitment,
+		QuorumNumbers:   []core.QuorumID{0},
+		PaymentMetadata: core.PaymentMetadata{
+			AccountID:         gethcommon.HexToAddress("0x1234"),
+			Timestamp:         0,
+			CumulativePayment: big.NewInt(532),
+		},
+	}
+	blobKey, err := blobHeader.BlobKey()
+	require.NoError(t, err)
+	now := time.Now()
+	err = c.BlobMetadataStore.PutBlobMetadata(ctx, &dispv2.BlobMetadata{
+		BlobHeader: blobHeader,
+		BlobStatus: dispv2.Queued,
+		Expiry:     uint64(now.Add(time.Hour).Unix()),
+		NumRetries: 0,
+		UpdatedAt:  uint64(now.UnixNano()),
+	})
+	require.NoError(t, err)
+	err = c.BlobMetadataStore.PutBlobCertificate(ctx, &corev2.BlobCertificate{
+		BlobHeader: blobHeader,
+		RelayKeys:  []corev2.RelayKey{0, 1, 2},
+	}, nil)
+	require.NoError(t, err)
+
+	// Subscribing with an invalid blob key fails immediately
+	stream := &testBlobStatusStream{ctx: ctx, replies: make(chan *pbv2.BlobStatusReply, 10)}
+	err = c.DispersalServerV2.SubscribeBlobStatus(&pbv2.SubscribeBlobStatusRequest{BlobKey: []byte{1, 2, 3}}, stream)
+	require.Error(t, err)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- c.DispersalServerV2.SubscribeBlobStatus(&pbv2.SubscribeBlobStatusRequest{BlobKey: blobKey[:]}, stream)
+	}()
+
+	// The current status is sent as soon as the subscription starts, and is not sent again until it changes
+	reply := <-stream.replies
+	require.Equal(t, pbv2.BlobStatus_QUEUED, reply.GetStatus())
+	time.Sleep(50 * time.Millisecond)
+	require.Empty(t, stream.replies)
+
+	// Subscriptions beyond the maximum number of open subscriptions are rejected
+	otherStream := &testBlobStatusStream{ctx: ctx, replies: make(chan *pbv2.BlobStatusReply, 10)}
+	err = c.DispersalServerV2.SubscribeBlobStatus(&pbv2.SubscribeBlobStatusRequest{BlobKey: blobKey[:]}, otherStream)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	require.Empty(t, otherStream.replies)
+
+	err = c.BlobMetadataStore.UpdateBlobStatus(ctx, blobKey, dispv2.Encoded)
+	require.NoError(t, err)
+	reply = <-stream.replies
+	require.Equal(t, pbv2.BlobStatus_ENCODED, reply.GetStatus())
+
+	// The attestation is sent while the blob is gathering signatures, and sent again when more validators sign
+	batchHeader := &corev2.BatchHeader{
+		BatchRoot:            [32]byte{1, 2, 3},
+		ReferenceBlockNumber: 100,
+	}
+	err = c.BlobMetadataStore.PutBatchHeader(ctx, batchHeader)
+	require.NoError(t, err)
+	err = c.BlobMetadataStore.PutBlobInclusionInfo(ctx, &corev2.BlobInclusionInfo{
+		BatchHeader:    batchHeader,
+		BlobKey:        blobKey,
+		BlobIndex:      123,
+		InclusionProof: []byte("inclusion proof"),
+	})
+	require.NoError(t, err)
+	attestation := &corev2.Attestation{
+		BatchHeader: batchHeader,
+		NonSignerPubKeys: []*core.G1Point{
+			core.NewG1Point(big.NewInt(1), big.NewInt(2)),
+		},
+		APKG2: &core.G2Point{
+			G2Affine: &bn254.G2Affine{
+				X: mockCommitment.LengthCommitment.X,
+				Y: mockCommitment.LengthCommitment.Y,
+			},
+		},
+		Sigma: &core.Signature{
+			G1Point: core.NewG1Point(big.NewInt(5), big.NewInt(6)),
+		},
+		QuorumAPKs: map[core.QuorumID]*core.G1Point{
+			0: core.NewG1Point(big.NewInt(7), big.NewInt(8)),
+		},
+		QuorumNumbers: []core.QuorumID{0},
+		QuorumResults: map[core.QuorumID]uint8{0: 50},
+	}
+	err = c.BlobMetadataStore.PutAttestation(ctx, attestation)
+	require.NoError(t, err)
+	err = c.BlobMetadataStore.UpdateBlobStatus(ctx, blobKey, dispv2.GatheringSignatures)
+	require.NoError(t, err)
+	reply = <-stream.replies
+	require.Equal(t, pbv2.BlobStatus_GATHERING_SIGNATURES, reply.GetStatus())
+	require.Equal(t, []byte{50}, reply.GetSignedBatch().GetAttestation().GetQuorumSignedPercentages())
+
+	attestation.QuorumResults = map[core.QuorumID]uint8{0: 80}
+	err = c.BlobMetadataStore.PutAttestation(ctx, attestation)
+	require.NoError(t, err)
+	reply = <-stream.replies
+	require.Equal(t, pbv2.BlobStatus_GATHERING_SIGNATURES, reply.GetStatus())
+	require.Equal(t, []byte{80}, reply.GetSignedBatch().GetAttestation().GetQuorumSignedPercentages())
+
+	// The subscription ends once the blob is complete
+	err = c.BlobMetadataStore.UpdateBlobStatus(ctx, blobKey, dispv2.Complete)
+	require.NoError(t, err)
+	reply = <-stream.replies
+	require.Equal(t, pbv2.BlobStatus_COMPLETE, reply.GetStatus())
+	require.NoError(t, <-done)
+
+	// Subscribing to a blob that is already complete sends its status once
+	err = c.DispersalServerV2.SubscribeBlobStatus(&pbv2.SubscribeBlobStatusRequest{BlobKey: blobKey[:]}, stream)
+	require.NoError(t, err)
+	reply = <-stream.replies
+	require.Equal(t, pbv2.BlobStatus_COMPLETE, reply.GetStatus())
+	require.Empty(t, stream.replies)
+}
+
+func TestV2SubscribeBlobStatusLifetime(t *testing.T) {
+	c := newTestServerV2(t)
+	ctx := peer.NewContext(context.Background(), c.Peer)
+
+	blobHeader := &corev2.BlobHeader{
+		BlobVersion:     0,
+		BlobCommitments: mockCommitment,
+		QuorumNumbers:   []core.QuorumID{0},
+		PaymentMetadata: core.PaymentMetadata{
+			AccountID:         gethcommon.HexToAddress("0x1234"),
+			Timestamp:         0,
+			CumulativePayment: big.NewInt(533),
+		},
+	}
+	blobKey, err := blobHeader.BlobKey()
+	require.NoError(t, err)
+	now := time.Now()
+	err = c.BlobMetadataStore.PutBlobMetadata(ctx, &dispv2.BlobMetadata{
+		BlobHeader: blobHeader,
+		BlobStatus: dispv2.Queued,
+		Expiry:     uint64(now.Add(time.Hour).Unix()),
+		NumRetries: 0,
+		UpdatedAt:  uint64(now.UnixNano()),
+	})
+	require.NoError(t, err)
+
+	// The subscription to a blob that never reaches a terminal status ends once its maximum lifetime is reached
+	stream := &testBlobStatusStream{ctx: ctx, replies: make(chan *pbv2.BlobStatusReply, 10)}
+	start := time.Now()
+	err = c.DispersalServerV2.SubscribeBlobStatus(&pbv2.SubscribeBlobStatusRequest{BlobKey: blobKey[:]}, stream)
+	require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	require.GreaterOrEqual(t, time.Since(start), 2*time.Second)
+	reply := <-stream.replies
+	require.Equal(t, pbv2.BlobStatus_QUEUED, reply.GetStatus())
+	require.Empty(t, stream.replies)
+
+	// The subscription is closed, so another one can be opened
+	err = c.BlobMetadataStore.UpdateBlobStatus(ctx, blobKey, dispv2.Failed)
+	require.NoError(t, err)
+	err = c.DispersalServerV2.SubscribeBlobStatus(&pbv2.SubscribeBlobStatusRequest{BlobKey: blobKey[:]}, stream)
+	require.NoError(t, err)
+	reply = <-stream.replies
+	require.Equal(t, pbv2.BlobStatus_FAILED, reply.GetStatus())
+}
+
 func TestV2GetBlobCommitment(t *testing.T) {
 	c := newTestServerV2(t)
 	data := make([]byte, 50)
@@ -579,13 +770,21 @@ func newTestServerV2(t *testing.T) *testComponents {
 		panic("failed to create NTP clock: " + err.Error())
 	}
 
+	blobNotifier := notifier.NewInProcessNotifier()
+	queuedNotifications := blobNotifier.Subscribe(dispv2.Queued)
+
 	s, err := apiserver.NewDispersalServerV2(
 		disperser.ServerConfig{
-			GrpcPort:    "51002",
-			GrpcTimeout: 1 * time.Second,
+			GrpcPort:               "51002",
+			GrpcTimeout:            1 * time.Second,
+			BlobStatusPollInterval: 10 * time.Millisecond,
+			// A single subscription is open at once in the tests
+			MaxBlobStatusSubscriptions:        1,
+			MaxBlobStatusSubscriptionLifetime: 2 * time.Second,
 		},
 		blobStore,
 		blobMetadataStore,
+		blobNotifier,
 		chainReader,
 		meterer,
 		auth.NewBlobRequestAuthenticator(),
@@ -601,6 +800,7 @@ func newTestServerV2(t *testing.T) *testComponents {
 		ntpClock,
 		// reserved only mode
 		false,
+		nil,
 	)
 	assert.NoError(t, err)
 
@@ -616,12 +816,13 @@ func newTestServerV2(t *testing.T) *testComponents {
 	}
 
 	return &testComponents{
-		DispersalServerV2: s,
-		BlobStore:         blobStore,
-		BlobMetadataStore: blobMetadataStore,
-		ChainReader:       chainReader,
-		Signer:            signer,
-		Peer:              p,
+		DispersalServerV2:   s,
+		BlobStore:           blobStore,
+		BlobMetadataStore:   blobMetadataStore,
+		ChainReader:         chainReader,
+		Signer:              signer,
+		Peer:                p,
+		QueuedNotifications: queuedNotifications,
 	}
 }
 
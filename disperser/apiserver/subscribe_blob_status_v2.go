@@ -0,0 +1,76 @@
+package apiserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/api"
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"google.golang.org/protobuf/proto"
+)
+
+// SubscribeBlobStatus streams the status of a blob until it reaches a terminal status, or until the maximum lifetime
+// of a subscription is reached. The status is read by a poller shared by all subscriptions, and a reply is sent only
+// when the status or the attestation of the blob changes.
+func (s *DispersalServerV2) SubscribeBlobStatus(
+	req *pb.SubscribeBlobStatusRequest,
+	stream pb.Disperser_SubscribeBlobStatusServer,
+) error {
+	streamCtx := stream.Context()
+
+	if req.GetBlobKey() == nil || len(req.GetBlobKey()) != 32 {
+		return api.NewErrorInvalidArg("blob key must be present and with 32 bytes")
+	}
+
+	blobKey, err := corev2.BytesToBlobKey(req.GetBlobKey())
+	if err != nil {
+		return api.NewErrorInvalidArg(fmt.Sprintf("failed to parse the blob key bytes: %v", err))
+	}
+
+	subscription, err := s.blobStatusPoller.subscribe(blobKey)
+	if err != nil {
+		if errors.Is(err, errTooManyBlobStatusSubscriptions) {
+			return api.NewErrorResourceExhausted(err.Error())
+		}
+		return api.NewErrorInternal(err.Error())
+	}
+	defer s.blobStatusPoller.unsubscribe(blobKey, subscription)
+
+	s.metrics.reportBlobStatusSubscriptionStarted()
+	defer s.metrics.reportBlobStatusSubscriptionEnded()
+
+	ctx, cancel := context.WithTimeout(streamCtx, s.serverConfig.MaxBlobStatusSubscriptionLifetime)
+	defer cancel()
+
+	// The current status is read right away, rather than waiting for the next poll
+	reply, err := s.getBlobStatus(ctx, blobKey)
+	var lastReply *pb.BlobStatusReply
+	for {
+		if err != nil {
+			return err
+		}
+
+		if !proto.Equal(reply, lastReply) {
+			if err := stream.Send(reply); err != nil {
+				return fmt.Errorf("failed to send blob status: %w", err)
+			}
+			lastReply = reply
+		}
+
+		if reply.GetStatus() == pb.BlobStatus_COMPLETE || reply.GetStatus() == pb.BlobStatus_FAILED {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if streamCtx.Err() != nil {
+				return streamCtx.Err()
+			}
+			return api.NewErrorDeadlineExceeded("maximum lifetime of the blob status subscription reached")
+		case update := <-subscription.updates:
+			reply, err = update.reply, update.err
+		}
+	}
+}
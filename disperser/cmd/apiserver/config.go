@@ -12,7 +12,9 @@ import (
 	"github.com/Layr-Labs/eigenda/disperser/apiserver"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/apiserver/flags"
 	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
+	blobstorev2 "github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli"
 )
 
@@ -24,9 +26,15 @@ const (
 )
 
 type Config struct {
-	DisperserVersion            DisperserVersion
-	AwsClientConfig             aws.ClientConfig
-	BlobstoreConfig             blobstore.Config
+	DisperserVersion DisperserVersion
+	AwsClientConfig  aws.ClientConfig
+	BlobstoreConfig  blobstore.Config
+	// MetadataStoreBackend is the backend of the v2 blob metadata store
+	MetadataStoreBackend blobstorev2.BackendType
+	PostgresURL          string
+	// ControllerNotificationURL is the URL of the blob notification endpoint of the controller. Queued blobs are not
+	// notified if it is empty.
+	ControllerNotificationURL   string
 	ServerConfig                disperser.ServerConfig
 	LoggerConfig                common.LoggerConfig
 	MetricsConfig               disperser.MetricsConfig
@@ -36,10 +44,13 @@ type Config struct {
 	EnableRatelimiter           bool
 	EnablePaymentMeterer        bool
 	ReservedOnly                bool
+	PremiumAccounts             []gethcommon.Address
 	ChainReadTimeout            time.Duration
 	ReservationsTableName       string
 	OnDemandTableName           string
 	GlobalRateTableName         string
+	MeteringStorePath           string
+	MeteringStoreBinRetention   time.Duration
 	BucketTableName             string
 	BucketStoreSize             int
 	EthClientConfig             geth.EthClientConfig
@@ -47,6 +58,10 @@ type Config struct {
 	MaxNumSymbolsPerBlob        uint
 	OnchainStateRefreshInterval time.Duration
 
+	// PaymentVaultSyncInterval is the interval at which PaymentVault events are applied. Zero disables event sync.
+	PaymentVaultSyncInterval      time.Duration
+	PaymentVaultConfirmationDepth uint64
+
 	BLSOperatorStateRetrieverAddr   string
 	EigenDAServiceManagerAddr       string
 	AuthPmtStateRequestMaxPastAge   time.Duration
@@ -96,20 +111,43 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 		}
 	}
 
+	metadataStoreBackend := blobstorev2.BackendType(ctx.GlobalString(flags.MetadataStoreBackendFlag.Name))
+	if metadataStoreBackend != blobstorev2.BackendDynamoDB && metadataStoreBackend != blobstorev2.BackendPostgreSQL {
+		return Config{}, fmt.Errorf("unknown metadata store backend %s", metadataStoreBackend)
+	}
+	postgresURL := ctx.GlobalString(flags.PostgresURLFlag.Name)
+	if version == uint(V2) && metadataStoreBackend == blobstorev2.BackendPostgreSQL && postgresURL == "" {
+		return Config{}, fmt.Errorf("PostgresURL must be specified for the postgresql metadata store backend")
+	}
+
+	premiumAccounts := make([]gethcommon.Address, 0)
+	for _, account := range ctx.GlobalStringSlice(flags.PremiumAccounts.Name) {
+		if !gethcommon.IsHexAddress(account) {
+			return Config{}, fmt.Errorf("invalid premium account: %s", account)
+		}
+		premiumAccounts = append(premiumAccounts, gethcommon.HexToAddress(account))
+	}
+
 	config := Config{
 		DisperserVersion: DisperserVersion(version),
 		AwsClientConfig:  aws.ReadClientConfig(ctx, flags.FlagPrefix),
 		ServerConfig: disperser.ServerConfig{
-			GrpcPort:      ctx.GlobalString(flags.GrpcPortFlag.Name),
-			GrpcTimeout:   ctx.GlobalDuration(flags.GrpcTimeoutFlag.Name),
-			PprofHttpPort: ctx.GlobalString(flags.PprofHttpPort.Name),
-			EnablePprof:   ctx.GlobalBool(flags.EnablePprof.Name),
+			GrpcPort:                          ctx.GlobalString(flags.GrpcPortFlag.Name),
+			GrpcTimeout:                       ctx.GlobalDuration(flags.GrpcTimeoutFlag.Name),
+			BlobStatusPollInterval:            ctx.GlobalDuration(flags.BlobStatusPollIntervalFlag.Name),
+			MaxBlobStatusSubscriptions:        ctx.GlobalInt(flags.MaxBlobStatusSubscriptionsFlag.Name),
+			MaxBlobStatusSubscriptionLifetime: ctx.GlobalDuration(flags.MaxBlobStatusSubscriptionLifetimeFlag.Name),
+			PprofHttpPort:                     ctx.GlobalString(flags.PprofHttpPort.Name),
+			EnablePprof:                       ctx.GlobalBool(flags.EnablePprof.Name),
 		},
 		BlobstoreConfig: blobstore.Config{
 			BucketName: ctx.GlobalString(flags.S3BucketNameFlag.Name),
 			TableName:  ctx.GlobalString(flags.DynamoDBTableNameFlag.Name),
 		},
-		LoggerConfig: *loggerConfig,
+		MetadataStoreBackend:      metadataStoreBackend,
+		PostgresURL:               postgresURL,
+		ControllerNotificationURL: ctx.GlobalString(flags.ControllerNotificationURLFlag.Name),
+		LoggerConfig:              *loggerConfig,
 		MetricsConfig: disperser.MetricsConfig{
 			HTTPPort:      ctx.GlobalString(flags.MetricsHTTPPort.Name),
 			EnableMetrics: ctx.GlobalBool(flags.EnableMetrics.Name),
@@ -120,9 +158,12 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 		EnableRatelimiter:           ctx.GlobalBool(flags.EnableRatelimiter.Name),
 		EnablePaymentMeterer:        ctx.GlobalBool(flags.EnablePaymentMeterer.Name),
 		ReservedOnly:                ctx.GlobalBoolT(flags.ReservedOnly.Name),
+		PremiumAccounts:             premiumAccounts,
 		ReservationsTableName:       ctx.GlobalString(flags.ReservationsTableName.Name),
 		OnDemandTableName:           ctx.GlobalString(flags.OnDemandTableName.Name),
 		GlobalRateTableName:         ctx.GlobalString(flags.GlobalRateTableName.Name),
+		MeteringStorePath:           ctx.GlobalString(flags.MeteringStorePath.Name),
+		MeteringStoreBinRetention:   ctx.GlobalDuration(flags.MeteringStoreBinRetention.Name),
 		BucketTableName:             ctx.GlobalString(flags.BucketTableName.Name),
 		BucketStoreSize:             ctx.GlobalInt(flags.BucketStoreSize.Name),
 		ChainReadTimeout:            ctx.GlobalDuration(flags.ChainReadTimeout.Name),
@@ -131,6 +172,9 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 		MaxNumSymbolsPerBlob:        ctx.GlobalUint(flags.MaxNumSymbolsPerBlob.Name),
 		OnchainStateRefreshInterval: ctx.GlobalDuration(flags.OnchainStateRefreshInterval.Name),
 
+		PaymentVaultSyncInterval:      ctx.GlobalDuration(flags.PaymentVaultSyncInterval.Name),
+		PaymentVaultConfirmationDepth: ctx.GlobalUint64(flags.PaymentVaultConfirmationDepth.Name),
+
 		BLSOperatorStateRetrieverAddr:   ctx.GlobalString(flags.BlsOperatorStateRetrieverFlag.Name),
 		EigenDAServiceManagerAddr:       ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name),
 		AuthPmtStateRequestMaxPastAge:   ctx.GlobalDuration(flags.AuthPmtStateRequestMaxPastAge.Name),
@@ -46,6 +46,27 @@ var (
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "GRPC_STREAM_TIMEOUT"),
 		Value:    time.Second * 10,
 	}
+	BlobStatusPollIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-status-poll-interval"),
+		Usage:    "How often the status of a blob is read while a client is subscribed to it",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BLOB_STATUS_POLL_INTERVAL"),
+		Value:    time.Second,
+	}
+	MaxBlobStatusSubscriptionsFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-blob-status-subscriptions"),
+		Usage:    "Maximum number of blob status subscriptions open at once. Further subscriptions are rejected",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_BLOB_STATUS_SUBSCRIPTIONS"),
+		Value:    1000,
+	}
+	MaxBlobStatusSubscriptionLifetimeFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-blob-status-subscription-lifetime"),
+		Usage:    "Maximum duration of a blob status subscription",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_BLOB_STATUS_SUBSCRIPTION_LIFETIME"),
+		Value:    5 * time.Minute,
+	}
 	BlsOperatorStateRetrieverFlag = cli.StringFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "bls-operator-state-retriever"),
 		Usage:    "Address of the BLS Operator State Retriever",
@@ -59,6 +80,25 @@ var (
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "EIGENDA_SERVICE_MANAGER"),
 	}
 	/* Optional Flags*/
+	MetadataStoreBackendFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "metadata-store-backend"),
+		Usage:    "Backend of the blob metadata store. Options are dynamodb and postgresql. This flag is only relevant in v2",
+		Required: false,
+		Value:    "dynamodb",
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "METADATA_STORE_BACKEND"),
+	}
+	PostgresURLFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "postgres-url"),
+		Usage:    "URL of the PostgreSQL database used as the blob metadata store. Required if the metadata store backend is postgresql",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "POSTGRES_URL"),
+	}
+	ControllerNotificationURLFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "controller-notification-url"),
+		Usage:    "URL of the blob notification endpoint of the controller (e.g. http://controller:9102), which is signaled when blobs are queued so that they are encoded without waiting for the next encoding pull. Works with any metadata store backend. If not set, and the controller does not read the DynamoDB stream of the blob metadata table, queued blobs wait up to one encoding pull interval. This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CONTROLLER_NOTIFICATION_URL"),
+	}
 	DisperserVersionFlag = cli.UintFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "disperser-version"),
 		Usage:    "Disperser version. Options are 1 and 2.",
@@ -107,6 +147,19 @@ var (
 		Value:  "global_rate",
 		EnvVar: common.PrefixEnvVar(envVarPrefix, "GLOBAL_RATE_TABLE_NAME"),
 	}
+	MeteringStorePath = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "metering-store-path"),
+		Usage:    "If set, payment usage is kept in an embedded LevelDB database at this path instead of the DynamoDB metering tables. Only suitable for a single disperser instance. This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "METERING_STORE_PATH"),
+	}
+	MeteringStoreBinRetention = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "metering-store-bin-retention"),
+		Usage:    "How long reservation and global rate bins are kept in the embedded metering store. Must be longer than the reservation window. This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "METERING_STORE_BIN_RETENTION"),
+		Value:    24 * time.Hour,
+	}
 	ChainReadTimeout = cli.DurationFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "chain-read-timeout"),
 		Usage:    "timeout for reading from the chain",
@@ -141,6 +194,20 @@ var (
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ONCHAIN_STATE_REFRESH_INTERVAL"),
 		Value:    1 * time.Minute,
 	}
+	PaymentVaultSyncInterval = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "payment-vault-sync-interval"),
+		Usage:    "The interval at which to apply new PaymentVault events to the cached onchain payment state. Set to 0 to rely on the periodic full refresh only. This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "PAYMENT_VAULT_SYNC_INTERVAL"),
+		Value:    12 * time.Second,
+	}
+	PaymentVaultConfirmationDepth = cli.Uint64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "payment-vault-confirmation-depth"),
+		Usage:    "Number of blocks that must be built on top of a PaymentVault event before it is applied. This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "PAYMENT_VAULT_CONFIRMATION_DEPTH"),
+		Value:    5,
+	}
 	MaxNumSymbolsPerBlob = cli.UintFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "max-num-symbols-per-blob"),
 		Usage:    "max number of symbols per blob. This flag is only relevant in v2",
@@ -196,6 +263,12 @@ var (
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "RESERVED_ONLY"),
 		Hidden:   false,
 	}
+	PremiumAccounts = cli.StringSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "premium-accounts"),
+		Usage:    "Accounts whose blobs are encoded and dispatched with premium priority. This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "PREMIUM_ACCOUNTS"),
+	}
 )
 
 var kzgFlags = []cli.Flag{
@@ -276,17 +349,27 @@ var requiredFlags = []cli.Flag{
 
 var optionalFlags = []cli.Flag{
 	DisperserVersionFlag,
+	MetadataStoreBackendFlag,
+	PostgresURLFlag,
+	ControllerNotificationURLFlag,
 	MetricsHTTPPort,
 	EnableMetrics,
 	EnableRatelimiter,
 	EnablePaymentMeterer,
 	BucketStoreSize,
 	GrpcTimeoutFlag,
+	BlobStatusPollIntervalFlag,
+	MaxBlobStatusSubscriptionsFlag,
+	MaxBlobStatusSubscriptionLifetimeFlag,
 	MaxBlobSize,
 	ReservationsTableName,
 	OnDemandTableName,
 	GlobalRateTableName,
+	MeteringStorePath,
+	MeteringStoreBinRetention,
 	OnchainStateRefreshInterval,
+	PaymentVaultSyncInterval,
+	PaymentVaultConfirmationDepth,
 	MaxNumSymbolsPerBlob,
 	PprofHttpPort,
 	EnablePprof,
@@ -295,6 +378,7 @@ var optionalFlags = []cli.Flag{
 	NtpServerFlag,
 	NtpSyncIntervalFlag,
 	ReservedOnly,
+	PremiumAccounts,
 }
 
 // Flags contains the list of configuration options available to the binary.
@@ -12,6 +12,7 @@ import (
 	"github.com/Layr-Labs/eigenda/disperser/apiserver"
 	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
 	blobstorev2 "github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/notifier"
 	"github.com/Layr-Labs/eigenda/encoding/fft"
 	"github.com/Layr-Labs/eigenda/encoding/kzg/prover"
 	"github.com/prometheus/client_golang/prometheus"
@@ -19,6 +20,7 @@ import (
 	"github.com/Layr-Labs/eigenda/common/aws/dynamodb"
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/kvstore/tablestore"
 	"github.com/Layr-Labs/eigenda/common/ratelimit"
 	"github.com/Layr-Labs/eigenda/common/store"
 	"github.com/Layr-Labs/eigenda/core"
@@ -38,6 +40,10 @@ var (
 	gitDate   string
 )
 
+// controllerNotificationTimeout is the timeout of the requests that notify the controller of queued blobs. A
+// notification that times out is dropped, and the blob is picked up at the next encoding pull.
+const controllerNotificationTimeout = time.Second
+
 func main() {
 	app := cli.NewApp()
 	app.Flags = flags.Flags
@@ -121,17 +127,50 @@ func RunDisperserServer(ctx *cli.Context) error {
 		if err != nil {
 			return fmt.Errorf("failed to create onchain payment state: %w", err)
 		}
+
+		// The syncer must be created before the initial query, so that no events are missed in between.
+		var paymentVaultSyncer *mt.PaymentVaultSyncer
+		if config.PaymentVaultSyncInterval > 0 {
+			paymentVaultSyncer, err = mt.NewPaymentVaultSyncer(
+				context.Background(),
+				mt.PaymentVaultSyncerConfig{
+					ConfirmationDepth: config.PaymentVaultConfirmationDepth,
+					PollInterval:      config.PaymentVaultSyncInterval,
+					ChainReadTimeout:  config.ChainReadTimeout,
+				},
+				paymentChainState,
+				client,
+				transactor.GetPaymentVaultAddress(),
+				logger)
+			if err != nil {
+				return fmt.Errorf("failed to create payment vault syncer: %w", err)
+			}
+		}
+
 		if err := paymentChainState.RefreshOnchainPaymentState(context.Background()); err != nil {
 			return fmt.Errorf("failed to make initial query to the on-chain state: %w", err)
 		}
+		if paymentVaultSyncer != nil {
+			paymentVaultSyncer.Start(context.Background())
+		}
 
-		meteringStore, err := mt.NewDynamoDBMeteringStore(
-			config.AwsClientConfig,
-			config.ReservationsTableName,
-			config.OnDemandTableName,
-			config.GlobalRateTableName,
-			logger,
-		)
+		var meteringStore mt.MeteringStore
+		if config.MeteringStorePath != "" {
+			logger.Info("Using embedded metering store", "path", config.MeteringStorePath)
+			meteringStore, err = mt.NewEmbeddedMeteringStore(
+				tablestore.DefaultLevelDBConfig(config.MeteringStorePath),
+				config.MeteringStoreBinRetention,
+				logger,
+			)
+		} else {
+			meteringStore, err = mt.NewDynamoDBMeteringStore(
+				config.AwsClientConfig,
+				config.ReservationsTableName,
+				config.OnDemandTableName,
+				config.GlobalRateTableName,
+				logger,
+			)
+		}
 		if err != nil {
 			return fmt.Errorf("failed to create offchain store: %w", err)
 		}
@@ -182,18 +221,37 @@ func RunDisperserServer(ctx *cli.Context) error {
 		if err != nil {
 			return fmt.Errorf("failed to create encoder: %w", err)
 		}
-		baseBlobMetadataStore := blobstorev2.NewBlobMetadataStore(dynamoClient, logger, config.BlobstoreConfig.TableName)
+		var baseBlobMetadataStore blobstorev2.MetadataStore
+		if config.MetadataStoreBackend == blobstorev2.BackendPostgreSQL {
+			baseBlobMetadataStore, err = blobstorev2.ConnectPostgresBlobMetadataStore(context.Background(), config.PostgresURL, logger)
+			if err != nil {
+				return err
+			}
+		} else {
+			baseBlobMetadataStore = blobstorev2.NewBlobMetadataStore(dynamoClient, logger, config.BlobstoreConfig.TableName)
+		}
 		blobMetadataStore := blobstorev2.NewInstrumentedMetadataStore(baseBlobMetadataStore, blobstorev2.InstrumentedMetadataStoreConfig{
 			ServiceName: "apiserver",
 			Registry:    reg,
-			Backend:     blobstorev2.BackendDynamoDB,
+			Backend:     config.MetadataStoreBackend,
 		})
 		blobStore := blobstorev2.NewBlobStore(bucketName, s3Client, logger)
 
+		// The controller runs in a separate process, and is signaled of queued blobs over HTTP if configured
+		var blobNotifier notifier.Publisher
+		if config.ControllerNotificationURL != "" {
+			blobNotifier, err = notifier.NewHTTPPublisher(
+				context.Background(), config.ControllerNotificationURL, controllerNotificationTimeout, logger)
+			if err != nil {
+				return fmt.Errorf("failed to create controller notification publisher: %w", err)
+			}
+		}
+
 		server, err := apiserver.NewDispersalServerV2(
 			config.ServerConfig,
 			blobStore,
 			blobMetadataStore,
+			blobNotifier,
 			transactor,
 			meterer,
 			authv2.NewPaymentStateAuthenticator(config.AuthPmtStateRequestMaxPastAge, config.AuthPmtStateRequestMaxFutureAge),
@@ -205,6 +263,7 @@ func RunDisperserServer(ctx *cli.Context) error {
 			config.MetricsConfig,
 			ntpClock,
 			config.ReservedOnly,
+			config.PremiumAccounts,
 		)
 		if err != nil {
 			return err
@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
@@ -9,6 +10,8 @@ import (
 	"github.com/Layr-Labs/eigenda/core/thegraph"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/controller/flags"
+	dispv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
 	"github.com/Layr-Labs/eigenda/disperser/controller"
 	"github.com/Layr-Labs/eigenda/indexer"
 	"github.com/urfave/cli"
@@ -22,8 +25,22 @@ type Config struct {
 	NumConcurrentEncodingRequests  int
 	NumConcurrentDispersalRequests int
 	NodeClientCacheSize            int
+	// RelaySelectorConfig configures the selection of relays from the relay registry, which is used when
+	// EncodingManagerConfig.AvailableRelays is empty
+	RelaySelectorConfig controller.RelaySelectorConfig
+	RelayUseSecureGrpc  bool
 
 	DynamoDBTableName string
+	// MetadataStoreBackend is the backend of the blob metadata store
+	MetadataStoreBackend blobstore.BackendType
+	PostgresURL          string
+	// BlobMetadataStreamEnabled is whether the controller reads the DynamoDB stream of the blob metadata table to
+	// learn of queued blobs
+	BlobMetadataStreamEnabled      bool
+	BlobMetadataStreamPollInterval time.Duration
+	// BlobNotificationPort is the port of the HTTP endpoint at which the API servers notify queued blobs. The endpoint
+	// is disabled if it is 0.
+	BlobNotificationPort int
 
 	EthClientConfig                     geth.EthClientConfig
 	AwsClientConfig                     aws.ClientConfig
@@ -53,9 +70,6 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 		return Config{}, fmt.Errorf("invalid number of relay assignments: %d", numRelayAssignments)
 	}
 	availableRelays := ctx.GlobalIntSlice(flags.AvailableRelaysFlag.Name)
-	if len(availableRelays) == 0 {
-		return Config{}, fmt.Errorf("no available relays specified")
-	}
 	relays := make([]corev2.RelayKey, len(availableRelays))
 	for i, relay := range availableRelays {
 		if relay < 0 || relay > 65_535 {
@@ -63,8 +77,22 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 		}
 		relays[i] = corev2.RelayKey(relay)
 	}
+	encoderAddresses := ctx.GlobalStringSlice(flags.EncoderAddressFlag.Name)
+	if len(encoderAddresses) == 0 {
+		return Config{}, fmt.Errorf("no encoder addresses specified")
+	}
+	priorityWeights := controller.PriorityWeights{
+		dispv2.PriorityStandard: ctx.GlobalUint64(flags.StandardPriorityWeightFlag.Name),
+		dispv2.PriorityReserved: ctx.GlobalUint64(flags.ReservedPriorityWeightFlag.Name),
+		dispv2.PriorityPremium:  ctx.GlobalUint64(flags.PremiumPriorityWeightFlag.Name),
+	}
 	config := Config{
 		DynamoDBTableName:                   ctx.GlobalString(flags.DynamoDBTableNameFlag.Name),
+		MetadataStoreBackend:                blobstore.BackendType(ctx.GlobalString(flags.MetadataStoreBackendFlag.Name)),
+		PostgresURL:                         ctx.GlobalString(flags.PostgresURLFlag.Name),
+		BlobMetadataStreamEnabled:           ctx.GlobalBool(flags.BlobMetadataStreamEnabledFlag.Name),
+		BlobMetadataStreamPollInterval:      ctx.GlobalDuration(flags.BlobMetadataStreamPollIntervalFlag.Name),
+		BlobNotificationPort:                ctx.GlobalInt(flags.BlobNotificationPortFlag.Name),
 		EthClientConfig:                     ethClientConfig,
 		AwsClientConfig:                     aws.ReadClientConfig(ctx, flags.FlagPrefix),
 		DisperserStoreChunksSigningDisabled: ctx.GlobalBool(flags.DisperserStoreChunksSigningDisabledFlag.Name),
@@ -77,27 +105,41 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 			NumEncodingRetries:          ctx.GlobalInt(flags.NumEncodingRetriesFlag.Name),
 			NumRelayAssignment:          uint16(numRelayAssignments),
 			AvailableRelays:             relays,
-			EncoderAddress:              ctx.GlobalString(flags.EncoderAddressFlag.Name),
+			EncoderAddresses:            encoderAddresses,
+			EncoderBackoffDuration:      ctx.GlobalDuration(flags.EncoderBackoffDurationFlag.Name),
 			MaxNumBlobsPerIteration:     int32(ctx.GlobalInt(flags.MaxNumBlobsPerIterationFlag.Name)),
+			MaxBlobsPerEncodingRequest:  ctx.GlobalInt(flags.MaxBlobsPerEncodingRequestFlag.Name),
 			OnchainStateRefreshInterval: ctx.GlobalDuration(flags.OnchainStateRefreshIntervalFlag.Name),
+			PriorityWeights:             priorityWeights,
 		},
 		DispatcherConfig: controller.DispatcherConfig{
 			PullInterval:                          ctx.GlobalDuration(flags.DispatcherPullIntervalFlag.Name),
+			MinBatchInterval:                      ctx.GlobalDuration(flags.DispatcherMinBatchIntervalFlag.Name),
 			FinalizationBlockDelay:                ctx.GlobalUint64(flags.FinalizationBlockDelayFlag.Name),
 			AttestationTimeout:                    ctx.GlobalDuration(flags.AttestationTimeoutFlag.Name),
 			BatchAttestationTimeout:               ctx.GlobalDuration(flags.BatchAttestationTimeoutFlag.Name),
 			SignatureTickInterval:                 ctx.GlobalDuration(flags.SignatureTickIntervalFlag.Name),
 			NumRequestRetries:                     ctx.GlobalInt(flags.NumRequestRetriesFlag.Name),
 			MaxBatchSize:                          int32(ctx.GlobalInt(flags.MaxBatchSizeFlag.Name)),
+			MaxBatchSymbols:                       ctx.GlobalUint64(flags.MaxBatchSymbolsFlag.Name),
+			MaxBatchChunkBytesPerValidator:        ctx.GlobalUint64(flags.MaxBatchChunkBytesPerValidatorFlag.Name),
 			SignificantSigningThresholdPercentage: uint8(ctx.GlobalUint(flags.SignificantSigningThresholdPercentageFlag.Name)),
 			SignificantSigningMetricsThresholds:   ctx.GlobalStringSlice(flags.SignificantSigningMetricsThresholdsFlag.Name),
+			PriorityWeights:                       priorityWeights,
 		},
 		NumConcurrentEncodingRequests:  ctx.GlobalInt(flags.NumConcurrentEncodingRequestsFlag.Name),
 		NumConcurrentDispersalRequests: ctx.GlobalInt(flags.NumConcurrentDispersalRequestsFlag.Name),
 		NodeClientCacheSize:            ctx.GlobalInt(flags.NodeClientCacheNumEntriesFlag.Name),
-		IndexerConfig:                  indexer.ReadIndexerConfig(ctx),
-		ChainStateConfig:               thegraph.ReadCLIConfig(ctx),
-		UseGraph:                       ctx.GlobalBool(flags.UseGraphFlag.Name),
+		RelaySelectorConfig: controller.RelaySelectorConfig{
+			ProbeInterval:       ctx.GlobalDuration(flags.RelayProbeIntervalFlag.Name),
+			ProbeTimeout:        ctx.GlobalDuration(flags.RelayProbeTimeoutFlag.Name),
+			MaxUtilization:      ctx.GlobalFloat64(flags.RelayMaxUtilizationFlag.Name),
+			StoredBlobsLookback: ctx.GlobalDuration(flags.RelayStoredBlobsLookbackFlag.Name),
+		},
+		RelayUseSecureGrpc: ctx.GlobalBool(flags.RelayUseSecureGrpcFlag.Name),
+		IndexerConfig:      indexer.ReadIndexerConfig(ctx),
+		ChainStateConfig:   thegraph.ReadCLIConfig(ctx),
+		UseGraph:           ctx.GlobalBool(flags.UseGraphFlag.Name),
 
 		BLSOperatorStateRetrieverAddr: ctx.GlobalString(flags.BlsOperatorStateRetrieverFlag.Name),
 		EigenDAServiceManagerAddr:     ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name),
@@ -105,6 +147,23 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 		ControllerReadinessProbePath:  ctx.GlobalString(flags.ControllerReadinessProbePathFlag.Name),
 		ControllerHealthProbePath:     ctx.GlobalString(flags.ControllerHealthProbePathFlag.Name),
 	}
+	switch config.MetadataStoreBackend {
+	case blobstore.BackendDynamoDB:
+	case blobstore.BackendPostgreSQL:
+		if config.PostgresURL == "" {
+			return Config{}, fmt.Errorf("PostgresURL must be specified for the postgresql metadata store backend")
+		}
+		if config.BlobMetadataStreamEnabled {
+			return Config{}, fmt.Errorf(
+				"the blob metadata stream is only available with the dynamodb metadata store backend, " +
+					"use the blob notification port to notify queued blobs with postgresql")
+		}
+	default:
+		return Config{}, fmt.Errorf("unknown metadata store backend %s", config.MetadataStoreBackend)
+	}
+	if config.BlobNotificationPort != 0 && config.BlobNotificationPort == config.MetricsPort {
+		return Config{}, fmt.Errorf("BlobNotificationPort must differ from MetricsPort")
+	}
 	if !config.DisperserStoreChunksSigningDisabled && config.DisperserKMSKeyID == "" {
 		return Config{}, fmt.Errorf("DisperserKMSKeyID is required when StoreChunks() signing is enabled")
 	}
@@ -23,6 +23,19 @@ var (
 		Required: true,
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "DYNAMODB_TABLE_NAME"),
 	}
+	MetadataStoreBackendFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "metadata-store-backend"),
+		Usage:    "Backend of the blob metadata store. Options are dynamodb and postgresql",
+		Required: false,
+		Value:    "dynamodb",
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "METADATA_STORE_BACKEND"),
+	}
+	PostgresURLFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "postgres-url"),
+		Usage:    "URL of the PostgreSQL database used as the blob metadata store. Required if the metadata store backend is postgresql",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "POSTGRES_URL"),
+	}
 	BlsOperatorStateRetrieverFlag = cli.StringFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "bls-operator-state-retriever"),
 		Usage:    "Address of the BLS Operator State Retriever",
@@ -48,6 +61,26 @@ var (
 		Required: false,
 		Value:    "./data/",
 	}
+	BlobMetadataStreamEnabledFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-metadata-stream-enabled"),
+		Usage:    "Whether to read the DynamoDB stream of the blob metadata table to encode queued blobs without waiting for the next pull. The stream is enabled on the table if it is not already",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BLOB_METADATA_STREAM_ENABLED"),
+	}
+	BlobNotificationPortFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-notification-port"),
+		Usage:    "Port of the HTTP endpoint at which the API servers signal queued blobs, so that they are encoded without waiting for the next pull. Works with any metadata store backend. The API servers must be configured with the URL of the endpoint. Disabled if 0. Without this endpoint or the DynamoDB stream of the blob metadata table, queued blobs wait up to one encoding pull interval",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BLOB_NOTIFICATION_PORT"),
+		Value:    0,
+	}
+	BlobMetadataStreamPollIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-metadata-stream-poll-interval"),
+		Usage:    "Interval at which to read the DynamoDB stream of the blob metadata table",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BLOB_METADATA_STREAM_POLL_INTERVAL"),
+		Value:    250 * time.Millisecond,
+	}
 	// EncodingManager Flags
 	EncodingPullIntervalFlag = cli.DurationFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "encoding-pull-interval"),
@@ -58,16 +91,57 @@ var (
 	}
 	AvailableRelaysFlag = cli.IntSliceFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "available-relays"),
-		Usage:    "List of available relays",
-		Required: true,
+		Usage:    "Static list of relays to assign blobs to at random. If empty, the relays are read from the relay registry contract and selected by health and stored bytes",
+		Required: false,
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "AVAILABLE_RELAYS"),
 	}
-	EncoderAddressFlag = cli.StringFlag{
+	RelayProbeIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "relay-probe-interval"),
+		Usage:    "Interval at which the relay registry is refreshed and the relays are probed",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "RELAY_PROBE_INTERVAL"),
+		Value:    10 * time.Second,
+	}
+	RelayProbeTimeoutFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "relay-probe-timeout"),
+		Usage:    "Timeout of each probe of a relay",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "RELAY_PROBE_TIMEOUT"),
+		Value:    2 * time.Second,
+	}
+	RelayMaxUtilizationFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "relay-max-utilization"),
+		Usage:    "Fraction of its concurrent operation limit above which a relay is not assigned new blobs",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "RELAY_MAX_UTILIZATION"),
+		Value:    0.9,
+	}
+	RelayStoredBlobsLookbackFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "relay-stored-blobs-lookback"),
+		Usage:    "How far back blobs are read at startup to account the data stored by each relay. Should be at least the time-to-live of blobs",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "RELAY_STORED_BLOBS_LOOKBACK"),
+		Value:    14 * 24 * time.Hour,
+	}
+	RelayUseSecureGrpcFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "relay-use-secure-grpc"),
+		Usage:    "Whether to use TLS when probing the relays",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "RELAY_USE_SECURE_GRPC"),
+	}
+	EncoderAddressFlag = cli.StringSliceFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "encoder-address"),
-		Usage:    "the http ip:port which the distributed encoder server is listening",
+		Usage:    "the http ip:port which the distributed encoder servers are listening (comma separated for multiple encoders)",
 		Required: true,
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENCODER_ADDRESS"),
 	}
+	EncoderBackoffDurationFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "encoder-backoff-duration"),
+		Usage:    "Duration for which an encoder is avoided after it rejects a request because it is overloaded or unavailable",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENCODER_BACKOFF_DURATION"),
+		Value:    10 * time.Second,
+	}
 	EncodingRequestTimeoutFlag = cli.DurationFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "encoding-request-timeout"),
 		Usage:    "Timeout for encoding requests",
@@ -110,6 +184,13 @@ var (
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_NUM_BLOBS_PER_ITERATION"),
 		Value:    128,
 	}
+	MaxBlobsPerEncodingRequestFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-blobs-per-encoding-request"),
+		Usage:    "Max number of blobs with the same encoding parameters to send to an encoder in a single request. If 1, each blob is sent in its own request. Must not exceed the max-blobs-per-encode-blobs-request of the encoders",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_BLOBS_PER_ENCODING_REQUEST"),
+		Value:    16,
+	}
 	OnchainStateRefreshIntervalFlag = cli.DurationFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "onchain-state-refresh-interval"),
 		Usage:    "Interval at which to refresh the onchain state",
@@ -117,6 +198,27 @@ var (
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ONCHAIN_STATE_REFRESH_INTERVAL"),
 		Value:    1 * time.Hour,
 	}
+	StandardPriorityWeightFlag = cli.Uint64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "standard-priority-weight"),
+		Usage:    "Share of the encoding and dispatching capacity given to blobs paid for on demand, relative to the other priority classes",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "STANDARD_PRIORITY_WEIGHT"),
+		Value:    1,
+	}
+	ReservedPriorityWeightFlag = cli.Uint64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "reserved-priority-weight"),
+		Usage:    "Share of the encoding and dispatching capacity given to blobs paid for by a reservation, relative to the other priority classes",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "RESERVED_PRIORITY_WEIGHT"),
+		Value:    4,
+	}
+	PremiumPriorityWeightFlag = cli.Uint64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "premium-priority-weight"),
+		Usage:    "Share of the encoding and dispatching capacity given to blobs from premium accounts, relative to the other priority classes",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "PREMIUM_PRIORITY_WEIGHT"),
+		Value:    8,
+	}
 
 	// Dispatcher Flags
 	DispatcherPullIntervalFlag = cli.DurationFlag{
@@ -126,6 +228,13 @@ var (
 		Value:    1 * time.Second,
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "DISPATCHER_PULL_INTERVAL"),
 	}
+	DispatcherMinBatchIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "dispatcher-min-batch-interval"),
+		Usage:    "Minimum interval between the batches created as soon as blobs are encoded. The blobs encoded within the interval are batched together",
+		Required: false,
+		Value:    500 * time.Millisecond,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "DISPATCHER_MIN_BATCH_INTERVAL"),
+	}
 	AttestationTimeoutFlag = cli.DurationFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "attestation-timeout"),
 		Usage:    "Timeout for node requests",
@@ -182,6 +291,20 @@ var (
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_BATCH_SIZE"),
 		Value:    32,
 	}
+	MaxBatchSymbolsFlag = cli.Uint64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-batch-symbols"),
+		Usage:    "Max total number of symbols of the blobs in a batch (0 for no limit)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_BATCH_SYMBOLS"),
+		Value:    0,
+	}
+	MaxBatchChunkBytesPerValidatorFlag = cli.Uint64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-batch-chunk-bytes-per-validator"),
+		Usage:    "Max number of encoded chunk bytes sent to a single validator in a batch (0 for no limit)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_BATCH_CHUNK_BYTES_PER_VALIDATOR"),
+		Value:    0,
+	}
 	MetricsPortFlag = cli.IntFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "metrics-port"),
 		Usage:    "Port to expose metrics",
@@ -239,7 +362,6 @@ var requiredFlags = []cli.Flag{
 	EigenDAServiceManagerFlag,
 	UseGraphFlag,
 	EncodingPullIntervalFlag,
-	AvailableRelaysFlag,
 	EncoderAddressFlag,
 
 	DispatcherPullIntervalFlag,
@@ -249,20 +371,39 @@ var requiredFlags = []cli.Flag{
 
 var optionalFlags = []cli.Flag{
 	IndexerDataDirFlag,
+	MetadataStoreBackendFlag,
+	PostgresURLFlag,
+	BlobMetadataStreamEnabledFlag,
+	BlobMetadataStreamPollIntervalFlag,
+	BlobNotificationPortFlag,
 	EncodingRequestTimeoutFlag,
 	EncodingStoreTimeoutFlag,
 	NumEncodingRetriesFlag,
 	NumRelayAssignmentFlag,
+	AvailableRelaysFlag,
+	RelayProbeIntervalFlag,
+	RelayProbeTimeoutFlag,
+	RelayMaxUtilizationFlag,
+	RelayStoredBlobsLookbackFlag,
+	RelayUseSecureGrpcFlag,
 	NumConcurrentEncodingRequestsFlag,
 	MaxNumBlobsPerIterationFlag,
+	MaxBlobsPerEncodingRequestFlag,
 	OnchainStateRefreshIntervalFlag,
+	EncoderBackoffDurationFlag,
+	StandardPriorityWeightFlag,
+	ReservedPriorityWeightFlag,
+	PremiumPriorityWeightFlag,
 
 	SignatureTickIntervalFlag,
+	DispatcherMinBatchIntervalFlag,
 	FinalizationBlockDelayFlag,
 	NumRequestRetriesFlag,
 	NumConcurrentDispersalRequestsFlag,
 	NodeClientCacheNumEntriesFlag,
 	MaxBatchSizeFlag,
+	MaxBatchSymbolsFlag,
+	MaxBatchChunkBytesPerValidatorFlag,
 	MetricsPortFlag,
 	DisperserStoreChunksSigningDisabledFlag,
 	DisperserKMSKeyIDFlag,
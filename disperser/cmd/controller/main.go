@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/Layr-Labs/eigenda/api/clients/v2"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/relay"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -23,10 +24,14 @@ import (
 	"github.com/Layr-Labs/eigenda/core/indexer"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/controller/flags"
+	dispv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
 	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/notifier"
 	"github.com/Layr-Labs/eigenda/disperser/controller"
 	"github.com/Layr-Labs/eigenda/disperser/encoder"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/gammazero/workerpool"
@@ -103,22 +108,89 @@ func RunController(ctx *cli.Context) error {
 		Handler: mux,
 	}
 
-	baseBlobMetadataStore := blobstore.NewBlobMetadataStore(
-		dynamoClient,
-		logger,
-		config.DynamoDBTableName,
-	)
+	var baseBlobMetadataStore blobstore.MetadataStore
+	if config.MetadataStoreBackend == blobstore.BackendPostgreSQL {
+		baseBlobMetadataStore, err = blobstore.ConnectPostgresBlobMetadataStore(context.Background(), config.PostgresURL, logger)
+		if err != nil {
+			return err
+		}
+	} else {
+		baseBlobMetadataStore = blobstore.NewBlobMetadataStore(
+			dynamoClient,
+			logger,
+			config.DynamoDBTableName,
+		)
+	}
 	blobMetadataStore := blobstore.NewInstrumentedMetadataStore(baseBlobMetadataStore, blobstore.InstrumentedMetadataStoreConfig{
 		ServiceName: "controller",
 		Registry:    metricsRegistry,
-		Backend:     blobstore.BackendDynamoDB,
+		Backend:     config.MetadataStoreBackend,
 	})
 
 	controllerLivenessChan := make(chan healthcheck.HeartbeatMessage, 10)
 
-	encoderClient, err := encoder.NewEncoderClientV2(config.EncodingManagerConfig.EncoderAddress)
-	if err != nil {
-		return fmt.Errorf("failed to create encoder client: %v", err)
+	// The encoding manager and the dispatcher run in this process, so they notify each other directly, while the
+	// queued blobs are notified by the API servers over HTTP, or from the stream of the metadata table, if enabled
+	blobNotifier := notifier.NewInProcessNotifier()
+	var notificationServer *http.Server
+	if config.BlobNotificationPort != 0 {
+		notificationServer = &http.Server{
+			Addr:              fmt.Sprintf(":%d", config.BlobNotificationPort),
+			Handler:           notifier.NewHTTPHandler(blobNotifier, []dispv2.BlobStatus{dispv2.Queued}, logger),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+	}
+	var streamListener *notifier.StreamListener
+	if config.BlobMetadataStreamEnabled {
+		// The stream is only enabled by the schema of newly created tables, so it is enabled here on existing ones
+		err = dynamodb.EnableStream(
+			context.Background(), config.AwsClientConfig, config.DynamoDBTableName, types.StreamViewTypeNewImage)
+		if err != nil {
+			return err
+		}
+		streamsClient, err := dynamodb.NewStreamsClient(config.AwsClientConfig)
+		if err != nil {
+			return fmt.Errorf("failed to create dynamodb streams client: %v", err)
+		}
+		streamListener, err = notifier.NewStreamListener(
+			streamsClient,
+			config.DynamoDBTableName,
+			[]dispv2.BlobStatus{dispv2.Queued},
+			blobNotifier,
+			config.BlobMetadataStreamPollInterval,
+			logger,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create blob metadata stream listener: %v", err)
+		}
+	}
+
+	encoderClients := make(map[string]disperser.EncoderClientV2, len(config.EncodingManagerConfig.EncoderAddresses))
+	for _, address := range config.EncodingManagerConfig.EncoderAddresses {
+		encoderClient, err := encoder.NewEncoderClientV2(address)
+		if err != nil {
+			return fmt.Errorf("failed to create encoder client for %s: %v", address, err)
+		}
+		encoderClients[address] = encoderClient
+	}
+	// Without a static list of relays, blobs are assigned to the relays of the registry by their health and load
+	var relaySelector *controller.RelaySelector
+	if len(config.EncodingManagerConfig.AvailableRelays) == 0 {
+		relayUrlProvider, err := relay.NewRelayUrlProvider(gethClient, chainReader.GetRelayRegistryAddress())
+		if err != nil {
+			return fmt.Errorf("failed to create relay url provider: %v", err)
+		}
+		relaySelector, err = controller.NewRelaySelector(
+			&config.RelaySelectorConfig,
+			relayUrlProvider,
+			controller.NewRelayProber(config.RelayUseSecureGrpc),
+			blobMetadataStore,
+			metricsRegistry,
+			logger,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create relay selector: %v", err)
+		}
 	}
 	encodingPool := workerpool.New(config.NumConcurrentEncodingRequests)
 	encodingManagerBlobSet := controller.NewBlobSet()
@@ -126,11 +198,13 @@ func RunController(ctx *cli.Context) error {
 		&config.EncodingManagerConfig,
 		blobMetadataStore,
 		encodingPool,
-		encoderClient,
+		encoderClients,
 		chainReader,
 		logger,
 		metricsRegistry,
 		encodingManagerBlobSet,
+		blobNotifier,
+		relaySelector,
 		controllerLivenessChan,
 	)
 	if err != nil {
@@ -199,12 +273,14 @@ func RunController(ctx *cli.Context) error {
 		blobMetadataStore,
 		dispatcherPool,
 		ics,
+		chainReader,
 		sigAgg,
 		nodeClientManager,
 		logger,
 		metricsRegistry,
 		beforeDispatch,
 		dispatcherBlobSet,
+		blobNotifier,
 		controllerLivenessChan,
 	)
 	if err != nil {
@@ -228,6 +304,22 @@ func RunController(ctx *cli.Context) error {
 		return fmt.Errorf("failed to start dispatcher: %v", err)
 	}
 
+	if streamListener != nil {
+		err = streamListener.Start(c)
+		if err != nil {
+			return fmt.Errorf("failed to start blob metadata stream listener: %v", err)
+		}
+	}
+
+	if notificationServer != nil {
+		go func() {
+			err := notificationServer.ListenAndServe()
+			if err != nil && !strings.Contains(err.Error(), "http: Server closed") {
+				logger.Errorf("blob notification server error: %v", err)
+			}
+		}()
+	}
+
 	go func() {
 		err := metricsServer.ListenAndServe()
 		if err != nil && !strings.Contains(err.Error(), "http: Server closed") {
@@ -53,16 +53,17 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 		EncoderConfig: kzg.ReadCLIConfig(ctx),
 		LoggerConfig:  *loggerConfig,
 		ServerConfig: &encoder.ServerConfig{
-			GrpcPort:                 ctx.GlobalString(flags.GrpcPortFlag.Name),
-			MaxConcurrentRequests:    ctx.GlobalInt(flags.MaxConcurrentRequestsFlag.Name),
-			RequestPoolSize:          ctx.GlobalInt(flags.RequestPoolSizeFlag.Name),
-			RequestQueueSize:         ctx.GlobalInt(flags.RequestQueueSizeFlag.Name),
-			EnableGnarkChunkEncoding: ctx.Bool(flags.EnableGnarkChunkEncodingFlag.Name),
-			PreventReencoding:        ctx.Bool(flags.PreventReencodingFlag.Name),
-			Backend:                  ctx.String(flags.BackendFlag.Name),
-			GPUEnable:                ctx.Bool(flags.GPUEnableFlag.Name),
-			PprofHttpPort:            ctx.GlobalString(flags.PprofHttpPort.Name),
-			EnablePprof:              ctx.GlobalBool(flags.EnablePprof.Name),
+			GrpcPort:                      ctx.GlobalString(flags.GrpcPortFlag.Name),
+			MaxConcurrentRequests:         ctx.GlobalInt(flags.MaxConcurrentRequestsFlag.Name),
+			RequestPoolSize:               ctx.GlobalInt(flags.RequestPoolSizeFlag.Name),
+			RequestQueueSize:              ctx.GlobalInt(flags.RequestQueueSizeFlag.Name),
+			EnableGnarkChunkEncoding:      ctx.Bool(flags.EnableGnarkChunkEncodingFlag.Name),
+			PreventReencoding:             ctx.Bool(flags.PreventReencodingFlag.Name),
+			MaxBlobsPerEncodeBlobsRequest: ctx.GlobalInt(flags.MaxBlobsPerEncodeBlobsRequestFlag.Name),
+			Backend:                       ctx.String(flags.BackendFlag.Name),
+			GPUEnable:                     ctx.Bool(flags.GPUEnableFlag.Name),
+			PprofHttpPort:                 ctx.GlobalString(flags.PprofHttpPort.Name),
+			EnablePprof:                   ctx.GlobalBool(flags.EnablePprof.Name),
 		},
 		MetricsConfig: &encoder.MetricsConfig{
 			HTTPPort:      ctx.GlobalString(flags.MetricsHTTPPort.Name),
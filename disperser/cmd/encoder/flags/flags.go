@@ -94,6 +94,13 @@ var (
 		Required: false,
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "PREVENT_REENCODING"),
 	}
+	MaxBlobsPerEncodeBlobsRequestFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-blobs-per-encode-blobs-request"),
+		Usage:    "maximum number of blobs that can be encoded in a single EncodeBlobs request",
+		Required: false,
+		Value:    32,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_BLOBS_PER_ENCODE_BLOBS_REQUEST"),
+	}
 	PprofHttpPort = cli.StringFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "pprof-http-port"),
 		Usage:    "the http port which the pprof server is listening",
@@ -125,6 +132,7 @@ var optionalFlags = []cli.Flag{
 	GPUEnableFlag,
 	BackendFlag,
 	PreventReencodingFlag,
+	MaxBlobsPerEncodeBlobsRequestFlag,
 	PprofHttpPort,
 	EnablePprof,
 }
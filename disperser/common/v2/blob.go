@@ -69,6 +69,34 @@ func BlobStatusFromProtobuf(s pb.BlobStatus) (BlobStatus, error) {
 	}
 }
 
+// PriorityClass determines the share of the encoding and dispatching capacity given to a blob.
+type PriorityClass uint8
+
+const (
+	// PriorityStandard is the priority of blobs paid for on demand, and of blobs stored before priority classes
+	PriorityStandard PriorityClass = iota
+	// PriorityReserved is the priority of blobs paid for by a reservation
+	PriorityReserved
+	// PriorityPremium is the priority of blobs from allow-listed accounts
+	PriorityPremium
+)
+
+// PriorityClasses are all the priority classes, from lowest to highest priority.
+var PriorityClasses = []PriorityClass{PriorityStandard, PriorityReserved, PriorityPremium}
+
+func (p PriorityClass) String() string {
+	switch p {
+	case PriorityStandard:
+		return "standard"
+	case PriorityReserved:
+		return "reserved"
+	case PriorityPremium:
+		return "premium"
+	default:
+		return "unknown"
+	}
+}
+
 // BlobMetadata is an internal representation of a blob's metadata.
 type BlobMetadata struct {
 	BlobHeader *corev2.BlobHeader
@@ -86,6 +114,8 @@ type BlobMetadata struct {
 	RequestedAt uint64
 	// UpdatedAt is the Unix timestamp of when the blob was last updated in _nanoseconds_
 	UpdatedAt uint64
+	// Priority is the priority class of the blob, which determines its share of the encoding and dispatching capacity
+	Priority PriorityClass
 
 	*encoding.FragmentInfo
 }
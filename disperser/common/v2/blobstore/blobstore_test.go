@@ -18,6 +18,7 @@ import (
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
 	"github.com/ory/dockertest/v3"
@@ -32,12 +33,23 @@ var (
 	deployLocalStack bool
 	localStackPort   = "4571"
 
+	postgresDockertestPool     *dockertest.Pool
+	postgresDockertestResource *dockertest.Resource
+
+	// testPostgres is set if the PostgresBlobMetadataStore tests are run. This requires either DEPLOY_POSTGRES=true to
+	// start a Postgres container, or POSTGRES_PORT to use an existing server.
+	testPostgres   bool
+	deployPostgres bool
+	postgresPort   = "5433"
+
 	s3Client                s3.Client
 	dynamoClient            dynamodb.Client
 	mockDynamoClient        *mock.MockDynamoDBClient
 	blobStore               *blobstore.BlobStore
 	blobMetadataStore       *blobstore.BlobMetadataStore
 	mockedBlobMetadataStore *blobstore.BlobMetadataStore
+	postgresPool            *pgxpool.Pool
+	postgresMetadataStore   *blobstore.PostgresBlobMetadataStore
 
 	UUID              = uuid.New()
 	s3BucketName      = "test-eigenda-blobstore"
@@ -69,6 +81,21 @@ func setup(m *testing.M) {
 		}
 	}
 
+	deployPostgres = os.Getenv("DEPLOY_POSTGRES") == "true"
+	if os.Getenv("POSTGRES_PORT") != "" {
+		postgresPort = os.Getenv("POSTGRES_PORT")
+	}
+	testPostgres = deployPostgres || os.Getenv("POSTGRES_PORT") != ""
+
+	if deployPostgres {
+		var err error
+		postgresDockertestPool, postgresDockertestResource, err = deploy.StartDockertestWithPostgresContainer(postgresPort)
+		if err != nil {
+			teardown()
+			panic("failed to start postgres container")
+		}
+	}
+
 	cfg := aws.ClientConfig{
 		Region:          "us-east-1",
 		AccessKey:       "localstack",
@@ -104,6 +131,20 @@ func setup(m *testing.M) {
 	}
 	blobStore = blobstore.NewBlobStore(s3BucketName, s3Client, logger)
 
+	if testPostgres {
+		postgresPool, err = pgxpool.New(context.Background(), deploy.PostgresURL(postgresPort))
+		if err != nil {
+			teardown()
+			panic("failed to create postgres pool: " + err.Error())
+		}
+		err = blobstore.MigratePostgresSchema(context.Background(), postgresPool, logger)
+		if err != nil {
+			teardown()
+			panic("failed to migrate postgres schema: " + err.Error())
+		}
+		postgresMetadataStore = blobstore.NewPostgresBlobMetadataStore(postgresPool, logger)
+	}
+
 	var X1, Y1 fp.Element
 	X1 = *X1.SetBigInt(big.NewInt(1))
 	Y1 = *Y1.SetBigInt(big.NewInt(2))
@@ -150,7 +191,45 @@ func setup(m *testing.M) {
 }
 
 func teardown() {
+	if postgresPool != nil {
+		postgresPool.Close()
+	}
 	if deployLocalStack {
 		deploy.PurgeDockertestResources(dockertestPool, dockertestResource)
 	}
+	if deployPostgres {
+		deploy.PurgeDockertestResources(postgresDockertestPool, postgresDockertestResource)
+	}
+}
+
+// metadataStoreBackends are the MetadataStore implementations that the MetadataStore tests are run against. Each
+// setup function returns a store, skipping the test if the backend is not available.
+var metadataStoreBackends = []struct {
+	name  string
+	setup func(t *testing.T) blobstore.MetadataStore
+}{
+	{name: "dynamo", setup: setupDynamoMetadataStore},
+	{name: "postgres", setup: setupPostgresMetadataStore},
+}
+
+// forEachMetadataStore runs the test as a subtest against every MetadataStore implementation
+func forEachMetadataStore(t *testing.T, test func(t *testing.T, blobMetadataStore blobstore.MetadataStore)) {
+	for _, backend := range metadataStoreBackends {
+		t.Run(backend.name, func(t *testing.T) {
+			test(t, backend.setup(t))
+		})
+	}
+}
+
+// setupDynamoMetadataStore returns the store backed by the shared DynamoDB table. The tests remove the items they
+// write with deleteItems.
+func setupDynamoMetadataStore(t *testing.T) blobstore.MetadataStore {
+	return blobMetadataStore
+}
+
+// setupPostgresMetadataStore skips the test unless a postgres server is available, and otherwise returns the postgres
+// store with all of its records removed.
+func setupPostgresMetadataStore(t *testing.T) blobstore.MetadataStore {
+	setupPostgresTest(t)
+	return postgresMetadataStore
 }
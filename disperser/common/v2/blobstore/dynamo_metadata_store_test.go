@@ -248,6 +248,10 @@ func TestBlobFeedCursor_OrderPreserving(t *testing.T) {
 }
 
 func TestBlobMetadataStoreOperations(t *testing.T) {
+	forEachMetadataStore(t, testBlobMetadataStoreOperations)
+}
+
+func testBlobMetadataStoreOperations(t *testing.T, blobMetadataStore blobstore.MetadataStore) {
 	ctx := context.Background()
 	blobKey1, blobHeader1 := newBlob(t)
 	blobKey2, blobHeader2 := newBlob(t)
@@ -315,6 +319,10 @@ func TestBlobMetadataStoreOperations(t *testing.T) {
 }
 
 func TestBlobMetadataStoreGetBlobMetadataByRequestedAtForwardWithIdenticalTimestamp(t *testing.T) {
+	forEachMetadataStore(t, testBlobMetadataStoreGetBlobMetadataByRequestedAtForwardWithIdenticalTimestamp)
+}
+
+func testBlobMetadataStoreGetBlobMetadataByRequestedAtForwardWithIdenticalTimestamp(t *testing.T, blobMetadataStore blobstore.MetadataStore) {
 	ctx := context.Background()
 	now := uint64(time.Now().UnixNano())
 	firstBlobTime := now - uint64(time.Hour.Nanoseconds())
@@ -488,6 +496,10 @@ func TestBlobMetadataStoreGetBlobMetadataByRequestedAtForwardWithDynamoPaginatio
 }
 
 func TestBlobMetadataStoreGetBlobMetadataByRequestedAtForward(t *testing.T) {
+	forEachMetadataStore(t, testBlobMetadataStoreGetBlobMetadataByRequestedAtForward)
+}
+
+func testBlobMetadataStoreGetBlobMetadataByRequestedAtForward(t *testing.T, blobMetadataStore blobstore.MetadataStore) {
 	ctx := context.Background()
 	numBlobs := 103
 	now := uint64(time.Now().UnixNano())
@@ -667,6 +679,10 @@ func TestBlobMetadataStoreGetBlobMetadataByRequestedAtForward(t *testing.T) {
 }
 
 func TestBlobMetadataStoreGetBlobMetadataByRequestedAtBackward(t *testing.T) {
+	forEachMetadataStore(t, testBlobMetadataStoreGetBlobMetadataByRequestedAtBackward)
+}
+
+func testBlobMetadataStoreGetBlobMetadataByRequestedAtBackward(t *testing.T, blobMetadataStore blobstore.MetadataStore) {
 	ctx := context.Background()
 	numBlobs := 103
 	now := uint64(time.Now().UnixNano())
@@ -855,6 +871,10 @@ func TestBlobMetadataStoreGetBlobMetadataByRequestedAtBackward(t *testing.T) {
 }
 
 func TestBlobMetadataStoreGetBlobMetadataByAccountID(t *testing.T) {
+	forEachMetadataStore(t, testBlobMetadataStoreGetBlobMetadataByAccountID)
+}
+
+func testBlobMetadataStoreGetBlobMetadataByAccountID(t *testing.T, blobMetadataStore blobstore.MetadataStore) {
 	ctx := context.Background()
 
 	// Make all blobs happen in 12s
@@ -1020,6 +1040,10 @@ func TestBlobMetadataStoreGetBlobMetadataByAccountID(t *testing.T) {
 }
 
 func TestBlobMetadataStoreGetAttestationByAttestedAtForward(t *testing.T) {
+	forEachMetadataStore(t, testBlobMetadataStoreGetAttestationByAttestedAtForward)
+}
+
+func testBlobMetadataStoreGetAttestationByAttestedAtForward(t *testing.T, blobMetadataStore blobstore.MetadataStore) {
 	ctx := context.Background()
 	numBatches := 72
 	now := uint64(time.Now().UnixNano())
@@ -1149,6 +1173,10 @@ func TestBlobMetadataStoreGetAttestationByAttestedAtForward(t *testing.T) {
 }
 
 func TestBlobMetadataStoreGetAttestationByAttestedAtBackward(t *testing.T) {
+	forEachMetadataStore(t, testBlobMetadataStoreGetAttestationByAttestedAtBackward)
+}
+
+func testBlobMetadataStoreGetAttestationByAttestedAtBackward(t *testing.T, blobMetadataStore blobstore.MetadataStore) {
 	ctx := context.Background()
 	numBatches := 72
 	now := uint64(time.Now().UnixNano())
@@ -1379,6 +1407,10 @@ func TestBlobMetadataStoreGetAttestationByAttestedAtForwardWithDynamoPagination(
 }
 
 func TestBlobMetadataStoreGetBlobMetadataByStatusPaginated(t *testing.T) {
+	forEachMetadataStore(t, testBlobMetadataStoreGetBlobMetadataByStatusPaginated)
+}
+
+func testBlobMetadataStoreGetBlobMetadataByStatusPaginated(t *testing.T, blobMetadataStore blobstore.MetadataStore) {
 	ctx := context.Background()
 	numBlobs := 103
 	pageSize := 10
@@ -1461,6 +1493,10 @@ func TestBlobMetadataStoreGetBlobMetadataByStatusPaginated(t *testing.T) {
 }
 
 func TestBlobMetadataStoreCerts(t *testing.T) {
+	forEachMetadataStore(t, testBlobMetadataStoreCerts)
+}
+
+func testBlobMetadataStoreCerts(t *testing.T, blobMetadataStore blobstore.MetadataStore) {
 	ctx := context.Background()
 	blobKey, blobHeader := newBlob(t)
 	blobCert := &corev2.BlobCertificate{
@@ -1536,6 +1572,10 @@ func TestBlobMetadataStoreCerts(t *testing.T) {
 }
 
 func TestBlobMetadataStoreUpdateBlobStatus(t *testing.T) {
+	forEachMetadataStore(t, testBlobMetadataStoreUpdateBlobStatus)
+}
+
+func testBlobMetadataStoreUpdateBlobStatus(t *testing.T, blobMetadataStore blobstore.MetadataStore) {
 	ctx := context.Background()
 	blobKey, blobHeader := newBlob(t)
 
@@ -1585,6 +1625,10 @@ func TestBlobMetadataStoreUpdateBlobStatus(t *testing.T) {
 }
 
 func TestBlobMetadataStoreDispersals(t *testing.T) {
+	forEachMetadataStore(t, testBlobMetadataStoreDispersals)
+}
+
+func testBlobMetadataStoreDispersals(t *testing.T, blobMetadataStore blobstore.MetadataStore) {
 	ctx := context.Background()
 	opID := core.OperatorID{0, 1}
 	dispersalRequest := &corev2.DispersalRequest{
@@ -1681,6 +1725,10 @@ func TestBlobMetadataStoreDispersals(t *testing.T) {
 }
 
 func TestBlobMetadataStoreDispersalsByRespondedAt(t *testing.T) {
+	forEachMetadataStore(t, testBlobMetadataStoreDispersalsByRespondedAt)
+}
+
+func testBlobMetadataStoreDispersalsByRespondedAt(t *testing.T, blobMetadataStore blobstore.MetadataStore) {
 	ctx := context.Background()
 
 	numRequests := 60
@@ -1846,6 +1894,10 @@ func TestBlobMetadataStoreDispersalsByRespondedAt(t *testing.T) {
 }
 
 func TestBlobMetadataStoreBatch(t *testing.T) {
+	forEachMetadataStore(t, testBlobMetadataStoreBatch)
+}
+
+func testBlobMetadataStoreBatch(t *testing.T, blobMetadataStore blobstore.MetadataStore) {
 	ctx := context.Background()
 	_, blobHeader := newBlob(t)
 	blobCert := &corev2.BlobCertificate{
@@ -1874,6 +1926,10 @@ func TestBlobMetadataStoreBatch(t *testing.T) {
 }
 
 func TestBlobMetadataStoreBlobAttestationInfo(t *testing.T) {
+	forEachMetadataStore(t, testBlobMetadataStoreBlobAttestationInfo)
+}
+
+func testBlobMetadataStoreBlobAttestationInfo(t *testing.T, blobMetadataStore blobstore.MetadataStore) {
 	ctx := context.Background()
 	blobKey := corev2.BlobKey{1, 1, 1}
 	batchHeader := &corev2.BatchHeader{
@@ -1949,6 +2005,10 @@ func TestBlobMetadataStoreBlobAttestationInfo(t *testing.T) {
 }
 
 func TestBlobMetadataStoreInclusionInfo(t *testing.T) {
+	forEachMetadataStore(t, testBlobMetadataStoreInclusionInfo)
+}
+
+func testBlobMetadataStoreInclusionInfo(t *testing.T, blobMetadataStore blobstore.MetadataStore) {
 	ctx := context.Background()
 	blobKey := corev2.BlobKey{1, 1, 1}
 	batchHeader := &corev2.BatchHeader{
@@ -1992,8 +2052,32 @@ func TestBlobMetadataStoreInclusionInfo(t *testing.T) {
 	}
 	err = blobMetadataStore.PutBlobInclusionInfos(ctx, []*corev2.BlobInclusionInfo{inclusionInfo1, inclusionInfo2})
 	assert.NoError(t, err)
+}
+
+// The retries of PutBlobInclusionInfos are specific to the DynamoDB batch writes, so they are only tested against
+// the DynamoDB store.
+func TestBlobMetadataStorePutBlobInclusionInfosRetries(t *testing.T) {
+	ctx := context.Background()
+	batchHeader := &corev2.BatchHeader{
+		BatchRoot:            [32]byte{1, 2, 3},
+		ReferenceBlockNumber: 100,
+	}
+	bhh, err := batchHeader.Hash()
+	assert.NoError(t, err)
+	blobKey1 := corev2.BlobKey{2, 2, 2}
+	inclusionInfo1 := &corev2.BlobInclusionInfo{
+		BatchHeader:    batchHeader,
+		BlobKey:        blobKey1,
+		BlobIndex:      12,
+		InclusionProof: []byte("proof 1"),
+	}
+	inclusionInfo2 := &corev2.BlobInclusionInfo{
+		BatchHeader:    batchHeader,
+		BlobKey:        corev2.BlobKey{3, 3, 3},
+		BlobIndex:      14,
+		InclusionProof: []byte("proof 2"),
+	}
 
-	// test retries
 	nonTransientError := errors.New("non transient error")
 	mockDynamoClient.On("PutItems", mock.Anything, mock.Anything, mock.Anything).Return(nil, nonTransientError).Once()
 	err = mockedBlobMetadataStore.PutBlobInclusionInfos(ctx, []*corev2.BlobInclusionInfo{inclusionInfo1, inclusionInfo2})
@@ -2021,6 +2105,10 @@ func TestBlobMetadataStoreInclusionInfo(t *testing.T) {
 }
 
 func TestBlobMetadataStoreBatchAttestation(t *testing.T) {
+	forEachMetadataStore(t, testBlobMetadataStoreBatchAttestation)
+}
+
+func testBlobMetadataStoreBatchAttestation(t *testing.T, blobMetadataStore blobstore.MetadataStore) {
 	ctx := context.Background()
 	h := &corev2.BatchHeader{
 		BatchRoot:            [32]byte{1, 2, 3},
@@ -2124,6 +2212,8 @@ func TestBlobMetadataStoreBatchAttestation(t *testing.T) {
 	})
 }
 
+// deleteItems removes items from the shared DynamoDB table. The tests that run against every MetadataStore also call
+// it for the other stores, in which case there is nothing to delete.
 func deleteItems(t *testing.T, keys []commondynamodb.Key) {
 	failed, err := dynamoClient.DeleteItems(context.Background(), metadataTableName, keys)
 	assert.NoError(t, err)
@@ -2157,6 +2247,10 @@ func newBlob(t *testing.T) (corev2.BlobKey, *corev2.BlobHeader) {
 }
 
 func TestCheckBlobExists(t *testing.T) {
+	forEachMetadataStore(t, testCheckBlobExists)
+}
+
+func testCheckBlobExists(t *testing.T, blobMetadataStore blobstore.MetadataStore) {
 	ctx := context.Background()
 	// Create a test blob
 	blobKey, blobHeader := newBlob(t)
@@ -8,10 +8,12 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/api"
 	"github.com/Layr-Labs/eigenda/core"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	v2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
 	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigensdk-go/logging"
 	gethcommon "github.com/ethereum/go-ethereum/common"
 )
 
@@ -314,3 +316,48 @@ func computeAttestedAtBucket(attestedAt uint64) string {
 	id := computeBucketID(attestedAt, attestedAtBucketSizeNano)
 	return fmt.Sprintf("%d", id)
 }
+
+// getBlobAttestationInfo returns the inclusion info of a blob along with the attestation of the batch that the
+// blob was included in.
+func getBlobAttestationInfo(
+	ctx context.Context,
+	store MetadataStore,
+	logger logging.Logger,
+	blobKey corev2.BlobKey,
+) (*v2.BlobAttestationInfo, error) {
+	blobInclusionInfos, err := store.GetBlobInclusionInfos(ctx, blobKey)
+	if err != nil {
+		logger.Error("failed to get blob inclusion info for blob", "err", err, "blobKey", blobKey.Hex())
+		return nil, api.NewErrorInternal(fmt.Sprintf("failed to get blob inclusion info: %s", err.Error()))
+	}
+
+	if len(blobInclusionInfos) == 0 {
+		logger.Error("no blob inclusion info found for blob", "blobKey", blobKey.Hex())
+		return nil, api.NewErrorInternal("no blob inclusion info found")
+	}
+
+	if len(blobInclusionInfos) > 1 {
+		logger.Warn("multiple inclusion info found for blob", "blobKey", blobKey.Hex())
+	}
+
+	for _, inclusionInfo := range blobInclusionInfos {
+		// get the signed batch from this inclusion info
+		batchHeaderHash, err := inclusionInfo.BatchHeader.Hash()
+		if err != nil {
+			logger.Error("failed to get batch header hash from blob inclusion info", "err", err, "blobKey", blobKey.Hex())
+			continue
+		}
+		_, attestation, err := store.GetSignedBatch(ctx, batchHeaderHash)
+		if err != nil {
+			logger.Error("failed to get signed batch", "err", err, "blobKey", blobKey.Hex())
+			continue
+		}
+
+		return &v2.BlobAttestationInfo{
+			InclusionInfo: inclusionInfo,
+			Attestation:   attestation,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("no attestation info found for blobkey: %s", blobKey.Hex())
+}
@@ -0,0 +1,1093 @@
+package blobstore
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	v2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	postgresMigrationsDirectory = "postgres_migrations"
+	postgresMigrationsTable     = "blobstore_schema_migrations"
+
+	// postgresMigrationLockID is the key of the advisory lock held while migrations are applied, so that
+	// services sharing a database do not attempt to migrate it at the same time.
+	postgresMigrationLockID = int64(0x6569_6765_6e64_6132) // "eigenda2"
+
+	blobMetadataColumns = "metadata, blob_status, updated_at"
+)
+
+//go:embed postgres_migrations/*.sql
+var postgresMigrations embed.FS
+
+var _ MetadataStore = (*PostgresBlobMetadataStore)(nil)
+
+// PostgresBlobMetadataStore is a blob metadata storage backed by PostgreSQL.
+//
+// Each record is stored as a JSON document, alongside the columns needed to look it up and order it.
+// The schema must be created with MigratePostgresSchema before the store is used.
+type PostgresBlobMetadataStore struct {
+	pool   *pgxpool.Pool
+	logger logging.Logger
+}
+
+func NewPostgresBlobMetadataStore(pool *pgxpool.Pool, logger logging.Logger) *PostgresBlobMetadataStore {
+	logger.Debugf("creating postgres blob metadata store v2")
+	return &PostgresBlobMetadataStore{
+		pool:   pool,
+		logger: logger.With("component", "postgresBlobMetadataStoreV2"),
+	}
+}
+
+// ConnectPostgresBlobMetadataStore connects to the database at the given URL, applies any pending schema
+// migrations, and returns a store backed by the database.
+func ConnectPostgresBlobMetadataStore(ctx context.Context, url string, logger logging.Logger) (*PostgresBlobMetadataStore, error) {
+	pool, err := pgxpool.New(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create postgres pool: %w", err)
+	}
+	err = MigratePostgresSchema(ctx, pool, logger)
+	if err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("failed to migrate postgres schema: %w", err)
+	}
+
+	return NewPostgresBlobMetadataStore(pool, logger), nil
+}
+
+// MigratePostgresSchema applies all schema migrations that have not yet been applied to the database.
+// Migrations are applied in a single transaction, so either all pending migrations are applied or none are.
+// It is safe to call this concurrently from multiple processes.
+func MigratePostgresSchema(ctx context.Context, pool *pgxpool.Pool, logger logging.Logger) error {
+	migrations, err := readPostgresMigrations()
+	if err != nil {
+		return err
+	}
+
+	return pgx.BeginFunc(ctx, pool, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1)", postgresMigrationLockID)
+		if err != nil {
+			return fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+
+		_, err = tx.Exec(ctx, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+			version    INTEGER     PRIMARY KEY,
+			name       TEXT        NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+		)`, postgresMigrationsTable))
+		if err != nil {
+			return fmt.Errorf("failed to create migrations table: %w", err)
+		}
+
+		var currentVersion int
+		err = tx.QueryRow(ctx,
+			fmt.Sprintf("SELECT COALESCE(MAX(version), 0) FROM %s", postgresMigrationsTable)).Scan(&currentVersion)
+		if err != nil {
+			return fmt.Errorf("failed to get current schema version: %w", err)
+		}
+
+		for _, migration := range migrations {
+			if migration.version <= currentVersion {
+				continue
+			}
+
+			logger.Info("applying postgres migration", "version", migration.version, "name", migration.name)
+			// Statements without arguments are sent using the simple protocol, which permits several
+			// statements in a single call.
+			_, err = tx.Exec(ctx, migration.statements)
+			if err != nil {
+				return fmt.Errorf("failed to apply migration %s: %w", migration.name, err)
+			}
+			_, err = tx.Exec(ctx,
+				fmt.Sprintf("INSERT INTO %s (version, name) VALUES ($1, $2)", postgresMigrationsTable),
+				migration.version, migration.name)
+			if err != nil {
+				return fmt.Errorf("failed to record migration %s: %w", migration.name, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+type postgresMigration struct {
+	version    int
+	name       string
+	statements string
+}
+
+// readPostgresMigrations returns the embedded migrations ordered by version. Migration files are named
+// <version>_<description>.sql.
+func readPostgresMigrations() ([]*postgresMigration, error) {
+	entries, err := fs.ReadDir(postgresMigrations, postgresMigrationsDirectory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	migrations := make([]*postgresMigration, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		prefix, _, found := strings.Cut(name, "_")
+		if !found {
+			return nil, fmt.Errorf("migration %s is not named <version>_<description>.sql", name)
+		}
+		version, err := strconv.Atoi(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s has invalid version: %w", name, err)
+		}
+		statements, err := postgresMigrations.ReadFile(postgresMigrationsDirectory + "/" + name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", name, err)
+		}
+		migrations = append(migrations, &postgresMigration{
+			version:    version,
+			name:       name,
+			statements: string(statements),
+		})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].version < migrations[j].version
+	})
+	for i := 1; i < len(migrations); i++ {
+		if migrations[i].version == migrations[i-1].version {
+			return nil, fmt.Errorf("migrations %s and %s have the same version",
+				migrations[i-1].name, migrations[i].name)
+		}
+	}
+
+	return migrations, nil
+}
+
+func (s *PostgresBlobMetadataStore) PutBlobMetadata(ctx context.Context, blobMetadata *v2.BlobMetadata) error {
+	s.logger.Debug("store put blob metadata", "blobMetadata", blobMetadata)
+	blobKey, err := blobMetadata.BlobHeader.BlobKey()
+	if err != nil {
+		return err
+	}
+	document, err := json.Marshal(blobMetadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal blob metadata: %w", err)
+	}
+
+	tag, err := s.pool.Exec(ctx, `
+		INSERT INTO blob_metadata (blob_key, blob_status, updated_at, requested_at, account_id, metadata)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT DO NOTHING`,
+		blobKey[:],
+		int16(blobMetadata.BlobStatus),
+		toPostgresTimestamp(blobMetadata.UpdatedAt),
+		toPostgresTimestamp(blobMetadata.RequestedAt),
+		blobMetadata.BlobHeader.PaymentMetadata.AccountID.Bytes(),
+		document)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrAlreadyExists
+	}
+
+	return nil
+}
+
+func (s *PostgresBlobMetadataStore) UpdateBlobStatus(ctx context.Context, blobKey corev2.BlobKey, status v2.BlobStatus) error {
+	validStatuses := statusUpdatePrecondition[status]
+	if len(validStatuses) == 0 {
+		return fmt.Errorf("%w: invalid status transition to %s", ErrInvalidStateTransition, status.String())
+	}
+
+	previousStatuses := make([]int16, len(validStatuses))
+	for i, validStatus := range validStatuses {
+		previousStatuses[i] = int16(validStatus)
+	}
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE blob_metadata SET blob_status = $1, updated_at = $2
+		WHERE blob_key = $3 AND blob_status = ANY($4)`,
+		int16(status), time.Now().UnixNano(), blobKey[:], previousStatuses)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		blob, err := s.GetBlobMetadata(ctx, blobKey)
+		if err != nil {
+			return fmt.Errorf("failed to get blob metadata for key %s: %v", blobKey.Hex(), err)
+		}
+
+		if blob.BlobStatus == status {
+			return fmt.Errorf("%w: blob already in status %s", ErrAlreadyExists, status.String())
+		}
+
+		return fmt.Errorf("%w: invalid status transition from %s to %s", ErrInvalidStateTransition, blob.BlobStatus.String(), status.String())
+	}
+
+	return nil
+}
+
+func (s *PostgresBlobMetadataStore) DeleteBlobMetadata(ctx context.Context, blobKey corev2.BlobKey) error {
+	_, err := s.pool.Exec(ctx, "DELETE FROM blob_metadata WHERE blob_key = $1", blobKey[:])
+	return err
+}
+
+func (s *PostgresBlobMetadataStore) GetBlobMetadata(ctx context.Context, blobKey corev2.BlobKey) (*v2.BlobMetadata, error) {
+	row := s.pool.QueryRow(ctx,
+		"SELECT "+blobMetadataColumns+" FROM blob_metadata WHERE blob_key = $1", blobKey[:])
+	metadata, err := scanBlobMetadata(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: metadata not found for key %s", ErrMetadataNotFound, blobKey.Hex())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return metadata, nil
+}
+
+// CheckBlobExists checks if a blob exists without fetching the entire metadata.
+func (s *PostgresBlobMetadataStore) CheckBlobExists(ctx context.Context, blobKey corev2.BlobKey) (bool, error) {
+	var exists bool
+	err := s.pool.QueryRow(ctx,
+		"SELECT EXISTS (SELECT 1 FROM blob_metadata WHERE blob_key = $1)", blobKey[:]).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check blob existence: %w", err)
+	}
+
+	return exists, nil
+}
+
+// GetBlobMetadataByStatus returns all the metadata with the given status that were updated after lastUpdatedAt.
+// Results are ordered by UpdatedAt in ascending order.
+func (s *PostgresBlobMetadataStore) GetBlobMetadataByStatus(ctx context.Context, status v2.BlobStatus, lastUpdatedAt uint64) ([]*v2.BlobMetadata, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+blobMetadataColumns+` FROM blob_metadata
+		WHERE blob_status = $1 AND updated_at > $2
+		ORDER BY updated_at ASC, blob_key ASC`,
+		int16(status), toPostgresTimestamp(lastUpdatedAt))
+	if err != nil {
+		return nil, err
+	}
+
+	return collectBlobMetadata(rows)
+}
+
+// GetBlobMetadataByRequestedAtForward returns blobs (as BlobMetadata) in cursor range
+// (after, before) (both exclusive). Blobs are retrieved and ordered by <RequestedAt, BlobKey>
+// in ascending order.
+//
+// If limit > 0, returns at most that many blobs. If limit <= 0, returns all blobs in range.
+// Also returns the cursor of the last processed blob, or nil if no blobs were processed.
+func (s *PostgresBlobMetadataStore) GetBlobMetadataByRequestedAtForward(
+	ctx context.Context,
+	after BlobFeedCursor,
+	before BlobFeedCursor,
+	limit int,
+) ([]*v2.BlobMetadata, *BlobFeedCursor, error) {
+	return s.queryBlobFeed(ctx, after, before, limit, true)
+}
+
+// GetBlobMetadataByRequestedAtBackward returns blobs (as BlobMetadata) in cursor range
+// (after, before) (both exclusive). Blobs are retrieved and ordered by <RequestedAt, BlobKey>
+// in descending order.
+//
+// If limit > 0, returns at most that many blobs. If limit <= 0, returns all blobs in range.
+// Also returns the cursor of the last processed blob, or nil if no blobs were processed.
+func (s *PostgresBlobMetadataStore) GetBlobMetadataByRequestedAtBackward(
+	ctx context.Context,
+	before BlobFeedCursor,
+	after BlobFeedCursor,
+	limit int,
+) ([]*v2.BlobMetadata, *BlobFeedCursor, error) {
+	return s.queryBlobFeed(ctx, after, before, limit, false)
+}
+
+// queryBlobFeed returns blobs in cursor range (after, before) (both exclusive), ordered by
+// <RequestedAt, BlobKey> in the specified order.
+func (s *PostgresBlobMetadataStore) queryBlobFeed(
+	ctx context.Context,
+	after BlobFeedCursor,
+	before BlobFeedCursor,
+	limit int,
+	ascending bool,
+) ([]*v2.BlobMetadata, *BlobFeedCursor, error) {
+	if !after.LessThan(&before) {
+		return nil, nil, errors.New("after cursor must be less than before cursor")
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+blobMetadataColumns+` FROM blob_metadata
+		WHERE (requested_at, blob_key) > ($1::BIGINT, $2::BYTEA)
+		  AND (requested_at, blob_key) < ($3::BIGINT, $4::BYTEA)
+		ORDER BY requested_at `+sortOrder(ascending)+`, blob_key `+sortOrder(ascending)+`
+		LIMIT $5`,
+		toPostgresTimestamp(after.RequestedAt), blobFeedCursorKeyBytes(after.BlobKey),
+		toPostgresTimestamp(before.RequestedAt), blobFeedCursorKeyBytes(before.BlobKey),
+		postgresLimit(limit))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result, err := collectBlobMetadata(rows)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(result) == 0 {
+		return result, nil, nil
+	}
+
+	last := result[len(result)-1]
+	blobKey, err := last.BlobHeader.BlobKey()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get blob key: %w", err)
+	}
+
+	return result, &BlobFeedCursor{
+		RequestedAt: last.RequestedAt,
+		BlobKey:     &blobKey,
+	}, nil
+}
+
+// GetBlobMetadataByAccountID returns blobs (as BlobMetadata) within time range (start, end)
+// (in ns, both exclusive), retrieved and ordered by RequestedAt timestamp in specified order, for
+// a given account.
+//
+// If specified order is ascending (`ascending` is true), retrieve data from the oldest (`start`)
+// to the newest (`end`); otherwise retrieve by the opposite direction.
+//
+// If limit > 0, returns at most that many blobs. If limit <= 0, returns all results
+// in the time range.
+func (s *PostgresBlobMetadataStore) GetBlobMetadataByAccountID(
+	ctx context.Context,
+	accountId gethcommon.Address,
+	start uint64,
+	end uint64,
+	limit int,
+	ascending bool,
+) ([]*v2.BlobMetadata, error) {
+	if start+1 > end-1 {
+		return nil, fmt.Errorf("no time point in exclusive time range (%d, %d)", start, end)
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+blobMetadataColumns+` FROM blob_metadata
+		WHERE account_id = $1 AND requested_at > $2 AND requested_at < $3
+		ORDER BY requested_at `+sortOrder(ascending)+`
+		LIMIT $4`,
+		accountId.Bytes(), toPostgresTimestamp(start), toPostgresTimestamp(end), postgresLimit(limit))
+	if err != nil {
+		return nil, fmt.Errorf("query failed for accountId %s with time range (%d, %d): %w", accountId.Hex(), start, end, err)
+	}
+
+	return collectBlobMetadata(rows)
+}
+
+// GetAttestationByAttestedAtForward returns attestations within time range (after, before)
+// (both exclusive), retrieved and ordered by AttestedAt timestamp in ascending order.
+//
+// If limit > 0, returns at most that many attestations. If limit <= 0, returns all attestations
+// in the time range.
+func (s *PostgresBlobMetadataStore) GetAttestationByAttestedAtForward(
+	ctx context.Context,
+	after uint64,
+	before uint64,
+	limit int,
+) ([]*corev2.Attestation, error) {
+	return s.queryAttestations(ctx, after, before, limit, true)
+}
+
+// GetAttestationByAttestedAtBackward returns attestations within time range (after, before)
+// (both exclusive), retrieved and ordered by AttestedAt timestamp in descending order.
+//
+// If limit > 0, returns at most that many attestations. If limit <= 0, returns all attestations
+// in the time range.
+func (s *PostgresBlobMetadataStore) GetAttestationByAttestedAtBackward(
+	ctx context.Context,
+	before uint64,
+	after uint64,
+	limit int,
+) ([]*corev2.Attestation, error) {
+	return s.queryAttestations(ctx, after, before, limit, false)
+}
+
+// queryAttestations returns attestations within time range (after, before) (both exclusive), ordered
+// by AttestedAt in the specified order.
+func (s *PostgresBlobMetadataStore) queryAttestations(
+	ctx context.Context,
+	after uint64,
+	before uint64,
+	limit int,
+	ascending bool,
+) ([]*corev2.Attestation, error) {
+	if after+1 > before-1 {
+		return nil, fmt.Errorf("no time point in exclusive time range (%d, %d)", after, before)
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT attestation FROM attestations
+		WHERE attested_at > $1 AND attested_at < $2
+		ORDER BY attested_at `+sortOrder(ascending)+`
+		LIMIT $3`,
+		toPostgresTimestamp(after), toPostgresTimestamp(before), postgresLimit(limit))
+	if err != nil {
+		return nil, fmt.Errorf("query failed for time range (%d, %d): %w", after, before, err)
+	}
+
+	return collectDocuments[corev2.Attestation](rows)
+}
+
+// GetBlobMetadataByStatusPaginated returns all the metadata with the given status that were updated after the given cursor.
+// It also returns a new cursor to be used for the next page when a full page of results is returned.
+// If there are no results at all, the given cursor is returned so that it can be used to get new results when
+// they become available. If fewer than limit results are returned, the returned cursor is nil.
+func (s *PostgresBlobMetadataStore) GetBlobMetadataByStatusPaginated(
+	ctx context.Context,
+	status v2.BlobStatus,
+	exclusiveStartKey *StatusIndexCursor,
+	limit int32,
+) ([]*v2.BlobMetadata, *StatusIndexCursor, error) {
+	// An empty key sorts before all blob keys, so a cursor without a blob key includes every blob updated after
+	// the cursor's timestamp.
+	startUpdatedAt := uint64(0)
+	startBlobKey := []byte{}
+	if exclusiveStartKey != nil {
+		startUpdatedAt = exclusiveStartKey.UpdatedAt
+		if exclusiveStartKey.BlobKey != nil {
+			startBlobKey = exclusiveStartKey.BlobKey[:]
+		}
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT blob_key, `+blobMetadataColumns+` FROM blob_metadata
+		WHERE blob_status = $1 AND (updated_at, blob_key) > ($2::BIGINT, $3::BYTEA)
+		ORDER BY updated_at ASC, blob_key ASC
+		LIMIT $4`,
+		int16(status), toPostgresTimestamp(startUpdatedAt), startBlobKey, postgresLimit(int(limit)))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	metadata := make([]*v2.BlobMetadata, 0)
+	numRows := 0
+	var lastBlobKey corev2.BlobKey
+	var lastUpdatedAt uint64
+	for rows.Next() {
+		numRows++
+		var blobKey []byte
+		var document []byte
+		var blobStatus int16
+		var updatedAt int64
+		err = rows.Scan(&blobKey, &document, &blobStatus, &updatedAt)
+		if err != nil {
+			return nil, nil, err
+		}
+		copy(lastBlobKey[:], blobKey)
+		lastUpdatedAt = uint64(updatedAt)
+
+		m, err := unmarshalBlobMetadata(document, blobStatus, updatedAt)
+		// Skip invalid/corrupt items
+		if err != nil {
+			s.logger.Errorf("failed to unmarshal blob metadata: %v", err)
+			continue
+		}
+		metadata = append(metadata, m)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	// No results
+	if numRows == 0 {
+		// return the same cursor
+		return nil, exclusiveStartKey, nil
+	}
+
+	// There may be more results
+	if limit > 0 && numRows >= int(limit) {
+		return metadata, &StatusIndexCursor{
+			BlobKey:   &lastBlobKey,
+			UpdatedAt: lastUpdatedAt,
+		}, nil
+	}
+
+	return metadata, nil, nil
+}
+
+// GetBlobMetadataCountByStatus returns the count of all the metadata with the given status
+func (s *PostgresBlobMetadataStore) GetBlobMetadataCountByStatus(ctx context.Context, status v2.BlobStatus) (int32, error) {
+	var count int32
+	err := s.pool.QueryRow(ctx,
+		"SELECT COUNT(*)::INTEGER FROM blob_metadata WHERE blob_status = $1", int16(status)).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (s *PostgresBlobMetadataStore) PutBlobCertificate(ctx context.Context, blobCert *corev2.BlobCertificate, fragmentInfo *encoding.FragmentInfo) error {
+	blobKey, err := blobCert.BlobHeader.BlobKey()
+	if err != nil {
+		return err
+	}
+	certificate, err := json.Marshal(blobCert)
+	if err != nil {
+		return fmt.Errorf("failed to marshal blob certificate: %w", err)
+	}
+	fragmentInfoDocument, err := json.Marshal(fragmentInfo)
+	if err != nil {
+		return fmt.Errorf("failed to marshal fragment info: %w", err)
+	}
+
+	return s.insert(ctx, `
+		INSERT INTO blob_certificates (blob_key, certificate, fragment_info) VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING`,
+		blobKey[:], certificate, fragmentInfoDocument)
+}
+
+func (s *PostgresBlobMetadataStore) DeleteBlobCertificate(ctx context.Context, blobKey corev2.BlobKey) error {
+	_, err := s.pool.Exec(ctx, "DELETE FROM blob_certificates WHERE blob_key = $1", blobKey[:])
+	return err
+}
+
+func (s *PostgresBlobMetadataStore) GetBlobCertificate(ctx context.Context, blobKey corev2.BlobKey) (*corev2.BlobCertificate, *encoding.FragmentInfo, error) {
+	var certificate []byte
+	var fragmentInfo []byte
+	err := s.pool.QueryRow(ctx,
+		"SELECT certificate, fragment_info FROM blob_certificates WHERE blob_key = $1",
+		blobKey[:]).Scan(&certificate, &fragmentInfo)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil, fmt.Errorf("%w: certificate not found for key %s", ErrMetadataNotFound, blobKey.Hex())
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return unmarshalBlobCertificate(certificate, fragmentInfo)
+}
+
+// GetBlobCertificates returns the certificates for the given blob keys
+// Note: the returned certificates are NOT necessarily ordered by the order of the input blob keys
+func (s *PostgresBlobMetadataStore) GetBlobCertificates(ctx context.Context, blobKeys []corev2.BlobKey) ([]*corev2.BlobCertificate, []*encoding.FragmentInfo, error) {
+	keys := make([][]byte, len(blobKeys))
+	for i := range blobKeys {
+		keys[i] = blobKeys[i][:]
+	}
+
+	rows, err := s.pool.Query(ctx,
+		"SELECT certificate, fragment_info FROM blob_certificates WHERE blob_key = ANY($1)", keys)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	certs := make([]*corev2.BlobCertificate, 0, len(blobKeys))
+	fragmentInfos := make([]*encoding.FragmentInfo, 0, len(blobKeys))
+	for rows.Next() {
+		var certificate []byte
+		var fragmentInfo []byte
+		err = rows.Scan(&certificate, &fragmentInfo)
+		if err != nil {
+			return nil, nil, err
+		}
+		cert, info, err := unmarshalBlobCertificate(certificate, fragmentInfo)
+		if err != nil {
+			return nil, nil, err
+		}
+		certs = append(certs, cert)
+		fragmentInfos = append(fragmentInfos, info)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	return certs, fragmentInfos, nil
+}
+
+func (s *PostgresBlobMetadataStore) PutDispersalRequest(ctx context.Context, req *corev2.DispersalRequest) error {
+	batchHeaderHash, err := req.BatchHeader.Hash()
+	if err != nil {
+		return fmt.Errorf("failed to hash batch header: %w", err)
+	}
+	document, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to marshal dispersal request: %w", err)
+	}
+
+	return s.insert(ctx, `
+		INSERT INTO dispersal_requests (batch_header_hash, operator_id, request) VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING`,
+		batchHeaderHash[:], req.OperatorID[:], document)
+}
+
+func (s *PostgresBlobMetadataStore) GetDispersalRequest(ctx context.Context, batchHeaderHash [32]byte, operatorID core.OperatorID) (*corev2.DispersalRequest, error) {
+	req, err := getDocument[corev2.DispersalRequest](ctx, s.pool,
+		"SELECT request FROM dispersal_requests WHERE batch_header_hash = $1 AND operator_id = $2",
+		batchHeaderHash[:], operatorID[:])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: dispersal request not found for batch header hash %x and operator %s", ErrMetadataNotFound, batchHeaderHash, operatorID.Hex())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// GetDispersalsByRespondedAt returns dispersals (in DispersalResponse, which has joined
+// request and response together) to the given operator, within time range (start, end)
+// (both exclusive), retrieved and ordered by RespondedAt timestamp in the specified order.
+//
+// If specified order is ascending (`ascending` is true), retrieve data from the oldest (`start`)
+// to the newest (`end`); otherwise retrieve by the opposite direction.
+//
+// If limit > 0, returns at most that many dispersals. If limit <= 0, returns all results
+// in the time range.
+func (s *PostgresBlobMetadataStore) GetDispersalsByRespondedAt(
+	ctx context.Context,
+	operatorId core.OperatorID,
+	start uint64,
+	end uint64,
+	limit int,
+	ascending bool,
+) ([]*corev2.DispersalResponse, error) {
+	if start+1 > end-1 {
+		return nil, fmt.Errorf("no time point in exclusive time range (%d, %d)", start, end)
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT response FROM dispersal_responses
+		WHERE operator_id = $1 AND responded_at > $2 AND responded_at < $3
+		ORDER BY responded_at `+sortOrder(ascending)+`
+		LIMIT $4`,
+		operatorId[:], toPostgresTimestamp(start), toPostgresTimestamp(end), postgresLimit(limit))
+	if err != nil {
+		return nil, fmt.Errorf("query failed for operatorId %s with time range (%d, %d): %w", operatorId.Hex(), start, end, err)
+	}
+
+	return collectDocuments[corev2.DispersalResponse](rows)
+}
+
+func (s *PostgresBlobMetadataStore) PutDispersalResponse(ctx context.Context, res *corev2.DispersalResponse) error {
+	batchHeaderHash, err := res.BatchHeader.Hash()
+	if err != nil {
+		return fmt.Errorf("failed to hash batch header: %w", err)
+	}
+	document, err := json.Marshal(res)
+	if err != nil {
+		return fmt.Errorf("failed to marshal dispersal response: %w", err)
+	}
+
+	return s.insert(ctx, `
+		INSERT INTO dispersal_responses (batch_header_hash, operator_id, responded_at, response)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT DO NOTHING`,
+		batchHeaderHash[:], res.OperatorID[:], toPostgresTimestamp(res.RespondedAt), document)
+}
+
+func (s *PostgresBlobMetadataStore) GetDispersalResponse(ctx context.Context, batchHeaderHash [32]byte, operatorID core.OperatorID) (*corev2.DispersalResponse, error) {
+	res, err := getDocument[corev2.DispersalResponse](ctx, s.pool,
+		"SELECT response FROM dispersal_responses WHERE batch_header_hash = $1 AND operator_id = $2",
+		batchHeaderHash[:], operatorID[:])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: dispersal response not found for batch header hash %x and operator %s", ErrMetadataNotFound, batchHeaderHash, operatorID.Hex())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *PostgresBlobMetadataStore) GetDispersalResponses(ctx context.Context, batchHeaderHash [32]byte) ([]*corev2.DispersalResponse, error) {
+	rows, err := s.pool.Query(ctx,
+		"SELECT response FROM dispersal_responses WHERE batch_header_hash = $1 ORDER BY operator_id ASC",
+		batchHeaderHash[:])
+	if err != nil {
+		return nil, err
+	}
+
+	responses, err := collectDocuments[corev2.DispersalResponse](rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(responses) == 0 {
+		return nil, fmt.Errorf("%w: dispersal responses not found for batch header hash %x", ErrMetadataNotFound, batchHeaderHash)
+	}
+
+	return responses, nil
+}
+
+func (s *PostgresBlobMetadataStore) PutBatch(ctx context.Context, batch *corev2.Batch) error {
+	hash, err := batch.BatchHeader.Hash()
+	if err != nil {
+		return fmt.Errorf("failed to hash batch header: %w", err)
+	}
+	document, err := json.Marshal(batch)
+	if err != nil {
+		return fmt.Errorf("failed to marshal batch: %w", err)
+	}
+
+	return s.insert(ctx,
+		"INSERT INTO batches (batch_header_hash, batch) VALUES ($1, $2) ON CONFLICT DO NOTHING",
+		hash[:], document)
+}
+
+func (s *PostgresBlobMetadataStore) GetBatch(ctx context.Context, batchHeaderHash [32]byte) (*corev2.Batch, error) {
+	batch, err := getDocument[corev2.Batch](ctx, s.pool,
+		"SELECT batch FROM batches WHERE batch_header_hash = $1", batchHeaderHash[:])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: batch info not found for hash %x", ErrMetadataNotFound, batchHeaderHash)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return batch, nil
+}
+
+func (s *PostgresBlobMetadataStore) PutBatchHeader(ctx context.Context, batchHeader *corev2.BatchHeader) error {
+	hash, err := batchHeader.Hash()
+	if err != nil {
+		return fmt.Errorf("failed to hash batch header: %w", err)
+	}
+	document, err := json.Marshal(batchHeader)
+	if err != nil {
+		return fmt.Errorf("failed to marshal batch header: %w", err)
+	}
+
+	return s.insert(ctx,
+		"INSERT INTO batch_headers (batch_header_hash, batch_header) VALUES ($1, $2) ON CONFLICT DO NOTHING",
+		hash[:], document)
+}
+
+func (s *PostgresBlobMetadataStore) DeleteBatchHeader(ctx context.Context, batchHeaderHash [32]byte) error {
+	_, err := s.pool.Exec(ctx, "DELETE FROM batch_headers WHERE batch_header_hash = $1", batchHeaderHash[:])
+	return err
+}
+
+func (s *PostgresBlobMetadataStore) GetBatchHeader(ctx context.Context, batchHeaderHash [32]byte) (*corev2.BatchHeader, error) {
+	header, err := getDocument[corev2.BatchHeader](ctx, s.pool,
+		"SELECT batch_header FROM batch_headers WHERE batch_header_hash = $1", batchHeaderHash[:])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: batch header not found for hash %x", ErrMetadataNotFound, batchHeaderHash)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return header, nil
+}
+
+func (s *PostgresBlobMetadataStore) PutAttestation(ctx context.Context, attestation *corev2.Attestation) error {
+	hash, err := attestation.BatchHeader.Hash()
+	if err != nil {
+		return fmt.Errorf("failed to hash batch header: %w", err)
+	}
+	document, err := json.Marshal(attestation)
+	if err != nil {
+		return fmt.Errorf("failed to marshal attestation: %w", err)
+	}
+
+	// Allow overwrite of existing attestation
+	_, err = s.pool.Exec(ctx, `
+		INSERT INTO attestations (batch_header_hash, attested_at, attestation) VALUES ($1, $2, $3)
+		ON CONFLICT (batch_header_hash)
+		DO UPDATE SET attested_at = EXCLUDED.attested_at, attestation = EXCLUDED.attestation`,
+		hash[:], toPostgresTimestamp(attestation.AttestedAt), document)
+	return err
+}
+
+func (s *PostgresBlobMetadataStore) GetAttestation(ctx context.Context, batchHeaderHash [32]byte) (*corev2.Attestation, error) {
+	attestation, err := getDocument[corev2.Attestation](ctx, s.pool,
+		"SELECT attestation FROM attestations WHERE batch_header_hash = $1", batchHeaderHash[:])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: attestation not found for hash %x", ErrMetadataNotFound, batchHeaderHash)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return attestation, nil
+}
+
+func (s *PostgresBlobMetadataStore) PutBlobInclusionInfo(ctx context.Context, inclusionInfo *corev2.BlobInclusionInfo) error {
+	hash, err := inclusionInfo.BatchHeader.Hash()
+	if err != nil {
+		return fmt.Errorf("failed to hash batch header: %w", err)
+	}
+	document, err := json.Marshal(inclusionInfo)
+	if err != nil {
+		return fmt.Errorf("failed to marshal blob inclusion info: %w", err)
+	}
+
+	return s.insert(ctx, `
+		INSERT INTO blob_inclusion_infos (blob_key, batch_header_hash, inclusion_info) VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING`,
+		inclusionInfo.BlobKey[:], hash[:], document)
+}
+
+// PutBlobInclusionInfos puts multiple inclusion infos into the store in a single transaction.
+// Existing inclusion infos are overwritten.
+func (s *PostgresBlobMetadataStore) PutBlobInclusionInfos(ctx context.Context, inclusionInfos []*corev2.BlobInclusionInfo) error {
+	batch := &pgx.Batch{}
+	for _, inclusionInfo := range inclusionInfos {
+		hash, err := inclusionInfo.BatchHeader.Hash()
+		if err != nil {
+			return fmt.Errorf("failed to hash batch header: %w", err)
+		}
+		document, err := json.Marshal(inclusionInfo)
+		if err != nil {
+			return fmt.Errorf("failed to marshal blob inclusion info: %w", err)
+		}
+		batch.Queue(`
+			INSERT INTO blob_inclusion_infos (blob_key, batch_header_hash, inclusion_info) VALUES ($1, $2, $3)
+			ON CONFLICT (blob_key, batch_header_hash) DO UPDATE SET inclusion_info = EXCLUDED.inclusion_info`,
+			inclusionInfo.BlobKey[:], hash[:], document)
+	}
+
+	return pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		return tx.SendBatch(ctx, batch).Close()
+	})
+}
+
+func (s *PostgresBlobMetadataStore) GetBlobInclusionInfo(ctx context.Context, blobKey corev2.BlobKey, batchHeaderHash [32]byte) (*corev2.BlobInclusionInfo, error) {
+	info, err := getDocument[corev2.BlobInclusionInfo](ctx, s.pool,
+		"SELECT inclusion_info FROM blob_inclusion_infos WHERE blob_key = $1 AND batch_header_hash = $2",
+		blobKey[:], batchHeaderHash[:])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: inclusion info not found for key %s", ErrMetadataNotFound, blobKey.Hex())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+func (s *PostgresBlobMetadataStore) GetBlobAttestationInfo(ctx context.Context, blobKey corev2.BlobKey) (*v2.BlobAttestationInfo, error) {
+	return getBlobAttestationInfo(ctx, s, s.logger, blobKey)
+}
+
+func (s *PostgresBlobMetadataStore) GetBlobInclusionInfos(ctx context.Context, blobKey corev2.BlobKey) ([]*corev2.BlobInclusionInfo, error) {
+	rows, err := s.pool.Query(ctx,
+		"SELECT inclusion_info FROM blob_inclusion_infos WHERE blob_key = $1 ORDER BY batch_header_hash ASC",
+		blobKey[:])
+	if err != nil {
+		return nil, err
+	}
+
+	infos, err := collectDocuments[corev2.BlobInclusionInfo](rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal inclusion info: %w", err)
+	}
+
+	if len(infos) == 0 {
+		return nil, fmt.Errorf("%w: inclusion info not found for key %s", ErrMetadataNotFound, blobKey.Hex())
+	}
+
+	return infos, nil
+}
+
+func (s *PostgresBlobMetadataStore) GetSignedBatch(ctx context.Context, batchHeaderHash [32]byte) (*corev2.BatchHeader, *corev2.Attestation, error) {
+	// Both records are read by a single statement, so they are read from the same snapshot.
+	var headerDocument []byte
+	var attestationDocument []byte
+	err := s.pool.QueryRow(ctx, `
+		SELECT
+			(SELECT batch_header FROM batch_headers WHERE batch_header_hash = $1),
+			(SELECT attestation FROM attestations WHERE batch_header_hash = $1)`,
+		batchHeaderHash[:]).Scan(&headerDocument, &attestationDocument)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if headerDocument == nil && attestationDocument == nil {
+		return nil, nil, fmt.Errorf("%w: no records found for batch header hash %x", ErrMetadataNotFound, batchHeaderHash)
+	}
+
+	if headerDocument == nil {
+		return nil, nil, fmt.Errorf("%w: batch header not found for hash %x", ErrMetadataNotFound, batchHeaderHash)
+	}
+
+	if attestationDocument == nil {
+		return nil, nil, fmt.Errorf("%w: attestation not found for hash %x", ErrMetadataNotFound, batchHeaderHash)
+	}
+
+	header := &corev2.BatchHeader{}
+	err = json.Unmarshal(headerDocument, header)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal batch header: %w", err)
+	}
+	attestation := &corev2.Attestation{}
+	err = json.Unmarshal(attestationDocument, attestation)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal attestation: %w", err)
+	}
+
+	return header, attestation, nil
+}
+
+// insert executes an INSERT ... ON CONFLICT DO NOTHING statement, and returns ErrAlreadyExists if
+// the record already exists.
+func (s *PostgresBlobMetadataStore) insert(ctx context.Context, statement string, args ...any) error {
+	tag, err := s.pool.Exec(ctx, statement, args...)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrAlreadyExists
+	}
+	return nil
+}
+
+// getDocument returns the JSON document selected by a query that returns a single column and at most one row.
+// Returns pgx.ErrNoRows if the query returns no rows.
+func getDocument[T any](ctx context.Context, pool *pgxpool.Pool, query string, args ...any) (*T, error) {
+	var document []byte
+	err := pool.QueryRow(ctx, query, args...).Scan(&document)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(T)
+	err = json.Unmarshal(document, result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %T: %w", result, err)
+	}
+	return result, nil
+}
+
+// collectDocuments unmarshals the JSON documents returned by a query that returns a single column.
+func collectDocuments[T any](rows pgx.Rows) ([]*T, error) {
+	defer rows.Close()
+
+	result := make([]*T, 0)
+	for rows.Next() {
+		var document []byte
+		err := rows.Scan(&document)
+		if err != nil {
+			return nil, err
+		}
+		item := new(T)
+		err = json.Unmarshal(document, item)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %T: %w", item, err)
+		}
+		result = append(result, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// scanBlobMetadata reads blob metadata from a row that selects blobMetadataColumns.
+func scanBlobMetadata(row pgx.Row) (*v2.BlobMetadata, error) {
+	var document []byte
+	var blobStatus int16
+	var updatedAt int64
+	err := row.Scan(&document, &blobStatus, &updatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalBlobMetadata(document, blobStatus, updatedAt)
+}
+
+// collectBlobMetadata reads blob metadata from rows that select blobMetadataColumns.
+func collectBlobMetadata(rows pgx.Rows) ([]*v2.BlobMetadata, error) {
+	defer rows.Close()
+
+	result := make([]*v2.BlobMetadata, 0)
+	for rows.Next() {
+		metadata, err := scanBlobMetadata(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal blob metadata: %w", err)
+		}
+		result = append(result, metadata)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// unmarshalBlobMetadata decodes a blob metadata document. The status and update time are stored in their own
+// columns so that they can be updated without rewriting the document, and take precedence over the document.
+func unmarshalBlobMetadata(document []byte, blobStatus int16, updatedAt int64) (*v2.BlobMetadata, error) {
+	metadata := &v2.BlobMetadata{}
+	err := json.Unmarshal(document, metadata)
+	if err != nil {
+		return nil, err
+	}
+	metadata.BlobStatus = v2.BlobStatus(blobStatus)
+	metadata.UpdatedAt = uint64(updatedAt)
+	return metadata, nil
+}
+
+func unmarshalBlobCertificate(certificate []byte, fragmentInfo []byte) (*corev2.BlobCertificate, *encoding.FragmentInfo, error) {
+	cert := &corev2.BlobCertificate{}
+	err := json.Unmarshal(certificate, cert)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal blob certificate: %w", err)
+	}
+	info := &encoding.FragmentInfo{}
+	err = json.Unmarshal(fragmentInfo, info)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal fragment info: %w", err)
+	}
+	return cert, info, nil
+}
+
+// toPostgresTimestamp converts a nanosecond timestamp to a BIGINT. Timestamps that do not fit are clamped,
+// which only happens for open-ended query bounds.
+func toPostgresTimestamp(timestamp uint64) int64 {
+	if timestamp > math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return int64(timestamp)
+}
+
+// postgresLimit converts a limit to a LIMIT argument. A limit <= 0 means no limit, which is expressed as NULL.
+func postgresLimit(limit int) *int64 {
+	if limit <= 0 {
+		return nil
+	}
+	l := int64(limit)
+	return &l
+}
+
+// blobFeedCursorKeyBytes returns the blob key of a BlobFeedCursor as stored in the database. A nil blob key is
+// treated as all zeros, the same as in ToCursorKey().
+func blobFeedCursorKeyBytes(blobKey *corev2.BlobKey) []byte {
+	if blobKey == nil {
+		return make([]byte, 32)
+	}
+	return blobKey[:]
+}
+
+func sortOrder(ascending bool) string {
+	if ascending {
+		return "ASC"
+	}
+	return "DESC"
+}
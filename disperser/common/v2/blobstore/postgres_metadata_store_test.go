@@ -0,0 +1,33 @@
+package blobstore_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/stretchr/testify/require"
+)
+
+// setupPostgresTest skips the test unless a postgres server is available, and otherwise removes all records from the
+// postgres metadata store.
+func setupPostgresTest(t *testing.T) {
+	if !testPostgres {
+		t.Skip("set DEPLOY_POSTGRES=true or POSTGRES_PORT to run against postgres")
+	}
+	_, err := postgresPool.Exec(context.Background(), `TRUNCATE blob_metadata, blob_certificates, batch_headers,
+		batches, attestations, dispersal_requests, dispersal_responses, blob_inclusion_infos`)
+	require.NoError(t, err)
+}
+
+func TestPostgresMigrationIsIdempotent(t *testing.T) {
+	setupPostgresTest(t)
+	ctx := context.Background()
+
+	err := blobstore.MigratePostgresSchema(ctx, postgresPool, logger)
+	require.NoError(t, err)
+
+	var numMigrations int
+	err = postgresPool.QueryRow(ctx, "SELECT COUNT(*) FROM blobstore_schema_migrations").Scan(&numMigrations)
+	require.NoError(t, err)
+	require.Equal(t, 1, numMigrations)
+}
@@ -0,0 +1,67 @@
+-- Timestamps are unix nanoseconds. BIGINT can represent them until the year 2262.
+
+CREATE TABLE blob_metadata (
+    blob_key     BYTEA    PRIMARY KEY,
+    blob_status  SMALLINT NOT NULL,
+    updated_at   BIGINT   NOT NULL,
+    requested_at BIGINT   NOT NULL,
+    account_id   BYTEA    NOT NULL,
+    -- The full v2.BlobMetadata. blob_status and updated_at take precedence over the values in this document.
+    metadata     JSONB    NOT NULL
+);
+
+-- Serves GetBlobMetadataByStatus, GetBlobMetadataByStatusPaginated and GetBlobMetadataCountByStatus.
+CREATE INDEX blob_metadata_status_idx ON blob_metadata (blob_status, updated_at, blob_key);
+-- Serves the blob feed, which is ordered by <requested_at, blob_key>.
+CREATE INDEX blob_metadata_requested_at_idx ON blob_metadata (requested_at, blob_key);
+-- Serves GetBlobMetadataByAccountID.
+CREATE INDEX blob_metadata_account_idx ON blob_metadata (account_id, requested_at);
+
+CREATE TABLE blob_certificates (
+    blob_key      BYTEA PRIMARY KEY,
+    certificate   JSONB NOT NULL,
+    fragment_info JSONB NOT NULL
+);
+
+CREATE TABLE batch_headers (
+    batch_header_hash BYTEA PRIMARY KEY,
+    batch_header      JSONB NOT NULL
+);
+
+CREATE TABLE batches (
+    batch_header_hash BYTEA PRIMARY KEY,
+    batch             JSONB NOT NULL
+);
+
+CREATE TABLE attestations (
+    batch_header_hash BYTEA  PRIMARY KEY,
+    attested_at       BIGINT NOT NULL,
+    attestation       JSONB  NOT NULL
+);
+
+CREATE INDEX attestations_attested_at_idx ON attestations (attested_at);
+
+CREATE TABLE dispersal_requests (
+    batch_header_hash BYTEA NOT NULL,
+    operator_id       BYTEA NOT NULL,
+    request           JSONB NOT NULL,
+    PRIMARY KEY (batch_header_hash, operator_id)
+);
+
+CREATE TABLE dispersal_responses (
+    batch_header_hash BYTEA  NOT NULL,
+    operator_id       BYTEA  NOT NULL,
+    responded_at      BIGINT NOT NULL,
+    response          JSONB  NOT NULL,
+    PRIMARY KEY (batch_header_hash, operator_id)
+);
+
+-- Serves GetDispersalsByRespondedAt.
+CREATE INDEX dispersal_responses_operator_idx ON dispersal_responses (operator_id, responded_at);
+
+CREATE TABLE blob_inclusion_infos (
+    blob_key          BYTEA NOT NULL,
+    batch_header_hash BYTEA NOT NULL,
+    inclusion_info    JSONB NOT NULL,
+    PRIMARY KEY (blob_key, batch_header_hash)
+);
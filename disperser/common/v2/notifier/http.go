@@ -0,0 +1,179 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	v2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// NotificationPath is the path of the HTTP endpoint served by NewHTTPHandler
+const NotificationPath = "/v2/notify"
+
+// statusParam is the query parameter that holds the status of a notification, as its numeric value
+const statusParam = "status"
+
+// NewHTTPHandler returns an HTTP handler that relays the notifications sent by HTTPPublishers in other processes to
+// the given Notifier. Only the given statuses are accepted. A notification is a POST request to NotificationPath,
+// which carries no body and is answered with 204 No Content.
+//
+// Unlike the DynamoDB stream of the blob metadata table, this works with any metadata store backend. Notifications
+// only trigger an early read of the metadata store, so the endpoint is not authenticated, and should only be reachable
+// from the other components of the disperser.
+func NewHTTPHandler(notifier Notifier, statuses []v2.BlobStatus, logger logging.Logger) http.Handler {
+	statusSet := make(map[v2.BlobStatus]struct{}, len(statuses))
+	for _, status := range statuses {
+		statusSet[status] = struct{}{}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(NotificationPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		status, err := strconv.ParseUint(r.URL.Query().Get(statusParam), 10, 32)
+		if err != nil {
+			http.Error(w, "invalid status", http.StatusBadRequest)
+			return
+		}
+		if _, ok := statusSet[v2.BlobStatus(status)]; !ok {
+			http.Error(w, fmt.Sprintf("notifications of status %s are not accepted", v2.BlobStatus(status)),
+				http.StatusBadRequest)
+			return
+		}
+
+		notifier.Notify(v2.BlobStatus(status))
+		w.WriteHeader(http.StatusNoContent)
+	})
+	return mux
+}
+
+// HTTPPublisher is a Publisher that sends notifications to the HTTP endpoint of a Notifier in another process, e.g.
+// from the API server to the controller.
+//
+// Notify never blocks. Notifications are sent by a background goroutine one at a time, and the notifications of a
+// status made while one is being sent are coalesced into a single request. Notifications that fail to be sent are
+// dropped, since the receiver polls the metadata store as a fallback.
+type HTTPPublisher struct {
+	client *http.Client
+	// baseURL is the URL of the notification endpoint, without the status
+	baseURL *url.URL
+	logger  logging.Logger
+	// failing is whether the last request failed, so that failures are only logged as warnings when they start. It
+	// is only accessed by the sending goroutine.
+	failing bool
+
+	mu sync.Mutex
+	// pending are the statuses notified since the last requests were sent
+	pending map[v2.BlobStatus]struct{}
+	// wake receives a value when a status is added to pending
+	wake chan struct{}
+}
+
+var _ Publisher = (*HTTPPublisher)(nil)
+
+// NewHTTPPublisher creates a publisher that sends notifications to the given host (e.g. "http://controller:9102")
+// until the context is cancelled. Each request times out after the given timeout.
+func NewHTTPPublisher(
+	ctx context.Context,
+	host string,
+	timeout time.Duration,
+	logger logging.Logger,
+) (*HTTPPublisher, error) {
+	baseURL, err := url.Parse(host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid notification host %s: %w", host, err)
+	}
+	if baseURL.Scheme != "http" && baseURL.Scheme != "https" {
+		return nil, fmt.Errorf("notification host %s must be an http or https URL", host)
+	}
+	if timeout <= 0 {
+		return nil, fmt.Errorf("timeout must be positive")
+	}
+	baseURL = baseURL.JoinPath(NotificationPath)
+
+	p := &HTTPPublisher{
+		client:  &http.Client{Timeout: timeout},
+		baseURL: baseURL,
+		logger:  logger.With("component", "HTTPPublisher"),
+		pending: make(map[v2.BlobStatus]struct{}),
+		wake:    make(chan struct{}, 1),
+	}
+	go p.run(ctx)
+	return p, nil
+}
+
+func (p *HTTPPublisher) Notify(status v2.BlobStatus) {
+	p.mu.Lock()
+	p.pending[status] = struct{}{}
+	p.mu.Unlock()
+
+	select {
+	case p.wake <- struct{}{}:
+	default:
+		// The sender is already woken up
+	}
+}
+
+func (p *HTTPPublisher) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.wake:
+		}
+
+		p.mu.Lock()
+		pending := p.pending
+		p.pending = make(map[v2.BlobStatus]struct{})
+		p.mu.Unlock()
+
+		for status := range pending {
+			p.send(ctx, status)
+		}
+	}
+}
+
+// send sends the notification of a status, and logs the result
+func (p *HTTPPublisher) send(ctx context.Context, status v2.BlobStatus) {
+	err := p.post(ctx, status)
+	if err != nil {
+		if !p.failing {
+			p.logger.Warn("failed to send notification, the receiver will learn of the blobs at its next poll",
+				"status", status.String(), "url", p.baseURL.String(), "err", err)
+		} else {
+			p.logger.Debug("failed to send notification", "status", status.String(), "err", err)
+		}
+		p.failing = true
+		return
+	}
+	if p.failing {
+		p.logger.Info("notifications are delivered again", "url", p.baseURL.String())
+	}
+	p.failing = false
+}
+
+func (p *HTTPPublisher) post(ctx context.Context, status v2.BlobStatus) error {
+	u := *p.baseURL
+	u.RawQuery = url.Values{statusParam: {strconv.FormatUint(uint64(status), 10)}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+	return nil
+}
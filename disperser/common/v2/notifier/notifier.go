@@ -0,0 +1,103 @@
+package notifier
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	v2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+)
+
+// Notifier signals the components of the disperser that blobs have transitioned to a status, so that the components
+// waiting for blobs in that status can handle them right away instead of waiting for their next poll of the blob
+// metadata store.
+//
+// Notifications carry no payload and may be dropped or coalesced, so they only serve to trigger an early read of the
+// metadata store. Subscribers must keep polling the metadata store as a fallback.
+type Notifier interface {
+	Publisher
+	// Subscribe returns a channel that receives a value after blobs transition to the given status. Notifications
+	// sent while the subscriber has not yet received the previous one are coalesced into it.
+	Subscribe(status v2.BlobStatus) <-chan struct{}
+}
+
+// Publisher is the sending side of a Notifier. Components that only signal status transitions, such as the API
+// server, depend on a Publisher so that they can signal a Notifier in another process.
+type Publisher interface {
+	// Notify signals that one or more blobs have transitioned to the given status. It never blocks.
+	Notify(status v2.BlobStatus)
+}
+
+// InProcessNotifier is a Notifier that delivers notifications to subscribers in the same process.
+type InProcessNotifier struct {
+	mu          sync.Mutex
+	subscribers map[v2.BlobStatus][]chan struct{}
+}
+
+var _ Notifier = (*InProcessNotifier)(nil)
+
+func NewInProcessNotifier() *InProcessNotifier {
+	return &InProcessNotifier{
+		subscribers: make(map[v2.BlobStatus][]chan struct{}),
+	}
+}
+
+func (n *InProcessNotifier) Notify(status v2.BlobStatus) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for _, ch := range n.subscribers[status] {
+		select {
+		case ch <- struct{}{}:
+		default:
+			// A notification is already pending for this subscriber
+		}
+	}
+}
+
+func (n *InProcessNotifier) Subscribe(status v2.BlobStatus) <-chan struct{} {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	ch := make(chan struct{}, 1)
+	n.subscribers[status] = append(n.subscribers[status], ch)
+	return ch
+}
+
+// Throttle returns a channel that receives the notifications of the given channel no more often than once per
+// interval. A notification received within the interval of the previous one is delayed until the interval has
+// elapsed, and coalesced with the notifications received in the meantime. The returned channel is not closed, and
+// stops receiving notifications once the context is cancelled.
+func Throttle(ctx context.Context, notifications <-chan struct{}, interval time.Duration) <-chan struct{} {
+	throttled := make(chan struct{}, 1)
+	go func() {
+		var lastDelivery time.Time
+		// delayed fires when a delayed notification may be delivered. It is nil while no notification is delayed.
+		var delayed <-chan time.Time
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-notifications:
+				if delayed != nil {
+					// The notification is coalesced with the delayed one
+					continue
+				}
+				if wait := interval - time.Since(lastDelivery); wait > 0 {
+					delayed = time.After(wait)
+					continue
+				}
+			case <-delayed:
+				delayed = nil
+			}
+
+			lastDelivery = time.Now()
+			select {
+			case throttled <- struct{}{}:
+			default:
+				// A notification is already pending for the receiver
+			}
+		}
+	}()
+	return throttled
+}
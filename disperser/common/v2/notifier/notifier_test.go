@@ -0,0 +1,283 @@
+package notifier_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/testutils"
+	v2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/notifier"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodbstreams"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodbstreams/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInProcessNotifier(t *testing.T) {
+	n := notifier.NewInProcessNotifier()
+	queued1 := n.Subscribe(v2.Queued)
+	queued2 := n.Subscribe(v2.Queued)
+	encoded := n.Subscribe(v2.Encoded)
+
+	// Notifications are delivered to every subscriber of the status, and coalesced while pending
+	n.Notify(v2.Queued)
+	n.Notify(v2.Queued)
+	require.Len(t, queued1, 1)
+	require.Len(t, queued2, 1)
+	require.Len(t, encoded, 0)
+
+	<-queued1
+	n.Notify(v2.Queued)
+	require.Len(t, queued1, 1)
+	require.Len(t, queued2, 1)
+
+	// Notifying a status without subscribers does not block
+	n.Notify(v2.Complete)
+}
+
+// fakeStreamsClient serves the records appended to its shards, as a DynamoDB stream would
+type fakeStreamsClient struct {
+	mu sync.Mutex
+	// shards are the records of each shard, by shard ID
+	shards map[string][]types.Record
+	// closed are the shards that are no longer written to
+	closed map[string]bool
+}
+
+var _ notifier.StreamsClient = (*fakeStreamsClient)(nil)
+
+func newFakeStreamsClient() *fakeStreamsClient {
+	return &fakeStreamsClient{
+		shards: make(map[string][]types.Record),
+		closed: make(map[string]bool),
+	}
+}
+
+func (c *fakeStreamsClient) write(shardID string, status v2.BlobStatus) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.shards[shardID] = append(c.shards[shardID], types.Record{
+		EventName: types.OperationTypeInsert,
+		Dynamodb: &types.StreamRecord{
+			NewImage: map[string]types.AttributeValue{
+				"BlobStatus": &types.AttributeValueMemberN{Value: strconv.Itoa(int(status))},
+			},
+		},
+	})
+}
+
+func (c *fakeStreamsClient) ListStreams(context.Context, *dynamodbstreams.ListStreamsInput, ...func(*dynamodbstreams.Options)) (*dynamodbstreams.ListStreamsOutput, error) {
+	return &dynamodbstreams.ListStreamsOutput{
+		Streams: []types.Stream{{StreamArn: aws.String("stream")}},
+	}, nil
+}
+
+func (c *fakeStreamsClient) DescribeStream(context.Context, *dynamodbstreams.DescribeStreamInput, ...func(*dynamodbstreams.Options)) (*dynamodbstreams.DescribeStreamOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	shards := make([]types.Shard, 0, len(c.shards))
+	for shardID := range c.shards {
+		shard := types.Shard{
+			ShardId:             aws.String(shardID),
+			SequenceNumberRange: &types.SequenceNumberRange{},
+		}
+		if c.closed[shardID] {
+			shard.SequenceNumberRange.EndingSequenceNumber = aws.String(strconv.Itoa(len(c.shards[shardID])))
+		}
+		shards = append(shards, shard)
+	}
+	return &dynamodbstreams.DescribeStreamOutput{
+		StreamDescription: &types.StreamDescription{Shards: shards},
+	}, nil
+}
+
+func (c *fakeStreamsClient) GetShardIterator(_ context.Context, params *dynamodbstreams.GetShardIteratorInput, _ ...func(*dynamodbstreams.Options)) (*dynamodbstreams.GetShardIteratorOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	position := 0
+	if params.ShardIteratorType == types.ShardIteratorTypeLatest {
+		position = len(c.shards[*params.ShardId])
+	}
+	return &dynamodbstreams.GetShardIteratorOutput{
+		ShardIterator: aws.String(*params.ShardId + "/" + strconv.Itoa(position)),
+	}, nil
+}
+
+func (c *fakeStreamsClient) GetRecords(_ context.Context, params *dynamodbstreams.GetRecordsInput, _ ...func(*dynamodbstreams.Options)) (*dynamodbstreams.GetRecordsOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// Iterators are of the form <shard ID>/<position of the next record>
+	i := strings.LastIndex(*params.ShardIterator, "/")
+	shardID := (*params.ShardIterator)[:i]
+	position, err := strconv.Atoi((*params.ShardIterator)[i+1:])
+	if err != nil {
+		return nil, err
+	}
+
+	records := c.shards[shardID][position:]
+	out := &dynamodbstreams.GetRecordsOutput{Records: records}
+	if !c.closed[shardID] {
+		out.NextShardIterator = aws.String(shardID + "/" + strconv.Itoa(len(c.shards[shardID])))
+	}
+	return out, nil
+}
+
+func TestStreamListener(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := newFakeStreamsClient()
+	// A record written before the listener starts is not notified
+	client.write("shard-0", v2.Queued)
+
+	n := notifier.NewInProcessNotifier()
+	queued := n.Subscribe(v2.Queued)
+	encoded := n.Subscribe(v2.Encoded)
+	listener, err := notifier.NewStreamListener(
+		client, "table", []v2.BlobStatus{v2.Queued}, n, time.Hour, testutils.GetLogger())
+	require.NoError(t, err)
+	require.NoError(t, listener.Start(ctx))
+
+	require.NoError(t, listener.Poll(ctx))
+	require.Len(t, queued, 0)
+
+	// Only the watched statuses are notified
+	client.write("shard-0", v2.Encoded)
+	require.NoError(t, listener.Poll(ctx))
+	require.Len(t, queued, 0)
+	require.Len(t, encoded, 0)
+
+	client.write("shard-0", v2.Queued)
+	client.write("shard-0", v2.Queued)
+	require.NoError(t, listener.Poll(ctx))
+	require.Len(t, queued, 1)
+	<-queued
+
+	// When a shard is closed, its remaining records are read and the listener moves on to its child shard
+	client.write("shard-0", v2.Queued)
+	client.mu.Lock()
+	client.closed["shard-0"] = true
+	client.shards["shard-1"] = nil
+	client.mu.Unlock()
+	require.NoError(t, listener.Poll(ctx))
+	require.Len(t, queued, 1)
+	<-queued
+
+	client.write("shard-1", v2.Queued)
+	// The first poll picks up the child shard, which is read from its start
+	require.NoError(t, listener.Poll(ctx))
+	require.Len(t, queued, 1)
+}
+
+func TestThrottle(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	interval := 200 * time.Millisecond
+	notifications := make(chan struct{}, 1)
+	throttled := notifier.Throttle(ctx, notifications, interval)
+
+	// The first notification is delivered right away
+	start := time.Now()
+	notifications <- struct{}{}
+	select {
+	case <-throttled:
+	case <-time.After(interval / 2):
+		t.Fatal("first notification was not delivered right away")
+	}
+
+	// The notifications within the interval are delayed until it has elapsed, and delivered once
+	for i := 0; i < 5; i++ {
+		notifications <- struct{}{}
+	}
+	select {
+	case <-throttled:
+		require.GreaterOrEqual(t, time.Since(start), interval)
+	case <-time.After(2 * interval):
+		t.Fatal("delayed notification was not delivered")
+	}
+	select {
+	case <-throttled:
+		t.Fatal("delayed notifications were not coalesced")
+	case <-time.After(2 * interval):
+	}
+
+	// Once the interval has elapsed, notifications are delivered right away again
+	notifications <- struct{}{}
+	select {
+	case <-throttled:
+	case <-time.After(interval / 2):
+		t.Fatal("notification was not delivered right away")
+	}
+}
+
+func TestHTTPNotifications(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	n := notifier.NewInProcessNotifier()
+	queued := n.Subscribe(v2.Queued)
+	encoded := n.Subscribe(v2.Encoded)
+	handler := notifier.NewHTTPHandler(n, []v2.BlobStatus{v2.Queued}, testutils.GetLogger())
+	// down makes the server fail every request, as if the receiving process was restarting
+	var down atomic.Bool
+	var failedRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if down.Load() {
+			failedRequests.Add(1)
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	_, err := notifier.NewHTTPPublisher(ctx, "controller:9102", time.Second, testutils.GetLogger())
+	require.Error(t, err)
+	publisher, err := notifier.NewHTTPPublisher(ctx, server.URL, time.Second, testutils.GetLogger())
+	require.NoError(t, err)
+
+	// A notification published in one process is delivered to the subscribers in the other
+	publisher.Notify(v2.Queued)
+	select {
+	case <-queued:
+	case <-time.After(5 * time.Second):
+		t.Fatal("queued notification was not delivered")
+	}
+
+	// Only POST requests of the accepted statuses are relayed
+	resp, err := http.Post(server.URL+notifier.NotificationPath+"?status=1", "", nil)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp, err = http.Post(server.URL+notifier.NotificationPath+"?status=queued", "", nil)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp, err = http.Get(server.URL + notifier.NotificationPath + "?status=0")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+	require.Len(t, queued, 0)
+	require.Len(t, encoded, 0)
+
+	// A notification that fails to be sent is dropped, and does not hold back the following ones
+	down.Store(true)
+	publisher.Notify(v2.Queued)
+	require.Eventually(t, func() bool { return failedRequests.Load() == 1 }, 5*time.Second, 10*time.Millisecond)
+	down.Store(false)
+	require.Len(t, queued, 0)
+	publisher.Notify(v2.Queued)
+	select {
+	case <-queued:
+	case <-time.After(5 * time.Second):
+		t.Fatal("queued notification was not delivered after the receiver recovered")
+	}
+}
@@ -0,0 +1,249 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	v2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodbstreams"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodbstreams/types"
+)
+
+// shardRefreshInterval is the interval at which the shards of the stream are listed, to pick up the shards created
+// since the last listing
+const shardRefreshInterval = time.Minute
+
+// StreamsClient is the subset of the DynamoDB Streams API used by StreamListener
+type StreamsClient interface {
+	ListStreams(ctx context.Context, params *dynamodbstreams.ListStreamsInput, optFns ...func(*dynamodbstreams.Options)) (*dynamodbstreams.ListStreamsOutput, error)
+	DescribeStream(ctx context.Context, params *dynamodbstreams.DescribeStreamInput, optFns ...func(*dynamodbstreams.Options)) (*dynamodbstreams.DescribeStreamOutput, error)
+	GetShardIterator(ctx context.Context, params *dynamodbstreams.GetShardIteratorInput, optFns ...func(*dynamodbstreams.Options)) (*dynamodbstreams.GetShardIteratorOutput, error)
+	GetRecords(ctx context.Context, params *dynamodbstreams.GetRecordsInput, optFns ...func(*dynamodbstreams.Options)) (*dynamodbstreams.GetRecordsOutput, error)
+}
+
+// StreamListener consumes the DynamoDB stream of the blob metadata table, and notifies a Notifier whenever a blob is
+// written with one of the watched statuses. It lets the controller learn of the blobs queued by the API server, which
+// runs in a different process, as soon as they are stored.
+//
+// The stream must be enabled on the table with a view type that includes the new image of the items.
+type StreamListener struct {
+	client       StreamsClient
+	tableName    string
+	statuses     map[v2.BlobStatus]struct{}
+	notifier     Notifier
+	pollInterval time.Duration
+	logger       logging.Logger
+
+	streamArn *string
+	// shardIterators are the iterators of the open shards being read, by shard ID
+	shardIterators map[string]*string
+	// lastShardRefresh is when the shards of the stream were last listed
+	lastShardRefresh time.Time
+}
+
+func NewStreamListener(
+	client StreamsClient,
+	tableName string,
+	statuses []v2.BlobStatus,
+	notifier Notifier,
+	pollInterval time.Duration,
+	logger logging.Logger,
+) (*StreamListener, error) {
+	if len(statuses) == 0 {
+		return nil, errors.New("no statuses to watch")
+	}
+	if pollInterval <= 0 {
+		return nil, errors.New("poll interval must be positive")
+	}
+
+	statusSet := make(map[v2.BlobStatus]struct{}, len(statuses))
+	for _, status := range statuses {
+		statusSet[status] = struct{}{}
+	}
+
+	return &StreamListener{
+		client:         client,
+		tableName:      tableName,
+		statuses:       statusSet,
+		notifier:       notifier,
+		pollInterval:   pollInterval,
+		logger:         logger.With("component", "StreamListener"),
+		shardIterators: make(map[string]*string),
+	}, nil
+}
+
+// Start resolves the stream of the table and starts reading it in the background until the context is cancelled.
+func (l *StreamListener) Start(ctx context.Context) error {
+	out, err := l.client.ListStreams(ctx, &dynamodbstreams.ListStreamsInput{
+		TableName: aws.String(l.tableName),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list streams of table %s: %w", l.tableName, err)
+	}
+	if len(out.Streams) == 0 {
+		return fmt.Errorf("no stream is enabled on table %s", l.tableName)
+	}
+	// The latest stream is listed first
+	l.streamArn = out.Streams[0].StreamArn
+
+	// Start reading from the current end of the stream, since the blobs written before the listener started are picked
+	// up by the regular polling of the metadata store
+	err = l.refreshShards(ctx, types.ShardIteratorTypeLatest)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		ticker := time.NewTicker(l.pollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := l.Poll(ctx); err != nil {
+					l.logger.Warn("failed to read blob metadata stream", "err", err)
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Poll reads the records added to the stream since the last call, and notifies the statuses that blobs were written
+// with.
+//
+// WARNING: This method is not thread-safe. It is called periodically by the loop started by Start.
+func (l *StreamListener) Poll(ctx context.Context) error {
+	if l.streamArn == nil {
+		return errors.New("stream listener is not started")
+	}
+
+	if time.Since(l.lastShardRefresh) > shardRefreshInterval {
+		// The shards created since the last listing are children of the shards being read, so they are read from
+		// their start to not miss any record
+		err := l.refreshShards(ctx, types.ShardIteratorTypeTrimHorizon)
+		if err != nil {
+			return err
+		}
+	}
+
+	notified := make(map[v2.BlobStatus]struct{})
+	for shardID, iterator := range l.shardIterators {
+		out, err := l.client.GetRecords(ctx, &dynamodbstreams.GetRecordsInput{
+			ShardIterator: iterator,
+		})
+		if err != nil {
+			var expiredErr *types.ExpiredIteratorException
+			var trimmedErr *types.TrimmedDataAccessException
+			if errors.As(err, &expiredErr) || errors.As(err, &trimmedErr) {
+				// Get a new iterator for the shard at the next refresh
+				l.logger.Warn("shard iterator is no longer valid", "shardID", shardID, "err", err)
+				delete(l.shardIterators, shardID)
+				l.lastShardRefresh = time.Time{}
+				continue
+			}
+			return fmt.Errorf("failed to get records of shard %s: %w", shardID, err)
+		}
+
+		for _, record := range out.Records {
+			status, ok := recordBlobStatus(record)
+			if !ok {
+				continue
+			}
+			if _, ok := l.statuses[status]; !ok {
+				continue
+			}
+			if _, ok := notified[status]; !ok {
+				l.notifier.Notify(status)
+				notified[status] = struct{}{}
+			}
+		}
+
+		if out.NextShardIterator == nil {
+			// The shard is closed and all its records have been read. Its children are picked up at the next refresh.
+			delete(l.shardIterators, shardID)
+			l.lastShardRefresh = time.Time{}
+			continue
+		}
+		l.shardIterators[shardID] = out.NextShardIterator
+	}
+
+	return nil
+}
+
+// refreshShards lists the shards of the stream, and gets an iterator of the given type for each open shard that is not
+// being read yet.
+func (l *StreamListener) refreshShards(ctx context.Context, iteratorType types.ShardIteratorType) error {
+	var exclusiveStartShardID *string
+	for {
+		out, err := l.client.DescribeStream(ctx, &dynamodbstreams.DescribeStreamInput{
+			StreamArn:             l.streamArn,
+			ExclusiveStartShardId: exclusiveStartShardID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to describe stream: %w", err)
+		}
+		if out.StreamDescription == nil {
+			return errors.New("stream description is nil")
+		}
+
+		for _, shard := range out.StreamDescription.Shards {
+			if shard.ShardId == nil {
+				continue
+			}
+			shardID := *shard.ShardId
+			if _, ok := l.shardIterators[shardID]; ok {
+				continue
+			}
+			if shard.SequenceNumberRange != nil && shard.SequenceNumberRange.EndingSequenceNumber != nil {
+				// Closed shards are not written to anymore
+				continue
+			}
+
+			iterator, err := l.client.GetShardIterator(ctx, &dynamodbstreams.GetShardIteratorInput{
+				StreamArn:         l.streamArn,
+				ShardId:           shard.ShardId,
+				ShardIteratorType: iteratorType,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to get iterator of shard %s: %w", shardID, err)
+			}
+			l.shardIterators[shardID] = iterator.ShardIterator
+		}
+
+		exclusiveStartShardID = out.StreamDescription.LastEvaluatedShardId
+		if exclusiveStartShardID == nil {
+			break
+		}
+	}
+
+	l.lastShardRefresh = time.Now()
+	return nil
+}
+
+// recordBlobStatus returns the blob status of the item written by the record, if the item is a blob metadata item.
+func recordBlobStatus(record types.Record) (v2.BlobStatus, bool) {
+	if record.Dynamodb == nil {
+		return 0, false
+	}
+	attr, ok := record.Dynamodb.NewImage["BlobStatus"]
+	if !ok {
+		return 0, false
+	}
+	n, ok := attr.(*types.AttributeValueMemberN)
+	if !ok {
+		return 0, false
+	}
+	status, err := strconv.ParseUint(n.Value, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return v2.BlobStatus(status), true
+}
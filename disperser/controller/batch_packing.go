@@ -0,0 +1,99 @@
+package controller
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	v2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+)
+
+// BatchLimits are the limits on the contents of a batch. A zero limit is not enforced.
+type BatchLimits struct {
+	// MaxSymbols is the maximum total number of symbols of the blobs in a batch
+	MaxSymbols uint64
+	// MaxChunkBytesPerValidator is the maximum number of encoded chunk bytes any single validator receives for a batch
+	MaxChunkBytesPerValidator uint64
+}
+
+// PackBatch determines how many of the given blobs, taken in order, fit in a batch without exceeding the limits.
+// The blobs that do not fit are left for the next batch. A first blob that exceeds the limits on its own is put in a
+// batch by itself, so that an oversized blob cannot stall dispatching.
+//
+// PackBatch returns the number of blobs that fit and the number of encoded chunk bytes each validator receives for
+// them.
+func PackBatch(
+	blobs []*v2.BlobMetadata,
+	state *core.OperatorState,
+	blobVersionParams *corev2.BlobVersionParameterMap,
+	limits BatchLimits,
+) (int, map[core.OperatorID]uint64, error) {
+	validatorBytes := make(map[core.OperatorID]uint64)
+	// The assignments of a blob only depend on its version and quorums, so they are shared by most blobs of a batch
+	assignmentsCache := make(map[string]map[core.OperatorID]corev2.Assignment)
+	totalSymbols := uint64(0)
+
+	for i, blob := range blobs {
+		if blob == nil || blob.BlobHeader == nil {
+			return 0, nil, fmt.Errorf("invalid blob metadata")
+		}
+		header := blob.BlobHeader
+		blobParams, ok := blobVersionParams.Get(header.BlobVersion)
+		if !ok {
+			return 0, nil, fmt.Errorf("blob version parameters not found for version %d", header.BlobVersion)
+		}
+
+		// Quorums that are not in the operator state are not dispersed to, see Dispatcher.GetOperatorState
+		quorums := make([]core.QuorumID, 0, len(header.QuorumNumbers))
+		for _, quorum := range header.QuorumNumbers {
+			if _, ok := state.Operators[quorum]; ok {
+				quorums = append(quorums, quorum)
+			}
+		}
+		slices.Sort(quorums)
+		cacheKey := fmt.Sprintf("%d/%x", header.BlobVersion, quorums)
+		assignments, ok := assignmentsCache[cacheKey]
+		if !ok && len(quorums) > 0 {
+			var err error
+			assignments, err = corev2.GetAssignmentsForBlob(state, blobParams, quorums)
+			if err != nil {
+				return 0, nil, fmt.Errorf("failed to get assignments for blob: %w", err)
+			}
+			assignmentsCache[cacheKey] = assignments
+		}
+
+		chunkLength, err := corev2.GetChunkLength(uint32(header.BlobCommitments.Length), blobParams)
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to get chunk length: %w", err)
+		}
+		chunkSize := uint64(bn254.SizeOfG1AffineCompressed + encoding.BYTES_PER_SYMBOL*int(chunkLength))
+
+		blobSymbols := uint64(header.BlobCommitments.Length)
+		fits := limits.MaxSymbols == 0 || totalSymbols+blobSymbols <= limits.MaxSymbols
+		if fits && limits.MaxChunkBytesPerValidator > 0 {
+			for opID, assignment := range assignments {
+				if validatorBytes[opID]+uint64(assignment.NumChunks())*chunkSize > limits.MaxChunkBytesPerValidator {
+					fits = false
+					break
+				}
+			}
+		}
+		if !fits && i > 0 {
+			return i, validatorBytes, nil
+		}
+
+		totalSymbols += blobSymbols
+		for opID, assignment := range assignments {
+			validatorBytes[opID] += uint64(assignment.NumChunks()) * chunkSize
+		}
+		if !fits {
+			// An oversized blob is dispatched in a batch of its own
+			return 1, validatorBytes, nil
+		}
+	}
+
+	return len(blobs), validatorBytes, nil
+}
@@ -0,0 +1,133 @@
+package controller_test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/core"
+	coremock "github.com/Layr-Labs/eigenda/core/mock"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	commonv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/disperser/controller"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/stretchr/testify/require"
+)
+
+func newPackingTestBlob(length uint, quorums []core.QuorumID) *commonv2.BlobMetadata {
+	return &commonv2.BlobMetadata{
+		BlobHeader: &corev2.BlobHeader{
+			BlobVersion:   0,
+			QuorumNumbers: quorums,
+			BlobCommitments: encoding.BlobCommitments{
+				Length: length,
+			},
+		},
+	}
+}
+
+func TestPackBatch(t *testing.T) {
+	stakes := map[core.QuorumID]map[core.OperatorID]int{
+		0: {
+			coremock.MakeOperatorId(0): 1,
+			coremock.MakeOperatorId(1): 2,
+			coremock.MakeOperatorId(2): 3,
+			coremock.MakeOperatorId(3): 1,
+		},
+		1: {
+			coremock.MakeOperatorId(0): 1,
+			coremock.MakeOperatorId(3): 1,
+		},
+	}
+	dat, err := coremock.NewChainDataMock(stakes)
+	require.NoError(t, err)
+	state := dat.GetTotalOperatorState(context.Background(), 0)
+	blobVersionParams := corev2.NewBlobVersionParameterMap(map[corev2.BlobVersion]*core.BlobVersionParameters{
+		0: {
+			NumChunks:       8192,
+			CodingRate:      8,
+			MaxNumOperators: 2048,
+		},
+	})
+
+	t.Run("no limits", func(t *testing.T) {
+		blobs := []*commonv2.BlobMetadata{
+			newPackingTestBlob(1<<16, []core.QuorumID{1}),
+			newPackingTestBlob(1<<16, []core.QuorumID{1}),
+		}
+		numBlobs, validatorBytes, err := controller.PackBatch(blobs, state.OperatorState, blobVersionParams, controller.BatchLimits{})
+		require.NoError(t, err)
+		require.Equal(t, 2, numBlobs)
+		// Only the operators of quorum 1 receive chunks, and they split the chunks evenly
+		require.Len(t, validatorBytes, 2)
+		require.Equal(t, validatorBytes[coremock.MakeOperatorId(0)], validatorBytes[coremock.MakeOperatorId(3)])
+	})
+
+	t.Run("symbol limit", func(t *testing.T) {
+		blobs := []*commonv2.BlobMetadata{
+			newPackingTestBlob(1<<10, []core.QuorumID{0}),
+			newPackingTestBlob(1<<10, []core.QuorumID{0}),
+			newPackingTestBlob(1<<10, []core.QuorumID{0}),
+		}
+		numBlobs, _, err := controller.PackBatch(blobs, state.OperatorState, blobVersionParams, controller.BatchLimits{
+			MaxSymbols: 1<<11 + 1,
+		})
+		require.NoError(t, err)
+		require.Equal(t, 2, numBlobs)
+	})
+
+	t.Run("oversized first blob", func(t *testing.T) {
+		blobs := []*commonv2.BlobMetadata{
+			newPackingTestBlob(1<<16, []core.QuorumID{0}),
+			newPackingTestBlob(1<<4, []core.QuorumID{0}),
+		}
+		numBlobs, _, err := controller.PackBatch(blobs, state.OperatorState, blobVersionParams, controller.BatchLimits{
+			MaxSymbols: 1 << 10,
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, numBlobs)
+	})
+
+	t.Run("simulation", func(t *testing.T) {
+		// A stream of blobs of mixed sizes is cut into batches. Every batch should stay within the per-validator
+		// limit, except for batches made of a single oversized blob, and every blob should be dispatched exactly once
+		// and in order.
+		maxChunkBytesPerValidator := uint64(4 * 1024 * 1024)
+		quorumSets := [][]core.QuorumID{{0}, {1}, {0, 1}}
+		rng := rand.New(rand.NewSource(1))
+		blobs := make([]*commonv2.BlobMetadata, 1000)
+		for i := range blobs {
+			length := uint(1) << (4 + rng.Intn(14))
+			blobs[i] = newPackingTestBlob(length, quorumSets[rng.Intn(len(quorumSets))])
+		}
+
+		dispatched := make([]*commonv2.BlobMetadata, 0, len(blobs))
+		numBatches := 0
+		remaining := blobs
+		for len(remaining) > 0 {
+			// The dispatcher fetches at most MaxBatchSize blobs at a time
+			page := remaining[:min(len(remaining), 100)]
+			numBlobs, validatorBytes, err := controller.PackBatch(
+				page,
+				state.OperatorState,
+				blobVersionParams,
+				controller.BatchLimits{MaxChunkBytesPerValidator: maxChunkBytesPerValidator})
+			require.NoError(t, err)
+			require.Greater(t, numBlobs, 0)
+
+			if numBlobs > 1 {
+				for _, bytes := range validatorBytes {
+					require.LessOrEqual(t, bytes, maxChunkBytesPerValidator)
+				}
+			}
+
+			dispatched = append(dispatched, page[:numBlobs]...)
+			remaining = remaining[numBlobs:]
+			numBatches++
+		}
+
+		require.Equal(t, blobs, dispatched)
+		// The large blobs should have split the stream into more batches than the blob count limit alone
+		require.Greater(t, numBatches, len(blobs)/100)
+	})
+}
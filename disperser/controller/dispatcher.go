@@ -9,6 +9,7 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/api/clients/v2"
@@ -18,6 +19,7 @@ import (
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	v2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
 	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/notifier"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/hashicorp/go-multierror"
@@ -29,7 +31,12 @@ var errNoBlobsToDispatch = errors.New("no blobs to dispatch")
 type BlobCallback func(blobKey corev2.BlobKey) error
 
 type DispatcherConfig struct {
+	// PullInterval is the interval at which the metadata store is polled for encoded blobs. Blobs are usually
+	// dispatched as soon as they are notified, so polling only picks up the blobs whose notification was missed.
 	PullInterval time.Duration
+	// MinBatchInterval is the minimum interval between the batches created upon notifications of encoded blobs.
+	// The blobs notified within the interval are batched together once it has elapsed.
+	MinBatchInterval time.Duration
 
 	FinalizationBlockDelay uint64
 	// The maximum time permitted to wait for a node to provide a signature for a batch.
@@ -42,6 +49,11 @@ type DispatcherConfig struct {
 	NumRequestRetries     int
 	// MaxBatchSize is the maximum number of blobs to dispatch in a batch
 	MaxBatchSize int32
+	// MaxBatchSymbols is the maximum total number of symbols of the blobs in a batch. If zero, it is not enforced.
+	MaxBatchSymbols uint64
+	// MaxBatchChunkBytesPerValidator is the maximum number of encoded chunk bytes sent to any single validator in a
+	// batch. If zero, it is not enforced.
+	MaxBatchChunkBytesPerValidator uint64
 	// SignificantSigningThresholdPercentage is a configurable "important" signing threshold. Right now, it's being
 	// used to track signing metrics, to understand system performance. If the value is 0, then special handling for
 	// the threshold is disabled.
@@ -49,6 +61,9 @@ type DispatcherConfig struct {
 	// Important signing thresholds for metrics reporting.
 	// Values should be between 0.0 (0% signed) and 1.0 (100% signed).
 	SignificantSigningMetricsThresholds []string
+	// PriorityWeights are the shares of the batch capacity given to each priority class. If nil,
+	// DefaultPriorityWeights is used.
+	PriorityWeights PriorityWeights
 }
 
 type Dispatcher struct {
@@ -57,12 +72,17 @@ type Dispatcher struct {
 	blobMetadataStore blobstore.MetadataStore
 	pool              common.WorkerPool
 	chainState        core.IndexedChainState
+	chainReader       core.Reader
 	aggregator        core.SignatureAggregator
 	nodeClientManager NodeClientManager
-	logger            logging.Logger
-	metrics           *dispatcherMetrics
-
-	cursor *blobstore.StatusIndexCursor
+	// blobNotifier notifies the dispatcher of encoded blobs
+	blobNotifier notifier.Notifier
+	logger       logging.Logger
+	metrics      *dispatcherMetrics
+
+	cursor                *blobstore.StatusIndexCursor
+	fairQueue             *FairQueue
+	blobVersionParameters atomic.Pointer[corev2.BlobVersionParameterMap]
 	// beforeDispatch function is called before dispatching a blob
 	beforeDispatch BlobCallback
 	// blobSet keeps track of blobs that are being dispatched
@@ -85,12 +105,14 @@ func NewDispatcher(
 	blobMetadataStore blobstore.MetadataStore,
 	pool common.WorkerPool,
 	chainState core.IndexedChainState,
+	chainReader core.Reader,
 	aggregator core.SignatureAggregator,
 	nodeClientManager NodeClientManager,
 	logger logging.Logger,
 	registry *prometheus.Registry,
 	beforeDispatch func(blobKey corev2.BlobKey) error,
 	blobSet BlobSet,
+	blobNotifier notifier.Notifier,
 	controllerLivenessChan chan<- healthcheck.HeartbeatMessage,
 ) (*Dispatcher, error) {
 	if config == nil {
@@ -103,6 +125,9 @@ func NewDispatcher(
 		config.MaxBatchSize == 0 {
 		return nil, errors.New("invalid config")
 	}
+	if blobNotifier == nil {
+		return nil, errors.New("blob notifier is required")
+	}
 
 	// CLI library doesn't support float slices at current version, parsing must happen manually
 	significantThresholds := make([]float64, 0, len(config.SignificantSigningMetricsThresholds))
@@ -119,18 +144,30 @@ func NewDispatcher(
 		return nil, fmt.Errorf("failed to initialize metrics: %v", err)
 	}
 
+	priorityWeights := config.PriorityWeights
+	if priorityWeights == nil {
+		priorityWeights = DefaultPriorityWeights
+	}
+	fairQueue, err := NewFairQueue(priorityWeights)
+	if err != nil {
+		return nil, fmt.Errorf("invalid priority weights: %w", err)
+	}
+
 	return &Dispatcher{
 		DispatcherConfig: config,
 
 		blobMetadataStore: blobMetadataStore,
 		pool:              pool,
 		chainState:        chainState,
+		chainReader:       chainReader,
 		aggregator:        aggregator,
 		nodeClientManager: nodeClientManager,
+		blobNotifier:      blobNotifier,
 		logger:            logger.With("component", "Dispatcher"),
 		metrics:           metrics,
 
 		cursor:                 nil,
+		fairQueue:              fairQueue,
 		beforeDispatch:         beforeDispatch,
 		blobSet:                blobSet,
 		controllerLivenessChan: controllerLivenessChan,
@@ -143,6 +180,14 @@ func (d *Dispatcher) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to start chain state: %w", err)
 	}
 
+	if d.batchLimits() != (BatchLimits{}) {
+		err = d.refreshBlobVersionParams(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to refresh blob version parameters: %w", err)
+		}
+	}
+
+	encoded := notifier.Throttle(ctx, d.blobNotifier.Subscribe(v2.Encoded), d.MinBatchInterval)
 	go func() {
 		ticker := time.NewTicker(d.PullInterval)
 		defer ticker.Stop()
@@ -150,31 +195,35 @@ func (d *Dispatcher) Start(ctx context.Context) error {
 			select {
 			case <-ctx.Done():
 				return
+			case <-encoded:
+				d.metrics.reportIteration("notification")
 			case <-ticker.C:
-				attestationCtx, cancel := context.WithTimeout(ctx, d.BatchAttestationTimeout)
-				probe := d.metrics.newBatchProbe()
+				d.metrics.reportIteration("poll")
+			}
 
-				sigChan, batchData, err := d.HandleBatch(attestationCtx, probe)
-				if err != nil {
-					if errors.Is(err, errNoBlobsToDispatch) {
-						d.logger.Debug("no blobs to dispatch")
-					} else {
-						d.logger.Error("failed to process a batch", "err", err)
-					}
-					cancel()
-					probe.End()
-					continue
+			attestationCtx, cancel := context.WithTimeout(ctx, d.BatchAttestationTimeout)
+			probe := d.metrics.newBatchProbe()
+
+			sigChan, batchData, err := d.HandleBatch(attestationCtx, probe)
+			if err != nil {
+				if errors.Is(err, errNoBlobsToDispatch) {
+					d.logger.Debug("no blobs to dispatch")
+				} else {
+					d.logger.Error("failed to process a batch", "err", err)
 				}
-				go func() {
-					probe.SetStage("handle_signatures")
-					err := d.HandleSignatures(ctx, attestationCtx, batchData, sigChan)
-					if err != nil {
-						d.logger.Error("failed to handle signatures", "err", err)
-					}
-					cancel()
-					probe.End()
-				}()
+				cancel()
+				probe.End()
+				continue
 			}
+			go func() {
+				probe.SetStage("handle_signatures")
+				err := d.HandleSignatures(ctx, attestationCtx, batchData, sigChan)
+				if err != nil {
+					d.logger.Error("failed to handle signatures", "err", err)
+				}
+				cancel()
+				probe.End()
+			}()
 		}
 	}()
 
@@ -563,21 +612,23 @@ func (d *Dispatcher) NewBatch(
 ) (*batchData, error) {
 
 	probe.SetStage("get_blob_metadata")
-	blobMetadatas, cursor, err := d.blobMetadataStore.GetBlobMetadataByStatusPaginated(
+	encodedBlobs, cursor, err := d.blobMetadataStore.GetBlobMetadataByStatusPaginated(
 		ctx,
 		v2.Encoded,
 		d.cursor,
-		d.MaxBatchSize,
+		d.MaxBatchSize*schedulingWindowFactor,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get blob metadata by status: %w", err)
 	}
 
-	blobMetadatas = d.dedupBlobs(blobMetadatas)
+	encodedBlobs = d.dedupBlobs(encodedBlobs)
 	d.metrics.reportBlobSetSize(d.blobSet.Size())
-	if len(blobMetadatas) == 0 {
+	d.metrics.reportQueueDepth(encodedBlobs)
+	if len(encodedBlobs) == 0 {
 		return nil, errNoBlobsToDispatch
 	}
+	blobMetadatas := d.fairQueue.Schedule(encodedBlobs, int(d.MaxBatchSize))
 	d.logger.Debug("got new metadatas to make batch",
 		"numBlobs", len(blobMetadatas),
 		"referenceBlockNumber", referenceBlockNumber)
@@ -588,6 +639,38 @@ func (d *Dispatcher) NewBatch(
 		return nil, fmt.Errorf("failed to get operator state at block %d: %w", referenceBlockNumber, err)
 	}
 
+	if limits := d.batchLimits(); limits != (BatchLimits{}) {
+		probe.SetStage("pack_batch")
+		blobVersionParams, err := d.getBlobVersionParams(ctx, blobMetadatas)
+		if err != nil {
+			return nil, err
+		}
+		numBlobs, validatorBytes, err := PackBatch(blobMetadatas, state.OperatorState, blobVersionParams, limits)
+		if err != nil {
+			return nil, fmt.Errorf("failed to pack batch: %w", err)
+		}
+		d.metrics.reportBatchValidatorBytes(validatorBytes)
+
+		if numBlobs < len(blobMetadatas) {
+			d.logger.Debug("batch limits reached, leaving blobs for the next batch",
+				"numBlobs", numBlobs,
+				"numLeftoverBlobs", len(blobMetadatas)-numBlobs)
+			blobMetadatas = blobMetadatas[:numBlobs]
+
+			// The leftover blobs may be the only ones in some of the quorums
+			state, err = d.GetOperatorState(ctx, blobMetadatas, referenceBlockNumber)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get operator state at block %d: %w", referenceBlockNumber, err)
+			}
+		}
+	}
+
+	// Resume from the first blob left out of the batch, so that the leftover blobs are picked up by the next batch
+	cursor, err = resumeCursor(encodedBlobs, blobMetadatas, d.cursor, cursor)
+	if err != nil {
+		return nil, err
+	}
+
 	keys := make([]corev2.BlobKey, len(blobMetadatas))
 	metadataMap := make(map[corev2.BlobKey]*v2.BlobMetadata, len(blobMetadatas))
 	for i, metadata := range blobMetadatas {
@@ -600,6 +683,8 @@ func (d *Dispatcher) NewBatch(
 		}
 		keys[i] = blobKey
 		metadataMap[blobKey] = metadata
+		// The blob was last updated when it was encoded
+		d.metrics.reportEncodedToBatchLatency(time.Since(time.Unix(0, int64(metadata.UpdatedAt))))
 
 		if d.beforeDispatch != nil {
 			err = d.beforeDispatch(blobKey)
@@ -727,6 +812,45 @@ func (d *Dispatcher) NewBatch(
 	}, nil
 }
 
+// batchLimits returns the limits on the contents of a batch, beyond the number of blobs.
+func (d *Dispatcher) batchLimits() BatchLimits {
+	return BatchLimits{
+		MaxSymbols:                d.MaxBatchSymbols,
+		MaxChunkBytesPerValidator: d.MaxBatchChunkBytesPerValidator,
+	}
+}
+
+// getBlobVersionParams returns the blob version parameters, refreshing them if any of the given blobs has a version
+// that is not known yet.
+func (d *Dispatcher) getBlobVersionParams(
+	ctx context.Context,
+	metadatas []*v2.BlobMetadata,
+) (*corev2.BlobVersionParameterMap, error) {
+	blobVersionParams := d.blobVersionParameters.Load()
+	for _, metadata := range metadatas {
+		if blobVersionParams != nil {
+			if _, ok := blobVersionParams.Get(metadata.BlobHeader.BlobVersion); ok {
+				continue
+			}
+		}
+		if err := d.refreshBlobVersionParams(ctx); err != nil {
+			return nil, fmt.Errorf("failed to refresh blob version parameters: %w", err)
+		}
+		return d.blobVersionParameters.Load(), nil
+	}
+	return blobVersionParams, nil
+}
+
+func (d *Dispatcher) refreshBlobVersionParams(ctx context.Context) error {
+	blobParams, err := d.chainReader.GetAllVersionedBlobParams(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get blob version parameters: %w", err)
+	}
+
+	d.blobVersionParameters.Store(corev2.NewBlobVersionParameterMap(blobParams))
+	return nil
+}
+
 // GetOperatorState returns the operator state for the given quorums at the given block number
 func (d *Dispatcher) GetOperatorState(
 	ctx context.Context,
@@ -833,7 +957,7 @@ func (d *Dispatcher) updateBatchStatus(
 		}
 		if metadata, ok := batch.Metadata[blobKey]; ok {
 			requestedAt := time.Unix(0, int64(metadata.RequestedAt))
-			d.metrics.reportE2EDispersalLatency(time.Since(requestedAt))
+			d.metrics.reportE2EDispersalLatency(metadata.Priority, time.Since(requestedAt))
 			d.metrics.reportCompletedBlob(int(metadata.BlobSize), v2.Complete)
 		}
 	}
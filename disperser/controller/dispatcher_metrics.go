@@ -16,24 +16,29 @@ const dispatcherNamespace = "eigenda_dispatcher"
 
 // dispatcherMetrics is a struct that holds the metrics for the dispatcher.
 type dispatcherMetrics struct {
-	sendChunksRetryCount         *prometheus.GaugeVec
-	processSigningMessageLatency *prometheus.SummaryVec
-	signingMessageChannelLatency *prometheus.SummaryVec
-	attestationUpdateLatency     *prometheus.SummaryVec
-	attestationBuildingLatency   *prometheus.SummaryVec
-	thresholdSignedToDoneLatency *prometheus.SummaryVec
-	aggregateSignaturesLatency   *prometheus.SummaryVec
-	putAttestationLatency        *prometheus.SummaryVec
-	attestationUpdateCount       *prometheus.SummaryVec
-	updateBatchStatusLatency     *prometheus.SummaryVec
-	blobE2EDispersalLatency      *prometheus.SummaryVec
-	completedBlobs               *prometheus.CounterVec
-	attestation                  *prometheus.GaugeVec
-	blobSetSize                  *prometheus.GaugeVec
-	batchStageTimer              *common.StageTimer
-	sendToValidatorStageTimer    *common.StageTimer
-	importantSigningThresholds   []float64
-	signatureThresholds          *prometheus.CounterVec
+	sendChunksRetryCount              *prometheus.GaugeVec
+	processSigningMessageLatency      *prometheus.SummaryVec
+	signingMessageChannelLatency      *prometheus.SummaryVec
+	attestationUpdateLatency          *prometheus.SummaryVec
+	attestationBuildingLatency        *prometheus.SummaryVec
+	thresholdSignedToDoneLatency      *prometheus.SummaryVec
+	aggregateSignaturesLatency        *prometheus.SummaryVec
+	putAttestationLatency             *prometheus.SummaryVec
+	attestationUpdateCount            *prometheus.SummaryVec
+	updateBatchStatusLatency          *prometheus.SummaryVec
+	blobE2EDispersalLatency           *prometheus.SummaryVec
+	blobE2EDispersalLatencyByPriority *prometheus.SummaryVec
+	encodedToBatchLatency             *prometheus.SummaryVec
+	completedBlobs                    *prometheus.CounterVec
+	attestation                       *prometheus.GaugeVec
+	blobSetSize                       *prometheus.GaugeVec
+	queueDepth                        *prometheus.GaugeVec
+	batchValidatorBytes               *prometheus.GaugeVec
+	iterations                        *prometheus.CounterVec
+	batchStageTimer                   *common.StageTimer
+	sendToValidatorStageTimer         *common.StageTimer
+	importantSigningThresholds        []float64
+	signatureThresholds               *prometheus.CounterVec
 }
 
 // NewDispatcherMetrics sets up metrics for the dispatcher.
@@ -166,6 +171,26 @@ func newDispatcherMetrics(
 		[]string{},
 	)
 
+	blobE2EDispersalLatencyByPriority := promauto.With(registry).NewSummaryVec(
+		prometheus.SummaryOpts{
+			Namespace:  dispatcherNamespace,
+			Name:       "e2e_dispersal_latency_by_priority_ms",
+			Help:       "The time required to disperse a blob end-to-end, by priority class.",
+			Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+		},
+		[]string{"priority"},
+	)
+
+	encodedToBatchLatency := promauto.With(registry).NewSummaryVec(
+		prometheus.SummaryOpts{
+			Namespace:  dispatcherNamespace,
+			Name:       "encoded_to_batch_latency_ms",
+			Help:       "The time between a blob being encoded and being added to a batch.",
+			Objectives: objectives,
+		},
+		[]string{},
+	)
+
 	completedBlobs := promauto.With(registry).NewCounterVec(
 		prometheus.CounterOpts{
 			Namespace: dispatcherNamespace,
@@ -184,6 +209,33 @@ func newDispatcherMetrics(
 		[]string{},
 	)
 
+	queueDepth := promauto.With(registry).NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: dispatcherNamespace,
+			Name:      "queue_depth",
+			Help:      "The number of encoded blobs in the scheduling window, by priority class.",
+		},
+		[]string{"priority"},
+	)
+
+	batchValidatorBytes := promauto.With(registry).NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: dispatcherNamespace,
+			Name:      "batch_validator_chunk_bytes",
+			Help:      "The number of encoded chunk bytes sent to a validator in the last batch (max and mean over validators).",
+		},
+		[]string{"stat"},
+	)
+
+	iterations := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: dispatcherNamespace,
+			Name:      "iterations_total",
+			Help:      "The number of iterations of the dispatch loop, by what triggered them (notification or poll).",
+		},
+		[]string{"trigger"},
+	)
+
 	batchStageTimer := common.NewStageTimer(registry, dispatcherNamespace, "batch", false)
 	sendToValidatorStageTimer := common.NewStageTimer(
 		registry,
@@ -217,24 +269,29 @@ func newDispatcherMetrics(
 	)
 
 	return &dispatcherMetrics{
-		sendChunksRetryCount:         sendChunksRetryCount,
-		processSigningMessageLatency: processSigningMessageLatency,
-		signingMessageChannelLatency: signingMessageChannelLatency,
-		attestationUpdateLatency:     attestationUpdateLatency,
-		attestationBuildingLatency:   attestationBuildingLatency,
-		thresholdSignedToDoneLatency: thresholdSignedToDoneLatency,
-		aggregateSignaturesLatency:   aggregateSignaturesLatency,
-		putAttestationLatency:        putAttestationLatency,
-		attestationUpdateCount:       attestationUpdateCount,
-		updateBatchStatusLatency:     updateBatchStatusLatency,
-		blobE2EDispersalLatency:      blobE2EDispersalLatency,
-		completedBlobs:               completedBlobs,
-		attestation:                  attestation,
-		blobSetSize:                  blobSetSize,
-		batchStageTimer:              batchStageTimer,
-		sendToValidatorStageTimer:    sendToValidatorStageTimer,
-		importantSigningThresholds:   importantSigningThresholds,
-		signatureThresholds:          batchSigningThresholdCount,
+		sendChunksRetryCount:              sendChunksRetryCount,
+		processSigningMessageLatency:      processSigningMessageLatency,
+		signingMessageChannelLatency:      signingMessageChannelLatency,
+		attestationUpdateLatency:          attestationUpdateLatency,
+		attestationBuildingLatency:        attestationBuildingLatency,
+		thresholdSignedToDoneLatency:      thresholdSignedToDoneLatency,
+		aggregateSignaturesLatency:        aggregateSignaturesLatency,
+		putAttestationLatency:             putAttestationLatency,
+		attestationUpdateCount:            attestationUpdateCount,
+		updateBatchStatusLatency:          updateBatchStatusLatency,
+		blobE2EDispersalLatency:           blobE2EDispersalLatency,
+		blobE2EDispersalLatencyByPriority: blobE2EDispersalLatencyByPriority,
+		encodedToBatchLatency:             encodedToBatchLatency,
+		completedBlobs:                    completedBlobs,
+		attestation:                       attestation,
+		blobSetSize:                       blobSetSize,
+		queueDepth:                        queueDepth,
+		batchValidatorBytes:               batchValidatorBytes,
+		iterations:                        iterations,
+		batchStageTimer:                   batchStageTimer,
+		sendToValidatorStageTimer:         sendToValidatorStageTimer,
+		importantSigningThresholds:        importantSigningThresholds,
+		signatureThresholds:               batchSigningThresholdCount,
 	}, nil
 }
 
@@ -279,8 +336,13 @@ func (m *dispatcherMetrics) reportUpdateBatchStatusLatency(duration time.Duratio
 	m.updateBatchStatusLatency.WithLabelValues().Observe(common.ToMilliseconds(duration))
 }
 
-func (m *dispatcherMetrics) reportE2EDispersalLatency(duration time.Duration) {
+func (m *dispatcherMetrics) reportE2EDispersalLatency(priority dispv2.PriorityClass, duration time.Duration) {
 	m.blobE2EDispersalLatency.WithLabelValues().Observe(common.ToMilliseconds(duration))
+	m.blobE2EDispersalLatencyByPriority.WithLabelValues(priority.String()).Observe(common.ToMilliseconds(duration))
+}
+
+func (m *dispatcherMetrics) reportEncodedToBatchLatency(duration time.Duration) {
+	m.encodedToBatchLatency.WithLabelValues().Observe(common.ToMilliseconds(duration))
 }
 
 func (m *dispatcherMetrics) reportCompletedBlob(size int, status dispv2.BlobStatus) {
@@ -303,6 +365,34 @@ func (m *dispatcherMetrics) reportBlobSetSize(size int) {
 	m.blobSetSize.WithLabelValues().Set(float64(size))
 }
 
+func (m *dispatcherMetrics) reportQueueDepth(blobs []*dispv2.BlobMetadata) {
+	depths := make(map[dispv2.PriorityClass]int, len(dispv2.PriorityClasses))
+	for _, blob := range blobs {
+		depths[blob.Priority]++
+	}
+	for _, priority := range dispv2.PriorityClasses {
+		m.queueDepth.WithLabelValues(priority.String()).Set(float64(depths[priority]))
+	}
+}
+
+func (m *dispatcherMetrics) reportIteration(trigger string) {
+	m.iterations.WithLabelValues(trigger).Inc()
+}
+
+func (m *dispatcherMetrics) reportBatchValidatorBytes(validatorBytes map[core.OperatorID]uint64) {
+	if len(validatorBytes) == 0 {
+		return
+	}
+	maxBytes := uint64(0)
+	totalBytes := uint64(0)
+	for _, bytes := range validatorBytes {
+		maxBytes = max(maxBytes, bytes)
+		totalBytes += bytes
+	}
+	m.batchValidatorBytes.WithLabelValues("max").Set(float64(maxBytes))
+	m.batchValidatorBytes.WithLabelValues("mean").Set(float64(totalBytes) / float64(len(validatorBytes)))
+}
+
 func (m *dispatcherMetrics) reportAttestation(
 	operatorCount map[core.QuorumID]int,
 	signerCount map[core.QuorumID]int,
@@ -18,6 +18,7 @@ import (
 	commonv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
 	v2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
 	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/notifier"
 	"github.com/Layr-Labs/eigenda/disperser/controller"
 	"github.com/Layr-Labs/eigenda/encoding"
 	gethcommon "github.com/ethereum/go-ethereum/common"
@@ -696,7 +697,7 @@ func newDispatcherComponents(t *testing.T) *dispatcherComponents {
 		SignatureTickInterval:   1 * time.Second,
 		NumRequestRetries:       3,
 		MaxBatchSize:            maxBatchSize,
-	}, blobMetadataStore, pool, mockChainState, agg, nodeClientManager, logger, prometheus.NewRegistry(), beforeDispatch, blobSet, livenessChan)
+	}, blobMetadataStore, pool, mockChainState, chainReader, agg, nodeClientManager, logger, prometheus.NewRegistry(), beforeDispatch, blobSet, notifier.NewInProcessNotifier(), livenessChan)
 	require.NoError(t, err)
 	return &dispatcherComponents{
 		Dispatcher:        d,
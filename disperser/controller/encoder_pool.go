@@ -0,0 +1,223 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// pooledEncoder is an encoder in the encoderPool, along with the state the pool tracks for it.
+type pooledEncoder struct {
+	address string
+	client  disperser.EncoderClientV2
+
+	// outstandingBytes is the total size of the blobs currently being encoded by the encoder
+	outstandingBytes uint64
+	// backoffUntil is the time until which the encoder is considered unhealthy. Unhealthy encoders are only used
+	// when no healthy encoder is available.
+	backoffUntil time.Time
+}
+
+func (p *pooledEncoder) healthy(now time.Time) bool {
+	return !now.Before(p.backoffUntil)
+}
+
+// encoderPool distributes encoding requests across a set of encoders. Each request is sent to the healthy encoder
+// with the least outstanding bytes. If an encoder rejects a request because its backlog is full or because it is
+// unreachable, the encoder is backed off and the request fails over to the next encoder.
+type encoderPool struct {
+	encoders []*pooledEncoder
+	// backoffDuration is how long an encoder is considered unhealthy after rejecting a request
+	backoffDuration time.Duration
+
+	mu      sync.Mutex
+	metrics *encodingManagerMetrics
+	logger  logging.Logger
+}
+
+var _ disperser.EncoderClientV2 = (*encoderPool)(nil)
+
+func newEncoderPool(
+	clients map[string]disperser.EncoderClientV2,
+	backoffDuration time.Duration,
+	metrics *encodingManagerMetrics,
+	logger logging.Logger,
+) (*encoderPool, error) {
+	if len(clients) == 0 {
+		return nil, errors.New("no encoders provided")
+	}
+
+	encoders := make([]*pooledEncoder, 0, len(clients))
+	for address, client := range clients {
+		encoders = append(encoders, &pooledEncoder{
+			address: address,
+			client:  client,
+		})
+	}
+	// Keep the order of the encoders deterministic so that ties are broken consistently
+	sort.Slice(encoders, func(i, j int) bool {
+		return encoders[i].address < encoders[j].address
+	})
+
+	for _, encoder := range encoders {
+		metrics.reportEncoderOutstandingBytes(encoder.address, 0)
+		metrics.reportEncoderHealthy(encoder.address, true)
+	}
+
+	return &encoderPool{
+		encoders:        encoders,
+		backoffDuration: backoffDuration,
+		metrics:         metrics,
+		logger:          logger,
+	}, nil
+}
+
+// EncodeBlob sends the encoding request to the encoders of the pool, trying each encoder at most once, until one of
+// them accepts it. Errors other than backlog or availability errors are returned without trying other encoders.
+func (p *encoderPool) EncodeBlob(
+	ctx context.Context,
+	blobKey corev2.BlobKey,
+	encodingParams encoding.EncodingParams,
+	blobSize uint64,
+) (*encoding.FragmentInfo, error) {
+	var fragmentInfo *encoding.FragmentInfo
+	err := p.send(ctx, blobSize, func(client disperser.EncoderClientV2) error {
+		var err error
+		fragmentInfo, err = client.EncodeBlob(ctx, blobKey, encodingParams, blobSize)
+		return err
+	}, fmt.Sprintf("encode blob %s", blobKey.Hex()))
+	if err != nil {
+		return nil, err
+	}
+	return fragmentInfo, nil
+}
+
+// EncodeBlobs sends a request to encode several blobs to the encoders of the pool, in the same way as EncodeBlob. The
+// whole request is sent to a single encoder.
+func (p *encoderPool) EncodeBlobs(
+	ctx context.Context,
+	blobs []*disperser.BlobToEncode,
+) ([]*encoding.FragmentInfo, error) {
+	batchSize := uint64(0)
+	for _, blob := range blobs {
+		batchSize += blob.BlobSize
+	}
+
+	var fragmentInfos []*encoding.FragmentInfo
+	err := p.send(ctx, batchSize, func(client disperser.EncoderClientV2) error {
+		var err error
+		fragmentInfos, err = client.EncodeBlobs(ctx, blobs)
+		return err
+	}, fmt.Sprintf("encode %d blobs", len(blobs)))
+	if err != nil {
+		return nil, err
+	}
+	return fragmentInfos, nil
+}
+
+// send sends a request of size bytes to the encoders of the pool as described by EncodeBlob. The description names
+// the request in logs and errors.
+func (p *encoderPool) send(
+	ctx context.Context,
+	size uint64,
+	request func(client disperser.EncoderClientV2) error,
+	description string,
+) error {
+	tried := make(map[*pooledEncoder]struct{}, len(p.encoders))
+	var lastErr error
+	for len(tried) < len(p.encoders) {
+		encoder := p.acquire(tried, size)
+		tried[encoder] = struct{}{}
+
+		err := request(encoder.client)
+		p.release(encoder, size, err)
+		if err == nil {
+			return nil
+		}
+		if !isRetryableEncoderError(err) {
+			return err
+		}
+
+		p.logger.Warn("encoder is unable to accept request, failing over",
+			"encoder", encoder.address, "request", description, "err", err)
+		lastErr = err
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	return fmt.Errorf("all encoders failed to %s: %w", description, lastErr)
+}
+
+// acquire selects the encoder that a request of blobSize bytes is sent to, skipping the encoders already tried, and
+// accounts the request against it.
+func (p *encoderPool) acquire(tried map[*pooledEncoder]struct{}, blobSize uint64) *pooledEncoder {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	var selected *pooledEncoder
+	for _, encoder := range p.encoders {
+		if _, ok := tried[encoder]; ok {
+			continue
+		}
+		if selected == nil {
+			selected = encoder
+			continue
+		}
+		// Prefer healthy encoders, then the ones with the least outstanding bytes
+		healthy, selectedHealthy := encoder.healthy(now), selected.healthy(now)
+		if healthy != selectedHealthy {
+			if healthy {
+				selected = encoder
+			}
+			continue
+		}
+		if encoder.outstandingBytes < selected.outstandingBytes {
+			selected = encoder
+		}
+	}
+
+	selected.outstandingBytes += blobSize
+	p.metrics.reportEncoderOutstandingBytes(selected.address, selected.outstandingBytes)
+	return selected
+}
+
+// release accounts the completion of a request of blobSize bytes by the encoder, and updates the health of the
+// encoder from the result of the request.
+func (p *encoderPool) release(encoder *pooledEncoder, blobSize uint64, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	encoder.outstandingBytes -= blobSize
+	p.metrics.reportEncoderOutstandingBytes(encoder.address, encoder.outstandingBytes)
+
+	switch {
+	case err == nil:
+		encoder.backoffUntil = time.Time{}
+		p.metrics.reportEncoderRequest(encoder.address, "success")
+	case isRetryableEncoderError(err):
+		encoder.backoffUntil = time.Now().Add(p.backoffDuration)
+		p.metrics.reportEncoderRequest(encoder.address, status.Code(err).String())
+	default:
+		p.metrics.reportEncoderRequest(encoder.address, "failure")
+	}
+	p.metrics.reportEncoderHealthy(encoder.address, encoder.backoffUntil.IsZero())
+}
+
+// isRetryableEncoderError returns true if the error indicates that the encoder cannot take the request at this time,
+// i.e. its backlog is full or it is unreachable, so the request may be sent to another encoder.
+func isRetryableEncoderError(err error) bool {
+	code := status.Code(err)
+	return code == codes.ResourceExhausted || code == codes.Unavailable
+}
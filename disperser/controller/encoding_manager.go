@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 	dispcommon "github.com/Layr-Labs/eigenda/disperser/common"
 	v2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
 	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/notifier"
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/prometheus/client_golang/prometheus"
@@ -26,6 +28,8 @@ import (
 var errNoBlobsToEncode = errors.New("no blobs to encode")
 
 type EncodingManagerConfig struct {
+	// PullInterval is the interval at which the metadata store is polled for queued blobs. Blobs are usually
+	// encoded as soon as they are notified, so polling only picks up the blobs whose notification was missed.
 	PullInterval time.Duration
 
 	EncodingRequestTimeout time.Duration
@@ -34,31 +38,46 @@ type EncodingManagerConfig struct {
 	NumEncodingRetries int
 	// NumRelayAssignment defines how many relays will be assigned to a blob
 	NumRelayAssignment uint16
-	// AvailableRelays is a list of available relays
+	// AvailableRelays is a static list of relays that blobs are assigned to at random. It is only used when the
+	// encoding manager has no RelaySelector.
 	AvailableRelays []corev2.RelayKey
-	// EncoderAddress is the address of the encoder
-	EncoderAddress string
+	// EncoderAddresses are the addresses of the encoders
+	EncoderAddresses []string
+	// EncoderBackoffDuration is how long an encoder is avoided after it rejects a request because its backlog is
+	// full or it is unavailable
+	EncoderBackoffDuration time.Duration
 	// MaxNumBlobsPerIteration is the maximum number of blobs to encode per iteration
 	MaxNumBlobsPerIteration int32
+	// MaxBlobsPerEncodingRequest is the maximum number of blobs with the same encoding parameters that are sent to an
+	// encoder in a single EncodeBlobs request. If it is 1 or less, each blob is sent in its own EncodeBlob request.
+	MaxBlobsPerEncodingRequest int
 	// OnchainStateRefreshInterval is the interval at which the onchain state is refreshed
 	OnchainStateRefreshInterval time.Duration
+	// PriorityWeights are the shares of the encoding capacity given to each priority class. If nil,
+	// DefaultPriorityWeights is used.
+	PriorityWeights PriorityWeights
 }
 
 // EncodingManager is responsible for pulling queued blobs from the blob
-// metadata store periodically and encoding them. It receives the encoder responses
-// and creates BlobCertificates.
+// metadata store and encoding them, whenever blobs are notified as queued and periodically.
+// It receives the encoder responses and creates BlobCertificates.
 type EncodingManager struct {
 	*EncodingManagerConfig
 
 	// components
 	blobMetadataStore blobstore.MetadataStore
 	pool              common.WorkerPool
-	encodingClient    disperser.EncoderClientV2
+	encodingClient    *encoderPool
 	chainReader       core.Reader
-	logger            logging.Logger
+	// blobNotifier notifies the encoding manager of queued blobs, and is signaled when blobs are encoded
+	blobNotifier notifier.Notifier
+	// relaySelector assigns blobs to relays. If nil, blobs are assigned to AvailableRelays at random.
+	relaySelector *RelaySelector
+	logger        logging.Logger
 
 	// state
 	cursor                *blobstore.StatusIndexCursor
+	fairQueue             *FairQueue
 	blobVersionParameters atomic.Pointer[corev2.BlobVersionParameterMap]
 	// blobSet keeps track of blobs that are currently being encoded
 	// This is used to deduplicate blobs to prevent the same blob from being encoded multiple times
@@ -73,30 +92,52 @@ func NewEncodingManager(
 	config *EncodingManagerConfig,
 	blobMetadataStore blobstore.MetadataStore,
 	pool common.WorkerPool,
-	encodingClient disperser.EncoderClientV2,
+	encodingClients map[string]disperser.EncoderClientV2,
 	chainReader core.Reader,
 	logger logging.Logger,
 	registry *prometheus.Registry,
 	blobSet BlobSet,
+	blobNotifier notifier.Notifier,
+	relaySelector *RelaySelector,
 	controllerLivenessChan chan<- healthcheck.HeartbeatMessage,
 ) (*EncodingManager, error) {
 	if config.NumRelayAssignment < 1 ||
-		len(config.AvailableRelays) == 0 ||
+		(relaySelector == nil && len(config.AvailableRelays) == 0) ||
 		config.MaxNumBlobsPerIteration < 1 {
 		return nil, fmt.Errorf("invalid encoding manager config")
 	}
-	if int(config.NumRelayAssignment) > len(config.AvailableRelays) {
+	if relaySelector == nil && int(config.NumRelayAssignment) > len(config.AvailableRelays) {
 		return nil, fmt.Errorf("NumRelayAssignment (%d) cannot be greater than NumRelays (%d)", config.NumRelayAssignment, len(config.AvailableRelays))
 	}
+	if blobNotifier == nil {
+		return nil, errors.New("blob notifier is required")
+	}
+	priorityWeights := config.PriorityWeights
+	if priorityWeights == nil {
+		priorityWeights = DefaultPriorityWeights
+	}
+	fairQueue, err := NewFairQueue(priorityWeights)
+	if err != nil {
+		return nil, fmt.Errorf("invalid priority weights: %w", err)
+	}
+	logger = logger.With("component", "EncodingManager")
+	metrics := newEncodingManagerMetrics(registry)
+	encodingClient, err := newEncoderPool(encodingClients, config.EncoderBackoffDuration, metrics, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create encoder pool: %w", err)
+	}
 	return &EncodingManager{
 		EncodingManagerConfig:  config,
 		blobMetadataStore:      blobMetadataStore,
 		pool:                   pool,
 		encodingClient:         encodingClient,
 		chainReader:            chainReader,
-		logger:                 logger.With("component", "EncodingManager"),
+		blobNotifier:           blobNotifier,
+		relaySelector:          relaySelector,
+		logger:                 logger,
 		cursor:                 nil,
-		metrics:                newEncodingManagerMetrics(registry),
+		fairQueue:              fairQueue,
+		metrics:                metrics,
 		blobSet:                blobSet,
 		controllerLivenessChan: controllerLivenessChan,
 	}, nil
@@ -109,6 +150,13 @@ func (e *EncodingManager) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to refresh blob version parameters: %w", err)
 	}
 
+	if e.relaySelector != nil {
+		err = e.relaySelector.Start(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to start relay selector: %w", err)
+		}
+	}
+
 	go func() {
 		ticker := time.NewTicker(e.EncodingManagerConfig.OnchainStateRefreshInterval)
 		defer ticker.Stop()
@@ -127,6 +175,7 @@ func (e *EncodingManager) Start(ctx context.Context) error {
 	}()
 
 	// Start the encoding loop
+	queued := e.blobNotifier.Subscribe(v2.Queued)
 	go func() {
 		ticker := time.NewTicker(e.PullInterval)
 		defer ticker.Stop()
@@ -134,14 +183,18 @@ func (e *EncodingManager) Start(ctx context.Context) error {
 			select {
 			case <-ctx.Done():
 				return
+			case <-queued:
+				e.metrics.reportIteration("notification")
 			case <-ticker.C:
-				err := e.HandleBatch(ctx)
-				if err != nil {
-					if errors.Is(err, errNoBlobsToEncode) {
-						e.logger.Debug("no blobs to encode")
-					} else {
-						e.logger.Error("failed to process a batch", "err", err)
-					}
+				e.metrics.reportIteration("poll")
+			}
+
+			err := e.HandleBatch(ctx)
+			if err != nil {
+				if errors.Is(err, errNoBlobsToEncode) {
+					e.logger.Debug("no blobs to encode")
+				} else {
+					e.logger.Error("failed to process a batch", "err", err)
 				}
 			}
 		}
@@ -174,18 +227,30 @@ func (e *EncodingManager) HandleBatch(ctx context.Context) error {
 	// Signal Liveness to indicate no stall
 	healthcheck.SignalHeartbeat("encodingManager", e.controllerLivenessChan, e.logger)
 
-	// Get a batch of blobs to encode
-	blobMetadatas, cursor, err := e.blobMetadataStore.GetBlobMetadataByStatusPaginated(ctx, v2.Queued, e.cursor, e.MaxNumBlobsPerIteration)
+	// Get the queued blobs to choose a batch of blobs to encode from
+	queuedBlobs, cursor, err := e.blobMetadataStore.GetBlobMetadataByStatusPaginated(
+		ctx,
+		v2.Queued,
+		e.cursor,
+		e.MaxNumBlobsPerIteration*schedulingWindowFactor,
+	)
 	if err != nil {
 		return err
 	}
 
-	blobMetadatas = e.dedupBlobs(blobMetadatas)
+	queuedBlobs = e.dedupBlobs(queuedBlobs)
 	e.metrics.reportBlobSetSize(e.blobSet.Size())
-	if len(blobMetadatas) == 0 {
+	e.metrics.reportQueueDepth(queuedBlobs)
+	if len(queuedBlobs) == 0 {
 		return errNoBlobsToEncode
 	}
 
+	blobMetadatas := e.fairQueue.Schedule(queuedBlobs, int(e.MaxNumBlobsPerIteration))
+	cursor, err = resumeCursor(queuedBlobs, blobMetadatas, e.cursor, cursor)
+	if err != nil {
+		return err
+	}
+
 	blobVersionParams := e.blobVersionParameters.Load()
 	if blobVersionParams == nil {
 		return fmt.Errorf("blob version parameters is nil")
@@ -200,9 +265,13 @@ func (e *EncodingManager) HandleBatch(ctx context.Context) error {
 
 	submissionStart := time.Now()
 
-	e.logger.Debug("request encoding", "numBlobs", len(blobMetadatas))
+	// The dispatcher is notified once all the blobs of the batch are handled, rather than once per blob, so that it
+	// wakes up once for the whole batch
+	var handled sync.WaitGroup
+	var anyEncoded atomic.Bool
+
+	blobs := make([]*blobToEncode, 0, len(blobMetadatas))
 	for _, blob := range blobMetadatas {
-		blob := blob
 		blobKey, err := blob.BlobHeader.BlobKey()
 		if err != nil {
 			e.logger.Error("failed to get blob key", "err", err, "requestedAt", blob.RequestedAt, "paymentMetadata", blob.BlobHeader.PaymentMetadata)
@@ -215,92 +284,61 @@ func (e *EncodingManager) HandleBatch(ctx context.Context) error {
 			continue
 		}
 
+		blobs = append(blobs, &blobToEncode{
+			blob:       blob,
+			blobKey:    blobKey,
+			blobParams: blobParams,
+		})
+	}
+
+	e.logger.Debug("request encoding", "numBlobs", len(blobs))
+	for _, group := range e.groupBlobs(blobs) {
+		group := group
+
 		// Encode the blobs
+		handled.Add(1)
 		e.pool.Submit(func() {
+			defer handled.Done()
 			start := time.Now()
 
-			var i int
-			var finishedEncodingTime time.Time
-			var finishedPutBlobCertificateTime time.Time
-			var finishedUpdateBlobStatusTime time.Time
-			var success bool
-
-			for i = 0; i < e.NumEncodingRetries+1; i++ {
+			// The blobs of a group are encoded in a single request. If the request fails, each blob is retried on
+			// its own.
+			fragmentInfos := make([]*encoding.FragmentInfo, len(group.blobs))
+			if len(group.blobs) > 1 {
 				encodingCtx, cancel := context.WithTimeout(ctx, e.EncodingRequestTimeout)
-				fragmentInfo, err := e.encodeBlob(encodingCtx, blobKey, blob, blobParams)
+				batchFragmentInfos, err := e.encodeBlobs(encodingCtx, group)
 				cancel()
 				if err != nil {
-					e.logger.Error("failed to encode blob", "blobKey", blobKey.Hex(), "err", err)
-					continue
-				}
-
-				finishedEncodingTime = time.Now()
-
-				relayKeys, err := GetRelayKeys(e.NumRelayAssignment, e.AvailableRelays)
-				if err != nil {
-					e.logger.Error("failed to get relay keys", "err", err)
-					// Stop retrying
-					break
-				}
-				cert := &corev2.BlobCertificate{
-					BlobHeader: blob.BlobHeader,
-					Signature:  blob.Signature,
-					RelayKeys:  relayKeys,
-				}
-
-				storeCtx, cancel := context.WithTimeout(ctx, e.StoreTimeout)
-				err = e.blobMetadataStore.PutBlobCertificate(storeCtx, cert, fragmentInfo)
-				cancel()
-				if err != nil && !errors.Is(err, dispcommon.ErrAlreadyExists) {
-					e.logger.Error("failed to put blob certificate", "err", err)
-					continue
+					e.logger.Error("failed to encode blobs, encoding them one by one",
+						"numBlobs", len(group.blobs), "err", err)
+				} else {
+					fragmentInfos = batchFragmentInfos
 				}
-
-				finishedPutBlobCertificateTime = time.Now()
-
-				storeCtx, cancel = context.WithTimeout(ctx, e.StoreTimeout)
-				err = e.blobMetadataStore.UpdateBlobStatus(storeCtx, blobKey, v2.Encoded)
-				finishedUpdateBlobStatusTime = time.Now()
-				cancel()
-				if err == nil || errors.Is(err, dispcommon.ErrAlreadyExists) {
-					// Successfully updated the status to Encoded
-					success = true
-					break
-				}
-
-				e.logger.Error("failed to update blob status to Encoded", "blobKey", blobKey.Hex(), "err", err)
-				sleepTime := time.Duration(math.Pow(2, float64(i))) * time.Second
-				time.Sleep(sleepTime) // Wait before retrying
 			}
 
-			e.metrics.reportBatchRetryCount(i)
-
-			if success {
-				e.metrics.reportEncodingLatency(finishedEncodingTime.Sub(start))
-				e.metrics.reportPutBlobCertLatency(finishedPutBlobCertificateTime.Sub(finishedEncodingTime))
-				e.metrics.reportUpdateBlobStatusLatency(
-					finishedUpdateBlobStatusTime.Sub(finishedPutBlobCertificateTime))
-				e.metrics.reportBlobHandleLatency(time.Since(start))
-
-				requestedAt := time.Unix(0, int64(blob.RequestedAt))
-				e.metrics.reportE2EEncodingLatency(time.Since(requestedAt))
-				e.metrics.reportCompletedBlob(int(blob.BlobSize), v2.Encoded)
-			} else {
-				e.metrics.reportFailedSubmission()
-				storeCtx, cancel := context.WithTimeout(ctx, e.StoreTimeout)
-				err = e.blobMetadataStore.UpdateBlobStatus(storeCtx, blobKey, v2.Failed)
-				cancel()
-				if err != nil {
-					e.logger.Error("failed to update blob status to Failed", "blobKey", blobKey.Hex(), "err", err)
-					return
-				}
-				e.metrics.reportCompletedBlob(int(blob.BlobSize), v2.Failed)
+			var wg sync.WaitGroup
+			for i, blob := range group.blobs {
+				wg.Add(1)
+				go func(blob *blobToEncode, fragmentInfo *encoding.FragmentInfo) {
+					defer wg.Done()
+					if e.handleBlob(ctx, blob, fragmentInfo, start) {
+						anyEncoded.Store(true)
+					}
+				}(blob, fragmentInfos[i])
 			}
+			wg.Wait()
 		})
 	}
 
 	e.metrics.reportBatchSubmissionLatency(time.Since(submissionStart))
 
+	go func() {
+		handled.Wait()
+		if anyEncoded.Load() {
+			e.blobNotifier.Notify(v2.Encoded)
+		}
+	}()
+
 	e.cursor = cursor
 
 	for _, blob := range blobMetadatas {
@@ -316,6 +354,141 @@ func (e *EncodingManager) HandleBatch(ctx context.Context) error {
 	return nil
 }
 
+// blobToEncode is a blob of a batch that is sent to the encoders.
+type blobToEncode struct {
+	blob       *v2.BlobMetadata
+	blobKey    corev2.BlobKey
+	blobParams *core.BlobVersionParameters
+}
+
+// encodingGroup is a group of blobs with the same encoding parameters, which are encoded in a single request.
+type encodingGroup struct {
+	encodingParams encoding.EncodingParams
+	blobs          []*blobToEncode
+}
+
+// groupBlobs groups the blobs by encoding parameters, with at most MaxBlobsPerEncodingRequest blobs per group. A blob
+// whose encoding parameters can't be computed is put in a group of its own, and fails when it is encoded.
+func (e *EncodingManager) groupBlobs(blobs []*blobToEncode) []*encodingGroup {
+	groups := make([]*encodingGroup, 0, len(blobs))
+	// openGroups are the groups that still have room for more blobs, by encoding parameters
+	openGroups := make(map[encoding.EncodingParams]*encodingGroup)
+	for _, blob := range blobs {
+		encodingParams, err := corev2.GetEncodingParams(blob.blob.BlobHeader.BlobCommitments.Length, blob.blobParams)
+		if err != nil || e.MaxBlobsPerEncodingRequest <= 1 {
+			groups = append(groups, &encodingGroup{encodingParams: encodingParams, blobs: []*blobToEncode{blob}})
+			continue
+		}
+
+		group, ok := openGroups[encodingParams]
+		if !ok {
+			group = &encodingGroup{encodingParams: encodingParams}
+			groups = append(groups, group)
+			openGroups[encodingParams] = group
+		}
+		group.blobs = append(group.blobs, blob)
+		if len(group.blobs) >= e.MaxBlobsPerEncodingRequest {
+			delete(openGroups, encodingParams)
+		}
+	}
+	return groups
+}
+
+// handleBlob encodes a blob, unless it was already encoded with the given fragment info, and stores its certificate.
+// The blob is retried up to NumEncodingRetries times, and is marked as failed if it can't be handled. Returns true if
+// the blob is encoded.
+func (e *EncodingManager) handleBlob(
+	ctx context.Context,
+	blob *blobToEncode,
+	fragmentInfo *encoding.FragmentInfo,
+	start time.Time,
+) bool {
+	blobKey := blob.blobKey
+
+	var i int
+	var finishedEncodingTime time.Time
+	var finishedPutBlobCertificateTime time.Time
+	var finishedUpdateBlobStatusTime time.Time
+	var success bool
+
+	for i = 0; i < e.NumEncodingRetries+1; i++ {
+		if fragmentInfo == nil {
+			var err error
+			encodingCtx, cancel := context.WithTimeout(ctx, e.EncodingRequestTimeout)
+			fragmentInfo, err = e.encodeBlob(encodingCtx, blobKey, blob.blob, blob.blobParams)
+			cancel()
+			if err != nil {
+				e.logger.Error("failed to encode blob", "blobKey", blobKey.Hex(), "err", err)
+				continue
+			}
+		}
+
+		finishedEncodingTime = time.Now()
+
+		relayKeys, err := e.getRelayKeys(blob.blob)
+		if err != nil {
+			e.logger.Error("failed to get relay keys", "err", err)
+			// Stop retrying
+			break
+		}
+		cert := &corev2.BlobCertificate{
+			BlobHeader: blob.blob.BlobHeader,
+			Signature:  blob.blob.Signature,
+			RelayKeys:  relayKeys,
+		}
+
+		storeCtx, cancel := context.WithTimeout(ctx, e.StoreTimeout)
+		err = e.blobMetadataStore.PutBlobCertificate(storeCtx, cert, fragmentInfo)
+		cancel()
+		if err != nil && !errors.Is(err, dispcommon.ErrAlreadyExists) {
+			e.logger.Error("failed to put blob certificate", "err", err)
+			continue
+		}
+
+		finishedPutBlobCertificateTime = time.Now()
+
+		storeCtx, cancel = context.WithTimeout(ctx, e.StoreTimeout)
+		err = e.blobMetadataStore.UpdateBlobStatus(storeCtx, blobKey, v2.Encoded)
+		finishedUpdateBlobStatusTime = time.Now()
+		cancel()
+		if err == nil || errors.Is(err, dispcommon.ErrAlreadyExists) {
+			// Successfully updated the status to Encoded
+			success = true
+			break
+		}
+
+		e.logger.Error("failed to update blob status to Encoded", "blobKey", blobKey.Hex(), "err", err)
+		sleepTime := time.Duration(math.Pow(2, float64(i))) * time.Second
+		time.Sleep(sleepTime) // Wait before retrying
+	}
+
+	e.metrics.reportBatchRetryCount(i)
+
+	if success {
+		e.metrics.reportEncodingLatency(finishedEncodingTime.Sub(start))
+		e.metrics.reportPutBlobCertLatency(finishedPutBlobCertificateTime.Sub(finishedEncodingTime))
+		e.metrics.reportUpdateBlobStatusLatency(
+			finishedUpdateBlobStatusTime.Sub(finishedPutBlobCertificateTime))
+		e.metrics.reportBlobHandleLatency(time.Since(start))
+
+		requestedAt := time.Unix(0, int64(blob.blob.RequestedAt))
+		e.metrics.reportE2EEncodingLatency(blob.blob.Priority, time.Since(requestedAt))
+		e.metrics.reportCompletedBlob(int(blob.blob.BlobSize), v2.Encoded)
+		return true
+	}
+
+	e.metrics.reportFailedSubmission()
+	storeCtx, cancel := context.WithTimeout(ctx, e.StoreTimeout)
+	err := e.blobMetadataStore.UpdateBlobStatus(storeCtx, blobKey, v2.Failed)
+	cancel()
+	if err != nil {
+		e.logger.Error("failed to update blob status to Failed", "blobKey", blobKey.Hex(), "err", err)
+		return false
+	}
+	e.metrics.reportCompletedBlob(int(blob.blob.BlobSize), v2.Failed)
+	return false
+}
+
 func (e *EncodingManager) encodeBlob(ctx context.Context, blobKey corev2.BlobKey, blob *v2.BlobMetadata, blobParams *core.BlobVersionParameters) (*encoding.FragmentInfo, error) {
 	// Add headers for routing
 	md := metadata.New(map[string]string{
@@ -331,6 +504,37 @@ func (e *EncodingManager) encodeBlob(ctx context.Context, blobKey corev2.BlobKey
 	return e.encodingClient.EncodeBlob(ctx, blobKey, encodingParams, blob.BlobSize)
 }
 
+// encodeBlobs encodes the blobs of a group in a single request, and returns the fragment info of each blob in the
+// order of the blobs of the group.
+func (e *EncodingManager) encodeBlobs(ctx context.Context, group *encodingGroup) ([]*encoding.FragmentInfo, error) {
+	blobs := make([]*disperser.BlobToEncode, len(group.blobs))
+	batchSize := uint64(0)
+	for i, blob := range group.blobs {
+		blobs[i] = &disperser.BlobToEncode{
+			BlobKey:        blob.blobKey,
+			EncodingParams: group.encodingParams,
+			BlobSize:       blob.blob.BlobSize,
+		}
+		batchSize += blob.blob.BlobSize
+	}
+
+	// Add headers for routing
+	md := metadata.New(map[string]string{
+		"content-type": "application/grpc",
+		"x-blob-size":  fmt.Sprintf("%d", batchSize),
+	})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	fragmentInfos, err := e.encodingClient.EncodeBlobs(ctx, blobs)
+	if err != nil {
+		return nil, err
+	}
+	if len(fragmentInfos) != len(blobs) {
+		return nil, fmt.Errorf("encoder returned %d fragment infos for %d blobs", len(fragmentInfos), len(blobs))
+	}
+	return fragmentInfos, nil
+}
+
 func (e *EncodingManager) refreshBlobVersionParams(ctx context.Context) error {
 	e.logger.Debug("Refreshing blob version params")
 	blobParams, err := e.chainReader.GetAllVersionedBlobParams(ctx)
@@ -342,6 +546,14 @@ func (e *EncodingManager) refreshBlobVersionParams(ctx context.Context) error {
 	return nil
 }
 
+// getRelayKeys returns the relays the blob is assigned to.
+func (e *EncodingManager) getRelayKeys(blob *v2.BlobMetadata) ([]corev2.RelayKey, error) {
+	if e.relaySelector != nil {
+		return e.relaySelector.SelectRelays(e.NumRelayAssignment, blob.BlobSize, blob.Expiry)
+	}
+	return GetRelayKeys(e.NumRelayAssignment, e.AvailableRelays)
+}
+
 func GetRelayKeys(numAssignment uint16, availableRelays []corev2.RelayKey) ([]corev2.RelayKey, error) {
 	if int(numAssignment) > len(availableRelays) {
 		return nil, fmt.Errorf("numAssignment (%d) cannot be greater than numRelays (%d)", numAssignment, len(availableRelays))
@@ -13,18 +13,24 @@ const encodingManagerNamespace = "eigenda_encoding_manager"
 
 // encodingManagerMetrics is a struct that holds the metrics for the encoding manager.
 type encodingManagerMetrics struct {
-	batchSubmissionLatency  *prometheus.SummaryVec
-	blobHandleLatency       *prometheus.SummaryVec
-	encodingLatency         *prometheus.SummaryVec
-	putBlobCertLatency      *prometheus.SummaryVec
-	updateBlobStatusLatency *prometheus.SummaryVec
-	blobE2EEncodingLatency  *prometheus.SummaryVec
-	batchSize               *prometheus.GaugeVec
-	batchDataSize           *prometheus.GaugeVec
-	batchRetryCount         *prometheus.GaugeVec
-	failedSubmissionCount   *prometheus.CounterVec
-	completedBlobs          *prometheus.CounterVec
-	blobSetSize             *prometheus.GaugeVec
+	batchSubmissionLatency           *prometheus.SummaryVec
+	blobHandleLatency                *prometheus.SummaryVec
+	encodingLatency                  *prometheus.SummaryVec
+	putBlobCertLatency               *prometheus.SummaryVec
+	updateBlobStatusLatency          *prometheus.SummaryVec
+	blobE2EEncodingLatency           *prometheus.SummaryVec
+	blobE2EEncodingLatencyByPriority *prometheus.SummaryVec
+	batchSize                        *prometheus.GaugeVec
+	batchDataSize                    *prometheus.GaugeVec
+	batchRetryCount                  *prometheus.GaugeVec
+	failedSubmissionCount            *prometheus.CounterVec
+	completedBlobs                   *prometheus.CounterVec
+	blobSetSize                      *prometheus.GaugeVec
+	queueDepth                       *prometheus.GaugeVec
+	encoderOutstandingBytes          *prometheus.GaugeVec
+	encoderHealthy                   *prometheus.GaugeVec
+	encoderRequests                  *prometheus.CounterVec
+	iterations                       *prometheus.CounterVec
 }
 
 // NewEncodingManagerMetrics sets up metrics for the encoding manager.
@@ -89,6 +95,16 @@ func newEncodingManagerMetrics(registry *prometheus.Registry) *encodingManagerMe
 		[]string{},
 	)
 
+	blobE2EEncodingLatencyByPriority := promauto.With(registry).NewSummaryVec(
+		prometheus.SummaryOpts{
+			Namespace:  encodingManagerNamespace,
+			Name:       "e2e_encoding_latency_by_priority_ms",
+			Help:       "The time required to encode a blob end-to-end, by priority class.",
+			Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+		},
+		[]string{"priority"},
+	)
+
 	batchSize := promauto.With(registry).NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace: encodingManagerNamespace,
@@ -143,19 +159,70 @@ func newEncodingManagerMetrics(registry *prometheus.Registry) *encodingManagerMe
 		[]string{},
 	)
 
+	queueDepth := promauto.With(registry).NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: encodingManagerNamespace,
+			Name:      "queue_depth",
+			Help:      "The number of queued blobs in the scheduling window, by priority class.",
+		},
+		[]string{"priority"},
+	)
+
+	encoderOutstandingBytes := promauto.With(registry).NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: encodingManagerNamespace,
+			Name:      "encoder_outstanding_bytes",
+			Help:      "The size of the blobs currently being encoded, by encoder.",
+		},
+		[]string{"encoder"},
+	)
+
+	encoderHealthy := promauto.With(registry).NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: encodingManagerNamespace,
+			Name:      "encoder_healthy",
+			Help:      "Whether the encoder accepted its last request (1) or was backed off (0).",
+		},
+		[]string{"encoder"},
+	)
+
+	encoderRequests := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: encodingManagerNamespace,
+			Name:      "encoder_requests_total",
+			Help:      "The number of encoding requests sent to each encoder, by result.",
+		},
+		[]string{"encoder", "result"},
+	)
+
+	iterations := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: encodingManagerNamespace,
+			Name:      "iterations_total",
+			Help:      "The number of iterations of the encoding loop, by what triggered them (notification or poll).",
+		},
+		[]string{"trigger"},
+	)
+
 	return &encodingManagerMetrics{
-		batchSubmissionLatency:  batchSubmissionLatency,
-		blobHandleLatency:       blobHandleLatency,
-		encodingLatency:         encodingLatency,
-		putBlobCertLatency:      putBlobCertLatency,
-		updateBlobStatusLatency: updateBlobStatusLatency,
-		blobE2EEncodingLatency:  blobE2EEncodingLatency,
-		batchSize:               batchSize,
-		batchDataSize:           batchDataSize,
-		batchRetryCount:         batchRetryCount,
-		failedSubmissionCount:   failSubmissionCount,
-		completedBlobs:          completedBlobs,
-		blobSetSize:             blobSetSize,
+		batchSubmissionLatency:           batchSubmissionLatency,
+		blobHandleLatency:                blobHandleLatency,
+		encodingLatency:                  encodingLatency,
+		putBlobCertLatency:               putBlobCertLatency,
+		updateBlobStatusLatency:          updateBlobStatusLatency,
+		blobE2EEncodingLatency:           blobE2EEncodingLatency,
+		blobE2EEncodingLatencyByPriority: blobE2EEncodingLatencyByPriority,
+		batchSize:                        batchSize,
+		batchDataSize:                    batchDataSize,
+		batchRetryCount:                  batchRetryCount,
+		failedSubmissionCount:            failSubmissionCount,
+		completedBlobs:                   completedBlobs,
+		blobSetSize:                      blobSetSize,
+		queueDepth:                       queueDepth,
+		encoderOutstandingBytes:          encoderOutstandingBytes,
+		encoderHealthy:                   encoderHealthy,
+		encoderRequests:                  encoderRequests,
+		iterations:                       iterations,
 	}
 }
 
@@ -179,8 +246,9 @@ func (m *encodingManagerMetrics) reportUpdateBlobStatusLatency(duration time.Dur
 	m.updateBlobStatusLatency.WithLabelValues().Observe(common.ToMilliseconds(duration))
 }
 
-func (m *encodingManagerMetrics) reportE2EEncodingLatency(duration time.Duration) {
+func (m *encodingManagerMetrics) reportE2EEncodingLatency(priority dispv2.PriorityClass, duration time.Duration) {
 	m.blobE2EEncodingLatency.WithLabelValues().Observe(common.ToMilliseconds(duration))
+	m.blobE2EEncodingLatencyByPriority.WithLabelValues(priority.String()).Observe(common.ToMilliseconds(duration))
 }
 
 func (m *encodingManagerMetrics) reportBatchSize(size int) {
@@ -218,3 +286,33 @@ func (m *encodingManagerMetrics) reportCompletedBlob(size int, status dispv2.Blo
 func (m *encodingManagerMetrics) reportBlobSetSize(size int) {
 	m.blobSetSize.WithLabelValues().Set(float64(size))
 }
+
+func (m *encodingManagerMetrics) reportQueueDepth(blobs []*dispv2.BlobMetadata) {
+	depths := make(map[dispv2.PriorityClass]int, len(dispv2.PriorityClasses))
+	for _, blob := range blobs {
+		depths[blob.Priority]++
+	}
+	for _, priority := range dispv2.PriorityClasses {
+		m.queueDepth.WithLabelValues(priority.String()).Set(float64(depths[priority]))
+	}
+}
+
+func (m *encodingManagerMetrics) reportEncoderOutstandingBytes(encoder string, size uint64) {
+	m.encoderOutstandingBytes.WithLabelValues(encoder).Set(float64(size))
+}
+
+func (m *encodingManagerMetrics) reportEncoderHealthy(encoder string, healthy bool) {
+	value := 0.0
+	if healthy {
+		value = 1.0
+	}
+	m.encoderHealthy.WithLabelValues(encoder).Set(value)
+}
+
+func (m *encodingManagerMetrics) reportEncoderRequest(encoder string, result string) {
+	m.encoderRequests.WithLabelValues(encoder, result).Inc()
+}
+
+func (m *encodingManagerMetrics) reportIteration(trigger string) {
+	m.iterations.WithLabelValues(trigger).Inc()
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/Layr-Labs/eigenda/encoding"
 
+	"github.com/Layr-Labs/eigenda/api"
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/healthcheck"
 	commonmock "github.com/Layr-Labs/eigenda/common/mock"
@@ -15,8 +16,10 @@ import (
 	coremock "github.com/Layr-Labs/eigenda/core/mock"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	v2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/disperser"
 	commonv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
 	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/notifier"
 	"github.com/Layr-Labs/eigenda/disperser/controller"
 	dispmock "github.com/Layr-Labs/eigenda/disperser/mock"
 	"github.com/gammazero/workerpool"
@@ -24,6 +27,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -37,6 +42,7 @@ type testComponents struct {
 	ChainReader     *coremock.MockWriter
 	MockPool        *commonmock.MockWorkerpool
 	BlobSet         *controller.MockBlobSet
+	BlobNotifier    *notifier.InProcessNotifier
 	LivenessChan    chan healthcheck.HeartbeatMessage
 }
 
@@ -142,12 +148,15 @@ func TestEncodingManagerHandleBatch(t *testing.T) {
 		}
 		close(done)
 	}()
+	encoded := c.BlobNotifier.Subscribe(commonv2.Encoded)
 
 	err = c.EncodingManager.HandleBatch(ctx)
 	require.NoError(t, err)
 	c.Pool.StopWait()
 	c.BlobSet.AssertCalled(t, "Contains", blobKey1)
 	c.BlobSet.AssertCalled(t, "AddBlob", blobKey1)
+	// the dispatcher is notified of the encoded blob
+	require.Eventually(t, func() bool { return len(encoded) == 1 }, time.Second, 10*time.Millisecond)
 
 	// give the signals a moment to be sent
 	time.Sleep(10 * time.Millisecond)
@@ -543,6 +552,7 @@ func newTestComponents(t *testing.T, mockPool bool) *testComponents {
 	blobSet := &controller.MockBlobSet{}
 	blobSet.On("Size", mock.Anything).Return(0)
 
+	blobNotifier := notifier.NewInProcessNotifier()
 	livenessChan := make(chan healthcheck.HeartbeatMessage, 100)
 
 	em, err := controller.NewEncodingManager(&controller.EncodingManagerConfig{
@@ -554,7 +564,7 @@ func newTestComponents(t *testing.T, mockPool bool) *testComponents {
 		AvailableRelays:             []corev2.RelayKey{0, 1, 2, 3},
 		MaxNumBlobsPerIteration:     5,
 		OnchainStateRefreshInterval: onchainRefreshInterval,
-	}, blobMetadataStore, pool, encodingClient, chainReader, logger, prometheus.NewRegistry(), blobSet, livenessChan)
+	}, blobMetadataStore, pool, map[string]disperser.EncoderClientV2{"encoder": encodingClient}, chainReader, logger, prometheus.NewRegistry(), blobSet, blobNotifier, nil, livenessChan)
 	assert.NoError(t, err)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 2*onchainRefreshInterval)
@@ -568,6 +578,165 @@ func newTestComponents(t *testing.T, mockPool bool) *testComponents {
 		ChainReader:     chainReader,
 		MockPool:        mockP,
 		BlobSet:         blobSet,
+		BlobNotifier:    blobNotifier,
 		LivenessChan:    livenessChan,
 	}
 }
+
+func TestEncodingManagerHandleBatchEncoderFailover(t *testing.T) {
+	ctx := context.Background()
+	blobKey1, blobHeader1 := newBlob(t, []core.QuorumID{0, 1})
+	now := time.Now()
+	metadata1 := &commonv2.BlobMetadata{
+		BlobHeader: blobHeader1,
+		BlobStatus: commonv2.Queued,
+		Expiry:     uint64(now.Add(time.Hour).Unix()),
+		NumRetries: 0,
+		UpdatedAt:  uint64(now.UnixNano()),
+	}
+	err := blobMetadataStore.PutBlobMetadata(ctx, metadata1)
+	require.NoError(t, err)
+
+	c := newTestComponents(t, false)
+	c.BlobSet.On("Contains", mock.Anything).Return(false)
+	c.BlobSet.On("AddBlob", mock.Anything).Return(nil)
+
+	// The first encoder has a full backlog, so the blob fails over to the second encoder
+	busyEncodingClient := dispmock.NewMockEncoderClientV2()
+	busyEncodingClient.On("EncodeBlob", mock.Anything, mock.Anything, mock.Anything).Return(
+		nil, api.NewErrorResourceExhausted("request queue is full"))
+	c.EncodingClient.On("EncodeBlob", mock.Anything, mock.Anything, mock.Anything).Return(&encoding.FragmentInfo{
+		TotalChunkSizeBytes: 100,
+		FragmentSizeBytes:   1024 * 1024 * 4,
+	}, nil)
+	em, err := controller.NewEncodingManager(
+		c.EncodingManager.EncodingManagerConfig,
+		blobMetadataStore,
+		c.Pool,
+		map[string]disperser.EncoderClientV2{
+			"encoder0": busyEncodingClient,
+			"encoder1": c.EncodingClient,
+		},
+		c.ChainReader,
+		logger,
+		prometheus.NewRegistry(),
+		c.BlobSet,
+		c.BlobNotifier,
+		nil,
+		c.LivenessChan,
+	)
+	require.NoError(t, err)
+	startCtx, cancel := context.WithTimeout(ctx, time.Millisecond)
+	defer cancel()
+	require.NoError(t, em.Start(startCtx))
+
+	err = em.HandleBatch(ctx)
+	require.NoError(t, err)
+	c.Pool.StopWait()
+
+	fetchedMetadata, err := blobMetadataStore.GetBlobMetadata(ctx, blobKey1)
+	require.NoError(t, err)
+	require.Equal(t, commonv2.Encoded, fetchedMetadata.BlobStatus)
+	busyEncodingClient.AssertNumberOfCalls(t, "EncodeBlob", 1)
+	c.EncodingClient.AssertNumberOfCalls(t, "EncodeBlob", 1)
+
+	deleteBlobs(t, blobMetadataStore, []corev2.BlobKey{blobKey1}, nil)
+}
+
+func TestEncodingManagerHandleBatchEncodeBlobs(t *testing.T) {
+	fragmentInfo := &encoding.FragmentInfo{
+		TotalChunkSizeBytes: 100,
+		FragmentSizeBytes:   1024 * 1024 * 4,
+	}
+
+	tests := []struct {
+		name string
+		// encodeBlobsErr is the error returned by EncodeBlobs
+		encodeBlobsErr error
+		// numEncodeBlobCalls is the number of blobs encoded one by one
+		numEncodeBlobCalls int
+	}{
+		{
+			name:               "blobs encoded in a single request",
+			numEncodeBlobCalls: 0,
+		},
+		{
+			name:               "blobs encoded one by one when the request fails",
+			encodeBlobsErr:     status.Error(codes.Unimplemented, "unknown method EncodeBlobs"),
+			numEncodeBlobCalls: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			now := time.Now()
+			blobKeys := make([]corev2.BlobKey, 2)
+			for i := range blobKeys {
+				blobKey, blobHeader := newBlob(t, []core.QuorumID{0, 1})
+				blobKeys[i] = blobKey
+				err := blobMetadataStore.PutBlobMetadata(ctx, &commonv2.BlobMetadata{
+					BlobHeader: blobHeader,
+					BlobStatus: commonv2.Queued,
+					Expiry:     uint64(now.Add(time.Hour).Unix()),
+					NumRetries: 0,
+					UpdatedAt:  uint64(now.UnixNano()),
+				})
+				require.NoError(t, err)
+			}
+
+			c := newTestComponents(t, false)
+			c.BlobSet.On("Contains", mock.Anything).Return(false)
+			c.BlobSet.On("AddBlob", mock.Anything).Return(nil)
+			if tt.encodeBlobsErr != nil {
+				c.EncodingClient.On("EncodeBlobs", mock.Anything).Return(nil, tt.encodeBlobsErr)
+			} else {
+				c.EncodingClient.On("EncodeBlobs", mock.Anything).Return(
+					[]*encoding.FragmentInfo{fragmentInfo, fragmentInfo}, nil)
+			}
+			c.EncodingClient.On("EncodeBlob", mock.Anything, mock.Anything, mock.Anything).Return(fragmentInfo, nil)
+
+			// Both blobs have the same encoding parameters, so they are sent to the encoder in a single request
+			config := *c.EncodingManager.EncodingManagerConfig
+			config.MaxBlobsPerEncodingRequest = 2
+			em, err := controller.NewEncodingManager(
+				&config,
+				blobMetadataStore,
+				c.Pool,
+				map[string]disperser.EncoderClientV2{"encoder": c.EncodingClient},
+				c.ChainReader,
+				logger,
+				prometheus.NewRegistry(),
+				c.BlobSet,
+				c.BlobNotifier,
+				nil,
+				c.LivenessChan,
+			)
+			require.NoError(t, err)
+			startCtx, cancel := context.WithTimeout(ctx, time.Millisecond)
+			defer cancel()
+			require.NoError(t, em.Start(startCtx))
+
+			err = em.HandleBatch(ctx)
+			require.NoError(t, err)
+			c.Pool.StopWait()
+
+			c.EncodingClient.AssertNumberOfCalls(t, "EncodeBlobs", 1)
+			blobs := c.EncodingClient.Calls[0].Arguments.Get(0).([]*disperser.BlobToEncode)
+			require.ElementsMatch(t, blobKeys, []corev2.BlobKey{blobs[0].BlobKey, blobs[1].BlobKey})
+			c.EncodingClient.AssertNumberOfCalls(t, "EncodeBlob", tt.numEncodeBlobCalls)
+
+			for _, blobKey := range blobKeys {
+				fetchedMetadata, err := blobMetadataStore.GetBlobMetadata(ctx, blobKey)
+				require.NoError(t, err)
+				require.Equal(t, commonv2.Encoded, fetchedMetadata.BlobStatus)
+
+				_, fetchedFragmentInfo, err := blobMetadataStore.GetBlobCertificate(ctx, blobKey)
+				require.NoError(t, err)
+				require.Equal(t, fragmentInfo, fetchedFragmentInfo)
+			}
+
+			deleteBlobs(t, blobMetadataStore, blobKeys, nil)
+		})
+	}
+}
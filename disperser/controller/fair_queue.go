@@ -0,0 +1,172 @@
+package controller
+
+import (
+	"fmt"
+
+	v2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// schedulingWindowFactor is how many more blobs than can be handled at once are fetched from the metadata store,
+// so that the FairQueue has blobs from several classes and accounts to choose from.
+const schedulingWindowFactor = 4
+
+// PriorityWeights are the relative shares of the encoding and dispatching capacity given to each priority class.
+type PriorityWeights map[v2.PriorityClass]uint64
+
+// DefaultPriorityWeights are the priority weights used when none are configured.
+var DefaultPriorityWeights = PriorityWeights{
+	v2.PriorityStandard: 1,
+	v2.PriorityReserved: 4,
+	v2.PriorityPremium:  8,
+}
+
+// FairQueue selects the blobs to handle next with weighted fair queuing. Priority classes get shares of the selected
+// blobs in proportion to their weights, and within a class the accounts take turns, so that a burst of blobs from one
+// account cannot delay the other accounts.
+//
+// The shares of the classes are tracked across calls to Schedule with start-time fair queuing, so a class that was
+// not selected in one call is preferred in the next one.
+//
+// FairQueue is not thread safe.
+type FairQueue struct {
+	weights PriorityWeights
+
+	// virtualTime is the virtual start time of the last selected blob
+	virtualTime float64
+	// finishTimes is the virtual finish time of the last selected blob of each class
+	finishTimes map[v2.PriorityClass]float64
+}
+
+// NewFairQueue creates a FairQueue with the given weights. Classes without a weight get a weight of 1.
+func NewFairQueue(weights PriorityWeights) (*FairQueue, error) {
+	for class, weight := range weights {
+		if weight == 0 {
+			return nil, fmt.Errorf("weight of priority class %s must be positive", class)
+		}
+	}
+	return &FairQueue{
+		weights:     weights,
+		finishTimes: make(map[v2.PriorityClass]float64),
+	}, nil
+}
+
+// accountQueue holds the blobs of an account, in the order they were requested.
+type accountQueue struct {
+	blobs []*v2.BlobMetadata
+	// served is the number of blobs of the account selected in the current call to Schedule
+	served int
+	// position is the position of the next blob of the account in the scheduled blobs, used to break ties
+	position int
+}
+
+// Schedule selects up to maxNumBlobs of the given blobs, which must be in the order they were queued, and returns
+// them in the order they should be handled.
+func (q *FairQueue) Schedule(blobs []*v2.BlobMetadata, maxNumBlobs int) []*v2.BlobMetadata {
+	classes := make(map[v2.PriorityClass]map[gethcommon.Address]*accountQueue)
+	for i, blob := range blobs {
+		accounts, ok := classes[blob.Priority]
+		if !ok {
+			accounts = make(map[gethcommon.Address]*accountQueue)
+			classes[blob.Priority] = accounts
+		}
+		accountID := blob.BlobHeader.PaymentMetadata.AccountID
+		account, ok := accounts[accountID]
+		if !ok {
+			account = &accountQueue{position: i}
+			accounts[accountID] = account
+		}
+		account.blobs = append(account.blobs, blob)
+	}
+	positions := make(map[*v2.BlobMetadata]int, len(blobs))
+	for i, blob := range blobs {
+		positions[blob] = i
+	}
+
+	selected := make([]*v2.BlobMetadata, 0, min(len(blobs), maxNumBlobs))
+	for len(selected) < maxNumBlobs && len(classes) > 0 {
+		// Select the class with the smallest virtual start time, preferring higher classes on ties
+		var class v2.PriorityClass
+		var start float64
+		first := true
+		for c := range classes {
+			s := max(q.finishTimes[c], q.virtualTime)
+			if first || s < start || (s == start && c > class) {
+				class, start, first = c, s, false
+			}
+		}
+
+		// Within the class, select the account that was served the least, preferring older blobs on ties
+		accounts := classes[class]
+		var accountID gethcommon.Address
+		var account *accountQueue
+		for id, a := range accounts {
+			if account == nil ||
+				a.served < account.served ||
+				(a.served == account.served && a.position < account.position) {
+				accountID, account = id, a
+			}
+		}
+
+		blob := account.blobs[0]
+		selected = append(selected, blob)
+		account.blobs = account.blobs[1:]
+		account.served++
+		if len(account.blobs) == 0 {
+			delete(accounts, accountID)
+			if len(accounts) == 0 {
+				delete(classes, class)
+			}
+		} else {
+			account.position = positions[account.blobs[0]]
+		}
+
+		q.virtualTime = start
+		q.finishTimes[class] = start + 1/float64(q.weight(class))
+	}
+
+	return selected
+}
+
+func (q *FairQueue) weight(class v2.PriorityClass) uint64 {
+	if weight, ok := q.weights[class]; ok {
+		return weight
+	}
+	return 1
+}
+
+// resumeCursor returns the cursor from which the next query of the metadata store should resume, given the blobs
+// returned by the last query and the blobs that were selected out of them. The next query resumes right before the
+// first blob that was not selected, so that the blobs left behind are considered again. If all blobs were selected,
+// it resumes from nextCursor, the cursor returned by the last query.
+func resumeCursor(
+	blobs []*v2.BlobMetadata,
+	selected []*v2.BlobMetadata,
+	prevCursor *blobstore.StatusIndexCursor,
+	nextCursor *blobstore.StatusIndexCursor,
+) (*blobstore.StatusIndexCursor, error) {
+	selectedSet := make(map[*v2.BlobMetadata]struct{}, len(selected))
+	for _, blob := range selected {
+		selectedSet[blob] = struct{}{}
+	}
+
+	for i, blob := range blobs {
+		if _, ok := selectedSet[blob]; ok {
+			continue
+		}
+		if i == 0 {
+			return prevCursor, nil
+		}
+		last := blobs[i-1]
+		blobKey, err := last.BlobHeader.BlobKey()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get blob key: %w", err)
+		}
+		return &blobstore.StatusIndexCursor{
+			BlobKey:   &blobKey,
+			UpdatedAt: last.UpdatedAt,
+		}, nil
+	}
+	return nextCursor, nil
+}
@@ -0,0 +1,107 @@
+package controller_test
+
+import (
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	commonv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/disperser/controller"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func newQueuedTestBlob(account byte, priority commonv2.PriorityClass) *commonv2.BlobMetadata {
+	return &commonv2.BlobMetadata{
+		BlobHeader: &corev2.BlobHeader{
+			PaymentMetadata: core.PaymentMetadata{
+				AccountID: gethcommon.BytesToAddress([]byte{account}),
+			},
+		},
+		Priority: priority,
+	}
+}
+
+func countPriorities(blobs []*commonv2.BlobMetadata) map[commonv2.PriorityClass]int {
+	counts := make(map[commonv2.PriorityClass]int)
+	for _, blob := range blobs {
+		counts[blob.Priority]++
+	}
+	return counts
+}
+
+func TestFairQueue(t *testing.T) {
+	_, err := controller.NewFairQueue(controller.PriorityWeights{commonv2.PriorityStandard: 0})
+	require.Error(t, err)
+
+	t.Run("weighted shares", func(t *testing.T) {
+		queue, err := controller.NewFairQueue(controller.DefaultPriorityWeights)
+		require.NoError(t, err)
+
+		blobs := make([]*commonv2.BlobMetadata, 0)
+		for _, priority := range commonv2.PriorityClasses {
+			for i := 0; i < 100; i++ {
+				blobs = append(blobs, newQueuedTestBlob(byte(i), priority))
+			}
+		}
+		selected := queue.Schedule(blobs, 26)
+		require.Len(t, selected, 26)
+		counts := countPriorities(selected)
+		require.Equal(t, 2, counts[commonv2.PriorityStandard])
+		require.Equal(t, 8, counts[commonv2.PriorityReserved])
+		require.Equal(t, 16, counts[commonv2.PriorityPremium])
+	})
+
+	t.Run("all blobs of a single class", func(t *testing.T) {
+		queue, err := controller.NewFairQueue(controller.DefaultPriorityWeights)
+		require.NoError(t, err)
+
+		blobs := []*commonv2.BlobMetadata{
+			newQueuedTestBlob(0, commonv2.PriorityStandard),
+			newQueuedTestBlob(1, commonv2.PriorityStandard),
+			newQueuedTestBlob(2, commonv2.PriorityStandard),
+		}
+		// A class without competition gets all the capacity, in queue order
+		require.Equal(t, blobs[:2], queue.Schedule(blobs, 2))
+		require.Equal(t, blobs, queue.Schedule(blobs, 10))
+	})
+
+	t.Run("accounts take turns", func(t *testing.T) {
+		queue, err := controller.NewFairQueue(controller.DefaultPriorityWeights)
+		require.NoError(t, err)
+
+		// A burst from account 0 is queued before a single blob from account 1
+		blobs := make([]*commonv2.BlobMetadata, 0)
+		for i := 0; i < 10; i++ {
+			blobs = append(blobs, newQueuedTestBlob(0, commonv2.PriorityReserved))
+		}
+		late := newQueuedTestBlob(1, commonv2.PriorityReserved)
+		blobs = append(blobs, late)
+
+		selected := queue.Schedule(blobs, 2)
+		require.Equal(t, []*commonv2.BlobMetadata{blobs[0], late}, selected)
+	})
+
+	t.Run("shares carry over between calls", func(t *testing.T) {
+		queue, err := controller.NewFairQueue(controller.PriorityWeights{
+			commonv2.PriorityStandard: 1,
+			commonv2.PriorityPremium:  3,
+		})
+		require.NoError(t, err)
+
+		blobs := make([]*commonv2.BlobMetadata, 0)
+		for i := 0; i < 10; i++ {
+			blobs = append(blobs, newQueuedTestBlob(byte(i), commonv2.PriorityStandard))
+			blobs = append(blobs, newQueuedTestBlob(byte(i), commonv2.PriorityPremium))
+		}
+		// Selecting a single blob at a time should still converge to the configured shares
+		counts := make(map[commonv2.PriorityClass]int)
+		for i := 0; i < 40; i++ {
+			selected := queue.Schedule(blobs, 1)
+			require.Len(t, selected, 1)
+			counts[selected[0].Priority]++
+		}
+		require.Equal(t, 10, counts[commonv2.PriorityStandard])
+		require.Equal(t, 30, counts[commonv2.PriorityPremium])
+	})
+}
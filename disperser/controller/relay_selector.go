@@ -0,0 +1,519 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/relay"
+	relaygrpc "github.com/Layr-Labs/eigenda/api/grpc/relay"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	v2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// RelayProber reports the health and load of a relay.
+type RelayProber interface {
+	// ProbeRelay returns the status of the relay at the given URL. It returns an error if the relay is unreachable or
+	// does not report itself as serving.
+	ProbeRelay(ctx context.Context, url string) (*relaygrpc.GetRelayStatusReply, error)
+}
+
+// grpcRelayProber is a RelayProber that checks the gRPC health service of the relays, and queries their status with
+// GetRelayStatus.
+type grpcRelayProber struct {
+	dialOptions []grpc.DialOption
+
+	mu sync.Mutex
+	// conns are the connections to the relays, by URL
+	conns map[string]*grpc.ClientConn
+}
+
+var _ RelayProber = (*grpcRelayProber)(nil)
+
+// NewRelayProber creates a RelayProber that connects to the relays over gRPC.
+func NewRelayProber(useSecureGrpc bool) RelayProber {
+	return &grpcRelayProber{
+		dialOptions: clients.GetGrpcDialOptions(useSecureGrpc, 1024*1024),
+		conns:       make(map[string]*grpc.ClientConn),
+	}
+}
+
+func (p *grpcRelayProber) ProbeRelay(ctx context.Context, url string) (*relaygrpc.GetRelayStatusReply, error) {
+	conn, err := p.getConn(url)
+	if err != nil {
+		return nil, err
+	}
+
+	health, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{
+		Service: relaygrpc.Relay_ServiceDesc.ServiceName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check health of relay %s: %w", url, err)
+	}
+	if health.GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING {
+		return nil, fmt.Errorf("relay %s is not serving: %s", url, health.GetStatus())
+	}
+
+	reply, err := relaygrpc.NewRelayClient(conn).GetRelayStatus(ctx, &relaygrpc.GetRelayStatusRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status of relay %s: %w", url, err)
+	}
+	return reply, nil
+}
+
+func (p *grpcRelayProber) getConn(url string) (*grpc.ClientConn, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if conn, ok := p.conns[url]; ok {
+		return conn, nil
+	}
+	conn, err := grpc.NewClient(url, p.dialOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create grpc client for relay %s: %w", url, err)
+	}
+	p.conns[url] = conn
+	return conn, nil
+}
+
+type RelaySelectorConfig struct {
+	// ProbeInterval is the interval at which the relay registry is refreshed and the relays are probed
+	ProbeInterval time.Duration
+	// ProbeTimeout is the timeout of each probe of a relay
+	ProbeTimeout time.Duration
+	// MaxUtilization is the fraction of its concurrent operation limit above which a relay is considered overloaded,
+	// and is not assigned new blobs
+	MaxUtilization float64
+	// StoredBlobsLookback is how far back blobs are read from the metadata store when the data stored by the relays is
+	// loaded at startup. It should be at least the time-to-live the API server gives blobs, since blobs requested
+	// earlier are not taken into account. Blobs past their expiry are skipped regardless.
+	StoredBlobsLookback time.Duration
+}
+
+// expiryBucket is the total size of the blobs assigned to a relay that expire in the same minute.
+type expiryBucket struct {
+	// expiry is the end of the minute, in Unix seconds
+	expiry uint64
+	bytes  uint64
+}
+
+// storedBlobsPageSize is the number of blobs read from the metadata store at a time when the data stored by the relays
+// is loaded at startup.
+const storedBlobsPageSize = 1000
+
+// selectedRelay is a relay known to the RelaySelector, along with the state the selector tracks for it.
+type selectedRelay struct {
+	key corev2.RelayKey
+	// url is the URL of the relay, or empty if it could not be read from the registry yet
+	url string
+
+	// healthy is whether the last probe of the relay succeeded and found the relay below the utilization limit
+	healthy bool
+	// utilization is the highest utilization of the concurrent operation limits of the relay at the last probe
+	utilization float64
+	// cacheMissRatio is the fraction of the cache lookups of the relay that missed between the last two probes
+	cacheMissRatio float64
+	// lastStatus is the status returned by the last successful probe, used to compute cacheMissRatio
+	lastStatus *relaygrpc.GetRelayStatusReply
+
+	// storedBytes is the total size of the unexpired blobs assigned to the relay
+	storedBytes uint64
+	// expiryBuckets are the blobs assigned to the relay grouped by the minute they expire in, in order of expiry
+	expiryBuckets []expiryBucket
+}
+
+// score is the cost of assigning a blob to the relay. Relays that store less data are preferred, and the score is
+// scaled up for relays that are busy or whose caches are thrashing.
+func (r *selectedRelay) score() float64 {
+	return float64(r.storedBytes+1) * (1 + r.utilization + r.cacheMissRatio)
+}
+
+// addBlob accounts a blob of the given size assigned to the relay until expiry.
+func (r *selectedRelay) addBlob(size uint64, expiry uint64) {
+	bucketExpiry := (expiry/60 + 1) * 60
+	r.storedBytes += size
+
+	// Blobs are usually assigned in the order they expire, so the bucket is searched from the end
+	i := len(r.expiryBuckets)
+	for i > 0 && r.expiryBuckets[i-1].expiry > bucketExpiry {
+		i--
+	}
+	if i > 0 && r.expiryBuckets[i-1].expiry == bucketExpiry {
+		r.expiryBuckets[i-1].bytes += size
+		return
+	}
+	r.expiryBuckets = append(r.expiryBuckets, expiryBucket{})
+	copy(r.expiryBuckets[i+1:], r.expiryBuckets[i:])
+	r.expiryBuckets[i] = expiryBucket{expiry: bucketExpiry, bytes: size}
+}
+
+// pruneExpired stops accounting the blobs that have expired by now, in Unix seconds.
+func (r *selectedRelay) pruneExpired(now uint64) {
+	i := 0
+	for i < len(r.expiryBuckets) && r.expiryBuckets[i].expiry <= now {
+		r.storedBytes -= r.expiryBuckets[i].bytes
+		i++
+	}
+	r.expiryBuckets = r.expiryBuckets[i:]
+}
+
+// RelaySelector assigns blobs to the relays registered in the relay registry contract. The relays are probed
+// periodically, and the relays that are unreachable or overloaded are not assigned new blobs. Among the healthy
+// relays, blobs are assigned to the relays that store the least data, so that the stored data is spread evenly.
+//
+// The data stored by each relay is tracked from the blobs assigned by the selector and their expiry. When the selector
+// starts, it is loaded from the unexpired blobs in the metadata store, so that it carries over restarts.
+type RelaySelector struct {
+	config            *RelaySelectorConfig
+	relayUrlProvider  relay.RelayUrlProvider
+	prober            RelayProber
+	blobMetadataStore blobstore.MetadataStore
+	metrics           *relaySelectorMetrics
+	logger            logging.Logger
+
+	mu     sync.Mutex
+	relays map[corev2.RelayKey]*selectedRelay
+}
+
+func NewRelaySelector(
+	config *RelaySelectorConfig,
+	relayUrlProvider relay.RelayUrlProvider,
+	prober RelayProber,
+	blobMetadataStore blobstore.MetadataStore,
+	registry *prometheus.Registry,
+	logger logging.Logger,
+) (*RelaySelector, error) {
+	if config.ProbeInterval <= 0 || config.ProbeTimeout <= 0 {
+		return nil, errors.New("relay probe interval and timeout must be positive")
+	}
+	if config.MaxUtilization <= 0 {
+		return nil, errors.New("relay max utilization must be positive")
+	}
+	if relayUrlProvider == nil || prober == nil {
+		return nil, errors.New("relay URL provider and prober are required")
+	}
+	if config.StoredBlobsLookback <= 0 {
+		return nil, errors.New("stored blobs lookback must be positive")
+	}
+	if blobMetadataStore == nil {
+		return nil, errors.New("blob metadata store is required")
+	}
+
+	return &RelaySelector{
+		config:            config,
+		relayUrlProvider:  relayUrlProvider,
+		prober:            prober,
+		blobMetadataStore: blobMetadataStore,
+		metrics:           newRelaySelectorMetrics(registry),
+		logger:            logger.With("component", "RelaySelector"),
+		relays:            make(map[corev2.RelayKey]*selectedRelay),
+	}, nil
+}
+
+// Start refreshes the relays from the registry and probes them, and loads the data they store from the metadata
+// store. It then keeps refreshing the relays periodically in the background until the context is cancelled.
+func (s *RelaySelector) Start(ctx context.Context) error {
+	err := s.Refresh(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The stored data only guides the selection, so blobs are assigned even if it could not be loaded
+	err = s.loadStoredBlobs(ctx)
+	if err != nil {
+		s.logger.Warn("failed to load the blobs stored by the relays, stored bytes start from zero", "err", err)
+	}
+
+	go func() {
+		ticker := time.NewTicker(s.config.ProbeInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.Refresh(ctx); err != nil {
+					s.logger.Warn("failed to refresh relays", "err", err)
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Refresh picks up the relays added to the registry since the last refresh, and probes all relays. A relay whose URL
+// can't be read from the registry is kept unhealthy, and reading its URL is retried at the next refresh.
+func (s *RelaySelector) Refresh(ctx context.Context) error {
+	relayCount, err := s.relayUrlProvider.GetRelayCount(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get relay count: %w", err)
+	}
+
+	// Relays cannot be removed from the registry, and their keys are assigned sequentially
+	s.mu.Lock()
+	known := make(map[corev2.RelayKey]string, len(s.relays))
+	for key, r := range s.relays {
+		known[key] = r.url
+	}
+	s.mu.Unlock()
+
+	for key := corev2.RelayKey(0); key < corev2.RelayKey(relayCount); key++ {
+		if url, ok := known[key]; ok && url != "" {
+			continue
+		}
+		url, err := s.relayUrlProvider.GetRelayUrl(ctx, key)
+		if err != nil {
+			s.logger.Warn("failed to get URL of relay", "relayKey", key, "err", err)
+		}
+		known[key] = url
+	}
+
+	statuses := make(map[corev2.RelayKey]*relaygrpc.GetRelayStatusReply, len(known))
+	var statusesMu sync.Mutex
+	var wg sync.WaitGroup
+	for key, url := range known {
+		if url == "" {
+			continue
+		}
+		wg.Add(1)
+		go func(key corev2.RelayKey, url string) {
+			defer wg.Done()
+			probeCtx, cancel := context.WithTimeout(ctx, s.config.ProbeTimeout)
+			defer cancel()
+			status, err := s.prober.ProbeRelay(probeCtx, url)
+			if err != nil {
+				s.logger.Warn("failed to probe relay", "relayKey", key, "url", url, "err", err)
+				return
+			}
+			statusesMu.Lock()
+			statuses[key] = status
+			statusesMu.Unlock()
+		}(key, url)
+	}
+	wg.Wait()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := uint64(time.Now().Unix())
+	for key, url := range known {
+		r, ok := s.relays[key]
+		if !ok {
+			r = &selectedRelay{key: key}
+			s.relays[key] = r
+		}
+		r.url = url
+		r.pruneExpired(now)
+		s.updateStatus(r, statuses[key])
+		s.metrics.reportRelayHealthy(key, r.healthy)
+		s.metrics.reportRelayStoredBytes(key, r.storedBytes)
+	}
+
+	return nil
+}
+
+// loadStoredBlobs accounts the unexpired blobs in the metadata store against the relays they are assigned to, so that
+// the data assigned to the relays before the selector started is taken into account.
+func (s *RelaySelector) loadStoredBlobs(ctx context.Context) error {
+	// Blobs requested before the lookback window are assumed to have expired
+	now := time.Now()
+	after := blobstore.BlobFeedCursor{}
+	if now.Add(-s.config.StoredBlobsLookback).UnixNano() > 0 {
+		after.RequestedAt = uint64(now.Add(-s.config.StoredBlobsLookback).UnixNano())
+	}
+	before := blobstore.BlobFeedCursor{RequestedAt: uint64(now.UnixNano())}
+	nowSeconds := uint64(now.Unix())
+
+	numBlobs := 0
+	for {
+		blobs, cursor, err := s.blobMetadataStore.GetBlobMetadataByRequestedAtForward(
+			ctx, after, before, storedBlobsPageSize)
+		if err != nil {
+			return fmt.Errorf("failed to get blob metadata: %w", err)
+		}
+
+		metadataByKey := make(map[corev2.BlobKey]*v2.BlobMetadata, len(blobs))
+		blobKeys := make([]corev2.BlobKey, 0, len(blobs))
+		for _, metadata := range blobs {
+			if metadata.Expiry <= nowSeconds {
+				continue
+			}
+			blobKey, err := metadata.BlobHeader.BlobKey()
+			if err != nil {
+				return fmt.Errorf("failed to get blob key: %w", err)
+			}
+			metadataByKey[blobKey] = metadata
+			blobKeys = append(blobKeys, blobKey)
+		}
+
+		if len(blobKeys) > 0 {
+			// Blobs that have not been encoded yet have no certificate, and are not assigned to any relay
+			certs, _, err := s.blobMetadataStore.GetBlobCertificates(ctx, blobKeys)
+			if err != nil {
+				return fmt.Errorf("failed to get blob certificates: %w", err)
+			}
+
+			s.mu.Lock()
+			for _, cert := range certs {
+				blobKey, err := cert.BlobHeader.BlobKey()
+				if err != nil {
+					s.mu.Unlock()
+					return fmt.Errorf("failed to get blob key: %w", err)
+				}
+				metadata, ok := metadataByKey[blobKey]
+				if !ok {
+					continue
+				}
+				for _, relayKey := range cert.RelayKeys {
+					if r, ok := s.relays[relayKey]; ok {
+						r.addBlob(metadata.BlobSize, metadata.Expiry)
+					}
+				}
+				numBlobs++
+			}
+			s.mu.Unlock()
+		}
+
+		if cursor == nil || len(blobs) < storedBlobsPageSize {
+			break
+		}
+		after = *cursor
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, r := range s.relays {
+		s.metrics.reportRelayStoredBytes(key, r.storedBytes)
+	}
+	s.logger.Info("loaded the blobs stored by the relays", "numBlobs", numBlobs)
+
+	return nil
+}
+
+// updateStatus updates the health and load of the relay from the status returned by its probe, which is nil if the
+// probe failed.
+func (s *RelaySelector) updateStatus(r *selectedRelay, status *relaygrpc.GetRelayStatusReply) {
+	if status == nil {
+		r.healthy = false
+		return
+	}
+
+	r.utilization = max(status.GetBlobOperationUtilization(), status.GetChunkOperationUtilization())
+	r.healthy = r.utilization < s.config.MaxUtilization
+
+	r.cacheMissRatio = 0
+	if r.lastStatus != nil {
+		// The counters are reset when the relay restarts
+		hits := counterDelta(status.GetBlobCacheHits(), r.lastStatus.GetBlobCacheHits()) +
+			counterDelta(status.GetChunkCacheHits(), r.lastStatus.GetChunkCacheHits())
+		misses := counterDelta(status.GetBlobCacheMisses(), r.lastStatus.GetBlobCacheMisses()) +
+			counterDelta(status.GetChunkCacheMisses(), r.lastStatus.GetChunkCacheMisses())
+		if hits+misses > 0 {
+			r.cacheMissRatio = float64(misses) / float64(hits+misses)
+		}
+	}
+	r.lastStatus = status
+}
+
+func counterDelta(current uint64, previous uint64) uint64 {
+	if current < previous {
+		return current
+	}
+	return current - previous
+}
+
+// SelectRelays returns the relays a blob of the given size that expires at expiry, in Unix seconds, is assigned to,
+// and accounts the blob against them. If fewer than numAssignment relays are healthy, the remaining relays are chosen
+// among the unhealthy relays.
+func (s *RelaySelector) SelectRelays(numAssignment uint16, blobSize uint64, expiry uint64) ([]corev2.RelayKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if int(numAssignment) > len(s.relays) {
+		return nil, fmt.Errorf("numAssignment (%d) cannot be greater than numRelays (%d)", numAssignment, len(s.relays))
+	}
+
+	now := uint64(time.Now().Unix())
+	candidates := make([]*selectedRelay, 0, len(s.relays))
+	for _, r := range s.relays {
+		r.pruneExpired(now)
+		candidates = append(candidates, r)
+	}
+	// Shuffle the relays so that ties are broken randomly
+	rand.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].healthy != candidates[j].healthy {
+			return candidates[i].healthy
+		}
+		return candidates[i].score() < candidates[j].score()
+	})
+
+	selected := candidates[:numAssignment]
+	relayKeys := make([]corev2.RelayKey, 0, numAssignment)
+	for _, r := range selected {
+		if !r.healthy {
+			s.logger.Warn("not enough healthy relays, assigning blob to unhealthy relay", "relayKey", r.key)
+		}
+		r.addBlob(blobSize, expiry)
+		s.metrics.reportRelayStoredBytes(r.key, r.storedBytes)
+		relayKeys = append(relayKeys, r.key)
+	}
+
+	return relayKeys, nil
+}
+
+type relaySelectorMetrics struct {
+	relayHealthy     *prometheus.GaugeVec
+	relayStoredBytes *prometheus.GaugeVec
+}
+
+func newRelaySelectorMetrics(registry *prometheus.Registry) *relaySelectorMetrics {
+	relayHealthy := promauto.With(registry).NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: encodingManagerNamespace,
+			Name:      "relay_healthy",
+			Help:      "Whether the relay passed its last probe (1) or not (0).",
+		},
+		[]string{"relay"},
+	)
+
+	relayStoredBytes := promauto.With(registry).NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: encodingManagerNamespace,
+			Name:      "relay_stored_bytes",
+			Help:      "The total size of the unexpired blobs assigned to the relay.",
+		},
+		[]string{"relay"},
+	)
+
+	return &relaySelectorMetrics{
+		relayHealthy:     relayHealthy,
+		relayStoredBytes: relayStoredBytes,
+	}
+}
+
+func (m *relaySelectorMetrics) reportRelayHealthy(key corev2.RelayKey, healthy bool) {
+	value := 0.0
+	if healthy {
+		value = 1.0
+	}
+	m.relayHealthy.WithLabelValues(fmt.Sprintf("%d", key)).Set(value)
+}
+
+func (m *relaySelectorMetrics) reportRelayStoredBytes(key corev2.RelayKey, size uint64) {
+	m.relayStoredBytes.WithLabelValues(fmt.Sprintf("%d", key)).Set(float64(size))
+}
@@ -0,0 +1,313 @@
+package controller_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	relaytest "github.com/Layr-Labs/eigenda/api/clients/v2/payloadretrieval/test"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/relay"
+	relaygrpc "github.com/Layr-Labs/eigenda/api/grpc/relay"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	commonv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigenda/disperser/controller"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRelayProber returns the configured status of each relay, or an error for the relays without a status
+type fakeRelayProber struct {
+	mu       sync.Mutex
+	statuses map[string]*relaygrpc.GetRelayStatusReply
+}
+
+var _ controller.RelayProber = (*fakeRelayProber)(nil)
+
+func (p *fakeRelayProber) ProbeRelay(_ context.Context, url string) (*relaygrpc.GetRelayStatusReply, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	status, ok := p.statuses[url]
+	if !ok {
+		return nil, errors.New("relay is unreachable")
+	}
+	return status, nil
+}
+
+func (p *fakeRelayProber) setStatus(url string, status *relaygrpc.GetRelayStatusReply) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.statuses[url] = status
+}
+
+// failingRelayUrlProvider fails to return the URLs of some of the relays
+type failingRelayUrlProvider struct {
+	*relaytest.TestRelayUrlProvider
+	failing map[corev2.RelayKey]bool
+}
+
+func (p *failingRelayUrlProvider) GetRelayUrl(ctx context.Context, relayKey corev2.RelayKey) (string, error) {
+	if p.failing[relayKey] {
+		return "", errors.New("failed to read relay URL")
+	}
+	return p.TestRelayUrlProvider.GetRelayUrl(ctx, relayKey)
+}
+
+// storedBlobsMetadataStore is a metadata store with only the blob metadata and certificates read by the
+// RelaySelector
+type storedBlobsMetadataStore struct {
+	blobstore.MetadataStore
+	blobs []*commonv2.BlobMetadata
+	certs map[corev2.BlobKey]*corev2.BlobCertificate
+}
+
+func (s *storedBlobsMetadataStore) GetBlobMetadataByRequestedAtForward(
+	_ context.Context,
+	after blobstore.BlobFeedCursor,
+	before blobstore.BlobFeedCursor,
+	limit int,
+) ([]*commonv2.BlobMetadata, *blobstore.BlobFeedCursor, error) {
+	var result []*commonv2.BlobMetadata
+	var cursor *blobstore.BlobFeedCursor
+	for _, metadata := range s.blobs {
+		if metadata.RequestedAt <= after.RequestedAt || metadata.RequestedAt >= before.RequestedAt {
+			continue
+		}
+		result = append(result, metadata)
+		cursor = &blobstore.BlobFeedCursor{RequestedAt: metadata.RequestedAt}
+		if len(result) == limit {
+			break
+		}
+	}
+	return result, cursor, nil
+}
+
+func (s *storedBlobsMetadataStore) GetBlobCertificates(
+	_ context.Context,
+	blobKeys []corev2.BlobKey,
+) ([]*corev2.BlobCertificate, []*encoding.FragmentInfo, error) {
+	var certs []*corev2.BlobCertificate
+	var fragmentInfos []*encoding.FragmentInfo
+	for _, blobKey := range blobKeys {
+		if cert, ok := s.certs[blobKey]; ok {
+			certs = append(certs, cert)
+			fragmentInfos = append(fragmentInfos, &encoding.FragmentInfo{})
+		}
+	}
+	return certs, fragmentInfos, nil
+}
+
+// storeBlob adds a blob requested at requestedAt that is assigned to the given relays, or to no relay if relayKeys is
+// nil
+func (s *storedBlobsMetadataStore) storeBlob(
+	t *testing.T,
+	size uint64,
+	requestedAt time.Time,
+	expiry time.Time,
+	relayKeys []corev2.RelayKey,
+) {
+	blobKey, blobHeader := newBlob(t, []core.QuorumID{0})
+	s.blobs = append(s.blobs, &commonv2.BlobMetadata{
+		BlobHeader:  blobHeader,
+		BlobStatus:  commonv2.Complete,
+		Expiry:      uint64(expiry.Unix()),
+		BlobSize:    size,
+		RequestedAt: uint64(requestedAt.UnixNano()),
+		UpdatedAt:   uint64(requestedAt.UnixNano()),
+	})
+	if relayKeys != nil {
+		s.certs[blobKey] = &corev2.BlobCertificate{BlobHeader: blobHeader, RelayKeys: relayKeys}
+	}
+}
+
+func newTestRelaySelector(t *testing.T, numRelays int) (*controller.RelaySelector, *relaytest.TestRelayUrlProvider, *fakeRelayProber) {
+	urlProvider := relaytest.NewTestRelayUrlProvider()
+	prober := &fakeRelayProber{statuses: make(map[string]*relaygrpc.GetRelayStatusReply)}
+	for i := 0; i < numRelays; i++ {
+		url := fmt.Sprintf("relay%d:32011", i)
+		urlProvider.StoreRelayUrl(corev2.RelayKey(i), url)
+		prober.setStatus(url, &relaygrpc.GetRelayStatusReply{})
+	}
+
+	metadataStore := &storedBlobsMetadataStore{certs: make(map[corev2.BlobKey]*corev2.BlobCertificate)}
+	selector := newTestRelaySelectorWithStore(t, urlProvider, prober, metadataStore)
+	return selector, urlProvider, prober
+}
+
+func newTestRelaySelectorWithStore(
+	t *testing.T,
+	urlProvider relay.RelayUrlProvider,
+	prober controller.RelayProber,
+	metadataStore blobstore.MetadataStore,
+) *controller.RelaySelector {
+	selector, err := controller.NewRelaySelector(&controller.RelaySelectorConfig{
+		ProbeInterval:       time.Hour,
+		ProbeTimeout:        time.Second,
+		MaxUtilization:      0.9,
+		StoredBlobsLookback: time.Hour,
+	}, urlProvider, prober, metadataStore, prometheus.NewRegistry(), logger)
+	require.NoError(t, err)
+	return selector
+}
+
+func TestRelaySelectorExcludesUnhealthyRelays(t *testing.T) {
+	ctx := context.Background()
+	selector, urlProvider, prober := newTestRelaySelector(t, 4)
+	expiry := uint64(time.Now().Add(time.Hour).Unix())
+
+	// No relays are known before the registry is read
+	_, err := selector.SelectRelays(1, 100, expiry)
+	require.Error(t, err)
+
+	// Relay 2 is overloaded and relay 3 is unreachable
+	prober.setStatus("relay2:32011", &relaygrpc.GetRelayStatusReply{ChunkOperationUtilization: 0.95})
+	prober.mu.Lock()
+	delete(prober.statuses, "relay3:32011")
+	prober.mu.Unlock()
+	require.NoError(t, selector.Refresh(ctx))
+
+	for i := 0; i < 10; i++ {
+		relayKeys, err := selector.SelectRelays(2, 100, expiry)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []corev2.RelayKey{0, 1}, relayKeys)
+	}
+
+	// When there are not enough healthy relays, unhealthy relays are assigned too
+	relayKeys, err := selector.SelectRelays(3, 100, expiry)
+	require.NoError(t, err)
+	require.Len(t, relayKeys, 3)
+	require.Subset(t, relayKeys, []corev2.RelayKey{0, 1})
+
+	_, err = selector.SelectRelays(5, 100, expiry)
+	require.Error(t, err)
+
+	// Relays added to the registry are picked up at the next refresh, and recovered relays are assigned blobs again
+	urlProvider.StoreRelayUrl(4, "relay4:32011")
+	prober.setStatus("relay4:32011", &relaygrpc.GetRelayStatusReply{})
+	prober.setStatus("relay2:32011", &relaygrpc.GetRelayStatusReply{ChunkOperationUtilization: 0.5})
+	require.NoError(t, selector.Refresh(ctx))
+	relayKeys, err = selector.SelectRelays(2, 100, expiry)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []corev2.RelayKey{2, 4}, relayKeys)
+}
+
+func TestRelaySelectorSpreadsStoredBytes(t *testing.T) {
+	ctx := context.Background()
+	selector, _, _ := newTestRelaySelector(t, 2)
+	require.NoError(t, selector.Refresh(ctx))
+	now := time.Now()
+
+	// A blob that has already expired does not count towards the bytes stored by its relay
+	expiredRelays, err := selector.SelectRelays(1, 1000, uint64(now.Add(-2*time.Minute).Unix()))
+	require.NoError(t, err)
+	require.Len(t, expiredRelays, 1)
+
+	counts := make(map[corev2.RelayKey]int)
+	for i := 0; i < 10; i++ {
+		relayKeys, err := selector.SelectRelays(1, 100, uint64(now.Add(time.Hour).Unix()))
+		require.NoError(t, err)
+		require.Len(t, relayKeys, 1)
+		counts[relayKeys[0]]++
+	}
+	require.Equal(t, map[corev2.RelayKey]int{0: 5, 1: 5}, counts)
+
+	// A blob that has not expired does
+	largeRelays, err := selector.SelectRelays(1, 1000, uint64(now.Add(time.Hour).Unix()))
+	require.NoError(t, err)
+	for i := 0; i < 5; i++ {
+		relayKeys, err := selector.SelectRelays(1, 100, uint64(now.Add(time.Hour).Unix()))
+		require.NoError(t, err)
+		require.NotEqual(t, largeRelays[0], relayKeys[0])
+	}
+}
+
+func TestRelaySelectorPrefersRelaysWithWarmCaches(t *testing.T) {
+	ctx := context.Background()
+	selector, _, prober := newTestRelaySelector(t, 2)
+	require.NoError(t, selector.Refresh(ctx))
+
+	// Between the two probes, every lookup of relay 0 missed its cache while every lookup of relay 1 hit it
+	prober.setStatus("relay0:32011", &relaygrpc.GetRelayStatusReply{BlobCacheMisses: 50, ChunkCacheMisses: 50})
+	prober.setStatus("relay1:32011", &relaygrpc.GetRelayStatusReply{BlobCacheHits: 50, ChunkCacheHits: 50})
+	require.NoError(t, selector.Refresh(ctx))
+
+	relayKeys, err := selector.SelectRelays(1, 100, uint64(time.Now().Add(time.Hour).Unix()))
+	require.NoError(t, err)
+	require.Equal(t, []corev2.RelayKey{1}, relayKeys)
+}
+
+func TestRelaySelectorLoadsStoredBlobs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	urlProvider := relaytest.NewTestRelayUrlProvider()
+	prober := &fakeRelayProber{statuses: make(map[string]*relaygrpc.GetRelayStatusReply)}
+	for i := 0; i < 3; i++ {
+		url := fmt.Sprintf("relay%d:32011", i)
+		urlProvider.StoreRelayUrl(corev2.RelayKey(i), url)
+		prober.setStatus(url, &relaygrpc.GetRelayStatusReply{})
+	}
+
+	// Relays 0 and 1 store unexpired blobs assigned before the selector started. The blobs assigned to relay 2 have
+	// expired, or were requested before the lookback window, and the blob without a certificate is not assigned yet.
+	now := time.Now()
+	metadataStore := &storedBlobsMetadataStore{certs: make(map[corev2.BlobKey]*corev2.BlobCertificate)}
+	metadataStore.storeBlob(t, 10_000, now.Add(-2*time.Hour), now.Add(time.Hour), []corev2.RelayKey{2})
+	metadataStore.storeBlob(t, 10_000, now.Add(-50*time.Minute), now.Add(-time.Minute), []corev2.RelayKey{2})
+	metadataStore.storeBlob(t, 10_000, now.Add(-40*time.Minute), now.Add(time.Hour), []corev2.RelayKey{0, 1})
+	metadataStore.storeBlob(t, 5_000, now.Add(-30*time.Minute), now.Add(time.Hour), []corev2.RelayKey{1})
+	metadataStore.storeBlob(t, 10_000, now.Add(-20*time.Minute), now.Add(time.Hour), nil)
+
+	selector := newTestRelaySelectorWithStore(t, urlProvider, prober, metadataStore)
+	require.NoError(t, selector.Start(ctx))
+
+	// Relay 2 stores the least data, then relay 0
+	expiry := uint64(now.Add(time.Hour).Unix())
+	for i := 0; i < 9; i++ {
+		relayKeys, err := selector.SelectRelays(1, 1_000, expiry)
+		require.NoError(t, err)
+		require.Equal(t, []corev2.RelayKey{2}, relayKeys)
+	}
+	relayKeys, err := selector.SelectRelays(2, 1_000, expiry)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []corev2.RelayKey{0, 2}, relayKeys)
+}
+
+func TestRelaySelectorToleratesMissingRelayUrls(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	urlProvider := &failingRelayUrlProvider{
+		TestRelayUrlProvider: relaytest.NewTestRelayUrlProvider(),
+		failing:              map[corev2.RelayKey]bool{1: true},
+	}
+	prober := &fakeRelayProber{statuses: make(map[string]*relaygrpc.GetRelayStatusReply)}
+	for i := 0; i < 3; i++ {
+		url := fmt.Sprintf("relay%d:32011", i)
+		urlProvider.StoreRelayUrl(corev2.RelayKey(i), url)
+		prober.setStatus(url, &relaygrpc.GetRelayStatusReply{})
+	}
+	metadataStore := &storedBlobsMetadataStore{certs: make(map[corev2.BlobKey]*corev2.BlobCertificate)}
+	selector := newTestRelaySelectorWithStore(t, urlProvider, prober, metadataStore)
+
+	// The relay whose URL can't be read is not assigned blobs while there are enough healthy relays
+	require.NoError(t, selector.Start(ctx))
+	expiry := uint64(time.Now().Add(time.Hour).Unix())
+	for i := 0; i < 10; i++ {
+		relayKeys, err := selector.SelectRelays(2, 100, expiry)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []corev2.RelayKey{0, 2}, relayKeys)
+	}
+
+	// Its URL is read again at the next refresh
+	delete(urlProvider.failing, 1)
+	require.NoError(t, selector.Refresh(ctx))
+	relayKeys, err := selector.SelectRelays(1, 100, expiry)
+	require.NoError(t, err)
+	require.Equal(t, []corev2.RelayKey{1}, relayKeys)
+}
@@ -85,6 +85,70 @@ const docTemplateV2 = `{
                 }
             }
         },
+        "/accounts/{account_id}/usage": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Accounts"
+                ],
+                "summary": "Fetch the reservation usage and on-demand spend of an account in a time window, bucketed by time",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The account ID to fetch usage for",
+                        "name": "account_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fetch usage before this time, exclusive (ISO 8601 format, example: 2006-01-02T15:04:05Z) [default: now]",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fetch usage after this time, exclusive (ISO 8601 format, example: 2006-01-02T15:04:05Z); must be smaller than ` + "`" + `before` + "`" + ` [default: ` + "`" + `before` + "`" + `-1h]",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Width of the time buckets in seconds [default: the reservation window]",
+                        "name": "interval",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.AccountUsageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not found",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "error: Server error",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/batches/feed": {
             "get": {
                 "produces": [
@@ -1190,6 +1254,51 @@ const docTemplateV2 = `{
                 }
             }
         },
+        "v2.AccountUsageBucket": {
+            "type": "object",
+            "properties": {
+                "num_blobs": {
+                    "type": "integer"
+                },
+                "on_demand_payment": {
+                    "description": "On-demand payment charged, in wei",
+                    "type": "string"
+                },
+                "on_demand_symbols": {
+                    "type": "integer"
+                },
+                "overflow_symbols": {
+                    "description": "Symbols charged to the overflow bins of the reservation",
+                    "type": "integer"
+                },
+                "reservation_symbols": {
+                    "description": "Symbols charged against the reservation, including the overflow",
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "description": "Start of the bucket, in Unix seconds",
+                    "type": "integer"
+                }
+            }
+        },
+        "v2.AccountUsageResponse": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "string"
+                },
+                "buckets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v2.AccountUsageBucket"
+                    }
+                },
+                "interval": {
+                    "description": "Width of the buckets in seconds",
+                    "type": "integer"
+                }
+            }
+        },
         "v2.AttestationInfo": {
             "type": "object",
             "properties": {
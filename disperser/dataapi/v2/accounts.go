@@ -1,12 +1,18 @@
 package v2
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"math/big"
 	"net/http"
+	"strconv"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/core/meterer"
 	v2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigenda/encoding"
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/gin-gonic/gin"
 )
@@ -102,3 +108,214 @@ func (s *ServerV2) FetchAccountBlobFeed(c *gin.Context) {
 	c.Writer.Header().Set(cacheControlParam, fmt.Sprintf("max-age=%d", maxBlobFeedAge))
 	c.JSON(http.StatusOK, response)
 }
+
+// FetchAccountUsage godoc
+//
+//	@Summary	Fetch the reservation usage and on-demand spend of an account in a time window, bucketed by time
+//	@Tags		Accounts
+//	@Produce	json
+//	@Param		account_id	path		string	true	"The account ID to fetch usage for"
+//	@Param		before		query		string	false	"Fetch usage before this time, exclusive (ISO 8601 format, example: 2006-01-02T15:04:05Z) [default: now]"
+//	@Param		after		query		string	false	"Fetch usage after this time, exclusive (ISO 8601 format, example: 2006-01-02T15:04:05Z); must be smaller than `before` [default: `before`-1h]"
+//	@Param		interval	query		int		false	"Width of the time buckets in seconds [default: the reservation window]"
+//	@Success	200			{object}	AccountUsageResponse
+//	@Failure	400			{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404			{object}	ErrorResponse	"error: Not found"
+//	@Failure	500			{object}	ErrorResponse	"error: Server error"
+//	@Router		/accounts/{account_id}/usage [get]
+func (s *ServerV2) FetchAccountUsage(c *gin.Context) {
+	handlerStart := time.Now()
+	ctx := c.Request.Context()
+
+	// Parse account ID
+	accountStr := c.Param("account_id")
+	if !gethcommon.IsHexAddress(accountStr) {
+		s.metrics.IncrementInvalidArgRequestNum("FetchAccountUsage")
+		invalidParamsErrorResponse(c, errors.New("account id is not valid hex"))
+		return
+	}
+	accountId := gethcommon.HexToAddress(accountStr)
+	if accountId == (gethcommon.Address{}) {
+		s.metrics.IncrementInvalidArgRequestNum("FetchAccountUsage")
+		invalidParamsErrorResponse(c, errors.New("zero account id is not valid"))
+		return
+	}
+
+	// Only the time range of the feed params is used
+	params, err := ParseFeedParams(c, s.metrics, "FetchAccountUsage")
+	if err != nil {
+		s.metrics.IncrementInvalidArgRequestNum("FetchAccountUsage")
+		invalidParamsErrorResponse(c, err)
+		return
+	}
+
+	paymentParams, err := s.getPaymentVaultParams(ctx)
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchAccountUsage")
+		errorResponse(c, fmt.Errorf("failed to fetch payment vault params: %w", err))
+		return
+	}
+
+	interval := paymentParams.ReservationWindow
+	if c.Query("interval") != "" {
+		interval, err = strconv.ParseUint(c.Query("interval"), 10, 64)
+		if err != nil {
+			s.metrics.IncrementInvalidArgRequestNum("FetchAccountUsage")
+			invalidParamsErrorResponse(c, fmt.Errorf("failed to parse interval param: %w", err))
+			return
+		}
+	}
+	if interval == 0 {
+		s.metrics.IncrementInvalidArgRequestNum("FetchAccountUsage")
+		invalidParamsErrorResponse(c, errors.New("interval must be greater than 0"))
+		return
+	}
+	intervalNanos := interval * uint64(time.Second)
+	afterNanos := uint64(params.afterTime.UnixNano())
+	beforeNanos := uint64(params.beforeTime.UnixNano())
+	numBuckets := (beforeNanos - afterNanos + intervalNanos - 1) / intervalNanos
+	if numBuckets > maxNumBucketsPerAccountUsageResponse {
+		s.metrics.IncrementInvalidArgRequestNum("FetchAccountUsage")
+		invalidParamsErrorResponse(c, fmt.Errorf(
+			"too many buckets: %d, the interval must be at least %d seconds for this time range",
+			numBuckets, (beforeNanos-afterNanos)/maxNumBucketsPerAccountUsageResponse/uint64(time.Second)+1))
+		return
+	}
+
+	reservations, err := s.chainReader.GetReservedPaymentByAccount(ctx, accountId)
+	if err != nil {
+		s.logger.Debug("failed to fetch reservation, ignore reservation overflows", "err", err, "accountId", accountId.Hex())
+	}
+
+	// Replay the blobs from the start of the first reservation period, so that the reservation usage of the blobs in
+	// the time range is accounted from the start of the period
+	periodStart := meterer.GetReservationPeriodByNanosecond(int64(afterNanos), paymentParams.ReservationWindow) *
+		uint64(time.Second)
+	if periodStart > 0 {
+		// The time range is exclusive
+		periodStart--
+	}
+	buckets := make([]*AccountUsageBucket, numBuckets)
+	onDemandPayments := make([]*big.Int, numBuckets)
+	for i := range buckets {
+		buckets[i] = &AccountUsageBucket{
+			Timestamp: uint64(params.afterTime.Add(time.Duration(i) * time.Duration(intervalNanos)).Unix()),
+		}
+		onDemandPayments[i] = big.NewInt(0)
+	}
+
+	replayer := meterer.NewUsageReplayer(
+		reservations,
+		paymentParams.MinNumSymbols,
+		paymentParams.PricePerSymbol,
+		paymentParams.ReservationWindow,
+	)
+	err = forEachAccountBlob(ctx, s.blobMetadataStore, accountId, periodStart, beforeNanos, accountUsagePageSize,
+		func(blob *v2.BlobMetadata) {
+			charge := replayer.Charge(
+				blob.BlobHeader.PaymentMetadata,
+				uint64(encoding.GetBlobLengthPowerOf2(uint(blob.BlobSize))),
+			)
+			if blob.RequestedAt <= afterNanos {
+				return
+			}
+
+			i := (blob.RequestedAt - afterNanos) / intervalNanos
+			buckets[i].NumBlobs++
+			if charge.PaymentCharged.Sign() > 0 {
+				buckets[i].OnDemandSymbols += charge.SymbolsCharged
+				onDemandPayments[i].Add(onDemandPayments[i], charge.PaymentCharged)
+			} else {
+				buckets[i].ReservationSymbols += charge.SymbolsCharged
+				buckets[i].OverflowSymbols += charge.OverflowSymbols
+			}
+		})
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchAccountUsage")
+		errorResponse(c, fmt.Errorf("failed to fetch blobs from blob metadata store for account (%s): %w", accountId.Hex(), err))
+		return
+	}
+	for i := range buckets {
+		buckets[i].OnDemandPayment = onDemandPayments[i].String()
+	}
+
+	response := &AccountUsageResponse{
+		AccountId: accountId.Hex(),
+		Interval:  interval,
+		Buckets:   buckets,
+	}
+
+	s.metrics.IncrementSuccessfulRequestNum("FetchAccountUsage")
+	s.metrics.ObserveLatency("FetchAccountUsage", time.Since(handlerStart))
+	c.Writer.Header().Set(cacheControlParam, fmt.Sprintf("max-age=%d", maxAccountUsageAge))
+	c.JSON(http.StatusOK, response)
+}
+
+// forEachAccountBlob calls fn on each blob of the account requested in the exclusive time range (start, end), in
+// ascending order of request time. The blobs are fetched pageSize at a time, so that the blobs of a long time range
+// are never all held in memory.
+func forEachAccountBlob(
+	ctx context.Context,
+	blobMetadataStore blobstore.MetadataStore,
+	accountId gethcommon.Address,
+	start uint64,
+	end uint64,
+	pageSize int,
+	fn func(blob *v2.BlobMetadata),
+) error {
+	for start+1 < end {
+		blobs, err := blobMetadataStore.GetBlobMetadataByAccountID(ctx, accountId, start, end, pageSize, true)
+		if err != nil {
+			return err
+		}
+		if len(blobs) < pageSize {
+			for _, blob := range blobs {
+				fn(blob)
+			}
+			return nil
+		}
+
+		// The blobs requested at the same time as the last blob of the page may not all be in the page, so they are
+		// left for the next page. If the whole page was requested at the same time, there is no earlier point to
+		// resume from, so the page is processed as a whole and any other blobs requested at that nanosecond are
+		// skipped.
+		last := blobs[len(blobs)-1].RequestedAt
+		next := last - 1
+		if blobs[0].RequestedAt == last {
+			next = last
+		}
+		for _, blob := range blobs {
+			if blob.RequestedAt > next {
+				break
+			}
+			fn(blob)
+		}
+		start = next
+	}
+	return nil
+}
+
+// getPaymentVaultParams fetches the payment vault parameters needed to recompute the charges of dispersals.
+func (s *ServerV2) getPaymentVaultParams(ctx context.Context) (*meterer.PaymentVaultParams, error) {
+	blockNumber, err := s.chainReader.GetCurrentBlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	minNumSymbols, err := s.chainReader.GetMinNumSymbols(ctx, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	pricePerSymbol, err := s.chainReader.GetPricePerSymbol(ctx, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	reservationWindow, err := s.chainReader.GetReservationWindow(ctx, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return &meterer.PaymentVaultParams{
+		MinNumSymbols:     minNumSymbols,
+		PricePerSymbol:    pricePerSymbol,
+		ReservationWindow: reservationWindow,
+	}, nil
+}
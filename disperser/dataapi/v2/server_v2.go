@@ -38,6 +38,12 @@ const (
 	// range or "limit" param.
 	maxNumBatchesPerBatchFeedResponse = 1000
 
+	// The max number of time buckets to return from account usage API.
+	maxNumBucketsPerAccountUsageResponse = 1000
+
+	// The number of blobs fetched from the metadata store at a time by the account usage API.
+	accountUsagePageSize = 1000
+
 	// The quorum IDs that are allowed to query for signing info are [0, maxQuorumIDAllowed]
 	maxQuorumIDAllowed = 2
 
@@ -65,6 +71,7 @@ const (
 	maxMetricAge        = 5
 	maxThroughputAge    = 5
 	maxBlobFeedAge      = 5
+	maxAccountUsageAge  = 5
 	maxBatchFeedAge     = 5
 	maxDispersalFeedAge = 5
 	maxSigningInfoAge   = 5
@@ -241,6 +248,7 @@ func (s *ServerV2) Start() error {
 		accounts := v2.Group("/accounts")
 		{
 			accounts.GET("/:account_id/blobs", s.FetchAccountBlobFeed)
+			accounts.GET("/:account_id/usage", s.FetchAccountUsage)
 		}
 		operators := v2.Group("/operators")
 		{
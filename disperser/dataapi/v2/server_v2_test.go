@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"math/big"
 	"net/http"
 	"net/http/httptest"
@@ -2465,6 +2466,123 @@ func TestFetchAccountBlobFeed(t *testing.T) {
 	})
 }
 
+func TestFetchAccountUsage(t *testing.T) {
+	r := setUpRouter()
+	ctx := context.Background()
+
+	// Reservation periods are 10 minutes long and the reservation allows 600 symbols per period
+	mockTx.On("GetMinNumSymbols").Return(uint64(64), nil)
+	mockTx.On("GetPricePerSymbol").Return(uint64(2), nil)
+	mockTx.On("GetReservationWindow").Return(uint64(600), nil)
+	mockTx.On("GetReservedPaymentByAccount", mock.Anything, mock.Anything).Return(
+		map[core.QuorumID]*core.ReservedPayment{
+			0: {SymbolsPerSecond: 1, StartTimestamp: 0, EndTimestamp: math.MaxUint32, QuorumNumbers: []uint8{0}},
+		},
+		nil,
+	)
+
+	now := time.Now()
+	accountId := gethcommon.HexToAddress(fmt.Sprintf("0x000000000000000000000000000000000000000%d", 7))
+
+	// Two blobs paid for by reservation, followed by two blobs paid on demand. Each blob is charged 1024 symbols.
+	blobTimes := []time.Duration{45 * time.Minute, 35 * time.Minute, 25 * time.Minute, 5 * time.Minute}
+	cumulativePayments := []int64{0, 0, 2048, 4096}
+	dynamoKeys := make([]commondynamodb.Key, len(blobTimes))
+	for i := range blobTimes {
+		requestedAt := uint64(now.Add(-blobTimes[i]).UnixNano())
+		blobHeader := makeBlobHeaderV2(t)
+		blobHeader.PaymentMetadata.AccountID = accountId
+		blobHeader.PaymentMetadata.Timestamp = int64(requestedAt)
+		blobHeader.PaymentMetadata.CumulativePayment = big.NewInt(cumulativePayments[i])
+		blobKey, err := blobHeader.BlobKey()
+		require.NoError(t, err)
+		metadata := &v2.BlobMetadata{
+			BlobHeader:  blobHeader,
+			Signature:   []byte{1, 2, 3},
+			BlobStatus:  v2.Complete,
+			Expiry:      uint64(now.Add(time.Hour).Unix()),
+			BlobSize:    1000 * encoding.BYTES_PER_SYMBOL,
+			UpdatedAt:   requestedAt,
+			RequestedAt: requestedAt,
+		}
+		err = blobMetadataStore.PutBlobMetadata(ctx, metadata)
+		require.NoError(t, err)
+		dynamoKeys[i] = commondynamodb.Key{
+			"PK": &types.AttributeValueMemberS{Value: "BlobKey#" + blobKey.Hex()},
+			"SK": &types.AttributeValueMemberS{Value: "BlobMetadata"},
+		}
+	}
+	defer deleteItems(t, dynamoKeys)
+
+	r.GET("/v2/accounts/:account_id/usage", testDataApiServerV2.FetchAccountUsage)
+	baseUrl := fmt.Sprintf("/v2/accounts/%s/usage", accountId.Hex())
+
+	t.Run("invalid params", func(t *testing.T) {
+		tests := []struct {
+			url           string
+			expectedError string
+		}{
+			{
+				url:           "/v2/accounts/0x123/usage",
+				expectedError: "account id is not valid hex",
+			},
+			{
+				url:           baseUrl + "?interval=0",
+				expectedError: "interval must be greater than 0",
+			},
+			{
+				url:           baseUrl + "?interval=1",
+				expectedError: "too many buckets",
+			},
+		}
+		for _, tc := range tests {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, tc.url, nil)
+			r.ServeHTTP(w, req)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+
+			var response serverv2.ErrorResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			require.NoError(t, err)
+			assert.Contains(t, response.Error, tc.expectedError)
+		}
+	})
+
+	t.Run("default interval", func(t *testing.T) {
+		// The buckets default to the reservation window
+		w := executeRequest(t, r, http.MethodGet, baseUrl)
+		response := decodeResponseBody[serverv2.AccountUsageResponse](t, w)
+		assert.Equal(t, accountId.Hex(), response.AccountId)
+		assert.Equal(t, uint64(600), response.Interval)
+		require.Equal(t, 6, len(response.Buckets))
+	})
+
+	t.Run("bucketed usage", func(t *testing.T) {
+		// Buckets of 20 minutes over the last hour
+		w := executeRequest(t, r, http.MethodGet, baseUrl+"?interval=1200")
+		response := decodeResponseBody[serverv2.AccountUsageResponse](t, w)
+		require.Equal(t, 3, len(response.Buckets))
+
+		// Each reservation blob overflows the 600 symbols allowed in its reservation period
+		assert.Equal(t, uint64(1), response.Buckets[0].NumBlobs)
+		assert.Equal(t, uint64(1024), response.Buckets[0].ReservationSymbols)
+		assert.Equal(t, uint64(424), response.Buckets[0].OverflowSymbols)
+		assert.Equal(t, uint64(0), response.Buckets[0].OnDemandSymbols)
+		assert.Equal(t, "0", response.Buckets[0].OnDemandPayment)
+
+		assert.Equal(t, uint64(2), response.Buckets[1].NumBlobs)
+		assert.Equal(t, uint64(1024), response.Buckets[1].ReservationSymbols)
+		assert.Equal(t, uint64(424), response.Buckets[1].OverflowSymbols)
+		assert.Equal(t, uint64(1024), response.Buckets[1].OnDemandSymbols)
+		assert.Equal(t, "2048", response.Buckets[1].OnDemandPayment)
+
+		assert.Equal(t, uint64(1), response.Buckets[2].NumBlobs)
+		assert.Equal(t, uint64(0), response.Buckets[2].ReservationSymbols)
+		assert.Equal(t, uint64(1024), response.Buckets[2].OnDemandSymbols)
+		assert.Equal(t, "2048", response.Buckets[2].OnDemandPayment)
+	})
+}
+
 func TestFetchOperatorDispersalResponse(t *testing.T) {
 	r := setUpRouter()
 	ctx := context.Background()
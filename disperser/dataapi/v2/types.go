@@ -175,6 +175,26 @@ type (
 		AccountId string     `json:"account_id"`
 		Blobs     []BlobInfo `json:"blobs"`
 	}
+
+	// AccountUsageBucket is the usage of an account in the time interval [Timestamp, Timestamp+interval).
+	AccountUsageBucket struct {
+		// Start of the bucket, in Unix seconds
+		Timestamp uint64 `json:"timestamp"`
+		NumBlobs  uint64 `json:"num_blobs"`
+		// Symbols charged against the reservation, including the overflow
+		ReservationSymbols uint64 `json:"reservation_symbols"`
+		// Symbols charged to the overflow bins of the reservation
+		OverflowSymbols uint64 `json:"overflow_symbols"`
+		OnDemandSymbols uint64 `json:"on_demand_symbols"`
+		// On-demand payment charged, in wei
+		OnDemandPayment string `json:"on_demand_payment"`
+	}
+	AccountUsageResponse struct {
+		AccountId string `json:"account_id"`
+		// Width of the buckets in seconds
+		Interval uint64                `json:"interval"`
+		Buckets  []*AccountUsageBucket `json:"buckets"`
+	}
 )
 
 // System types
@@ -63,3 +63,55 @@ func (c *clientV2) EncodeBlob(
 		FragmentSizeBytes:   reply.GetFragmentInfo().GetFragmentSizeBytes(),
 	}, nil
 }
+
+func (c *clientV2) EncodeBlobs(
+	ctx context.Context,
+	blobs []*disperser.BlobToEncode) ([]*encoding.FragmentInfo, error) {
+
+	// Establish connection
+	conn, err := grpc.NewClient(
+		c.addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial encoder: %w", err)
+	}
+	defer conn.Close()
+
+	// Create client
+	client := pb.NewEncoderClient(conn)
+
+	// Prepare request
+	req := &pb.EncodeBlobsRequest{
+		Blobs: make([]*pb.EncodeBlobRequest, len(blobs)),
+	}
+	for i, blob := range blobs {
+		req.Blobs[i] = &pb.EncodeBlobRequest{
+			BlobKey: blob.BlobKey[:],
+			EncodingParams: &pb.EncodingParams{
+				ChunkLength: blob.EncodingParams.ChunkLength,
+				NumChunks:   blob.EncodingParams.NumChunks,
+			},
+			BlobSize: blob.BlobSize,
+		}
+	}
+
+	// Make the RPC call
+	reply, err := client.EncodeBlobs(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode blobs: %w", err)
+	}
+	if len(reply.GetReplies()) != len(blobs) {
+		return nil, fmt.Errorf("encoder returned %d replies for %d blobs", len(reply.GetReplies()), len(blobs))
+	}
+
+	// Extract and return fragment info
+	fragmentInfos := make([]*encoding.FragmentInfo, len(blobs))
+	for i, blobReply := range reply.GetReplies() {
+		fragmentInfos[i] = &encoding.FragmentInfo{
+			TotalChunkSizeBytes: blobReply.GetFragmentInfo().GetTotalChunkSizeBytes(),
+			FragmentSizeBytes:   blobReply.GetFragmentInfo().GetFragmentSizeBytes(),
+		}
+	}
+	return fragmentInfos, nil
+}
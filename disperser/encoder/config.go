@@ -15,4 +15,7 @@ type ServerConfig struct {
 	GPUEnable                bool
 	PprofHttpPort            string
 	EnablePprof              bool
+
+	// MaxBlobsPerEncodeBlobsRequest is the maximum number of blobs that can be encoded in a single EncodeBlobs request
+	MaxBlobsPerEncodeBlobsRequest int
 }
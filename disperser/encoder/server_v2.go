@@ -21,6 +21,7 @@ import (
 	"github.com/Layr-Labs/eigenda/relay/chunkstore"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	grpcprom "github.com/grpc-ecosystem/go-grpc-middleware/providers/prometheus"
+	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/reflection"
@@ -152,17 +153,8 @@ func (s *EncoderServerV2) handleEncodingToChunkStore(ctx context.Context, blobKe
 	s.logger.Info("Preparing to encode", "blobKey", blobKey.Hex(), "encodingParams", encodingParams)
 
 	// Check if the blob has already been encoded
-	if s.config.PreventReencoding && s.chunkWriter.ProofExists(ctx, blobKey) {
-		coefExist, fragmentInfo := s.chunkWriter.CoefficientsExists(ctx, blobKey)
-		if coefExist {
-			s.logger.Info("blob already encoded", "blobKey", blobKey.Hex())
-			return &pb.EncodeBlobReply{
-				FragmentInfo: &pb.FragmentInfo{
-					TotalChunkSizeBytes: fragmentInfo.TotalChunkSizeBytes,
-					FragmentSizeBytes:   fragmentInfo.FragmentSizeBytes,
-				},
-			}, nil
-		}
+	if reply := s.getExistingEncoding(ctx, blobKey); reply != nil {
+		return reply, nil
 	}
 
 	// Fetch blob data
@@ -190,6 +182,224 @@ func (s *EncoderServerV2) handleEncodingToChunkStore(ctx context.Context, blobKe
 	return s.processAndStoreResults(ctx, blobKey, frames)
 }
 
+// getExistingEncoding returns the reply for a blob that has already been encoded, or nil if the blob has to be
+// encoded.
+func (s *EncoderServerV2) getExistingEncoding(ctx context.Context, blobKey corev2.BlobKey) *pb.EncodeBlobReply {
+	if !s.config.PreventReencoding || !s.chunkWriter.ProofExists(ctx, blobKey) {
+		return nil
+	}
+	coefExist, fragmentInfo := s.chunkWriter.CoefficientsExists(ctx, blobKey)
+	if !coefExist {
+		return nil
+	}
+
+	s.logger.Info("blob already encoded", "blobKey", blobKey.Hex())
+	return &pb.EncodeBlobReply{
+		FragmentInfo: &pb.FragmentInfo{
+			TotalChunkSizeBytes: fragmentInfo.TotalChunkSizeBytes,
+			FragmentSizeBytes:   fragmentInfo.FragmentSizeBytes,
+		},
+	}
+}
+
+// blobToEncode is a blob of an EncodeBlobs request.
+type blobToEncode struct {
+	blobKey        corev2.BlobKey
+	encodingParams encoding.EncodingParams
+	blobSize       int
+}
+
+// EncodeBlobs encodes several blobs in a single request. Blobs with the same encoding parameters are encoded together,
+// sharing the encoding setup and the proof computation. A batch counts as a single request towards the backlog and
+// concurrency limits.
+func (s *EncoderServerV2) EncodeBlobs(ctx context.Context, req *pb.EncodeBlobsRequest) (*pb.EncodeBlobsReply, error) {
+	totalStart := time.Now()
+	defer func() {
+		s.metrics.ObserveLatency("batch_total", time.Since(totalStart))
+	}()
+
+	// Validate the request.
+	blobs, err := s.validateAndParseBatchRequest(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	batchSize := 0
+	for _, blob := range blobs {
+		batchSize += blob.blobSize
+	}
+
+	// If we have too large of a backlog, refuse to accept new work.
+	err = s.pushBacklogLimiter(batchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer s.popBacklogLimiter(batchSize)
+
+	// Limit the number of concurrent requests.
+	err = s.pushConcurrencyLimiter(ctx, batchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer s.popConcurrencyLimiter()
+
+	s.metrics.ObserveLatency("batch_queuing", time.Since(totalStart))
+	replies, err := s.handleBatchEncodingToChunkStore(ctx, blobs)
+	for _, blob := range blobs {
+		if err != nil {
+			s.metrics.IncrementFailedBlobRequestNum(blob.blobSize)
+		} else {
+			s.metrics.IncrementSuccessfulBlobRequestNum(blob.blobSize)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.EncodeBlobsReply{
+		Replies: replies,
+	}, nil
+}
+
+// handleBatchEncodingToChunkStore encodes the blobs of an EncodeBlobs request that haven't been encoded yet, and
+// stores the results of all blobs in the chunk store once every blob has been encoded. The replies are returned in
+// the order of the blobs.
+func (s *EncoderServerV2) handleBatchEncodingToChunkStore(ctx context.Context, blobs []*blobToEncode) ([]*pb.EncodeBlobReply, error) {
+	s.logger.Info("Preparing to encode batch", "numBlobs", len(blobs))
+
+	// Check which blobs have already been encoded
+	replies := make([]*pb.EncodeBlobReply, len(blobs))
+	toEncode := make([]int, 0, len(blobs))
+	for i, blob := range blobs {
+		if reply := s.getExistingEncoding(ctx, blob.blobKey); reply != nil {
+			replies[i] = reply
+			continue
+		}
+		toEncode = append(toEncode, i)
+	}
+	if len(toEncode) == 0 {
+		return replies, nil
+	}
+
+	// Fetch blob data
+	fetchStart := time.Now()
+	data := make([][]byte, len(blobs))
+	fetchErrors := make(chan error, len(toEncode))
+	for _, i := range toEncode {
+		go func(i int) {
+			blobData, err := s.blobStore.GetBlob(ctx, blobs[i].blobKey)
+			if err != nil {
+				fetchErrors <- status.Errorf(codes.Internal, "failed to get blob %s from blob store: %v",
+					blobs[i].blobKey.Hex(), err)
+				return
+			}
+			if len(blobData) == 0 {
+				fetchErrors <- status.Errorf(codes.NotFound, "blob %s length is zero", blobs[i].blobKey.Hex())
+				return
+			}
+			data[i] = blobData
+			fetchErrors <- nil
+		}(i)
+	}
+	var fetchErr error
+	for range toEncode {
+		if err := <-fetchErrors; err != nil {
+			fetchErr = err
+		}
+	}
+	if fetchErr != nil {
+		return nil, fetchErr
+	}
+	s.metrics.ObserveLatency("batch_s3_download", time.Since(fetchStart))
+	s.logger.Info("fetched batch", "numBlobs", len(toEncode), "duration", time.Since(fetchStart).String())
+
+	// Encode the data, grouping the blobs by encoding parameters
+	encodingStart := time.Now()
+	groups := make(map[encoding.EncodingParams][]int)
+	paramsOrder := make([]encoding.EncodingParams, 0)
+	for _, i := range toEncode {
+		params := blobs[i].encodingParams
+		if _, ok := groups[params]; !ok {
+			paramsOrder = append(paramsOrder, params)
+		}
+		groups[params] = append(groups[params], i)
+	}
+
+	frames := make([][]*encoding.Frame, len(blobs))
+	for _, params := range paramsOrder {
+		group := groups[params]
+		groupData := make([][]byte, len(group))
+		for j, i := range group {
+			groupData[j] = data[i]
+		}
+
+		groupFrames, err := s.prover.GetFramesBatch(groupData, params)
+		if err != nil {
+			s.logger.Error("failed to encode frames", "encodingParams", params, "numBlobs", len(group), "error", err)
+			return nil, status.Errorf(codes.Internal, "encoding failed: %v", err)
+		}
+		for j, i := range group {
+			frames[i] = groupFrames[j]
+		}
+	}
+	s.metrics.ObserveLatency("batch_encoding", time.Since(encodingStart))
+	s.logger.Info("encoding batch frames", "numBlobs", len(toEncode), "numGroups", len(paramsOrder),
+		"duration", time.Since(encodingStart).String())
+
+	// Store the results of all blobs
+	if err := s.storeBatchResults(ctx, blobs, toEncode, frames, replies); err != nil {
+		return nil, err
+	}
+
+	return replies, nil
+}
+
+// storeBatchResults writes the proofs and coefficients of the encoded blobs of a batch to the chunk store in a single
+// pass, issuing the uploads of every blob together rather than storing the blobs one after another. The reply of each
+// stored blob is set in replies.
+func (s *EncoderServerV2) storeBatchResults(
+	ctx context.Context,
+	blobs []*blobToEncode,
+	toEncode []int,
+	frames [][]*encoding.Frame,
+	replies []*pb.EncodeBlobReply) error {
+
+	storeStart := time.Now()
+	group, groupCtx := errgroup.WithContext(ctx)
+	for _, i := range toEncode {
+		i := i
+		blobKey := blobs[i].blobKey
+		proofs, coeffs := extractProofsAndCoeffs(frames[i])
+
+		group.Go(func() error {
+			if err := s.chunkWriter.PutFrameProofs(groupCtx, blobKey, proofs); err != nil {
+				return status.Errorf(codes.Internal, "failed to upload chunk proofs of blob %s: %v", blobKey.Hex(), err)
+			}
+			return nil
+		})
+		group.Go(func() error {
+			fragmentInfo, err := s.chunkWriter.PutFrameCoefficients(groupCtx, blobKey, coeffs)
+			if err != nil {
+				return status.Errorf(codes.Internal, "failed to upload chunk coefficients of blob %s: %v",
+					blobKey.Hex(), err)
+			}
+			replies[i] = &pb.EncodeBlobReply{
+				FragmentInfo: &pb.FragmentInfo{
+					TotalChunkSizeBytes: fragmentInfo.TotalChunkSizeBytes,
+					FragmentSizeBytes:   fragmentInfo.FragmentSizeBytes,
+				},
+			}
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return err
+	}
+	s.metrics.ObserveLatency("batch_store", time.Since(storeStart))
+	s.logger.Info("stored batch", "numBlobs", len(toEncode), "duration", time.Since(storeStart).String())
+
+	return nil
+}
+
 // pushBacklogLimiter pushes a token to the backlog limiter and increments the queue stats accordingly.
 // If there is no capacity in the backlog limiter, an error is returned.
 func (s *EncoderServerV2) pushBacklogLimiter(blobSizeBytes int) error {
@@ -292,6 +502,39 @@ func (s *EncoderServerV2) validateAndParseRequest(req *pb.EncodeBlobRequest) (co
 	return blobKey, params, nil
 }
 
+func (s *EncoderServerV2) validateAndParseBatchRequest(req *pb.EncodeBlobsRequest) ([]*blobToEncode, error) {
+	if req == nil {
+		return nil, errors.New("request cannot be nil")
+	}
+	if len(req.GetBlobs()) == 0 {
+		return nil, errors.New("no blobs provided")
+	}
+	if len(req.GetBlobs()) > s.config.MaxBlobsPerEncodeBlobsRequest {
+		return nil, fmt.Errorf("too many blobs provided, max is %d", s.config.MaxBlobsPerEncodeBlobsRequest)
+	}
+
+	blobs := make([]*blobToEncode, len(req.GetBlobs()))
+	blobKeys := make(map[corev2.BlobKey]struct{}, len(req.GetBlobs()))
+	for i, blobReq := range req.GetBlobs() {
+		blobKey, encodingParams, err := s.validateAndParseRequest(blobReq)
+		if err != nil {
+			return nil, fmt.Errorf("invalid blob %d: %w", i, err)
+		}
+		if _, ok := blobKeys[blobKey]; ok {
+			return nil, fmt.Errorf("duplicate blob key %s", blobKey.Hex())
+		}
+		blobKeys[blobKey] = struct{}{}
+
+		blobs[i] = &blobToEncode{
+			blobKey:        blobKey,
+			encodingParams: encodingParams,
+			blobSize:       int(blobReq.GetBlobSize()),
+		}
+	}
+
+	return blobs, nil
+}
+
 func (s *EncoderServerV2) processAndStoreResults(ctx context.Context, blobKey corev2.BlobKey, frames []*encoding.Frame) (*pb.EncodeBlobReply, error) {
 	// Store proofs
 	storeStart := time.Now()
@@ -11,6 +11,7 @@ import (
 
 	pb "github.com/Layr-Labs/eigenda/api/grpc/encoder/v2"
 	"github.com/Layr-Labs/eigenda/common/aws/mock"
+	"github.com/Layr-Labs/eigenda/common/testutils/random"
 	"github.com/Layr-Labs/eigenda/core"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
@@ -26,6 +27,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/exp/rand"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var blobParams = &core.BlobVersionParameters{
@@ -198,6 +201,90 @@ func TestEncodeBlob(t *testing.T) {
 	})
 }
 
+func TestEncodeBlobs(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	c := createTestComponents(t)
+	server := c.encoderServer
+	testRandom := random.NewTestRandom()
+
+	// the first two blobs share their encoding parameters, and are encoded together
+	smallParams := &pb.EncodingParams{ChunkLength: 4, NumChunks: 16}
+	largeParams := &pb.EncodingParams{ChunkLength: 16, NumChunks: 16}
+	paramsList := []*pb.EncodingParams{smallParams, smallParams, largeParams}
+	blobSizes := []int{31, 16 * 31, 64 * 31}
+
+	requests := make([]*pb.EncodeBlobRequest, len(paramsList))
+	blobKeys := make([]corev2.BlobKey, len(paramsList))
+	for i, params := range paramsList {
+		blobHeader := createTestBlobHeader(t)
+		blobHeader.PaymentMetadata.Timestamp = int64(i + 1)
+		blobKey, err := blobHeader.BlobKey()
+		require.NoError(t, err)
+		blobKeys[i] = blobKey
+
+		data := codec.ConvertByPaddingEmptyByte(testRandom.Bytes(blobSizes[i]))
+		require.NoError(t, c.blobStore.StoreBlob(ctx, blobKey, data))
+
+		requests[i] = &pb.EncodeBlobRequest{
+			BlobKey:        blobKey[:],
+			EncodingParams: params,
+			BlobSize:       uint64(len(data)),
+		}
+	}
+
+	reply, err := server.EncodeBlobs(ctx, &pb.EncodeBlobsRequest{Blobs: requests})
+	require.NoError(t, err)
+	require.Len(t, reply.GetReplies(), len(requests))
+
+	uploadCalls := c.s3Client.Called["UploadObject"]
+	fragmentedUploadCalls := c.s3Client.Called["FragmentedUploadObject"]
+	for i, blobKey := range blobKeys {
+		require.True(t, c.chunkStoreWriter.ProofExists(ctx, blobKey))
+		binaryProofs, err := c.chunkStoreReader.GetBinaryChunkProofs(ctx, blobKey)
+		require.NoError(t, err)
+		require.Len(t, rs.DeserializeSplitFrameProofs(binaryProofs), int(paramsList[i].NumChunks))
+
+		fragmentInfo := &encoding.FragmentInfo{
+			TotalChunkSizeBytes: reply.GetReplies()[i].GetFragmentInfo().GetTotalChunkSizeBytes(),
+			FragmentSizeBytes:   reply.GetReplies()[i].GetFragmentInfo().GetFragmentSizeBytes(),
+		}
+		coefExist, fetchedFragmentInfo := c.chunkStoreWriter.CoefficientsExists(ctx, blobKey)
+		require.True(t, coefExist)
+		require.Equal(t, fragmentInfo, fetchedFragmentInfo)
+
+		elementCount, binaryCoefficients, err := c.chunkStoreReader.GetBinaryChunkCoefficients(ctx, blobKey, fragmentInfo)
+		require.NoError(t, err)
+		require.Equal(t, uint32(paramsList[i].ChunkLength), elementCount)
+		require.Len(t, rs.DeserializeSplitFrameCoeffs(elementCount, binaryCoefficients), int(paramsList[i].NumChunks))
+	}
+
+	// blobs that have already been encoded are not encoded again
+	secondReply, err := server.EncodeBlobs(ctx, &pb.EncodeBlobsRequest{Blobs: requests})
+	require.NoError(t, err)
+	require.Len(t, secondReply.GetReplies(), len(requests))
+	for i := range requests {
+		require.Equal(t, reply.GetReplies()[i].GetFragmentInfo().GetTotalChunkSizeBytes(),
+			secondReply.GetReplies()[i].GetFragmentInfo().GetTotalChunkSizeBytes())
+	}
+	require.Equal(t, uploadCalls, c.s3Client.Called["UploadObject"])
+	require.Equal(t, fragmentedUploadCalls, c.s3Client.Called["FragmentedUploadObject"])
+
+	// invalid batches are rejected
+	invalidRequests := []*pb.EncodeBlobsRequest{
+		nil,
+		{},
+		{Blobs: []*pb.EncodeBlobRequest{requests[0], requests[0]}},
+		{Blobs: []*pb.EncodeBlobRequest{requests[0], requests[1], requests[2], requests[0], requests[1]}},
+		{Blobs: []*pb.EncodeBlobRequest{requests[0], {BlobKey: blobKeys[1][:], BlobSize: requests[1].BlobSize}}},
+	}
+	for _, request := range invalidRequests {
+		_, err = server.EncodeBlobs(ctx, request)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+}
+
 // Helper function to create test blob header
 func createTestBlobHeader(t *testing.T) *corev2.BlobHeader {
 	t.Helper()
@@ -230,10 +317,11 @@ func createTestComponents(t *testing.T) *testComponents {
 	chunkStoreWriter := chunkstore.NewChunkWriter(logger, s3Client, s3BucketName, 512*1024)
 	chunkStoreReader := chunkstore.NewChunkReader(logger, s3Client, s3BucketName)
 	encoderServer := encoder.NewEncoderServerV2(encoder.ServerConfig{
-		GrpcPort:              "8080",
-		MaxConcurrentRequests: 10,
-		RequestQueueSize:      5,
-		PreventReencoding:     true,
+		GrpcPort:                      "8080",
+		MaxConcurrentRequests:         10,
+		RequestQueueSize:              5,
+		PreventReencoding:             true,
+		MaxBlobsPerEncodeBlobsRequest: 4,
 	}, blobStore, chunkStoreWriter, logger, prover, metrics, grpcMetrics)
 
 	return &testComponents{
@@ -7,6 +7,16 @@ import (
 	"github.com/Layr-Labs/eigenda/encoding"
 )
 
+// BlobToEncode is a blob of an EncodeBlobs request.
+type BlobToEncode struct {
+	BlobKey        corev2.BlobKey
+	EncodingParams encoding.EncodingParams
+	BlobSize       uint64
+}
+
 type EncoderClientV2 interface {
 	EncodeBlob(ctx context.Context, blobKey corev2.BlobKey, encodingParams encoding.EncodingParams, blobSize uint64) (*encoding.FragmentInfo, error)
+	// EncodeBlobs encodes several blobs in a single request, and returns the fragment info of each blob in the order
+	// of the blobs.
+	EncodeBlobs(ctx context.Context, blobs []*BlobToEncode) ([]*encoding.FragmentInfo, error)
 }
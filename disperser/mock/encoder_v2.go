@@ -27,3 +27,12 @@ func (m *MockEncoderClientV2) EncodeBlob(ctx context.Context, blobKey corev2.Blo
 	}
 	return fragmentInfo, args.Error(1)
 }
+
+func (m *MockEncoderClientV2) EncodeBlobs(ctx context.Context, blobs []*disperser.BlobToEncode) ([]*encoding.FragmentInfo, error) {
+	args := m.Called(blobs)
+	var fragmentInfos []*encoding.FragmentInfo
+	if args.Get(0) != nil {
+		fragmentInfos = args.Get(0).([]*encoding.FragmentInfo)
+	}
+	return fragmentInfos, args.Error(1)
+}
@@ -9,6 +9,12 @@ const (
 type ServerConfig struct {
 	GrpcPort    string
 	GrpcTimeout time.Duration
+	// BlobStatusPollInterval is how often the status of a blob is read while a client is subscribed to it
+	BlobStatusPollInterval time.Duration
+	// MaxBlobStatusSubscriptions is the maximum number of blob status subscriptions open at once
+	MaxBlobStatusSubscriptions int
+	// MaxBlobStatusSubscriptionLifetime is the maximum duration of a blob status subscription
+	MaxBlobStatusSubscriptionLifetime time.Duration
 
 	PprofHttpPort string
 	EnablePprof   bool
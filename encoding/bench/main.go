@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"reflect"
 	"runtime"
 	"runtime/pprof"
 	"time"
@@ -27,6 +28,17 @@ type BenchmarkResult struct {
 	VerifyResult bool          `json:"verify_result"`
 }
 
+type BatchBenchmarkResult struct {
+	NumChunks        uint64        `json:"num_chunks"`
+	ChunkLength      uint64        `json:"chunk_length"`
+	BlobLength       uint64        `json:"blob_length"`
+	BatchSize        uint64        `json:"batch_size"`
+	SingleEncodeTime time.Duration `json:"single_encode_time"`
+	BatchEncodeTime  time.Duration `json:"batch_encode_time"`
+	Speedup          float64       `json:"speedup"`
+	VerifyResult     bool          `json:"verify_result"`
+}
+
 type Config struct {
 	MinBlobLength uint64 `json:"min_blob_length"`
 	MaxBlobLength uint64 `json:"max_blob_length"`
@@ -37,6 +49,7 @@ type Config struct {
 	CPUProfile    string
 	MemProfile    string
 	EnableVerify  bool
+	BatchSize     uint64
 }
 
 func parseFlags() Config {
@@ -48,6 +61,7 @@ func parseFlags() Config {
 	flag.StringVar(&config.CPUProfile, "cpuprofile", "", "Write CPU profile to file")
 	flag.StringVar(&config.MemProfile, "memprofile", "", "Write memory profile to file")
 	flag.BoolVar(&config.EnableVerify, "enable-verify", true, "Verify blobs after encoding")
+	flag.Uint64Var(&config.BatchSize, "batch-size", 0, "If set, compare encoding this many blobs one at a time against encoding them as a batch")
 	flag.Parse()
 	return config
 }
@@ -95,7 +109,12 @@ func main() {
 		defer pprof.StopCPUProfile()
 	}
 
-	results := runBenchmark(p, &config)
+	var results interface{}
+	if config.BatchSize > 0 {
+		results = runBatchBenchmark(p, &config)
+	} else {
+		results = runBenchmark(p, &config)
+	}
 	if config.MemProfile != "" {
 		f, err := os.Create(config.MemProfile)
 		if err != nil {
@@ -212,3 +231,91 @@ func benchmarkEncodeAndVerify(p *prover.Prover, blobLength uint64, numChunks uin
 		VerifyResult: verifyResult,
 	}
 }
+
+func runBatchBenchmark(p *prover.Prover, config *Config) []BatchBenchmarkResult {
+	var results []BatchBenchmarkResult
+
+	// Fixed coding ratio of 8
+	codingRatio := uint64(8)
+
+	for blobLength := config.MinBlobLength; blobLength <= config.MaxBlobLength; blobLength *= 2 {
+		chunkLen := (blobLength * codingRatio) / config.NumChunks
+		if chunkLen < 1 {
+			continue // Skip invalid configurations
+		}
+		result := benchmarkSingleAndBatchEncode(p, blobLength, config.NumChunks, chunkLen, config.BatchSize, config.EnableVerify)
+		results = append(results, result)
+	}
+	return results
+}
+
+// benchmarkSingleAndBatchEncode encodes batchSize blobs of the same length one at a time, and then as a single batch.
+func benchmarkSingleAndBatchEncode(
+	p *prover.Prover,
+	blobLength uint64,
+	numChunks uint64,
+	chunkLen uint64,
+	batchSize uint64,
+	verifyResults bool,
+) BatchBenchmarkResult {
+	params := encoding.EncodingParams{
+		NumChunks:   numChunks,
+		ChunkLength: chunkLen,
+	}
+
+	fmt.Printf("Running batch benchmark: numChunks=%d, chunkLen=%d, blobLength=%d, batchSize=%d\n",
+		params.NumChunks, params.ChunkLength, blobLength, batchSize)
+
+	enc, err := p.GetKzgEncoder(params)
+	if err != nil {
+		log.Fatalf("Failed to get KZG encoder: %v", err)
+	}
+
+	// Create polynomials, each one different from the others
+	inputsFr := make([][]fr.Element, batchSize)
+	for b := uint64(0); b < batchSize; b++ {
+		inputsFr[b] = make([]fr.Element, blobLength)
+		for i := uint64(0); i < blobLength; i++ {
+			inputsFr[b][i].SetInt64(int64(b*blobLength + i + 1))
+		}
+	}
+
+	// Warm up the encoder, so that setup costs are not included in either measurement
+	_, _, err = enc.GetFrames(inputsFr[0])
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	singleFrames := make([][]encoding.Frame, batchSize)
+	start := time.Now()
+	for b, inputFr := range inputsFr {
+		singleFrames[b], _, err = enc.GetFrames(inputFr)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	singleDuration := time.Since(start)
+
+	start = time.Now()
+	batchFrames, err := enc.GetFramesBatch(inputsFr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	batchDuration := time.Since(start)
+
+	verifyResult := true
+	if verifyResults {
+		verifyResult = reflect.DeepEqual(singleFrames, batchFrames)
+	}
+
+	return BatchBenchmarkResult{
+		NumChunks:        numChunks,
+		ChunkLength:      chunkLen,
+		BlobLength:       blobLength,
+		BatchSize:        batchSize,
+		SingleEncodeTime: singleDuration,
+		BatchEncodeTime:  batchDuration,
+		Speedup:          float64(singleDuration) / float64(batchDuration),
+		VerifyResult:     verifyResult,
+	}
+}
@@ -19,6 +19,10 @@ type Prover interface {
 
 	GetFrames(data []byte, params EncodingParams) ([]*Frame, error)
 
+	// GetFramesBatch computes the frames of several blobs encoded with the same parameters, sharing the encoding
+	// setup and the proof computation between them. The frames of each blob are returned in the order of the blobs.
+	GetFramesBatch(blobs [][]byte, params EncodingParams) ([][]*Frame, error)
+
 	GetMultiFrameProofs(data []byte, params EncodingParams) ([]Proof, error)
 
 	GetSRSOrder() uint64
@@ -87,24 +87,135 @@ func (p *KzgMultiProofGnarkBackend) ComputeMultiFrameProof(polyFr []fr.Element,
 	return proofs, nil
 }
 
+// ComputeBatchMultiFrameProofs computes the multiframe proofs of several polynomials encoded with the same parameters.
+// The polynomials share the FFT settings and SRS tables of the backend, and the coefficient computations, MSMs and
+// FFTs of all polynomials are scheduled on a single pool of workers. This keeps all workers busy even when each
+// polynomial is too small to do so on its own. The proofs are returned in the order of the polynomials.
+func (p *KzgMultiProofGnarkBackend) ComputeBatchMultiFrameProofs(polysFr [][]fr.Element, numChunks, chunkLen, numWorker uint64) ([][]bn254.G1Affine, error) {
+	begin := time.Now()
+	dimE := numChunks
+	l := chunkLen
+	if numWorker == 0 {
+		numWorker = 1
+	}
+
+	// Pre-processing stage
+	coeffStores, err := p.computeBatchCoeffStore(polysFr, numWorker, l, dimE)
+	if err != nil {
+		return nil, fmt.Errorf("coefficient computation error: %v", err)
+	}
+	preprocessDone := time.Now()
+
+	// compute proofs by multi scalar multiplication. When there are enough MSMs to keep all workers busy, each MSM
+	// runs on a single task so that the workers don't compete with each other.
+	numMSMs := uint64(len(polysFr)) * dimE * 2
+	msmConfig := ecc.MultiExpConfig{}
+	if numMSMs >= numWorker {
+		msmConfig.NbTasks = 1
+	}
+	sumVecs := make([][]bn254.G1Affine, len(polysFr))
+	for b := range sumVecs {
+		sumVecs[b] = make([]bn254.G1Affine, dimE*2)
+	}
+	err = runBatchJobs(numMSMs, numWorker, func(job uint64) error {
+		b, k := job/(dimE*2), job%(dimE*2)
+		_, err := sumVecs[b][k].MultiExp(p.FFTPointsT[k], coeffStores[b][k], msmConfig)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("msm error: %v", err)
+	}
+	msmDone := time.Now()
+
+	// only 1 ifft is needed per polynomial, outputs are out of order - buttefly
+	proofs := make([][]bn254.G1Affine, len(polysFr))
+	err = runBatchJobs(uint64(len(polysFr)), numWorker, func(b uint64) error {
+		sumVecInv, err := p.Fs.FFTG1(sumVecs[b], true)
+		if err != nil {
+			return err
+		}
+		proofs[b], err = p.Fs.FFTG1(sumVecInv[:dimE], false)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fft error: %v", err)
+	}
+	fftDone := time.Now()
+
+	slog.Info("Batch multiproof Time Decomp",
+		"num_polys", len(polysFr),
+		"total", fftDone.Sub(begin),
+		"preproc", preprocessDone.Sub(begin),
+		"msm", msmDone.Sub(preprocessDone),
+		"fft", fftDone.Sub(msmDone),
+	)
+
+	return proofs, nil
+}
+
+// runBatchJobs runs the jobs 0 to numJobs-1 on numWorker workers, and returns the last error encountered
+func runBatchJobs(numJobs, numWorker uint64, job func(uint64) error) error {
+	jobChan := make(chan uint64, numWorker)
+	results := make(chan error, numWorker)
+
+	for w := uint64(0); w < numWorker; w++ {
+		go func() {
+			var lastErr error
+			for j := range jobChan {
+				if err := job(j); err != nil {
+					lastErr = err
+				}
+			}
+			results <- lastErr
+		}()
+	}
+
+	for j := uint64(0); j < numJobs; j++ {
+		jobChan <- j
+	}
+	close(jobChan)
+
+	var lastErr error
+	for w := uint64(0); w < numWorker; w++ {
+		if err := <-results; err != nil {
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
 // Helper function to handle coefficient computation
 func (p *KzgMultiProofGnarkBackend) computeCoeffStore(polyFr []fr.Element, numWorker, l, dimE uint64) ([][]fr.Element, error) {
-	jobChan := make(chan uint64, numWorker)
+	coeffStores, err := p.computeBatchCoeffStore([][]fr.Element{polyFr}, numWorker, l, dimE)
+	if err != nil {
+		return nil, err
+	}
+	return coeffStores[0], nil
+}
+
+// computeBatchCoeffStore computes the coefficient stores of several polynomials with a single pool of workers
+func (p *KzgMultiProofGnarkBackend) computeBatchCoeffStore(polysFr [][]fr.Element, numWorker, l, dimE uint64) ([][][]fr.Element, error) {
+	jobChan := make(chan coeffJob, numWorker)
 	results := make(chan WorkerResult, numWorker)
 
-	coeffStore := make([][]fr.Element, dimE*2)
-	for i := range coeffStore {
-		coeffStore[i] = make([]fr.Element, l)
+	coeffStores := make([][][]fr.Element, len(polysFr))
+	for b := range coeffStores {
+		coeffStores[b] = make([][]fr.Element, dimE*2)
+		for i := range coeffStores[b] {
+			coeffStores[b][i] = make([]fr.Element, l)
+		}
 	}
 
 	// Start workers
 	for w := uint64(0); w < numWorker; w++ {
-		go p.proofWorker(polyFr, jobChan, l, dimE, coeffStore, results)
+		go p.proofWorker(polysFr, jobChan, l, dimE, coeffStores, results)
 	}
 
 	// Send jobs
-	for j := uint64(0); j < l; j++ {
-		jobChan <- j
+	for b := range polysFr {
+		for j := uint64(0); j < l; j++ {
+			jobChan <- coeffJob{poly: b, j: j}
+		}
 	}
 	close(jobChan)
 
@@ -120,27 +231,33 @@ func (p *KzgMultiProofGnarkBackend) computeCoeffStore(polyFr []fr.Element, numWo
 		return nil, fmt.Errorf("proof worker error: %v", lastErr)
 	}
 
-	return coeffStore, nil
+	return coeffStores, nil
+}
+
+// coeffJob is the computation of the j-th column of the coefficient store of a polynomial
+type coeffJob struct {
+	poly int
+	j    uint64
 }
 
 func (p *KzgMultiProofGnarkBackend) proofWorker(
-	polyFr []fr.Element,
-	jobChan <-chan uint64,
+	polysFr [][]fr.Element,
+	jobChan <-chan coeffJob,
 	l uint64,
 	dimE uint64,
-	coeffStore [][]fr.Element,
+	coeffStores [][][]fr.Element,
 	results chan<- WorkerResult,
 ) {
 
-	for j := range jobChan {
-		coeffs, err := p.GetSlicesCoeff(polyFr, dimE, j, l)
+	for job := range jobChan {
+		coeffs, err := p.GetSlicesCoeff(polysFr[job.poly], dimE, job.j, l)
 		if err != nil {
 			results <- WorkerResult{
 				err: err,
 			}
 		} else {
 			for i := 0; i < len(coeffs); i++ {
-				coeffStore[i][j] = coeffs[i]
+				coeffStores[job.poly][i][job.j] = coeffs[i]
 			}
 		}
 	}
@@ -56,6 +56,12 @@ type proofsResult struct {
 	Err      error
 }
 
+type batchProofsResult struct {
+	Proofs   [][]bn254.G1Affine
+	Duration time.Duration
+	Err      error
+}
+
 type commitmentsResult struct {
 	commitment       *bn254.G1Affine
 	lengthCommitment *bn254.G2Affine
@@ -256,6 +262,115 @@ func (g *ParametrizedProver) GetFrames(inputFr []fr.Element) ([]encoding.Frame,
 	return kzgFrames, rsResult.Indices, nil
 }
 
+// GetFramesBatch computes the frames of several blobs encoded with the parameters of the prover. The RS encodings of
+// the blobs run concurrently, and the multiproofs are computed together by backends that support it. The frames of
+// each blob are returned in the order of the blobs.
+func (g *ParametrizedProver) GetFramesBatch(inputsFr [][]fr.Element) ([][]encoding.Frame, error) {
+	for _, inputFr := range inputsFr {
+		if err := g.validateInput(inputFr); err != nil {
+			return nil, err
+		}
+	}
+	if len(inputsFr) == 0 {
+		return [][]encoding.Frame{}, nil
+	}
+
+	encodeStart := time.Now()
+
+	proofChan := make(chan batchProofsResult, 1)
+	rsChans := make([]chan rsEncodeResult, len(inputsFr))
+
+	// inputFr is untouched
+	// compute chunks
+	for i, inputFr := range inputsFr {
+		rsChans[i] = make(chan rsEncodeResult, 1)
+		go func(inputFr []fr.Element, rsChan chan<- rsEncodeResult) {
+			start := time.Now()
+
+			frames, indices, err := g.Encoder.Encode(inputFr, g.EncodingParams)
+			rsChan <- rsEncodeResult{
+				Frames:   frames,
+				Indices:  indices,
+				Err:      err,
+				Duration: time.Since(start),
+			}
+		}(inputFr, rsChans[i])
+	}
+
+	go func() {
+		start := time.Now()
+		// compute proofs
+		paddedCoeffs := make([][]fr.Element, len(inputsFr))
+		for i, inputFr := range inputsFr {
+			// polyCoeffs has less points than paddedCoeffs in general due to erasure redundancy
+			paddedCoeffs[i] = make([]fr.Element, g.NumEvaluations())
+			copy(paddedCoeffs[i], inputFr)
+		}
+
+		proofs, err := g.computeBatchMultiFrameProofs(paddedCoeffs)
+		proofChan <- batchProofsResult{
+			Proofs:   proofs,
+			Err:      err,
+			Duration: time.Since(start),
+		}
+	}()
+
+	rsResults := make([]rsEncodeResult, len(inputsFr))
+	var rsErr error
+	for i, rsChan := range rsChans {
+		rsResults[i] = <-rsChan
+		if rsResults[i].Err != nil {
+			rsErr = multierror.Append(rsErr, rsResults[i].Err)
+		}
+	}
+	proofsResult := <-proofChan
+
+	if rsErr != nil || proofsResult.Err != nil {
+		return nil, multierror.Append(rsErr, proofsResult.Err)
+	}
+
+	totalProcessingTime := time.Since(encodeStart)
+	slog.Info("Batch frame process details",
+		"Num_blobs", len(inputsFr),
+		"Num_chunks", g.NumChunks,
+		"Chunk_length", g.ChunkLength,
+		"Total_duration", totalProcessingTime,
+		"multiProof_duration", proofsResult.Duration,
+	)
+
+	// assemble frames
+	kzgFrames := make([][]encoding.Frame, len(inputsFr))
+	for b, rsResult := range rsResults {
+		kzgFrames[b] = make([]encoding.Frame, len(rsResult.Frames))
+		for i, index := range rsResult.Indices {
+			kzgFrames[b][i] = encoding.Frame{
+				Proof:  proofsResult.Proofs[b][index],
+				Coeffs: rsResult.Frames[i],
+			}
+		}
+	}
+
+	return kzgFrames, nil
+}
+
+// computeBatchMultiFrameProofs computes the multiproofs of several padded polynomials, together if the backend
+// supports it and one at a time otherwise.
+func (g *ParametrizedProver) computeBatchMultiFrameProofs(paddedCoeffs [][]fr.Element) ([][]bn254.G1Affine, error) {
+	if batchBackend, ok := g.KzgMultiProofBackend.(KzgBatchMultiProofsBackend); ok {
+		return batchBackend.ComputeBatchMultiFrameProofs(paddedCoeffs, g.NumChunks, g.ChunkLength, g.KzgConfig.NumWorker)
+	}
+
+	proofs := make([][]bn254.G1Affine, len(paddedCoeffs))
+	for i, coeffs := range paddedCoeffs {
+		var err error
+		proofs[i], err = g.KzgMultiProofBackend.ComputeMultiFrameProof(coeffs, g.NumChunks, g.ChunkLength, g.KzgConfig.NumWorker)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return proofs, nil
+}
+
 func (g *ParametrizedProver) GetMultiFrameProofs(inputFr []fr.Element) ([]encoding.Proof, error) {
 	if err := g.validateInput(inputFr); err != nil {
 		return nil, err
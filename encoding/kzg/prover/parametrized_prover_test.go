@@ -9,6 +9,7 @@ import (
 	"github.com/Layr-Labs/eigenda/encoding/kzg/prover"
 	"github.com/Layr-Labs/eigenda/encoding/kzg/verifier"
 	"github.com/Layr-Labs/eigenda/encoding/rs"
+	"github.com/Layr-Labs/eigenda/encoding/utils/codec"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -47,3 +48,31 @@ func TestProveAllCosetThreads(t *testing.T) {
 		assert.Nil(t, verifier.VerifyFrame(&f, enc.Ks, commit, &lc, &g2Atn), "Proof %v failed\n", i)
 	}
 }
+
+func TestGetFramesBatch(t *testing.T) {
+	group, err := prover.NewProver(kzgConfig, nil)
+	require.NoError(t, err)
+
+	params := encoding.ParamsFromSysPar(numSys, numPar, uint64(len(gettysburgAddressBytes)))
+	blobs := [][]byte{
+		gettysburgAddressBytes,
+		// cut at a symbol boundary, so that padding the blob doesn't overwrite the rest of gettysburgAddressBytes
+		gettysburgAddressBytes[:len(gettysburgAddressBytes)/2/encoding.BYTES_PER_SYMBOL*encoding.BYTES_PER_SYMBOL],
+		codec.ConvertByPaddingEmptyByte([]byte("a short blob")),
+	}
+
+	batchFrames, err := group.GetFramesBatch(blobs, params)
+	require.NoError(t, err)
+	require.Len(t, batchFrames, len(blobs))
+
+	// the frames of each blob are the same as when the blob is encoded on its own
+	for i, blob := range blobs {
+		frames, err := group.GetFrames(blob, params)
+		require.NoError(t, err)
+		require.Equal(t, frames, batchFrames[i])
+	}
+
+	batchFrames, err = group.GetFramesBatch(nil, params)
+	require.NoError(t, err)
+	require.Empty(t, batchFrames)
+}
@@ -10,6 +10,12 @@ type KzgMultiProofsBackend interface {
 	ComputeMultiFrameProof(blobFr []fr.Element, numChunks, chunkLen, numWorker uint64) ([]bn254.G1Affine, error)
 }
 
+// KzgBatchMultiProofsBackend is a backend capable of computing the KZG multiproofs of several blobs encoded with the
+// same parameters together. Backends that don't implement it compute the multiproofs of a batch one blob at a time.
+type KzgBatchMultiProofsBackend interface {
+	ComputeBatchMultiFrameProofs(blobsFr [][]fr.Element, numChunks, chunkLen, numWorker uint64) ([][]bn254.G1Affine, error)
+}
+
 // CommitmentDevice represents a backend capable of computing various KZG commitments.
 type KzgCommitmentsBackend interface {
 	ComputeCommitment(coeffs []fr.Element) (*bn254.G1Affine, error)
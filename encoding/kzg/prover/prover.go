@@ -17,6 +17,7 @@ import (
 	gnarkprover "github.com/Layr-Labs/eigenda/encoding/kzg/prover/gnark"
 	"github.com/Layr-Labs/eigenda/encoding/rs"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
 	_ "go.uber.org/automaxprocs"
 )
 
@@ -236,6 +237,40 @@ func (e *Prover) GetFrames(data []byte, params encoding.EncodingParams) ([]*enco
 	return chunks, nil
 }
 
+func (e *Prover) GetFramesBatch(blobs [][]byte, params encoding.EncodingParams) ([][]*encoding.Frame, error) {
+	inputsFr := make([][]fr.Element, len(blobs))
+	for i, data := range blobs {
+		symbols, err := rs.ToFrArray(data)
+		if err != nil {
+			return nil, err
+		}
+		inputsFr[i] = symbols
+	}
+
+	enc, err := e.GetKzgEncoder(params)
+	if err != nil {
+		return nil, err
+	}
+
+	kzgFrames, err := enc.GetFramesBatch(inputsFr)
+	if err != nil {
+		return nil, err
+	}
+
+	chunks := make([][]*encoding.Frame, len(kzgFrames))
+	for b, blobFrames := range kzgFrames {
+		chunks[b] = make([]*encoding.Frame, len(blobFrames))
+		for ind, frame := range blobFrames {
+			chunks[b][ind] = &encoding.Frame{
+				Coeffs: frame.Coeffs,
+				Proof:  frame.Proof,
+			}
+		}
+	}
+
+	return chunks, nil
+}
+
 // GetCommitmentsForPaddedLength takes in a byte slice representing a list of bn254
 // field elements (32 bytes each, except potentially the last element),
 // pads the (potentially incomplete) last element with zeroes, and returns the commitments for the padded list.
@@ -35,6 +35,12 @@ func (e *MockEncoder) GetFrames(data []byte, params encoding.EncodingParams) ([]
 	return args.Get(0).([]*encoding.Frame), args.Error(1)
 }
 
+func (e *MockEncoder) GetFramesBatch(blobs [][]byte, params encoding.EncodingParams) ([][]*encoding.Frame, error) {
+	args := e.Called(blobs, params)
+	time.Sleep(e.Delay)
+	return args.Get(0).([][]*encoding.Frame), args.Error(1)
+}
+
 func (e *MockEncoder) GetMultiFrameProofs(data []byte, params encoding.EncodingParams) ([]encoding.Proof, error) {
 	args := e.Called(data, params)
 	time.Sleep(e.Delay)
@@ -61,6 +61,166 @@ func (c *Frame) DeserializeGnark(data []byte) (*Frame, error) {
 	return &f, nil
 }
 
+// packedSymbolBits is the number of bits each symbol takes in the compressed gnark format. Symbols are elements of
+// the bn254 scalar field, whose modulus is less than 2^254, so the two most significant bits of a serialized symbol
+// are always zero and don't need to be sent or stored.
+const packedSymbolBits = 254
+
+// GnarkCompressedChunkSize returns the size in bytes of a chunk with chunkLen symbols, serialized with
+// SerializeGnarkCompressed.
+func GnarkCompressedChunkSize(chunkLen int) int {
+	return bn254.SizeOfG1AffineCompressed + (chunkLen*packedSymbolBits+7)/8
+}
+
+// SerializeGnarkCompressed serializes the frame in the compressed gnark format:
+// <32 bytes proof><coeff 1: 254 bits><coeff 2: 254 bits>...<coeff n: 254 bits><zero padding to a whole byte>
+//
+// The proof is a compressed G1 point, as in SerializeGnark. The coefficients are serialized big endian as in
+// SerializeGnark, with the two leading zero bits of each coefficient dropped. The number of coefficients is implied
+// by the size of the chunk.
+func (c *Frame) SerializeGnarkCompressed() ([]byte, error) {
+	coded := make([]byte, GnarkCompressedChunkSize(len(c.Coeffs)))
+	proofBytes := c.Proof.Bytes()
+	copy(coded, proofBytes[:])
+	packed := coded[bn254.SizeOfG1AffineCompressed:]
+	for i := range c.Coeffs {
+		coeffBytes := c.Coeffs[i].Bytes()
+		err := packSymbol(packed, i, coeffBytes[:])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return coded, nil
+}
+
+// DeserializeGnarkCompressed is the inverse of SerializeGnarkCompressed.
+func (c *Frame) DeserializeGnarkCompressed(data []byte) (*Frame, error) {
+	chunkLen, err := compressedChunkLen(data)
+	if err != nil {
+		return nil, err
+	}
+	var f Frame
+	err = f.Proof.Unmarshal(data[:bn254.SizeOfG1AffineCompressed])
+	if err != nil {
+		return nil, err
+	}
+	packed := data[bn254.SizeOfG1AffineCompressed:]
+	f.Coeffs = make([]Symbol, chunkLen)
+	var coeffBytes [BYTES_PER_SYMBOL]byte
+	for i := range f.Coeffs {
+		unpackSymbol(packed, i, coeffBytes[:])
+		f.Coeffs[i].Unmarshal(coeffBytes[:])
+	}
+	return &f, nil
+}
+
+// CompressGnarkChunk converts a chunk serialized with SerializeGnark into the format of SerializeGnarkCompressed,
+// without deserializing the proof and coefficients. The compressed chunk is written to the first
+// GnarkCompressedChunkSize() bytes of target.
+func CompressGnarkChunk(chunk []byte, target []byte) error {
+	if len(chunk) <= bn254.SizeOfG1AffineCompressed || (len(chunk)-bn254.SizeOfG1AffineCompressed)%BYTES_PER_SYMBOL != 0 {
+		return fmt.Errorf("invalid gnark chunk length: %d", len(chunk))
+	}
+	chunkLen := (len(chunk) - bn254.SizeOfG1AffineCompressed) / BYTES_PER_SYMBOL
+	size := GnarkCompressedChunkSize(chunkLen)
+	if len(target) < size {
+		return fmt.Errorf("target is too small: %d bytes given, %d bytes needed", len(target), size)
+	}
+	target = target[:size]
+	copy(target, chunk[:bn254.SizeOfG1AffineCompressed])
+	packed := target[bn254.SizeOfG1AffineCompressed:]
+	clear(packed)
+	coeffs := chunk[bn254.SizeOfG1AffineCompressed:]
+	for i := 0; i < chunkLen; i++ {
+		err := packSymbol(packed, i, coeffs[i*BYTES_PER_SYMBOL:(i+1)*BYTES_PER_SYMBOL])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecompressGnarkChunk converts a chunk serialized with SerializeGnarkCompressed into the format of SerializeGnark,
+// without deserializing the proof and coefficients.
+func DecompressGnarkChunk(chunk []byte) ([]byte, error) {
+	chunkLen, err := compressedChunkLen(chunk)
+	if err != nil {
+		return nil, err
+	}
+	decompressed := make([]byte, bn254.SizeOfG1AffineCompressed+BYTES_PER_SYMBOL*chunkLen)
+	copy(decompressed, chunk[:bn254.SizeOfG1AffineCompressed])
+	packed := chunk[bn254.SizeOfG1AffineCompressed:]
+	coeffs := decompressed[bn254.SizeOfG1AffineCompressed:]
+	for i := 0; i < chunkLen; i++ {
+		unpackSymbol(packed, i, coeffs[i*BYTES_PER_SYMBOL:(i+1)*BYTES_PER_SYMBOL])
+	}
+	return decompressed, nil
+}
+
+// compressedChunkLen returns the number of symbols in a chunk serialized with SerializeGnarkCompressed, and checks
+// that the chunk is well formed.
+func compressedChunkLen(chunk []byte) (int, error) {
+	if len(chunk) <= bn254.SizeOfG1AffineCompressed {
+		return 0, fmt.Errorf("chunk length must be at least %d: %d given", bn254.SizeOfG1AffineCompressed, len(chunk))
+	}
+	packedBits := 8 * (len(chunk) - bn254.SizeOfG1AffineCompressed)
+	// The padding is less than a byte, so the number of symbols is unambiguous
+	chunkLen := packedBits / packedSymbolBits
+	if chunkLen == 0 || GnarkCompressedChunkSize(chunkLen) != len(chunk) {
+		return 0, errors.New("invalid chunk length")
+	}
+	paddingBits := packedBits - chunkLen*packedSymbolBits
+	if chunk[len(chunk)-1]&(byte(1)<<paddingBits-1) != 0 {
+		return 0, errors.New("chunk padding is not zero")
+	}
+	return chunkLen, nil
+}
+
+// packSymbol writes the 254 low bits of a 32 byte big endian symbol at the position of the index-th symbol in packed.
+// The bits of packed that the symbol is written to must be zero.
+func packSymbol(packed []byte, index int, symbol []byte) error {
+	if symbol[0]&0xc0 != 0 {
+		return fmt.Errorf("symbol %d is larger than %d bits", index, packedSymbolBits)
+	}
+	bitOffset := index * packedSymbolBits
+	start := bitOffset / 8
+	shift := uint(bitOffset % 8)
+	for i := 0; i < BYTES_PER_SYMBOL; i++ {
+		// Drop the two leading zero bits of the symbol
+		b := symbol[i] << 2
+		if i+1 < BYTES_PER_SYMBOL {
+			b |= symbol[i+1] >> 6
+		}
+		packed[start+i] |= b >> shift
+		if shift > 0 && start+i+1 < len(packed) {
+			packed[start+i+1] |= b << (8 - shift)
+		}
+	}
+	return nil
+}
+
+// unpackSymbol is the inverse of packSymbol. It writes the index-th symbol of packed to symbol, as a 32 byte big
+// endian value.
+func unpackSymbol(packed []byte, index int, symbol []byte) {
+	bitOffset := index * packedSymbolBits
+	start := bitOffset / 8
+	shift := uint(bitOffset % 8)
+	var previous byte
+	for i := 0; i < BYTES_PER_SYMBOL; i++ {
+		b := packed[start+i] << shift
+		if shift > 0 && start+i+1 < len(packed) {
+			b |= packed[start+i+1] >> (8 - shift)
+		}
+		if i == BYTES_PER_SYMBOL-1 {
+			// The last two bits belong to the next symbol
+			b &= 0xfc
+		}
+		// Restore the two leading zero bits of the symbol
+		symbol[i] = b>>2 | previous<<6
+		previous = b
+	}
+}
+
 func (f *Frame) Encode() ([]byte, error) {
 	var buf bytes.Buffer
 	enc := gob.NewEncoder(&buf)
@@ -51,6 +51,75 @@ func TestSerDeserGnark(t *testing.T) {
 	assert.ErrorContains(t, err, "chunk length must be at least")
 }
 
+func TestSerDeserGnarkCompressed(t *testing.T) {
+	var XCoord, YCoord fp.Element
+	_, err := XCoord.SetString("21661178944771197726808973281966770251114553549453983978976194544185382599016")
+	assert.NoError(t, err)
+	_, err = YCoord.SetString("9207254729396071334325696286939045899948985698134704137261649190717970615186")
+	assert.NoError(t, err)
+
+	for _, numCoeffs := range []int{1, 2, 3, 4, 5, 16, 64} {
+		var f encoding.Frame
+		f.Proof = encoding.Proof{
+			X: XCoord,
+			Y: YCoord,
+		}
+		for i := 0; i < numCoeffs; i++ {
+			var coeff fr.Element
+			_, err = coeff.SetRandom()
+			assert.NoError(t, err)
+			f.Coeffs = append(f.Coeffs, coeff)
+		}
+		// the largest coefficient uses all 254 bits
+		f.Coeffs[numCoeffs-1].SetInt64(-1)
+
+		compressed, err := f.SerializeGnarkCompressed()
+		assert.NoError(t, err)
+		assert.Equal(t, encoding.GnarkCompressedChunkSize(numCoeffs), len(compressed))
+		assert.Equal(t, 32+(254*numCoeffs+7)/8, len(compressed))
+
+		c, err := new(encoding.Frame).DeserializeGnarkCompressed(compressed)
+		assert.NoError(t, err)
+		assert.True(t, f.Proof.Equal(&c.Proof))
+		assert.Equal(t, len(f.Coeffs), len(c.Coeffs))
+		for i := 0; i < len(f.Coeffs); i++ {
+			assert.True(t, f.Coeffs[i].Equal(&c.Coeffs[i]))
+		}
+
+		// converting between the gnark formats without deserializing gives the same bytes
+		gnark, err := f.SerializeGnark()
+		assert.NoError(t, err)
+		// two bits are saved per coefficient
+		assert.Equal(t, len(gnark)-(2*numCoeffs)/8, len(compressed))
+		converted := make([]byte, len(compressed))
+		err = encoding.CompressGnarkChunk(gnark, converted)
+		assert.NoError(t, err)
+		assert.Equal(t, compressed, converted)
+		decompressed, err := encoding.DecompressGnarkChunk(compressed)
+		assert.NoError(t, err)
+		assert.Equal(t, gnark, decompressed)
+	}
+
+	// invalid length should return error
+	_, err = new(encoding.Frame).DeserializeGnarkCompressed([]byte{1, 2, 3})
+	assert.ErrorContains(t, err, "chunk length must be at least")
+	_, err = new(encoding.Frame).DeserializeGnarkCompressed(make([]byte, 32+30))
+	assert.ErrorContains(t, err, "invalid chunk length")
+
+	// padding bits must be zero
+	var f encoding.Frame
+	f.Proof = encoding.Proof{
+		X: XCoord,
+		Y: YCoord,
+	}
+	f.Coeffs = []fr.Element{fr.NewElement(1)}
+	compressed, err := f.SerializeGnarkCompressed()
+	assert.NoError(t, err)
+	compressed[len(compressed)-1] |= 1
+	_, err = new(encoding.Frame).DeserializeGnarkCompressed(compressed)
+	assert.ErrorContains(t, err, "padding")
+}
+
 func createFrames(b *testing.B, numFrames int) []encoding.Frame {
 	var XCoord, YCoord fp.Element
 	_, err := XCoord.SetString("21661178944771197726808973281966770251114553549453983978976194544185382599016")
@@ -121,3 +190,28 @@ func BenchmarkFrameGnarkDeserialization(b *testing.B) {
 		_, _ = new(encoding.Frame).DeserializeGnark(bytes[i%numSamples])
 	}
 }
+
+func BenchmarkFrameGnarkCompressedSerialization(b *testing.B) {
+	numSamples := 64
+	frames := createFrames(b, numSamples)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = frames[i%numSamples].SerializeGnarkCompressed()
+	}
+}
+
+func BenchmarkFrameGnarkCompressedDeserialization(b *testing.B) {
+	numSamples := 64
+	frames := createFrames(b, numSamples)
+	bytes := make([][]byte, numSamples)
+	for n := 0; n < numSamples; n++ {
+		compressed, _ := frames[n].SerializeGnarkCompressed()
+		bytes[n] = compressed
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = new(encoding.Frame).DeserializeGnarkCompressed(bytes[i%numSamples])
+	}
+}
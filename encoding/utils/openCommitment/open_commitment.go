@@ -4,7 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"math/bits"
 
+	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
@@ -60,6 +62,193 @@ func ComputeKzgProof(
 	return &proof, &valueFr, nil
 }
 
+// ComputeMultiPointKzgProof computes a KZG multi-point opening proof of a polynomial in coefficient form at several
+// distinct points, and returns the proof along with the values of the polynomial at the points. This is the form in
+// which EigenDA commits to blobs, so the proof verifies against the blob commitment with VerifyMultiPointKzgProof.
+//
+// The polynomial p is divided by the vanishing polynomial Z of the points, p = q*Z + r. The remainder r interpolates
+// the values of p at the points, and the proof is the commitment to the quotient q = (p - r) / Z. Opening k points
+// costs a single pass over the coefficients of p for each point and a single multi-scalar multiplication over the
+// SRS, regardless of k.
+func ComputeMultiPointKzgProof(
+	coeffs []fr.Element,
+	zs []fr.Element,
+	G1srs []bn254.G1Affine,
+) (*bn254.G1Affine, []fr.Element, error) {
+	if len(coeffs) == 0 {
+		return nil, nil, fmt.Errorf("cannot open an empty polynomial")
+	}
+	vanishingPoly, err := vanishingPolynomial(zs)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(G1srs) < len(coeffs)-len(zs) {
+		return nil, nil, fmt.Errorf("insufficient SRS: have %d points, need %d", len(G1srs), len(coeffs)-len(zs))
+	}
+
+	// long division by the monic vanishing polynomial, the remainder is left in the low coefficients
+	remainder := make([]fr.Element, len(coeffs))
+	copy(remainder, coeffs)
+	var quotientPoly []fr.Element
+	if len(coeffs) > len(zs) {
+		quotientPoly = make([]fr.Element, len(coeffs)-len(zs))
+	}
+	var term fr.Element
+	for i := len(quotientPoly) - 1; i >= 0; i-- {
+		quotientPoly[i].Set(&remainder[i+len(zs)])
+		for j := 0; j < len(zs); j++ {
+			term.Mul(&quotientPoly[i], &vanishingPoly[j])
+			remainder[i+j].Sub(&remainder[i+j], &term)
+		}
+	}
+	remainder = remainder[:min(len(coeffs), len(zs))]
+
+	// p(z) = r(z) at each of the points, since Z(z) = 0
+	values := make([]fr.Element, len(zs))
+	for i := range zs {
+		values[i] = evaluatePolynomial(remainder, zs[i])
+	}
+
+	var proof bn254.G1Affine
+	if len(quotientPoly) == 0 {
+		// a polynomial of degree less than the number of points has a zero quotient, whose commitment is the point
+		// at infinity
+		return &proof, values, nil
+	}
+	_, err = proof.MultiExp(G1srs[:len(quotientPoly)], quotientPoly, ecc.MultiExpConfig{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &proof, values, nil
+}
+
+// VerifyMultiPointKzgProof verifies a proof computed by ComputeMultiPointKzgProof, i.e. that the polynomial committed
+// to by commitment evaluates to valuesFr[i] at zsFr[i] for all i. With I the polynomial of degree less than k that
+// interpolates the values and Z the vanishing polynomial of the k points, the check is
+// e(C - [I(tau)]G1, G2) = e(proof, [Z(tau)]G2). This requires the first k powers of tau in G1, and the first k+1
+// powers of tau in G2.
+func VerifyMultiPointKzgProof(
+	commitment, proof bn254.G1Affine,
+	valuesFr, zsFr []fr.Element,
+	G1srs []bn254.G1Affine,
+	G2srs []bn254.G2Affine,
+) error {
+	if len(valuesFr) != len(zsFr) {
+		return fmt.Errorf("inconsistent number of values (%d) and points (%d)", len(valuesFr), len(zsFr))
+	}
+	vanishingPoly, err := vanishingPolynomial(zsFr)
+	if err != nil {
+		return err
+	}
+	if len(G1srs) < len(zsFr) {
+		return fmt.Errorf("insufficient G1 SRS: have %d points, need %d", len(G1srs), len(zsFr))
+	}
+	if len(G2srs) < len(vanishingPoly) {
+		return fmt.Errorf("insufficient G2 SRS: have %d points, need %d", len(G2srs), len(vanishingPoly))
+	}
+
+	interpolationPoly := interpolatePolynomial(vanishingPoly, zsFr, valuesFr)
+
+	var interpolationCommitment bn254.G1Affine
+	_, err = interpolationCommitment.MultiExp(G1srs[:len(interpolationPoly)], interpolationPoly, ecc.MultiExpConfig{})
+	if err != nil {
+		return err
+	}
+	var commitMinusInterpolation bn254.G1Affine
+	commitMinusInterpolation.Sub(&commitment, &interpolationCommitment)
+
+	var vanishingCommitment bn254.G2Affine
+	_, err = vanishingCommitment.MultiExp(G2srs[:len(vanishingPoly)], vanishingPoly, ecc.MultiExpConfig{})
+	if err != nil {
+		return err
+	}
+
+	return PairingsVerify(&commitMinusInterpolation, &G2srs[0], &proof, &vanishingCommitment)
+}
+
+// vanishingPolynomial returns the coefficients of the monic polynomial prod (X - z_i), which has degree len(zs).
+// Returns an error if there are no points, or if the points are not distinct.
+func vanishingPolynomial(zs []fr.Element) ([]fr.Element, error) {
+	if len(zs) == 0 {
+		return nil, fmt.Errorf("no points to open")
+	}
+
+	seen := make(map[fr.Element]struct{}, len(zs))
+	poly := make([]fr.Element, 1, len(zs)+1)
+	poly[0].SetOne()
+	var term fr.Element
+	for i := range zs {
+		if _, ok := seen[zs[i]]; ok {
+			return nil, fmt.Errorf("point %d is a duplicate", i)
+		}
+		seen[zs[i]] = struct{}{}
+
+		// multiply by (X - z)
+		poly = append(poly, fr.Element{})
+		for j := len(poly) - 1; j > 0; j-- {
+			term.Mul(&poly[j], &zs[i])
+			poly[j].Sub(&poly[j-1], &term)
+		}
+		poly[0].Mul(&poly[0], &zs[i])
+		poly[0].Neg(&poly[0])
+	}
+
+	return poly, nil
+}
+
+// interpolatePolynomial returns the coefficients of the polynomial of degree less than len(zs) that evaluates to
+// values[i] at zs[i], given the vanishing polynomial of the (distinct) points.
+func interpolatePolynomial(vanishingPoly []fr.Element, zs []fr.Element, values []fr.Element) []fr.Element {
+	poly := make([]fr.Element, len(zs))
+	basis := make([]fr.Element, len(zs))
+	var denominator, scale, term fr.Element
+	for i := range zs {
+		// the Lagrange basis polynomial of z_i is Z(X) / (X - z_i) / prod_{j != i} (z_i - z_j), the numerator is
+		// computed by synthetic division
+		basis[len(zs)-1].Set(&vanishingPoly[len(zs)])
+		for j := len(zs) - 1; j > 0; j-- {
+			term.Mul(&basis[j], &zs[i])
+			basis[j-1].Add(&vanishingPoly[j], &term)
+		}
+		denominator = evaluatePolynomial(basis, zs[i])
+
+		scale.Div(&values[i], &denominator)
+		for j := range basis {
+			term.Mul(&basis[j], &scale)
+			poly[j].Add(&poly[j], &term)
+		}
+	}
+	return poly
+}
+
+// evaluatePolynomial evaluates a polynomial in coefficient form at z with Horner's method.
+func evaluatePolynomial(coeffs []fr.Element, z fr.Element) fr.Element {
+	var value fr.Element
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		value.Mul(&value, &z)
+		value.Add(&value, &coeffs[i])
+	}
+	return value
+}
+
+// SymbolEvaluationPoint returns the point z at which the polynomial of a blob evaluates to the symbol at the given
+// index, i.e. omega^index where omega is the primitive root of unity of order blobLength. blobLength is the length of
+// the blob in symbols, and must be a power of 2.
+func SymbolEvaluationPoint(index uint32, blobLength uint32) (fr.Element, error) {
+	var zFr fr.Element
+	if blobLength == 0 || !encoding.IsPowerOfTwo(blobLength) {
+		return zFr, fmt.Errorf("blob length %d is not a power of 2", blobLength)
+	}
+	if index >= blobLength {
+		return zFr, fmt.Errorf("symbol index %d out of range, blob length is %d", index, blobLength)
+	}
+
+	rootOfUnity := encoding.Scale2RootOfUnity[bits.TrailingZeros32(blobLength)]
+	zFr.Exp(rootOfUnity, new(big.Int).SetUint64(uint64(index)))
+	return zFr, nil
+}
+
 func VerifyKzgProof(G1Gen, commitment, proof bn254.G1Affine, G2Gen, G2tau bn254.G2Affine, valueFr, zFr fr.Element) error {
 
 	var valueG1 bn254.G1Affine
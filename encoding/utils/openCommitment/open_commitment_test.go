@@ -108,3 +108,89 @@ func TestOpenCommitment(t *testing.T) {
 		//fmt.Println("value Byte", string(valueBytse[1:]))
 	}
 }
+
+func TestComputeMultiPointKzgProof(t *testing.T) {
+	// a small SRS with a known secret, so that the test does not need the SRS files
+	var tau fr.Element
+	_, err := tau.SetRandom()
+	require.NoError(t, err)
+
+	_, _, g1Gen, g2Gen := bn254.Generators()
+	numCoeffs := 16
+	g1Srs := make([]bn254.G1Affine, numCoeffs)
+	g2Srs := make([]bn254.G2Affine, numCoeffs)
+	var power fr.Element
+	var powerBig big.Int
+	power.SetOne()
+	for i := range g1Srs {
+		g1Srs[i].ScalarMultiplication(&g1Gen, power.BigInt(&powerBig))
+		g2Srs[i].ScalarMultiplication(&g2Gen, power.BigInt(&powerBig))
+		power.Mul(&power, &tau)
+	}
+
+	coeffs := make([]fr.Element, numCoeffs)
+	for i := range coeffs {
+		_, err = coeffs[i].SetRandom()
+		require.NoError(t, err)
+	}
+	commitment, err := oc.CommitInLagrange(coeffs, g1Srs)
+	require.NoError(t, err)
+
+	numPoints := 5
+	zs := make([]fr.Element, numPoints)
+	for i := range zs {
+		_, err = zs[i].SetRandom()
+		require.NoError(t, err)
+	}
+
+	proof, values, err := oc.ComputeMultiPointKzgProof(coeffs, zs, g1Srs)
+	require.NoError(t, err)
+	require.Len(t, values, numPoints)
+
+	// the values are the evaluations of the polynomial at the points
+	for i := range zs {
+		var expected fr.Element
+		for j := numCoeffs - 1; j >= 0; j-- {
+			expected.Mul(&expected, &zs[i])
+			expected.Add(&expected, &coeffs[j])
+		}
+		require.Equal(t, expected, values[i])
+	}
+
+	err = oc.VerifyMultiPointKzgProof(*commitment, *proof, values, zs, g1Srs, g2Srs)
+	require.NoError(t, err)
+
+	// opening a single point agrees with the single point verifier
+	singleProof, singleValues, err := oc.ComputeMultiPointKzgProof(coeffs, zs[:1], g1Srs)
+	require.NoError(t, err)
+	err = oc.VerifyKzgProof(g1Gen, *commitment, *singleProof, g2Gen, g2Srs[1], singleValues[0], zs[0])
+	require.NoError(t, err)
+
+	// a single wrong value makes the verification fail
+	var one fr.Element
+	one.SetOne()
+	values[2].Add(&values[2], &one)
+	err = oc.VerifyMultiPointKzgProof(*commitment, *proof, values, zs, g1Srs, g2Srs)
+	require.Error(t, err)
+	values[2].Sub(&values[2], &one)
+
+	// the SRS must be large enough for the verifier
+	err = oc.VerifyMultiPointKzgProof(*commitment, *proof, values, zs, g1Srs, g2Srs[:numPoints])
+	require.Error(t, err)
+
+	// a polynomial with no more coefficients than points has a zero quotient
+	proof, values, err = oc.ComputeMultiPointKzgProof(coeffs[:3], zs, g1Srs)
+	require.NoError(t, err)
+	require.True(t, proof.IsInfinity())
+	smallCommitment, err := oc.CommitInLagrange(coeffs[:3], g1Srs[:3])
+	require.NoError(t, err)
+	err = oc.VerifyMultiPointKzgProof(*smallCommitment, *proof, values, zs, g1Srs, g2Srs)
+	require.NoError(t, err)
+
+	_, _, err = oc.ComputeMultiPointKzgProof(coeffs, zs, g1Srs[:numCoeffs-numPoints-1])
+	require.Error(t, err)
+	_, _, err = oc.ComputeMultiPointKzgProof(coeffs, nil, g1Srs)
+	require.Error(t, err)
+	_, _, err = oc.ComputeMultiPointKzgProof(coeffs, []fr.Element{zs[0], zs[1], zs[0]}, g1Srs)
+	require.Error(t, err)
+}
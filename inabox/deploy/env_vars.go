@@ -17,6 +17,12 @@ type DisperserVars struct {
 
 	DISPERSER_SERVER_DISPERSER_VERSION string
 
+	DISPERSER_SERVER_METADATA_STORE_BACKEND string
+
+	DISPERSER_SERVER_POSTGRES_URL string
+
+	DISPERSER_SERVER_CONTROLLER_NOTIFICATION_URL string
+
 	DISPERSER_SERVER_METRICS_HTTP_PORT string
 
 	DISPERSER_SERVER_ENABLE_METRICS string
@@ -27,10 +33,18 @@ type DisperserVars struct {
 
 	DISPERSER_SERVER_RESERVED_ONLY string
 
+	DISPERSER_SERVER_PREMIUM_ACCOUNTS string
+
 	DISPERSER_SERVER_RATE_BUCKET_STORE_SIZE string
 
 	DISPERSER_SERVER_GRPC_STREAM_TIMEOUT string
 
+	DISPERSER_SERVER_BLOB_STATUS_POLL_INTERVAL string
+
+	DISPERSER_SERVER_MAX_BLOB_STATUS_SUBSCRIPTIONS string
+
+	DISPERSER_SERVER_MAX_BLOB_STATUS_SUBSCRIPTION_LIFETIME string
+
 	DISPERSER_SERVER_MAX_BLOB_SIZE string
 
 	DISPERSER_SERVER_RESERVATIONS_TABLE_NAME string
@@ -39,8 +53,16 @@ type DisperserVars struct {
 
 	DISPERSER_SERVER_GLOBAL_RATE_TABLE_NAME string
 
+	DISPERSER_SERVER_METERING_STORE_PATH string
+
+	DISPERSER_SERVER_METERING_STORE_BIN_RETENTION string
+
 	DISPERSER_SERVER_ONCHAIN_STATE_REFRESH_INTERVAL string
 
+	DISPERSER_SERVER_PAYMENT_VAULT_SYNC_INTERVAL string
+
+	DISPERSER_SERVER_PAYMENT_VAULT_CONFIRMATION_DEPTH string
+
 	DISPERSER_SERVER_MAX_NUM_SYMBOLS_PER_BLOB string
 
 	DISPERSER_SERVER_PPROF_HTTP_PORT string
@@ -97,6 +119,10 @@ type DisperserVars struct {
 
 	DISPERSER_SERVER_FRAGMENT_WRITE_TIMEOUT string
 
+	DISPERSER_SERVER_AWS_LOCAL_STORAGE_PATH string
+
+	DISPERSER_SERVER_AWS_LOCAL_STORAGE_SHARDING_DEPTH string
+
 	DISPERSER_SERVER_REGISTERED_QUORUM_ID string
 
 	DISPERSER_SERVER_TOTAL_UNAUTH_BYTE_RATE string
@@ -244,6 +270,10 @@ type BatcherVars struct {
 
 	BATCHER_FRAGMENT_WRITE_TIMEOUT string
 
+	BATCHER_AWS_LOCAL_STORAGE_PATH string
+
+	BATCHER_AWS_LOCAL_STORAGE_SHARDING_DEPTH string
+
 	BATCHER_GRAPH_URL string
 
 	BATCHER_GRAPH_BACKOFF string
@@ -313,6 +343,10 @@ type EncoderVars struct {
 
 	DISPERSER_ENCODER_FRAGMENT_WRITE_TIMEOUT string
 
+	DISPERSER_ENCODER_AWS_LOCAL_STORAGE_PATH string
+
+	DISPERSER_ENCODER_AWS_LOCAL_STORAGE_SHARDING_DEPTH string
+
 	DISPERSER_ENCODER_G1_PATH string
 
 	DISPERSER_ENCODER_G2_PATH string
@@ -442,6 +476,8 @@ type OperatorVars struct {
 
 	NODE_CHUNK_DOWNLOAD_TIMEOUT string
 
+	NODE_ENABLE_RELAY_CHUNK_STREAMING string
+
 	NODE_GRPC_MSG_SIZE_LIMIT_V2 string
 
 	NODE_PPROF_HTTP_PORT string
@@ -480,6 +516,8 @@ type OperatorVars struct {
 
 	NODE_LITT_DB_STORAGE_PATHS string
 
+	NODE_LITT_DB_BLOB_RECORDS_PATH string
+
 	NODE_GET_CHUNKS_HOT_CACHE_READ_LIMIT_MB string
 
 	NODE_GET_CHUNKS_HOT_BURST_LIMIT_MB string
@@ -677,6 +715,16 @@ type ControllerVars struct {
 
 	CONTROLLER_INDEXER_DATA_DIR string
 
+	CONTROLLER_METADATA_STORE_BACKEND string
+
+	CONTROLLER_POSTGRES_URL string
+
+	CONTROLLER_BLOB_METADATA_STREAM_ENABLED string
+
+	CONTROLLER_BLOB_METADATA_STREAM_POLL_INTERVAL string
+
+	CONTROLLER_BLOB_NOTIFICATION_PORT string
+
 	CONTROLLER_ENCODING_REQUEST_TIMEOUT string
 
 	CONTROLLER_ENCODING_STORE_TIMEOUT string
@@ -685,14 +733,36 @@ type ControllerVars struct {
 
 	CONTROLLER_NUM_RELAY_ASSIGNMENT string
 
+	CONTROLLER_RELAY_PROBE_INTERVAL string
+
+	CONTROLLER_RELAY_PROBE_TIMEOUT string
+
+	CONTROLLER_RELAY_MAX_UTILIZATION string
+
+	CONTROLLER_RELAY_STORED_BLOBS_LOOKBACK string
+
+	CONTROLLER_RELAY_USE_SECURE_GRPC string
+
 	CONTROLLER_NUM_CONCURRENT_ENCODING_REQUESTS string
 
 	CONTROLLER_MAX_NUM_BLOBS_PER_ITERATION string
 
+	CONTROLLER_MAX_BLOBS_PER_ENCODING_REQUEST string
+
 	CONTROLLER_ONCHAIN_STATE_REFRESH_INTERVAL string
 
+	CONTROLLER_ENCODER_BACKOFF_DURATION string
+
+	CONTROLLER_STANDARD_PRIORITY_WEIGHT string
+
+	CONTROLLER_RESERVED_PRIORITY_WEIGHT string
+
+	CONTROLLER_PREMIUM_PRIORITY_WEIGHT string
+
 	CONTROLLER_SIGNATURE_TICK_INTERVAL string
 
+	CONTROLLER_DISPATCHER_MIN_BATCH_INTERVAL string
+
 	CONTROLLER_FINALIZATION_BLOCK_DELAY string
 
 	CONTROLLER_NUM_REQUEST_RETRIES string
@@ -703,6 +773,10 @@ type ControllerVars struct {
 
 	CONTROLLER_MAX_BATCH_SIZE string
 
+	CONTROLLER_MAX_BATCH_SYMBOLS string
+
+	CONTROLLER_MAX_BATCH_CHUNK_BYTES_PER_VALIDATOR string
+
 	CONTROLLER_METRICS_PORT string
 
 	CONTROLLER_DISPERSER_STORE_CHUNKS_SIGNING_DISABLED string
@@ -751,6 +825,10 @@ type ControllerVars struct {
 
 	CONTROLLER_FRAGMENT_WRITE_TIMEOUT string
 
+	CONTROLLER_AWS_LOCAL_STORAGE_PATH string
+
+	CONTROLLER_AWS_LOCAL_STORAGE_SHARDING_DEPTH string
+
 	CONTROLLER_GRAPH_URL string
 
 	CONTROLLER_GRAPH_BACKOFF string
@@ -836,6 +914,8 @@ type RelayVars struct {
 
 	RELAY_GET_CHUNKS_TIMEOUT string
 
+	RELAY_STREAM_CHUNKS_TIMEOUT string
+
 	RELAY_GET_BLOB_TIMEOUT string
 
 	RELAY_INTERNAL_GET_METADATA_TIMEOUT string
@@ -882,6 +962,10 @@ type RelayVars struct {
 
 	RELAY_FRAGMENT_WRITE_TIMEOUT string
 
+	RELAY_AWS_LOCAL_STORAGE_PATH string
+
+	RELAY_AWS_LOCAL_STORAGE_SHARDING_DEPTH string
+
 	RELAY_CHAIN_RPC string
 
 	RELAY_CHAIN_RPC_FALLBACK string
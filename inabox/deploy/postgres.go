@@ -0,0 +1,87 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/ory/dockertest/v3"
+	"github.com/ory/dockertest/v3/docker"
+)
+
+const (
+	PostgresUser     = "eigenda"
+	PostgresPassword = "eigenda"
+	PostgresDatabase = "eigenda"
+)
+
+// PostgresURL returns the connection string for a postgres instance started by StartDockertestWithPostgresContainer.
+func PostgresURL(postgresPort string) string {
+	return fmt.Sprintf("postgres://%s:%s@0.0.0.0:%s/%s?sslmode=disable",
+		PostgresUser, PostgresPassword, postgresPort, PostgresDatabase)
+}
+
+func StartDockertestWithPostgresContainer(postgresPort string) (*dockertest.Pool, *dockertest.Resource, error) {
+	fmt.Println("Starting Postgres container")
+	pool, err := dockertest.NewPool("")
+	if err != nil {
+		fmt.Println("Could not construct pool: %w", err)
+		return nil, nil, err
+	}
+
+	err = pool.Client.Ping()
+	if err != nil {
+		fmt.Println("Could not connect to Docker: %w", err)
+		return nil, nil, err
+	}
+
+	resource, err := pool.RunWithOptions(&dockertest.RunOptions{
+		Repository:   "postgres",
+		Tag:          "16-alpine",
+		Name:         "postgres-test",
+		ExposedPorts: []string{"5432"},
+		PortBindings: map[docker.Port][]docker.PortBinding{
+			docker.Port("5432/tcp"): {
+				{HostIP: "0.0.0.0", HostPort: postgresPort},
+			},
+		},
+		Env: []string{
+			fmt.Sprintf("POSTGRES_USER=%s", PostgresUser),
+			fmt.Sprintf("POSTGRES_PASSWORD=%s", PostgresPassword),
+			fmt.Sprintf("POSTGRES_DB=%s", PostgresDatabase),
+		},
+	}, func(config *docker.HostConfig) {
+		// set AutoRemove to true so that stopped container goes away by itself
+		config.AutoRemove = true
+		config.RestartPolicy = docker.RestartPolicy{Name: "no"}
+	})
+	if err != nil {
+		fmt.Println("Could not start resource: %w", err)
+		return nil, nil, err
+	}
+
+	pool.MaxWait = 30 * time.Second
+	if err := pool.Retry(func() error {
+		fmt.Println("Waiting for postgres to start")
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		conn, err := pgx.Connect(ctx, PostgresURL(postgresPort))
+		if err != nil {
+			fmt.Println("Server is not running:", err)
+			return err
+		}
+		defer func() { _ = conn.Close(ctx) }()
+
+		return conn.Ping(ctx)
+	}); err != nil {
+		fmt.Println("Could not connect to postgres:", err)
+		return nil, nil, err
+	}
+
+	log.Printf("Postgres started successfully! URL: %s", PostgresURL(postgresPort))
+
+	return pool, resource, nil
+}
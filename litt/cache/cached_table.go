@@ -170,6 +170,12 @@ func (c *cachedTable) Exists(key []byte) (exists bool, err error) {
 	return c.base.Exists(key)
 }
 
+// Iterate returns an iterator over the base table. Values returned by the iterator are read directly from the base
+// table, and are not inserted into the read cache (iterating over a table would otherwise evict the entire cache).
+func (c *cachedTable) Iterate(options *litt.IteratorOptions) (litt.TableIterator, error) {
+	return c.base.Iterate(options)
+}
+
 func (c *cachedTable) Flush() error {
 	return c.base.Flush()
 }
@@ -211,3 +217,23 @@ func (c *cachedTable) SetShardingFactor(shardingFactor uint32) error {
 func (c *cachedTable) RunGC() error {
 	return c.base.RunGC()
 }
+
+func (c *cachedTable) Snapshot(targetDirectory string) error {
+	return c.base.Snapshot(targetDirectory)
+}
+
+func (c *cachedTable) GetSegmentInfo() ([]*litt.SegmentInfo, error) {
+	return c.base.GetSegmentInfo()
+}
+
+func (c *cachedTable) AddRoot(root string) error {
+	return c.base.AddRoot(root)
+}
+
+func (c *cachedTable) RemoveRoot(root string) error {
+	return c.base.RemoveRoot(root)
+}
+
+func (c *cachedTable) CheckRemoveRoot(root string) error {
+	return c.base.CheckRemoveRoot(root)
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	pathFlagName    = "path"
+	verboseFlagName = "verbose"
+	rawFlagName     = "raw"
+	toFlagName      = "to"
+	maxKeysFlagName = "max-keys"
+)
+
+// buildApp builds the littdb command line application.
+func buildApp() *cli.App {
+	return &cli.App{
+		Name:  "littdb",
+		Usage: "inspect and maintain a LittDB instance",
+		Description: "The ls, get, and stat commands open the DB in read-only mode, and are safe to use while the " +
+			"DB is in use by another process. All other commands modify the DB, and fail if the DB is in use by " +
+			"another process.",
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:     pathFlagName,
+				Aliases:  []string{"p"},
+				Usage:    "a path where the DB stores its data, may be repeated if the DB uses multiple paths",
+				EnvVars:  []string{"LITTDB_PATHS"},
+				Required: true,
+			},
+			&cli.BoolFlag{
+				Name:  verboseFlagName,
+				Usage: "print DB log output",
+			},
+			&cli.Uint64Flag{
+				Name: maxKeysFlagName,
+				Usage: "the maximum number of keys a table opened in read-only mode may index in memory, " +
+					"defaults to the DB's ReadOnlyMaxKeyCount",
+			},
+		},
+		Commands: []*cli.Command{
+			{
+				Name:   "ls",
+				Usage:  "list tables along with their sizes, key counts, and segment ranges",
+				Action: lsCommand,
+			},
+			{
+				Name:      "get",
+				Usage:     "print the value for a key, hex encoded",
+				ArgsUsage: "<table> <hex-key>",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  rawFlagName,
+						Usage: "write the raw value bytes instead of hex",
+					},
+				},
+				Action: getCommand,
+			},
+			{
+				Name:      "stat",
+				Usage:     "print information about each segment in a table",
+				ArgsUsage: "<table>",
+				Action:    statCommand,
+			},
+			{
+				Name:      "set-ttl",
+				Usage:     "set the TTL of a table (e.g. 24h), a TTL of 0 means data never expires",
+				ArgsUsage: "<table> <ttl>",
+				Action:    withLock(setTTLCommand),
+			},
+			{
+				Name:      "set-sharding-factor",
+				Usage:     "set the sharding factor of a table, applies to data written after the change",
+				ArgsUsage: "<table> <sharding-factor>",
+				Action:    withLock(setShardingFactorCommand),
+			},
+			{
+				Name:      "gc",
+				Usage:     "delete expired data, from the given tables or from all tables if none are given",
+				ArgsUsage: "[table...]",
+				Action:    withLock(gcCommand),
+			},
+			{
+				Name:      "rebuild-keymap",
+				Usage:     "rebuild the keymap from segment key files, for the given tables or all tables if none are given",
+				ArgsUsage: "[table...]",
+				Action:    withLock(rebuildKeymapCommand),
+			},
+			{
+				Name:  "migrate-drives",
+				Usage: "move the DB's data from the paths given by --path to the paths given by --to",
+				Flags: []cli.Flag{
+					&cli.StringSliceFlag{
+						Name:     toFlagName,
+						Usage:    "a path where the DB should store its data after migration, may be repeated",
+						Required: true,
+					},
+				},
+				Action: withLock(migrateDrivesCommand),
+			},
+		},
+	}
+}
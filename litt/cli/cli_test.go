@@ -0,0 +1,239 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/common/testutils/random"
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/disktable/keymap"
+	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/stretchr/testify/require"
+)
+
+// runCommand runs the CLI with the given arguments and returns the output.
+func runCommand(t *testing.T, paths []string, args ...string) (string, error) {
+	app := buildApp()
+	output := &bytes.Buffer{}
+	app.Writer = output
+
+	fullArgs := []string{"littdb"}
+	for _, p := range paths {
+		fullArgs = append(fullArgs, "--path", p)
+	}
+	fullArgs = append(fullArgs, args...)
+
+	err := app.Run(fullArgs)
+	return output.String(), err
+}
+
+// buildTestDB creates a DB with some data in it, then closes it. Returns the values written, indexed by table name.
+func buildTestDB(t *testing.T, rand *random.TestRandom, paths []string) map[string]map[string][]byte {
+	config, err := litt.DefaultConfig(paths...)
+	require.NoError(t, err)
+	config.Fsync = false
+	config.TargetSegmentFileSize = 1024
+
+	db, err := littbuilder.NewDB(config)
+	require.NoError(t, err)
+
+	expectedValues := make(map[string]map[string][]byte)
+	for _, tableName := range []string{"alpha", "beta"} {
+		table, err := db.GetTable(tableName)
+		require.NoError(t, err)
+		expectedValues[tableName] = make(map[string][]byte)
+
+		for i := 0; i < 100; i++ {
+			key := rand.PrintableVariableBytes(32, 64)
+			value := rand.PrintableVariableBytes(1, 128)
+			err = table.Put(key, value)
+			require.NoError(t, err)
+			expectedValues[tableName][string(key)] = value
+		}
+	}
+
+	err = db.Close()
+	require.NoError(t, err)
+
+	return expectedValues
+}
+
+// verifyValues uses the get command to verify that the DB contains the expected values.
+func verifyValues(t *testing.T, paths []string, expectedValues map[string]map[string][]byte) {
+	for tableName, values := range expectedValues {
+		for key, expectedValue := range values {
+			output, err := runCommand(t, paths, "get", "--raw", tableName, hex.EncodeToString([]byte(key)))
+			require.NoError(t, err)
+			require.Equal(t, string(expectedValue), output)
+		}
+	}
+}
+
+func TestInspectionCommands(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+
+	directory := t.TempDir()
+	paths := []string{path.Join(directory, "a"), path.Join(directory, "b")}
+	expectedValues := buildTestDB(t, rand, paths)
+
+	output, err := runCommand(t, paths, "ls")
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	require.Equal(t, 3, len(lines))
+	require.True(t, strings.HasPrefix(lines[0], "TABLE"))
+	require.True(t, strings.HasPrefix(lines[1], "alpha"))
+	require.True(t, strings.HasPrefix(lines[2], "beta"))
+	require.Contains(t, lines[1], "100")
+
+	verifyValues(t, paths, expectedValues)
+
+	for key, value := range expectedValues["alpha"] {
+		output, err = runCommand(t, paths, "get", "alpha", "0x"+hex.EncodeToString([]byte(key)))
+		require.NoError(t, err)
+		require.Equal(t, hex.EncodeToString(value)+"\n", output)
+		break
+	}
+
+	_, err = runCommand(t, paths, "get", "alpha", hex.EncodeToString([]byte("not a key")))
+	require.Error(t, err)
+
+	_, err = runCommand(t, paths, "get", "gamma", hex.EncodeToString([]byte("not a key")))
+	require.Error(t, err)
+
+	output, err = runCommand(t, paths, "stat", "alpha")
+	require.NoError(t, err)
+	lines = strings.Split(strings.TrimSpace(output), "\n")
+	require.True(t, strings.HasPrefix(lines[0], "SEGMENT"))
+	// The small target segment size should cause data to be spread across several segments.
+	require.Greater(t, len(lines), 2)
+
+	// Tables with more keys than a read-only table may index in memory can not be inspected.
+	_, err = runCommand(t, paths, "--max-keys", "10", "ls")
+	require.ErrorContains(t, err, "ReadOnlyMaxKeyCount")
+
+	// Opening tables with the CLI must not have modified the data.
+	verifyValues(t, paths, expectedValues)
+}
+
+func TestMaintenanceCommands(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+
+	directory := t.TempDir()
+	paths := []string{path.Join(directory, "a"), path.Join(directory, "b")}
+	expectedValues := buildTestDB(t, rand, paths)
+
+	_, err := runCommand(t, paths, "set-sharding-factor", "alpha", "4")
+	require.NoError(t, err)
+	_, err = runCommand(t, paths, "set-sharding-factor", "alpha", "0")
+	require.Error(t, err)
+
+	// Rebuilding the keymap should not lose any data.
+	output, err := runCommand(t, paths, "rebuild-keymap")
+	require.NoError(t, err)
+	require.Contains(t, output, "alpha: rebuilt keymap with 100 keys")
+	require.Contains(t, output, "beta: rebuilt keymap with 100 keys")
+	verifyValues(t, paths, expectedValues)
+
+	// Move the data to a new set of paths.
+	newPaths := []string{path.Join(directory, "c"), path.Join(directory, "d"), path.Join(directory, "e")}
+	args := []string{"migrate-drives"}
+	for _, p := range newPaths {
+		args = append(args, "--to", p)
+	}
+	_, err = runCommand(t, paths, args...)
+	require.NoError(t, err)
+
+	// The old paths are left in place, but they should no longer contain any tables. Only the lock file remains.
+	for _, p := range paths {
+		entries, err := os.ReadDir(p)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(entries))
+		require.Equal(t, littbuilder.LockFileName, entries[0].Name())
+	}
+	verifyValues(t, newPaths, expectedValues)
+
+	// Migrating again with the same arguments should be a no-op.
+	_, err = runCommand(t, paths, args...)
+	require.NoError(t, err)
+	verifyValues(t, newPaths, expectedValues)
+
+	// A TTL of one nanosecond causes all data to be eligible for garbage collection. The mutable segment
+	// is never collected, so some data may remain.
+	_, err = runCommand(t, newPaths, "set-ttl", "alpha", "1ns")
+	require.NoError(t, err)
+	output, err = runCommand(t, newPaths, "gc", "alpha")
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(output, "alpha: "))
+
+	output, err = runCommand(t, newPaths, "ls")
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	require.Equal(t, 3, len(lines))
+	require.NotContains(t, lines[1], " 100 ")
+
+	// The beta table was not garbage collected.
+	for key, value := range expectedValues["beta"] {
+		output, err = runCommand(t, newPaths, "get", "--raw", "beta", hex.EncodeToString([]byte(key)))
+		require.NoError(t, err)
+		require.Equal(t, string(value), output)
+	}
+}
+
+func TestCommandsOnDBInUse(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+
+	directory := t.TempDir()
+	paths := []string{path.Join(directory, "a"), path.Join(directory, "b")}
+	expectedValues := buildTestDB(t, rand, paths)
+
+	config, err := litt.DefaultConfig(paths...)
+	require.NoError(t, err)
+	config.Fsync = false
+	db, err := littbuilder.NewDB(config)
+	require.NoError(t, err)
+
+	// Read-only commands may be used while the DB is in use.
+	_, err = runCommand(t, paths, "ls")
+	require.NoError(t, err)
+	_, err = runCommand(t, paths, "stat", "alpha")
+	require.NoError(t, err)
+	verifyValues(t, paths, expectedValues)
+
+	// Commands that modify the DB must not run while the DB is in use, even if only some of its paths are given.
+	_, err = runCommand(t, paths, "set-ttl", "alpha", "1h")
+	require.ErrorIs(t, err, littbuilder.ErrDBInUse)
+	_, err = runCommand(t, paths, "set-sharding-factor", "alpha", "4")
+	require.ErrorIs(t, err, littbuilder.ErrDBInUse)
+	_, err = runCommand(t, paths, "gc")
+	require.ErrorIs(t, err, littbuilder.ErrDBInUse)
+	_, err = runCommand(t, paths[1:], "rebuild-keymap")
+	require.ErrorIs(t, err, littbuilder.ErrDBInUse)
+	_, err = runCommand(t, paths, "migrate-drives", "--to", path.Join(directory, "c"))
+	require.ErrorIs(t, err, littbuilder.ErrDBInUse)
+
+	// Migrating another DB onto a path in use is not permitted either.
+	otherPaths := []string{path.Join(directory, "other")}
+	_, err = runCommand(t, otherPaths, "migrate-drives", "--to", paths[0])
+	require.ErrorIs(t, err, littbuilder.ErrDBInUse)
+
+	// The keymaps must not have been deleted by the rejected rebuild-keymap command.
+	for _, name := range []string{"alpha", "beta"} {
+		_, err = os.Stat(path.Join(paths[0], name, keymap.KeymapDirectoryName))
+		require.NoError(t, err)
+	}
+
+	err = db.Close()
+	require.NoError(t, err)
+
+	// Once the DB is closed, the lock is released.
+	_, err = runCommand(t, paths, "rebuild-keymap")
+	require.NoError(t, err)
+	verifyValues(t, paths, expectedValues)
+}
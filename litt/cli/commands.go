@@ -0,0 +1,359 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/disktable"
+	"github.com/Layr-Labs/eigenda/litt/disktable/keymap"
+	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/docker/go-units"
+	"github.com/urfave/cli/v2"
+)
+
+// buildLoggerConfig builds the logger configuration. DB log output is sent to stderr so that it does not interfere
+// with command output, and is suppressed (except for warnings and errors) unless --verbose is set.
+func buildLoggerConfig(ctx *cli.Context) *common.LoggerConfig {
+	loggerConfig := common.DefaultConsoleLoggerConfig()
+	loggerConfig.OutputWriter = os.Stderr
+	if !ctx.Bool(verboseFlagName) {
+		loggerConfig.HandlerOpts.Level = slog.LevelWarn
+	}
+	return &loggerConfig
+}
+
+// getTableNames returns the names of the tables passed as arguments, or the names of all tables if no
+// arguments were passed.
+func getTableNames(ctx *cli.Context) ([]string, error) {
+	if ctx.NArg() > 0 {
+		return ctx.Args().Slice(), nil
+	}
+	return disktable.ListTables(ctx.StringSlice(pathFlagName))
+}
+
+// detectKeymapType returns the type of the keymap currently used by a table. If the table has no keymap,
+// the default keymap type is returned. The CLI must always open a table with the keymap type it already uses,
+// otherwise the keymap would be deleted and rebuilt.
+func detectKeymapType(paths []string, tableName string, defaultType keymap.KeymapType) (keymap.KeymapType, error) {
+	for _, p := range paths {
+		keymapDirectory := path.Join(p, tableName, keymap.KeymapDirectoryName)
+		exists, err := keymap.KeymapFileExists(keymapDirectory)
+		if err != nil {
+			return "", fmt.Errorf("failed to check for keymap type file: %w", err)
+		}
+		if exists {
+			keymapTypeFile, err := keymap.LoadKeymapTypeFile(keymapDirectory)
+			if err != nil {
+				return "", fmt.Errorf("failed to load keymap type file: %w", err)
+			}
+			return keymapTypeFile.Type(), nil
+		}
+	}
+	return defaultType, nil
+}
+
+// openTable opens an existing table. Returns an error if the table does not exist. The caller is responsible for
+// closing the table. Tables opened in read-only mode may be inspected while the DB is in use by another process.
+// Tables may only be opened for writing by commands wrapped with withLock.
+func openTable(ctx *cli.Context, tableName string, readOnly bool) (litt.ManagedTable, error) {
+	paths := ctx.StringSlice(pathFlagName)
+
+	tables, err := disktable.ListTables(paths)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+	found := false
+	for _, name := range tables {
+		if name == tableName {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("table %s not found in %v", tableName, paths)
+	}
+
+	config, err := litt.DefaultConfig(paths...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build config: %w", err)
+	}
+	config.LoggerConfig = buildLoggerConfig(ctx)
+	config.ReadOnly = readOnly
+	if ctx.IsSet(maxKeysFlagName) {
+		config.ReadOnlyMaxKeyCount = ctx.Uint64(maxKeysFlagName)
+	}
+	config.KeymapType, err = detectKeymapType(paths, tableName, config.KeymapType)
+	if err != nil {
+		return nil, err
+	}
+
+	table, err := littbuilder.NewTable(config, tableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open table %s: %w", tableName, err)
+	}
+	return table, nil
+}
+
+// withTable opens a table, runs the given function, and then closes the table.
+func withTable(
+	ctx *cli.Context,
+	tableName string,
+	readOnly bool,
+	f func(table litt.ManagedTable) error) error {
+
+	table, err := openTable(ctx, tableName, readOnly)
+	if err != nil {
+		return err
+	}
+
+	err = f(table)
+	closeErr := table.Close()
+	if err != nil {
+		return err
+	}
+	if closeErr != nil {
+		return fmt.Errorf("failed to close table %s: %w", tableName, closeErr)
+	}
+	return nil
+}
+
+// withLock wraps a command that modifies the DB. The wrapped command runs only if the lock on the DB's paths (and
+// on the paths given by --to, if any) can be taken, which fails if the DB is in use by another process. The lock is
+// released once the command returns.
+func withLock(action cli.ActionFunc) cli.ActionFunc {
+	return func(ctx *cli.Context) error {
+		paths := append([]string(nil), ctx.StringSlice(pathFlagName)...)
+		paths = append(paths, ctx.StringSlice(toFlagName)...)
+		lock, err := littbuilder.LockPaths(paths...)
+		if err != nil {
+			return fmt.Errorf("failed to lock DB, it must not be in use by another process: %w", err)
+		}
+
+		err = action(ctx)
+		releaseErr := lock.Release()
+		if err != nil {
+			return err
+		}
+		if releaseErr != nil {
+			return fmt.Errorf("failed to release DB lock: %w", releaseErr)
+		}
+		return nil
+	}
+}
+
+// requireArgs returns an error if the number of arguments is not as expected.
+func requireArgs(ctx *cli.Context, count int) error {
+	if ctx.NArg() != count {
+		return fmt.Errorf("expected %d argument(s), got %d, usage: %s %s",
+			count, ctx.NArg(), ctx.Command.Name, ctx.Command.ArgsUsage)
+	}
+	return nil
+}
+
+// segmentRange returns a human-readable description of the range of segments in a table.
+func segmentRange(segments []*litt.SegmentInfo) string {
+	if len(segments) == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d-%d", segments[0].Index, segments[len(segments)-1].Index)
+}
+
+func lsCommand(ctx *cli.Context) error {
+	tables, err := disktable.ListTables(ctx.StringSlice(pathFlagName))
+	if err != nil {
+		return fmt.Errorf("failed to list tables: %w", err)
+	}
+
+	writer := tabwriter.NewWriter(ctx.App.Writer, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(writer, "TABLE\tSIZE\tKEYS\tSEGMENTS")
+	for _, name := range tables {
+		err = withTable(ctx, name, true, func(table litt.ManagedTable) error {
+			segments, err := table.GetSegmentInfo()
+			if err != nil {
+				return fmt.Errorf("failed to get segment info for table %s: %w", name, err)
+			}
+			_, _ = fmt.Fprintf(writer, "%s\t%s\t%d\t%s\n",
+				name,
+				units.BytesSize(float64(table.Size())),
+				table.KeyCount(),
+				segmentRange(segments))
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return writer.Flush()
+}
+
+func getCommand(ctx *cli.Context) error {
+	if err := requireArgs(ctx, 2); err != nil {
+		return err
+	}
+	tableName := ctx.Args().Get(0)
+	key, err := hex.DecodeString(strings.TrimPrefix(ctx.Args().Get(1), "0x"))
+	if err != nil {
+		return fmt.Errorf("failed to decode key: %w", err)
+	}
+
+	return withTable(ctx, tableName, true, func(table litt.ManagedTable) error {
+		value, ok, err := table.Get(key)
+		if err != nil {
+			return fmt.Errorf("failed to get key: %w", err)
+		}
+		if !ok {
+			return fmt.Errorf("key %x not found in table %s", key, tableName)
+		}
+
+		if ctx.Bool(rawFlagName) {
+			_, err = ctx.App.Writer.Write(value)
+		} else {
+			_, err = io.WriteString(ctx.App.Writer, hex.EncodeToString(value)+"\n")
+		}
+		return err
+	})
+}
+
+func statCommand(ctx *cli.Context) error {
+	if err := requireArgs(ctx, 1); err != nil {
+		return err
+	}
+	tableName := ctx.Args().Get(0)
+
+	return withTable(ctx, tableName, true, func(table litt.ManagedTable) error {
+		segments, err := table.GetSegmentInfo()
+		if err != nil {
+			return fmt.Errorf("failed to get segment info: %w", err)
+		}
+
+		writer := tabwriter.NewWriter(ctx.App.Writer, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(writer, "SEGMENT\tVERSION\tSEALED\tSEAL TIME\tSHARDS\tKEYS\tSIZE")
+		for _, segment := range segments {
+			sealTime := "-"
+			if segment.Sealed {
+				sealTime = segment.SealTime.Format(time.RFC3339)
+			}
+			_, _ = fmt.Fprintf(writer, "%d\t%d\t%t\t%s\t%d\t%d\t%s\n",
+				segment.Index,
+				segment.Version,
+				segment.Sealed,
+				sealTime,
+				segment.ShardCount,
+				segment.KeyCount,
+				units.BytesSize(float64(segment.Size)))
+		}
+		return writer.Flush()
+	})
+}
+
+func setTTLCommand(ctx *cli.Context) error {
+	if err := requireArgs(ctx, 2); err != nil {
+		return err
+	}
+	tableName := ctx.Args().Get(0)
+	ttl, err := time.ParseDuration(ctx.Args().Get(1))
+	if err != nil {
+		return fmt.Errorf("failed to parse TTL: %w", err)
+	}
+
+	return withTable(ctx, tableName, false, func(table litt.ManagedTable) error {
+		return table.SetTTL(ttl)
+	})
+}
+
+func setShardingFactorCommand(ctx *cli.Context) error {
+	if err := requireArgs(ctx, 2); err != nil {
+		return err
+	}
+	tableName := ctx.Args().Get(0)
+	shardingFactor, err := strconv.ParseUint(ctx.Args().Get(1), 10, 32)
+	if err != nil {
+		return fmt.Errorf("failed to parse sharding factor: %w", err)
+	}
+
+	return withTable(ctx, tableName, false, func(table litt.ManagedTable) error {
+		return table.SetShardingFactor(uint32(shardingFactor))
+	})
+}
+
+func gcCommand(ctx *cli.Context) error {
+	tables, err := getTableNames(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list tables: %w", err)
+	}
+
+	for _, name := range tables {
+		err = withTable(ctx, name, false, func(table litt.ManagedTable) error {
+			sizeBefore := table.Size()
+			err := table.RunGC()
+			if err != nil {
+				return fmt.Errorf("failed to run GC on table %s: %w", name, err)
+			}
+			_, _ = fmt.Fprintf(ctx.App.Writer, "%s: %s -> %s\n",
+				name, units.BytesSize(float64(sizeBefore)), units.BytesSize(float64(table.Size())))
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func rebuildKeymapCommand(ctx *cli.Context) error {
+	paths := ctx.StringSlice(pathFlagName)
+	tables, err := getTableNames(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list tables: %w", err)
+	}
+
+	for _, name := range tables {
+		// Deleting the keymap causes it to be rebuilt from the segment key files when the table is next opened.
+		for _, p := range paths {
+			err = os.RemoveAll(path.Join(p, name, keymap.KeymapDirectoryName))
+			if err != nil {
+				return fmt.Errorf("failed to delete keymap for table %s: %w", name, err)
+			}
+		}
+
+		err = withTable(ctx, name, false, func(table litt.ManagedTable) error {
+			_, _ = fmt.Fprintf(ctx.App.Writer, "%s: rebuilt keymap with %d keys\n", name, table.KeyCount())
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func migrateDrivesCommand(ctx *cli.Context) error {
+	logger, err := common.NewLogger(*buildLoggerConfig(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to build logger: %w", err)
+	}
+
+	oldPaths := ctx.StringSlice(pathFlagName)
+	newPaths := ctx.StringSlice(toFlagName)
+
+	err = littbuilder.MigratePaths(logger, oldPaths, newPaths)
+	if err != nil {
+		return fmt.Errorf("failed to migrate from %v to %v: %w", oldPaths, newPaths, err)
+	}
+
+	_, _ = fmt.Fprintf(ctx.App.Writer, "migrated data from %v to %v\n", oldPaths, newPaths)
+	return nil
+}
@@ -0,0 +1,17 @@
+package main
+
+import (
+	"log"
+	"os"
+)
+
+// littdb is a command line utility for inspecting and maintaining a LittDB instance without writing custom code.
+//
+// Commands that only inspect the DB open it in read-only mode, and may be used while the DB is in use by another
+// process. Commands that modify the DB must not be used while the DB is in use by any other process.
+func main() {
+	app := buildApp()
+	if err := app.Run(os.Args); err != nil {
+		log.Fatal(err)
+	}
+}
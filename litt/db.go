@@ -1,5 +1,11 @@
 package litt
 
+import "errors"
+
+// ErrReadOnly is returned when an operation that modifies the database is attempted on a database that was
+// opened in read-only mode (see Config.ReadOnly).
+var ErrReadOnly = errors.New("database is open in read-only mode")
+
 // DB is a highly specialized key-value store. It is intentionally very feature poor, sacrificing
 // unnecessary features for simplicity, high performance, and low memory usage.
 //
@@ -31,6 +37,8 @@ type DB interface {
 	// The first time a table is fetched (either a new table or an existing one loaded from disk), its TTL is always
 	// set to 0 (i.e. it has no TTL, meaning data is never deleted). If you want to set a TTL, you must call
 	// Table.SetTTL() to do so. This is necessary after each time the database is started/restarted.
+	//
+	// If the database was opened in read-only mode, only tables that already exist on disk can be fetched.
 	GetTable(name string) (Table, error)
 
 	// DropTable deletes a table and all of its data. This is a no-op if the table does not exist.
@@ -48,6 +56,37 @@ type DB interface {
 	// KeyCount returns the number of keys in the database.
 	KeyCount() uint64
 
+	// Snapshot writes a consistent snapshot of the database to the target directory without stopping writes. All
+	// data written prior to this call is included in the snapshot. Data written while the snapshot is being taken
+	// may or may not be included. Only tables that have been opened via GetTable() are included.
+	//
+	// Segment files are hard linked into the snapshot where possible, and copied otherwise. If the target directory
+	// already contains a snapshot of this database, the snapshot is updated incrementally: only segments created
+	// since the previous snapshot are added, and segments that have been garbage collected since the previous
+	// snapshot are removed. The target directory should not be one of the directories used by the database. Each
+	// table's snapshot includes a keymap of the snapshotted keys, so that the keymap is not rebuilt on restore.
+	//
+	// A database can be opened from a snapshot via littbuilder.NewDBFromSnapshot().
+	Snapshot(targetDirectory string) error
+
+	// AddPath adds a storage path to the database without stopping it. Segments created after this method returns
+	// spread their files across all paths, including the new one. Existing data is not moved.
+	//
+	// Paths added at runtime are not remembered across restarts. The path must be included in Config.Paths the
+	// next time the database is started.
+	AddPath(path string) error
+
+	// RemovePath removes a storage path from the database without stopping it. Once this method returns, no new
+	// data is written to the path. Existing segment files on the path are moved onto the remaining paths by a
+	// background goroutine, and data remains readable while this happens. Progress is reported via metrics. A
+	// table's directory on the path is deleted once all of its files have been moved.
+	//
+	// Tables that have data on the path but that have not yet been opened via GetTable() are opened by this method.
+	// The first path in Config.Paths cannot be removed, nor can a path that holds a table's metadata or keymap.
+	// If the database is stopped before the data has been moved, the path must still be included in Config.Paths
+	// the next time the database is started, and it can be removed again then.
+	RemovePath(path string) error
+
 	// Close stops the database. This method must be called when the database is no longer needed.
 	// Close ensures that all non-flushed data is crash durable on disk before returning. Calls to
 	// Put() concurrent with Close() may not be crash durable after Close() returns.
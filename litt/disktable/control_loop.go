@@ -2,15 +2,18 @@ package disktable
 
 import "C"
 import (
+	"errors"
 	"fmt"
 	"math/rand"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/litt"
 	"github.com/Layr-Labs/eigenda/litt/disktable/keymap"
 	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
 	"github.com/Layr-Labs/eigenda/litt/metrics"
+	"github.com/Layr-Labs/eigenda/litt/types"
 	"github.com/Layr-Labs/eigenda/litt/util"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 )
@@ -106,6 +109,12 @@ type controlLoop struct {
 
 	// garbageCollectionPeriod is the period at which garbage collection is run.
 	garbageCollectionPeriod time.Duration
+
+	// scrubPeriod is the period at which the scrubber is run. If zero, periodic scrubbing is disabled.
+	scrubPeriod time.Duration
+
+	// The scrubber is responsible for verifying the integrity of data in sealed segments.
+	scrubber *scrubber
 }
 
 // enqueue enqueues a request to the control loop. Returns an error if the request could not be sent due to the
@@ -121,6 +130,14 @@ func (c *controlLoop) run() {
 	ticker := time.NewTicker(c.garbageCollectionPeriod)
 	defer ticker.Stop()
 
+	// If scrubbing is disabled, this channel is nil and will never produce a value.
+	var scrubTickerChan <-chan time.Time
+	if c.scrubPeriod > 0 {
+		scrubTicker := time.NewTicker(c.scrubPeriod)
+		defer scrubTicker.Stop()
+		scrubTickerChan = scrubTicker.C
+	}
+
 	for {
 		select {
 		case <-c.fatalErrorHandler.ImmediateShutdownRequired():
@@ -139,12 +156,22 @@ func (c *controlLoop) run() {
 			} else if req, ok := message.(*controlLoopGCRequest); ok {
 				c.doGarbageCollection()
 				req.completionChan <- struct{}{}
+			} else if req, ok := message.(*controlLoopReserveSegmentsRequest); ok {
+				c.handleReserveSegmentsRequest(req)
+			} else if req, ok := message.(*controlLoopSegmentInfoRequest); ok {
+				c.handleSegmentInfoRequest(req)
+			} else if req, ok := message.(*controlLoopSetSegmentDirectoriesRequest); ok {
+				c.handleSetSegmentDirectoriesRequest(req)
+			} else if req, ok := message.(*controlLoopDeleteKeysRequest); ok {
+				c.handleDeleteKeysRequest(req)
 			} else {
 				c.fatalErrorHandler.Panic(fmt.Errorf("Unknown control message type %T", message))
 				return
 			}
 		case <-ticker.C:
 			c.doGarbageCollection()
+		case <-scrubTickerChan:
+			c.handleScrubTick()
 		}
 	}
 }
@@ -184,21 +211,20 @@ func (c *controlLoop) doGarbageCollection() {
 
 		// Segment is old enough to be deleted.
 		keys, err := seg.GetKeys()
-		if err != nil {
+		if errors.Is(err, segment.ErrChecksumMismatch) {
+			// The key file was corrupted after the segment was loaded. We can't recover keys that follow the corrupt
+			// entry, but there is no reason to prevent the rest of the segment from being deleted.
+			c.logger.Errorf("key file for segment %d is corrupt, some keys may not be removed from the keymap: %v",
+				index, err)
+		} else if err != nil {
 			c.fatalErrorHandler.Panic(fmt.Errorf("failed to get keys: %w", err))
 			return
 		}
 
-		for keyIndex := uint64(0); keyIndex < uint64(len(keys)); keyIndex += c.gcBatchSize {
-			lastIndex := keyIndex + c.gcBatchSize
-			if lastIndex > uint64(len(keys)) {
-				lastIndex = uint64(len(keys))
-			}
-			err = c.keymap.Delete(keys[keyIndex:lastIndex])
-			if err != nil {
-				c.fatalErrorHandler.Panic(fmt.Errorf("failed to delete keys: %w", err))
-				return
-			}
+		err = c.deleteKeys(keys)
+		if err != nil {
+			c.fatalErrorHandler.Panic(err)
+			return
 		}
 
 		if seg.Size() > c.immutableSegmentSize {
@@ -219,6 +245,32 @@ func (c *controlLoop) doGarbageCollection() {
 	}
 }
 
+// deleteKeys removes the given keys from the keymap, in batches of at most gcBatchSize keys.
+func (c *controlLoop) deleteKeys(keys []*types.ScopedKey) error {
+	for keyIndex := uint64(0); keyIndex < uint64(len(keys)); keyIndex += c.gcBatchSize {
+		lastIndex := keyIndex + c.gcBatchSize
+		if lastIndex > uint64(len(keys)) {
+			lastIndex = uint64(len(keys))
+		}
+		err := c.keymap.Delete(keys[keyIndex:lastIndex])
+		if err != nil {
+			return fmt.Errorf("failed to delete keys: %w", err)
+		}
+	}
+	return nil
+}
+
+// handleDeleteKeysRequest removes keys from the keymap on behalf of another goroutine, so that all keymap
+// mutations are serialized on the control loop.
+func (c *controlLoop) handleDeleteKeysRequest(req *controlLoopDeleteKeysRequest) {
+	err := c.deleteKeys(req.keys)
+	if err != nil {
+		c.fatalErrorHandler.Panic(err)
+		return
+	}
+	req.responseChan <- struct{}{}
+}
+
 // getReservedSegment returns the segment with the given index. Segment is reserved, and it is the caller's
 // responsibility to release the reservation when done. Returns true if the segment was found and reserved,
 // and false if the segment could not be found or could not be reserved.
@@ -240,6 +292,97 @@ func (c *controlLoop) getReservedSegment(index uint32) (*segment.Segment, bool)
 	return seg, true
 }
 
+// handleReserveSegmentsRequest reserves all sealed segments and sends them back to the requester. Since this
+// is performed on the control loop, no segment can be garbage collected while the reservations are being taken.
+func (c *controlLoop) handleReserveSegmentsRequest(req *controlLoopReserveSegmentsRequest) {
+	if req.sealMutableSegment && c.segments[c.highestSegmentIndex].KeyCount() > 0 {
+		// Seal the mutable segment so that all data written up until this point becomes visible.
+		err := c.expandSegments()
+		if err != nil {
+			c.fatalErrorHandler.Panic(fmt.Errorf("failed to expand segments: %w", err))
+			return
+		}
+	}
+
+	segments, err := c.reserveSealedSegments()
+	if err != nil {
+		c.fatalErrorHandler.Panic(err)
+		return
+	}
+
+	req.responseChan <- segments
+}
+
+// reserveSealedSegments reserves all sealed segments, returning them in order of increasing segment index.
+// It is the caller's responsibility to release the reservations.
+func (c *controlLoop) reserveSealedSegments() ([]*segment.Segment, error) {
+	segments := make([]*segment.Segment, 0, c.highestSegmentIndex-c.lowestSegmentIndex+1)
+	for index := c.lowestSegmentIndex; index <= c.highestSegmentIndex; index++ {
+		seg := c.segments[index]
+		if !seg.IsSealed() {
+			// Only the highest segment can be unsealed.
+			break
+		}
+		if !seg.Reserve() {
+			// This should be impossible, the control loop holds a reservation on all segments in the map.
+			for _, reserved := range segments {
+				reserved.Release()
+			}
+			return nil, fmt.Errorf("failed to reserve segment %d", index)
+		}
+		segments = append(segments, seg)
+	}
+	return segments, nil
+}
+
+// handleSegmentInfoRequest gathers information about each segment and sends it back to the requester.
+func (c *controlLoop) handleSegmentInfoRequest(req *controlLoopSegmentInfoRequest) {
+	infos := make([]*litt.SegmentInfo, 0, c.highestSegmentIndex-c.lowestSegmentIndex+1)
+	for index := c.lowestSegmentIndex; index <= c.highestSegmentIndex; index++ {
+		infos = append(infos, newSegmentInfo(c.segments[index]))
+	}
+
+	req.responseChan <- infos
+}
+
+// newSegmentInfo builds a description of a segment.
+func newSegmentInfo(seg *segment.Segment) *litt.SegmentInfo {
+	info := &litt.SegmentInfo{
+		Index:      seg.Index(),
+		Version:    uint32(seg.Version()),
+		Sealed:     seg.IsSealed(),
+		ShardCount: seg.ShardingFactor(),
+		KeyCount:   seg.KeyCount(),
+		Size:       seg.Size(),
+	}
+	if info.Sealed {
+		info.SealTime = seg.GetSealTime()
+	}
+	return info
+}
+
+// handleScrubTick starts a background scrub of all sealed segments, unless a scrub is already in progress.
+func (c *controlLoop) handleScrubTick() {
+	if c.scrubber.isRunning() {
+		c.logger.Warnf("skipping scheduled scrub of table %s, previous scrub is still running", c.name)
+		return
+	}
+
+	segments, err := c.reserveSealedSegments()
+	if err != nil {
+		c.fatalErrorHandler.Panic(err)
+		return
+	}
+
+	// The flag is set here rather than by the scrub goroutine, so that the next tick can't start a second scrub
+	// before the goroutine gets around to setting it.
+	c.scrubber.running.Store(true)
+	go func() {
+		defer c.scrubber.running.Store(false)
+		c.scrubber.scrub(segments)
+	}()
+}
+
 // getSegments returns the segments of the disk table. It is only legal to call this after the control loop has been
 // stopped.
 func (c *controlLoop) getSegments() (map[uint32]*segment.Segment, error) {
@@ -389,6 +532,33 @@ func (c *controlLoop) handleControlLoopSetShardingFactorRequest(req *controlLoop
 	}
 }
 
+// handleSetSegmentDirectoriesRequest changes the directories where segment files are stored. The mutable segment is
+// sealed and replaced, so that all segments created from this point onward use the new set of directories.
+func (c *controlLoop) handleSetSegmentDirectoriesRequest(req *controlLoopSetSegmentDirectoriesRequest) {
+	c.segmentDirectories = req.segmentDirectories
+
+	// The files of the current mutable segment may be in a directory that is being removed. Sealing it makes it
+	// possible to move those files elsewhere.
+	err := c.expandSegments()
+	if err != nil {
+		c.fatalErrorHandler.Panic(fmt.Errorf("failed to expand segments: %w", err))
+		return
+	}
+
+	if !req.reserveSegments {
+		req.responseChan <- nil
+		return
+	}
+
+	segments, err := c.reserveSealedSegments()
+	if err != nil {
+		c.fatalErrorHandler.Panic(err)
+		return
+	}
+
+	req.responseChan <- segments
+}
+
 // handleShutdownRequest performs tasks necessary to cleanly shut down the disk table.
 func (c *controlLoop) handleShutdownRequest(req *controlLoopShutdownRequest) {
 	// Instruct the flush loop to stop.
@@ -1,6 +1,10 @@
 package disktable
 
-import "github.com/Layr-Labs/eigenda/litt/types"
+import (
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
+	"github.com/Layr-Labs/eigenda/litt/types"
+)
 
 // This file contains various messages that can be sent to the disk table's control loop.
 
@@ -51,3 +55,49 @@ type controlLoopGCRequest struct {
 	// completionChan produces a value when the garbage collection is complete.
 	completionChan chan struct{}
 }
+
+// controlLoopReserveSegmentsRequest is a request to reserve all sealed segments that is sent to the control loop.
+type controlLoopReserveSegmentsRequest struct {
+	controlLoopMessage
+
+	// If true, the mutable segment is sealed (if it contains data) before segments are reserved.
+	sealMutableSegment bool
+
+	// responseChan produces the reserved segments, in order of increasing segment index.
+	responseChan chan []*segment.Segment
+}
+
+// controlLoopSegmentInfoRequest is a request for information about each segment that is sent to the control loop.
+type controlLoopSegmentInfoRequest struct {
+	controlLoopMessage
+
+	// responseChan produces information about each segment, in order of increasing segment index.
+	responseChan chan []*litt.SegmentInfo
+}
+
+// controlLoopSetSegmentDirectoriesRequest is a request to change the directories where new segment files are stored
+// that is sent to the control loop.
+type controlLoopSetSegmentDirectoriesRequest struct {
+	controlLoopMessage
+
+	// The directories where new segment files should be stored.
+	segmentDirectories []string
+
+	// If true, all sealed segments are reserved and sent back via the response channel.
+	reserveSegments bool
+
+	// responseChan produces a value once the change has been made. If reserveSegments is true, the value contains
+	// the reserved segments in order of increasing segment index, otherwise it is nil.
+	responseChan chan []*segment.Segment
+}
+
+// controlLoopDeleteKeysRequest is a request to remove keys from the keymap that is sent to the control loop.
+type controlLoopDeleteKeysRequest struct {
+	controlLoopMessage
+
+	// The keys to remove from the keymap.
+	keys []*types.ScopedKey
+
+	// responseChan produces a value once the keys have been removed.
+	responseChan chan struct{}
+}
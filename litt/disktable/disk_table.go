@@ -47,6 +47,13 @@ type DiskTable struct {
 	// The directories where segment files are stored.
 	segmentDirectories []string
 
+	// Root directories that have been removed from the table, but that may still contain segment files that have
+	// not yet been moved onto the remaining root directories.
+	retiredRoots []string
+
+	// Protects roots, segmentDirectories, and retiredRoots. Also serializes requests to add and remove roots.
+	rootsLock sync.Mutex
+
 	// The table's name.
 	name string
 
@@ -82,6 +89,12 @@ type DiskTable struct {
 	// The flush loop is a goroutine responsible for blocking on flush operations.
 	flushLoop *flushLoop
 
+	// The scrubber verifies the integrity of data in sealed segments.
+	scrubber *scrubber
+
+	// The path migrator moves segment files out of root directories that have been removed.
+	pathMigrator *pathMigrator
+
 	// Encapsulates metrics for the database.
 	metrics *metrics.LittDBMetrics
 }
@@ -263,6 +276,29 @@ func NewDiskTable(
 	table.flushLoop = fLoop
 	go fLoop.run()
 
+	scrub := &scrubber{
+		logger:            config.Logger,
+		fatalErrorHandler: fatalErrorHandler,
+		metrics:           metrics,
+		clock:             config.Clock,
+		name:              name,
+		quarantine:        config.QuarantineCorruptSegments,
+		quarantinePath:    path.Join(roots[0], quarantineDirectory),
+		quarantined:       make(map[uint32]map[string]struct{}),
+	}
+	table.scrubber = scrub
+
+	table.pathMigrator = &pathMigrator{
+		logger:            config.Logger,
+		fatalErrorHandler: fatalErrorHandler,
+		metrics:           metrics,
+		clock:             config.Clock,
+		name:              name,
+		fsync:             config.Fsync,
+		destinations:      table.getSegmentDirectories,
+		onComplete:        table.finishRemovingRoot,
+	}
+
 	// Start the control loop.
 	cLoop := &controlLoop{
 		logger:                  config.Logger,
@@ -289,9 +325,12 @@ func NewDiskTable(
 		flushLoop:               fLoop,
 		garbageCollectionPeriod: config.GCPeriod,
 		immutableSegmentSize:    immutableSegmentSize,
+		scrubPeriod:             config.ScrubPeriod,
+		scrubber:                scrub,
 	}
 	cLoop.threadsafeHighestSegmentIndex.Store(highestSegmentIndex)
 	table.controlLoop = cLoop
+	scrub.controlLoop = cLoop
 	cLoop.updateCurrentSize()
 	go cLoop.run()
 
@@ -384,6 +423,14 @@ func (d *DiskTable) Close() error {
 
 	d.fatalErrorHandler.Shutdown()
 
+	// Wait for any in-progress scrub to abort. The scrubber may send requests to the control loop, which is stopped
+	// below.
+	d.scrubber.stop()
+
+	// Wait for any in-progress path migration to abort. A migration that is interrupted is not resumed
+	// automatically, see RemoveRoot().
+	d.pathMigrator.stop()
+
 	shutdownCompleteChan := make(chan struct{}, 1)
 	request := &controlLoopShutdownRequest{
 		shutdownCompleteChan: shutdownCompleteChan,
@@ -439,6 +486,16 @@ func (d *DiskTable) Destroy() error {
 		}
 	}
 
+	// delete root directories that were in the process of being removed, their segment files are gone now
+	for _, root := range d.retiredRoots {
+		for _, directory := range []string{path.Join(root, segmentDirectory), root} {
+			err = os.Remove(directory)
+			if err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to remove directory %s: %w", directory, err)
+			}
+		}
+	}
+
 	// destroy the keymap
 	err = d.keymap.Destroy()
 	if err != nil {
@@ -459,6 +516,19 @@ func (d *DiskTable) Destroy() error {
 		}
 	}
 
+	// delete quarantined segment files, if there are any
+	quarantinePath := path.Join(d.roots[0], quarantineDirectory)
+	exists, err = util.Exists(quarantinePath)
+	if err != nil {
+		return fmt.Errorf("failed to check if quarantine directory exists: %w", err)
+	}
+	if exists {
+		err = os.RemoveAll(quarantinePath)
+		if err != nil {
+			return fmt.Errorf("failed to remove quarantine directory: %w", err)
+		}
+	}
+
 	// delete the metadata file
 	err = d.metadata.delete()
 	if err != nil {
@@ -476,6 +546,185 @@ func (d *DiskTable) Destroy() error {
 	return nil
 }
 
+// AddRoot adds a root directory to the table. Segments created after this method returns spread their files across
+// all of the table's root directories, including the new one.
+func (d *DiskTable) AddRoot(root string) error {
+	if ok, err := d.fatalErrorHandler.IsOk(); !ok {
+		return fmt.Errorf("Cannot process AddRoot() request, DB is in panicked state due to error: %w", err)
+	}
+
+	d.rootsLock.Lock()
+	defer d.rootsLock.Unlock()
+
+	for _, existingRoot := range d.roots {
+		if path.Clean(existingRoot) == path.Clean(root) {
+			return fmt.Errorf("%s is already a root directory of table %s", root, d.name)
+		}
+	}
+	for _, retiredRoot := range d.retiredRoots {
+		if path.Clean(retiredRoot) == path.Clean(root) {
+			return fmt.Errorf("%s is still being removed from table %s", root, d.name)
+		}
+	}
+
+	segDir := path.Join(root, segmentDirectory)
+	err := os.MkdirAll(segDir, 0755)
+	if err != nil {
+		return fmt.Errorf("failed to create segment directory: %w", err)
+	}
+
+	segmentDirectories := make([]string, 0, len(d.segmentDirectories)+1)
+	segmentDirectories = append(segmentDirectories, d.segmentDirectories...)
+	segmentDirectories = append(segmentDirectories, segDir)
+
+	_, err = d.setSegmentDirectories(segmentDirectories, false)
+	if err != nil {
+		return err
+	}
+
+	d.roots = append(d.roots, root)
+	d.segmentDirectories = segmentDirectories
+	d.logger.Infof("added root directory %s to table %s", root, d.name)
+
+	return nil
+}
+
+// RemoveRoot removes a root directory from the table. Once this method returns, no new segment files are placed in
+// the root directory. Existing segment files are moved onto the remaining root directories in the background, and
+// data remains readable while this happens. The root directory is deleted once it is empty.
+//
+// The first root directory, and any root directory that holds the table's metadata or keymap, cannot be removed.
+// If the table is closed before the migration is complete, the remaining files are left in place. In that case the
+// root directory must still be provided the next time the table is opened, and it can be removed again then.
+func (d *DiskTable) RemoveRoot(root string) error {
+	if ok, err := d.fatalErrorHandler.IsOk(); !ok {
+		return fmt.Errorf("Cannot process RemoveRoot() request, DB is in panicked state due to error: %w", err)
+	}
+
+	d.rootsLock.Lock()
+	defer d.rootsLock.Unlock()
+
+	index, err := d.checkRemoveRoot(root)
+	if err != nil {
+		return err
+	}
+
+	roots := make([]string, 0, len(d.roots)-1)
+	roots = append(roots, d.roots[:index]...)
+	roots = append(roots, d.roots[index+1:]...)
+	segmentDirectories := make([]string, 0, len(d.segmentDirectories)-1)
+	segmentDirectories = append(segmentDirectories, d.segmentDirectories[:index]...)
+	segmentDirectories = append(segmentDirectories, d.segmentDirectories[index+1:]...)
+
+	segments, err := d.setSegmentDirectories(segmentDirectories, true)
+	if err != nil {
+		return err
+	}
+
+	d.roots = roots
+	d.segmentDirectories = segmentDirectories
+	d.retiredRoots = append(d.retiredRoots, root)
+	d.logger.Infof("removed root directory %s from table %s", root, d.name)
+
+	go d.pathMigrator.migrate(root, segments)
+
+	return nil
+}
+
+// CheckRemoveRoot returns the error RemoveRoot would return for the given root directory, without removing it. This
+// allows a root directory to be checked against every table of a DB before it is removed from any of them.
+func (d *DiskTable) CheckRemoveRoot(root string) error {
+	if ok, err := d.fatalErrorHandler.IsOk(); !ok {
+		return fmt.Errorf("Cannot process CheckRemoveRoot() request, DB is in panicked state due to error: %w", err)
+	}
+
+	d.rootsLock.Lock()
+	defer d.rootsLock.Unlock()
+
+	_, err := d.checkRemoveRoot(root)
+	return err
+}
+
+// checkRemoveRoot verifies that a root directory can be removed from the table, and returns its index in d.roots.
+// The caller must hold rootsLock.
+func (d *DiskTable) checkRemoveRoot(root string) (int, error) {
+	index := -1
+	for i, existingRoot := range d.roots {
+		if path.Clean(existingRoot) == path.Clean(root) {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		return 0, fmt.Errorf("%s is not a root directory of table %s", root, d.name)
+	}
+	if index == 0 {
+		return 0, fmt.Errorf("cannot remove %s, the first root directory of a table cannot be removed", root)
+	}
+	err := VerifyRootRemovable(root)
+	if err != nil {
+		return 0, err
+	}
+	return index, nil
+}
+
+// setSegmentDirectories changes the directories where new segment files are stored. If reserveSegments is true,
+// all sealed segments are reserved and returned, and it is the caller's responsibility to release them.
+func (d *DiskTable) setSegmentDirectories(
+	segmentDirectories []string,
+	reserveSegments bool) ([]*segment.Segment, error) {
+
+	request := &controlLoopSetSegmentDirectoriesRequest{
+		segmentDirectories: segmentDirectories,
+		reserveSegments:    reserveSegments,
+		responseChan:       make(chan []*segment.Segment, 1),
+	}
+	err := d.controlLoop.enqueue(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send set segment directories request: %w", err)
+	}
+
+	segments, err := util.AwaitIfNotFatal(d.fatalErrorHandler, request.responseChan)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set segment directories: %w", err)
+	}
+
+	return segments, nil
+}
+
+// getSegmentDirectories returns a copy of the directories where new segment files are stored.
+func (d *DiskTable) getSegmentDirectories() []string {
+	d.rootsLock.Lock()
+	defer d.rootsLock.Unlock()
+
+	segmentDirectories := make([]string, len(d.segmentDirectories))
+	copy(segmentDirectories, d.segmentDirectories)
+	return segmentDirectories
+}
+
+// finishRemovingRoot is called once all segment files have been moved out of a root directory that was removed
+// from the table. Deletes the root directory.
+func (d *DiskTable) finishRemovingRoot(root string) {
+	d.rootsLock.Lock()
+	defer d.rootsLock.Unlock()
+
+	for i, retiredRoot := range d.retiredRoots {
+		if retiredRoot == root {
+			d.retiredRoots = append(d.retiredRoots[:i], d.retiredRoots[i+1:]...)
+			break
+		}
+	}
+
+	for _, directory := range []string{path.Join(root, segmentDirectory), root} {
+		err := os.Remove(directory)
+		if err != nil && !os.IsNotExist(err) {
+			// This can happen if the directory contains unrecognized files.
+			d.logger.Warnf("failed to remove directory %s, it may need to be removed manually: %v", directory, err)
+			return
+		}
+	}
+}
+
 // SetTTL sets the TTL for the disk table. If set to 0, no TTL is enforced. This setting affects both new
 // data and data already written.
 func (d *DiskTable) SetTTL(ttl time.Duration) error {
@@ -741,6 +990,55 @@ func (d *DiskTable) RunGC() error {
 	return nil
 }
 
+// GetSegmentInfo returns information about each of the table's segments, in order of increasing segment index.
+func (d *DiskTable) GetSegmentInfo() ([]*litt.SegmentInfo, error) {
+	if ok, err := d.fatalErrorHandler.IsOk(); !ok {
+		return nil, fmt.Errorf(
+			"Cannot process GetSegmentInfo() request, DB is in panicked state due to error: %w", err)
+	}
+
+	request := &controlLoopSegmentInfoRequest{
+		responseChan: make(chan []*litt.SegmentInfo, 1),
+	}
+	err := d.controlLoop.enqueue(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send segment info request: %w", err)
+	}
+
+	infos, err := util.AwaitIfNotFatal(d.fatalErrorHandler, request.responseChan)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get segment info: %w", err)
+	}
+
+	return infos, nil
+}
+
+// Scrub reads back all data in the table and verifies it against the checksums stored on disk. The mutable segment
+// is sealed before the scrub begins, so all data written prior to this call is verified. This method blocks until
+// the scrub is complete. If a background scrub is already in progress, this method waits for it to finish first.
+func (d *DiskTable) Scrub() (*ScrubReport, error) {
+	if ok, err := d.fatalErrorHandler.IsOk(); !ok {
+		return nil, fmt.Errorf(
+			"Cannot process Scrub() request, DB is in panicked state due to error: %w", err)
+	}
+
+	request := &controlLoopReserveSegmentsRequest{
+		sealMutableSegment: true,
+		responseChan:       make(chan []*segment.Segment, 1),
+	}
+	err := d.controlLoop.enqueue(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send reserve segments request: %w", err)
+	}
+
+	segments, err := util.AwaitIfNotFatal(d.fatalErrorHandler, request.responseChan)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve segments: %w", err)
+	}
+
+	return d.scrubber.scrub(segments), nil
+}
+
 // writeKeysToKeymap flushes all keys to the keymap. Once they are flushed, it also removes the keys from the
 // unflushedDataCache.
 func (d *DiskTable) writeKeysToKeymap(keys []*types.ScopedKey) error {
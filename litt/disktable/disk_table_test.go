@@ -1,6 +1,7 @@
 package disktable
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path"
@@ -1257,7 +1258,7 @@ func truncatedValueFileTest(t *testing.T, tableBuilder *tableBuilder) {
 		offset := key.Address.Offset()
 		valueSize := len(expectedValues[string(key.Key)])
 		// If there are not at least this many bytes remaining in the value file, the value is missing.
-		requiredLength := offset + uint32(valueSize) + 4
+		requiredLength := offset + uint32(valueSize) + 4 /* length prefix */ + 4 /* checksum */
 		if requiredLength > uint32(len(valueFileBytes)) {
 			missingKeys[string(key.Key)] = struct{}{}
 		}
@@ -2298,3 +2299,266 @@ func TestTableSize(t *testing.T) {
 		})
 	}
 }
+
+// This test flips a bit in a value file and verifies that the scrubber detects and quarantines the corruption.
+func scrubTest(t *testing.T, tableBuilder *tableBuilder) {
+	rand := random.NewTestRandom()
+
+	logger, err := common.NewLogger(common.DefaultTextLoggerConfig())
+	require.NoError(t, err)
+
+	directory := t.TempDir()
+
+	tableName := rand.String(8)
+	table, err := tableBuilder.builder(time.Now, tableName, []string{directory})
+	require.NoError(t, err)
+
+	expectedValues := make(map[string][]byte)
+	for i := 0; i < 100; i++ {
+		key := rand.PrintableVariableBytes(32, 64)
+		value := rand.PrintableVariableBytes(1, 128)
+		err = table.Put(key, value)
+		require.NoError(t, err)
+		expectedValues[string(key)] = value
+	}
+
+	// Scrubbing an uncorrupted table should find nothing.
+	report, err := table.(*DiskTable).Scrub()
+	require.NoError(t, err)
+	require.Equal(t, uint64(len(expectedValues)), report.KeysScrubbed)
+	require.Empty(t, report.CorruptKeys)
+	require.Empty(t, report.CorruptSegments)
+
+	err = table.Close()
+	require.NoError(t, err)
+
+	// Find a sealed segment that contains at least one key.
+	lowestSegmentIndex, highestSegmentIndex, segments, err := segment.GatherSegmentFiles(
+		logger,
+		table.(*DiskTable).fatalErrorHandler,
+		[]string{directory + "/table/segments"},
+		time.Now())
+	require.NoError(t, err)
+
+	var corruptSegmentIndex uint32
+	var segmentKeys []*types.ScopedKey
+	for index := lowestSegmentIndex; index <= highestSegmentIndex; index++ {
+		segmentKeys, err = segments[index].GetKeys()
+		require.NoError(t, err)
+		if len(segmentKeys) > 0 {
+			corruptSegmentIndex = index
+			break
+		}
+	}
+	require.NotEmpty(t, segmentKeys)
+
+	// Flip a bit in the first byte of a value. The first 4 bytes at the value's offset are its length prefix.
+	corruptKey := segmentKeys[rand.Intn(len(segmentKeys))]
+	shard := segments[corruptSegmentIndex].GetShard(corruptKey.Key)
+	valueFileName := fmt.Sprintf("%s/table/segments/%d-%d%s",
+		directory, corruptSegmentIndex, shard, segment.ValuesFileExtension)
+	valueFileBytes, err := os.ReadFile(valueFileName)
+	require.NoError(t, err)
+	valueFileBytes[corruptKey.Address.Offset()+4] ^= 1
+	err = os.WriteFile(valueFileName, valueFileBytes, 0644)
+	require.NoError(t, err)
+
+	// Restart the table with quarantine enabled.
+	table, err = tableBuilder.builder(time.Now, tableName, []string{directory})
+	require.NoError(t, err)
+	table.(*DiskTable).scrubber.quarantine = true
+
+	// Reading the corrupt value should fail.
+	_, _, err = table.Get(corruptKey.Key)
+	require.Error(t, err)
+
+	report, err = table.(*DiskTable).Scrub()
+	require.NoError(t, err)
+	require.Equal(t, uint64(len(expectedValues)), report.KeysScrubbed)
+	require.Equal(t, 1, len(report.CorruptKeys))
+	require.Equal(t, corruptKey.Key, report.CorruptKeys[0].Key)
+	require.Equal(t, []uint32{corruptSegmentIndex}, report.CorruptSegments)
+	require.Equal(t, []uint32{corruptSegmentIndex}, report.QuarantinedSegments)
+
+	// A copy of the segment's files should be in the quarantine directory.
+	quarantinedValueFile := fmt.Sprintf("%s/table/%s/%d/%d-%d%s",
+		directory, quarantineDirectory, corruptSegmentIndex, corruptSegmentIndex, shard, segment.ValuesFileExtension)
+	quarantinedBytes, err := os.ReadFile(quarantinedValueFile)
+	require.NoError(t, err)
+	require.Equal(t, valueFileBytes, quarantinedBytes)
+
+	// Only the corrupt key should no longer be visible. All other keys, including the other keys in the quarantined
+	// segment, should be unaffected.
+	for expectedKey, expectedValue := range expectedValues {
+		value, ok, err := table.Get([]byte(expectedKey))
+		require.NoError(t, err)
+		if expectedKey == string(corruptKey.Key) {
+			require.False(t, ok)
+		} else {
+			require.True(t, ok)
+			require.Equal(t, expectedValue, value)
+		}
+	}
+
+	// A segment should only be quarantined once. The remaining keys in the segment are still verified.
+	report, err = table.(*DiskTable).Scrub()
+	require.NoError(t, err)
+	require.Equal(t, uint64(len(expectedValues)-1), report.KeysScrubbed)
+	require.Empty(t, report.CorruptKeys)
+	require.Empty(t, report.QuarantinedSegments)
+
+	ok, _ := table.(*DiskTable).fatalErrorHandler.IsOk()
+	require.True(t, ok)
+	err = table.Destroy()
+	require.NoError(t, err)
+
+	// ensure that the test directory is empty
+	entries, err := os.ReadDir(directory)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestScrub(t *testing.T) {
+	t.Parallel()
+	for _, tb := range tableBuilders {
+		t.Run(tb.name, func(t *testing.T) {
+			scrubTest(t, tb)
+		})
+	}
+}
+
+// This test verifies that incremental snapshots drop segments that have been garbage collected.
+func snapshotGarbageCollectionTest(t *testing.T, tableBuilder *tableBuilder) {
+	rand := random.NewTestRandom()
+
+	directory := t.TempDir()
+	snapshotDirectory := path.Join(directory, "snapshot")
+
+	startTime := rand.Time()
+	var fakeTime atomic.Pointer[time.Time]
+	fakeTime.Store(&startTime)
+	clock := func() time.Time {
+		return *fakeTime.Load()
+	}
+
+	tableName := rand.String(8)
+	table, err := tableBuilder.builder(clock, tableName, []string{path.Join(directory, "source")})
+	require.NoError(t, err)
+
+	ttl := time.Minute
+	err = table.SetTTL(ttl)
+	require.NoError(t, err)
+
+	expiredKeys := make([][]byte, 0, 100)
+	for i := 0; i < 100; i++ {
+		key := rand.PrintableVariableBytes(32, 64)
+		err = table.Put(key, rand.PrintableVariableBytes(1, 128))
+		require.NoError(t, err)
+		expiredKeys = append(expiredKeys, key)
+	}
+
+	err = table.Snapshot(snapshotDirectory)
+	require.NoError(t, err)
+	snapshotSegmentDirectory := path.Join(snapshotDirectory, segmentDirectory)
+	firstSnapshotIndices, err := segment.GetSnapshotSegmentIndices(snapshotSegmentDirectory)
+	require.NoError(t, err)
+	require.NotEmpty(t, firstSnapshotIndices)
+
+	// Expire all data currently in the table, then write new data.
+	newTime := startTime.Add(2 * ttl)
+	fakeTime.Store(&newTime)
+	err = table.RunGC()
+	require.NoError(t, err)
+
+	expectedValues := make(map[string][]byte)
+	for i := 0; i < 100; i++ {
+		key := rand.PrintableVariableBytes(32, 64)
+		value := rand.PrintableVariableBytes(1, 128)
+		err = table.Put(key, value)
+		require.NoError(t, err)
+		expectedValues[string(key)] = value
+	}
+
+	err = table.Snapshot(snapshotDirectory)
+	require.NoError(t, err)
+	secondSnapshotIndices, err := segment.GetSnapshotSegmentIndices(snapshotSegmentDirectory)
+	require.NoError(t, err)
+	require.NotEmpty(t, secondSnapshotIndices)
+	for index := range firstSnapshotIndices {
+		_, present := secondSnapshotIndices[index]
+		require.False(t, present, "segment %d should have been removed from the snapshot", index)
+	}
+
+	logger, err := common.NewLogger(common.DefaultTextLoggerConfig())
+	require.NoError(t, err)
+
+	// The snapshot's keymap should contain exactly the keys in the snapshot.
+	snapshotKeymapDirectory := path.Join(snapshotDirectory, keymap.KeymapDirectoryName)
+	exists, err := util.Exists(path.Join(snapshotKeymapDirectory, keymap.KeymapInitializedFileName))
+	require.NoError(t, err)
+	require.True(t, exists)
+	snapshotKeymap, _, err := keymap.NewLevelDBKeymap(
+		logger, path.Join(snapshotKeymapDirectory, keymap.KeymapDataDirectoryName), false)
+	require.NoError(t, err)
+	for key := range expectedValues {
+		address, ok, err := snapshotKeymap.Get([]byte(key))
+		require.NoError(t, err)
+		require.True(t, ok)
+		_, present := secondSnapshotIndices[address.Index()]
+		require.True(t, present)
+	}
+	for _, key := range expiredKeys {
+		_, ok, err := snapshotKeymap.Get(key)
+		require.NoError(t, err)
+		require.False(t, ok)
+	}
+	err = snapshotKeymap.Stop()
+	require.NoError(t, err)
+
+	err = table.Destroy()
+	require.NoError(t, err)
+
+	// Restore the snapshot and verify that it contains the new data.
+	restoreDirectory := path.Join(directory, "restore")
+	err = RestoreSnapshot(snapshotDirectory, []string{path.Join(restoreDirectory, "table")})
+	require.NoError(t, err)
+
+	// Restoring a second time on top of the restored data is not permitted.
+	err = RestoreSnapshot(snapshotDirectory, []string{path.Join(restoreDirectory, "table")})
+	require.Error(t, err)
+
+	// The keymap is restored along with the segments, so it does not need to be rebuilt.
+	exists, err = util.Exists(
+		path.Join(restoreDirectory, "table", keymap.KeymapDirectoryName, keymap.KeymapInitializedFileName))
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	// Read the restored segments directly.
+	lowestSegmentIndex, highestSegmentIndex, segments, err := segment.GatherSegmentFiles(
+		logger,
+		util.NewFatalErrorHandler(context.Background(), logger, nil),
+		[]string{path.Join(restoreDirectory, "table", segmentDirectory)},
+		clock())
+	require.NoError(t, err)
+
+	restoredValues := make(map[string][]byte)
+	for index := lowestSegmentIndex; index <= highestSegmentIndex; index++ {
+		keys, err := segments[index].GetKeys()
+		require.NoError(t, err)
+		for _, key := range keys {
+			value, err := segments[index].Read(key.Key, key.Address)
+			require.NoError(t, err)
+			restoredValues[string(key.Key)] = value
+		}
+	}
+	require.Equal(t, expectedValues, restoredValues)
+}
+
+func TestSnapshotGarbageCollection(t *testing.T) {
+	t.Parallel()
+	for _, tb := range tableBuilders {
+		t.Run(tb.name, func(t *testing.T) {
+			snapshotGarbageCollectionTest(t, tb)
+		})
+	}
+}
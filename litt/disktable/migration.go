@@ -0,0 +1,194 @@
+package disktable
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"sort"
+
+	"github.com/Layr-Labs/eigenda/litt/disktable/keymap"
+	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// ListTables returns the names of all disk tables with data in the given DB paths, sorted by name.
+func ListTables(paths []string) ([]string, error) {
+	names := make(map[string]struct{})
+	for _, p := range paths {
+		entries, err := os.ReadDir(p)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read directory %s: %w", p, err)
+		}
+
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			exists, err := util.Exists(path.Join(p, entry.Name(), segmentDirectory))
+			if err != nil {
+				return nil, fmt.Errorf("failed to check for segment directory: %w", err)
+			}
+			if exists {
+				names[entry.Name()] = struct{}{}
+			}
+		}
+	}
+
+	sortedNames := make([]string, 0, len(names))
+	for name := range names {
+		sortedNames = append(sortedNames, name)
+	}
+	sort.Strings(sortedNames)
+
+	return sortedNames, nil
+}
+
+// MigrateTable moves the files of a disk table from one set of root directories to another. Segment files are
+// spread across the new root directories, and the table metadata, keymap, and quarantine directories are moved
+// if their current root directory is not one of the new root directories. Old root directories that are not
+// in the new set of root directories are deleted once they are empty.
+//
+// The table must not be in use while it is being migrated. If a migration is interrupted, it is safe to call
+// this method again with the same arguments.
+func MigrateTable(logger logging.Logger, oldRoots []string, newRoots []string) error {
+	if len(newRoots) == 0 {
+		return fmt.Errorf("at least one new root directory must be provided")
+	}
+
+	isNewRoot := make(map[string]bool, len(newRoots))
+	for _, root := range newRoots {
+		isNewRoot[path.Clean(root)] = true
+	}
+
+	// Only consider old roots that actually exist. A previous interrupted migration may have already removed some.
+	existingOldRoots := make([]string, 0, len(oldRoots))
+	for _, root := range oldRoots {
+		exists, err := util.Exists(root)
+		if err != nil {
+			return fmt.Errorf("failed to check if root %s exists: %w", root, err)
+		}
+		if exists {
+			existingOldRoots = append(existingOldRoots, root)
+		}
+	}
+
+	oldSegmentDirectories := make([]string, 0, len(existingOldRoots))
+	for _, root := range existingOldRoots {
+		segDir := path.Join(root, segmentDirectory)
+		exists, err := util.Exists(segDir)
+		if err != nil {
+			return fmt.Errorf("failed to check if segment directory %s exists: %w", segDir, err)
+		}
+		if exists {
+			oldSegmentDirectories = append(oldSegmentDirectories, segDir)
+		}
+	}
+
+	newSegmentDirectories := make([]string, 0, len(newRoots))
+	for _, root := range newRoots {
+		segDir := path.Join(root, segmentDirectory)
+		err := os.MkdirAll(segDir, 0755)
+		if err != nil {
+			return fmt.Errorf("failed to create segment directory %s: %w", segDir, err)
+		}
+		newSegmentDirectories = append(newSegmentDirectories, segDir)
+	}
+
+	err := segment.MigrateSegmentFiles(oldSegmentDirectories, newSegmentDirectories)
+	if err != nil {
+		return fmt.Errorf("failed to migrate segment files: %w", err)
+	}
+
+	for _, root := range existingOldRoots {
+		if isNewRoot[path.Clean(root)] {
+			continue
+		}
+
+		// Move the table metadata file.
+		exists, err := util.Exists(metadataPath(root))
+		if err != nil {
+			return fmt.Errorf("failed to check if table metadata exists: %w", err)
+		}
+		if exists {
+			logger.Infof("moving table metadata from %s to %s", root, newRoots[0])
+			err = util.MoveFile(metadataPath(root), metadataPath(newRoots[0]))
+			if err != nil {
+				return fmt.Errorf("failed to move table metadata: %w", err)
+			}
+		}
+
+		// Move the keymap.
+		keymapDirectory := path.Join(root, keymap.KeymapDirectoryName)
+		exists, err = util.Exists(keymapDirectory)
+		if err != nil {
+			return fmt.Errorf("failed to check if keymap directory exists: %w", err)
+		}
+		if exists {
+			logger.Infof("moving keymap from %s to %s", root, newRoots[0])
+			err = util.MoveDirectory(keymapDirectory, path.Join(newRoots[0], keymap.KeymapDirectoryName))
+			if err != nil {
+				return fmt.Errorf("failed to move keymap: %w", err)
+			}
+		}
+	}
+
+	// Quarantined segments are always stored in the first root directory.
+	for _, root := range existingOldRoots {
+		if path.Clean(root) == path.Clean(newRoots[0]) {
+			continue
+		}
+		err = migrateQuarantine(root, newRoots[0])
+		if err != nil {
+			return fmt.Errorf("failed to migrate quarantine directory: %w", err)
+		}
+	}
+
+	// Clean up old root directories that are no longer in use.
+	for _, root := range existingOldRoots {
+		if isNewRoot[path.Clean(root)] {
+			continue
+		}
+
+		err = os.Remove(path.Join(root, segmentDirectory))
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove segment directory in %s: %w", root, err)
+		}
+		err = os.Remove(root)
+		if err != nil {
+			return fmt.Errorf("failed to remove root directory %s, it may contain unrecognized files: %w", root, err)
+		}
+	}
+
+	return nil
+}
+
+// migrateQuarantine moves quarantined segments from one root directory to another.
+func migrateQuarantine(oldRoot string, newRoot string) error {
+	oldQuarantine := path.Join(oldRoot, quarantineDirectory)
+	entries, err := os.ReadDir(oldQuarantine)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read directory %s: %w", oldQuarantine, err)
+	}
+
+	newQuarantine := path.Join(newRoot, quarantineDirectory)
+	for _, entry := range entries {
+		err = util.MoveDirectory(path.Join(oldQuarantine, entry.Name()), path.Join(newQuarantine, entry.Name()))
+		if err != nil {
+			return fmt.Errorf("failed to move quarantined segment %s: %w", entry.Name(), err)
+		}
+	}
+
+	err = os.Remove(oldQuarantine)
+	if err != nil {
+		return fmt.Errorf("failed to remove directory %s: %w", oldQuarantine, err)
+	}
+
+	return nil
+}
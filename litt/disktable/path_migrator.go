@@ -0,0 +1,154 @@
+package disktable
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/litt/disktable/keymap"
+	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
+	"github.com/Layr-Labs/eigenda/litt/metrics"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// pathMigrator moves segment files out of root directories that have been removed from a table. Migration is
+// performed in a background goroutine so that it does not block the control loop.
+type pathMigrator struct {
+	logger logging.Logger
+
+	// fatalErrorHandler is used to react to fatal errors anywhere in the disk table.
+	fatalErrorHandler *util.FatalErrorHandler
+
+	// Encapsulates metrics for the database.
+	metrics *metrics.LittDBMetrics
+
+	// clock is the time source used by the disk table.
+	clock func() time.Time
+
+	// The table's name.
+	name string
+
+	// Whether fsync mode is enabled.
+	fsync bool
+
+	// Returns the directories that segment files may be moved into. Called each time a segment is moved, so that
+	// directories added while a migration is in progress are used.
+	destinations func() []string
+
+	// Called once all segment files have been moved out of a root directory.
+	onComplete func(root string)
+
+	// Ensures that only one migration runs at a time.
+	lock sync.Mutex
+}
+
+// migrate moves all of the provided segments' files that are stored in the given root directory onto the table's
+// remaining directories. The caller must hold a reservation on each segment, this method releases each reservation
+// once it is done with the segment. If multiple migrations are requested at the same time, they are run one after
+// the other.
+func (m *pathMigrator) migrate(root string, segments []*segment.Segment) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	start := m.clock()
+	sourceDirectory := path.Join(root, segmentDirectory)
+
+	bytesRemaining, err := directorySize(sourceDirectory)
+	if err != nil {
+		m.logger.Errorf("failed to measure size of %s: %v", sourceDirectory, err)
+	}
+	m.logger.Infof("moving %d bytes of table %s out of %s", bytesRemaining, m.name, root)
+	m.metrics.ReportPathMigrationProgress(m.name, 0, bytesRemaining)
+
+	for i, seg := range segments {
+		if ok, _ := m.fatalErrorHandler.IsOk(); !ok {
+			// The DB is shutting down, abandon the migration.
+			for _, remaining := range segments[i:] {
+				remaining.Release()
+			}
+			return
+		}
+
+		bytesMoved, err := seg.Relocate(sourceDirectory, m.destinations(), m.fsync)
+		if bytesMoved > bytesRemaining {
+			bytesRemaining = 0
+		} else {
+			bytesRemaining -= bytesMoved
+		}
+		m.metrics.ReportPathMigrationProgress(m.name, bytesMoved, bytesRemaining)
+
+		if err != nil {
+			// The segment's files are still intact (possibly with a copy in two places), so there is no need to
+			// halt the DB. The data remains readable from the directory being removed.
+			m.logger.Errorf("failed to move segment %s of table %s out of %s, abandoning migration: %v",
+				seg.String(), m.name, root, err)
+			for _, remaining := range segments[i:] {
+				remaining.Release()
+			}
+			return
+		}
+		seg.Release()
+	}
+
+	m.logger.Infof("finished moving table %s out of %s in %v", m.name, root, m.clock().Sub(start))
+	m.onComplete(root)
+}
+
+// stop waits for any in-progress migration to complete. The caller is expected to have already put the fatal error
+// handler into a shutdown state, which causes an in-progress migration to abort early.
+func (m *pathMigrator) stop() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+}
+
+// directorySize returns the sum of the sizes of the files in a directory. Subdirectories are not counted.
+func directorySize(directory string) (uint64, error) {
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read directory %s: %w", directory, err)
+	}
+
+	size := uint64(0)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			if os.IsNotExist(err) {
+				// The file was deleted after the directory was read.
+				continue
+			}
+			return 0, fmt.Errorf("failed to stat %s: %w", entry.Name(), err)
+		}
+		size += uint64(info.Size())
+	}
+
+	return size, nil
+}
+
+// VerifyRootRemovable returns an error if the given root directory of a table holds files that can not be moved
+// while the table is in use, i.e. the table metadata or the keymap. Such a root directory can only be removed
+// from a table while the table is not in use (see MigrateTable()).
+func VerifyRootRemovable(root string) error {
+	exists, err := util.Exists(metadataPath(root))
+	if err != nil {
+		return fmt.Errorf("failed to check if table metadata exists: %w", err)
+	}
+	if exists {
+		return fmt.Errorf("root directory %s holds the table metadata and cannot be removed", root)
+	}
+
+	exists, err = util.Exists(path.Join(root, keymap.KeymapDirectoryName))
+	if err != nil {
+		return fmt.Errorf("failed to check if keymap directory exists: %w", err)
+	}
+	if exists {
+		return fmt.Errorf("root directory %s holds the keymap and cannot be removed", root)
+	}
+
+	return nil
+}
@@ -0,0 +1,561 @@
+package disktable
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
+	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+var _ litt.ManagedTable = (*readOnlyDiskTable)(nil)
+
+// readOnlyDiskTable provides read access to a disk table without modifying any of the table's files. This permits
+// a table to be read by one process while another process is writing to it.
+//
+// A read-only table only sees segments that were sealed when the table was opened or when the table was last
+// refreshed. Since the keymap on disk belongs to the process that is writing to the table, a read-only table
+// maintains its own in-memory index, which is built from the segment key files. The size of this index is bounded
+// by config.ReadOnlyMaxKeyCount.
+type readOnlyDiskTable struct {
+	// The logger for the table.
+	logger logging.Logger
+
+	// Used to put the table into a "panicked" state if an unrecoverable error is encountered.
+	fatalErrorHandler *util.FatalErrorHandler
+
+	// The context for the table.
+	ctx context.Context
+
+	// The table's name.
+	name string
+
+	// The directories where segment files are stored.
+	segmentDirectories []string
+
+	// The table's metadata. The TTL and sharding factor are updated when the table is refreshed.
+	metadata *tableMetadata
+
+	// The time source used by the table.
+	clock func() time.Time
+
+	// Protects segments, addresses, lowestSegmentIndex, and highestSegmentIndex. These fields are only modified by
+	// refresh(), which always holds this lock for writing while doing so.
+	lock sync.RWMutex
+
+	// The segments that are visible to this table, indexed by segment index. The table holds a reservation on
+	// each of these segments.
+	segments map[uint32]*segment.Segment
+
+	// The address of each key in the visible segments.
+	addresses map[string]types.Address
+
+	// The maximum number of keys in addresses. A refresh that would exceed this limit fails.
+	maxKeyCount uint64
+
+	// The index of the lowest visible segment. Meaningless if there are no visible segments.
+	lowestSegmentIndex uint32
+
+	// The index of the highest visible segment. Meaningless if there are no visible segments.
+	highestSegmentIndex uint32
+
+	// Ensures that only one refresh happens at a time.
+	refreshLock sync.Mutex
+
+	// Closed when the table is closed, stops the background refresh goroutine.
+	stopChan chan struct{}
+
+	// Closed when the background refresh goroutine exits. Nil if there is no background refresh goroutine.
+	refreshDoneChan chan struct{}
+
+	// Ensures that the table is only closed once.
+	closeOnce sync.Once
+}
+
+// NewReadOnlyDiskTable opens an existing disk table in read-only mode. Returns an error if the table does not
+// exist in the given root directories. If config.ReadOnlyRefreshPeriod is non-zero, the table periodically
+// refreshes its view of the data on disk in the background.
+func NewReadOnlyDiskTable(config *litt.Config, name string, roots []string) (litt.ManagedTable, error) {
+	var metadataDirectory string
+	for _, root := range roots {
+		exists, err := util.Exists(metadataPath(root))
+		if err != nil {
+			return nil, fmt.Errorf("failed to check if metadata file exists: %w", err)
+		}
+		if exists {
+			if metadataDirectory != "" {
+				return nil, fmt.Errorf("multiple metadata files found: %s and %s",
+					metadataPath(metadataDirectory), metadataPath(root))
+			}
+			metadataDirectory = root
+		}
+	}
+	if metadataDirectory == "" {
+		return nil, fmt.Errorf("table %s does not exist in %v, tables can not be created in read-only mode",
+			name, roots)
+	}
+
+	metadata, err := readTableMetadata(config.Logger, metadataDirectory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read table metadata: %w", err)
+	}
+
+	segDirs := make([]string, 0, len(roots))
+	for _, root := range roots {
+		segDir := path.Join(root, segmentDirectory)
+		exists, err := util.Exists(segDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check if segment directory exists: %w", err)
+		}
+		if exists {
+			segDirs = append(segDirs, segDir)
+		}
+	}
+
+	table := &readOnlyDiskTable{
+		logger:             config.Logger,
+		fatalErrorHandler:  util.NewFatalErrorHandler(config.CTX, config.Logger, config.FatalErrorCallback),
+		ctx:                config.CTX,
+		name:               name,
+		segmentDirectories: segDirs,
+		metadata:           metadata,
+		clock:              config.Clock,
+		segments:           make(map[uint32]*segment.Segment),
+		addresses:          make(map[string]types.Address),
+		maxKeyCount:        config.ReadOnlyMaxKeyCount,
+		stopChan:           make(chan struct{}),
+	}
+
+	err = table.refresh()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load segments: %w", err)
+	}
+
+	if config.ReadOnlyRefreshPeriod > 0 {
+		table.refreshDoneChan = make(chan struct{})
+		go table.refreshLoop(config.ReadOnlyRefreshPeriod)
+	}
+
+	return table, nil
+}
+
+// refreshLoop periodically refreshes the table until the table is closed.
+func (t *readOnlyDiskTable) refreshLoop(period time.Duration) {
+	defer close(t.refreshDoneChan)
+
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-t.stopChan:
+			return
+		case <-t.ctx.Done():
+			return
+		case <-ticker.C:
+			err := t.refresh()
+			if err != nil {
+				// The process that owns the table may modify files while we are looking at them, so
+				// errors here are not necessarily fatal. Try again on the next tick.
+				t.logger.Warnf("failed to refresh read-only table %s: %v", t.name, err)
+			}
+		}
+	}
+}
+
+// refresh updates this table's view of the data on disk. Segments that have been deleted by the process that
+// owns the table are dropped, and segments that have been sealed since the previous refresh are added.
+func (t *readOnlyDiskTable) refresh() error {
+	t.refreshLock.Lock()
+	defer t.refreshLock.Unlock()
+
+	start := t.clock()
+
+	// The TTL and sharding factor may have been changed by the process that owns the table.
+	metadata, err := readTableMetadata(t.logger, t.metadata.tableDirectory)
+	if err != nil {
+		return fmt.Errorf("failed to read table metadata: %w", err)
+	}
+	t.metadata.ttl.Store(metadata.ttl.Load())
+	t.metadata.shardingFactor.Store(metadata.GetShardingFactor())
+
+	// Only refresh() modifies the segment map, so it is safe to read it here without holding the lock.
+	removed := make([]*segment.Segment, 0)
+	if len(t.segments) > 0 {
+		for index := t.lowestSegmentIndex; index <= t.highestSegmentIndex; index++ {
+			present, err := t.segments[index].IsPresentOnDisk()
+			if err != nil {
+				return fmt.Errorf("failed to check segment %d: %w", index, err)
+			}
+			if present {
+				break
+			}
+			removed = append(removed, t.segments[index])
+		}
+	}
+
+	added := make([]*segment.Segment, 0)
+	rescan := len(removed) == len(t.segments)
+	if rescan {
+		// Either this is the first refresh, or every segment we know about has been deleted. In either case,
+		// there is no known segment to continue from, so scan the directories from scratch.
+		lowest, highest, segments, err := segment.GatherSealedSegments(
+			t.logger, t.fatalErrorHandler, t.segmentDirectories)
+		if err != nil {
+			return fmt.Errorf("failed to gather segments: %w", err)
+		}
+		if len(segments) > 0 {
+			for index := lowest; index <= highest; index++ {
+				added = append(added, segments[index])
+			}
+		}
+	} else {
+		for index := t.highestSegmentIndex + 1; ; index++ {
+			seg, ok, err := segment.LoadSealedSegment(t.logger, t.fatalErrorHandler, index, t.segmentDirectories)
+			if err != nil {
+				return fmt.Errorf("failed to load segment %d: %w", index, err)
+			}
+			if !ok {
+				break
+			}
+			added = append(added, seg)
+		}
+	}
+
+	// Only refresh() modifies the address map, so it is safe to read its size here without holding the lock.
+	keyCount := uint64(len(t.addresses))
+	for _, seg := range removed {
+		keyCount -= min(keyCount, uint64(seg.KeyCount()))
+	}
+	for _, seg := range added {
+		keyCount += uint64(seg.KeyCount())
+	}
+	if keyCount > t.maxKeyCount {
+		for _, seg := range added {
+			seg.Release()
+		}
+		return fmt.Errorf("table %s has %d keys in its sealed segments, more than the %d keys a read-only table "+
+			"may hold in memory (see ReadOnlyMaxKeyCount)", t.name, keyCount, t.maxKeyCount)
+	}
+
+	// Read the keys of new segments before acquiring the lock, since this requires disk access.
+	addedKeys := make([][]*types.ScopedKey, len(added))
+	for i, seg := range added {
+		keys, err := seg.GetKeys()
+		if err != nil {
+			for _, s := range added {
+				s.Release()
+			}
+			return fmt.Errorf("failed to get keys for segment %d: %w", seg.Index(), err)
+		}
+		addedKeys[i] = keys
+	}
+
+	t.lock.Lock()
+
+	if len(removed) > 0 {
+		removedIndices := make(map[uint32]struct{}, len(removed))
+		for _, seg := range removed {
+			removedIndices[seg.Index()] = struct{}{}
+			delete(t.segments, seg.Index())
+		}
+		for key, address := range t.addresses {
+			if _, ok := removedIndices[address.Index()]; ok {
+				delete(t.addresses, key)
+			}
+		}
+		t.lowestSegmentIndex = removed[len(removed)-1].Index() + 1
+	}
+
+	for i, seg := range added {
+		if len(t.segments) == 0 {
+			t.lowestSegmentIndex = seg.Index()
+		} else if !rescan {
+			// Segments returned by GatherSealedSegments() are already linked to each other, but segments loaded
+			// individually must be linked to their predecessors.
+			t.segments[t.highestSegmentIndex].SetNextSegment(seg)
+		}
+		t.highestSegmentIndex = seg.Index()
+		t.segments[seg.Index()] = seg
+
+		for _, key := range addedKeys[i] {
+			t.addresses[string(key.Key)] = key.Address
+		}
+	}
+
+	t.lock.Unlock()
+
+	for _, seg := range removed {
+		seg.Release()
+	}
+
+	if len(removed) > 0 || len(added) > 0 {
+		t.logger.Debugf("refreshed read-only table %s, dropped %d segment(s), added %d segment(s), took %v",
+			t.name, len(removed), len(added), t.clock().Sub(start))
+	}
+
+	return nil
+}
+
+// reserveSegment looks up the address of a key and reserves the segment that contains it. Returns false if the key
+// is not present in the table. If this method returns true, the caller must release the segment.
+func (t *readOnlyDiskTable) reserveSegment(key []byte) (*segment.Segment, types.Address, bool) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	address, ok := t.addresses[util.UnsafeBytesToString(key)]
+	if !ok {
+		return nil, 0, false
+	}
+	seg, ok := t.segments[address.Index()]
+	if !ok {
+		return nil, 0, false
+	}
+	if !seg.Reserve() {
+		return nil, 0, false
+	}
+	return seg, address, true
+}
+
+// reserveAllSegments reserves all visible segments, in order of increasing segment index. The caller must
+// release each segment.
+func (t *readOnlyDiskTable) reserveAllSegments() []*segment.Segment {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	segments := make([]*segment.Segment, 0, len(t.segments))
+	if len(t.segments) == 0 {
+		return segments
+	}
+	for index := t.lowestSegmentIndex; index <= t.highestSegmentIndex; index++ {
+		seg := t.segments[index]
+		if seg.Reserve() {
+			segments = append(segments, seg)
+		}
+	}
+	return segments
+}
+
+// read reads a value from a reserved segment.
+func (t *readOnlyDiskTable) read(seg *segment.Segment, key []byte, address types.Address) ([]byte, bool, error) {
+	value, err := seg.Read(key, address)
+	if err != nil {
+		present, presentErr := seg.IsPresentOnDisk()
+		if presentErr == nil && !present {
+			// The segment was deleted by the process that owns the table after our most recent refresh.
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to read data: %w", err)
+	}
+	return value, true, nil
+}
+
+func (t *readOnlyDiskTable) Name() string {
+	return t.name
+}
+
+func (t *readOnlyDiskTable) Put(key []byte, value []byte) error {
+	return fmt.Errorf("cannot process Put() request for table %s: %w", t.name, litt.ErrReadOnly)
+}
+
+func (t *readOnlyDiskTable) PutBatch(batch []*types.KVPair) error {
+	return fmt.Errorf("cannot process PutBatch() request for table %s: %w", t.name, litt.ErrReadOnly)
+}
+
+func (t *readOnlyDiskTable) Get(key []byte) (value []byte, exists bool, err error) {
+	if ok, err := t.fatalErrorHandler.IsOk(); !ok {
+		return nil, false, fmt.Errorf(
+			"Cannot process Get() request, DB is in panicked state due to error: %w", err)
+	}
+
+	seg, address, ok := t.reserveSegment(key)
+	if !ok {
+		return nil, false, nil
+	}
+	defer seg.Release()
+
+	return t.read(seg, key, address)
+}
+
+func (t *readOnlyDiskTable) CacheAwareGet(
+	key []byte,
+	onlyReadFromCache bool,
+) (value []byte, exists bool, hot bool, err error) {
+
+	if ok, err := t.fatalErrorHandler.IsOk(); !ok {
+		return nil, false, false, fmt.Errorf(
+			"Cannot process CacheAwareGet() request, DB is in panicked state due to error: %w", err)
+	}
+
+	seg, address, ok := t.reserveSegment(key)
+	if !ok {
+		return nil, false, false, nil
+	}
+	defer seg.Release()
+
+	if onlyReadFromCache {
+		// The value exists but we are not allowed to read it from disk.
+		return nil, true, false, nil
+	}
+
+	value, exists, err = t.read(seg, key, address)
+	return value, exists, false, err
+}
+
+func (t *readOnlyDiskTable) Exists(key []byte) (bool, error) {
+	if ok, err := t.fatalErrorHandler.IsOk(); !ok {
+		return false, fmt.Errorf("Cannot process Exists() request, DB is in panicked state due to error: %w", err)
+	}
+
+	seg, _, ok := t.reserveSegment(key)
+	if !ok {
+		return false, nil
+	}
+	seg.Release()
+
+	return true, nil
+}
+
+// Iterate returns an iterator over all key-value pairs in the segments currently visible to this table.
+// The OnlySealedData option has no effect, since a read-only table only ever sees sealed data.
+func (t *readOnlyDiskTable) Iterate(options *litt.IteratorOptions) (litt.TableIterator, error) {
+	if ok, err := t.fatalErrorHandler.IsOk(); !ok {
+		return nil, fmt.Errorf("Cannot process Iterate() request, DB is in panicked state due to error: %w", err)
+	}
+
+	if options == nil {
+		options = &litt.IteratorOptions{}
+	}
+
+	return &tableIterator{
+		metadata: t.metadata,
+		clock:    t.clock,
+		segments: t.reserveAllSegments(),
+		prefix:   options.Prefix,
+	}, nil
+}
+
+// Flush is a no-op, since data can not be written to a read-only table.
+func (t *readOnlyDiskTable) Flush() error {
+	if ok, err := t.fatalErrorHandler.IsOk(); !ok {
+		return fmt.Errorf("Cannot process Flush() request, DB is in panicked state due to error: %w", err)
+	}
+	return nil
+}
+
+func (t *readOnlyDiskTable) Size() uint64 {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	size := t.metadata.Size()
+	for _, seg := range t.segments {
+		size += seg.Size()
+	}
+	return size
+}
+
+func (t *readOnlyDiskTable) KeyCount() uint64 {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return uint64(len(t.addresses))
+}
+
+func (t *readOnlyDiskTable) SetTTL(ttl time.Duration) error {
+	return fmt.Errorf("cannot process SetTTL() request for table %s: %w", t.name, litt.ErrReadOnly)
+}
+
+func (t *readOnlyDiskTable) SetShardingFactor(shardingFactor uint32) error {
+	return fmt.Errorf("cannot process SetShardingFactor() request for table %s: %w", t.name, litt.ErrReadOnly)
+}
+
+func (t *readOnlyDiskTable) SetWriteCacheSize(size uint64) error {
+	// this implementation does not provide a cache, if a cache is needed then it must be provided by a wrapper
+	return nil
+}
+
+func (t *readOnlyDiskTable) SetReadCacheSize(size uint64) error {
+	// this implementation does not provide a cache, if a cache is needed then it must be provided by a wrapper
+	return nil
+}
+
+// Close stops the background refresh goroutine (if there is one) and releases all segments. No files are modified.
+func (t *readOnlyDiskTable) Close() error {
+	t.closeOnce.Do(func() {
+		close(t.stopChan)
+		if t.refreshDoneChan != nil {
+			<-t.refreshDoneChan
+		}
+
+		t.fatalErrorHandler.Shutdown()
+
+		t.lock.Lock()
+		defer t.lock.Unlock()
+		for _, seg := range t.segments {
+			seg.Release()
+		}
+		t.segments = make(map[uint32]*segment.Segment)
+		t.addresses = make(map[string]types.Address)
+	})
+	return nil
+}
+
+func (t *readOnlyDiskTable) Destroy() error {
+	return fmt.Errorf("cannot process Destroy() request for table %s: %w", t.name, litt.ErrReadOnly)
+}
+
+func (t *readOnlyDiskTable) AddRoot(root string) error {
+	return fmt.Errorf("cannot process AddRoot() request for table %s: %w", t.name, litt.ErrReadOnly)
+}
+
+func (t *readOnlyDiskTable) RemoveRoot(root string) error {
+	return fmt.Errorf("cannot process RemoveRoot() request for table %s: %w", t.name, litt.ErrReadOnly)
+}
+
+func (t *readOnlyDiskTable) CheckRemoveRoot(root string) error {
+	return fmt.Errorf("cannot process CheckRemoveRoot() request for table %s: %w", t.name, litt.ErrReadOnly)
+}
+
+func (t *readOnlyDiskTable) RunGC() error {
+	return fmt.Errorf("cannot process RunGC() request for table %s: %w", t.name, litt.ErrReadOnly)
+}
+
+// Snapshot writes a snapshot of the segments currently visible to this table, see DiskTable.Snapshot(). Since a
+// read-only table can not seal the mutable segment of the process that owns the table, data in that segment is
+// not included in the snapshot.
+func (t *readOnlyDiskTable) Snapshot(targetDirectory string) error {
+	if ok, err := t.fatalErrorHandler.IsOk(); !ok {
+		return fmt.Errorf("Cannot process Snapshot() request, DB is in panicked state due to error: %w", err)
+	}
+
+	segments := t.reserveAllSegments()
+	defer func() {
+		for _, seg := range segments {
+			seg.Release()
+		}
+	}()
+
+	return writeSnapshot(t.logger, t.fatalErrorHandler, t.name, t.metadata, segments, targetDirectory, t.clock)
+}
+
+func (t *readOnlyDiskTable) GetSegmentInfo() ([]*litt.SegmentInfo, error) {
+	if ok, err := t.fatalErrorHandler.IsOk(); !ok {
+		return nil, fmt.Errorf(
+			"Cannot process GetSegmentInfo() request, DB is in panicked state due to error: %w", err)
+	}
+
+	segments := t.reserveAllSegments()
+	infos := make([]*litt.SegmentInfo, 0, len(segments))
+	for _, seg := range segments {
+		infos = append(infos, newSegmentInfo(seg))
+		seg.Release()
+	}
+
+	return infos, nil
+}
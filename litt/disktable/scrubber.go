@@ -0,0 +1,231 @@
+package disktable
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
+	"github.com/Layr-Labs/eigenda/litt/metrics"
+	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// quarantineDirectory is the directory where copies of corrupt segments are stored, relative to the root directory.
+const quarantineDirectory = "quarantine"
+
+// ScrubReport describes the results of a scrub operation.
+type ScrubReport struct {
+	// The number of segments that were scrubbed.
+	SegmentsScrubbed uint64
+
+	// The number of keys whose values were read back and verified.
+	KeysScrubbed uint64
+
+	// The keys whose values could not be read back from disk, or whose values did not match their checksums.
+	CorruptKeys []*types.ScopedKey
+
+	// The indices of segments that contained corruption (either in the key file or in a value file).
+	CorruptSegments []uint32
+
+	// The indices of segments that were quarantined by this scrub operation.
+	QuarantinedSegments []uint32
+}
+
+// scrubber reads back the data in sealed segments and verifies it against the checksums stored on disk. Scrubbing
+// is performed in a background goroutine so that it does not block the control loop.
+type scrubber struct {
+	logger logging.Logger
+
+	// fatalErrorHandler is used to react to fatal errors anywhere in the disk table.
+	fatalErrorHandler *util.FatalErrorHandler
+
+	// The control loop of the table. Corrupt keys are removed from the keymap by the control loop, which is
+	// responsible for all keymap mutations.
+	controlLoop *controlLoop
+
+	// Encapsulates metrics for the database.
+	metrics *metrics.LittDBMetrics
+
+	// clock is the time source used by the disk table.
+	clock func() time.Time
+
+	// The table's name.
+	name string
+
+	// If true, corrupt segments are quarantined.
+	quarantine bool
+
+	// The directory where copies of quarantined segments are stored.
+	quarantinePath string
+
+	// Set to true while a background scrub is scheduled or in progress.
+	running atomic.Bool
+
+	// Ensures that only one scrub runs at a time. Also protects quarantined.
+	lock sync.Mutex
+
+	// The keys that have been quarantined, by the index of the segment that holds them. These keys are no longer
+	// in the keymap, and are not verified again.
+	quarantined map[uint32]map[string]struct{}
+}
+
+// isRunning returns true if a background scrub is scheduled or in progress.
+func (s *scrubber) isRunning() bool {
+	return s.running.Load()
+}
+
+// scrub verifies the data in the provided segments. The caller must hold a reservation on each segment, this method
+// releases each reservation once it is done with the segment. If multiple scrubs are requested at the same time, they
+// are run one after the other.
+func (s *scrubber) scrub(segments []*segment.Segment) *ScrubReport {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	start := s.clock()
+	report := &ScrubReport{}
+
+	for i, seg := range segments {
+		if ok, _ := s.fatalErrorHandler.IsOk(); !ok {
+			// The DB is shutting down, abandon the scrub.
+			for _, remaining := range segments[i:] {
+				remaining.Release()
+			}
+			return report
+		}
+
+		s.scrubSegment(seg, report)
+		seg.Release()
+	}
+
+	s.metrics.ReportScrub(s.name, s.clock().Sub(start), report.KeysScrubbed, uint64(len(report.CorruptKeys)))
+
+	if len(report.CorruptSegments) > 0 {
+		s.logger.Errorf("scrub of table %s found %d corrupt key(s) in %d segment(s), %d segment(s) quarantined",
+			s.name, len(report.CorruptKeys), len(report.CorruptSegments), len(report.QuarantinedSegments))
+	} else {
+		s.logger.Infof("scrub of table %s verified %d key(s) in %d segment(s) in %v",
+			s.name, report.KeysScrubbed, report.SegmentsScrubbed, s.clock().Sub(start))
+	}
+
+	return report
+}
+
+// scrubSegment verifies the data in a single segment, recording the results in the report.
+func (s *scrubber) scrubSegment(seg *segment.Segment, report *ScrubReport) {
+	report.SegmentsScrubbed++
+	corrupt := false
+	quarantinedKeys := s.quarantined[seg.Index()]
+	corruptKeys := make([]*types.ScopedKey, 0)
+
+	// If the key file is corrupt, we still get back the keys that precede the corruption.
+	keys, err := seg.GetKeys()
+	if err != nil {
+		if !errors.Is(err, segment.ErrChecksumMismatch) {
+			s.logger.Errorf("failed to read keys from segment %s: %v", seg.String(), err)
+		} else {
+			s.logger.Errorf("key file for segment %s is corrupt: %v", seg.String(), err)
+		}
+		corrupt = true
+	}
+
+	for _, key := range keys {
+		if ok, _ := s.fatalErrorHandler.IsOk(); !ok {
+			return
+		}
+
+		if _, alreadyQuarantined := quarantinedKeys[string(key.Key)]; alreadyQuarantined {
+			// This key is no longer in the keymap, no need to report it again.
+			continue
+		}
+
+		report.KeysScrubbed++
+		_, err = seg.Read(key.Key, key.Address)
+		if err != nil {
+			s.logger.Errorf("corrupt value for key %x in segment %s: %v", key.Key, seg.String(), err)
+			report.CorruptKeys = append(report.CorruptKeys, key)
+			corruptKeys = append(corruptKeys, key)
+			corrupt = true
+		}
+	}
+
+	if !corrupt {
+		return
+	}
+
+	report.CorruptSegments = append(report.CorruptSegments, seg.Index())
+
+	// A segment whose files have already been copied is only quarantined again if more of its keys are corrupt.
+	_, alreadyQuarantined := s.quarantined[seg.Index()]
+	quarantined := false
+	if s.quarantine && (!alreadyQuarantined || len(corruptKeys) > 0) {
+		err = s.quarantineSegment(seg, corruptKeys)
+		if err != nil {
+			s.logger.Errorf("failed to quarantine segment %s: %v", seg.String(), err)
+		} else {
+			quarantined = true
+			report.QuarantinedSegments = append(report.QuarantinedSegments, seg.Index())
+		}
+	}
+
+	s.metrics.ReportCorruptSegment(s.name, quarantined)
+}
+
+// quarantineSegment places a copy of the segment's files in the quarantine directory and removes the corrupt keys
+// from the keymap. Keys in the segment whose values passed verification remain readable. The segment itself is left
+// in place, and is deleted by garbage collection once its data expires.
+func (s *scrubber) quarantineSegment(seg *segment.Segment, corruptKeys []*types.ScopedKey) error {
+	// Copy the files before touching the keymap. If the copy fails, the keys remain in the keymap (and reads of
+	// the corrupt values continue to fail their checksums).
+	destination := path.Join(s.quarantinePath, fmt.Sprintf("%d", seg.Index()))
+	err := os.MkdirAll(destination, 0755)
+	if err != nil {
+		return fmt.Errorf("failed to create quarantine directory: %w", err)
+	}
+	for _, filePath := range seg.GetFilePaths() {
+		err = util.CopyFile(filePath, path.Join(destination, path.Base(filePath)))
+		if err != nil {
+			return fmt.Errorf("failed to copy file %s to quarantine: %w", filePath, err)
+		}
+	}
+
+	if len(corruptKeys) > 0 {
+		request := &controlLoopDeleteKeysRequest{
+			keys:         corruptKeys,
+			responseChan: make(chan struct{}, 1),
+		}
+		err = s.controlLoop.enqueue(request)
+		if err != nil {
+			return fmt.Errorf("failed to send delete keys request: %w", err)
+		}
+		_, err = util.AwaitIfNotFatal(s.fatalErrorHandler, request.responseChan)
+		if err != nil {
+			return fmt.Errorf("failed to delete keys from keymap: %w", err)
+		}
+	}
+
+	quarantinedKeys, ok := s.quarantined[seg.Index()]
+	if !ok {
+		quarantinedKeys = make(map[string]struct{}, len(corruptKeys))
+		s.quarantined[seg.Index()] = quarantinedKeys
+	}
+	for _, key := range corruptKeys {
+		quarantinedKeys[string(key.Key)] = struct{}{}
+	}
+	s.logger.Warnf("quarantined %d key(s) from segment %s, files copied to %s",
+		len(corruptKeys), seg.String(), destination)
+
+	return nil
+}
+
+// stop waits for any in-progress scrub to complete. The caller is expected to have already put the fatal error
+// handler into a shutdown state, which causes an in-progress scrub to abort early.
+func (s *scrubber) stop() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+}
@@ -0,0 +1,34 @@
+package segment
+
+import (
+	"errors"
+	"hash/crc32"
+)
+
+// ErrChecksumMismatch is returned when data read from disk does not match the checksum that was written alongside it.
+// This indicates that the data on disk has been corrupted.
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// checksumSize is the size of a checksum in bytes.
+const checksumSize = 4
+
+// castagnoliTable is the CRC32C table used to compute checksums. CRC32C has hardware support on most platforms.
+var castagnoliTable = crc32.MakeTable(crc32.Castagnoli)
+
+// computeChecksum computes the checksum of the given data.
+func computeChecksum(data []byte) uint32 {
+	return crc32.Checksum(data, castagnoliTable)
+}
+
+// hasChecksums returns true if files written at the given segment version contain checksums.
+func hasChecksums(segmentVersion SegmentVersion) bool {
+	return segmentVersion >= ChecksumSegmentVersion
+}
+
+// valueOverhead returns the number of bytes stored in a value file for each value in addition to the value itself.
+func valueOverhead(segmentVersion SegmentVersion) uint64 {
+	if hasChecksums(segmentVersion) {
+		return 4 /* uint32 length */ + checksumSize
+	}
+	return 4 /* uint32 length */
+}
@@ -0,0 +1,111 @@
+package segment
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/Layr-Labs/eigenda/litt/util"
+)
+
+// SelectDirectory chooses which of the given segment directories a segment file should be placed in. Value files
+// are spread across the directories according to their shard, and the remaining files according to their segment
+// index. Returns false if the file is not a recognized segment file.
+func SelectDirectory(fileName string, segmentDirectories []string) (string, bool, error) {
+	if len(segmentDirectories) == 0 {
+		return "", false, fmt.Errorf("at least one segment directory must be provided")
+	}
+	directoryCount := uint32(len(segmentDirectories))
+
+	index, ok := segmentFileIndex(fileName)
+	if !ok {
+		return "", false, nil
+	}
+
+	if strings.HasSuffix(fileName, ValuesFileExtension) {
+		shard, err := getValueFileShard(fileName)
+		if err != nil {
+			return "", false, fmt.Errorf("failed to get shard for file %s: %w", fileName, err)
+		}
+		return segmentDirectories[shard%directoryCount], true, nil
+	}
+
+	return segmentDirectories[index%directoryCount], true, nil
+}
+
+// MigrateSegmentFiles moves the segment files found in the source directories into the destination directories,
+// placing each file in the directory chosen by SelectDirectory(). The source and destination directories may
+// overlap, files that are already in the correct location are not moved. Swap files left behind by a crash are
+// deleted. Files that are not recognized as segment files are not touched.
+//
+// This method must not be called while a table is using the files being migrated. It is safe to call this method
+// again if a previous call was interrupted.
+func MigrateSegmentFiles(sourceDirectories []string, destinationDirectories []string) error {
+	for _, sourceDirectory := range sourceDirectories {
+		entries, err := os.ReadDir(sourceDirectory)
+		if err != nil {
+			return fmt.Errorf("failed to read directory %s: %w", sourceDirectory, err)
+		}
+
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+			fileName := entry.Name()
+			sourcePath := path.Join(sourceDirectory, fileName)
+
+			if strings.HasSuffix(fileName, MetadataSwapExtension) ||
+				strings.HasSuffix(fileName, KeyFileSwapExtension) ||
+				strings.HasSuffix(fileName, RelocationSwapExtension) {
+				err = os.Remove(sourcePath)
+				if err != nil {
+					return fmt.Errorf("failed to remove swap file %s: %w", sourcePath, err)
+				}
+				continue
+			}
+
+			destinationDirectory, ok, err := SelectDirectory(fileName, destinationDirectories)
+			if err != nil {
+				return fmt.Errorf("failed to select directory for %s: %w", fileName, err)
+			}
+			if !ok || path.Clean(destinationDirectory) == path.Clean(sourceDirectory) {
+				continue
+			}
+			destinationPath := path.Join(destinationDirectory, fileName)
+
+			exists, err := util.Exists(destinationPath)
+			if err != nil {
+				return fmt.Errorf("failed to check if %s exists: %w", destinationPath, err)
+			}
+			if exists {
+				// A previous migration was interrupted after the file was moved into place but before the
+				// source was deleted.
+				sourceInfo, err := os.Stat(sourcePath)
+				if err != nil {
+					return fmt.Errorf("failed to stat %s: %w", sourcePath, err)
+				}
+				destinationInfo, err := os.Stat(destinationPath)
+				if err != nil {
+					return fmt.Errorf("failed to stat %s: %w", destinationPath, err)
+				}
+				if sourceInfo.Size() != destinationInfo.Size() {
+					return fmt.Errorf("file %s exists in both %s and %s with different sizes",
+						fileName, sourceDirectory, destinationDirectory)
+				}
+				err = os.Remove(sourcePath)
+				if err != nil {
+					return fmt.Errorf("failed to remove duplicate file %s: %w", sourcePath, err)
+				}
+				continue
+			}
+
+			err = util.MoveFile(sourcePath, destinationPath)
+			if err != nil {
+				return fmt.Errorf("failed to move %s to %s: %w", sourcePath, destinationPath, err)
+			}
+		}
+	}
+
+	return nil
+}
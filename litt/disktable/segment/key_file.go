@@ -54,6 +54,7 @@ func createKeyFile(
 	logger logging.Logger,
 	index uint32,
 	parentDirectory string,
+	segmentVersion SegmentVersion,
 	swap bool,
 ) (*keyFile, error) {
 
@@ -61,7 +62,7 @@ func createKeyFile(
 		logger:          logger,
 		index:           index,
 		parentDirectory: parentDirectory,
-		segmentVersion:  ValueSizeSegmentVersion,
+		segmentVersion:  segmentVersion,
 		swap:            swap,
 	}
 
@@ -176,35 +177,40 @@ func (k *keyFile) write(scopedKey *types.ScopedKey) error {
 		return fmt.Errorf("key file is sealed")
 	}
 
-	// Write the length of the key.
-	err := binary.Write(k.writer, binary.BigEndian, uint32(len(scopedKey.Key)))
-	if err != nil {
-		return fmt.Errorf("failed to write key length to key file: %v", err)
+	entrySize := 4 /* uint32 size of key */ + len(scopedKey.Key) + 8 /* uint64 address */
+	if k.segmentVersion >= ValueSizeSegmentVersion {
+		entrySize += 4 /* uint32 size of value */
 	}
+	entry := make([]byte, entrySize, entrySize+checksumSize)
+
+	// Write the length of the key.
+	binary.BigEndian.PutUint32(entry[0:4], uint32(len(scopedKey.Key)))
+	index := 4
 
 	// Write the key itself.
-	_, err = k.writer.Write(scopedKey.Key)
-	if err != nil {
-		return fmt.Errorf("failed to write key to key file: %v", err)
-	}
+	copy(entry[index:], scopedKey.Key)
+	index += len(scopedKey.Key)
 
 	// Write the address.
-	err = binary.Write(k.writer, binary.BigEndian, scopedKey.Address)
-	if err != nil {
-		return fmt.Errorf("failed to write address to key file: %v", err)
+	binary.BigEndian.PutUint64(entry[index:index+8], uint64(scopedKey.Address))
+	index += 8
+
+	if k.segmentVersion >= ValueSizeSegmentVersion {
+		// Write the size of the value.
+		binary.BigEndian.PutUint32(entry[index:index+4], scopedKey.ValueSize)
 	}
 
-	// Write the size of the value.
-	err = binary.Write(k.writer, binary.BigEndian, scopedKey.ValueSize)
+	if hasChecksums(k.segmentVersion) {
+		// Write the checksum of the entry.
+		entry = binary.BigEndian.AppendUint32(entry, computeChecksum(entry))
+	}
+
+	_, err := k.writer.Write(entry)
 	if err != nil {
-		return fmt.Errorf("failed to write value size to key file: %v", err)
+		return fmt.Errorf("failed to write key to key file: %v", err)
 	}
 
-	k.size += uint64(
-		4 /* uint32 size of key */ +
-			len(scopedKey.Key) +
-			8 /* uint64 address */ +
-			4 /* uint32 size of value */)
+	k.size += uint64(len(entry))
 
 	return nil
 }
@@ -250,22 +256,14 @@ func (k *keyFile) seal() error {
 // If there are keys that were only partially written (i.e. keys being written when the process crashed), then
 // those keys may not be returned. If a key is returned, it is guaranteed to be "whole" (i.e. a partial key will
 // never be returned).
+//
+// If the key file contains checksums and an entry with an invalid checksum is encountered, this method returns the
+// keys that precede the corrupt entry along with an error that wraps ErrChecksumMismatch.
 func (k *keyFile) readKeys() ([]*types.ScopedKey, error) {
 	if k.writer != nil {
 		return nil, fmt.Errorf("key file is not sealed")
 	}
 
-	file, err := os.Open(k.path())
-	if err != nil {
-		return nil, fmt.Errorf("failed to open key file: %v", err)
-	}
-	defer func() {
-		err = file.Close()
-		if err != nil {
-			k.logger.Errorf("failed to close key file: %v", err)
-		}
-	}()
-
 	// Key files are small as long as key length is sane. Safe to read the whole file into memory.
 	keyBytes, err := os.ReadFile(k.path())
 	if err != nil {
@@ -273,8 +271,19 @@ func (k *keyFile) readKeys() ([]*types.ScopedKey, error) {
 	}
 	keys := make([]*types.ScopedKey, 0)
 
+	// The number of bytes that follow the key in each entry.
+	trailerSize := 8 /* uint64 address */
+	if k.segmentVersion >= ValueSizeSegmentVersion {
+		trailerSize += 4 /* uint32 value size */
+	}
+	if hasChecksums(k.segmentVersion) {
+		trailerSize += checksumSize
+	}
+
 	index := 0
 	for {
+		entryStart := index
+
 		// We need at least 4 bytes to read the length of the key.
 		if index+4 > len(keyBytes) {
 			// There are fewer than 4 bytes left in the file.
@@ -283,18 +292,10 @@ func (k *keyFile) readKeys() ([]*types.ScopedKey, error) {
 		keyLength := int(binary.BigEndian.Uint32(keyBytes[index : index+4]))
 		index += 4
 
-		if k.segmentVersion < ValueSizeSegmentVersion {
-			// We need to read the key, as well as the 8 byte address.
-			if index+keyLength+8 > len(keyBytes) {
-				// There are insufficient bytes left in the file to read the key and address.
-				break
-			}
-		} else {
-			// We need to read the key, as well as the 8 byte address and 4 byte value size.
-			if index+keyLength+12 > len(keyBytes) {
-				// There are insufficient bytes left in the file to read the key, address, and value size.
-				break
-			}
+		if index+keyLength+trailerSize > len(keyBytes) {
+			// There are insufficient bytes left in the file to read the rest of the entry.
+			index = entryStart
+			break
 		}
 
 		key := keyBytes[index : index+keyLength]
@@ -309,6 +310,15 @@ func (k *keyFile) readKeys() ([]*types.ScopedKey, error) {
 			index += 4
 		}
 
+		if hasChecksums(k.segmentVersion) {
+			checksum := binary.BigEndian.Uint32(keyBytes[index : index+checksumSize])
+			if checksum != computeChecksum(keyBytes[entryStart:index]) {
+				return keys, fmt.Errorf("entry at offset %d in key file %s is corrupt: %w",
+					entryStart, k.path(), ErrChecksumMismatch)
+			}
+			index += checksumSize
+		}
+
 		keys = append(keys, &types.ScopedKey{
 			Key:       key,
 			Address:   address,
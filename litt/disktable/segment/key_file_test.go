@@ -29,7 +29,7 @@ func TestReadWriteKeys(t *testing.T) {
 		keys[i] = &types.ScopedKey{Key: key, Address: address, ValueSize: valueSize}
 	}
 
-	file, err := createKeyFile(logger, index, directory, false)
+	file, err := createKeyFile(logger, index, directory, LatestSegmentVersion, false)
 	require.NoError(t, err)
 
 	for _, key := range keys {
@@ -60,7 +60,7 @@ func TestReadWriteKeys(t *testing.T) {
 	}
 
 	// Create a new in-memory instance from the on-disk file and verify that it behaves the same.
-	file2, err := loadKeyFile(logger, index, []string{directory}, ValueSizeSegmentVersion)
+	file2, err := loadKeyFile(logger, index, []string{directory}, LatestSegmentVersion)
 	require.NoError(t, err)
 	require.Equal(t, file.Size(), file2.Size())
 
@@ -100,7 +100,7 @@ func TestReadingTruncatedKeyFile(t *testing.T) {
 		keys[i] = &types.ScopedKey{Key: key, Address: address, ValueSize: valueSize}
 	}
 
-	file, err := createKeyFile(logger, index, directory, false)
+	file, err := createKeyFile(logger, index, directory, LatestSegmentVersion, false)
 	require.NoError(t, err)
 
 	for _, key := range keys {
@@ -179,7 +179,7 @@ func TestSwappingKeyFile(t *testing.T) {
 		keys[i] = &types.ScopedKey{Key: key, Address: address, ValueSize: valueSize}
 	}
 
-	file, err := createKeyFile(logger, index, directory, false)
+	file, err := createKeyFile(logger, index, directory, LatestSegmentVersion, false)
 	require.NoError(t, err)
 
 	for _, key := range keys {
@@ -210,7 +210,7 @@ func TestSwappingKeyFile(t *testing.T) {
 	}
 
 	// Create a new in-memory instance from the on-disk file and verify that it behaves the same.
-	file2, err := loadKeyFile(logger, index, []string{directory}, ValueSizeSegmentVersion)
+	file2, err := loadKeyFile(logger, index, []string{directory}, LatestSegmentVersion)
 	require.NoError(t, err)
 	require.Equal(t, file.Size(), file2.Size())
 
@@ -222,7 +222,7 @@ func TestSwappingKeyFile(t *testing.T) {
 
 	// Create a new version of the key file that only contains the keys at even indices. The intention is to replace
 	// the on-disk file with this new version.
-	swapFile, err := createKeyFile(logger, index, directory, true)
+	swapFile, err := createKeyFile(logger, index, directory, LatestSegmentVersion, true)
 	require.NoError(t, err)
 	for i := 0; i < int(keyCount); i += 2 {
 		err := swapFile.write(keys[i])
@@ -260,7 +260,7 @@ func TestSwappingKeyFile(t *testing.T) {
 	require.Equal(t, actualSize, reportedSize)
 
 	// Verify the contents of the new file. Reload it from disk just to ensure that we aren't "cheating" somehow.
-	file2, err = loadKeyFile(logger, index, []string{directory}, ValueSizeSegmentVersion)
+	file2, err = loadKeyFile(logger, index, []string{directory}, LatestSegmentVersion)
 	require.NoError(t, err)
 	readKeys, err = file2.readKeys()
 	require.NoError(t, err)
@@ -281,3 +281,58 @@ func TestSwappingKeyFile(t *testing.T) {
 	_, err = os.Stat(filePath)
 	require.True(t, os.IsNotExist(err))
 }
+
+func TestCorruptKeyFile(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+	logger, err := common.NewLogger(common.DefaultTextLoggerConfig())
+	require.NoError(t, err)
+	directory := t.TempDir()
+
+	index := rand.Uint32()
+
+	keyCount := rand.Int32Range(100, 200)
+	keys := make([]*types.ScopedKey, keyCount)
+	for i := 0; i < int(keyCount); i++ {
+		key := rand.VariableBytes(1, 100)
+		address := types.Address(rand.Uint64())
+		valueSize := rand.Uint32()
+		keys[i] = &types.ScopedKey{Key: key, Address: address, ValueSize: valueSize}
+	}
+
+	file, err := createKeyFile(logger, index, directory, LatestSegmentVersion, false)
+	require.NoError(t, err)
+
+	// Keep track of where each entry begins.
+	entryOffsets := make([]uint64, keyCount)
+	for i, key := range keys {
+		entryOffsets[i] = file.Size()
+		err := file.write(key)
+		require.NoError(t, err)
+	}
+
+	err = file.seal()
+	require.NoError(t, err)
+
+	// Flip a bit in the key of a random entry.
+	corruptIndex := rand.Int32Range(0, keyCount)
+	corruptByteIndex := int(entryOffsets[corruptIndex]) + 4 /* key length */ +
+		int(rand.Int32Range(0, int32(len(keys[corruptIndex].Key))))
+
+	fileBytes, err := os.ReadFile(file.path())
+	require.NoError(t, err)
+	fileBytes[corruptByteIndex] ^= 1 << rand.Int32Range(0, 8)
+	err = os.WriteFile(file.path(), fileBytes, 0644)
+	require.NoError(t, err)
+
+	file, err = loadKeyFile(logger, index, []string{directory}, LatestSegmentVersion)
+	require.NoError(t, err)
+
+	// The keys prior to the corrupt entry should be returned alongside the error.
+	readKeys, err := file.readKeys()
+	require.ErrorIs(t, err, ErrChecksumMismatch)
+	require.Equal(t, int(corruptIndex), len(readKeys))
+	for i, key := range readKeys {
+		require.Equal(t, keys[i], key)
+	}
+}
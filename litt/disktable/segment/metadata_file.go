@@ -48,7 +48,8 @@ const (
 	// - and 1 byte for sealed.
 	V2MetadataSize = 37
 
-	// CurrentMetadataSize is the size of the metadata file at the current version.
+	// CurrentMetadataSize is the size of the metadata file at the current version. Version 3
+	// (aka ChecksumSegmentVersion) uses the same metadata layout as version 2.
 	CurrentMetadataSize = V2MetadataSize
 )
 
@@ -0,0 +1,149 @@
+package segment
+
+import (
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// LoadSealedSegment loads a sealed segment from disk without modifying any files. This is intended for use by
+// processes that read a DB's files while another process is writing to them. Returns false if the segment is not
+// yet sealed, or if any of its files are missing (e.g. because the segment is still being written, or because it
+// is in the process of being deleted).
+//
+// Segments loaded by this method never delete their files, even when all reservations on them are released.
+func LoadSealedSegment(
+	logger logging.Logger,
+	fatalErrorHandler *util.FatalErrorHandler,
+	index uint32,
+	parentDirectories []string) (*Segment, bool, error) {
+
+	metadataPath, err := lookForFile(parentDirectories, fmt.Sprintf("%d%s", index, MetadataFileExtension))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to find metadata file: %w", err)
+	}
+	if metadataPath == "" {
+		return nil, false, nil
+	}
+
+	metadata, err := loadMetadataFile(index, parentDirectories)
+	if err != nil {
+		// The metadata file may have been deleted after we checked for its existence.
+		exists, existsErr := util.Exists(metadataPath)
+		if existsErr == nil && !exists {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to open metadata file: %w", err)
+	}
+	if !metadata.sealed {
+		return nil, false, nil
+	}
+
+	// The key file and value files of a sealed segment are never modified, but they may be deleted at any time
+	// by the garbage collector of the process that owns the DB.
+	keysPath, err := lookForFile(parentDirectories, fmt.Sprintf("%d%s", index, KeyFileExtension))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to find key file: %w", err)
+	}
+	if keysPath == "" {
+		return nil, false, nil
+	}
+	keys, err := loadKeyFile(logger, index, parentDirectories, metadata.segmentVersion)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to open key file: %w", err)
+	}
+
+	shards := make([]*valueFile, metadata.shardingFactor)
+	for shard := uint32(0); shard < metadata.shardingFactor; shard++ {
+		valuesPath, err := lookForFile(parentDirectories, fmt.Sprintf("%d-%d%s", index, shard, ValuesFileExtension))
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to find value file: %w", err)
+		}
+		if valuesPath == "" {
+			return nil, false, nil
+		}
+		values, err := loadValueFile(logger, index, shard, parentDirectories, metadata.segmentVersion)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to open value file: %w", err)
+		}
+		shards[shard] = values
+	}
+
+	segment := &Segment{
+		logger:            logger,
+		fatalErrorHandler: fatalErrorHandler,
+		index:             index,
+		metadata:          metadata,
+		keys:              keys,
+		shards:            shards,
+		keyFileSize:       keys.Size(),
+		keyCount:          metadata.keyCount,
+		deletionChannel:   make(chan struct{}, 1),
+		readOnly:          true,
+	}
+	segment.reservationCount.Store(1)
+
+	return segment, true, nil
+}
+
+// GatherSealedSegments scans the given directories and loads the longest run of consecutive sealed segments
+// without modifying any files, see LoadSealedSegment(). Segments at the beginning of the range that are missing
+// files (i.e. that are being deleted) are skipped. The first segment after the run that is not sealed (i.e. the
+// mutable segment of the process writing to the DB) and all segments after it are ignored.
+//
+// If no sealed segments are found, the returned map is empty and the returned indices are meaningless.
+func GatherSealedSegments(
+	logger logging.Logger,
+	fatalErrorHandler *util.FatalErrorHandler,
+	rootDirectories []string,
+) (lowestSegmentIndex uint32, highestSegmentIndex uint32, segments map[uint32]*Segment, err error) {
+
+	metadataFiles, _, _, _, highestFileIndex, lowestFileIndex, err := scanDirectories(logger, rootDirectories)
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("failed to scan directories: %w", err)
+	}
+
+	segments = make(map[uint32]*Segment)
+	if len(metadataFiles) == 0 {
+		return 0, 0, segments, nil
+	}
+
+	for index := lowestFileIndex; index <= highestFileIndex; index++ {
+		seg, ok, err := LoadSealedSegment(logger, fatalErrorHandler, index, rootDirectories)
+		if err != nil {
+			return 0, 0, nil, fmt.Errorf("failed to load segment %d: %w", index, err)
+		}
+		if !ok {
+			if len(segments) == 0 {
+				// This segment is being deleted, keep looking for the start of the run.
+				continue
+			}
+			break
+		}
+
+		if len(segments) == 0 {
+			lowestSegmentIndex = index
+		} else {
+			segments[highestSegmentIndex].SetNextSegment(seg)
+		}
+		highestSegmentIndex = index
+		segments[index] = seg
+	}
+
+	return lowestSegmentIndex, highestSegmentIndex, segments, nil
+}
+
+// IsPresentOnDisk returns true if the segment's key file still exists on disk. A segment's key file is the first
+// file deleted when a segment is deleted, so a return value of false means that the segment has been (or is being)
+// deleted, possibly by another process.
+func (s *Segment) IsPresentOnDisk() (bool, error) {
+	s.fileLock.RLock()
+	defer s.fileLock.RUnlock()
+
+	exists, err := util.Exists(s.keys.path())
+	if err != nil {
+		return false, fmt.Errorf("failed to check if key file exists: %w", err)
+	}
+	return exists, nil
+}
@@ -0,0 +1,219 @@
+package segment
+
+import (
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// RelocationSwapExtension is the extension given to a copy of a segment file while it is being relocated into
+// a new directory. Once the copy is complete, it is renamed to the file's real name. Files with this extension
+// that are found when segment files are gathered were left behind by a crash, and are deleted.
+const RelocationSwapExtension = ".relocating"
+
+// relocatableFile is a segment file that can be moved from one directory to another.
+type relocatableFile struct {
+	// The name of the file.
+	name string
+
+	// Points to the field that records the directory containing the file.
+	parentDirectory *string
+}
+
+// relocatableFiles returns all files that make up this segment.
+func (s *Segment) relocatableFiles() []*relocatableFile {
+	files := make([]*relocatableFile, 0, 2+len(s.shards))
+	files = append(files,
+		&relocatableFile{name: s.metadata.name(), parentDirectory: &s.metadata.parentDirectory},
+		&relocatableFile{name: s.keys.name(), parentDirectory: &s.keys.parentDirectory})
+	for _, shard := range s.shards {
+		files = append(files, &relocatableFile{name: shard.name(), parentDirectory: &shard.parentDirectory})
+	}
+	return files
+}
+
+// Relocate moves each of this segment's files that is stored in the source directory into one of the destination
+// directories, chosen by SelectDirectory(). Files stored in other directories are not moved. Returns the number of
+// bytes moved. Only sealed segments can be relocated, and the caller must hold a reservation on the segment for the
+// duration of this call.
+//
+// It is safe to read from the segment while it is being relocated. A file is copied into its new directory before
+// it is deleted from the old one, and the segment switches to the new copy before the old one is deleted. If the
+// process crashes part way through a relocation, a file may be left in both directories. Such duplicates are
+// cleaned up the next time segment files are gathered (see GatherSegmentFiles()).
+func (s *Segment) Relocate(sourceDirectory string, destinationDirectories []string, fsync bool) (uint64, error) {
+	if !s.IsSealed() {
+		return 0, fmt.Errorf("segment %d is not sealed, cannot relocate", s.index)
+	}
+
+	bytesMoved := uint64(0)
+	for _, file := range s.relocatableFiles() {
+		s.fileLock.RLock()
+		parentDirectory := *file.parentDirectory
+		s.fileLock.RUnlock()
+
+		if path.Clean(parentDirectory) != path.Clean(sourceDirectory) {
+			continue
+		}
+
+		destinationDirectory, ok, err := SelectDirectory(file.name, destinationDirectories)
+		if err != nil {
+			return bytesMoved, fmt.Errorf("failed to select directory for %s: %w", file.name, err)
+		}
+		if !ok {
+			return bytesMoved, fmt.Errorf("file %s is not a segment file", file.name)
+		}
+		if path.Clean(destinationDirectory) == path.Clean(sourceDirectory) {
+			continue
+		}
+
+		size, err := s.relocateFile(file, sourceDirectory, destinationDirectory, fsync)
+		if err != nil {
+			return bytesMoved, fmt.Errorf("failed to relocate %s: %w", file.name, err)
+		}
+		bytesMoved += size
+	}
+
+	return bytesMoved, nil
+}
+
+// relocateFile moves a single file from the source directory into the destination directory. Returns the size
+// of the file.
+func (s *Segment) relocateFile(
+	file *relocatableFile,
+	sourceDirectory string,
+	destinationDirectory string,
+	fsync bool) (uint64, error) {
+
+	sourcePath := path.Join(sourceDirectory, file.name)
+	destinationPath := path.Join(destinationDirectory, file.name)
+
+	info, err := os.Stat(sourcePath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to stat %s: %w", sourcePath, err)
+	}
+	exists, err := util.Exists(destinationPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to check if %s exists: %w", destinationPath, err)
+	}
+	if exists {
+		return 0, fmt.Errorf("destination %s already exists", destinationPath)
+	}
+
+	// If both directories are on the same file system, the file can be atomically renamed.
+	s.fileLock.Lock()
+	err = os.Rename(sourcePath, destinationPath)
+	if err == nil {
+		*file.parentDirectory = destinationDirectory
+	}
+	s.fileLock.Unlock()
+	if err == nil {
+		return uint64(info.Size()), nil
+	}
+
+	// The directories are on different file systems. Copy the file to a swap location next to its destination,
+	// then move it into place once it is fully written.
+	swapPath := destinationPath + RelocationSwapExtension
+	err = util.CopyFile(sourcePath, swapPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to copy %s to %s: %w", sourcePath, swapPath, err)
+	}
+	if fsync {
+		err = syncFile(swapPath)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	s.fileLock.Lock()
+	err = os.Rename(swapPath, destinationPath)
+	if err == nil {
+		*file.parentDirectory = destinationDirectory
+	}
+	s.fileLock.Unlock()
+	if err != nil {
+		return 0, fmt.Errorf("failed to rename %s to %s: %w", swapPath, destinationPath, err)
+	}
+
+	// Readers that opened the old file before the switch can continue reading from it after it is deleted.
+	err = os.Remove(sourcePath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to remove %s: %w", sourcePath, err)
+	}
+
+	return uint64(info.Size()), nil
+}
+
+// syncFile flushes a file's contents to disk.
+func syncFile(filePath string) error {
+	file, err := os.OpenFile(filePath, os.O_RDWR, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", filePath, err)
+	}
+	err = file.Sync()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to sync %s: %w", filePath, err)
+	}
+	err = file.Close()
+	if err != nil {
+		return fmt.Errorf("failed to close %s: %w", filePath, err)
+	}
+	return nil
+}
+
+// repairInterruptedRelocations cleans up after a relocation that was interrupted by a crash (see Relocate()). If a
+// segment file is found in more than one directory, all copies except for one are deleted. A relocated file is only
+// moved into place after it has been completely written, so all copies of the file are identical.
+func repairInterruptedRelocations(logger logging.Logger, rootDirectories []string) error {
+	// file name -> paths of all copies of the file
+	locations := make(map[string][]string)
+
+	for _, rootDirectory := range rootDirectories {
+		entries, err := os.ReadDir(rootDirectory)
+		if err != nil {
+			return fmt.Errorf("failed to read directory %s: %w", rootDirectory, err)
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+			if _, ok := segmentFileIndex(entry.Name()); !ok {
+				continue
+			}
+			locations[entry.Name()] = append(locations[entry.Name()], path.Join(rootDirectory, entry.Name()))
+		}
+	}
+
+	for fileName, paths := range locations {
+		if len(paths) < 2 {
+			continue
+		}
+
+		keptInfo, err := os.Stat(paths[0])
+		if err != nil {
+			return fmt.Errorf("failed to stat %s: %w", paths[0], err)
+		}
+		for _, duplicate := range paths[1:] {
+			duplicateInfo, err := os.Stat(duplicate)
+			if err != nil {
+				return fmt.Errorf("failed to stat %s: %w", duplicate, err)
+			}
+			if duplicateInfo.Size() != keptInfo.Size() {
+				return fmt.Errorf("file %s found in multiple directories with different sizes: %v", fileName, paths)
+			}
+
+			logger.Warnf("deleting %s, a duplicate of %s left behind by an interrupted relocation",
+				duplicate, paths[0])
+			err = os.Remove(duplicate)
+			if err != nil {
+				return fmt.Errorf("failed to remove duplicate file %s: %w", duplicate, err)
+			}
+		}
+	}
+
+	return nil
+}
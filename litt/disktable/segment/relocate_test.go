@@ -0,0 +1,114 @@
+package segment
+
+import (
+	"context"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/testutils/random"
+	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelocate(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+	logger, err := common.NewLogger(common.DefaultTextLoggerConfig())
+	require.NoError(t, err)
+	fatalErrorHandler := util.NewFatalErrorHandler(context.Background(), logger, nil)
+
+	directory := t.TempDir()
+	directories := []string{path.Join(directory, "a"), path.Join(directory, "b"), path.Join(directory, "c")}
+	for _, dir := range directories {
+		require.NoError(t, os.MkdirAll(dir, 0755))
+	}
+
+	salt := ([16]byte)(rand.Bytes(16))
+	seg, err := CreateSegment(logger, fatalErrorHandler, 0, directories, 4, salt, false)
+	require.NoError(t, err)
+
+	expectedValues := make(map[string][]byte)
+	for i := 0; i < 100; i++ {
+		key := rand.PrintableVariableBytes(1, 100)
+		value := rand.PrintableVariableBytes(1, 100)
+		expectedValues[string(key)] = value
+		_, _, err = seg.Write(&types.KVPair{Key: key, Value: value})
+		require.NoError(t, err)
+	}
+
+	// Only sealed segments can be relocated.
+	_, err = seg.Relocate(directories[1], []string{directories[0], directories[2]}, false)
+	require.Error(t, err)
+
+	flushedKeys, err := seg.Seal(rand.Time())
+	require.NoError(t, err)
+	addresses := make(map[string]types.Address)
+	for _, key := range flushedKeys {
+		addresses[string(key.Key)] = key.Address
+	}
+
+	verify := func() {
+		for key, address := range addresses {
+			value, err := seg.Read([]byte(key), address)
+			require.NoError(t, err)
+			require.Equal(t, expectedValues[key], value)
+		}
+		keys, err := seg.GetKeys()
+		require.NoError(t, err)
+		require.Equal(t, len(expectedValues), len(keys))
+	}
+
+	// Move everything out of the middle directory.
+	require.NotEqual(t, 0, countFilesInDirectory(t, directories[1]))
+	bytesInDirectory := uint64(0)
+	entries, err := os.ReadDir(directories[1])
+	require.NoError(t, err)
+	for _, entry := range entries {
+		info, err := entry.Info()
+		require.NoError(t, err)
+		bytesInDirectory += uint64(info.Size())
+	}
+
+	bytesMoved, err := seg.Relocate(directories[1], []string{directories[0], directories[2]}, false)
+	require.NoError(t, err)
+	require.Equal(t, bytesInDirectory, bytesMoved)
+	require.Equal(t, 0, countFilesInDirectory(t, directories[1]))
+	verify()
+
+	// Relocating again is a no-op.
+	bytesMoved, err = seg.Relocate(directories[1], []string{directories[0], directories[2]}, false)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), bytesMoved)
+
+	// Move everything into the last directory.
+	_, err = seg.Relocate(directories[0], []string{directories[2]}, false)
+	require.NoError(t, err)
+	require.Equal(t, 0, countFilesInDirectory(t, directories[0]))
+	require.Equal(t, 6, countFilesInDirectory(t, directories[2]))
+	verify()
+
+	for _, filePath := range seg.GetFilePaths() {
+		require.Equal(t, directories[2], path.Dir(filePath))
+	}
+
+	// Simulate a crash part way through a relocation, leaving behind a duplicate file and a partial copy.
+	keyFileName := path.Base(seg.keys.path())
+	err = util.CopyFile(path.Join(directories[2], keyFileName), path.Join(directories[0], keyFileName))
+	require.NoError(t, err)
+	valueFileName := path.Base(seg.shards[0].path())
+	err = os.WriteFile(path.Join(directories[1], valueFileName+RelocationSwapExtension), []byte("partial"), 0644)
+	require.NoError(t, err)
+
+	_, _, segments, err := GatherSegmentFiles(logger, fatalErrorHandler, directories, time.Now())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(segments))
+	require.Equal(t, 0, countFilesInDirectory(t, directories[1]))
+	require.Equal(t, 6, countFilesInDirectory(t, directories[0])+countFilesInDirectory(t, directories[2]))
+
+	seg = segments[0]
+	verify()
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math"
 	"path"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -84,6 +85,14 @@ type Segment struct {
 	// segment but have not yet been flushed to the keymap. When the segment is eventually sealed, the code
 	// asserts that this value is zero. This check should never fail, but is a nice safety net.
 	unflushedKeyCount atomic.Int64
+
+	// If true, this segment was loaded by a process that does not own the DB's files (see LoadSealedSegment()).
+	// Files belonging to a read-only segment are never deleted by this process.
+	readOnly bool
+
+	// fileLock protects the locations of this segment's files. Operations that access files by path hold a read
+	// lock, and Relocate() holds a write lock while it moves a file from one directory to another.
+	fileLock sync.RWMutex
 }
 
 // CreateSegment creates a new data segment.
@@ -131,7 +140,7 @@ func CreateSegment(
 		// By default, put the key file in the first parent directory.
 		keysDirectory = parentDirectories[0]
 	}
-	keys, err := createKeyFile(logger, index, keysDirectory, false)
+	keys, err := createKeyFile(logger, index, keysDirectory, metadata.segmentVersion, false)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open key file: %v", err)
 	}
@@ -146,7 +155,7 @@ func CreateSegment(
 		// use it for value files too.
 		parentDirectory := parentDirectories[int(shard+1)%len(parentDirectories)]
 
-		values, err := createValueFile(logger, index, shard, parentDirectory, fsync)
+		values, err := createValueFile(logger, index, shard, parentDirectory, metadata.segmentVersion, fsync)
 		if err != nil {
 			return nil, fmt.Errorf("failed to open value file: %v", err)
 		}
@@ -220,7 +229,7 @@ func LoadSegment(logger logging.Logger,
 	// Look for the value files. There should be one for each shard.
 	shards := make([]*valueFile, metadata.shardingFactor)
 	for shard := uint32(0); shard < metadata.shardingFactor; shard++ {
-		values, err := loadValueFile(logger, index, shard, parentDirectories)
+		values, err := loadValueFile(logger, index, shard, parentDirectories, metadata.segmentVersion)
 		if err != nil {
 			return nil, fmt.Errorf("failed to open value file: %v", err)
 		}
@@ -258,8 +267,15 @@ func LoadSegment(logger logging.Logger,
 // value files.
 func (s *Segment) sealLoadedSegment(now time.Time) error {
 	scopedKeys, err := s.keys.readKeys()
+	corruptKeyFile := false
 	if err != nil {
-		return fmt.Errorf("failed to read keys: %w", err)
+		if !errors.Is(err, ErrChecksumMismatch) {
+			return fmt.Errorf("failed to read keys: %w", err)
+		}
+		// A corrupt entry in the key file of an unsealed segment is most likely the result of a crash while
+		// the file was being written. Treat the corrupt entry as the end of the file.
+		s.logger.Warnf("segment %d has a corrupt key file entry, discarding all subsequent keys: %v", s.index, err)
+		corruptKeyFile = true
 	}
 
 	// keys with values that are not present in the value files
@@ -272,7 +288,7 @@ func (s *Segment) sealLoadedSegment(now time.Time) error {
 		shard := s.GetShard(scopedKey.Key)
 
 		requiredValueFileLength := uint64(scopedKey.Address.Offset()) +
-			4 /* value size uint32 */ +
+			valueOverhead(s.metadata.segmentVersion) +
 			uint64(scopedKey.ValueSize)
 
 		if s.shards[shard].Size() < requiredValueFileLength {
@@ -282,12 +298,12 @@ func (s *Segment) sealLoadedSegment(now time.Time) error {
 		}
 	}
 
-	if len(badKeys) > 0 {
+	if len(badKeys) > 0 || corruptKeyFile {
 		// We have at least one bad key. Rewrite the keyfile with only the good keys.
 		s.logger.Warnf("segment %d has %d unflushed value(s)",
 			s.index, len(badKeys))
 
-		swapFile, err := createKeyFile(s.logger, s.index, s.keys.parentDirectory, true)
+		swapFile, err := createKeyFile(s.logger, s.index, s.keys.parentDirectory, s.metadata.segmentVersion, true)
 		if err != nil {
 			return fmt.Errorf("failed to create swap key file: %w", err)
 		}
@@ -346,6 +362,37 @@ func (s *Segment) KeyCount() uint32 {
 	return s.keyCount
 }
 
+// Index returns the index of the segment.
+func (s *Segment) Index() uint32 {
+	return s.index
+}
+
+// ShardingFactor returns the number of shards in the segment.
+func (s *Segment) ShardingFactor() uint32 {
+	return s.metadata.shardingFactor
+}
+
+// Version returns the serialization version of the segment.
+func (s *Segment) Version() SegmentVersion {
+	return s.metadata.segmentVersion
+}
+
+// GetFilePaths returns the paths of all files that make up this segment (the metadata file, the key file, and one
+// value file per shard).
+//
+// The returned paths become stale if the segment's files are later moved by Relocate().
+func (s *Segment) GetFilePaths() []string {
+	s.fileLock.RLock()
+	defer s.fileLock.RUnlock()
+
+	paths := make([]string, 0, 2+len(s.shards))
+	paths = append(paths, s.metadata.path(), s.keys.path())
+	for _, shard := range s.shards {
+		paths = append(paths, shard.path())
+	}
+	return paths
+}
+
 // lookForFile looks for a file in a list of directories. It returns an error if the file appears
 // in more than one directory, and an empty string if the file is not found. If the file is found and
 // there are no errors, this method returns the path to the file.
@@ -416,12 +463,15 @@ func (s *Segment) Write(data *types.KVPair) (keyCount uint32, keyFileSize uint64
 	s.unflushedKeyCount.Add(1)
 	firstByteIndex := uint32(currentSize)
 
-	s.shardSizes[shard] += uint64(len(data.Value)) + 4 /* uint32 length */
+	s.shardSizes[shard] += uint64(len(data.Value)) + valueOverhead(s.metadata.segmentVersion)
 	if s.shardSizes[shard] > s.maxShardSize {
 		s.maxShardSize = s.shardSizes[shard]
 	}
 	s.keyCount++
 	s.keyFileSize += uint64(len(data.Key)) + 4 /* uint32 length */ + 8 /* uint64 Address */ + 4 /* uint32 ValueSize */
+	if hasChecksums(s.metadata.segmentVersion) {
+		s.keyFileSize += checksumSize
+	}
 
 	// Forward the value to the shard control loop, which asynchronously writes it to the value file.
 	shardRequest := &valueToWrite{
@@ -455,13 +505,17 @@ func (s *Segment) GetMaxShardSize() uint64 {
 	return s.maxShardSize
 }
 
-// Read fetches the data for a key from the data segment.
+// Read fetches the data for a key from the data segment. If the segment contains checksums and the value read from
+// disk does not match its checksum, the returned error wraps ErrChecksumMismatch.
 //
 // It is only thread safe to read from a segment if the key being read has previously been flushed to disk.
 func (s *Segment) Read(key []byte, dataAddress types.Address) ([]byte, error) {
 	shard := s.GetShard(key)
 	values := s.shards[shard]
 
+	s.fileLock.RLock()
+	defer s.fileLock.RUnlock()
+
 	value, err := values.read(dataAddress.Offset())
 	if err != nil {
 		return nil, fmt.Errorf("failed to read value: %w", err)
@@ -470,14 +524,19 @@ func (s *Segment) Read(key []byte, dataAddress types.Address) ([]byte, error) {
 }
 
 // GetKeys returns all keys in the data segment. Only permitted to be called after the segment has been sealed.
+// If the key file is corrupt, the keys preceding the corrupt entry are returned along with an error that
+// wraps ErrChecksumMismatch.
 func (s *Segment) GetKeys() ([]*types.ScopedKey, error) {
 	if !s.metadata.sealed {
 		return nil, fmt.Errorf("segment is not sealed, cannot read keys")
 	}
 
+	s.fileLock.RLock()
+	defer s.fileLock.RUnlock()
+
 	keys, err := s.keys.readKeys()
 	if err != nil {
-		return nil, fmt.Errorf("failed to read keys: %w", err)
+		return keys, fmt.Errorf("failed to read keys: %w", err)
 	}
 	return keys, nil
 }
@@ -632,6 +691,17 @@ func (s *Segment) delete() error {
 		s.deletionChannel <- struct{}{}
 	}()
 
+	if s.readOnly {
+		// The files belong to another process, that process is responsible for deleting them.
+		if s.nextSegment != nil {
+			s.nextSegment.Release()
+		}
+		return nil
+	}
+
+	s.fileLock.RLock()
+	defer s.fileLock.RUnlock()
+
 	err := s.keys.delete()
 	if err != nil {
 		return fmt.Errorf("failed to delete key file, segment %d: %w", s.index, err)
@@ -51,7 +51,7 @@ func scanDirectories(logger logging.Logger, rootDirectories []string) (
 			var index uint32
 
 			switch extension {
-			case MetadataSwapExtension, KeyFileSwapExtension:
+			case MetadataSwapExtension, KeyFileSwapExtension, RelocationSwapExtension:
 				garbageFiles = append(garbageFiles, filePath)
 				continue
 			case MetadataFileExtension:
@@ -299,6 +299,13 @@ func GatherSegmentFiles(
 	rootDirectories []string,
 	now time.Time) (lowestSegmentIndex uint32, highestSegmentIndex uint32, segments map[uint32]*Segment, err error) {
 
+	// A crash while segment files were being moved between directories may leave duplicate files behind.
+	err = repairInterruptedRelocations(logger, rootDirectories)
+	if err != nil {
+		return 0, 0, nil,
+			fmt.Errorf("failed to repair interrupted relocations: %v", err)
+	}
+
 	// Scan the root directories for segment files.
 	metadataFiles, keyFiles, valueFiles, garbageFiles, highestSegmentIndex, lowestSegmentIndex, err :=
 		scanDirectories(logger, rootDirectories)
@@ -65,7 +65,7 @@ func TestWriteAndReadSegmentSingleShard(t *testing.T) {
 		value := values[i]
 		expectedValues[string(key)] = value
 
-		expectedLargestShardSize += uint64(len(value)) + 4 /* uint32 length */
+		expectedLargestShardSize += uint64(len(value)) + valueOverhead(LatestSegmentVersion)
 
 		_, _, err := seg.Write(&types.KVPair{Key: key, Value: value})
 		largestShardSize := seg.GetMaxShardSize()
@@ -14,7 +14,11 @@ const (
 	// ValueSizeSegmentVersion adds the length of values to the key file. Previously, only the key and the address were
 	// stored in the key file. It also adds the key count to the segment metadata file.
 	ValueSizeSegmentVersion SegmentVersion = 2
+
+	// ChecksumSegmentVersion adds a CRC32C checksum after each value in the value files, and after each entry in the
+	// key file. The metadata file format is unchanged from ValueSizeSegmentVersion.
+	ChecksumSegmentVersion SegmentVersion = 3
 )
 
 // LatestSegmentVersion always refers to the latest version of the segment serialization format.
-const LatestSegmentVersion = ValueSizeSegmentVersion
+const LatestSegmentVersion = ChecksumSegmentVersion
@@ -0,0 +1,179 @@
+package segment
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/Layr-Labs/eigenda/litt/util"
+)
+
+// Snapshot places a copy of this segment's files in the target directory. Where possible, files are hard linked
+// instead of copied. Only sealed segments may be snapshotted, since the files of a sealed segment are never modified.
+//
+// The metadata file is always written last. If the metadata file for a segment is present in a snapshot directory,
+// then all other files for that segment are guaranteed to also be present.
+func (s *Segment) Snapshot(targetDirectory string) error {
+	if !s.IsSealed() {
+		return fmt.Errorf("segment %d is not sealed, cannot snapshot", s.index)
+	}
+
+	s.fileLock.RLock()
+	defer s.fileLock.RUnlock()
+
+	err := util.LinkOrCopyFile(s.keys.path(), path.Join(targetDirectory, s.keys.name()))
+	if err != nil {
+		return fmt.Errorf("failed to snapshot key file: %w", err)
+	}
+
+	for _, shard := range s.shards {
+		err = util.LinkOrCopyFile(shard.path(), path.Join(targetDirectory, shard.name()))
+		if err != nil {
+			return fmt.Errorf("failed to snapshot value file: %w", err)
+		}
+	}
+
+	// Write the metadata file to a swap location first, then atomically move it into place.
+	swapPath := path.Join(targetDirectory, s.metadata.swapName())
+	err = util.LinkOrCopyFile(s.metadata.path(), swapPath)
+	if err != nil {
+		return fmt.Errorf("failed to snapshot metadata file: %w", err)
+	}
+	err = os.Rename(swapPath, path.Join(targetDirectory, s.metadata.name()))
+	if err != nil {
+		return fmt.Errorf("failed to rename metadata file: %w", err)
+	}
+
+	return nil
+}
+
+// segmentFileIndex returns the segment index of a segment file. Returns false if the file is not a metadata file,
+// a key file, or a value file.
+func segmentFileIndex(fileName string) (uint32, bool) {
+	var index uint32
+	var err error
+
+	switch {
+	case strings.HasSuffix(fileName, MetadataFileExtension):
+		index, err = getMetadataFileIndex(fileName)
+	case strings.HasSuffix(fileName, KeyFileExtension):
+		index, err = getKeyFileIndex(fileName)
+	case strings.HasSuffix(fileName, ValuesFileExtension):
+		index, err = getValueFileIndex(fileName)
+	default:
+		return 0, false
+	}
+
+	if err != nil {
+		return 0, false
+	}
+	return index, true
+}
+
+// GetSnapshotSegmentIndices returns the indices of all segments that are fully present in a snapshot directory.
+func GetSnapshotSegmentIndices(snapshotDirectory string) (map[uint32]struct{}, error) {
+	entries, err := os.ReadDir(snapshotDirectory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory %s: %w", snapshotDirectory, err)
+	}
+
+	indices := make(map[uint32]struct{})
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), MetadataFileExtension) {
+			continue
+		}
+		index, ok := segmentFileIndex(entry.Name())
+		if ok {
+			indices[index] = struct{}{}
+		}
+	}
+
+	return indices, nil
+}
+
+// PruneSnapshot deletes all segment files from a snapshot directory that do not belong to one of the segments
+// in the keep set. Also deletes swap files left behind by an interrupted snapshot. Files that are not recognized
+// as segment files are not touched.
+func PruneSnapshot(snapshotDirectory string, keep map[uint32]struct{}) error {
+	entries, err := os.ReadDir(snapshotDirectory)
+	if err != nil {
+		return fmt.Errorf("failed to read directory %s: %w", snapshotDirectory, err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		fileName := entry.Name()
+
+		if strings.HasSuffix(fileName, MetadataSwapExtension) || strings.HasSuffix(fileName, KeyFileSwapExtension) {
+			err = os.Remove(path.Join(snapshotDirectory, fileName))
+			if err != nil {
+				return fmt.Errorf("failed to remove swap file %s: %w", fileName, err)
+			}
+			continue
+		}
+
+		index, ok := segmentFileIndex(fileName)
+		if !ok {
+			continue
+		}
+		if _, kept := keep[index]; kept {
+			continue
+		}
+
+		err = os.Remove(path.Join(snapshotDirectory, fileName))
+		if err != nil {
+			return fmt.Errorf("failed to remove file %s: %w", fileName, err)
+		}
+	}
+
+	return nil
+}
+
+// RestoreSnapshot places the segment files found in a snapshot directory into the given segment directories.
+// Where possible, files are hard linked instead of copied. Files are spread across the segment directories as
+// described by SelectDirectory(). Segments that are not fully present in the snapshot are ignored.
+func RestoreSnapshot(snapshotDirectory string, segmentDirectories []string) error {
+	if len(segmentDirectories) == 0 {
+		return fmt.Errorf("at least one segment directory must be provided")
+	}
+
+	completeSegments, err := GetSnapshotSegmentIndices(snapshotDirectory)
+	if err != nil {
+		return fmt.Errorf("failed to get snapshot segment indices: %w", err)
+	}
+
+	entries, err := os.ReadDir(snapshotDirectory)
+	if err != nil {
+		return fmt.Errorf("failed to read directory %s: %w", snapshotDirectory, err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		fileName := entry.Name()
+
+		index, ok := segmentFileIndex(fileName)
+		if !ok {
+			continue
+		}
+		if _, complete := completeSegments[index]; !complete {
+			continue
+		}
+
+		destination, _, err := SelectDirectory(fileName, segmentDirectories)
+		if err != nil {
+			return fmt.Errorf("failed to select directory for file %s: %w", fileName, err)
+		}
+
+		err = util.LinkOrCopyFile(path.Join(snapshotDirectory, fileName), path.Join(destination, fileName))
+		if err != nil {
+			return fmt.Errorf("failed to restore file %s: %w", fileName, err)
+		}
+	}
+
+	return nil
+}
@@ -34,6 +34,9 @@ type valueFile struct {
 	// The parent directory containing this file.
 	parentDirectory string
 
+	// The segment version. Determines serialization format.
+	segmentVersion SegmentVersion
+
 	// The file wrapped by the writer. If the file is sealed, this value is nil.
 	file *os.File
 
@@ -60,6 +63,7 @@ func createValueFile(
 	index uint32,
 	shard uint32,
 	parentDirectory string,
+	segmentVersion SegmentVersion,
 	fsync bool) (*valueFile, error) {
 
 	values := &valueFile{
@@ -67,6 +71,7 @@ func createValueFile(
 		index:           index,
 		shard:           shard,
 		parentDirectory: parentDirectory,
+		segmentVersion:  segmentVersion,
 		fsync:           fsync,
 	}
 
@@ -98,7 +103,8 @@ func loadValueFile(
 	logger logging.Logger,
 	index uint32,
 	shard uint32,
-	parentDirectories []string) (*valueFile, error) {
+	parentDirectories []string,
+	segmentVersion SegmentVersion) (*valueFile, error) {
 
 	valuesFileName := fmt.Sprintf("%d-%d%s", index, shard, ValuesFileExtension)
 	valuesPath, err := lookForFile(parentDirectories, valuesFileName)
@@ -116,6 +122,7 @@ func loadValueFile(
 		index:           index,
 		shard:           shard,
 		parentDirectory: parentDirectory,
+		segmentVersion:  segmentVersion,
 		fsync:           false,
 	}
 
@@ -210,6 +217,9 @@ func (v *valueFile) read(firstByteIndex uint32) ([]byte, error) {
 	}()
 
 	_, err = file.Seek(int64(firstByteIndex), 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to seek in value file: %v", err)
+	}
 	reader := bufio.NewReader(file)
 
 	// Read the length of the value.
@@ -230,6 +240,19 @@ func (v *valueFile) read(firstByteIndex uint32) ([]byte, error) {
 		return nil, fmt.Errorf("failed to read value from value file: read %d bytes, expected %d", bytesRead, length)
 	}
 
+	if hasChecksums(v.segmentVersion) {
+		var checksum uint32
+		err = binary.Read(reader, binary.BigEndian, &checksum)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read checksum from value file: %v", err)
+		}
+
+		if checksum != computeChecksum(value) {
+			return nil, fmt.Errorf("value at offset %d in value file %s is corrupt: %w",
+				firstByteIndex, v.path(), ErrChecksumMismatch)
+		}
+	}
+
 	return value, nil
 }
 
@@ -259,7 +282,15 @@ func (v *valueFile) write(value []byte) (uint32, error) {
 		return 0, fmt.Errorf("failed to write value to value file: %v", err)
 	}
 
-	v.size += uint64(len(value) + 4)
+	if hasChecksums(v.segmentVersion) {
+		// Finally, write the checksum of the value.
+		err = binary.Write(v.writer, binary.BigEndian, computeChecksum(value))
+		if err != nil {
+			return 0, fmt.Errorf("failed to write checksum to value file: %v", err)
+		}
+	}
+
+	v.size += uint64(len(value)) + valueOverhead(v.segmentVersion)
 
 	return firstByteIndex, nil
 }
@@ -23,13 +23,13 @@ func TestWriteThenReadValues(t *testing.T) {
 	expectedFileSize := uint64(0)
 	for i := 0; i < int(valueCount); i++ {
 		values[i] = rand.VariableBytes(1, 100)
-		expectedFileSize += uint64(len(values[i])) + 4 /* length uint32 */
+		expectedFileSize += uint64(len(values[i])) + valueOverhead(LatestSegmentVersion)
 	}
 
 	// A map from the first byte index of the value to the value itself.
 	addressMap := make(map[uint32][]byte)
 
-	file, err := createValueFile(logger, index, shard, directory, false)
+	file, err := createValueFile(logger, index, shard, directory, LatestSegmentVersion, false)
 	require.NoError(t, err)
 
 	for _, value := range values {
@@ -71,7 +71,7 @@ func TestWriteThenReadValues(t *testing.T) {
 	require.Equal(t, actualFileSize, reportedFileSize)
 
 	// Create a new in-memory instance from the on-disk file and verify that it behaves the same.
-	file2, err := loadValueFile(logger, index, shard, []string{directory})
+	file2, err := loadValueFile(logger, index, shard, []string{directory}, LatestSegmentVersion)
 	require.NoError(t, err)
 	require.Equal(t, file.size, file2.size)
 	for key, val := range addressMap {
@@ -110,7 +110,7 @@ func TestReadingTruncatedValueFile(t *testing.T) {
 	// A map from the first byte index of the value to the value itself.
 	addressMap := make(map[uint32][]byte)
 
-	file, err := createValueFile(logger, index, shard, directory, false)
+	file, err := createValueFile(logger, index, shard, directory, LatestSegmentVersion, false)
 	require.NoError(t, err)
 
 	var lastAddress uint32
@@ -138,7 +138,7 @@ func TestReadingTruncatedValueFile(t *testing.T) {
 	err = os.WriteFile(filePath, bytes, 0644)
 	require.NoError(t, err)
 
-	file, err = loadValueFile(logger, index, shard, []string{directory})
+	file, err = loadValueFile(logger, index, shard, []string{directory}, LatestSegmentVersion)
 	require.NoError(t, err)
 
 	// We should be able to read all values except for the last one.
@@ -160,7 +160,7 @@ func TestReadingTruncatedValueFile(t *testing.T) {
 	err = os.WriteFile(filePath, bytes, 0644)
 	require.NoError(t, err)
 
-	file, err = loadValueFile(logger, index, shard, []string{directory})
+	file, err = loadValueFile(logger, index, shard, []string{directory}, LatestSegmentVersion)
 	require.NoError(t, err)
 
 	// We should be able to read all values except for the last one.
@@ -185,3 +185,100 @@ func TestReadingTruncatedValueFile(t *testing.T) {
 	_, err = os.Stat(filePath)
 	require.True(t, os.IsNotExist(err))
 }
+
+func TestCorruptValueFile(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+	logger, err := common.NewLogger(common.DefaultTextLoggerConfig())
+	require.NoError(t, err)
+	directory := t.TempDir()
+
+	index := rand.Uint32()
+	shard := rand.Uint32()
+	valueCount := rand.Int32Range(100, 200)
+
+	// A map from the first byte index of the value to the value itself.
+	addressMap := make(map[uint32][]byte)
+
+	file, err := createValueFile(logger, index, shard, directory, LatestSegmentVersion, false)
+	require.NoError(t, err)
+
+	for i := 0; i < int(valueCount); i++ {
+		value := rand.VariableBytes(1, 100)
+		address, err := file.write(value)
+		require.NoError(t, err)
+		addressMap[address] = value
+	}
+
+	err = file.seal()
+	require.NoError(t, err)
+
+	// Flip a bit in the body of a random value.
+	var corruptAddress uint32
+	for corruptAddress = range addressMap {
+		// iteration order is random
+		break
+	}
+	corruptValue := addressMap[corruptAddress]
+	corruptByteIndex := int(corruptAddress) + 4 /* length */ + int(rand.Int32Range(0, int32(len(corruptValue))))
+
+	fileBytes, err := os.ReadFile(file.path())
+	require.NoError(t, err)
+	fileBytes[corruptByteIndex] ^= 1 << rand.Int32Range(0, 8)
+	err = os.WriteFile(file.path(), fileBytes, 0644)
+	require.NoError(t, err)
+
+	file, err = loadValueFile(logger, index, shard, []string{directory}, LatestSegmentVersion)
+	require.NoError(t, err)
+
+	// The corrupt value should be detected, all other values should be readable.
+	for address, expectedValue := range addressMap {
+		value, err := file.read(address)
+		if address == corruptAddress {
+			require.ErrorIs(t, err, ErrChecksumMismatch)
+		} else {
+			require.NoError(t, err)
+			require.Equal(t, expectedValue, value)
+		}
+	}
+}
+
+func TestReadValueFileWithoutChecksums(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+	logger, err := common.NewLogger(common.DefaultTextLoggerConfig())
+	require.NoError(t, err)
+	directory := t.TempDir()
+
+	index := rand.Uint32()
+	shard := rand.Uint32()
+	valueCount := rand.Int32Range(100, 200)
+
+	// A map from the first byte index of the value to the value itself.
+	addressMap := make(map[uint32][]byte)
+	expectedFileSize := uint64(0)
+
+	file, err := createValueFile(logger, index, shard, directory, ValueSizeSegmentVersion, false)
+	require.NoError(t, err)
+
+	for i := 0; i < int(valueCount); i++ {
+		value := rand.VariableBytes(1, 100)
+		address, err := file.write(value)
+		require.NoError(t, err)
+		addressMap[address] = value
+		expectedFileSize += uint64(len(value)) + 4 /* length uint32 */
+	}
+
+	err = file.seal()
+	require.NoError(t, err)
+	require.Equal(t, expectedFileSize, file.Size())
+
+	file, err = loadValueFile(logger, index, shard, []string{directory}, ValueSizeSegmentVersion)
+	require.NoError(t, err)
+
+	for address, expectedValue := range addressMap {
+		value, err := file.read(address)
+		require.NoError(t, err)
+		require.Equal(t, expectedValue, value)
+	}
+}
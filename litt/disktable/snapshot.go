@@ -0,0 +1,308 @@
+package disktable
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/litt/disktable/keymap"
+	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// Snapshot writes a consistent snapshot of the table to the target directory. The mutable segment is sealed before
+// the snapshot is taken, so all data written prior to this call is included in the snapshot. Writes may continue
+// while the snapshot is being taken, but data written after this call begins is not included.
+//
+// Sealed segment files are never modified, and so they are hard linked into the snapshot where possible (and copied
+// otherwise). If the target directory already contains a snapshot of this table, the snapshot is updated
+// incrementally: only segments not already present in the target directory are added, and segments that have since
+// been garbage collected are removed.
+//
+// The snapshot contains its own LevelDB keymap covering exactly the keys in the snapshot, which is updated
+// incrementally along with the segments. The table's own keymap can't be used, since it also tracks data that is not
+// part of the snapshot. When a table is restored from a snapshot, the snapshot's keymap is copied instead of being
+// rebuilt from the key files.
+func (d *DiskTable) Snapshot(targetDirectory string) error {
+	if ok, err := d.fatalErrorHandler.IsOk(); !ok {
+		return fmt.Errorf("Cannot process Snapshot() request, DB is in panicked state due to error: %w", err)
+	}
+
+	request := &controlLoopReserveSegmentsRequest{
+		sealMutableSegment: true,
+		responseChan:       make(chan []*segment.Segment, 1),
+	}
+	err := d.controlLoop.enqueue(request)
+	if err != nil {
+		return fmt.Errorf("failed to send reserve segments request: %w", err)
+	}
+
+	segments, err := util.AwaitIfNotFatal(d.fatalErrorHandler, request.responseChan)
+	if err != nil {
+		return fmt.Errorf("failed to reserve segments: %w", err)
+	}
+	defer func() {
+		for _, seg := range segments {
+			seg.Release()
+		}
+	}()
+
+	return writeSnapshot(d.logger, d.fatalErrorHandler, d.name, d.metadata, segments, targetDirectory, d.clock)
+}
+
+// writeSnapshot writes a snapshot containing the given sealed segments to the target directory, see Snapshot().
+// The caller is responsible for holding a reservation on each segment until this method returns.
+func writeSnapshot(
+	logger logging.Logger,
+	fatalErrorHandler *util.FatalErrorHandler,
+	name string,
+	metadata *tableMetadata,
+	segments []*segment.Segment,
+	targetDirectory string,
+	clock func() time.Time) error {
+
+	start := clock()
+
+	snapshotSegmentDirectory := path.Join(targetDirectory, segmentDirectory)
+	err := os.MkdirAll(snapshotSegmentDirectory, 0755)
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	previouslySnapshotted, err := segment.GetSnapshotSegmentIndices(snapshotSegmentDirectory)
+	if err != nil {
+		return fmt.Errorf("failed to read previous snapshot: %w", err)
+	}
+
+	keep := make(map[uint32]struct{}, len(segments))
+	newSegments := make([]*segment.Segment, 0, len(segments))
+	for _, seg := range segments {
+		keep[seg.Index()] = struct{}{}
+		if _, ok := previouslySnapshotted[seg.Index()]; ok {
+			continue
+		}
+
+		err = seg.Snapshot(snapshotSegmentDirectory)
+		if err != nil {
+			return fmt.Errorf("failed to snapshot segment %d: %w", seg.Index(), err)
+		}
+		newSegments = append(newSegments, seg)
+	}
+
+	removedSegments := make([]uint32, 0)
+	for index := range previouslySnapshotted {
+		if _, kept := keep[index]; !kept {
+			removedSegments = append(removedSegments, index)
+		}
+	}
+
+	// The keymap must be updated before the garbage collected segments are pruned, since the keys to remove from the
+	// keymap are read from the key files of those segments.
+	err = writeSnapshotKeymap(
+		logger, fatalErrorHandler, targetDirectory, segments, newSegments, removedSegments)
+	if err != nil {
+		return fmt.Errorf("failed to write keymap to snapshot: %w", err)
+	}
+
+	// Remove segments that have been garbage collected since the previous snapshot. If these were left in place,
+	// the snapshot could contain a gap in its segment indices, which would prevent it from being loaded.
+	err = segment.PruneSnapshot(snapshotSegmentDirectory, keep)
+	if err != nil {
+		return fmt.Errorf("failed to prune snapshot: %w", err)
+	}
+
+	_, err = newTableMetadata(logger, targetDirectory, metadata.GetTTL(), metadata.GetShardingFactor())
+	if err != nil {
+		return fmt.Errorf("failed to write table metadata to snapshot: %w", err)
+	}
+
+	logger.Infof("snapshot of table %s written to %s, %d segment(s) total, %d new segment(s), took %v",
+		name, targetDirectory, len(segments), len(newSegments), clock().Sub(start))
+
+	return nil
+}
+
+// writeSnapshotKeymap brings the keymap of a snapshot up to date: the keys of the new segments are added, and the
+// keys of the removed segments (which must still be present in the snapshot directory) are deleted. If the snapshot
+// does not yet contain a fully written keymap, the keymap is instead built from scratch from all segments.
+//
+// The initialized marker file is removed while the keymap is being modified, so a keymap left behind by an
+// interrupted snapshot is rebuilt by the next snapshot (and is ignored when restoring).
+func writeSnapshotKeymap(
+	logger logging.Logger,
+	fatalErrorHandler *util.FatalErrorHandler,
+	targetDirectory string,
+	segments []*segment.Segment,
+	newSegments []*segment.Segment,
+	removedSegments []uint32) error {
+
+	keymapDirectory := path.Join(targetDirectory, keymap.KeymapDirectoryName)
+	initializedPath := path.Join(keymapDirectory, keymap.KeymapInitializedFileName)
+
+	initialized, err := util.Exists(initializedPath)
+	if err != nil {
+		return fmt.Errorf("failed to check if keymap initialized file exists: %w", err)
+	}
+	if initialized {
+		err = os.Remove(initializedPath)
+		if err != nil {
+			return fmt.Errorf("failed to remove keymap initialized file: %w", err)
+		}
+	} else {
+		err = os.RemoveAll(keymapDirectory)
+		if err != nil {
+			return fmt.Errorf("failed to remove incomplete keymap: %w", err)
+		}
+		newSegments = segments
+		removedSegments = nil
+
+		err = os.MkdirAll(keymapDirectory, 0755)
+		if err != nil {
+			return fmt.Errorf("failed to create keymap directory: %w", err)
+		}
+		err = keymap.NewKeymapTypeFile(keymapDirectory, keymap.LevelDBKeymapType).Write()
+		if err != nil {
+			return fmt.Errorf("failed to write keymap type file: %w", err)
+		}
+	}
+
+	kmap, _, err := keymap.NewLevelDBKeymap(
+		logger, path.Join(keymapDirectory, keymap.KeymapDataDirectoryName), false)
+	if err != nil {
+		return fmt.Errorf("failed to open keymap: %w", err)
+	}
+
+	err = updateSnapshotKeymap(
+		logger, fatalErrorHandler, kmap, targetDirectory, newSegments, removedSegments)
+	if err != nil {
+		_ = kmap.Stop()
+		return err
+	}
+
+	err = kmap.Stop()
+	if err != nil {
+		return fmt.Errorf("failed to stop keymap: %w", err)
+	}
+
+	f, err := os.Create(initializedPath)
+	if err != nil {
+		return fmt.Errorf("failed to create keymap initialized file: %w", err)
+	}
+	err = f.Close()
+	if err != nil {
+		return fmt.Errorf("failed to close keymap initialized file: %w", err)
+	}
+
+	return nil
+}
+
+// updateSnapshotKeymap deletes the keys of the removed segments from the keymap, then adds the keys of the new
+// segments.
+func updateSnapshotKeymap(
+	logger logging.Logger,
+	fatalErrorHandler *util.FatalErrorHandler,
+	kmap keymap.Keymap,
+	targetDirectory string,
+	newSegments []*segment.Segment,
+	removedSegments []uint32) error {
+
+	snapshotSegmentDirectories := []string{path.Join(targetDirectory, segmentDirectory)}
+	for _, index := range removedSegments {
+		seg, ok, err := segment.LoadSealedSegment(logger, fatalErrorHandler, index, snapshotSegmentDirectories)
+		if err != nil {
+			return fmt.Errorf("failed to load snapshot segment %d: %w", index, err)
+		}
+		if !ok {
+			return fmt.Errorf("snapshot segment %d is incomplete", index)
+		}
+		keys, err := seg.GetKeys()
+		if err != nil {
+			return fmt.Errorf("failed to get keys from snapshot segment %d: %w", index, err)
+		}
+		err = kmap.Delete(keys)
+		if err != nil {
+			return fmt.Errorf("failed to delete keys of segment %d from keymap: %w", index, err)
+		}
+	}
+
+	for _, seg := range newSegments {
+		keys, err := seg.GetKeys()
+		if err != nil {
+			return fmt.Errorf("failed to get keys from segment %d: %w", seg.Index(), err)
+		}
+		for batchStart := 0; batchStart < len(keys); batchStart += keymapReloadBatchSize {
+			batchEnd := min(batchStart+keymapReloadBatchSize, len(keys))
+			err = kmap.Put(keys[batchStart:batchEnd])
+			if err != nil {
+				return fmt.Errorf("failed to put keys of segment %d into keymap: %w", seg.Index(), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// RestoreSnapshot places the data from a table snapshot (as written by Snapshot()) into the given table root
+// directories. Where possible, files are hard linked instead of copied. The root directories must not already
+// contain table data. Once restored, the table can be loaded by NewDiskTable() using the same root directories.
+func RestoreSnapshot(snapshotDirectory string, roots []string) error {
+	if len(roots) == 0 {
+		return fmt.Errorf("at least one root directory must be provided")
+	}
+
+	exists, err := util.Exists(metadataPath(snapshotDirectory))
+	if err != nil {
+		return fmt.Errorf("failed to check if table metadata file exists: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("directory %s does not contain a table snapshot", snapshotDirectory)
+	}
+
+	segmentDirectories := make([]string, 0, len(roots))
+	for _, root := range roots {
+		entries, err := os.ReadDir(root)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to read root directory %s: %w", root, err)
+		}
+		if len(entries) > 0 {
+			return fmt.Errorf("root directory %s is not empty, refusing to overwrite existing data", root)
+		}
+
+		segDir := path.Join(root, segmentDirectory)
+		err = os.MkdirAll(segDir, 0755)
+		if err != nil {
+			return fmt.Errorf("failed to create segment directory: %w", err)
+		}
+		segmentDirectories = append(segmentDirectories, segDir)
+	}
+
+	// The table metadata file is replaced (not modified in place) when it is updated, so it is safe to link.
+	err = util.LinkOrCopyFile(metadataPath(snapshotDirectory), metadataPath(roots[0]))
+	if err != nil {
+		return fmt.Errorf("failed to restore table metadata: %w", err)
+	}
+
+	err = segment.RestoreSnapshot(path.Join(snapshotDirectory, segmentDirectory), segmentDirectories)
+	if err != nil {
+		return fmt.Errorf("failed to restore segments: %w", err)
+	}
+
+	// The keymap is copied rather than linked, since LevelDB modifies some of its files in place. The keymap is
+	// placed in the first root, which is where the table looks for it. If the snapshot's keymap is incomplete, it
+	// is left out, and the keymap is rebuilt from the segments when the table is opened.
+	snapshotKeymapDirectory := path.Join(snapshotDirectory, keymap.KeymapDirectoryName)
+	keymapInitialized, err := util.Exists(path.Join(snapshotKeymapDirectory, keymap.KeymapInitializedFileName))
+	if err != nil {
+		return fmt.Errorf("failed to check if keymap initialized file exists: %w", err)
+	}
+	if keymapInitialized {
+		err = util.CopyDirectoryRecursively(snapshotKeymapDirectory, path.Join(roots[0], keymap.KeymapDirectoryName))
+		if err != nil {
+			return fmt.Errorf("failed to restore keymap: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package disktable
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
+	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/Layr-Labs/eigenda/litt/util"
+)
+
+var _ litt.TableIterator = (*tableIterator)(nil)
+
+// tableIterator iterates over the key-value pairs in a disk table, one segment at a time. The iterator holds a
+// reservation on each segment it has not yet finished iterating over, which prevents those segments from being
+// deleted by the garbage collector.
+type tableIterator struct {
+	// The metadata of the table being iterated over, used to determine the table's TTL.
+	metadata *tableMetadata
+
+	// The time source used by the table.
+	clock func() time.Time
+
+	// The segments that have not yet been fully iterated over, in order of increasing segment index. The iterator
+	// holds a reservation on each of these segments. The segment currently being iterated over is at index 0.
+	segments []*segment.Segment
+
+	// The keys in the segment currently being iterated over. Nil if the keys have not yet been loaded.
+	keys []*types.ScopedKey
+
+	// The index of the next key to return from keys.
+	keyIndex int
+
+	// If non-empty, only keys with this prefix are returned.
+	prefix []byte
+
+	// If true, the iterator has been closed.
+	closed bool
+}
+
+// Iterate returns an iterator over all live key-value pairs in the table.
+func (d *DiskTable) Iterate(options *litt.IteratorOptions) (litt.TableIterator, error) {
+	if ok, err := d.fatalErrorHandler.IsOk(); !ok {
+		return nil, fmt.Errorf("Cannot process Iterate() request, DB is in panicked state due to error: %w", err)
+	}
+
+	if options == nil {
+		options = &litt.IteratorOptions{}
+	}
+
+	request := &controlLoopReserveSegmentsRequest{
+		sealMutableSegment: !options.OnlySealedData,
+		responseChan:       make(chan []*segment.Segment, 1),
+	}
+	err := d.controlLoop.enqueue(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send reserve segments request: %w", err)
+	}
+
+	segments, err := util.AwaitIfNotFatal(d.fatalErrorHandler, request.responseChan)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve segments: %w", err)
+	}
+
+	return &tableIterator{
+		metadata: d.metadata,
+		clock:    d.clock,
+		segments: segments,
+		prefix:   options.Prefix,
+	}, nil
+}
+
+// Next returns the next key-value pair in the table.
+func (t *tableIterator) Next() (*types.KVPair, bool, error) {
+	if t.closed {
+		return nil, false, fmt.Errorf("iterator is closed")
+	}
+
+	for len(t.segments) > 0 {
+		seg := t.segments[0]
+
+		if t.keys == nil {
+			if t.isExpired(seg) {
+				// All data in this segment has expired, skip it entirely.
+				t.advanceSegment()
+				continue
+			}
+
+			keys, err := seg.GetKeys()
+			if err != nil {
+				return nil, false, fmt.Errorf("failed to get keys for segment %s: %w", seg.String(), err)
+			}
+			t.keys = keys
+			t.keyIndex = 0
+		}
+
+		for t.keyIndex < len(t.keys) {
+			key := t.keys[t.keyIndex]
+			t.keyIndex++
+
+			if len(t.prefix) > 0 && !bytes.HasPrefix(key.Key, t.prefix) {
+				continue
+			}
+
+			value, err := seg.Read(key.Key, key.Address)
+			if err != nil {
+				return nil, false, fmt.Errorf("failed to read value from segment %s: %w", seg.String(), err)
+			}
+
+			return &types.KVPair{Key: key.Key, Value: value}, true, nil
+		}
+
+		t.advanceSegment()
+	}
+
+	return nil, false, nil
+}
+
+// isExpired returns true if all data in the given segment has expired.
+func (t *tableIterator) isExpired(seg *segment.Segment) bool {
+	ttl := t.metadata.GetTTL()
+	if ttl <= 0 {
+		return false
+	}
+	return t.clock().Sub(seg.GetSealTime()) >= ttl
+}
+
+// advanceSegment releases the segment currently being iterated over and moves on to the next segment.
+func (t *tableIterator) advanceSegment() {
+	t.segments[0].Release()
+	t.segments[0] = nil
+	t.segments = t.segments[1:]
+	t.keys = nil
+	t.keyIndex = 0
+}
+
+// Close releases all segment reservations held by the iterator.
+func (t *tableIterator) Close() error {
+	if t.closed {
+		return nil
+	}
+	t.closed = true
+
+	for _, seg := range t.segments {
+		seg.Release()
+	}
+	t.segments = nil
+	t.keys = nil
+
+	return nil
+}
@@ -63,6 +63,22 @@ func newTableMetadata(
 
 // loadTableMetadata loads the table metadata from disk.
 func loadTableMetadata(logger logging.Logger, tableDirectory string) (*tableMetadata, error) {
+	metadata, err := readTableMetadata(logger, tableDirectory)
+	if err != nil {
+		return nil, err
+	}
+
+	err = metadata.deleteOrphanedSwapFile()
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete orphaned swap file: %v", err)
+	}
+
+	return metadata, nil
+}
+
+// readTableMetadata reads the table metadata from disk without modifying any files. Unlike loadTableMetadata(),
+// this is safe to call while another process owns the table.
+func readTableMetadata(logger logging.Logger, tableDirectory string) (*tableMetadata, error) {
 	mPath := metadataPath(tableDirectory)
 
 	exists, err := util.Exists(mPath)
@@ -85,11 +101,6 @@ func loadTableMetadata(logger logging.Logger, tableDirectory string) (*tableMeta
 	metadata.logger = logger
 	metadata.tableDirectory = tableDirectory
 
-	err = metadata.deleteOrphanedSwapFile()
-	if err != nil {
-		return nil, fmt.Errorf("failed to delete orphaned swap file: %v", err)
-	}
-
 	return metadata, nil
 }
 
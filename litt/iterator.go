@@ -0,0 +1,40 @@
+package litt
+
+import "github.com/Layr-Labs/eigenda/litt/types"
+
+// IteratorOptions configures the behavior of a TableIterator.
+type IteratorOptions struct {
+	// If non-empty, then only keys that begin with this prefix are returned by the iterator. Note that because
+	// LittDB does not store keys in sorted order, a prefix filter does not make iteration cheaper, it only
+	// reduces the number of values that need to be read from disk.
+	Prefix []byte
+
+	// If false (default), then all data written to the table prior to the creation of the iterator is visible
+	// to the iterator. For disk tables, this is achieved by sealing the mutable segment (if it contains any data)
+	// at the moment the iterator is created. If true, then only data in segments that were already sealed
+	// when the iterator was created is visible. This avoids the creation of small segments when iterators are
+	// created frequently, at the cost of not observing recently written data.
+	OnlySealedData bool
+}
+
+// TableIterator iterates over the key-value pairs in a table. Iteration is unordered. Key-value pairs are returned
+// in the order in which they are stored on disk (i.e. roughly in the order they were written), NOT in key order.
+//
+// An iterator provides a consistent view of the table. Data that is visible when the iterator is created can not be
+// deleted by garbage collection until the iterator is closed. Data written after the iterator is created is never
+// visible to the iterator. Data that has expired by the time the iterator reaches it is skipped, even if it has
+// not yet been garbage collected.
+//
+// A TableIterator is not thread safe. It is the caller's responsibility to call Close() when done with the iterator.
+// Until an iterator is closed, the table is unable to delete the data visible to the iterator from disk.
+type TableIterator interface {
+	// Next returns the next key-value pair. If there are no more key-value pairs, ok is false. If an error is
+	// returned, the values of the other return values are undefined.
+	//
+	// For the sake of performance, the returned data is NOT safe to mutate.
+	Next() (kv *types.KVPair, ok bool, err error)
+
+	// Close releases all resources held by the iterator. After Close is called, Next must not be called.
+	// It is safe to call Close more than once.
+	Close() error
+}
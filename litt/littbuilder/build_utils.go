@@ -36,6 +36,7 @@ func cacheWeight(key string, value []byte) uint64 {
 // buildKeymap creates a new keymap based on the configuration.
 func buildKeymap(
 	config *litt.Config,
+	paths []string,
 	logger logging.Logger,
 	tableName string,
 ) (kmap keymap.Keymap, keymapPath string, keymapTypeFile *keymap.KeymapTypeFile, requiresReload bool, err error) {
@@ -46,8 +47,8 @@ func buildKeymap(
 			fmt.Errorf("unsupported keymap type: %v", config.KeymapType)
 	}
 
-	potentialKeymapDirectories := make([]string, len(config.Paths))
-	for i, p := range config.Paths {
+	potentialKeymapDirectories := make([]string, len(paths))
+	for i, p := range paths {
 		potentialKeymapDirectories[i] = path.Join(p, tableName, keymap.KeymapDirectoryName)
 	}
 
@@ -181,9 +182,10 @@ func buildKeymap(
 	return kmap, keymapDirectory, keymapTypeFile, requiresReload || newKeymap, nil
 }
 
-// buildTable creates a new table based on the configuration.
+// buildTable creates a new table based on the configuration, with its data stored in the given paths.
 func buildTable(
 	config *litt.Config,
+	paths []string,
 	logger logging.Logger,
 	name string,
 	metrics *metrics.LittDBMetrics) (litt.ManagedTable, error) {
@@ -194,28 +196,37 @@ func buildTable(
 		return nil, fmt.Errorf("sharding factor must be at least 1")
 	}
 
-	kmap, keymapDirectory, keymapTypeFile, requiresReload, err := buildKeymap(config, logger, name)
-	if err != nil {
-		return nil, fmt.Errorf("error creating keymap: %w", err)
-	}
-
-	tableRoots := make([]string, len(config.Paths))
-	for i, p := range config.Paths {
+	tableRoots := make([]string, len(paths))
+	for i, p := range paths {
 		tableRoots[i] = path.Join(p, name)
 	}
 
-	table, err = disktable.NewDiskTable(
-		config,
-		name,
-		kmap,
-		keymapDirectory,
-		keymapTypeFile,
-		tableRoots,
-		requiresReload,
-		metrics)
+	if config.ReadOnly {
+		// A read-only table never touches the keymap on disk, it builds its own index in memory.
+		var err error
+		table, err = disktable.NewReadOnlyDiskTable(config, name, tableRoots)
+		if err != nil {
+			return nil, fmt.Errorf("error opening table in read-only mode: %w", err)
+		}
+	} else {
+		kmap, keymapDirectory, keymapTypeFile, requiresReload, err := buildKeymap(config, paths, logger, name)
+		if err != nil {
+			return nil, fmt.Errorf("error creating keymap: %w", err)
+		}
 
-	if err != nil {
-		return nil, fmt.Errorf("error creating table: %w", err)
+		table, err = disktable.NewDiskTable(
+			config,
+			name,
+			kmap,
+			keymapDirectory,
+			keymapTypeFile,
+			tableRoots,
+			requiresReload,
+			metrics)
+
+		if err != nil {
+			return nil, fmt.Errorf("error creating table: %w", err)
+		}
 	}
 
 	writeCache := cache.NewFIFOCache[string, []byte](config.WriteCacheSize, cacheWeight, metrics.GetWriteCacheMetrics())
@@ -229,6 +240,30 @@ func buildTable(
 	return cachedTable, nil
 }
 
+// NewTable builds a single table without building a DB around it. This is intended for tools that operate on
+// individual tables (e.g. the littdb CLI). Metrics are not reported for tables built this way. The table must not
+// be in use by any other DB instance. Unlike NewDB, this method does not take the lock on the DB's paths. Unless the
+// table is opened in read-only mode, the caller must hold that lock (see LockPaths) for as long as the table is open.
+// After this method is called, the config object should not be modified.
+func NewTable(config *litt.Config, name string) (litt.ManagedTable, error) {
+	var err error
+	config.Logger, err = buildLogger(config)
+	if err != nil {
+		return nil, fmt.Errorf("error building logger: %w", err)
+	}
+
+	err = config.SanityCheck()
+	if err != nil {
+		return nil, fmt.Errorf("error checking config: %w", err)
+	}
+
+	if !tableNameRegex.MatchString(name) {
+		return nil, fmt.Errorf("table name %s is invalid", name)
+	}
+
+	return buildTable(config, config.Paths, config.Logger, name, nil)
+}
+
 // buildLogger creates a new logger based on the configuration.
 func buildLogger(config *litt.Config) (logging.Logger, error) {
 	if config.Logger != nil {
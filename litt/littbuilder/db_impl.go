@@ -4,12 +4,14 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"path"
 	"regexp"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/disktable"
 	"github.com/Layr-Labs/eigenda/litt/metrics"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 )
@@ -20,11 +22,12 @@ var tableNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
 
 var _ litt.DB = &db{}
 
-// TableBuilderFunc is a function that creates a new table.
+// TableBuilderFunc is a function that creates a new table, with its data stored in the given paths.
 type TableBuilderFunc func(
 	ctx context.Context,
 	logger logging.Logger,
 	name string,
+	paths []string,
 	metrics *metrics.LittDBMetrics) (litt.ManagedTable, error)
 
 // db is an implementation of DB.
@@ -41,15 +44,26 @@ type db struct {
 	// The period between garbage collection runs.
 	gcPeriod time.Duration
 
+	// If true, the database was opened in read-only mode.
+	readOnly bool
+
+	// The paths where the database stores its data. This is a private copy of the configured paths, which is
+	// updated as paths are added and removed at runtime, so that tables opened later use the current set of
+	// paths. Protected by lock.
+	paths []string
+
 	// A function that creates new tables.
 	tableBuilder TableBuilderFunc
 
 	// A map of all tables in the database.
 	tables map[string]litt.ManagedTable
 
-	// Protects access to tables and ttl.
+	// Protects access to tables, ttl, and paths.
 	lock sync.Mutex
 
+	// Serializes calls to Snapshot(). Snapshots do not hold lock while writing, since that may take a long time.
+	snapshotLock sync.Mutex
+
 	// True if the database has been stopped.
 	stopped atomic.Bool
 
@@ -58,39 +72,91 @@ type db struct {
 
 	// The HTTP server for metrics. nil if metrics are disabled or if an external party is managing the server.
 	metricsServer *http.Server
+
+	// The lock on the paths where the database stores its data, held until the database is closed. nil if the
+	// database was opened in read-only mode. Protected by lock.
+	pathLock *PathLock
 }
 
 // NewDB creates a new DB instance. After this method is called, the config object should not be modified.
+//
+// Unless the DB is opened in read-only mode, it takes the lock on its paths (see LockPaths) and holds it until it is
+// closed. An error wrapping ErrDBInUse is returned if the DB is already in use by another writer.
 func NewDB(config *litt.Config) (litt.DB, error) {
+	pathLock, err := lockIfWritable(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return openDB(config, pathLock)
+}
+
+// lockIfWritable takes the lock on the configured paths, unless the DB is being opened in read-only mode.
+func lockIfWritable(config *litt.Config) (*PathLock, error) {
+	if config.ReadOnly {
+		return nil, nil
+	}
+
+	pathLock, err := LockPaths(config.Paths...)
+	if err != nil {
+		return nil, fmt.Errorf("error locking DB: %w", err)
+	}
+	return pathLock, nil
+}
+
+// releaseOnError releases a path lock after a DB fails to open, and returns the error that caused the failure.
+func releaseOnError(pathLock *PathLock, err error) error {
+	releaseErr := pathLock.Release()
+	if releaseErr != nil {
+		return fmt.Errorf("%w (failed to release lock: %v)", err, releaseErr)
+	}
+	return err
+}
+
+// openDB creates a new DB instance with the default table builder. The DB takes ownership of the given lock, which
+// is released if the DB can't be opened.
+func openDB(config *litt.Config, pathLock *PathLock) (litt.DB, error) {
 	var err error
 	config.Logger, err = buildLogger(config)
 	if err != nil {
-		return nil, fmt.Errorf("error building logger: %w", err)
+		return nil, releaseOnError(pathLock, fmt.Errorf("error building logger: %w", err))
 	}
 
 	err = config.SanityCheck()
 	if err != nil {
-		return nil, fmt.Errorf("error checking config: %w", err)
+		return nil, releaseOnError(pathLock, fmt.Errorf("error checking config: %w", err))
 	}
 
 	tableBuilder := func(
 		ctx context.Context,
 		logger logging.Logger,
 		name string,
+		paths []string,
 		metrics *metrics.LittDBMetrics) (litt.ManagedTable, error) {
 
-		return buildTable(config, logger, name, metrics)
+		return buildTable(config, paths, logger, name, metrics)
 	}
 
-	return NewDBUnsafe(config, tableBuilder)
+	return newDB(config, tableBuilder, pathLock)
 }
 
 // NewDBUnsafe creates a new DB instance with a custom table builder. This is intended for unit test use,
 // and should not be considered a stable API.
 func NewDBUnsafe(config *litt.Config, tableBuilder TableBuilderFunc) (litt.DB, error) {
+	pathLock, err := lockIfWritable(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return newDB(config, tableBuilder, pathLock)
+}
+
+// newDB creates a new DB instance. The DB takes ownership of the given lock, which is released if the DB can't
+// be opened.
+func newDB(config *litt.Config, tableBuilder TableBuilderFunc, pathLock *PathLock) (litt.DB, error) {
 	logger, err := buildLogger(config)
 	if err != nil {
-		return nil, fmt.Errorf("error building logger: %w", err)
+		return nil, releaseOnError(pathLock, fmt.Errorf("error building logger: %w", err))
 	}
 
 	var dbMetrics *metrics.LittDBMetrics
@@ -105,17 +171,24 @@ func NewDBUnsafe(config *litt.Config, tableBuilder TableBuilderFunc) (litt.DB, e
 		clock:         config.Clock,
 		ttl:           config.TTL,
 		gcPeriod:      config.GCPeriod,
+		readOnly:      config.ReadOnly,
+		paths:         append([]string(nil), config.Paths...),
 		tableBuilder:  tableBuilder,
 		tables:        make(map[string]litt.ManagedTable),
 		metrics:       dbMetrics,
 		metricsServer: metricsServer,
+		pathLock:      pathLock,
 	}
 
 	if config.MetricsEnabled {
 		go database.gatherMetrics(config.MetricsUpdateInterval)
 	}
 
-	logger.Infof("LittDB started, current data size: %d", database.Size())
+	if config.ReadOnly {
+		logger.Infof("LittDB started in read-only mode")
+	} else {
+		logger.Infof("LittDB started, current data size: %d", database.Size())
+	}
 
 	return database, nil
 }
@@ -167,7 +240,7 @@ func (d *db) GetTable(name string) (litt.Table, error) {
 
 		var err error
 		d.logger.Infof("creating table %s", name)
-		table, err = d.tableBuilder(d.ctx, d.logger, name, d.metrics)
+		table, err = d.tableBuilder(d.ctx, d.logger, name, d.paths, d.metrics)
 		if err != nil {
 			return nil, fmt.Errorf("error creating table: %w", err)
 		}
@@ -179,6 +252,10 @@ func (d *db) GetTable(name string) (litt.Table, error) {
 }
 
 func (d *db) DropTable(name string) error {
+	if d.readOnly {
+		return fmt.Errorf("cannot drop table %s: %w", name, litt.ErrReadOnly)
+	}
+
 	d.lock.Lock()
 	defer d.lock.Unlock()
 
@@ -199,6 +276,162 @@ func (d *db) DropTable(name string) error {
 	return nil
 }
 
+func (d *db) Snapshot(targetDirectory string) error {
+	d.snapshotLock.Lock()
+	defer d.snapshotLock.Unlock()
+
+	// Snapshotting a table may take a long time, so the lock is only held while copying the table list. A table
+	// dropped in the meantime fails to snapshot, since it has been stopped.
+	d.lock.Lock()
+	tables := make(map[string]litt.ManagedTable, len(d.tables))
+	for name, table := range d.tables {
+		tables[name] = table
+	}
+	d.lock.Unlock()
+
+	d.logger.Infof("writing snapshot to %s", targetDirectory)
+
+	for name, table := range tables {
+		err := table.Snapshot(path.Join(targetDirectory, name))
+		if err != nil {
+			return fmt.Errorf("error writing snapshot of table %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func (d *db) AddPath(newPath string) error {
+	if d.readOnly {
+		return fmt.Errorf("cannot add path %s: %w", newPath, litt.ErrReadOnly)
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	for _, p := range d.paths {
+		if path.Clean(p) == path.Clean(newPath) {
+			return fmt.Errorf("path %s is already in use", newPath)
+		}
+	}
+
+	d.logger.Infof("adding path %s", newPath)
+
+	err := d.pathLock.Add(newPath)
+	if err != nil {
+		return fmt.Errorf("error locking path %s: %w", newPath, err)
+	}
+
+	added := make([]string, 0, len(d.tables))
+	for name, table := range d.tables {
+		err = table.AddRoot(path.Join(newPath, name))
+		if err != nil {
+			d.rollBackAddPath(newPath, added)
+			return fmt.Errorf("error adding path to table %s: %w", name, err)
+		}
+		added = append(added, name)
+	}
+
+	d.paths = append(d.paths, newPath)
+
+	return nil
+}
+
+// rollBackAddPath removes a path from the tables it was added to by a call to AddPath that failed partway through,
+// and releases the lock on that path. The caller must hold the lock.
+func (d *db) rollBackAddPath(newPath string, tableNames []string) {
+	for _, name := range tableNames {
+		err := d.tables[name].RemoveRoot(path.Join(newPath, name))
+		if err != nil {
+			d.logger.Errorf("error removing path %s from table %s after failing to add it: %v", newPath, name, err)
+		}
+	}
+
+	err := d.pathLock.Remove(newPath)
+	if err != nil {
+		d.logger.Errorf("error unlocking path %s after failing to add it: %v", newPath, err)
+	}
+}
+
+func (d *db) RemovePath(oldPath string) error {
+	if d.readOnly {
+		return fmt.Errorf("cannot remove path %s: %w", oldPath, litt.ErrReadOnly)
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	index := -1
+	for i, p := range d.paths {
+		if path.Clean(p) == path.Clean(oldPath) {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		return fmt.Errorf("path %s is not in use", oldPath)
+	}
+	if index == 0 {
+		return fmt.Errorf("cannot remove path %s, the first path cannot be removed", oldPath)
+	}
+
+	// Check all tables before changing anything, so that a table that can't be moved doesn't leave the
+	// database with some tables removed from the path and others not.
+	tablesOnDisk, err := disktable.ListTables([]string{oldPath})
+	if err != nil {
+		return fmt.Errorf("error listing tables: %w", err)
+	}
+	for _, name := range tablesOnDisk {
+		err = disktable.VerifyRootRemovable(path.Join(oldPath, name))
+		if err != nil {
+			return fmt.Errorf("cannot remove path %s from table %s: %w", oldPath, name, err)
+		}
+	}
+
+	d.logger.Infof("removing path %s", oldPath)
+
+	// Tables that have not been opened may still have data on the path being removed.
+	for _, name := range tablesOnDisk {
+		if _, ok := d.tables[name]; ok {
+			continue
+		}
+		d.logger.Infof("opening table %s to move its data off of path %s", name, oldPath)
+		table, err := d.tableBuilder(d.ctx, d.logger, name, d.paths, d.metrics)
+		if err != nil {
+			return fmt.Errorf("error opening table %s: %w", name, err)
+		}
+		d.tables[name] = table
+	}
+
+	// A root can't be added back to a table while its data is being moved off of it, so a removal can't be rolled
+	// back. Every table is checked before the path is removed from any of them instead.
+	for name, table := range d.tables {
+		err = table.CheckRemoveRoot(path.Join(oldPath, name))
+		if err != nil {
+			return fmt.Errorf("cannot remove path %s from table %s: %w", oldPath, name, err)
+		}
+	}
+
+	for name, table := range d.tables {
+		err = table.RemoveRoot(path.Join(oldPath, name))
+		if err != nil {
+			return fmt.Errorf("error removing path from table %s: %w", name, err)
+		}
+	}
+
+	paths := make([]string, 0, len(d.paths)-1)
+	paths = append(paths, d.paths[:index]...)
+	paths = append(paths, d.paths[index+1:]...)
+	d.paths = paths
+
+	err = d.pathLock.Remove(oldPath)
+	if err != nil {
+		return fmt.Errorf("error unlocking path %s: %w", oldPath, err)
+	}
+
+	return nil
+}
+
 func (d *db) Close() error {
 	d.lock.Lock()
 	defer d.lock.Unlock()
@@ -213,10 +446,19 @@ func (d *db) Close() error {
 		}
 	}
 
+	err := d.pathLock.Release()
+	if err != nil {
+		return fmt.Errorf("error releasing lock: %w", err)
+	}
+
 	return nil
 }
 
 func (d *db) Destroy() error {
+	if d.readOnly {
+		return fmt.Errorf("cannot destroy database: %w", litt.ErrReadOnly)
+	}
+
 	d.lock.Lock()
 	defer d.lock.Unlock()
 
@@ -229,6 +471,11 @@ func (d *db) Destroy() error {
 		}
 	}
 
+	err := d.pathLock.Delete()
+	if err != nil {
+		return fmt.Errorf("error deleting lock: %w", err)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,125 @@
+package littbuilder
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/gofrs/flock"
+)
+
+// LockFileName is the name of the lock file that LittDB keeps in each of its paths. A process that may modify the DB
+// holds an exclusive lock on this file in every path the DB uses. The file is left in place when the lock is released.
+const LockFileName = "littdb.lock"
+
+// ErrDBInUse is returned when the lock on a DB can't be taken because another writer holds it.
+var ErrDBInUse = errors.New("DB is in use")
+
+// PathLock is an exclusive lock on the paths of a DB. Only one writer, be it a DB instance or a maintenance tool,
+// may hold the lock on a path at any given time. DBs opened in read-only mode do not take the lock, and may be
+// opened while another process is writing to the DB.
+//
+// The lock is an advisory file lock, so it only protects DBs that are accessed from a single host (or on a file
+// system with working flock support). A PathLock is not thread safe.
+type PathLock struct {
+	// File locks, keyed by the cleaned path that contains the lock file.
+	locks map[string]*flock.Flock
+}
+
+// LockPaths takes the lock on each of the given paths, creating the paths if they do not exist. Returns an error
+// wrapping ErrDBInUse if any of the paths are locked by another writer. If an error is returned, no locks are held.
+func LockPaths(paths ...string) (*PathLock, error) {
+	lock := &PathLock{
+		locks: make(map[string]*flock.Flock, len(paths)),
+	}
+
+	for _, p := range paths {
+		err := lock.Add(p)
+		if err != nil {
+			releaseErr := lock.Release()
+			if releaseErr != nil {
+				return nil, fmt.Errorf("%w (failed to release partially taken lock: %v)", err, releaseErr)
+			}
+			return nil, err
+		}
+	}
+
+	return lock, nil
+}
+
+// Add takes the lock on an additional path, creating the path if it does not exist. Adding a path that is already
+// locked by this PathLock is a no-op.
+func (l *PathLock) Add(p string) error {
+	p = path.Clean(p)
+	if _, ok := l.locks[p]; ok {
+		return nil
+	}
+
+	err := os.MkdirAll(p, 0755)
+	if err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", p, err)
+	}
+
+	fileLock := flock.New(path.Join(p, LockFileName))
+	locked, err := fileLock.TryLock()
+	if err != nil {
+		return fmt.Errorf("failed to lock %s: %w", p, err)
+	}
+	if !locked {
+		return fmt.Errorf("failed to lock %s: %w", p, ErrDBInUse)
+	}
+
+	l.locks[p] = fileLock
+	return nil
+}
+
+// Remove releases the lock on a single path. Removing a path that is not locked by this PathLock is a no-op.
+func (l *PathLock) Remove(p string) error {
+	p = path.Clean(p)
+	fileLock, ok := l.locks[p]
+	if !ok {
+		return nil
+	}
+
+	err := fileLock.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to unlock %s: %w", p, err)
+	}
+	delete(l.locks, p)
+
+	return nil
+}
+
+// Release releases the lock on every path. Calling Release on a nil PathLock is a no-op.
+func (l *PathLock) Release() error {
+	if l == nil {
+		return nil
+	}
+
+	for p := range l.locks {
+		err := l.Remove(p)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Delete deletes the lock file in every path and then releases the lock. Intended for use when the DB is destroyed,
+// so that its paths are left empty. Calling Delete on a nil PathLock is a no-op.
+func (l *PathLock) Delete() error {
+	if l == nil {
+		return nil
+	}
+
+	for p := range l.locks {
+		err := os.Remove(path.Join(p, LockFileName))
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to delete lock file in %s: %w", p, err)
+		}
+	}
+
+	return l.Release()
+}
@@ -0,0 +1,47 @@
+package littbuilder
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/Layr-Labs/eigenda/litt/disktable"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// MigratePaths moves the data of a DB from one set of paths to another, spreading segment files across the new
+// paths. Paths may be added, removed, or both. Paths that appear in both sets keep the files that belong there.
+// Paths that are removed are left in place (but emptied of table data), since they may contain unrelated files.
+//
+// The DB must not be running while its paths are being migrated, and the caller must hold the lock on both the old and
+// the new paths (see LockPaths). If a migration is interrupted, it is safe to call this method again with the same
+// arguments.
+func MigratePaths(logger logging.Logger, oldPaths []string, newPaths []string) error {
+	if len(newPaths) == 0 {
+		return fmt.Errorf("at least one new path must be provided")
+	}
+
+	tables, err := disktable.ListTables(oldPaths)
+	if err != nil {
+		return fmt.Errorf("error listing tables: %w", err)
+	}
+
+	for _, name := range tables {
+		logger.Infof("migrating table %s", name)
+
+		oldRoots := make([]string, len(oldPaths))
+		for i, p := range oldPaths {
+			oldRoots[i] = path.Join(p, name)
+		}
+		newRoots := make([]string, len(newPaths))
+		for i, p := range newPaths {
+			newRoots[i] = path.Join(p, name)
+		}
+
+		err = disktable.MigrateTable(logger, oldRoots, newRoots)
+		if err != nil {
+			return fmt.Errorf("error migrating table %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
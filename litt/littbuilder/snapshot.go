@@ -0,0 +1,61 @@
+package littbuilder
+
+import (
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/disktable"
+)
+
+// NewDBFromSnapshot restores a snapshot written by DB.Snapshot() into the directories described by config.Paths,
+// and then opens a DB on top of those directories. The directories in config.Paths must not already contain data
+// for any table present in the snapshot. The config.Paths do not need to match the paths used by the database that
+// produced the snapshot, which makes this suitable for moving a database to new hardware.
+//
+// Where possible, segment files are hard linked from the snapshot instead of being copied. Segment files in a snapshot
+// are never modified in place, so the snapshot remains valid (and may continue to be incrementally updated) after a restore.
+// Keymaps are copied from the snapshot. A table's keymap is only rebuilt if the snapshot's keymap is incomplete, or if
+// config.KeymapType is not a LevelDB keymap.
+//
+// The lock on config.Paths (see LockPaths) is taken before the snapshot is restored, and is held by the returned DB
+// until it is closed.
+func NewDBFromSnapshot(snapshotDirectory string, config *litt.Config) (litt.DB, error) {
+	if len(config.Paths) == 0 {
+		return nil, fmt.Errorf("at least one path must be provided")
+	}
+
+	entries, err := os.ReadDir(snapshotDirectory)
+	if err != nil {
+		return nil, fmt.Errorf("error reading snapshot directory %s: %w", snapshotDirectory, err)
+	}
+
+	pathLock, err := LockPaths(config.Paths...)
+	if err != nil {
+		return nil, fmt.Errorf("error locking DB: %w", err)
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		tableName := entry.Name()
+		if !tableNameRegex.MatchString(tableName) {
+			return nil, releaseOnError(pathLock, fmt.Errorf("snapshot contains invalid table name %s", tableName))
+		}
+
+		tableRoots := make([]string, len(config.Paths))
+		for i, p := range config.Paths {
+			tableRoots[i] = path.Join(p, tableName)
+		}
+
+		err = disktable.RestoreSnapshot(path.Join(snapshotDirectory, tableName), tableRoots)
+		if err != nil {
+			return nil, releaseOnError(pathLock,
+				fmt.Errorf("error restoring table %s from snapshot: %w", tableName, err))
+		}
+	}
+
+	return openDB(config, pathLock)
+}
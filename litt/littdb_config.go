@@ -28,7 +28,8 @@ type Config struct {
 	// drives, then providing multiple permits that. The number of provided paths should be a small number, perhaps
 	// a few dozen paths at most. Providing an excessive number of paths may lead to degraded performance.
 	//
-	// Providing zero paths will cause the DB to return an error at startup.
+	// Providing zero paths will cause the DB to return an error at startup. Paths can be added and removed while
+	// the database is running via DB.AddPath() and DB.RemovePath().
 	Paths []string
 
 	// The logger for the database. If nil, a logger is built using the LoggerConfig.
@@ -71,6 +72,20 @@ type Config struct {
 	// The size of the keymap deletion batch for garbage collection. The default is 10,000.
 	GCBatchSize uint64
 
+	// The period between scrub runs. During a scrub, every value in every sealed segment is read back from disk and
+	// verified against its checksum. Corrupt keys are logged and reported via metrics. Scrubbing reads the entire
+	// contents of the database, and so this period should be long (e.g. once a day). Segments written prior to the
+	// introduction of checksums can only be partially verified. If zero (the default), periodic scrubbing is disabled.
+	ScrubPeriod time.Duration
+
+	// If true, then when a scrub finds a corrupt segment, the segment is quarantined. A copy of the segment's files
+	// is placed in a "quarantine" directory in the table's first root directory for offline analysis, and the keys
+	// whose values failed verification are removed from the keymap. Once quarantined, those keys appear to the rest
+	// of the system as if they had never been written, which permits the data to be re-fetched from another source.
+	// Keys in the segment whose values passed verification remain readable. The quarantined segment is deleted from
+	// the table normally when its data expires. Default is false.
+	QuarantineCorruptSegments bool
+
 	// The sharding factor for the database. If the sharding factor is greater than 1, then values will be spread
 	// out across multiple files. (Note that individual values will always be written to a single file, but two
 	// different values may be written to different files.) These shard files are spead evenly across the paths
@@ -135,6 +150,35 @@ type Config struct {
 	// The interval at which various DB metrics are updated. The default is 1 second.
 	MetricsUpdateInterval time.Duration
 
+	// If true, the database is opened in read-only mode. A read-only database never modifies any files on disk, and
+	// so it can be opened by one process while another process is actively writing to the same database. This is
+	// intended for tools that need to inspect a live database (e.g. analytics and debugging tools).
+	//
+	// A read-only database only sees data in segments that were sealed at the time the database was opened (or at the
+	// time of the most recent refresh, see ReadOnlyRefreshPeriod). Data that has not yet been flushed and sealed by
+	// the writing process is not visible. Garbage collection and flushing are never performed by a read-only
+	// database. Operations that would modify the database (e.g. Put, SetTTL, DropTable) return ErrReadOnly, and
+	// tables that do not already exist on disk cannot be opened.
+	//
+	// The keymap of a read-only database is always rebuilt in memory from the segment key files when a table is
+	// opened, since the keymap on disk is owned by the writing process (and LevelDB does not permit a second process
+	// to open it, even for reading). The KeymapType setting is ignored. The in-memory keymap costs roughly 100 bytes
+	// plus the length of the key for each key in the table, and is bounded by ReadOnlyMaxKeyCount.
+	// Default is false.
+	ReadOnly bool
+
+	// If ReadOnly is true, this is the maximum number of keys that a table may hold in its in-memory keymap. A table
+	// with more keys in its sealed segments can not be opened in read-only mode, and a refresh that would push a table
+	// over this limit fails (leaving the table's view of the data unchanged). Default is 10,000,000, which amounts to
+	// roughly 1.5 GiB of memory per table for 32 byte keys. Ignored if ReadOnly is false.
+	ReadOnlyMaxKeyCount uint64
+
+	// If ReadOnly is true and this is non-zero, then each table periodically checks for segments that have been
+	// sealed or garbage collected by the writing process since the table was opened, and updates its view of the
+	// data accordingly. If zero (the default), a read-only database only ever sees the data that was present on disk
+	// when each table was opened. Ignored if ReadOnly is false.
+	ReadOnlyRefreshPeriod time.Duration
+
 	// A function that is called if the database experiences a non-recoverable error (e.g. data corruption,
 	// a crashed goroutine, a full disk, etc.). If nil (the default), no callback is called. If called at all,
 	// this method is called exactly once.
@@ -172,6 +216,7 @@ func DefaultConfig(paths ...string) (*Config, error) {
 		MetricsNamespace:         "litt",
 		MetricsPort:              9101,
 		MetricsUpdateInterval:    time.Second,
+		ReadOnlyMaxKeyCount:      10_000_000,
 	}, nil
 }
 
@@ -214,6 +259,9 @@ func (c *Config) SanityCheck() error {
 	if c.SaltShaker == nil {
 		return fmt.Errorf("salt shaker cannot be nil")
 	}
+	if c.ReadOnly && c.ReadOnlyMaxKeyCount == 0 {
+		return fmt.Errorf("read-only max key count must be at least 1")
+	}
 	if (c.MetricsEnabled || c.MetricsRegistry != nil) && c.MetricsUpdateInterval == 0 {
 		return fmt.Errorf("metrics update interval must be at least 1 if metrics are enabled")
 	}
@@ -2,6 +2,7 @@ package memtable
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -147,6 +148,63 @@ func (m *memTable) Exists(key []byte) (exists bool, err error) {
 	return exists, nil
 }
 
+func (m *memTable) Iterate(options *litt.IteratorOptions) (litt.TableIterator, error) {
+	if options == nil {
+		options = &litt.IteratorOptions{}
+	}
+
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	// The expiration queue holds keys in the order in which they were inserted, which mirrors the
+	// iteration order of a disk table. Take a snapshot of the data so that the iterator provides a consistent view.
+	earliestPermittedCreationTime := m.clock().Add(-m.ttl)
+	snapshot := make([]*types.KVPair, 0, m.expirationQueue.Size())
+	for _, item := range m.expirationQueue.Values() {
+		expiration := item.(*expirationRecord)
+		if m.ttl > 0 && !expiration.creationTime.After(earliestPermittedCreationTime) {
+			continue
+		}
+		if !strings.HasPrefix(expiration.key, string(options.Prefix)) {
+			continue
+		}
+		snapshot = append(snapshot, &types.KVPair{
+			Key:   []byte(expiration.key),
+			Value: m.data[expiration.key],
+		})
+	}
+
+	return &memTableIterator{data: snapshot}, nil
+}
+
+// memTableIterator iterates over a snapshot of the data in a memTable.
+type memTableIterator struct {
+	// The snapshot of the data being iterated over.
+	data []*types.KVPair
+	// The index of the next key-value pair to return.
+	index int
+	// If true, the iterator has been closed.
+	closed bool
+}
+
+func (i *memTableIterator) Next() (*types.KVPair, bool, error) {
+	if i.closed {
+		return nil, false, fmt.Errorf("iterator is closed")
+	}
+	if i.index >= len(i.data) {
+		return nil, false, nil
+	}
+	kv := i.data[i.index]
+	i.index++
+	return kv, true, nil
+}
+
+func (i *memTableIterator) Close() error {
+	i.closed = true
+	i.data = nil
+	return nil
+}
+
 func (m *memTable) Flush() error {
 	// This is a no-op for a memory table. Memory tables are ephemeral by nature.
 	return nil
@@ -187,6 +245,29 @@ func (m *memTable) SetShardingFactor(shardingFactor uint32) error {
 	return nil
 }
 
+func (m *memTable) Snapshot(targetDirectory string) error {
+	return fmt.Errorf("snapshots are not supported by in-memory tables")
+}
+
+func (m *memTable) GetSegmentInfo() ([]*litt.SegmentInfo, error) {
+	return []*litt.SegmentInfo{}, nil
+}
+
+func (m *memTable) AddRoot(root string) error {
+	// the memory table does not store data on disk
+	return nil
+}
+
+func (m *memTable) RemoveRoot(root string) error {
+	// the memory table does not store data on disk
+	return nil
+}
+
+func (m *memTable) CheckRemoveRoot(root string) error {
+	// the memory table does not store data on disk
+	return nil
+}
+
 func (m *memTable) RunGC() error {
 	m.lock.Lock()
 	defer m.lock.Unlock()
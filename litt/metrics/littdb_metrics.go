@@ -74,6 +74,27 @@ type LittDBMetrics struct {
 	// The latency of garbage collection operations.1
 	garbageCollectionLatency *prometheus.SummaryVec
 
+	// The latency of scrub operations.
+	scrubLatency *prometheus.SummaryVec
+
+	// The number of keys verified by scrub operations since startup.
+	keysScrubbedCounter *prometheus.CounterVec
+
+	// The number of corrupt keys found by scrub operations since startup.
+	corruptKeyCounter *prometheus.CounterVec
+
+	// The number of corrupt segments found by scrub operations since startup.
+	corruptSegmentCounter *prometheus.CounterVec
+
+	// The number of segments quarantined since startup.
+	quarantinedSegmentCounter *prometheus.CounterVec
+
+	// The number of bytes moved off of removed paths since startup.
+	pathMigrationBytesMovedCounter *prometheus.CounterVec
+
+	// The number of bytes that still need to be moved off of removed paths.
+	pathMigrationBytesRemaining *prometheus.GaugeVec
+
 	// Metrics for the write cache.
 	writeCacheMetrics *cache.CacheMetrics
 
@@ -243,6 +264,70 @@ func NewLittDBMetrics(registry *prometheus.Registry, namespace string) *LittDBMe
 		[]string{"table"},
 	)
 
+	scrubLatency := promauto.With(registry).NewSummaryVec(
+		prometheus.SummaryOpts{
+			Namespace:  namespace,
+			Name:       "scrub_latency_ms",
+			Help:       "Reports on the latency of scrub operations.",
+			Objectives: objectives,
+		},
+		[]string{"table"},
+	)
+
+	keysScrubbedCounter := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "keys_scrubbed",
+			Help:      "The number of keys verified by scrub operations since startup.",
+		},
+		[]string{"table"},
+	)
+
+	corruptKeyCounter := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "corrupt_keys",
+			Help:      "The number of corrupt keys found by scrub operations since startup.",
+		},
+		[]string{"table"},
+	)
+
+	corruptSegmentCounter := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "corrupt_segments",
+			Help:      "The number of corrupt segments found by scrub operations since startup.",
+		},
+		[]string{"table"},
+	)
+
+	quarantinedSegmentCounter := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "quarantined_segments",
+			Help:      "The number of segments quarantined since startup.",
+		},
+		[]string{"table"},
+	)
+
+	pathMigrationBytesMovedCounter := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "path_migration_bytes_moved",
+			Help:      "The number of bytes moved off of removed paths since startup.",
+		},
+		[]string{"table"},
+	)
+
+	pathMigrationBytesRemaining := promauto.With(registry).NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "path_migration_bytes_remaining",
+			Help:      "The number of bytes that still need to be moved off of removed paths.",
+		},
+		[]string{"table"},
+	)
+
 	writeCacheMetrics := cache.NewCacheMetrics(
 		registry,
 		namespace,
@@ -256,24 +341,31 @@ func NewLittDBMetrics(registry *prometheus.Registry, namespace string) *LittDBMe
 	)
 
 	return &LittDBMetrics{
-		tableSizeInBytes:         tableSizeInBytes,
-		tableKeyCount:            tableKeyCount,
-		bytesReadCounter:         bytesReadCounter,
-		keysReadCounter:          keysReadCounter,
-		cacheHitCounter:          cacheHitCounter,
-		cacheMissCounter:         cacheMissCounter,
-		readLatency:              readLatency,
-		cacheMissLatency:         cacheMissLatency,
-		bytesWrittenCounter:      bytesWrittenCounter,
-		keysWrittenCounter:       keysWrittenCounter,
-		writeLatency:             writeLatency,
-		flushCount:               flushCount,
-		flushLatency:             flushLatency,
-		garbageCollectionLatency: garbageCollectionLatency,
-		segmentFlushLatency:      segmentFlushLatency,
-		keymapFlushLatency:       keymapFlushLatency,
-		writeCacheMetrics:        writeCacheMetrics,
-		readCacheMetrics:         readCacheMetrics,
+		tableSizeInBytes:               tableSizeInBytes,
+		tableKeyCount:                  tableKeyCount,
+		bytesReadCounter:               bytesReadCounter,
+		keysReadCounter:                keysReadCounter,
+		cacheHitCounter:                cacheHitCounter,
+		cacheMissCounter:               cacheMissCounter,
+		readLatency:                    readLatency,
+		cacheMissLatency:               cacheMissLatency,
+		bytesWrittenCounter:            bytesWrittenCounter,
+		keysWrittenCounter:             keysWrittenCounter,
+		writeLatency:                   writeLatency,
+		flushCount:                     flushCount,
+		flushLatency:                   flushLatency,
+		garbageCollectionLatency:       garbageCollectionLatency,
+		segmentFlushLatency:            segmentFlushLatency,
+		keymapFlushLatency:             keymapFlushLatency,
+		scrubLatency:                   scrubLatency,
+		keysScrubbedCounter:            keysScrubbedCounter,
+		corruptKeyCounter:              corruptKeyCounter,
+		corruptSegmentCounter:          corruptSegmentCounter,
+		quarantinedSegmentCounter:      quarantinedSegmentCounter,
+		writeCacheMetrics:              writeCacheMetrics,
+		readCacheMetrics:               readCacheMetrics,
+		pathMigrationBytesMovedCounter: pathMigrationBytesMovedCounter,
+		pathMigrationBytesRemaining:    pathMigrationBytesRemaining,
 	}
 }
 
@@ -371,6 +463,39 @@ func (m *LittDBMetrics) ReportGarbageCollectionLatency(tableName string, latency
 	m.garbageCollectionLatency.WithLabelValues(tableName).Observe(common.ToMilliseconds(latency))
 }
 
+// ReportScrub reports the results of a scrub operation.
+func (m *LittDBMetrics) ReportScrub(tableName string, latency time.Duration, keysScrubbed uint64, corruptKeys uint64) {
+	if m == nil {
+		return
+	}
+
+	m.scrubLatency.WithLabelValues(tableName).Observe(common.ToMilliseconds(latency))
+	m.keysScrubbedCounter.WithLabelValues(tableName).Add(float64(keysScrubbed))
+	m.corruptKeyCounter.WithLabelValues(tableName).Add(float64(corruptKeys))
+}
+
+// ReportCorruptSegment reports that a scrub operation found a corrupt segment.
+func (m *LittDBMetrics) ReportCorruptSegment(tableName string, quarantined bool) {
+	if m == nil {
+		return
+	}
+
+	m.corruptSegmentCounter.WithLabelValues(tableName).Inc()
+	if quarantined {
+		m.quarantinedSegmentCounter.WithLabelValues(tableName).Inc()
+	}
+}
+
+// ReportPathMigrationProgress reports progress made moving data off of removed paths.
+func (m *LittDBMetrics) ReportPathMigrationProgress(tableName string, bytesMoved uint64, bytesRemaining uint64) {
+	if m == nil {
+		return
+	}
+
+	m.pathMigrationBytesMovedCounter.WithLabelValues(tableName).Add(float64(bytesMoved))
+	m.pathMigrationBytesRemaining.WithLabelValues(tableName).Set(float64(bytesRemaining))
+}
+
 func (m *LittDBMetrics) GetWriteCacheMetrics() *cache.CacheMetrics {
 	if m == nil {
 		return nil
@@ -63,6 +63,13 @@ type Table interface {
 	// It is not safe to modify the key byte slice after it is passed to this method.
 	Exists(key []byte) (exists bool, err error)
 
+	// Iterate returns an iterator over all live (i.e. non-expired) key-value pairs in the table. Iteration is
+	// unordered, see TableIterator for details. If options is nil, default options are used.
+	//
+	// The caller is responsible for closing the returned iterator. Failing to do so prevents the table from
+	// deleting expired data from disk.
+	Iterate(options *IteratorOptions) (TableIterator, error)
+
 	// Flush ensures that all data written to the database is crash durable on disk. When this method returns,
 	// all data written by Put() operations is guaranteed to be crash durable. Put() operations that overlap with calls
 	// to Flush() may not be crash durable after this method returns.
@@ -131,4 +138,49 @@ type ManagedTable interface {
 	// This method is intended for use in tests, where it can be useful to force a garbage collection run to occur
 	// at a specific time.
 	RunGC() error
+
+	// Snapshot writes a consistent snapshot of the table's data to the target directory, see DB.Snapshot()
+	// for details.
+	Snapshot(targetDirectory string) error
+
+	// GetSegmentInfo returns information about each of the table's segments, in order of increasing segment index.
+	// Table implementations that do not store data in segments return an empty list.
+	GetSegmentInfo() ([]*SegmentInfo, error)
+
+	// AddRoot adds a root directory to the table, see DB.AddPath() for details. Table implementations that do not
+	// store data on disk ignore this call.
+	AddRoot(root string) error
+
+	// RemoveRoot removes a root directory from the table, see DB.RemovePath() for details. Table implementations
+	// that do not store data on disk ignore this call.
+	RemoveRoot(root string) error
+
+	// CheckRemoveRoot returns the error that RemoveRoot would return for the given root directory without removing
+	// it, or nil if it could currently be removed. This allows a root to be checked against every table before it is
+	// removed from any of them.
+	CheckRemoveRoot(root string) error
+}
+
+// SegmentInfo describes a single segment of a table. It is intended for diagnostic purposes.
+type SegmentInfo struct {
+	// The index of the segment.
+	Index uint32
+
+	// The serialization version of the segment.
+	Version uint32
+
+	// True if the segment is sealed (i.e. immutable).
+	Sealed bool
+
+	// The time when the segment was sealed. The zero time if the segment is not sealed.
+	SealTime time.Time
+
+	// The number of shards (i.e. value files) in the segment.
+	ShardCount uint32
+
+	// The number of keys in the segment.
+	KeyCount uint32
+
+	// The size of the segment on disk, in bytes.
+	Size uint64
 }
@@ -4,16 +4,19 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path"
 	"testing"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common/testutils/random"
 	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/disktable"
 	"github.com/Layr-Labs/eigenda/litt/disktable/keymap"
 	"github.com/Layr-Labs/eigenda/litt/littbuilder"
 	"github.com/Layr-Labs/eigenda/litt/memtable"
 	"github.com/Layr-Labs/eigenda/litt/metrics"
 	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/Layr-Labs/eigenda/litt/util"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/stretchr/testify/require"
 )
@@ -59,6 +62,7 @@ func buildMemDB(t *testing.T, path string) (litt.DB, error) {
 		ctx context.Context,
 		logger logging.Logger,
 		name string,
+		paths []string,
 		metrics *metrics.LittDBMetrics) (litt.ManagedTable, error) {
 		return memtable.NewMemTable(config, name), nil
 	}
@@ -314,3 +318,81 @@ func TestDBRestart(t *testing.T) {
 		})
 	}
 }
+
+func TestDBLock(t *testing.T) {
+	t.Parallel()
+
+	directory := t.TempDir()
+	paths := []string{path.Join(directory, "a"), path.Join(directory, "b")}
+
+	buildConfig := func(readOnly bool, paths ...string) *litt.Config {
+		config, err := litt.DefaultConfig(paths...)
+		require.NoError(t, err)
+		config.Fsync = false
+		config.ReadOnly = readOnly
+		return config
+	}
+
+	db, err := littbuilder.NewDB(buildConfig(false, paths...))
+	require.NoError(t, err)
+	table, err := db.GetTable("table")
+	require.NoError(t, err)
+	err = table.Put([]byte("key"), []byte("value"))
+	require.NoError(t, err)
+
+	// A second writer may not open the DB, even if it is only configured with some of the DB's paths.
+	_, err = littbuilder.NewDB(buildConfig(false, paths...))
+	require.ErrorIs(t, err, littbuilder.ErrDBInUse)
+	_, err = littbuilder.NewDB(buildConfig(false, paths[1]))
+	require.ErrorIs(t, err, littbuilder.ErrDBInUse)
+
+	// A failed attempt to lock the DB must not leave the paths it did lock behind.
+	otherPath := path.Join(directory, "c")
+	_, err = littbuilder.NewDB(buildConfig(false, otherPath, paths[0]))
+	require.ErrorIs(t, err, littbuilder.ErrDBInUse)
+	lock, err := littbuilder.LockPaths(otherPath)
+	require.NoError(t, err)
+	require.NoError(t, lock.Release())
+
+	// Readers do not take the lock.
+	reader, err := littbuilder.NewDB(buildConfig(true, paths...))
+	require.NoError(t, err)
+	require.NoError(t, reader.Close())
+
+	// Paths added at runtime are locked until they are removed.
+	err = db.AddPath(otherPath)
+	require.NoError(t, err)
+	_, err = littbuilder.LockPaths(otherPath)
+	require.ErrorIs(t, err, littbuilder.ErrDBInUse)
+	err = db.RemovePath(otherPath)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		tables, err := disktable.ListTables([]string{otherPath})
+		require.NoError(t, err)
+		return len(tables) == 0
+	}, 10*time.Second, 10*time.Millisecond)
+	lock, err = littbuilder.LockPaths(otherPath)
+	require.NoError(t, err)
+	require.NoError(t, lock.Release())
+
+	// Closing the DB releases the lock.
+	err = db.Close()
+	require.NoError(t, err)
+	db, err = littbuilder.NewDB(buildConfig(false, paths...))
+	require.NoError(t, err)
+	table, err = db.GetTable("table")
+	require.NoError(t, err)
+	value, ok, err := table.Get([]byte("key"))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, []byte("value"), value)
+
+	// Destroying the DB releases the lock and deletes the lock files.
+	err = db.Destroy()
+	require.NoError(t, err)
+	for _, p := range paths {
+		exists, err := util.Exists(path.Join(p, littbuilder.LockFileName))
+		require.NoError(t, err)
+		require.False(t, exists)
+	}
+}
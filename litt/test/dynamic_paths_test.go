@@ -0,0 +1,394 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/testutils/random"
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/Layr-Labs/eigenda/litt/metrics"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/require"
+)
+
+// countSegmentFiles returns the number of segment files a table has in the given DB path.
+func countSegmentFiles(t *testing.T, dbPath string, tableName string) int {
+	entries, err := os.ReadDir(path.Join(dbPath, tableName, "segments"))
+	if os.IsNotExist(err) {
+		return 0
+	}
+	require.NoError(t, err)
+	return len(entries)
+}
+
+func TestAddAndRemovePaths(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+
+	directory := t.TempDir()
+	pathA := path.Join(directory, "a")
+	pathB := path.Join(directory, "b")
+	pathC := path.Join(directory, "c")
+
+	config, err := litt.DefaultConfig(pathA, pathB)
+	require.NoError(t, err)
+	config.TargetSegmentFileSize = 1024
+	config.ShardingFactor = 4
+	config.Fsync = false
+
+	db, err := littbuilder.NewDB(config)
+	require.NoError(t, err)
+
+	table, err := db.GetTable("table")
+	require.NoError(t, err)
+
+	expectedValues := make(map[string][]byte)
+	writeData := func() {
+		for i := 0; i < 100; i++ {
+			key := rand.PrintableVariableBytes(32, 64)
+			value := rand.PrintableVariableBytes(1, 128)
+			err = table.Put(key, value)
+			require.NoError(t, err)
+			expectedValues[string(key)] = value
+		}
+		err = table.Flush()
+		require.NoError(t, err)
+	}
+
+	verifyData := func(table litt.Table) {
+		for key, expectedValue := range expectedValues {
+			value, ok, err := table.Get([]byte(key))
+			require.NoError(t, err)
+			require.True(t, ok)
+			require.Equal(t, expectedValue, value)
+		}
+	}
+
+	writeData()
+
+	// Data in other tables should also be moved when a path is removed.
+	otherTable, err := db.GetTable("other")
+	require.NoError(t, err)
+	err = otherTable.Put([]byte("key"), []byte("value"))
+	require.NoError(t, err)
+
+	// Invalid requests.
+	err = db.AddPath(pathA)
+	require.Error(t, err)
+	err = db.RemovePath(pathA)
+	require.Error(t, err)
+	err = db.RemovePath(pathC)
+	require.Error(t, err)
+
+	// New data should be written to the added path.
+	err = db.AddPath(pathC)
+	require.NoError(t, err)
+	writeData()
+	require.NotEqual(t, 0, countSegmentFiles(t, pathC, "table"))
+	verifyData(table)
+
+	// Read continuously while data is moved off of a path.
+	stopReading := atomic.Bool{}
+	readErrors := atomic.Int64{}
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for !stopReading.Load() {
+			for key, expectedValue := range expectedValues {
+				value, ok, err := table.Get([]byte(key))
+				if err != nil || !ok || string(value) != string(expectedValue) {
+					readErrors.Add(1)
+				}
+			}
+		}
+	}()
+
+	require.NotEqual(t, 0, countSegmentFiles(t, pathB, "table"))
+	err = db.RemovePath(pathB)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		exists, err := util.Exists(path.Join(pathB, "table"))
+		require.NoError(t, err)
+		return !exists
+	}, 10*time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool {
+		exists, err := util.Exists(path.Join(pathB, "other"))
+		require.NoError(t, err)
+		return !exists
+	}, 10*time.Second, 10*time.Millisecond)
+
+	stopReading.Store(true)
+	wg.Wait()
+	require.Equal(t, int64(0), readErrors.Load())
+	verifyData(table)
+
+	// Now that its data has been moved, the path can be added back and removed again.
+	err = db.AddPath(pathB)
+	require.NoError(t, err)
+	err = db.RemovePath(pathB)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		exists, err := util.Exists(path.Join(pathB, "table"))
+		require.NoError(t, err)
+		return !exists
+	}, 10*time.Second, 10*time.Millisecond)
+
+	writeData()
+	err = db.Close()
+	require.NoError(t, err)
+
+	// The DB should restart using only the remaining paths.
+	config, err = litt.DefaultConfig(pathA, pathC)
+	require.NoError(t, err)
+	config.Fsync = false
+	db, err = littbuilder.NewDB(config)
+	require.NoError(t, err)
+
+	table, err = db.GetTable("table")
+	require.NoError(t, err)
+	verifyData(table)
+
+	otherTable, err = db.GetTable("other")
+	require.NoError(t, err)
+	value, ok, err := otherTable.Get([]byte("key"))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, []byte("value"), value)
+
+	err = db.Close()
+	require.NoError(t, err)
+}
+
+func TestRemovePathOfUnopenedTable(t *testing.T) {
+	t.Parallel()
+
+	directory := t.TempDir()
+	pathA := path.Join(directory, "a")
+	pathB := path.Join(directory, "b")
+
+	config, err := litt.DefaultConfig(pathA, pathB)
+	require.NoError(t, err)
+	config.TargetSegmentFileSize = 100
+	config.Fsync = false
+
+	db, err := littbuilder.NewDB(config)
+	require.NoError(t, err)
+	table, err := db.GetTable("table")
+	require.NoError(t, err)
+	for i := 0; i < 100; i++ {
+		err = table.Put([]byte{byte(i)}, []byte{byte(i)})
+		require.NoError(t, err)
+	}
+	err = db.Close()
+	require.NoError(t, err)
+	require.NotEqual(t, 0, countSegmentFiles(t, pathB, "table"))
+
+	// Restart the DB, but don't open the table before removing the path.
+	config, err = litt.DefaultConfig(pathA, pathB)
+	require.NoError(t, err)
+	config.Fsync = false
+	db, err = littbuilder.NewDB(config)
+	require.NoError(t, err)
+
+	err = db.RemovePath(pathB)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		exists, err := util.Exists(path.Join(pathB, "table"))
+		require.NoError(t, err)
+		return !exists
+	}, 10*time.Second, 10*time.Millisecond)
+
+	table, err = db.GetTable("table")
+	require.NoError(t, err)
+	for i := 0; i < 100; i++ {
+		value, ok, err := table.Get([]byte{byte(i)})
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, []byte{byte(i)}, value)
+	}
+
+	err = db.Close()
+	require.NoError(t, err)
+}
+
+func TestAddPathRollback(t *testing.T) {
+	t.Parallel()
+
+	directory := t.TempDir()
+	pathA := path.Join(directory, "a")
+	pathB := path.Join(directory, "b")
+
+	config, err := litt.DefaultConfig(pathA)
+	require.NoError(t, err)
+	config.TargetSegmentFileSize = 100
+	config.Fsync = false
+
+	db, err := littbuilder.NewDB(config)
+	require.NoError(t, err)
+
+	tableNames := []string{"table1", "table2", "table3"}
+	for _, name := range tableNames {
+		_, err = db.GetTable(name)
+		require.NoError(t, err)
+	}
+
+	// A file where one of the tables keeps its data prevents the path from being added to that table.
+	err = os.MkdirAll(pathB, 0755)
+	require.NoError(t, err)
+	blockingFile := path.Join(pathB, "table2")
+	err = os.WriteFile(blockingFile, []byte{}, 0644)
+	require.NoError(t, err)
+
+	err = db.AddPath(pathB)
+	require.Error(t, err)
+
+	// The path is removed from the tables it was added to, so it can be added again once the problem is fixed.
+	err = os.Remove(blockingFile)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return db.AddPath(pathB) == nil
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// The database doesn't modify the paths of the config it was built with.
+	require.Equal(t, []string{pathA}, config.Paths)
+
+	for _, name := range tableNames {
+		table, err := db.GetTable(name)
+		require.NoError(t, err)
+		for i := 0; i < 100; i++ {
+			err = table.Put([]byte{byte(i)}, []byte{byte(i)})
+			require.NoError(t, err)
+		}
+		err = table.Flush()
+		require.NoError(t, err)
+		require.NotEqual(t, 0, countSegmentFiles(t, pathB, name))
+	}
+
+	err = db.Close()
+	require.NoError(t, err)
+}
+
+// faultyTable is a table that can be made to fail the check done before a root is removed from it.
+type faultyTable struct {
+	litt.ManagedTable
+	// If set, checks fail once this many checks have been made across all tables.
+	failAfter *atomic.Int32
+	// The number of root removal checks made across all tables.
+	checks *atomic.Int32
+	// The number of roots removed across all tables.
+	removals *atomic.Int32
+}
+
+func (f *faultyTable) CheckRemoveRoot(root string) error {
+	count := f.checks.Add(1)
+	failAfter := f.failAfter.Load()
+	if failAfter > 0 && count >= failAfter {
+		return errors.New("injected failure")
+	}
+	return f.ManagedTable.CheckRemoveRoot(root)
+}
+
+func (f *faultyTable) RemoveRoot(root string) error {
+	f.removals.Add(1)
+	return f.ManagedTable.RemoveRoot(root)
+}
+
+func TestRemovePathFailure(t *testing.T) {
+	t.Parallel()
+
+	directory := t.TempDir()
+	pathA := path.Join(directory, "a")
+	pathB := path.Join(directory, "b")
+
+	config, err := litt.DefaultConfig(pathA, pathB)
+	require.NoError(t, err)
+	config.Fsync = false
+
+	failAfter := &atomic.Int32{}
+	checks := &atomic.Int32{}
+	removals := &atomic.Int32{}
+	tableBuilder := func(
+		ctx context.Context,
+		logger logging.Logger,
+		name string,
+		paths []string,
+		metrics *metrics.LittDBMetrics) (litt.ManagedTable, error) {
+
+		tableConfig, err := litt.DefaultConfig(paths...)
+		require.NoError(t, err)
+		tableConfig.TargetSegmentFileSize = 100
+		tableConfig.Fsync = false
+		table, err := littbuilder.NewTable(tableConfig, name)
+		if err != nil {
+			return nil, err
+		}
+		return &faultyTable{
+			ManagedTable: table,
+			failAfter:    failAfter,
+			checks:       checks,
+			removals:     removals,
+		}, nil
+	}
+
+	db, err := littbuilder.NewDBUnsafe(config, tableBuilder)
+	require.NoError(t, err)
+
+	tableNames := []string{"table1", "table2", "table3"}
+	for _, name := range tableNames {
+		table, err := db.GetTable(name)
+		require.NoError(t, err)
+		for i := 0; i < 100; i++ {
+			err = table.Put([]byte{byte(i)}, []byte{byte(i)})
+			require.NoError(t, err)
+		}
+		err = table.Flush()
+		require.NoError(t, err)
+		require.NotEqual(t, 0, countSegmentFiles(t, pathB, name))
+	}
+
+	// The second table checked fails, so the path isn't removed from any table.
+	failAfter.Store(2)
+	err = db.RemovePath(pathB)
+	require.Error(t, err)
+	require.Equal(t, int32(2), checks.Load())
+	require.Equal(t, int32(0), removals.Load())
+	for _, name := range tableNames {
+		require.NotEqual(t, 0, countSegmentFiles(t, pathB, name))
+	}
+
+	// The path is still in use by every table, so removing it again succeeds once the failure clears.
+	failAfter.Store(0)
+	err = db.RemovePath(pathB)
+	require.NoError(t, err)
+	require.Equal(t, int32(len(tableNames)), removals.Load())
+	// Only the lock file is left behind.
+	require.Eventually(t, func() bool {
+		entries, err := os.ReadDir(pathB)
+		require.NoError(t, err)
+		return len(entries) == 1 && entries[0].Name() == littbuilder.LockFileName
+	}, 10*time.Second, 10*time.Millisecond)
+
+	for _, name := range tableNames {
+		table, err := db.GetTable(name)
+		require.NoError(t, err)
+		for i := 0; i < 100; i++ {
+			value, ok, err := table.Get([]byte{byte(i)})
+			require.NoError(t, err)
+			require.True(t, ok)
+			require.Equal(t, []byte{byte(i)}, value)
+		}
+	}
+
+	err = db.Close()
+	require.NoError(t, err)
+}
@@ -0,0 +1,200 @@
+package test
+
+import (
+	"bytes"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/testutils/random"
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/stretchr/testify/require"
+)
+
+// drainIterator reads all remaining key-value pairs from an iterator and closes it.
+func drainIterator(t *testing.T, iterator litt.TableIterator) map[string][]byte {
+	values := make(map[string][]byte)
+	for {
+		kv, ok, err := iterator.Next()
+		require.NoError(t, err)
+		if !ok {
+			break
+		}
+		_, duplicate := values[string(kv.Key)]
+		require.False(t, duplicate, "key %s returned more than once", kv.Key)
+		values[string(kv.Key)] = kv.Value
+	}
+	err := iterator.Close()
+	require.NoError(t, err)
+	return values
+}
+
+func iterationTest(t *testing.T, tableBuilder *tableBuilder) {
+	rand := random.NewTestRandom()
+
+	directory := t.TempDir()
+
+	tableName := rand.String(8)
+	table, err := tableBuilder.builder(time.Now, tableName, directory)
+	require.NoError(t, err)
+
+	prefixA := []byte("aaaa")
+	prefixB := []byte("bbbb")
+
+	expectedValues := make(map[string][]byte)
+
+	iterations := 100
+	for i := 0; i < iterations; i++ {
+		batchSize := rand.Int32Range(1, 10)
+		batch := make([]*types.KVPair, 0, batchSize)
+		for j := int32(0); j < batchSize; j++ {
+			prefix := prefixA
+			if rand.Bool() {
+				prefix = prefixB
+			}
+			key := append(bytes.Clone(prefix), rand.PrintableVariableBytes(32, 64)...)
+			value := rand.PrintableVariableBytes(1, 128)
+			batch = append(batch, &types.KVPair{Key: key, Value: value})
+			expectedValues[string(key)] = value
+		}
+		err = table.PutBatch(batch)
+		require.NoError(t, err)
+
+		if rand.BoolWithProbability(0.1) {
+			err = table.Flush()
+			require.NoError(t, err)
+		}
+
+		if rand.BoolWithProbability(0.1) || i == iterations-1 {
+			// All data written so far should be visible.
+			iterator, err := table.Iterate(nil)
+			require.NoError(t, err)
+			require.Equal(t, expectedValues, drainIterator(t, iterator))
+
+			// Only data with the requested prefix should be visible.
+			iterator, err = table.Iterate(&litt.IteratorOptions{Prefix: prefixA})
+			require.NoError(t, err)
+			found := drainIterator(t, iterator)
+			expectedCount := 0
+			for key, expectedValue := range expectedValues {
+				if !bytes.HasPrefix([]byte(key), prefixA) {
+					continue
+				}
+				expectedCount++
+				require.Equal(t, expectedValue, found[key])
+			}
+			require.Equal(t, expectedCount, len(found))
+		}
+	}
+
+	// Data written after an iterator is created should not be visible to that iterator.
+	iterator, err := table.Iterate(nil)
+	require.NoError(t, err)
+	err = table.Put(rand.PrintableVariableBytes(32, 64), rand.PrintableVariableBytes(1, 128))
+	require.NoError(t, err)
+	require.Equal(t, expectedValues, drainIterator(t, iterator))
+
+	// Calling Next() on a closed iterator is an error.
+	_, _, err = iterator.Next()
+	require.Error(t, err)
+
+	err = table.Destroy()
+	require.NoError(t, err)
+
+	// ensure that the test directory is empty
+	entries, err := os.ReadDir(directory)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestIteration(t *testing.T) {
+	t.Parallel()
+	for _, tb := range tableBuilders {
+		t.Run(tb.name, func(t *testing.T) {
+			iterationTest(t, tb)
+		})
+	}
+}
+
+func iterationDuringGarbageCollectionTest(t *testing.T, tableBuilder *tableBuilder) {
+	rand := random.NewTestRandom()
+
+	directory := t.TempDir()
+
+	startTime := rand.Time()
+	var fakeTime atomic.Pointer[time.Time]
+	fakeTime.Store(&startTime)
+	clock := func() time.Time {
+		return *fakeTime.Load()
+	}
+
+	tableName := rand.String(8)
+	table, err := tableBuilder.builder(clock, tableName, directory)
+	require.NoError(t, err)
+
+	ttl := time.Minute
+	err = table.SetTTL(ttl)
+	require.NoError(t, err)
+
+	expectedValues := make(map[string][]byte)
+	for i := 0; i < 100; i++ {
+		key := rand.PrintableVariableBytes(32, 64)
+		value := rand.PrintableVariableBytes(1, 128)
+		err = table.Put(key, value)
+		require.NoError(t, err)
+		expectedValues[string(key)] = value
+	}
+	err = table.Flush()
+	require.NoError(t, err)
+
+	iterator, err := table.Iterate(nil)
+	require.NoError(t, err)
+
+	// Read the first value before the data expires.
+	kv, ok, err := iterator.Next()
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, expectedValues[string(kv.Key)], kv.Value)
+
+	// Expire all data and run garbage collection. The iterator should still be able to read the values it has
+	// already committed to reading, and should skip the rest.
+	newTime := startTime.Add(2 * ttl)
+	fakeTime.Store(&newTime)
+	err = table.RunGC()
+	require.NoError(t, err)
+
+	for {
+		kv, ok, err = iterator.Next()
+		require.NoError(t, err)
+		if !ok {
+			break
+		}
+		require.Equal(t, expectedValues[string(kv.Key)], kv.Value)
+	}
+	err = iterator.Close()
+	require.NoError(t, err)
+
+	// A fresh iterator should not observe any expired data.
+	iterator, err = table.Iterate(nil)
+	require.NoError(t, err)
+	require.Empty(t, drainIterator(t, iterator))
+
+	err = table.Destroy()
+	require.NoError(t, err)
+
+	// ensure that the test directory is empty
+	entries, err := os.ReadDir(directory)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestIterationDuringGarbageCollection(t *testing.T) {
+	t.Parallel()
+	for _, tb := range noCacheTableBuilders {
+		t.Run(tb.name, func(t *testing.T) {
+			iterationDuringGarbageCollectionTest(t, tb)
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package test
+
+import (
+	"errors"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/testutils/random"
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/stretchr/testify/require"
+)
+
+// sealData forces the table to seal its mutable segment, making all data written so far visible to readers
+// that have the DB open in read-only mode. Iterating over a table with OnlySealedData=false seals the mutable segment.
+func sealData(t *testing.T, table litt.Table) {
+	iterator, err := table.Iterate(nil)
+	require.NoError(t, err)
+	err = iterator.Close()
+	require.NoError(t, err)
+}
+
+// listFiles returns the path and modification time of every file in the given directories.
+func listFiles(t *testing.T, directories []string) map[string]time.Time {
+	files := make(map[string]time.Time)
+	for _, directory := range directories {
+		err := filepath.Walk(directory, func(p string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			files[p] = info.ModTime()
+			return nil
+		})
+		require.NoError(t, err)
+	}
+	return files
+}
+
+func TestReadOnly(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+
+	directory := t.TempDir()
+	paths := []string{path.Join(directory, "a"), path.Join(directory, "b")}
+
+	writerConfig, err := litt.DefaultConfig(paths...)
+	require.NoError(t, err)
+	writerConfig.TargetSegmentFileSize = 1024
+	writerConfig.ShardingFactor = 4
+	writerConfig.Fsync = false
+	writerConfig.GCPeriod = 10 * time.Millisecond
+	writer, err := littbuilder.NewDB(writerConfig)
+	require.NoError(t, err)
+
+	writerTable, err := writer.GetTable("table")
+	require.NoError(t, err)
+
+	firstValues := make(map[string][]byte)
+	for i := 0; i < 100; i++ {
+		key := rand.PrintableVariableBytes(32, 64)
+		value := rand.PrintableVariableBytes(1, 128)
+		err = writerTable.Put(key, value)
+		require.NoError(t, err)
+		firstValues[string(key)] = value
+	}
+	err = writerTable.Flush()
+	require.NoError(t, err)
+	sealData(t, writerTable)
+
+	filesBefore := listFiles(t, paths)
+
+	// Open one reader that never refreshes, and one that refreshes frequently.
+	readerConfig, err := litt.DefaultConfig(paths...)
+	require.NoError(t, err)
+	readerConfig.ReadOnly = true
+	reader, err := littbuilder.NewDB(readerConfig)
+	require.NoError(t, err)
+
+	refreshingReaderConfig, err := litt.DefaultConfig(paths...)
+	require.NoError(t, err)
+	refreshingReaderConfig.ReadOnly = true
+	refreshingReaderConfig.ReadOnlyRefreshPeriod = 10 * time.Millisecond
+	refreshingReader, err := littbuilder.NewDB(refreshingReaderConfig)
+	require.NoError(t, err)
+
+	readerTable, err := reader.GetTable("table")
+	require.NoError(t, err)
+	refreshingReaderTable, err := refreshingReader.GetTable("table")
+	require.NoError(t, err)
+
+	// Both readers should see all data that was sealed when they were opened.
+	for _, table := range []litt.Table{readerTable, refreshingReaderTable} {
+		require.Equal(t, uint64(len(firstValues)), table.KeyCount())
+		for key, expectedValue := range firstValues {
+			value, ok, err := table.Get([]byte(key))
+			require.NoError(t, err)
+			require.True(t, ok)
+			require.Equal(t, expectedValue, value)
+		}
+
+		iterator, err := table.Iterate(nil)
+		require.NoError(t, err)
+		iterated := 0
+		for {
+			_, ok, err := iterator.Next()
+			require.NoError(t, err)
+			if !ok {
+				break
+			}
+			iterated++
+		}
+		require.Equal(t, len(firstValues), iterated)
+		err = iterator.Close()
+		require.NoError(t, err)
+	}
+
+	// Operations that modify the DB are rejected.
+	err = readerTable.Put([]byte("key"), []byte("value"))
+	require.True(t, errors.Is(err, litt.ErrReadOnly))
+	err = readerTable.SetTTL(time.Hour)
+	require.True(t, errors.Is(err, litt.ErrReadOnly))
+	err = reader.DropTable("table")
+	require.True(t, errors.Is(err, litt.ErrReadOnly))
+	_, err = reader.GetTable("does-not-exist")
+	require.Error(t, err)
+
+	// Opening the DB in read-only mode must not have modified any files.
+	require.Equal(t, filesBefore, listFiles(t, paths))
+
+	// Write more data. Only the refreshing reader should eventually see it.
+	secondValues := make(map[string][]byte)
+	for i := 0; i < 100; i++ {
+		key := rand.PrintableVariableBytes(32, 64)
+		value := rand.PrintableVariableBytes(1, 128)
+		err = writerTable.Put(key, value)
+		require.NoError(t, err)
+		secondValues[string(key)] = value
+	}
+	err = writerTable.Flush()
+	require.NoError(t, err)
+	sealData(t, writerTable)
+
+	require.Eventually(t, func() bool {
+		return refreshingReaderTable.KeyCount() == uint64(len(firstValues)+len(secondValues))
+	}, 10*time.Second, 10*time.Millisecond)
+	for key, expectedValue := range secondValues {
+		value, ok, err := refreshingReaderTable.Get([]byte(key))
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, expectedValue, value)
+
+		ok, err = readerTable.Exists([]byte(key))
+		require.NoError(t, err)
+		require.False(t, ok)
+	}
+	require.Equal(t, uint64(len(firstValues)), readerTable.KeyCount())
+
+	// Cause the writer to delete all sealed data.
+	err = writerTable.SetTTL(time.Nanosecond)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return refreshingReaderTable.KeyCount() == 0
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// The reader that does not refresh still believes the data exists, but the data can no longer be read.
+	for key := range firstValues {
+		_, ok, err := readerTable.Get([]byte(key))
+		require.NoError(t, err)
+		require.False(t, ok)
+	}
+
+	err = reader.Close()
+	require.NoError(t, err)
+	err = refreshingReader.Close()
+	require.NoError(t, err)
+	err = writer.Close()
+	require.NoError(t, err)
+}
+
+func TestReadOnlyMaxKeyCount(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+
+	directory := t.TempDir()
+	paths := []string{path.Join(directory, "a")}
+
+	writerConfig, err := litt.DefaultConfig(paths...)
+	require.NoError(t, err)
+	writerConfig.TargetSegmentFileSize = 1024
+	writerConfig.Fsync = false
+	writer, err := littbuilder.NewDB(writerConfig)
+	require.NoError(t, err)
+	writerTable, err := writer.GetTable("table")
+	require.NoError(t, err)
+
+	writeKeys := func() {
+		for i := 0; i < 100; i++ {
+			err = writerTable.Put(rand.PrintableVariableBytes(32, 64), rand.PrintableVariableBytes(1, 128))
+			require.NoError(t, err)
+		}
+		err = writerTable.Flush()
+		require.NoError(t, err)
+		sealData(t, writerTable)
+	}
+	writeKeys()
+
+	buildReaderConfig := func(maxKeyCount uint64) *litt.Config {
+		config, err := litt.DefaultConfig(paths...)
+		require.NoError(t, err)
+		config.ReadOnly = true
+		config.ReadOnlyRefreshPeriod = 10 * time.Millisecond
+		config.ReadOnlyMaxKeyCount = maxKeyCount
+		return config
+	}
+
+	// A table with more keys than the limit can not be opened.
+	reader, err := littbuilder.NewDB(buildReaderConfig(50))
+	require.NoError(t, err)
+	_, err = reader.GetTable("table")
+	require.ErrorContains(t, err, "ReadOnlyMaxKeyCount")
+	err = reader.Close()
+	require.NoError(t, err)
+
+	reader, err = littbuilder.NewDB(buildReaderConfig(150))
+	require.NoError(t, err)
+	readerTable, err := reader.GetTable("table")
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), readerTable.KeyCount())
+
+	// Refreshes that would exceed the limit fail, leaving the table's view of the data unchanged.
+	writeKeys()
+	require.Never(t, func() bool {
+		return readerTable.KeyCount() != 100
+	}, 200*time.Millisecond, 10*time.Millisecond)
+
+	err = reader.Close()
+	require.NoError(t, err)
+	err = writer.Close()
+	require.NoError(t, err)
+}
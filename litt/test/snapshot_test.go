@@ -0,0 +1,170 @@
+package test
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/common/testutils/random"
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/stretchr/testify/require"
+)
+
+// writeRandomData writes random data to the given tables, recording the values written in expectedValues.
+func writeRandomData(
+	t *testing.T,
+	rand *random.TestRandom,
+	tables map[string]litt.Table,
+	expectedValues map[string]map[string][]byte) {
+
+	for i := 0; i < 100; i++ {
+		for name, table := range tables {
+			key := rand.PrintableVariableBytes(32, 64)
+			value := rand.PrintableVariableBytes(1, 128)
+			err := table.Put(key, value)
+			require.NoError(t, err)
+			expectedValues[name][string(key)] = value
+		}
+	}
+}
+
+// restoreAndVerify opens a DB from a snapshot and verifies that it contains exactly the expected data.
+func restoreAndVerify(
+	t *testing.T,
+	snapshotDirectory string,
+	restorePaths []string,
+	expectedValues map[string]map[string][]byte,
+	unexpectedValues map[string]map[string][]byte) {
+
+	config, err := litt.DefaultConfig(restorePaths...)
+	require.NoError(t, err)
+	config.Fsync = false
+
+	db, err := littbuilder.NewDBFromSnapshot(snapshotDirectory, config)
+	require.NoError(t, err)
+
+	for tableName, values := range expectedValues {
+		table, err := db.GetTable(tableName)
+		require.NoError(t, err)
+		require.Equal(t, uint64(len(values)), table.KeyCount())
+
+		for key, expectedValue := range values {
+			value, ok, err := table.Get([]byte(key))
+			require.NoError(t, err)
+			require.True(t, ok)
+			require.Equal(t, expectedValue, value)
+		}
+		for key := range unexpectedValues[tableName] {
+			_, ok, err := table.Get([]byte(key))
+			require.NoError(t, err)
+			require.False(t, ok)
+		}
+	}
+
+	err = db.Destroy()
+	require.NoError(t, err)
+}
+
+func snapshotTest(t *testing.T, builder *dbBuilder) {
+	rand := random.NewTestRandom()
+
+	directory := t.TempDir()
+	sourceDirectory := path.Join(directory, "source")
+	snapshotDirectory := path.Join(directory, "snapshot")
+
+	db, err := builder.builder(t, sourceDirectory)
+	require.NoError(t, err)
+
+	tableNames := []string{rand.String(8), rand.String(8)}
+	tables := make(map[string]litt.Table)
+	firstValues := make(map[string]map[string][]byte)
+	secondValues := make(map[string]map[string][]byte)
+	for _, name := range tableNames {
+		table, err := db.GetTable(name)
+		require.NoError(t, err)
+		tables[name] = table
+		firstValues[name] = make(map[string][]byte)
+		secondValues[name] = make(map[string][]byte)
+	}
+
+	// Take a snapshot, then write more data. The data written after the snapshot should not be visible in it.
+	writeRandomData(t, rand, tables, firstValues)
+	err = db.Snapshot(snapshotDirectory)
+	require.NoError(t, err)
+	writeRandomData(t, rand, tables, secondValues)
+
+	restoreAndVerify(
+		t,
+		snapshotDirectory,
+		[]string{path.Join(directory, "restore1a"), path.Join(directory, "restore1b")},
+		firstValues,
+		secondValues)
+
+	// Remember a segment file from the first snapshot so we can verify that it isn't rewritten.
+	segmentDirectory := path.Join(snapshotDirectory, tableNames[0], "segments")
+	firstSnapshotFile := path.Join(segmentDirectory, "0.keys")
+	firstSnapshotInfo, err := os.Stat(firstSnapshotFile)
+	require.NoError(t, err)
+
+	// Take an incremental snapshot. It should contain all data.
+	err = db.Snapshot(snapshotDirectory)
+	require.NoError(t, err)
+
+	secondSnapshotInfo, err := os.Stat(firstSnapshotFile)
+	require.NoError(t, err)
+	require.True(t, os.SameFile(firstSnapshotInfo, secondSnapshotInfo))
+
+	allValues := make(map[string]map[string][]byte)
+	for _, name := range tableNames {
+		allValues[name] = make(map[string][]byte)
+		for key, value := range firstValues[name] {
+			allValues[name][key] = value
+		}
+		for key, value := range secondValues[name] {
+			allValues[name][key] = value
+		}
+	}
+	restoreAndVerify(
+		t,
+		snapshotDirectory,
+		[]string{path.Join(directory, "restore2")},
+		allValues,
+		nil)
+
+	// Restoring on top of existing data is not permitted.
+	config, err := litt.DefaultConfig(sourceDirectory)
+	require.NoError(t, err)
+	_, err = littbuilder.NewDBFromSnapshot(snapshotDirectory, config)
+	require.Error(t, err)
+
+	// The source database should be unaffected by the snapshots.
+	for name, table := range tables {
+		for key, expectedValue := range allValues[name] {
+			value, ok, err := table.Get([]byte(key))
+			require.NoError(t, err)
+			require.True(t, ok)
+			require.Equal(t, expectedValue, value)
+		}
+	}
+
+	err = db.Destroy()
+	require.NoError(t, err)
+
+	// Destroying the source database should not affect the snapshot.
+	restoreAndVerify(
+		t,
+		snapshotDirectory,
+		[]string{path.Join(directory, "restore3")},
+		allValues,
+		nil)
+}
+
+func TestSnapshot(t *testing.T) {
+	t.Parallel()
+	for _, builder := range restartableBuilders {
+		t.Run(builder.name, func(t *testing.T) {
+			snapshotTest(t, builder)
+		})
+	}
+}
@@ -116,6 +116,116 @@ func CopyDirectoryRecursively(source string, destination string) error {
 	})
 }
 
+// CopyFile copies a single regular file from source to destination, preserving permissions and timestamps.
+// If a file already exists at the destination, it is replaced. The parent directory of the destination
+// is created if it does not exist.
+func CopyFile(source string, destination string) error {
+	info, err := os.Stat(source)
+	if err != nil {
+		return fmt.Errorf("failed to stat source file %s: %w", source, err)
+	}
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("source %s is not a regular file", source)
+	}
+
+	return copyRegularFile(source, destination, info.Mode(), info.ModTime())
+}
+
+// LinkOrCopyFile creates a hard link at destination that points to source. If a hard link cannot be created
+// (e.g. because source and destination are on different file systems), the file is copied instead. If a file
+// already exists at the destination, it is replaced. The parent directory of the destination is created if it
+// does not exist.
+//
+// Since a hard link shares its contents with the original file, this should only be used for files that are
+// never modified in place.
+func LinkOrCopyFile(source string, destination string) error {
+	if err := ensureParentDirExists(destination); err != nil {
+		return err
+	}
+
+	if _, err := os.Lstat(destination); err == nil {
+		if err := os.Remove(destination); err != nil {
+			return fmt.Errorf("failed to remove existing destination file %s: %w", destination, err)
+		}
+	}
+
+	if err := os.Link(source, destination); err == nil {
+		return nil
+	}
+
+	return CopyFile(source, destination)
+}
+
+// MoveFile moves a regular file from source to destination. If the file can't be renamed (e.g. because source and
+// destination are on different file systems), the file is copied to a temporary location next to the destination,
+// atomically renamed into place, and then the source is deleted. The parent directory of the destination is
+// created if it does not exist. It is an error if a file already exists at the destination.
+func MoveFile(source string, destination string) error {
+	if err := ensureParentDirExists(destination); err != nil {
+		return err
+	}
+
+	exists, err := Exists(destination)
+	if err != nil {
+		return fmt.Errorf("failed to check if destination %s exists: %w", destination, err)
+	}
+	if exists {
+		return fmt.Errorf("destination %s already exists", destination)
+	}
+
+	if err = os.Rename(source, destination); err == nil {
+		return nil
+	}
+
+	temporaryPath := destination + ".tmp"
+	err = CopyFile(source, temporaryPath)
+	if err != nil {
+		return fmt.Errorf("failed to copy %s to %s: %w", source, temporaryPath, err)
+	}
+	err = os.Rename(temporaryPath, destination)
+	if err != nil {
+		return fmt.Errorf("failed to rename %s to %s: %w", temporaryPath, destination, err)
+	}
+	err = os.Remove(source)
+	if err != nil {
+		return fmt.Errorf("failed to remove %s: %w", source, err)
+	}
+
+	return nil
+}
+
+// MoveDirectory moves a directory (and all of its contents) from source to destination. If the directory can't be
+// renamed (e.g. because source and destination are on different file systems), the directory is copied recursively
+// and then the source is deleted. It is an error if something already exists at the destination.
+func MoveDirectory(source string, destination string) error {
+	if err := ensureParentDirExists(destination); err != nil {
+		return err
+	}
+
+	exists, err := Exists(destination)
+	if err != nil {
+		return fmt.Errorf("failed to check if destination %s exists: %w", destination, err)
+	}
+	if exists {
+		return fmt.Errorf("destination %s already exists", destination)
+	}
+
+	if err = os.Rename(source, destination); err == nil {
+		return nil
+	}
+
+	err = CopyDirectoryRecursively(source, destination)
+	if err != nil {
+		return fmt.Errorf("failed to copy %s to %s: %w", source, destination, err)
+	}
+	err = os.RemoveAll(source)
+	if err != nil {
+		return fmt.Errorf("failed to remove %s: %w", source, err)
+	}
+
+	return nil
+}
+
 // verifyDirectoryWritable checks if a directory exists and is writable.
 // Returns nil if the directory is writable, or an error explaining why it's not.
 // If the directory doesn't exist but its parent is writable, returns nil.
@@ -351,6 +351,125 @@ func TestCopyRegularFile(t *testing.T) {
 	}
 }
 
+func TestCopyFile(t *testing.T) {
+	tempDir := t.TempDir()
+
+	sourceFile := filepath.Join(tempDir, "source-file")
+	content := []byte("test content")
+	err := os.WriteFile(sourceFile, content, 0640)
+	require.NoError(t, err)
+
+	// Copy to a directory that does not yet exist.
+	destFile := filepath.Join(tempDir, "subdir", "dest-file")
+	err = CopyFile(sourceFile, destFile)
+	require.NoError(t, err)
+
+	destContent, err := os.ReadFile(destFile)
+	require.NoError(t, err)
+	require.Equal(t, content, destContent)
+
+	// Copying a directory is not supported.
+	err = CopyFile(tempDir, filepath.Join(tempDir, "dest-dir"))
+	require.Error(t, err)
+
+	// Copying a file that does not exist is an error.
+	err = CopyFile(filepath.Join(tempDir, "does-not-exist"), filepath.Join(tempDir, "dest-file"))
+	require.Error(t, err)
+}
+
+func TestLinkOrCopyFile(t *testing.T) {
+	tempDir := t.TempDir()
+
+	sourceFile := filepath.Join(tempDir, "source-file")
+	content := []byte("test content")
+	err := os.WriteFile(sourceFile, content, 0640)
+	require.NoError(t, err)
+
+	// Link into a directory that does not yet exist.
+	destFile := filepath.Join(tempDir, "subdir", "dest-file")
+	err = LinkOrCopyFile(sourceFile, destFile)
+	require.NoError(t, err)
+
+	destContent, err := os.ReadFile(destFile)
+	require.NoError(t, err)
+	require.Equal(t, content, destContent)
+
+	// The destination should be a hard link to the source.
+	sourceInfo, err := os.Stat(sourceFile)
+	require.NoError(t, err)
+	destInfo, err := os.Stat(destFile)
+	require.NoError(t, err)
+	require.True(t, os.SameFile(sourceInfo, destInfo))
+
+	// Replacing an existing file is permitted.
+	err = os.Remove(destFile)
+	require.NoError(t, err)
+	err = os.WriteFile(destFile, []byte("original content"), 0600)
+	require.NoError(t, err)
+	err = LinkOrCopyFile(sourceFile, destFile)
+	require.NoError(t, err)
+	destContent, err = os.ReadFile(destFile)
+	require.NoError(t, err)
+	require.Equal(t, content, destContent)
+
+	// Deleting the source should not affect the destination.
+	err = os.Remove(sourceFile)
+	require.NoError(t, err)
+	destContent, err = os.ReadFile(destFile)
+	require.NoError(t, err)
+	require.Equal(t, content, destContent)
+}
+
+func TestMoveFile(t *testing.T) {
+	tempDir := t.TempDir()
+
+	sourceFile := filepath.Join(tempDir, "source-file")
+	content := []byte("test content")
+	err := os.WriteFile(sourceFile, content, 0640)
+	require.NoError(t, err)
+
+	destFile := filepath.Join(tempDir, "subdir", "dest-file")
+	err = MoveFile(sourceFile, destFile)
+	require.NoError(t, err)
+
+	destContent, err := os.ReadFile(destFile)
+	require.NoError(t, err)
+	require.Equal(t, content, destContent)
+
+	exists, err := Exists(sourceFile)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	// Moving on top of an existing file is not permitted.
+	err = os.WriteFile(sourceFile, content, 0640)
+	require.NoError(t, err)
+	err = MoveFile(sourceFile, destFile)
+	require.Error(t, err)
+}
+
+func TestMoveDirectory(t *testing.T) {
+	tempDir := t.TempDir()
+
+	sourceDir := filepath.Join(tempDir, "source")
+	content := []byte("test content")
+	err := os.MkdirAll(filepath.Join(sourceDir, "nested"), 0755)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(sourceDir, "nested", "file"), content, 0640)
+	require.NoError(t, err)
+
+	destDir := filepath.Join(tempDir, "subdir", "dest")
+	err = MoveDirectory(sourceDir, destDir)
+	require.NoError(t, err)
+
+	destContent, err := os.ReadFile(filepath.Join(destDir, "nested", "file"))
+	require.NoError(t, err)
+	require.Equal(t, content, destContent)
+
+	exists, err := Exists(sourceDir)
+	require.NoError(t, err)
+	require.False(t, exists)
+}
+
 func TestCopySymlink(t *testing.T) {
 	// Skip on platforms that don't support symlinks (like Windows in some cases)
 	if !supportsSymlinks() {
@@ -0,0 +1,205 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/gammazero/workerpool"
+)
+
+// bundleToRepair is a blob whose bundle is missing from the validator store.
+type bundleToRepair struct {
+	record    *BlobRecord
+	blobKey   corev2.BlobKey
+	bundleKey []byte
+}
+
+// repairLoop periodically repairs the bundles missing from the validator store, starting immediately so that a
+// validator restarted after losing data catches up as soon as possible.
+func (n *Node) repairLoop(ctx context.Context) {
+	if n.Config.ChunkRepairInterval <= 0 {
+		n.Logger.Warn("chunk repair is enabled but the repair interval is not positive, chunks will not be repaired",
+			"interval", n.Config.ChunkRepairInterval)
+		return
+	}
+	n.Logger.Info("Start repairLoop goroutine in background to periodically repair missing bundles",
+		"interval", n.Config.ChunkRepairInterval)
+
+	ticker := time.NewTicker(n.Config.ChunkRepairInterval)
+	defer ticker.Stop()
+
+	for {
+		repaired, err := n.RepairBundles(ctx)
+		if err != nil {
+			n.Logger.Error("failed to repair bundles, will retry in the next cycle", "numRepaired", repaired, "err", err)
+		} else if repaired > 0 {
+			n.Logger.Info("repaired missing bundles", "numRepaired", repaired)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RepairBundles finds the bundles of unexpired blobs that this validator has stored but that are now missing from the
+// ValidatorStore, e.g. because of a disk failure, and rebuilds them from the chunks held by other validators. Each
+// rebuilt bundle is validated against the blob commitments before it is stored. Returns the number of bundles
+// repaired.
+//
+// Blobs that can't be repaired are skipped and retried by the next call, and an error is returned after every
+// missing bundle has been attempted.
+func (n *Node) RepairBundles(ctx context.Context) (int, error) {
+	if n.BundleRepairFetcher == nil {
+		return 0, fmt.Errorf("bundle repair fetcher is not set")
+	}
+	if n.ValidatorStore == nil {
+		return 0, fmt.Errorf("validator store is not set")
+	}
+	if n.ValidatorV2 == nil {
+		return 0, fmt.Errorf("validator v2 is not set")
+	}
+	blobVersionParams := n.BlobVersionParams.Load()
+	if blobVersionParams == nil {
+		return 0, fmt.Errorf("blob version params is nil")
+	}
+
+	missing, err := n.findMissingBundles()
+	if err != nil {
+		return 0, err
+	}
+	if len(missing) == 0 {
+		return 0, nil
+	}
+	n.Logger.Warn("found bundles missing from the validator store, repairing them from other validators",
+		"numBundles", len(missing))
+
+	pool := workerpool.New(n.Config.NumBatchValidators)
+	defer pool.StopWait()
+
+	repaired := 0
+	failed := 0
+	for _, bundle := range missing {
+		if ctx.Err() != nil {
+			return repaired, ctx.Err()
+		}
+
+		err := n.repairBundle(ctx, bundle, blobVersionParams, pool)
+		if err != nil {
+			failed++
+			n.Logger.Warn("failed to repair bundle", "blobKey", bundle.blobKey.Hex(), "err", err)
+			continue
+		}
+		repaired++
+	}
+
+	if failed > 0 {
+		return repaired, fmt.Errorf("failed to repair %d of %d missing bundles", failed, len(missing))
+	}
+	return repaired, nil
+}
+
+// findMissingBundles returns the blobs with a record in the validator store but no bundle.
+func (n *Node) findMissingBundles() ([]*bundleToRepair, error) {
+	missing := make([]*bundleToRepair, 0)
+	err := n.ValidatorStore.IterateBlobRecords(func(record *BlobRecord) error {
+		blobKey, err := record.BlobCertificate.BlobHeader.BlobKey()
+		if err != nil {
+			return fmt.Errorf("failed to get blob key: %w", err)
+		}
+
+		// The current sampling scheme will store the same chunks for all quorums, so we always use quorum 0 as the
+		// quorum key in storage.
+		bundleKey, err := BundleKey(blobKey, core.QuorumID(0))
+		if err != nil {
+			return fmt.Errorf("failed to get bundle key: %w", err)
+		}
+
+		exists, err := n.ValidatorStore.HasBundle(bundleKey)
+		if err != nil {
+			return fmt.Errorf("failed to check bundle of blob %s: %w", blobKey.Hex(), err)
+		}
+		if !exists {
+			missing = append(missing, &bundleToRepair{
+				record:    record,
+				blobKey:   blobKey,
+				bundleKey: bundleKey,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find missing bundles: %w", err)
+	}
+	return missing, nil
+}
+
+// repairBundle rebuilds the bundle of a blob from the chunks of other validators, validates it and stores it.
+func (n *Node) repairBundle(
+	ctx context.Context,
+	bundle *bundleToRepair,
+	blobVersionParams *corev2.BlobVersionParameterMap,
+	pool *workerpool.WorkerPool,
+) error {
+	blobCert := bundle.record.BlobCertificate
+	referenceBlockNumber := bundle.record.ReferenceBlockNumber
+
+	blobParams, ok := blobVersionParams.Get(blobCert.BlobHeader.BlobVersion)
+	if !ok {
+		return fmt.Errorf("blob version %d not found", blobCert.BlobHeader.BlobVersion)
+	}
+
+	operatorState, err := n.ChainState.GetOperatorState(
+		ctx,
+		uint(referenceBlockNumber),
+		blobCert.BlobHeader.QuorumNumbers)
+	if err != nil {
+		return fmt.Errorf("failed to get the operator state: %w", err)
+	}
+
+	assignment, err := corev2.GetAssignmentForBlob(
+		operatorState,
+		blobParams,
+		blobCert.BlobHeader.QuorumNumbers,
+		n.Config.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get assignment: %w", err)
+	}
+
+	fetchCtx, cancel := context.WithTimeout(ctx, n.Config.ChunkDownloadTimeout)
+	defer cancel()
+	bundleBytes, err := n.BundleRepairFetcher.FetchBundle(
+		fetchCtx, blobCert, blobParams, referenceBlockNumber, assignment)
+	if err != nil {
+		return fmt.Errorf("failed to fetch bundle from other validators: %w", err)
+	}
+
+	chunks, err := new(core.Bundle).Deserialize(bundleBytes)
+	if err != nil {
+		return fmt.Errorf("failed to deserialize bundle: %w", err)
+	}
+
+	blobShards := []*corev2.BlobShard{{
+		BlobCertificate: blobCert,
+		Bundle:          chunks,
+	}}
+	err = n.ValidatorV2.ValidateBlobs(ctx, blobShards, blobVersionParams, pool, operatorState)
+	if err != nil {
+		return fmt.Errorf("failed to validate repaired bundle: %w", err)
+	}
+
+	_, err = n.ValidatorStore.StoreBatch([]*BundleToStore{{
+		BundleKey:   bundle.bundleKey,
+		BundleBytes: bundleBytes,
+	}})
+	if err != nil {
+		return fmt.Errorf("failed to store repaired bundle: %w", err)
+	}
+
+	return nil
+}
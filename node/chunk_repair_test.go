@@ -0,0 +1,375 @@
+package node_test
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	coremockv2 "github.com/Layr-Labs/eigenda/core/mock/v2"
+	v2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/node"
+	nodemock "github.com/Layr-Labs/eigenda/node/mock"
+	"github.com/docker/go-units"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+const testChunkTTL = 2 * time.Hour
+
+func newTestValidatorStore(
+	t *testing.T,
+	chunkPath string,
+	blobRecordsPath string,
+	timeSource func() time.Time,
+) node.ValidatorStore {
+
+	logger, err := common.NewLogger(common.DefaultTextLoggerConfig())
+	require.NoError(t, err)
+
+	config := &node.Config{
+		GetChunksHotCacheReadLimitMB:  units.GiB,
+		GetChunksHotBurstLimitMB:      units.GiB,
+		GetChunksColdCacheReadLimitMB: units.GiB,
+		GetChunksColdBurstLimitMB:     units.GiB,
+		LittDBStoragePaths:            []string{chunkPath},
+		LittDBBlobRecordsPath:         blobRecordsPath,
+		EnableChunkRepair:             true,
+	}
+	store, err := node.NewValidatorStore(logger, config, timeSource, testChunkTTL, nil)
+	require.NoError(t, err)
+	return store
+}
+
+// storeBlobRecords stores a placeholder bundle and a blob record for each of the blobs.
+func storeBlobRecords(
+	t *testing.T,
+	store node.ValidatorStore,
+	blobKeys []v2.BlobKey,
+	certs []*v2.BlobCertificate,
+) []*node.BlobRecord {
+	records := make([]*node.BlobRecord, len(certs))
+	batchData := make([]*node.BundleToStore, len(certs))
+	for i, cert := range certs {
+		records[i] = &node.BlobRecord{
+			BlobCertificate:      cert,
+			ReferenceBlockNumber: uint64(100 + i),
+		}
+		bundleKey, err := node.BundleKey(blobKeys[i], 0)
+		require.NoError(t, err)
+		batchData[i] = &node.BundleToStore{
+			BundleKey:   bundleKey,
+			BundleBytes: []byte{byte(i)},
+			BlobRecord:  records[i],
+		}
+	}
+	_, err := store.StoreBatch(batchData)
+	require.NoError(t, err)
+	// Storing the same batch again is a no-op
+	_, err = store.StoreBatch(batchData)
+	require.NoError(t, err)
+	return records
+}
+
+// iterateBlobRecords returns the blob records of the store, indexed by blob key.
+func iterateBlobRecords(t *testing.T, store node.ValidatorStore) map[v2.BlobKey]*node.BlobRecord {
+	iterated := make(map[v2.BlobKey]*node.BlobRecord)
+	err := store.IterateBlobRecords(func(record *node.BlobRecord) error {
+		blobKey, err := record.BlobCertificate.BlobHeader.BlobKey()
+		require.NoError(t, err)
+		iterated[blobKey] = record
+		return nil
+	})
+	require.NoError(t, err)
+	return iterated
+}
+
+func TestBlobRecords(t *testing.T) {
+	chunkPath := t.TempDir()
+	blobRecordsPath := t.TempDir()
+	start := time.Now()
+	now := start
+	timeSource := func() time.Time { return now }
+	store := newTestValidatorStore(t, chunkPath, blobRecordsPath, timeSource)
+	blobKeys, batch, _ := nodemock.MockBatch(t)
+	records := storeBlobRecords(t, store, blobKeys[:1], batch.BlobCertificates[:1])
+
+	// The first iteration seals the mutable segment, so the record is iterated
+	iterated := iterateBlobRecords(t, store)
+	require.Len(t, iterated, 1)
+	require.Equal(t, records[0].ReferenceBlockNumber, iterated[blobKeys[0]].ReferenceBlockNumber)
+	require.Equal(t, records[0].BlobCertificate.RelayKeys, iterated[blobKeys[0]].BlobCertificate.RelayKeys)
+	require.True(t, start.Equal(iterated[blobKeys[0]].StoredAt))
+	// The store time is set on a copy of the record
+	require.True(t, records[0].StoredAt.IsZero())
+
+	// Records stored after the seal are only iterated once the mutable segment is sealed again
+	now = start.Add(time.Minute)
+	records = append(records, storeBlobRecords(t, store, blobKeys[1:], batch.BlobCertificates[1:])...)
+	require.Len(t, iterateBlobRecords(t, store), 1)
+	now = start.Add(10 * time.Minute)
+	require.Len(t, iterateBlobRecords(t, store), len(records))
+
+	// Records are persisted across restarts, and survive the loss of the chunks
+	require.NoError(t, store.Stop())
+	require.NoError(t, os.RemoveAll(chunkPath))
+	store = newTestValidatorStore(t, chunkPath, blobRecordsPath, timeSource)
+	defer func() {
+		require.NoError(t, store.Stop())
+	}()
+	iterated = iterateBlobRecords(t, store)
+	require.Len(t, iterated, len(records))
+	for i, blobKey := range blobKeys {
+		require.Equal(t, records[i].ReferenceBlockNumber, iterated[blobKey].ReferenceBlockNumber)
+		require.Equal(t, records[i].BlobCertificate.RelayKeys, iterated[blobKey].BlobCertificate.RelayKeys)
+	}
+
+	// Iteration stops at the first error returned by the handler
+	handlerErr := errors.New("handler error")
+	calls := 0
+	err := store.IterateBlobRecords(func(record *node.BlobRecord) error {
+		calls++
+		return handlerErr
+	})
+	require.ErrorIs(t, err, handlerErr)
+	require.Equal(t, 1, calls)
+
+	// Records are skipped once their bundles expire
+	now = start.Add(testChunkTTL)
+	iterated = iterateBlobRecords(t, store)
+	require.Len(t, iterated, len(records)-1)
+	require.NotContains(t, iterated, blobKeys[0])
+	now = start.Add(testChunkTTL + time.Minute)
+	require.Empty(t, iterateBlobRecords(t, store))
+}
+
+func TestBlobRecordsDisabled(t *testing.T) {
+	logger, err := common.NewLogger(common.DefaultTextLoggerConfig())
+	require.NoError(t, err)
+
+	// Without chunk repair, no blob record path is required, and none is created.
+	dbPath := t.TempDir()
+	config := &node.Config{
+		GetChunksHotCacheReadLimitMB:  units.GiB,
+		GetChunksHotBurstLimitMB:      units.GiB,
+		GetChunksColdCacheReadLimitMB: units.GiB,
+		GetChunksColdBurstLimitMB:     units.GiB,
+		LittDBStoragePaths:            []string{t.TempDir()},
+		LittDBBlobRecordsPath:         path.Join(dbPath, "blob_records"),
+	}
+	store, err := node.NewValidatorStore(logger, config, time.Now, testChunkTTL, nil)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, store.Stop())
+	}()
+
+	blobKeys, batch, _ := nodemock.MockBatch(t)
+	batchData := make([]*node.BundleToStore, len(batch.BlobCertificates))
+	for i, cert := range batch.BlobCertificates {
+		bundleKey, err := node.BundleKey(blobKeys[i], 0)
+		require.NoError(t, err)
+		batchData[i] = &node.BundleToStore{
+			BundleKey:   bundleKey,
+			BundleBytes: []byte{byte(i)},
+			BlobRecord:  &node.BlobRecord{BlobCertificate: cert, ReferenceBlockNumber: 100},
+		}
+	}
+	_, err = store.StoreBatch(batchData)
+	require.NoError(t, err)
+	exists, err := store.HasBundle(batchData[0].BundleKey)
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	err = store.IterateBlobRecords(func(record *node.BlobRecord) error {
+		require.Fail(t, "no blob records should be stored")
+		return nil
+	})
+	require.NoError(t, err)
+
+	entries, err := os.ReadDir(dbPath)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestRepairBundles(t *testing.T) {
+	c := newComponents(t, op0)
+	c.node.Config.ChunkDownloadTimeout = 10 * time.Second
+	ctx := context.Background()
+	blobKeys, batch, bundles := mockBatchWithRelays(t, 0)
+
+	chunkPath := t.TempDir()
+	blobRecordsPath := t.TempDir()
+	store := newTestValidatorStore(t, chunkPath, blobRecordsPath, time.Now)
+
+	batchData := make([]*node.BundleToStore, 0, len(blobKeys))
+	for i, blobKey := range blobKeys {
+		bundleKey, err := node.BundleKey(blobKey, 0)
+		require.NoError(t, err)
+		batchData = append(batchData, &node.BundleToStore{
+			BundleKey:   bundleKey,
+			BundleBytes: bundles[blobKey],
+			BlobRecord: &node.BlobRecord{
+				BlobCertificate:      batch.BlobCertificates[i],
+				ReferenceBlockNumber: batch.BatchHeader.ReferenceBlockNumber,
+			},
+		})
+	}
+	_, err := store.StoreBatch(batchData)
+	require.NoError(t, err)
+
+	// The chunks are lost, and only the bundles of blobs 0 and 2 are stored again
+	require.NoError(t, store.Stop())
+	require.NoError(t, os.RemoveAll(chunkPath))
+	store = newTestValidatorStore(t, chunkPath, blobRecordsPath, time.Now)
+	defer func() {
+		require.NoError(t, store.Stop())
+	}()
+	_, err = store.StoreBatch([]*node.BundleToStore{batchData[0], batchData[2]})
+	require.NoError(t, err)
+	missingBundleKey := batchData[1].BundleKey
+
+	c.node.ValidatorStore = store
+	validator := coremockv2.NewMockShardValidator()
+	c.node.ValidatorV2 = validator
+	peerFetcher := &fakePeerBundleFetcher{bundles: bundles}
+	c.node.BundleRepairFetcher = peerFetcher
+
+	// Bundles that fail validation are not stored
+	validator.On("ValidateBlobs").Return(errors.New("invalid bundle")).Once()
+	repaired, err := c.node.RepairBundles(ctx)
+	require.Error(t, err)
+	require.Equal(t, 0, repaired)
+	exists, err := store.HasBundle(missingBundleKey)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	// Only the missing bundle is repaired
+	validator.On("ValidateBlobs").Return(nil)
+	repaired, err = c.node.RepairBundles(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, repaired)
+	require.Equal(t, []v2.BlobKey{blobKeys[1], blobKeys[1]}, peerFetcher.fetched)
+	exists, err = store.HasBundle(missingBundleKey)
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	// Once repaired, there is nothing left to repair
+	repaired, err = c.node.RepairBundles(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, repaired)
+	require.Len(t, peerFetcher.fetched, 2)
+	validator.AssertNumberOfCalls(t, "ValidateBlobs", 2)
+}
+
+func TestRepairSkipsExpiredBundles(t *testing.T) {
+	c := newComponents(t, op0)
+	ctx := context.Background()
+	blobKeys, batch, bundles := mockBatchWithRelays(t, 0)
+
+	chunkPath := t.TempDir()
+	blobRecordsPath := t.TempDir()
+	now := time.Now()
+	timeSource := func() time.Time { return now }
+	store := newTestValidatorStore(t, chunkPath, blobRecordsPath, timeSource)
+
+	batchData := make([]*node.BundleToStore, 0, len(blobKeys))
+	for i, blobKey := range blobKeys {
+		bundleKey, err := node.BundleKey(blobKey, 0)
+		require.NoError(t, err)
+		batchData = append(batchData, &node.BundleToStore{
+			BundleKey:   bundleKey,
+			BundleBytes: bundles[blobKey],
+			BlobRecord: &node.BlobRecord{
+				BlobCertificate:      batch.BlobCertificates[i],
+				ReferenceBlockNumber: batch.BatchHeader.ReferenceBlockNumber,
+			},
+		})
+	}
+	_, err := store.StoreBatch(batchData)
+	require.NoError(t, err)
+
+	// The bundles expire, and are deleted
+	require.NoError(t, store.Stop())
+	require.NoError(t, os.RemoveAll(chunkPath))
+	now = now.Add(testChunkTTL)
+	store = newTestValidatorStore(t, chunkPath, blobRecordsPath, timeSource)
+	defer func() {
+		require.NoError(t, store.Stop())
+	}()
+
+	c.node.ValidatorStore = store
+	validator := coremockv2.NewMockShardValidator()
+	c.node.ValidatorV2 = validator
+	peerFetcher := &fakePeerBundleFetcher{bundles: bundles}
+	c.node.BundleRepairFetcher = peerFetcher
+
+	// Expired bundles are not repaired
+	repaired, err := c.node.RepairBundles(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, repaired)
+	require.Empty(t, peerFetcher.fetched)
+	validator.AssertNotCalled(t, "ValidateBlobs")
+}
+
+// BenchmarkStoreChunks measures the cost of recording the blobs of each batch on top of storing its bundles.
+func BenchmarkStoreChunks(b *testing.B) {
+	t := &testing.T{}
+	_, batch, _ := nodemock.MockBatch(t)
+	bundleBytes := make([]byte, 64*units.KiB)
+	_, err := rand.Read(bundleBytes)
+	require.NoError(b, err)
+
+	for _, recordBlobs := range []bool{false, true} {
+		name := "bundles"
+		if recordBlobs {
+			name = "bundles and blob records"
+		}
+		b.Run(name, func(b *testing.B) {
+			logger, err := common.NewLogger(common.DefaultTextLoggerConfig())
+			require.NoError(b, err)
+			config := &node.Config{
+				GetChunksHotCacheReadLimitMB:  units.GiB,
+				GetChunksHotBurstLimitMB:      units.GiB,
+				GetChunksColdCacheReadLimitMB: units.GiB,
+				GetChunksColdBurstLimitMB:     units.GiB,
+				LittDBStoragePaths:            []string{b.TempDir()},
+				LittDBBlobRecordsPath:         b.TempDir(),
+				EnableChunkRepair:             true,
+			}
+			store, err := node.NewValidatorStore(logger, config, time.Now, testChunkTTL, prometheus.NewRegistry())
+			require.NoError(b, err)
+			defer func() {
+				require.NoError(b, store.Stop())
+			}()
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				bundles := make([]*node.BundleToStore, 0, len(batch.BlobCertificates))
+				for _, cert := range batch.BlobCertificates {
+					// Every iteration stores new blobs
+					blobHeader := *cert.BlobHeader
+					blobHeader.PaymentMetadata.Timestamp = int64(i)
+					blobCert := *cert
+					blobCert.BlobHeader = &blobHeader
+
+					blobKey, err := blobHeader.BlobKey()
+					require.NoError(b, err)
+					bundleKey, err := node.BundleKey(blobKey, 0)
+					require.NoError(b, err)
+					bundle := &node.BundleToStore{BundleKey: bundleKey, BundleBytes: bundleBytes}
+					if recordBlobs {
+						bundle.BlobRecord = &node.BlobRecord{BlobCertificate: &blobCert, ReferenceBlockNumber: 100}
+					}
+					bundles = append(bundles, bundle)
+				}
+
+				_, err := store.StoreBatch(bundles)
+				require.NoError(b, err)
+			}
+		})
+	}
+}
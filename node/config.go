@@ -94,6 +94,20 @@ type Config struct {
 	ChunkDownloadTimeout        time.Duration
 	GRPCMsgSizeLimitV2          int
 
+	// if true then chunks are downloaded from relays with the streaming StreamChunks() API (v2 only)
+	EnableRelayChunkStreaming bool
+	// if true then chunks that can't be downloaded from any relay are rebuilt from the chunks of other validators
+	// (v2 only). This requires the G1 SRS points to re-encode blobs.
+	EnablePeerChunkRecovery bool
+	// if true then bundles missing from the validator store are periodically rebuilt from the chunks of other
+	// validators (v2 only). This requires the G1 SRS points to re-encode blobs.
+	EnableChunkRepair bool
+	// the interval at which the validator store is checked for missing bundles
+	ChunkRepairInterval time.Duration
+	// if true then bundles are downloaded from relays in the compressed GNARK format, and stored in that format in
+	// the validator store (v2 only). Bundles stored in either format can be served to clients.
+	EnableCompressedBundleEncoding bool
+
 	PprofHttpPort string
 	EnablePprof   bool
 
@@ -132,6 +146,12 @@ type Config struct {
 	// Directories do not need to be on the same filesystem.
 	LittDBStoragePaths []string
 
+	// The path to the littDB storing the records of the blobs whose chunks are stored, which are used to repair
+	// missing chunks. Should be on a different disk than LittDBStoragePaths, so that the records survive the loss of
+	// the chunks. Falls back to DbPath with a '/blob_records_litt' suffix if empty. Only used if EnableChunkRepair is
+	// set.
+	LittDBBlobRecordsPath string
+
 	// The rate limit for the number of bytes served by the GetChunks API if the data is in the cache.
 	// Unit is in megabytes per second.
 	GetChunksHotCacheReadLimitMB float64
@@ -364,6 +384,11 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 		OnchainStateRefreshInterval:         ctx.GlobalDuration(flags.OnchainStateRefreshIntervalFlag.Name),
 		ChunkDownloadTimeout:                ctx.GlobalDuration(flags.ChunkDownloadTimeoutFlag.Name),
 		GRPCMsgSizeLimitV2:                  ctx.GlobalInt(flags.GRPCMsgSizeLimitV2Flag.Name),
+		EnableRelayChunkStreaming:           ctx.GlobalBool(flags.EnableRelayChunkStreamingFlag.Name),
+		EnablePeerChunkRecovery:             ctx.GlobalBool(flags.EnablePeerChunkRecoveryFlag.Name),
+		EnableChunkRepair:                   ctx.GlobalBool(flags.EnableChunkRepairFlag.Name),
+		ChunkRepairInterval:                 ctx.GlobalDuration(flags.ChunkRepairIntervalFlag.Name),
+		EnableCompressedBundleEncoding:      ctx.GlobalBool(flags.EnableCompressedBundleEncodingFlag.Name),
 		PprofHttpPort:                       ctx.GlobalString(flags.PprofHttpPort.Name),
 		EnablePprof:                         ctx.GlobalBool(flags.EnablePprof.Name),
 		DisableDispersalAuthentication:      ctx.GlobalBool(flags.DisableDispersalAuthenticationFlag.Name),
@@ -376,6 +401,7 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 		LittDBReadCacheSizeGB:               ctx.GlobalFloat64(flags.LittDBReadCacheSizeGBFlag.Name),
 		LittDBReadCacheSizeFraction:         ctx.GlobalFloat64(flags.LittDBReadCacheSizeFractionFlag.Name),
 		LittDBStoragePaths:                  ctx.GlobalStringSlice(flags.LittDBStoragePathsFlag.Name),
+		LittDBBlobRecordsPath:               ctx.GlobalString(flags.LittDBBlobRecordsPathFlag.Name),
 		DownloadPoolSize:                    ctx.GlobalInt(flags.DownloadPoolSizeFlag.Name),
 		GetChunksHotCacheReadLimitMB:        ctx.GlobalFloat64(flags.GetChunksHotCacheReadLimitMBFlag.Name),
 		GetChunksHotBurstLimitMB:            ctx.GlobalFloat64(flags.GetChunksHotBurstLimitMBFlag.Name),
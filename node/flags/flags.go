@@ -247,11 +247,42 @@ var (
 	}
 	ChunkDownloadTimeoutFlag = cli.DurationFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "chunk-download-timeout"),
-		Usage:    "The timeout for downloading chunks from the relay (default: 30s)",
+		Usage:    "The timeout of each attempt to download chunks from a relay or from other validators. All attempts share the deadline of the StoreChunks request",
 		Required: false,
 		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "CHUNK_DOWNLOAD_TIMEOUT"),
 		Value:    20 * time.Second,
 	}
+	EnableRelayChunkStreamingFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "enable-relay-chunk-streaming"),
+		Usage:    "Download chunks from relays with the streaming StreamChunks() API instead of GetChunks(). Relays that do not support streaming are queried with GetChunks(). This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "ENABLE_RELAY_CHUNK_STREAMING"),
+	}
+	EnablePeerChunkRecoveryFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "enable-peer-chunk-recovery"),
+		Usage:    "Rebuild chunks that can't be downloaded from any relay from the chunks held by other validators. Requires the G1 SRS points to re-encode blobs. This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "ENABLE_PEER_CHUNK_RECOVERY"),
+	}
+	EnableChunkRepairFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "enable-chunk-repair"),
+		Usage:    "Periodically rebuild the chunks missing from the local store (e.g. after a disk failure) from the chunks held by other validators. Requires the G1 SRS points to re-encode blobs. This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "ENABLE_CHUNK_REPAIR"),
+	}
+	ChunkRepairIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "chunk-repair-interval"),
+		Usage:    "The interval at which the local store is checked for missing chunks when chunk repair is enabled. This flag is only relevant in v2 (default: 10m)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "CHUNK_REPAIR_INTERVAL"),
+		Value:    10 * time.Minute,
+	}
+	EnableCompressedBundleEncodingFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "enable-compressed-bundle-encoding"),
+		Usage:    "Download chunks from relays in the compressed GNARK format, and store them in that format. This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "ENABLE_COMPRESSED_BUNDLE_ENCODING"),
+	}
 	GRPCMsgSizeLimitV2Flag = cli.IntFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "grpc-msg-size-limit-v2"),
 		Usage:    "The maximum message size in bytes the V2 dispersal endpoint can receive from the client. This flag is only relevant in v2 (default: 1MB)",
@@ -427,6 +458,12 @@ var (
 		Required: false,
 		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "LITT_DB_STORAGE_PATHS"),
 	}
+	LittDBBlobRecordsPathFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "litt-db-blob-records-path"),
+		Usage:    "Path to store the LittDB records of the blobs whose chunks are stored, which are used to repair missing chunks. Should be on a different disk than the LittDB storage paths. If not provided, falls back to NODE_DB_PATH with '/blob_records_litt' suffix. Only used if chunk repair is enabled.",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "LITT_DB_BLOB_RECORDS_PATH"),
+	}
 	DownloadPoolSizeFlag = cli.IntFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "download-pool-size"),
 		Usage:    "The size of the download pool. The default value is 16.",
@@ -578,6 +615,11 @@ var optionalFlags = []cli.Flag{
 	V2RetrievalPortFlag,
 	OnchainStateRefreshIntervalFlag,
 	ChunkDownloadTimeoutFlag,
+	EnableRelayChunkStreamingFlag,
+	EnablePeerChunkRecoveryFlag,
+	EnableChunkRepairFlag,
+	ChunkRepairIntervalFlag,
+	EnableCompressedBundleEncodingFlag,
 	GRPCMsgSizeLimitV2Flag,
 	PprofHttpPort,
 	EnablePprof,
@@ -597,6 +639,7 @@ var optionalFlags = []cli.Flag{
 	LittDBWriteCacheSizeFractionFlag,
 	LittDBReadCacheSizeFractionFlag,
 	LittDBStoragePathsFlag,
+	LittDBBlobRecordsPathFlag,
 	GetChunksHotCacheReadLimitMBFlag,
 	GetChunksHotBurstLimitMBFlag,
 	GetChunksColdCacheReadLimitMBFlag,
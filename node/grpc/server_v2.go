@@ -183,12 +183,20 @@ func (s *ServerV2) StoreChunks(ctx context.Context, in *pb.StoreChunksRequest) (
 		return nil, api.NewErrorInternal(fmt.Sprintf("failed to get the operator state: %v", err))
 	}
 
-	blobShards, rawBundles, err := s.node.DownloadBundles(ctx, batch, operatorState, probe)
+	probe.SetStage("validate")
+	err = s.node.ValidateBatchV2(ctx, batch)
 	if err != nil {
-		return nil, api.NewErrorInternal(fmt.Sprintf("failed to get the operator state: %v", err))
+		return nil, api.NewErrorInternal(
+			fmt.Sprintf("failed to validate batch %s: %v", hex.EncodeToString(batchHeaderHash[:]), err))
 	}
 
-	err = s.validateAndStoreChunks(ctx, batch, blobShards, rawBundles, operatorState, batchHeaderHash, probe)
+	// The bundles are validated as they are downloaded
+	_, rawBundles, err := s.node.DownloadBundles(ctx, batch, operatorState, probe)
+	if err != nil {
+		return nil, api.NewErrorInternal(fmt.Sprintf("failed to download the bundles: %v", err))
+	}
+
+	err = s.storeChunks(batch, rawBundles, batchHeaderHash, probe)
 	if err != nil {
 		return nil, err
 	}
@@ -204,12 +212,9 @@ func (s *ServerV2) StoreChunks(ctx context.Context, in *pb.StoreChunksRequest) (
 	}, nil
 }
 
-func (s *ServerV2) validateAndStoreChunks(
-	ctx context.Context,
+func (s *ServerV2) storeChunks(
 	batch *corev2.Batch,
-	blobShards []*corev2.BlobShard,
 	rawBundles []*node.RawBundle,
-	operatorState *core.OperatorState,
 	batchHeaderHash [32]byte,
 	probe *common.SequenceProbe,
 ) error {
@@ -232,35 +237,13 @@ func (s *ServerV2) validateAndStoreChunks(
 		batchData = append(batchData, &node.BundleToStore{
 			BundleKey:   bundleKey,
 			BundleBytes: bundle.Bundle,
+			BlobRecord: &node.BlobRecord{
+				BlobCertificate:      bundle.BlobCertificate,
+				ReferenceBlockNumber: batch.BatchHeader.ReferenceBlockNumber,
+			},
 		})
 	}
 
-	return s.validateAndStoreChunksLittDB(
-		ctx,
-		batch,
-		blobShards,
-		batchData,
-		operatorState,
-		batchHeaderHash,
-		probe)
-}
-
-func (s *ServerV2) validateAndStoreChunksLittDB(
-	ctx context.Context,
-	batch *corev2.Batch,
-	blobShards []*corev2.BlobShard,
-	batchData []*node.BundleToStore,
-	operatorState *core.OperatorState,
-	batchHeaderHash [32]byte,
-	probe *common.SequenceProbe,
-) error {
-	probe.SetStage("validate")
-	err := s.node.ValidateBatchV2(ctx, batch, blobShards, operatorState)
-	if err != nil {
-		return api.NewErrorInternal(
-			fmt.Sprintf("failed to validate batch %s: %v", hex.EncodeToString(batchHeaderHash[:]), err))
-	}
-
 	probe.SetStage("store")
 	size, err := s.node.ValidatorStore.StoreBatch(batchData)
 	if err != nil {
@@ -323,11 +306,26 @@ func (s *ServerV2) GetChunks(ctx context.Context, in *pb.GetChunksRequest) (*pb.
 		return nil, api.NewErrorInternal(fmt.Sprintf("failed to get chunks: %v", err))
 	}
 
-	chunks, _, err := node.DecodeChunks(bundleData)
+	chunks, format, err := node.DecodeBundleChunks(bundleData)
 	if err != nil {
 		return nil, api.NewErrorInternal(fmt.Sprintf("failed to decode chunks: %v", err))
 	}
 
+	chunkEncodingFormat := pb.ChunkEncodingFormat_GNARK
+	if format == core.GnarkCompressedChunkEncodingFormat {
+		if in.GetChunkEncodingFormat() == pb.ChunkEncodingFormat_GNARK_COMPRESSED {
+			chunkEncodingFormat = pb.ChunkEncodingFormat_GNARK_COMPRESSED
+		} else {
+			// The client may not be able to decode compressed chunks
+			for i, chunk := range chunks {
+				chunks[i], err = encoding.DecompressGnarkChunk(chunk)
+				if err != nil {
+					return nil, api.NewErrorInternal(fmt.Sprintf("failed to decompress chunk: %v", err))
+				}
+			}
+		}
+	}
+
 	size := 0
 	if len(chunks) > 0 {
 		size = len(chunks[0]) * len(chunks)
@@ -338,7 +336,7 @@ func (s *ServerV2) GetChunks(ctx context.Context, in *pb.GetChunksRequest) (*pb.
 
 	return &pb.GetChunksReply{
 		Chunks:              chunks,
-		ChunkEncodingFormat: pb.ChunkEncodingFormat_GNARK,
+		ChunkEncodingFormat: chunkEncodingFormat,
 	}, nil
 }
 
@@ -22,6 +22,7 @@ import (
 	coremock "github.com/Layr-Labs/eigenda/core/mock"
 	coremockv2 "github.com/Layr-Labs/eigenda/core/mock/v2"
 	v2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigenda/node"
 	"github.com/Layr-Labs/eigenda/node/grpc"
 	nodemock "github.com/Layr-Labs/eigenda/node/mock"
@@ -222,7 +223,14 @@ func TestV2StoreChunksSuccess(t *testing.T) {
 		require.Len(t, requests, 1)
 		require.Equal(t, blobKeys[1], requests[0].BlobKey)
 	})
-	c.store.On("StoreBatch", mock.Anything, mock.Anything).Return(nil, nil)
+	c.store.On("StoreBatch", mock.Anything, mock.Anything).Return(nil, nil).Run(func(args mock.Arguments) {
+		batchData := args.Get(0).([]*node.BundleToStore)
+		require.Len(t, batchData, len(batch.BlobCertificates))
+		for i, bundle := range batchData {
+			require.Equal(t, batch.BlobCertificates[i], bundle.BlobRecord.BlobCertificate)
+			require.Equal(t, batch.BatchHeader.ReferenceBlockNumber, bundle.BlobRecord.ReferenceBlockNumber)
+		}
+	})
 	reply, err := c.server.StoreChunks(context.Background(), &validator.StoreChunksRequest{
 		DisperserID: 0,
 		Signature:   ecdsaSig,
@@ -410,6 +418,58 @@ func TestV2GetChunksInputValidation(t *testing.T) {
 	requireErrorStatus(t, err, codes.InvalidArgument)
 }
 
+func TestV2GetChunksEncodingFormats(t *testing.T) {
+	config := makeConfig(t)
+	config.EnableV2 = true
+	c := newTestComponents(t, config)
+	ctx := context.Background()
+
+	_, _, bundles := nodemock.MockBatch(t)
+	bundle := bundles[0][0]
+	gnarkBundle, err := bundle.Serialize()
+	require.NoError(t, err)
+	chunksData, err := new(core.ChunksData).FromFrames(bundle)
+	require.NoError(t, err)
+	compressedBundle, err := chunksData.FlattenToCompressedBundle()
+	require.NoError(t, err)
+
+	bk := [32]byte{1}
+	gnarkRequest := &validator.GetChunksRequest{
+		BlobKey: bk[:],
+	}
+	compressedRequest := &validator.GetChunksRequest{
+		BlobKey:             bk[:],
+		ChunkEncodingFormat: validator.ChunkEncodingFormat_GNARK_COMPRESSED,
+	}
+
+	// chunks stored in the GNARK format are returned in the GNARK format, whatever the client asks for
+	c.store.On("GetBundleData", mock.Anything).Return(gnarkBundle, nil).Twice()
+	for _, request := range []*validator.GetChunksRequest{gnarkRequest, compressedRequest} {
+		reply, err := c.server.GetChunks(ctx, request)
+		require.NoError(t, err)
+		require.Equal(t, validator.ChunkEncodingFormat_GNARK, reply.GetChunkEncodingFormat())
+		require.Equal(t, chunksData.Chunks, reply.GetChunks())
+	}
+
+	// chunks stored in the compressed format are only returned compressed to clients that ask for it
+	c.store.On("GetBundleData", mock.Anything).Return(compressedBundle, nil).Twice()
+	reply, err := c.server.GetChunks(ctx, gnarkRequest)
+	require.NoError(t, err)
+	require.Equal(t, validator.ChunkEncodingFormat_GNARK, reply.GetChunkEncodingFormat())
+	require.Equal(t, chunksData.Chunks, reply.GetChunks())
+
+	reply, err = c.server.GetChunks(ctx, compressedRequest)
+	require.NoError(t, err)
+	require.Equal(t, validator.ChunkEncodingFormat_GNARK_COMPRESSED, reply.GetChunkEncodingFormat())
+	require.Len(t, reply.GetChunks(), len(bundle))
+	for i, chunk := range reply.GetChunks() {
+		decompressed, err := encoding.DecompressGnarkChunk(chunk)
+		require.NoError(t, err)
+		require.Equal(t, chunksData.Chunks[i], decompressed)
+	}
+	c.store.AssertExpectations(t)
+}
+
 func requireErrorStatus(t *testing.T, err error, code codes.Code) {
 	require.Error(t, err)
 	s, ok := status.FromError(err)
@@ -38,6 +38,16 @@ func (m *MockStoreV2) GetBundleData(bundleKey []byte) ([]byte, error) {
 	return args.Get(0).([]byte), args.Error(1)
 }
 
+func (m *MockStoreV2) HasBundle(bundleKey []byte) (bool, error) {
+	args := m.Called(bundleKey)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockStoreV2) IterateBlobRecords(handler func(record *node.BlobRecord) error) error {
+	args := m.Called(handler)
+	return args.Error(0)
+}
+
 func (m *MockStoreV2) Stop() error {
 	return nil
 }
@@ -18,8 +18,10 @@ import (
 	"time"
 
 	"github.com/Layr-Labs/eigenda/api/clients/v2/relay"
+	clientsv2 "github.com/Layr-Labs/eigenda/api/clients/v2/validator"
 	"github.com/Layr-Labs/eigenda/common/pprof"
 	"github.com/Layr-Labs/eigenda/common/pubip"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/prover"
 	"github.com/Layr-Labs/eigenda/encoding/kzg/verifier"
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -27,6 +29,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 
 	"github.com/Layr-Labs/eigenda/api/grpc/node"
+	relaygrpc "github.com/Layr-Labs/eigenda/api/grpc/relay"
 	"github.com/Layr-Labs/eigenda/common/geth"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/core/eth"
@@ -78,6 +81,15 @@ type Node struct {
 	BLSSigner blssigner.Signer
 
 	RelayClient atomic.Value
+	// RelayHealth tracks the latency and errors of relays, to choose the relays chunks are downloaded from. If nil,
+	// relays are chosen at random.
+	RelayHealth *RelayHealthTracker
+	// PeerBundleFetcher rebuilds bundles from the chunks of other validators when they can't be downloaded from any
+	// relay. If nil, bundles are only downloaded from relays.
+	PeerBundleFetcher PeerBundleFetcher
+	// BundleRepairFetcher rebuilds the bundles missing from the ValidatorStore from the chunks of other validators.
+	// If nil, missing bundles are not repaired.
+	BundleRepairFetcher PeerBundleFetcher
 
 	mu            sync.Mutex
 	CurrentSocket string
@@ -236,6 +248,7 @@ func NewNode(
 		ChainID:                 chainID,
 		BLSSigner:               blsSigner,
 		DownloadPool:            downloadPool,
+		RelayHealth:             NewRelayHealthTracker(),
 	}
 
 	if !config.EnableV2 {
@@ -264,6 +277,9 @@ func NewNode(
 			MessageSigner:      n.SignMessage,
 			MaxGRPCMessageSize: n.Config.RelayMaxMessageSize,
 		}
+		if config.EnableCompressedBundleEncoding {
+			relayClientConfig.BundleEncodingFormat = relaygrpc.BundleEncodingFormat_GNARK_COMPRESSED
+		}
 
 		relayUrlProvider, err := relay.NewRelayUrlProvider(client, tx.GetRelayRegistryAddress())
 		if err != nil {
@@ -277,6 +293,27 @@ func NewNode(
 
 		n.RelayClient.Store(relayClient)
 
+		if config.EnablePeerChunkRecovery || config.EnableChunkRepair {
+			chunkProver, err := prover.NewProver(&config.EncoderConfig, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create prover for peer chunk recovery: %w", err)
+			}
+			validatorClient := clientsv2.NewValidatorClient(
+				logger,
+				tx,
+				cst,
+				v,
+				clientsv2.DefaultClientConfig(),
+				clientsv2.NewValidatorClientMetrics(reg))
+			peerBundleFetcher := NewPeerBundleFetcher(validatorClient, chunkProver)
+			if config.EnablePeerChunkRecovery {
+				n.PeerBundleFetcher = peerBundleFetcher
+			}
+			if config.EnableChunkRepair {
+				n.BundleRepairFetcher = peerBundleFetcher
+			}
+		}
+
 		blockNumber, err := tx.GetCurrentBlockNumber(ctx)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get block number: %w", err)
@@ -319,6 +356,9 @@ func (n *Node) Start(ctx context.Context) error {
 			_ = n.RefreshOnchainState(ctx)
 		}()
 		go n.checkNodeReachability(v2CheckPath)
+		if n.BundleRepairFetcher != nil {
+			go n.repairLoop(ctx)
+		}
 	}
 
 	// Build the socket based on the hostname/IP provided in the CLI
@@ -16,6 +16,7 @@ import (
 	"github.com/Layr-Labs/eigenda/common/geth"
 	"github.com/Layr-Labs/eigenda/core"
 	coremock "github.com/Layr-Labs/eigenda/core/mock"
+	coremockv2 "github.com/Layr-Labs/eigenda/core/mock/v2"
 	v2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/node"
 	"github.com/stretchr/testify/assert"
@@ -75,6 +76,8 @@ func newComponents(t *testing.T, operatorID [32]byte) *components {
 
 	mockVal := coremock.NewMockShardValidator()
 	mockVal.On("ValidateBatch", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockValV2 := coremockv2.NewMockShardValidator()
+	mockValV2.On("ValidateBlobs").Return(nil)
 
 	chainState, _ := coremock.MakeChainDataMock(map[uint8]int{
 		0: 4,
@@ -102,6 +105,7 @@ func newComponents(t *testing.T, operatorID [32]byte) *components {
 		Store:        store,
 		ChainState:   chainState,
 		Validator:    mockVal,
+		ValidatorV2:  mockValV2,
 		Transactor:   tx,
 		DownloadPool: workerpool.New(1),
 	}
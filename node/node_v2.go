@@ -6,34 +6,44 @@ package node
 import (
 	"context"
 	"fmt"
-	"math/rand"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/api/clients/v2/relay"
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/core"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/gammazero/workerpool"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type requestMetadata struct {
 	blobShardIndex int
 	assignment     corev2.Assignment
+	blobParams     *core.BlobVersionParameters
+	// relayKeys are the relays the bundle can be downloaded from, in the order they are tried
+	relayKeys []corev2.RelayKey
+	// downloaded is set once the bundle has been downloaded and deserialized
+	downloaded bool
 }
 type relayRequest struct {
 	chunkRequests []*relay.ChunkRequestByIndex
 	metadata      []*requestMetadata
 }
-type response struct {
-	metadata []*requestMetadata
-	bundles  [][]byte
-	err      error
-}
 
 type RawBundle struct {
 	BlobCertificate *corev2.BlobCertificate
 	Bundle          []byte
 }
 
+// DownloadBundles downloads the bundles of chunks assigned to this validator for each blob in the batch.
+//
+// Each bundle is requested from one of the relays holding the blob, picked according to the health of the relays.
+// Bundles are validated against the blob commitments as soon as they are downloaded. The bundles that fail to
+// download or to validate are requested from the next relay holding their blob, and the bundles that can't be
+// downloaded from any relay are rebuilt from the chunks of other validators if a PeerBundleFetcher is set. All
+// attempts share the time budget of the StoreChunks request, given by the deadline of ctx, and each attempt is
+// further bounded by ChunkDownloadTimeout.
 func (n *Node) DownloadBundles(
 	ctx context.Context,
 	batch *corev2.Batch,
@@ -54,15 +64,20 @@ func (n *Node) DownloadBundles(
 		return nil, nil, fmt.Errorf("blob version params is nil")
 	}
 
+	if n.ValidatorV2 == nil {
+		return nil, nil, fmt.Errorf("shard validator is not set")
+	}
+	validation := &bundleValidation{
+		blobVersionParams: blobVersionParams,
+		operatorState:     operatorState,
+		pool:              workerpool.New(n.Config.NumBatchValidators),
+	}
+	defer validation.pool.Stop()
+
 	blobShards := make([]*corev2.BlobShard, len(batch.BlobCertificates))
 	rawBundles := make([]*RawBundle, len(batch.BlobCertificates))
-	requests := make(map[corev2.RelayKey]*relayRequest)
+	pending := make([]*requestMetadata, 0, len(batch.BlobCertificates))
 	for i, cert := range batch.BlobCertificates {
-		blobKey, err := cert.BlobHeader.BlobKey()
-		if err != nil {
-			return nil, nil, fmt.Errorf("failed to get blob key: %v", err)
-		}
-
 		if len(cert.RelayKeys) == 0 {
 			return nil, nil, fmt.Errorf("no relay keys in the certificate")
 		}
@@ -72,8 +87,6 @@ func (n *Node) DownloadBundles(
 		rawBundles[i] = &RawBundle{
 			BlobCertificate: cert,
 		}
-		relayIndex := rand.Intn(len(cert.RelayKeys))
-		relayKey := cert.RelayKeys[relayIndex]
 
 		blobParams, ok := blobVersionParams.Get(cert.BlobHeader.BlobVersion)
 		if !ok {
@@ -87,6 +100,79 @@ func (n *Node) DownloadBundles(
 			continue
 		}
 
+		pending = append(pending, &requestMetadata{
+			blobShardIndex: i,
+			assignment:     assgn,
+			blobParams:     blobParams,
+			relayKeys:      n.RelayHealth.OrderRelays(cert.RelayKeys),
+		})
+	}
+
+	probe.SetStage("download")
+
+	var downloadErr error
+	for attempt := 0; len(pending) > 0; attempt++ {
+		requests, err := n.buildRelayRequests(batch, pending, attempt)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(requests) == 0 {
+			// every relay holding the remaining blobs has been tried
+			break
+		}
+		if attempt > 0 {
+			n.Logger.Warn("retrying bundle downloads from other relays",
+				"attempt", attempt, "numBlobs", len(pending), "err", downloadErr)
+		}
+
+		attemptCtx, cancelAttempt := context.WithTimeout(ctx, n.attemptTimeout(ctx, pending, attempt))
+		err = n.downloadFromRelays(attemptCtx, relayClient, requests, validation, blobShards, rawBundles)
+		cancelAttempt()
+		if err != nil {
+			downloadErr = err
+		}
+		pending = filterPending(pending)
+	}
+
+	if len(pending) > 0 && n.PeerBundleFetcher != nil {
+		probe.SetStage("download_from_peers")
+		n.Logger.Warn("downloading bundles from other validators, as they could not be downloaded from relays",
+			"numBlobs", len(pending), "err", downloadErr)
+		peerCtx, cancelPeers := context.WithTimeout(ctx, n.attemptTimeout(ctx, nil, 0))
+		err := n.downloadFromPeers(peerCtx, batch, pending, validation, blobShards, rawBundles)
+		cancelPeers()
+		if err != nil {
+			downloadErr = err
+		}
+		pending = filterPending(pending)
+	}
+
+	if len(pending) > 0 {
+		return nil, nil, fmt.Errorf("failed to download the bundles of %d blobs: %v", len(pending), downloadErr)
+	}
+
+	return blobShards, rawBundles, nil
+}
+
+// buildRelayRequests groups the pending bundles by the relay they are requested from in the given attempt. Bundles
+// that have already been requested from all of their relays are left out.
+func (n *Node) buildRelayRequests(
+	batch *corev2.Batch,
+	pending []*requestMetadata,
+	attempt int,
+) (map[corev2.RelayKey]*relayRequest, error) {
+	requests := make(map[corev2.RelayKey]*relayRequest)
+	for _, metadata := range pending {
+		if attempt >= len(metadata.relayKeys) {
+			continue
+		}
+		relayKey := metadata.relayKeys[attempt]
+
+		blobKey, err := batch.BlobCertificates[metadata.blobShardIndex].BlobHeader.BlobKey()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get blob key: %v", err)
+		}
+
 		req, ok := requests[relayKey]
 		if !ok {
 			req = &relayRequest{
@@ -98,82 +184,272 @@ func (n *Node) DownloadBundles(
 		// Chunks from one blob are requested to the same relay
 		req.chunkRequests = append(req.chunkRequests, &relay.ChunkRequestByIndex{
 			BlobKey: blobKey,
-			Indices: assgn.Indices,
-		})
-		req.metadata = append(req.metadata, &requestMetadata{
-			blobShardIndex: i,
-			assignment:     assgn,
+			Indices: metadata.assignment.Indices,
 		})
+		req.metadata = append(req.metadata, metadata)
+	}
+	return requests, nil
+}
 
+// attemptTimeout splits the time left until the deadline of ctx evenly among the remaining attempts, i.e. the relays
+// not yet tried plus the download from other validators. No attempt is given more than ChunkDownloadTimeout.
+func (n *Node) attemptTimeout(ctx context.Context, pending []*requestMetadata, attempt int) time.Duration {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return n.Config.ChunkDownloadTimeout
 	}
 
-	probe.SetStage("download")
+	remainingAttempts := 0
+	for _, metadata := range pending {
+		remainingAttempts = max(remainingAttempts, len(metadata.relayKeys)-attempt)
+	}
+	if n.PeerBundleFetcher != nil {
+		remainingAttempts++
+	}
+	return min(n.Config.ChunkDownloadTimeout, time.Until(deadline)/time.Duration(max(remainingAttempts, 1)))
+}
 
-	bundleChan := make(chan response, len(requests))
+// downloadFromRelays sends the requests to the relays in parallel, and stores and validates the bundles that are
+// downloaded. It returns the last error encountered, if any. Bundles that fail to download or to validate are left
+// without the downloaded flag, and the relays that served them are reported as failing.
+func (n *Node) downloadFromRelays(
+	ctx context.Context,
+	relayClient relay.RelayClient,
+	requests map[corev2.RelayKey]*relayRequest,
+	validation *bundleValidation,
+	blobShards []*corev2.BlobShard,
+	rawBundles []*RawBundle,
+) error {
+	errChan := make(chan error, len(requests))
 	for relayKey := range requests {
 		relayKey := relayKey
 		req := requests[relayKey]
 		n.DownloadPool.Submit(func() {
-			ctxTimeout, cancel := context.WithTimeout(ctx, n.Config.ChunkDownloadTimeout)
-			defer cancel()
-			bundles, err := relayClient.GetChunksByIndex(ctxTimeout, relayKey, req.chunkRequests)
+			start := time.Now()
+			err := n.downloadFromRelay(ctx, relayClient, relayKey, req, blobShards, rawBundles)
 			if err != nil {
-				n.Logger.Errorf("failed to get chunks from relays: %v", err)
-				bundleChan <- response{
-					metadata: nil,
-					bundles:  nil,
-					err:      err,
-				}
-				return
+				err = fmt.Errorf("failed to get chunks from relay %d: %w", relayKey, err)
 			}
-			bundleChan <- response{
-				metadata: req.metadata,
-				bundles:  bundles,
-				err:      nil,
+
+			// Bundles streamed before a failure are kept, as long as they are valid
+			validationErr := n.validateBundles(ctx, validation, req.metadata, blobShards, rawBundles)
+			if validationErr != nil {
+				err = fmt.Errorf("invalid bundles from relay %d: %w", relayKey, validationErr)
 			}
+
+			if err != nil {
+				n.RelayHealth.ReportFailure(relayKey)
+			} else {
+				n.RelayHealth.ReportSuccess(relayKey, time.Since(start), len(req.chunkRequests))
+			}
+			errChan <- err
 		})
 	}
 
-	responses := make([]response, len(requests))
+	var lastErr error
 	for i := 0; i < len(requests); i++ {
-		responses[i] = <-bundleChan
+		if err := <-errChan; err != nil {
+			n.Logger.Warn("failed to download bundles from relay", "err", err)
+			lastErr = err
+		}
 	}
 
-	probe.SetStage("deserialize")
+	return lastErr
+}
 
-	var err error
-	for i := 0; i < len(requests); i++ {
-		resp := responses[i]
-		if resp.err != nil {
-			// TODO (cody-littley) this is flaky, and will fail if any relay fails. We should retry failures
-			return nil, nil, fmt.Errorf("failed to get chunks from relays: %v", resp.err)
+// downloadFromRelay downloads the bundles of a request from a single relay, and stores each bundle as it is
+// downloaded.
+func (n *Node) downloadFromRelay(
+	ctx context.Context,
+	relayClient relay.RelayClient,
+	relayKey corev2.RelayKey,
+	req *relayRequest,
+	blobShards []*corev2.BlobShard,
+	rawBundles []*RawBundle,
+) error {
+	if n.Config.EnableRelayChunkStreaming {
+		err := n.streamBundles(ctx, relayClient, relayKey, req, blobShards, rawBundles)
+		if status.Code(err) != codes.Unimplemented {
+			return err
 		}
+		n.Logger.Debug("relay does not support chunk streaming, falling back to GetChunks",
+			"relayKey", relayKey)
+	}
 
-		if len(resp.bundles) != len(resp.metadata) {
-			return nil, nil, fmt.Errorf("number of bundles and metadata do not match (%d != %d)",
-				len(resp.bundles), len(resp.metadata))
+	bundles, err := relayClient.GetChunksByIndex(ctx, relayKey, req.chunkRequests)
+	if err != nil {
+		return err
+	}
+	if len(bundles) != len(req.metadata) {
+		return fmt.Errorf("number of bundles and metadata do not match (%d != %d)", len(bundles), len(req.metadata))
+	}
+	for i, bundle := range bundles {
+		err = storeBundle(req.metadata[i], bundle, blobShards, rawBundles)
+		if err != nil {
+			return err
 		}
+	}
+	return nil
+}
 
-		for j, bundle := range resp.bundles {
-			metadata := resp.metadata[j]
-			blobShards[metadata.blobShardIndex].Bundle, err = new(core.Bundle).Deserialize(bundle)
+// downloadFromPeers rebuilds the pending bundles from the chunks of other validators in parallel. It returns the last
+// error encountered, if any.
+func (n *Node) downloadFromPeers(
+	ctx context.Context,
+	batch *corev2.Batch,
+	pending []*requestMetadata,
+	validation *bundleValidation,
+	blobShards []*corev2.BlobShard,
+	rawBundles []*RawBundle,
+) error {
+	errChan := make(chan error, len(pending))
+	for _, metadata := range pending {
+		metadata := metadata
+		n.DownloadPool.Submit(func() {
+			bundle, err := n.PeerBundleFetcher.FetchBundle(
+				ctx,
+				batch.BlobCertificates[metadata.blobShardIndex],
+				metadata.blobParams,
+				batch.BatchHeader.ReferenceBlockNumber,
+				metadata.assignment)
+			if err != nil {
+				errChan <- fmt.Errorf("failed to get chunks from validators: %w", err)
+				return
+			}
+			err = storeBundle(metadata, bundle, blobShards, rawBundles)
 			if err != nil {
-				return nil, nil, fmt.Errorf("failed to deserialize bundle: %v", err)
+				errChan <- err
+				return
 			}
-			rawBundles[metadata.blobShardIndex].Bundle = bundle
+			errChan <- n.validateBundles(ctx, validation, []*requestMetadata{metadata}, blobShards, rawBundles)
+		})
+	}
 
+	var lastErr error
+	for i := 0; i < len(pending); i++ {
+		if err := <-errChan; err != nil {
+			n.Logger.Warn("failed to download bundle from validators", "err", err)
+			lastErr = err
 		}
 	}
+	return lastErr
+}
 
-	return blobShards, rawBundles, nil
+// filterPending returns the bundles that have not been downloaded yet.
+func filterPending(pending []*requestMetadata) []*requestMetadata {
+	remaining := make([]*requestMetadata, 0, len(pending))
+	for _, metadata := range pending {
+		if !metadata.downloaded {
+			remaining = append(remaining, metadata)
+		}
+	}
+	return remaining
 }
 
-func (n *Node) ValidateBatchV2(
+// streamBundles downloads the bundles for a relay request with the streaming API, deserializing each bundle into
+// blobShards and rawBundles as soon as it arrives.
+func (n *Node) streamBundles(
 	ctx context.Context,
-	batch *corev2.Batch,
+	relayClient relay.RelayClient,
+	relayKey corev2.RelayKey,
+	req *relayRequest,
 	blobShards []*corev2.BlobShard,
-	operatorState *core.OperatorState,
+	rawBundles []*RawBundle,
+) error {
+	return relayClient.StreamChunksByIndex(ctx, relayKey, req.chunkRequests,
+		func(requestIndex int, bundle []byte) error {
+			if requestIndex >= len(req.metadata) {
+				return fmt.Errorf("request index %d out of range (%d requests)", requestIndex, len(req.metadata))
+			}
+			return storeBundle(req.metadata[requestIndex], bundle, blobShards, rawBundles)
+		})
+}
+
+// bundleValidation holds what is needed to validate the bundles downloaded for a batch.
+type bundleValidation struct {
+	blobVersionParams *corev2.BlobVersionParameterMap
+	operatorState     *core.OperatorState
+	pool              *workerpool.WorkerPool
+}
+
+// validateBundles validates the downloaded bundles among the given ones against their blob commitments. The bundles
+// are first validated together, which is cheaper than validating them one by one. If that fails, each bundle is
+// validated on its own, so that a single invalid bundle does not cause the valid ones to be downloaded again. Bundles
+// that fail validation are discarded and left without the downloaded flag, so that only their blobs are retried.
+func (n *Node) validateBundles(
+	ctx context.Context,
+	validation *bundleValidation,
+	metadata []*requestMetadata,
+	blobShards []*corev2.BlobShard,
+	rawBundles []*RawBundle,
+) error {
+	downloaded := make([]*requestMetadata, 0, len(metadata))
+	shards := make([]*corev2.BlobShard, 0, len(metadata))
+	for _, m := range metadata {
+		if m.downloaded {
+			downloaded = append(downloaded, m)
+			shards = append(shards, blobShards[m.blobShardIndex])
+		}
+	}
+	if len(shards) == 0 {
+		return nil
+	}
+
+	err := n.ValidatorV2.ValidateBlobs(
+		ctx, shards, validation.blobVersionParams, validation.pool, validation.operatorState)
+	if err == nil {
+		return nil
+	}
+	if len(shards) == 1 {
+		discardBundle(downloaded[0], blobShards, rawBundles)
+		return err
+	}
+
+	var lastErr error
+	invalid := 0
+	for i, m := range downloaded {
+		err = n.ValidatorV2.ValidateBlobs(
+			ctx, shards[i:i+1], validation.blobVersionParams, validation.pool, validation.operatorState)
+		if err != nil {
+			discardBundle(m, blobShards, rawBundles)
+			lastErr = err
+			invalid++
+		}
+	}
+	if invalid == 0 {
+		// The bundles are valid on their own, so the failure of the combined validation was not caused by any of them
+		return nil
+	}
+	return fmt.Errorf("%d of %d bundles are invalid: %w", invalid, len(downloaded), lastErr)
+}
+
+// discardBundle drops a downloaded bundle that failed validation, so that it is downloaded again.
+func discardBundle(metadata *requestMetadata, blobShards []*corev2.BlobShard, rawBundles []*RawBundle) {
+	metadata.downloaded = false
+	blobShards[metadata.blobShardIndex].Bundle = nil
+	rawBundles[metadata.blobShardIndex].Bundle = nil
+}
+
+// storeBundle deserializes a downloaded bundle and stores it at the position given by the metadata.
+func storeBundle(
+	metadata *requestMetadata,
+	bundle []byte,
+	blobShards []*corev2.BlobShard,
+	rawBundles []*RawBundle,
 ) error {
+	var err error
+	blobShards[metadata.blobShardIndex].Bundle, err = new(core.Bundle).Deserialize(bundle)
+	if err != nil {
+		return fmt.Errorf("failed to deserialize bundle: %v", err)
+	}
+	rawBundles[metadata.blobShardIndex].Bundle = bundle
+	metadata.downloaded = true
+	return nil
+}
+
+// ValidateBatchV2 validates the batch header against the blob certificates of the batch. The bundles of the blobs are
+// validated as they are downloaded, see DownloadBundles.
+func (n *Node) ValidateBatchV2(ctx context.Context, batch *corev2.Batch) error {
 	if n.ValidatorV2 == nil {
 		return fmt.Errorf("store v2 is not set")
 	}
@@ -181,7 +457,5 @@ func (n *Node) ValidateBatchV2(
 	if err := n.ValidatorV2.ValidateBatchHeader(ctx, batch.BatchHeader, batch.BlobCertificates); err != nil {
 		return fmt.Errorf("failed to validate batch header: %v", err)
 	}
-	pool := workerpool.New(n.Config.NumBatchValidators)
-	blobVersionParams := n.BlobVersionParams.Load()
-	return n.ValidatorV2.ValidateBlobs(ctx, blobShards, blobVersionParams, pool, operatorState)
+	return nil
 }
@@ -1,21 +1,27 @@
 package node_test
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/api/clients/v2/payloadretrieval/test"
 	"github.com/Layr-Labs/eigenda/api/clients/v2/relay"
+	"github.com/Layr-Labs/eigenda/common"
 	"github.com/docker/go-units"
 
 	"github.com/Layr-Labs/eigenda/core"
 	v2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/node"
 	nodemock "github.com/Layr-Labs/eigenda/node/mock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestDownloadBundles(t *testing.T) {
@@ -58,6 +64,59 @@ func TestDownloadBundles(t *testing.T) {
 	require.Equal(t, blobCerts[2], rawBundles[2].BlobCertificate)
 }
 
+func TestDownloadBundlesStreaming(t *testing.T) {
+	c := newComponents(t, op0)
+	c.node.Config.EnableRelayChunkStreaming = true
+	c.node.RelayClient.Store(c.relayClient)
+	ctx := context.Background()
+	blobKeys, batch, bundles := nodemock.MockBatch(t)
+	blobCerts := batch.BlobCertificates
+
+	bundles00Bytes, err := bundles[0][0].Serialize()
+	require.NoError(t, err)
+	bundles10Bytes, err := bundles[1][0].Serialize()
+	require.NoError(t, err)
+	bundles20Bytes, err := bundles[2][0].Serialize()
+	require.NoError(t, err)
+
+	c.relayClient.On("StreamChunksByIndex", mock.Anything, v2.RelayKey(0), mock.Anything).Return([][]byte{bundles00Bytes, bundles20Bytes}, nil).Run(func(args mock.Arguments) {
+		requests := args.Get(2).([]*relay.ChunkRequestByIndex)
+		require.Len(t, requests, 2)
+		require.Equal(t, blobKeys[0], requests[0].BlobKey)
+		require.Equal(t, blobKeys[2], requests[1].BlobKey)
+	})
+	// Relay 1 does not support streaming, so the node should fall back to GetChunks
+	c.relayClient.On("StreamChunksByIndex", mock.Anything, v2.RelayKey(1), mock.Anything).Return(nil, status.Error(codes.Unimplemented, "not implemented"))
+	c.relayClient.On("GetChunksByIndex", mock.Anything, v2.RelayKey(1), mock.Anything).Return([][]byte{bundles10Bytes}, nil).Run(func(args mock.Arguments) {
+		requests := args.Get(2).([]*relay.ChunkRequestByIndex)
+		require.Len(t, requests, 1)
+		require.Equal(t, blobKeys[1], requests[0].BlobKey)
+	})
+	state, err := c.node.ChainState.GetOperatorStateByOperator(ctx, uint(10), op0)
+	require.NoError(t, err)
+	blobShards, rawBundles, err := c.node.DownloadBundles(ctx, batch, state, nil)
+	require.NoError(t, err)
+	require.Len(t, blobShards, 3)
+	require.Len(t, rawBundles, 3)
+	for i, bundleBytes := range [][]byte{bundles00Bytes, bundles10Bytes, bundles20Bytes} {
+		require.Equal(t, blobCerts[i], blobShards[i].BlobCertificate)
+		require.Equal(t, blobCerts[i], rawBundles[i].BlobCertificate)
+		require.Equal(t, bundleBytes, rawBundles[i].Bundle)
+		require.Len(t, blobShards[i].Bundle, len(bundles[i][0]))
+	}
+	c.relayClient.AssertNotCalled(t, "GetChunksByIndex", mock.Anything, v2.RelayKey(0), mock.Anything)
+
+	// Other streaming errors are not retried with GetChunks
+	c = newComponents(t, op0)
+	c.node.Config.EnableRelayChunkStreaming = true
+	c.node.RelayClient.Store(c.relayClient)
+	c.relayClient.On("StreamChunksByIndex", mock.Anything, v2.RelayKey(0), mock.Anything).Return([][]byte{bundles00Bytes, bundles20Bytes}, nil)
+	c.relayClient.On("StreamChunksByIndex", mock.Anything, v2.RelayKey(1), mock.Anything).Return(nil, fmt.Errorf("relay server error"))
+	_, _, err = c.node.DownloadBundles(ctx, batch, state, nil)
+	require.Error(t, err)
+	c.relayClient.AssertNotCalled(t, "GetChunksByIndex", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestDownloadBundlesFail(t *testing.T) {
 	c := newComponents(t, op0)
 	c.node.RelayClient.Store(c.relayClient)
@@ -246,3 +305,373 @@ func TestRefreshOnchainStateSuccess(t *testing.T) {
 	quorumCount := c.node.QuorumCount.Load()
 	require.Equal(t, quorumCount, uint32(2))
 }
+
+// selectiveRelayClient serves the bundles of the blobs it holds, except that each failing relay serves a limited
+// number of bundles before failing the request.
+type selectiveRelayClient struct {
+	relay.RelayClient
+
+	mu      sync.Mutex
+	bundles map[v2.BlobKey][]byte
+	// failAfter is the number of bundles each failing relay serves per request before failing
+	failAfter map[v2.RelayKey]int
+	// invalidBundles are the bundles served by a relay in place of the bundles it holds
+	invalidBundles map[v2.RelayKey]map[v2.BlobKey][]byte
+	// requests are the blob keys requested from each relay, per request
+	requests map[v2.RelayKey][][]v2.BlobKey
+}
+
+func newSelectiveRelayClient(bundles map[v2.BlobKey][]byte, failAfter map[v2.RelayKey]int) *selectiveRelayClient {
+	return &selectiveRelayClient{
+		bundles:   bundles,
+		failAfter: failAfter,
+		requests:  make(map[v2.RelayKey][][]v2.BlobKey),
+	}
+}
+
+func (c *selectiveRelayClient) recordRequest(relayKey v2.RelayKey, requests []*relay.ChunkRequestByIndex) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	blobKeys := make([]v2.BlobKey, len(requests))
+	for i, request := range requests {
+		blobKeys[i] = request.BlobKey
+	}
+	c.requests[relayKey] = append(c.requests[relayKey], blobKeys)
+}
+
+func (c *selectiveRelayClient) getBundle(relayKey v2.RelayKey, blobKey v2.BlobKey) []byte {
+	if bundle, ok := c.invalidBundles[relayKey][blobKey]; ok {
+		return bundle
+	}
+	return c.bundles[blobKey]
+}
+
+func (c *selectiveRelayClient) getRequests(relayKey v2.RelayKey) [][]v2.BlobKey {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.requests[relayKey]
+}
+
+func (c *selectiveRelayClient) GetChunksByIndex(
+	_ context.Context,
+	relayKey v2.RelayKey,
+	requests []*relay.ChunkRequestByIndex,
+) ([][]byte, error) {
+	c.recordRequest(relayKey, requests)
+	if _, ok := c.failAfter[relayKey]; ok {
+		return nil, fmt.Errorf("relay %d is failing", relayKey)
+	}
+	bundles := make([][]byte, len(requests))
+	for i, request := range requests {
+		bundles[i] = c.getBundle(relayKey, request.BlobKey)
+	}
+	return bundles, nil
+}
+
+func (c *selectiveRelayClient) StreamChunksByIndex(
+	_ context.Context,
+	relayKey v2.RelayKey,
+	requests []*relay.ChunkRequestByIndex,
+	handler relay.ChunkBundleHandler,
+) error {
+	c.recordRequest(relayKey, requests)
+	for i, request := range requests {
+		if limit, ok := c.failAfter[relayKey]; ok && i >= limit {
+			return fmt.Errorf("relay %d is failing", relayKey)
+		}
+		if err := handler(i, c.getBundle(relayKey, request.BlobKey)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bundleCheckingValidator accepts the bundles that are identical to the bundles of their blob, and rejects the rest
+type bundleCheckingValidator struct {
+	v2.ShardValidator
+
+	bundles map[v2.BlobKey][]byte
+}
+
+func (v *bundleCheckingValidator) ValidateBlobs(
+	_ context.Context,
+	blobs []*v2.BlobShard,
+	_ *v2.BlobVersionParameterMap,
+	_ common.WorkerPool,
+	_ *core.OperatorState,
+) error {
+	for _, blob := range blobs {
+		blobKey, err := blob.BlobHeader.BlobKey()
+		if err != nil {
+			return err
+		}
+		bundle, err := blob.Bundle.Serialize()
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(bundle, v.bundles[blobKey]) {
+			return fmt.Errorf("invalid bundle for blob %s", blobKey.Hex())
+		}
+	}
+	return nil
+}
+
+// fakePeerBundleFetcher serves the bundles of the blobs it holds
+type fakePeerBundleFetcher struct {
+	mu      sync.Mutex
+	bundles map[v2.BlobKey][]byte
+	fetched []v2.BlobKey
+}
+
+func (f *fakePeerBundleFetcher) FetchBundle(
+	_ context.Context,
+	blobCert *v2.BlobCertificate,
+	_ *core.BlobVersionParameters,
+	_ uint64,
+	_ v2.Assignment,
+) ([]byte, error) {
+	blobKey, err := blobCert.BlobHeader.BlobKey()
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	bundle, ok := f.bundles[blobKey]
+	if !ok {
+		return nil, fmt.Errorf("blob %s not found", blobKey.Hex())
+	}
+	f.fetched = append(f.fetched, blobKey)
+	return bundle, nil
+}
+
+// mockBatchWithRelays returns the mock batch with each blob held by all of the given relays, along with the serialized
+// bundle of each blob
+func mockBatchWithRelays(t *testing.T, relayKeys ...v2.RelayKey) ([]v2.BlobKey, *v2.Batch, map[v2.BlobKey][]byte) {
+	blobKeys, batch, bundles := nodemock.MockBatch(t)
+	serializedBundles := make(map[v2.BlobKey][]byte)
+	for i, cert := range batch.BlobCertificates {
+		cert.RelayKeys = relayKeys
+		bundleBytes, err := bundles[i][0].Serialize()
+		require.NoError(t, err)
+		serializedBundles[blobKeys[i]] = bundleBytes
+	}
+	return blobKeys, batch, serializedBundles
+}
+
+func TestDownloadBundlesRelayFailover(t *testing.T) {
+	c := newComponents(t, op0)
+	c.node.Config.ChunkDownloadTimeout = 10 * time.Second
+	c.node.RelayHealth = node.NewRelayHealthTracker()
+	ctx := context.Background()
+	blobKeys, batch, bundles := mockBatchWithRelays(t, 0, 1, 2)
+
+	// Relays 0 and 1 fail every request, so every blob is eventually downloaded from relay 2
+	relayClient := newSelectiveRelayClient(bundles, map[v2.RelayKey]int{0: 0, 1: 0})
+	c.node.RelayClient.Store(relayClient)
+
+	state, err := c.node.ChainState.GetOperatorStateByOperator(ctx, uint(10), op0)
+	require.NoError(t, err)
+	blobShards, rawBundles, err := c.node.DownloadBundles(ctx, batch, state, nil)
+	require.NoError(t, err)
+	require.Len(t, blobShards, 3)
+	for i, blobKey := range blobKeys {
+		require.Equal(t, batch.BlobCertificates[i], blobShards[i].BlobCertificate)
+		require.Equal(t, bundles[blobKey], rawBundles[i].Bundle)
+		require.NotNil(t, blobShards[i].Bundle)
+	}
+
+	// Each blob is requested from each relay at most once
+	for _, relayKey := range []v2.RelayKey{0, 1, 2} {
+		requested := make(map[v2.BlobKey]int)
+		for _, request := range relayClient.getRequests(relayKey) {
+			for _, blobKey := range request {
+				requested[blobKey]++
+			}
+		}
+		for blobKey, count := range requested {
+			require.Equal(t, 1, count, "blob %s requested %d times from relay %d", blobKey.Hex(), count, relayKey)
+		}
+	}
+
+	// Once relay 2 has proven healthy, it is preferred to the failing relays
+	for i := 0; i < 10; i++ {
+		_, _, err = c.node.DownloadBundles(ctx, batch, state, nil)
+		require.NoError(t, err)
+	}
+	relayClient.mu.Lock()
+	relayClient.requests = make(map[v2.RelayKey][][]v2.BlobKey)
+	relayClient.mu.Unlock()
+	_, _, err = c.node.DownloadBundles(ctx, batch, state, nil)
+	require.NoError(t, err)
+	requested := 0
+	for _, request := range relayClient.getRequests(0) {
+		requested += len(request)
+	}
+	for _, request := range relayClient.getRequests(1) {
+		requested += len(request)
+	}
+	require.Less(t, requested, len(blobKeys))
+}
+
+func TestDownloadBundlesPartialRetry(t *testing.T) {
+	c := newComponents(t, op0)
+	c.node.Config.ChunkDownloadTimeout = 10 * time.Second
+	c.node.Config.EnableRelayChunkStreaming = true
+	ctx := context.Background()
+	blobKeys, batch, bundles := mockBatchWithRelays(t, 0, 1)
+	batch.BlobCertificates[1].RelayKeys = []v2.RelayKey{1}
+
+	state, err := c.node.ChainState.GetOperatorStateByOperator(ctx, uint(10), op0)
+	require.NoError(t, err)
+
+	// Relay 0 fails after streaming the first bundle of each request. The relays are tried in a random order, so
+	// repeat the download to cover both orders.
+	for i := 0; i < 10; i++ {
+		relayClient := newSelectiveRelayClient(bundles, map[v2.RelayKey]int{0: 1})
+		c.node.RelayClient.Store(relayClient)
+
+		_, rawBundles, err := c.node.DownloadBundles(ctx, batch, state, nil)
+		require.NoError(t, err)
+		for i, blobKey := range blobKeys {
+			require.Equal(t, bundles[blobKey], rawBundles[i].Bundle)
+		}
+
+		relay0Requests := relayClient.getRequests(0)
+		require.LessOrEqual(t, len(relay0Requests), 1)
+		relay1BlobKeys := make([]v2.BlobKey, 0)
+		for _, request := range relayClient.getRequests(1) {
+			relay1BlobKeys = append(relay1BlobKeys, request...)
+		}
+		require.Contains(t, relay1BlobKeys, blobKeys[1])
+		servedByRelay0 := 0
+		if len(relay0Requests) == 1 {
+			// Only the bundles relay 0 failed to serve are retried on relay 1
+			servedByRelay0 = 1
+			require.NotContains(t, relay1BlobKeys, relay0Requests[0][0])
+			for _, blobKey := range relay0Requests[0][1:] {
+				require.Contains(t, relay1BlobKeys, blobKey)
+			}
+		}
+		require.Len(t, relay1BlobKeys, len(blobKeys)-servedByRelay0)
+	}
+}
+
+func TestDownloadBundlesFromPeers(t *testing.T) {
+	c := newComponents(t, op0)
+	c.node.Config.ChunkDownloadTimeout = 10 * time.Second
+	ctx := context.Background()
+	blobKeys, batch, bundles := mockBatchWithRelays(t, 1)
+
+	// Blob 1 is only held by relay 0, which is failing, so it can't be downloaded from any relay
+	batch.BlobCertificates[1].RelayKeys = []v2.RelayKey{0}
+	relayClient := newSelectiveRelayClient(bundles, map[v2.RelayKey]int{0: 0})
+	c.node.RelayClient.Store(relayClient)
+
+	state, err := c.node.ChainState.GetOperatorStateByOperator(ctx, uint(10), op0)
+	require.NoError(t, err)
+
+	// Without a peer fetcher, the download fails
+	_, _, err = c.node.DownloadBundles(ctx, batch, state, nil)
+	require.Error(t, err)
+
+	// With a peer fetcher, only the missing bundle is rebuilt from the other validators
+	peerFetcher := &fakePeerBundleFetcher{bundles: bundles}
+	c.node.PeerBundleFetcher = peerFetcher
+	_, rawBundles, err := c.node.DownloadBundles(ctx, batch, state, nil)
+	require.NoError(t, err)
+	for i, blobKey := range blobKeys {
+		require.Equal(t, bundles[blobKey], rawBundles[i].Bundle)
+	}
+	require.Equal(t, []v2.BlobKey{blobKeys[1]}, peerFetcher.fetched)
+}
+
+func TestDownloadBundlesInvalidBundles(t *testing.T) {
+	c := newComponents(t, op0)
+	c.node.Config.ChunkDownloadTimeout = 10 * time.Second
+	c.node.RelayHealth = node.NewRelayHealthTracker()
+	ctx := context.Background()
+	blobKeys, batch, bundles := mockBatchWithRelays(t, 0, 1)
+	c.node.ValidatorV2 = &bundleCheckingValidator{bundles: bundles}
+
+	// Relay 0 serves the bundle of another blob for every blob, which deserializes but fails validation
+	invalidBundles := make(map[v2.BlobKey][]byte)
+	for i, blobKey := range blobKeys {
+		invalidBundles[blobKey] = bundles[blobKeys[(i+1)%len(blobKeys)]]
+	}
+	relayClient := newSelectiveRelayClient(bundles, nil)
+	relayClient.invalidBundles = map[v2.RelayKey]map[v2.BlobKey][]byte{0: invalidBundles}
+	c.node.RelayClient.Store(relayClient)
+
+	state, err := c.node.ChainState.GetOperatorStateByOperator(ctx, uint(10), op0)
+	require.NoError(t, err)
+
+	// The bundles from relay 0 are rejected, and downloaded again from relay 1
+	for i := 0; i < 10; i++ {
+		_, rawBundles, err := c.node.DownloadBundles(ctx, batch, state, nil)
+		require.NoError(t, err)
+		for i, blobKey := range blobKeys {
+			require.Equal(t, bundles[blobKey], rawBundles[i].Bundle)
+		}
+	}
+
+	// Relay 0 is reported as failing, so relay 1 is preferred
+	relayClient.mu.Lock()
+	relayClient.requests = make(map[v2.RelayKey][][]v2.BlobKey)
+	relayClient.mu.Unlock()
+	_, _, err = c.node.DownloadBundles(ctx, batch, state, nil)
+	require.NoError(t, err)
+	requested := 0
+	for _, request := range relayClient.getRequests(0) {
+		requested += len(request)
+	}
+	require.Less(t, requested, len(blobKeys))
+
+	// Without another relay, the download fails
+	for _, cert := range batch.BlobCertificates {
+		cert.RelayKeys = []v2.RelayKey{0}
+	}
+	_, _, err = c.node.DownloadBundles(ctx, batch, state, nil)
+	require.Error(t, err)
+}
+
+func TestDownloadBundlesRetriesOnlyInvalidBundles(t *testing.T) {
+	c := newComponents(t, op0)
+	c.node.Config.ChunkDownloadTimeout = 10 * time.Second
+	ctx := context.Background()
+	blobKeys, batch, bundles := mockBatchWithRelays(t, 0, 1)
+	c.node.ValidatorV2 = &bundleCheckingValidator{bundles: bundles}
+
+	state, err := c.node.ChainState.GetOperatorStateByOperator(ctx, uint(10), op0)
+	require.NoError(t, err)
+
+	// Relay 0 serves an invalid bundle for blob 1 only. The relays are tried in a random order, so repeat the download
+	// to cover both orders.
+	for i := 0; i < 10; i++ {
+		relayClient := newSelectiveRelayClient(bundles, nil)
+		relayClient.invalidBundles = map[v2.RelayKey]map[v2.BlobKey][]byte{
+			0: {blobKeys[1]: bundles[blobKeys[2]]},
+		}
+		c.node.RelayClient.Store(relayClient)
+
+		_, rawBundles, err := c.node.DownloadBundles(ctx, batch, state, nil)
+		require.NoError(t, err)
+		for i, blobKey := range blobKeys {
+			require.Equal(t, bundles[blobKey], rawBundles[i].Bundle)
+		}
+
+		// The valid bundles served by relay 0 are kept, and only the invalid one is downloaded again from relay 1
+		relay1BlobKeys := make(map[v2.BlobKey]struct{})
+		for _, request := range relayClient.getRequests(1) {
+			for _, blobKey := range request {
+				relay1BlobKeys[blobKey] = struct{}{}
+			}
+		}
+		for _, request := range relayClient.getRequests(0) {
+			for _, blobKey := range request {
+				_, retried := relay1BlobKeys[blobKey]
+				require.Equal(t, blobKey == blobKeys[1], retried, "blob %s", blobKey.Hex())
+			}
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package node
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/validator"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+)
+
+// PeerBundleFetcher rebuilds the bundle of chunks assigned to this validator from the chunks held by other
+// validators. It is used to acquire chunks that can't be downloaded from any relay.
+type PeerBundleFetcher interface {
+	// FetchBundle returns the serialized bundle of the chunks of the blob at the indices of the assignment.
+	FetchBundle(
+		ctx context.Context,
+		blobCert *corev2.BlobCertificate,
+		blobParams *core.BlobVersionParameters,
+		referenceBlockNumber uint64,
+		assignment corev2.Assignment,
+	) ([]byte, error)
+}
+
+// peerBundleFetcher downloads enough chunks from other validators to reconstruct the blob, and re-encodes the blob to
+// derive the chunks and proofs at this validator's indices. Chunks are different at every validator, so the chunks
+// of this validator can't be copied from another validator directly.
+type peerBundleFetcher struct {
+	validatorClient validator.ValidatorClient
+	prover          encoding.Prover
+}
+
+var _ PeerBundleFetcher = (*peerBundleFetcher)(nil)
+
+// NewPeerBundleFetcher creates a PeerBundleFetcher that reconstructs blobs with the validator client and derives
+// chunks with the prover.
+func NewPeerBundleFetcher(validatorClient validator.ValidatorClient, prover encoding.Prover) PeerBundleFetcher {
+	return &peerBundleFetcher{
+		validatorClient: validatorClient,
+		prover:          prover,
+	}
+}
+
+func (f *peerBundleFetcher) FetchBundle(
+	ctx context.Context,
+	blobCert *corev2.BlobCertificate,
+	blobParams *core.BlobVersionParameters,
+	referenceBlockNumber uint64,
+	assignment corev2.Assignment,
+) ([]byte, error) {
+	blobHeader, err := blobCert.BlobHeader.GetBlobHeaderWithHashedPayment()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob header with hashed payment: %w", err)
+	}
+
+	// The chunks downloaded from the validators are verified against the blob commitments before the blob is decoded
+	blob, err := f.validatorClient.GetBlob(ctx, blobHeader, referenceBlockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconstruct blob from validators: %w", err)
+	}
+
+	encodingParams, err := corev2.GetEncodingParams(blobCert.BlobHeader.BlobCommitments.Length, blobParams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get encoding params: %w", err)
+	}
+
+	frames, err := f.prover.GetFrames(blob, encodingParams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode blob: %w", err)
+	}
+
+	bundle := make(core.Bundle, len(assignment.Indices))
+	for i, index := range assignment.Indices {
+		if int(index) >= len(frames) {
+			return nil, fmt.Errorf("assigned chunk index %d out of range (%d chunks)", index, len(frames))
+		}
+		bundle[i] = frames[index]
+	}
+
+	return bundle.Serialize()
+}
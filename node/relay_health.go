@@ -0,0 +1,143 @@
+package node
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+)
+
+const (
+	// relayHealthDecay is the weight given to the latest observation in the moving averages of a relay's latency
+	// and error rate
+	relayHealthDecay = 0.2
+	// defaultRelayLatency is the latency assumed for relays that have not served any request yet
+	defaultRelayLatency = 100 * time.Millisecond
+	// minRelayLatency bounds the latency used to weigh a relay, so that a handful of very fast responses can't make
+	// the other relays negligible
+	minRelayLatency = time.Millisecond
+	// minRelaySuccessRate bounds the success rate used to weigh a relay, so that a relay that has been failing is
+	// still tried from time to time and can recover
+	minRelaySuccessRate = 0.01
+)
+
+// relayHealth is the moving average of the latency and error rate of the requests to a relay
+type relayHealth struct {
+	// latency is the average latency of successful requests, per blob requested
+	latency time.Duration
+	// errorRate is the average of 1 for failed requests and 0 for successful requests
+	errorRate float64
+}
+
+// RelayHealthTracker keeps track of the latency and errors of the requests made to each relay, and decides which
+// relays chunks are downloaded from. A nil RelayHealthTracker is valid, and orders relays at random.
+//
+// RelayHealthTracker is safe to use concurrently.
+type RelayHealthTracker struct {
+	mu     sync.Mutex
+	relays map[corev2.RelayKey]*relayHealth
+}
+
+// NewRelayHealthTracker creates a RelayHealthTracker with no history.
+func NewRelayHealthTracker() *RelayHealthTracker {
+	return &RelayHealthTracker{
+		relays: make(map[corev2.RelayKey]*relayHealth),
+	}
+}
+
+// ReportSuccess records a successful request to a relay for numBlobs blobs, which took the given latency.
+func (t *RelayHealthTracker) ReportSuccess(relayKey corev2.RelayKey, latency time.Duration, numBlobs int) {
+	if t == nil {
+		return
+	}
+	if numBlobs > 1 {
+		latency /= time.Duration(numBlobs)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	health, ok := t.relays[relayKey]
+	if !ok {
+		t.relays[relayKey] = &relayHealth{latency: latency}
+		return
+	}
+	health.latency = time.Duration((1-relayHealthDecay)*float64(health.latency) + relayHealthDecay*float64(latency))
+	health.errorRate = (1 - relayHealthDecay) * health.errorRate
+}
+
+// ReportFailure records a failed request to a relay.
+func (t *RelayHealthTracker) ReportFailure(relayKey corev2.RelayKey) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	health, ok := t.relays[relayKey]
+	if !ok {
+		t.relays[relayKey] = &relayHealth{latency: defaultRelayLatency, errorRate: 1}
+		return
+	}
+	health.errorRate = (1-relayHealthDecay)*health.errorRate + relayHealthDecay
+}
+
+// OrderRelays returns the given relays in the order they should be tried. The order is random, with each relay being
+// picked ahead of the others with a probability proportional to its success rate squared and inversely proportional
+// to its latency, so that load is spread across healthy relays while failing and slow relays are mostly tried last.
+func (t *RelayHealthTracker) OrderRelays(relayKeys []corev2.RelayKey) []corev2.RelayKey {
+	ordered := make([]corev2.RelayKey, len(relayKeys))
+	copy(ordered, relayKeys)
+	if t == nil {
+		rand.Shuffle(len(ordered), func(i, j int) {
+			ordered[i], ordered[j] = ordered[j], ordered[i]
+		})
+		return ordered
+	}
+
+	weights := make([]float64, len(ordered))
+	totalWeight := 0.0
+	t.mu.Lock()
+	for i, relayKey := range ordered {
+		weights[i] = t.weight(relayKey)
+		totalWeight += weights[i]
+	}
+	t.mu.Unlock()
+
+	// weighted sampling without replacement
+	for i := 0; i < len(ordered)-1; i++ {
+		target := rand.Float64() * totalWeight
+		picked := len(ordered) - 1
+		for j := i; j < len(ordered); j++ {
+			target -= weights[j]
+			if target < 0 {
+				picked = j
+				break
+			}
+		}
+		totalWeight -= weights[picked]
+		ordered[i], ordered[picked] = ordered[picked], ordered[i]
+		weights[i], weights[picked] = weights[picked], weights[i]
+	}
+
+	return ordered
+}
+
+// weight returns the relative likelihood of a relay being picked. The caller must hold the lock.
+func (t *RelayHealthTracker) weight(relayKey corev2.RelayKey) float64 {
+	latency := defaultRelayLatency
+	successRate := 1.0
+	if health, ok := t.relays[relayKey]; ok {
+		latency = health.latency
+		successRate = 1 - health.errorRate
+	}
+	if latency < minRelayLatency {
+		latency = minRelayLatency
+	}
+	successRate = math.Max(successRate, minRelaySuccessRate)
+
+	return successRate * successRate / latency.Seconds()
+}
@@ -0,0 +1,48 @@
+package node_test
+
+import (
+	"testing"
+	"time"
+
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelayHealthTrackerOrderRelays(t *testing.T) {
+	relayKeys := []corev2.RelayKey{0, 1, 2}
+
+	// Without a tracker, relays are shuffled
+	var nilTracker *node.RelayHealthTracker
+	nilTracker.ReportFailure(0)
+	require.ElementsMatch(t, relayKeys, nilTracker.OrderRelays(relayKeys))
+
+	// Relays without history are all tried
+	tracker := node.NewRelayHealthTracker()
+	require.ElementsMatch(t, relayKeys, tracker.OrderRelays(relayKeys))
+
+	// Failing relays are tried last, and slow relays after fast ones
+	for i := 0; i < 10; i++ {
+		tracker.ReportFailure(0)
+		tracker.ReportSuccess(1, time.Second, 1)
+		tracker.ReportSuccess(2, 10*time.Millisecond, 1)
+	}
+	counts := make(map[corev2.RelayKey]int)
+	for i := 0; i < 100; i++ {
+		ordered := tracker.OrderRelays(relayKeys)
+		require.ElementsMatch(t, relayKeys, ordered)
+		counts[ordered[0]]++
+	}
+	require.Greater(t, counts[2], 90)
+	require.Less(t, counts[0], 5)
+
+	// A relay that recovers is tried again
+	for i := 0; i < 30; i++ {
+		tracker.ReportSuccess(0, time.Millisecond, 1)
+	}
+	counts = make(map[corev2.RelayKey]int)
+	for i := 0; i < 100; i++ {
+		counts[tracker.OrderRelays(relayKeys)[0]]++
+	}
+	require.Greater(t, counts[0], 30)
+}
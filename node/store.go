@@ -678,6 +678,32 @@ func DecodeGnarkChunks(data []byte) ([][]byte, error) {
 	return chunks, nil
 }
 
+// DecodeGnarkCompressedChunks splits a bundle in the GnarkCompressedBundleEncodingFormat into its chunks.
+// All chunks have the same size, so the chunks are sliced out of data without copying them.
+func DecodeGnarkCompressedChunks(data []byte) ([][]byte, error) {
+	format, chunkLen, err := parseHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	if format != core.GnarkCompressedBundleEncodingFormat {
+		return nil, errors.New("invalid bundle data encoding format")
+	}
+	if chunkLen == 0 {
+		return nil, errors.New("chunk length must be greater than zero")
+	}
+	chunkSize := encoding.GnarkCompressedChunkSize(int(chunkLen))
+	buf := data[8:]
+	if len(buf)%chunkSize != 0 {
+		return nil, errors.New("invalid data to decode")
+	}
+	chunks := make([][]byte, 0, len(buf)/chunkSize)
+	for len(buf) > 0 {
+		chunks = append(chunks, buf[:chunkSize])
+		buf = buf[chunkSize:]
+	}
+	return chunks, nil
+}
+
 // DecodeChunks((len(chunks[0]), chunks[0], len(chunks[1]), chunks[1], ...)) = chunks
 func DecodeGobChunks(data []byte) ([][]byte, error) {
 	format, chunkLen, err := parseHeader(data)
@@ -746,6 +772,30 @@ func DecodeChunks(data []byte) ([][]byte, node.ChunkEncodingFormat, error) {
 	}
 }
 
+// DecodeBundleChunks converts a bundle stored by the v2 validator store into its constituent chunks, and returns the
+// format of the chunks. Unlike DecodeChunks, it supports the bundle encoding formats that are only used in v2.
+func DecodeBundleChunks(data []byte) ([][]byte, core.ChunkEncodingFormat, error) {
+	// Empty chunk is valid, but there is nothing to decode.
+	if len(data) == 0 {
+		return [][]byte{}, core.GnarkChunkEncodingFormat, nil
+	}
+	format, _, err := parseHeader(data)
+	if err != nil {
+		return nil, core.GnarkChunkEncodingFormat, err
+	}
+
+	switch format {
+	case core.GnarkBundleEncodingFormat:
+		chunks, err := DecodeGnarkChunks(data)
+		return chunks, core.GnarkChunkEncodingFormat, err
+	case core.GnarkCompressedBundleEncodingFormat:
+		chunks, err := DecodeGnarkCompressedChunks(data)
+		return chunks, core.GnarkCompressedChunkEncodingFormat, err
+	default:
+		return nil, core.GnarkChunkEncodingFormat, fmt.Errorf("invalid bundle encoding format: %d", format)
+	}
+}
+
 func copyBytes(src []byte) []byte {
 	dst := make([]byte, len(src))
 	copy(dst, src)
@@ -6,26 +6,39 @@ import (
 	"encoding/binary"
 	"fmt"
 	"strings"
+	"sync/atomic"
 	"time"
 
+	commonpb "github.com/Layr-Labs/eigenda/api/grpc/common/v2"
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/memory"
 	"github.com/Layr-Labs/eigenda/core"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/litt"
 	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/Layr-Labs/eigenda/litt/types"
 	"github.com/Layr-Labs/eigenda/litt/util"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/docker/go-units"
 	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/time/rate"
+	"google.golang.org/protobuf/proto"
 )
 
 const (
 	// The name of the littDB table containing chunk data.
 	chunksTableName = "chunks"
+	// The name of the littDB table containing the records of the blobs whose chunks are stored.
+	blobRecordsTableName = "blob_records"
 	// The metrics prefix for littDB.
 	littDBMetricsPrefix = "node_littdb"
+	// The metrics prefix for the littDB storing blob records.
+	blobRecordsLittDBMetricsPrefix = "node_blob_records_littdb"
+	// The maximum time blob records are kept in memory before being flushed to disk.
+	blobRecordFlushInterval = time.Minute
+	// The minimum time between two seals of the mutable segment of the blob record table. Records are only visible to
+	// repair once their segment is sealed, and sealing on every repair pass would fill the table with tiny segments.
+	blobRecordSealInterval = 10 * time.Minute
 )
 
 // BundleToStore is a struct that holds the bundle key and the bundle bytes.
@@ -34,18 +47,50 @@ type BundleToStore struct {
 	BundleKey []byte
 	// The binary bundle bytes.
 	BundleBytes []byte
+	// The record of the blob of the bundle, used to repair the bundle if it is lost. Not recorded if nil.
+	BlobRecord *BlobRecord
+}
+
+// BlobRecord describes a blob whose bundle is stored by the validator. Blob records are kept in a separate database
+// from the bundles they describe, so that they outlive the loss of the bundles, and are used to find and repair
+// missing bundles.
+type BlobRecord struct {
+	// The certificate of the blob.
+	BlobCertificate *corev2.BlobCertificate
+	// The reference block number of the batch the blob was dispersed in.
+	ReferenceBlockNumber uint64
+	// The time at which the record was stored, which is set by the store. The bundle of the blob expires one TTL
+	// after this time, and is not repaired afterward.
+	StoredAt time.Time
 }
 
 // ValidatorStore encapsulates the database for storing batches of chunk data for the V2 validator node.
 type ValidatorStore interface {
 
-	// StoreBatch stores a batch and its raw bundles in the database. Returns the size of the stored data, in bytes.
+	// StoreBatch stores a batch and its raw bundles in the database, and the records of their blobs. Returns the size
+	// of the stored bundles, in bytes. Failing to store blob records doesn't fail the batch, since it only prevents
+	// the bundles from being repaired if they are lost later. Blob records are not necessarily crash durable when
+	// this method returns: they are flushed at most once per blobRecordFlushInterval, and when the store is stopped.
+	// Blob records are only stored if chunk repair is enabled.
 	StoreBatch(batchData []*BundleToStore) (uint64, error)
 
 	// GetBundleData returns the chunks of a blob with the given bundle key.
 	// The returned chunks are encoded in bundle format.
 	GetBundleData(bundleKey []byte) ([]byte, error)
 
+	// HasBundle returns true if the bundle with the given bundle key is stored. Unlike GetBundleData, it is not
+	// subject to the read rate limits.
+	HasBundle(bundleKey []byte) (bool, error)
+
+	// IterateBlobRecords calls the handler for each blob record whose bundle has not expired, in no particular order.
+	// Records that were stored more than one TTL ago are skipped, since their bundles may already have been deleted,
+	// even if the records themselves are kept until their segment expires. Iteration stops at the first error returned
+	// by the handler. The handler should return quickly, since expired data can't be deleted from disk while records
+	// are being iterated. Records stored since the last seal of the table's mutable segment are skipped. The segment is
+	// sealed at most once per blobRecordSealInterval, so new records are visible within that interval. If chunk repair
+	// is disabled, no records are stored and the handler is never called.
+	IterateBlobRecords(handler func(record *BlobRecord) error) error
+
 	// Stop stops the store.
 	Stop() error
 }
@@ -60,6 +105,19 @@ type validatorStore struct {
 	// The table where chunks are stored in the littDB database.
 	chunkTable litt.Table
 
+	// The littDB database for storing blob records. It is kept apart from the chunk database so that the records
+	// survive the loss of the chunks. nil if chunk repair is disabled.
+	blobRecordDB litt.DB
+
+	// The table where blob records are stored in the blob record database. nil if chunk repair is disabled.
+	blobRecordTable litt.Table
+
+	// The time of the last flush of the blob record table, in nanoseconds since the epoch.
+	lastBlobRecordFlush atomic.Int64
+
+	// The time of the last seal of the mutable segment of the blob record table, in nanoseconds since the epoch.
+	lastBlobRecordSeal atomic.Int64
+
 	// The length of time to store data in the database.
 	ttl time.Duration
 
@@ -128,6 +186,15 @@ func NewValidatorStore(
 		return nil, fmt.Errorf("failed to get chunks table: %w", err)
 	}
 
+	var blobRecordDB litt.DB
+	var blobRecordTable litt.Table
+	if config.EnableChunkRepair {
+		blobRecordDB, blobRecordTable, err = newBlobRecordTable(logger, config, ttl, registry)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	maxMemory, err := memory.GetMaximumAvailableMemory()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get maximum available memory: %w", err)
@@ -195,6 +262,8 @@ func NewValidatorStore(
 		timeSource:           timeSource,
 		littDB:               littDB,
 		chunkTable:           chunkTable,
+		blobRecordDB:         blobRecordDB,
+		blobRecordTable:      blobRecordTable,
 		ttl:                  ttl,
 		duplicateRequestLock: common.NewIndexLock(1024),
 		duplicateRequestSalt: salt,
@@ -205,6 +274,55 @@ func NewValidatorStore(
 	return store, nil
 }
 
+// newBlobRecordTable opens the littDB where blob records are stored, and returns it along with its blob records table.
+func newBlobRecordTable(
+	logger logging.Logger,
+	config *Config,
+	ttl time.Duration,
+	registry *prometheus.Registry) (litt.DB, litt.Table, error) {
+
+	blobRecordsPath := config.LittDBBlobRecordsPath
+	if blobRecordsPath == "" {
+		if config.DbPath == "" {
+			return nil, nil, fmt.Errorf("no path is configured to store blob records")
+		}
+		blobRecordsPath = config.DbPath + "/blob_records_litt"
+	}
+	for _, path := range config.LittDBStoragePaths {
+		if path == blobRecordsPath {
+			return nil, nil, fmt.Errorf("blob records must not be stored at a littDB storage path: %s", path)
+		}
+	}
+	logger.Info("Using littDB for blob records at path " + blobRecordsPath)
+
+	blobRecordConfig, err := litt.DefaultConfig(blobRecordsPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create new litt config for blob records: %w", err)
+	}
+	// Without a registry, littDB serves its metrics on its own port, which is already taken by the chunk database.
+	blobRecordConfig.MetricsEnabled = registry != nil
+	blobRecordConfig.MetricsRegistry = registry
+	blobRecordConfig.MetricsNamespace = blobRecordsLittDBMetricsPrefix
+	blobRecordConfig.Logger = logger
+
+	blobRecordDB, err := littbuilder.NewDB(blobRecordConfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create new litt store for blob records: %w", err)
+	}
+
+	blobRecordTable, err := blobRecordDB.GetTable(blobRecordsTableName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get blob records table: %w", err)
+	}
+
+	err = blobRecordTable.SetTTL(ttl)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to set TTL for blob records table: %w", err)
+	}
+
+	return blobRecordDB, blobRecordTable, nil
+}
+
 func (s *validatorStore) StoreBatch(batchData []*BundleToStore) (uint64, error) {
 	if len(batchData) == 0 {
 		return 0, fmt.Errorf("no batch data")
@@ -265,6 +383,13 @@ func (s *validatorStore) StoreBatch(batchData []*BundleToStore) (uint64, error)
 		return 0, fmt.Errorf("failed to flush chunk table: %v", err)
 	}
 
+	if s.blobRecordTable != nil {
+		err = s.storeBlobRecords(batchData)
+		if err != nil {
+			s.logger.Warn("failed to store blob records", "err", err)
+		}
+	}
+
 	return size, nil
 }
 
@@ -317,6 +442,145 @@ func (s *validatorStore) getChunksLittDB(bundleKey []byte) (data []byte, exists
 	return bundle, true, nil
 }
 
+func (s *validatorStore) HasBundle(bundleKey []byte) (bool, error) {
+	exists, err := s.chunkTable.Exists(bundleKey)
+	if err != nil {
+		return false, fmt.Errorf("failed to check existence: %v", err)
+	}
+	return exists, nil
+}
+
+// storeBlobRecords stores the blob records of the bundles of a batch, keyed by bundle key. Records are only read to
+// repair bundles lost long after they were stored, so they are written in a single batch and only flushed
+// periodically, keeping the cost of recording a batch off the critical path of storing its chunks.
+func (s *validatorStore) storeBlobRecords(batchData []*BundleToStore) error {
+	storedAt := s.timeSource()
+	batch := make([]*types.KVPair, 0, len(batchData))
+	for _, bundle := range batchData {
+		if bundle.BlobRecord == nil {
+			continue
+		}
+
+		exists, err := s.blobRecordTable.Exists(bundle.BundleKey)
+		if err != nil {
+			return fmt.Errorf("failed to check existence: %v", err)
+		}
+		if exists {
+			continue
+		}
+
+		record := *bundle.BlobRecord
+		record.StoredAt = storedAt
+		recordBytes, err := serializeBlobRecord(&record)
+		if err != nil {
+			return fmt.Errorf("failed to serialize blob record: %v", err)
+		}
+
+		batch = append(batch, &types.KVPair{Key: bundle.BundleKey, Value: recordBytes})
+	}
+	if len(batch) == 0 {
+		return nil
+	}
+
+	err := s.blobRecordTable.PutBatch(batch)
+	if err != nil {
+		return fmt.Errorf("failed to put blob records: %v", err)
+	}
+
+	now := storedAt.UnixNano()
+	lastFlush := s.lastBlobRecordFlush.Load()
+	if now-lastFlush >= blobRecordFlushInterval.Nanoseconds() && s.lastBlobRecordFlush.CompareAndSwap(lastFlush, now) {
+		err = s.blobRecordTable.Flush()
+		if err != nil {
+			return fmt.Errorf("failed to flush blob records table: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *validatorStore) IterateBlobRecords(handler func(record *BlobRecord) error) error {
+	if s.blobRecordTable == nil {
+		return nil
+	}
+
+	// Iterating over unsealed data seals the mutable segment, which is only done once per blobRecordSealInterval.
+	now := s.timeSource()
+	lastSeal := s.lastBlobRecordSeal.Load()
+	seal := now.UnixNano()-lastSeal >= blobRecordSealInterval.Nanoseconds() &&
+		s.lastBlobRecordSeal.CompareAndSwap(lastSeal, now.UnixNano())
+	iterator, err := s.blobRecordTable.Iterate(&litt.IteratorOptions{OnlySealedData: !seal})
+	if err != nil {
+		return fmt.Errorf("failed to iterate blob records: %v", err)
+	}
+	defer iterator.Close()
+
+	for {
+		kv, ok, err := iterator.Next()
+		if err != nil {
+			return fmt.Errorf("failed to read blob record: %v", err)
+		}
+		if !ok {
+			return nil
+		}
+
+		record, err := deserializeBlobRecord(kv.Value)
+		if err != nil {
+			return fmt.Errorf("failed to deserialize blob record: %v", err)
+		}
+		if !now.Before(record.StoredAt.Add(s.ttl)) {
+			// The bundle has expired, and may already have been deleted
+			continue
+		}
+
+		err = handler(record)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// serializeBlobRecord encodes a blob record as the reference block number and the store time in nanoseconds since
+// the epoch, followed by the protobuf of the blob certificate.
+func serializeBlobRecord(record *BlobRecord) ([]byte, error) {
+	certProto, err := record.BlobCertificate.ToProtobuf()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert blob certificate to protobuf: %v", err)
+	}
+	certBytes, err := proto.Marshal(certProto)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal blob certificate: %v", err)
+	}
+
+	recordBytes := make([]byte, 16, 16+len(certBytes))
+	binary.BigEndian.PutUint64(recordBytes, record.ReferenceBlockNumber)
+	binary.BigEndian.PutUint64(recordBytes[8:], uint64(record.StoredAt.UnixNano()))
+	return append(recordBytes, certBytes...), nil
+}
+
+// deserializeBlobRecord decodes a blob record encoded by serializeBlobRecord.
+func deserializeBlobRecord(recordBytes []byte) (*BlobRecord, error) {
+	if len(recordBytes) < 16 {
+		return nil, fmt.Errorf("blob record is too short: %d bytes", len(recordBytes))
+	}
+
+	certProto := &commonpb.BlobCertificate{}
+	err := proto.Unmarshal(recordBytes[16:], certProto)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal blob certificate: %v", err)
+	}
+	blobCert, err := corev2.BlobCertificateFromProtobuf(certProto)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert blob certificate from protobuf: %v", err)
+	}
+
+	return &BlobRecord{
+		BlobCertificate:      blobCert,
+		ReferenceBlockNumber: binary.BigEndian.Uint64(recordBytes[:8]),
+		StoredAt:             time.Unix(0, int64(binary.BigEndian.Uint64(recordBytes[8:16]))),
+	}, nil
+}
+
 func BundleKey(blobKey corev2.BlobKey, quorumID core.QuorumID) ([]byte, error) {
 	buf := bytes.NewBuffer(blobKey[:])
 	err := binary.Write(buf, binary.LittleEndian, quorumID)
@@ -334,5 +598,12 @@ func (s *validatorStore) Stop() error {
 		}
 	}
 
+	if s.blobRecordDB != nil {
+		err := s.blobRecordDB.Close()
+		if err != nil {
+			return fmt.Errorf("failed to close blob records littDB: %v", err)
+		}
+	}
+
 	return nil
 }
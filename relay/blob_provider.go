@@ -79,6 +79,12 @@ func (s *blobProvider) GetBlob(ctx context.Context, blobKey v2.BlobKey) ([]byte,
 	return data, nil
 }
 
+// CacheStats returns the number of blob lookups served from the cache and the number of blob lookups that had to
+// fetch the blob from the blob store.
+func (s *blobProvider) CacheStats() (uint64, uint64) {
+	return s.blobCache.Stats()
+}
+
 // fetchBlob retrieves a single blob from the blob store.
 func (s *blobProvider) fetchBlob(blobKey v2.BlobKey) ([]byte, error) {
 	ctx, cancel := context.WithTimeout(s.ctx, s.fetchTimeout)
@@ -3,6 +3,7 @@ package cache
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	cachecommon "github.com/Layr-Labs/eigenda/common/cache"
@@ -16,6 +17,11 @@ type CacheAccessor[K comparable, V any] interface {
 	// If the context is cancelled, the function may abort early. If multiple goroutines request the same key,
 	// cancellation of one request will not affect the others.
 	Get(ctx context.Context, key K) (V, error)
+
+	// Stats returns the number of lookups served from the cache and the number of lookups that had to be fetched
+	// using the Accessor since the CacheAccessor was created. A lookup that waits for a fetch already in progress
+	// counts as a hit, since it does not cause any additional work.
+	Stats() (hits uint64, misses uint64)
 }
 
 // Accessor is function capable of fetching a value from a resource. Used by CacheAccessor when there is a cache miss.
@@ -61,6 +67,12 @@ type cacheAccessor[K comparable, V any] struct {
 
 	// metrics is used to record metrics about the cache accessor's performance.
 	metrics *CacheAccessorMetrics
+
+	// hits is the number of lookups that did not need to call the accessor.
+	hits atomic.Uint64
+
+	// misses is the number of lookups that called the accessor.
+	misses atomic.Uint64
 }
 
 // NewCacheAccessor creates a new CacheAccessor.
@@ -109,6 +121,7 @@ func (c *cacheAccessor[K, V]) Get(ctx context.Context, key K) (V, error) {
 	if ok {
 		c.cacheLock.Unlock()
 
+		c.hits.Add(1)
 		if c.metrics != nil {
 			c.metrics.ReportCacheHit()
 		}
@@ -124,6 +137,12 @@ func (c *cacheAccessor[K, V]) Get(ctx context.Context, key K) (V, error) {
 
 	c.cacheLock.Unlock()
 
+	if alreadyLoading {
+		c.hits.Add(1)
+	} else {
+		c.misses.Add(1)
+	}
+
 	if c.metrics != nil {
 		if alreadyLoading {
 			// A lookup is currently in progress. Not a cache hit, but this call won't duplicate the work.
@@ -143,6 +162,10 @@ func (c *cacheAccessor[K, V]) Get(ctx context.Context, key K) (V, error) {
 	}
 }
 
+func (c *cacheAccessor[K, V]) Stats() (uint64, uint64) {
+	return c.hits.Load(), c.misses.Load()
+}
+
 // waitForResult waits for the result of a lookup that was initiated by another requester and returns it
 // when it becomes is available. This method will return quickly if the provided context is cancelled.
 // Doing so does not disrupt the other requesters that are also waiting for this result.
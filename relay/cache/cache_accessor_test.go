@@ -119,6 +119,10 @@ func TestCacheMisses(t *testing.T) {
 		require.Equal(t, baseData[i], *value)
 		require.Equal(t, expectedCacheMissCount, cacheMissCount.Load())
 	}
+
+	hits, misses := ca.Stats()
+	require.Equal(t, uint64(cacheSize), hits)
+	require.Equal(t, expectedCacheMissCount, misses)
 }
 
 func ParallelAccessTest(t *testing.T, sleepEnabled bool) {
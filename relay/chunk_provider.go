@@ -89,8 +89,37 @@ func (s *chunkProvider) computeFramesCacheWeight(_ blobKeyWithMetadata, frames *
 	return frames.Size()
 }
 
+// framesResult is the result of fetching the frames for a single blob.
+type framesResult struct {
+	key  v2.BlobKey
+	data *core.ChunksData
+	err  error
+}
+
 // GetFrames retrieves the frames for a blob.
 func (s *chunkProvider) GetFrames(ctx context.Context, mMap metadataMap) (frameMap, error) {
+	completionChannel, err := s.StreamFrames(ctx, mMap)
+	if err != nil {
+		return nil, err
+	}
+
+	fMap := make(frameMap, len(mMap))
+	for len(fMap) < len(mMap) {
+		result := <-completionChannel
+		if result.err != nil {
+			return nil, fmt.Errorf("error fetching frames for blob %v: %w", result.key.Hex(), result.err)
+		}
+		fMap[result.key] = result.data
+	}
+
+	return fMap, nil
+}
+
+// StreamFrames retrieves the frames for a set of blobs. The frames for each blob are sent to the returned channel
+// as soon as they become available, so the caller does not need to wait for the slowest blob before processing the
+// others. Exactly one result is sent for each blob in mMap. The channel is buffered, so a caller that stops reading
+// early (e.g. after an error) does not leak goroutines.
+func (s *chunkProvider) StreamFrames(ctx context.Context, mMap metadataMap) (<-chan *framesResult, error) {
 
 	if len(mMap) == 0 {
 		return nil, fmt.Errorf("no metadata provided")
@@ -101,12 +130,6 @@ func (s *chunkProvider) GetFrames(ctx context.Context, mMap metadataMap) (frameM
 		keys = append(keys, &blobKeyWithMetadata{blobKey: k, metadata: *v})
 	}
 
-	type framesResult struct {
-		key  v2.BlobKey
-		data *core.ChunksData
-		err  error
-	}
-
 	// Channel for results.
 	completionChannel := make(chan *framesResult, len(keys))
 
@@ -131,16 +154,13 @@ func (s *chunkProvider) GetFrames(ctx context.Context, mMap metadataMap) (frameM
 		}()
 	}
 
-	fMap := make(frameMap, len(keys))
-	for len(fMap) < len(keys) {
-		result := <-completionChannel
-		if result.err != nil {
-			return nil, fmt.Errorf("error fetching frames for blob %v: %w", result.key.Hex(), result.err)
-		}
-		fMap[result.key] = result.data
-	}
+	return completionChannel, nil
+}
 
-	return fMap, nil
+// CacheStats returns the number of frame lookups served from the cache and the number of frame lookups that had to
+// fetch the frames from the chunk store.
+func (s *chunkProvider) CacheStats() (uint64, uint64) {
+	return s.frameCache.Stats()
 }
 
 // fetchFrames retrieves the frames for a single blob.
@@ -55,16 +55,19 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 		BucketName:        ctx.String(flags.BucketNameFlag.Name),
 		MetadataTableName: ctx.String(flags.MetadataTableNameFlag.Name),
 		RelayConfig: relay.Config{
-			RelayKeys:                  make([]core.RelayKey, len(relayKeys)),
-			GRPCPort:                   ctx.Int(flags.GRPCPortFlag.Name),
-			MaxGRPCMessageSize:         ctx.Int(flags.MaxGRPCMessageSizeFlag.Name),
-			MetadataCacheSize:          ctx.Int(flags.MetadataCacheSizeFlag.Name),
-			MetadataMaxConcurrency:     ctx.Int(flags.MetadataMaxConcurrencyFlag.Name),
-			BlobCacheBytes:             ctx.Uint64(flags.BlobCacheBytes.Name),
-			BlobMaxConcurrency:         ctx.Int(flags.BlobMaxConcurrencyFlag.Name),
-			ChunkCacheBytes:            ctx.Uint64(flags.ChunkCacheBytesFlag.Name),
-			ChunkMaxConcurrency:        ctx.Int(flags.ChunkMaxConcurrencyFlag.Name),
-			MaxKeysPerGetChunksRequest: ctx.Int(flags.MaxKeysPerGetChunksRequestFlag.Name),
+			RelayKeys:                        make([]core.RelayKey, len(relayKeys)),
+			GRPCPort:                         ctx.Int(flags.GRPCPortFlag.Name),
+			MaxGRPCMessageSize:               ctx.Int(flags.MaxGRPCMessageSizeFlag.Name),
+			MetadataCacheSize:                ctx.Int(flags.MetadataCacheSizeFlag.Name),
+			MetadataMaxConcurrency:           ctx.Int(flags.MetadataMaxConcurrencyFlag.Name),
+			BlobCacheBytes:                   ctx.Uint64(flags.BlobCacheBytes.Name),
+			BlobMaxConcurrency:               ctx.Int(flags.BlobMaxConcurrencyFlag.Name),
+			ChunkCacheBytes:                  ctx.Uint64(flags.ChunkCacheBytesFlag.Name),
+			ChunkMaxConcurrency:              ctx.Int(flags.ChunkMaxConcurrencyFlag.Name),
+			MaxKeysPerGetChunksRequest:       ctx.Int(flags.MaxKeysPerGetChunksRequestFlag.Name),
+			MaxPointsPerGetPointProofRequest: ctx.Int(flags.MaxPointsPerGetPointProofRequestFlag.Name),
+			PointProofG1Path:                 ctx.String(flags.PointProofG1PathFlag.Name),
+			PointProofSRSNumberToLoad:        ctx.Uint64(flags.PointProofSRSNumberToLoadFlag.Name),
 			RateLimits: limiter.Config{
 				MaxGetBlobOpsPerSecond:          ctx.Float64(flags.MaxGetBlobOpsPerSecondFlag.Name),
 				GetBlobOpsBurstiness:            ctx.Int(flags.GetBlobOpsBurstinessFlag.Name),
@@ -89,7 +92,9 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 			OnchainStateRefreshInterval:  ctx.Duration(flags.OnchainStateRefreshIntervalFlag.Name),
 			Timeouts: relay.TimeoutConfig{
 				GetChunksTimeout:               ctx.Duration(flags.GetChunksTimeoutFlag.Name),
+				StreamChunksTimeout:            ctx.Duration(flags.StreamChunksTimeoutFlag.Name),
 				GetBlobTimeout:                 ctx.Duration(flags.GetBlobTimeoutFlag.Name),
+				GetPointProofTimeout:           ctx.Duration(flags.GetPointProofTimeoutFlag.Name),
 				InternalGetMetadataTimeout:     ctx.Duration(flags.InternalGetMetadataTimeoutFlag.Name),
 				InternalGetBlobTimeout:         ctx.Duration(flags.InternalGetBlobTimeoutFlag.Name),
 				InternalGetProofsTimeout:       ctx.Duration(flags.InternalGetProofsTimeoutFlag.Name),
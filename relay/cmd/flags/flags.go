@@ -97,6 +97,26 @@ var (
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_KEYS_PER_GET_CHUNKS_REQUEST"),
 		Value:    1024,
 	}
+	MaxPointsPerGetPointProofRequestFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-points-per-get-point-proof-request"),
+		Usage:    "Max number of points to open in a single GetPointProof request",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_POINTS_PER_GET_POINT_PROOF_REQUEST"),
+		Value:    64,
+	}
+	PointProofG1PathFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "point-proof-g1-path"),
+		Usage:    "Path to the G1 SRS points used to compute KZG opening proofs. If not set, GetPointProof is disabled",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "POINT_PROOF_G1_PATH"),
+	}
+	PointProofSRSNumberToLoadFlag = cli.Uint64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "point-proof-srs-load"),
+		Usage:    "Number of G1 SRS points to load for computing KZG opening proofs, i.e. the maximum length of the blobs that can be opened (in symbols)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "POINT_PROOF_SRS_LOAD"),
+		Value:    524288,
+	}
 	MaxGetBlobOpsPerSecondFlag = cli.Float64Flag{
 		Name:     common.PrefixFlag(FlagPrefix, "max-get-blob-ops-per-second"),
 		Usage:    "Max number of GetBlob operations per second",
@@ -241,6 +261,20 @@ var (
 		Required: false,
 		Value:    20 * time.Second,
 	}
+	StreamChunksTimeoutFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "stream-chunks-timeout"),
+		Usage:    "Timeout for StreamChunks()",
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "STREAM_CHUNKS_TIMEOUT"),
+		Required: false,
+		Value:    60 * time.Second,
+	}
+	GetPointProofTimeoutFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "get-point-proof-timeout"),
+		Usage:    "Timeout for GetPointProof()",
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "GET_POINT_PROOF_TIMEOUT"),
+		Required: false,
+		Value:    20 * time.Second,
+	}
 	GetBlobTimeoutFlag = cli.DurationFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "get-blob-timeout"),
 		Usage:    "Timeout for GetBlob()",
@@ -344,6 +378,9 @@ var optionalFlags = []cli.Flag{
 	ChunkCacheBytesFlag,
 	ChunkMaxConcurrencyFlag,
 	MaxKeysPerGetChunksRequestFlag,
+	MaxPointsPerGetPointProofRequestFlag,
+	PointProofG1PathFlag,
+	PointProofSRSNumberToLoadFlag,
 	MaxGetBlobOpsPerSecondFlag,
 	GetBlobOpsBurstinessFlag,
 	MaxGetBlobBytesPerSecondFlag,
@@ -363,7 +400,9 @@ var optionalFlags = []cli.Flag{
 	AuthenticationTimeoutFlag,
 	AuthenticationDisabledFlag,
 	GetChunksTimeoutFlag,
+	StreamChunksTimeoutFlag,
 	GetBlobTimeoutFlag,
+	GetPointProofTimeoutFlag,
 	InternalGetMetadataTimeoutFlag,
 	InternalGetBlobTimeoutFlag,
 	InternalGetProofsTimeoutFlag,
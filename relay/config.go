@@ -43,6 +43,18 @@ type Config struct {
 	// MaxKeysPerGetChunksRequest is the maximum number of keys that can be requested in a single GetChunks request.
 	MaxKeysPerGetChunksRequest int
 
+	// MaxPointsPerGetPointProofRequest is the maximum number of points that can be opened in a single GetPointProof
+	// request.
+	MaxPointsPerGetPointProofRequest int
+
+	// PointProofG1Path is the path to the file containing the G1 SRS points, which are needed to compute the KZG
+	// opening proofs served by GetPointProof. If empty, GetPointProof is disabled.
+	PointProofG1Path string
+
+	// PointProofSRSNumberToLoad is the number of G1 SRS points to load. Blobs longer than this number of symbols
+	// can't be opened by GetPointProof.
+	PointProofSRSNumberToLoad uint64
+
 	// RateLimits contains configuration for rate limiting.
 	RateLimits limiter.Config
 
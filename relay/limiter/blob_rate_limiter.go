@@ -96,6 +96,19 @@ func (l *BlobRateLimiter) FinishGetBlobOperation() {
 	l.operationsInFlight--
 }
 
+// Utilization returns the number of GetBlob operations in flight, as a fraction of the maximum number of concurrent
+// GetBlob operations.
+func (l *BlobRateLimiter) Utilization() float64 {
+	if l == nil || l.config.MaxConcurrentGetBlobOps <= 0 {
+		return 0
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	return float64(l.operationsInFlight) / float64(l.config.MaxConcurrentGetBlobOps)
+}
+
 // RequestGetBlobBandwidth should be called when a GetBlob is about to start downloading blob data
 // from S3. It returns an error if there is insufficient bandwidth available. If it returns nil, the
 // operation should proceed.
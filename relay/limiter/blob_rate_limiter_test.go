@@ -49,12 +49,15 @@ func TestConcurrentBlobOperations(t *testing.T) {
 		require.NoError(t, err)
 	}
 
+	require.Equal(t, 1.0, limiter.Utilization())
+
 	// Starting one more operation should fail due to the concurrency limit
 	err := limiter.BeginGetBlobOperation(now)
 	require.Error(t, err)
 
 	// Finish an operation. This should permit exactly one more operation to start
 	limiter.FinishGetBlobOperation()
+	require.Equal(t, float64(concurrencyLimit-1)/float64(concurrencyLimit), limiter.Utilization())
 	err = limiter.BeginGetBlobOperation(now)
 	require.NoError(t, err)
 	err = limiter.BeginGetBlobOperation(now)
@@ -148,6 +148,19 @@ func (l *ChunkRateLimiter) FinishGetChunkOperation(requesterID string) {
 	l.perClientOperationsInFlight[requesterID]--
 }
 
+// Utilization returns the number of GetChunk operations in flight, as a fraction of the maximum number of concurrent
+// GetChunk operations.
+func (l *ChunkRateLimiter) Utilization() float64 {
+	if l == nil || l.config.MaxConcurrentGetChunkOps <= 0 {
+		return 0
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	return float64(l.globalOperationsInFlight) / float64(l.config.MaxConcurrentGetChunkOps)
+}
+
 // RequestGetChunkBandwidth should be called when a GetChunk is about to start downloading chunk data.
 func (l *ChunkRateLimiter) RequestGetChunkBandwidth(now time.Time, requesterID string, bytes uint32) error {
 	if l == nil {
@@ -33,12 +33,15 @@ func TestConcurrentGetChunksOperations(t *testing.T) {
 		require.NoError(t, err)
 	}
 
+	require.Equal(t, 1.0, limiter.Utilization())
+
 	// Starting one more operation should fail due to the concurrency limit
 	err := limiter.BeginGetChunkOperation(now, userID)
 	require.Error(t, err)
 
 	// Finish an operation. This should permit exactly one more operation to start
 	limiter.FinishGetChunkOperation(userID)
+	require.Equal(t, float64(concurrencyLimit-1)/float64(concurrencyLimit), limiter.Utilization())
 	err = limiter.BeginGetChunkOperation(now, userID)
 	require.NoError(t, err)
 	err = limiter.BeginGetChunkOperation(now, userID)
@@ -47,6 +47,10 @@ type RelayMetrics struct {
 	getBlobRateLimited        *prometheus.CounterVec
 	getBlobBandwidth          *prometheus.CounterVec
 	getBlobRequestedBandwidth *prometheus.CounterVec
+
+	// GetPointProof metrics
+	getPointProofLatency *prometheus.SummaryVec
+	getPointProofPoints  *prometheus.CounterVec
 }
 
 // NewRelayMetrics creates a new RelayMetrics instance, which encapsulates all metrics related to the relay.
@@ -223,6 +227,25 @@ func NewRelayMetrics(registry *prometheus.Registry, logger logging.Logger, port
 		[]string{},
 	)
 
+	getPointProofLatency := promauto.With(registry).NewSummaryVec(
+		prometheus.SummaryOpts{
+			Namespace:  namespace,
+			Name:       "get_point_proof_latency_ms",
+			Help:       "Latency of the GetPointProof RPC",
+			Objectives: objectives,
+		},
+		[]string{},
+	)
+
+	getPointProofPoints := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "get_point_proof_points_count",
+			Help:      "Running total number of points opened in GetPointProof requests.",
+		},
+		[]string{},
+	)
+
 	return &RelayMetrics{
 		logger:                         logger,
 		grpcServerOption:               grpcServerOption,
@@ -245,6 +268,8 @@ func NewRelayMetrics(registry *prometheus.Registry, logger logging.Logger, port
 		getBlobRateLimited:             getBlobRateLimited,
 		getBlobBandwidth:               getBlobBandwidth,
 		getBlobRequestedBandwidth:      getBlobRequestedBandwidth,
+		getPointProofLatency:           getPointProofLatency,
+		getPointProofPoints:            getPointProofPoints,
 	}
 }
 
@@ -327,3 +352,11 @@ func (m *RelayMetrics) ReportBlobBandwidthUsage(size int) {
 func (m *RelayMetrics) ReportBlobRequestedBandwidthUsage(size int) {
 	m.getBlobRequestedBandwidth.WithLabelValues().Add(float64(size))
 }
+
+func (m *RelayMetrics) ReportPointProofLatency(duration time.Duration) {
+	m.getPointProofLatency.WithLabelValues().Observe(common.ToMilliseconds(duration))
+}
+
+func (m *RelayMetrics) ReportPointProofPointCount(count int) {
+	m.getPointProofPoints.WithLabelValues().Add(float64(count))
+}
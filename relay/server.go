@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"runtime"
 	"strings"
 	"time"
 
@@ -16,11 +17,17 @@ import (
 	"github.com/Layr-Labs/eigenda/core"
 	v2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/Layr-Labs/eigenda/encoding/rs"
+	"github.com/Layr-Labs/eigenda/encoding/utils/openCommitment"
 	"github.com/Layr-Labs/eigenda/relay/auth"
 	"github.com/Layr-Labs/eigenda/relay/chunkstore"
 	"github.com/Layr-Labs/eigenda/relay/limiter"
 	"github.com/Layr-Labs/eigenda/relay/metrics"
 	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
 	"github.com/prometheus/client_golang/prometheus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/peer"
@@ -68,6 +75,9 @@ type Server struct {
 
 	// metrics encapsulates the metrics for the relay server.
 	metrics *metrics.RelayMetrics
+
+	// g1SRS contains the G1 SRS points used to compute KZG opening proofs. If empty, GetPointProof is disabled.
+	g1SRS []bn254.G1Affine
 }
 
 // NewServer creates a new relay Server.
@@ -142,6 +152,17 @@ func NewServer(
 		}
 	}
 
+	var g1SRS []bn254.G1Affine
+	if config.PointProofG1Path != "" {
+		g1SRS, err = kzg.ReadG1Points(
+			config.PointProofG1Path,
+			config.PointProofSRSNumberToLoad,
+			uint64(runtime.GOMAXPROCS(0)))
+		if err != nil {
+			return nil, fmt.Errorf("error reading G1 points for point proofs: %w", err)
+		}
+	}
+
 	replayGuardian := replay.NewReplayGuardian(
 		time.Now,
 		config.GetChunksRequestMaxPastAge,
@@ -158,6 +179,7 @@ func NewServer(
 		authenticator:    authenticator,
 		replayGuardian:   replayGuardian,
 		metrics:          relayMetrics,
+		g1SRS:            g1SRS,
 	}, nil
 }
 
@@ -219,6 +241,156 @@ func (s *Server) GetBlob(ctx context.Context, request *pb.GetBlobRequest) (*pb.G
 	return reply, nil
 }
 
+// GetPointProof computes a KZG multi-point opening of the polynomial of a blob stored by the relay. A single proof is
+// computed for all of the requested points, by dividing the polynomial by the vanishing polynomial of the points and
+// committing to the quotient with one multi-scalar multiplication, so the cost of a request is dominated by the size
+// of the blob rather than the number of points.
+func (s *Server) GetPointProof(
+	ctx context.Context,
+	request *pb.GetPointProofRequest) (*pb.GetPointProofReply, error) {
+
+	start := time.Now()
+
+	if len(s.g1SRS) == 0 {
+		return nil, api.NewErrorUnimplemented()
+	}
+
+	if s.config.Timeouts.GetPointProofTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.config.Timeouts.GetPointProofTimeout)
+		defer cancel()
+	}
+
+	key, err := v2.BytesToBlobKey(request.GetBlobKey())
+	if err != nil {
+		return nil, api.NewErrorInvalidArg(fmt.Sprintf("invalid blob key: %v", err))
+	}
+	if len(request.GetPoints()) == 0 {
+		return nil, api.NewErrorInvalidArg("no points provided")
+	}
+	if len(request.GetPoints()) > s.config.MaxPointsPerGetPointProofRequest {
+		return nil, api.NewErrorInvalidArg(fmt.Sprintf(
+			"too many points provided, max is %d", s.config.MaxPointsPerGetPointProofRequest))
+	}
+	s.logger.Debug("GetPointProof request received", "key", key.Hex(), "points", len(request.GetPoints()))
+
+	// Opening a blob requires the blob data and a single multi-scalar multiplication of the size of the blob, so
+	// GetPointProof is subject to the same limits as GetBlob.
+	err = s.blobRateLimiter.BeginGetBlobOperation(time.Now())
+	if err != nil {
+		return nil, api.NewErrorResourceExhausted(fmt.Sprintf("rate limit exceeded: %v", err))
+	}
+	defer s.blobRateLimiter.FinishGetBlobOperation()
+
+	mMap, err := s.metadataProvider.GetMetadataForBlobs(ctx, []v2.BlobKey{key})
+	if err != nil {
+		return nil, api.NewErrorInternal(fmt.Sprintf(
+			"error fetching metadata for blob, check if blob exists and is assigned to this relay: %v", err))
+	}
+	metadata := mMap[key]
+	if metadata == nil {
+		return nil, api.NewErrorNotFound("blob not found")
+	}
+
+	blobLength := metadata.blobSizeBytes / encoding.BYTES_PER_SYMBOL
+	if uint64(blobLength) > uint64(len(s.g1SRS)) {
+		return nil, api.NewErrorInvalidArg(fmt.Sprintf(
+			"blob length %d exceeds the maximum length of %d symbols that this relay can open",
+			blobLength, len(s.g1SRS)))
+	}
+
+	points, err := parsePointsToOpen(request.GetPoints(), blobLength)
+	if err != nil {
+		return nil, api.NewErrorInvalidArg(fmt.Sprintf("invalid point: %v", err))
+	}
+
+	err = s.blobRateLimiter.RequestGetBlobBandwidth(time.Now(), metadata.blobSizeBytes)
+	if err != nil {
+		return nil, api.NewErrorResourceExhausted(fmt.Sprintf("bandwidth limit exceeded: %v", err))
+	}
+
+	data, err := s.blobProvider.GetBlob(ctx, key)
+	if err != nil {
+		return nil, api.NewErrorInternal(fmt.Sprintf("error fetching blob %s: %v", key.Hex(), err))
+	}
+
+	reply, err := computePointOpening(data, points, s.g1SRS)
+	if err != nil {
+		return nil, api.NewErrorInternal(fmt.Sprintf("error computing point proof for blob %s: %v", key.Hex(), err))
+	}
+
+	s.metrics.ReportPointProofPointCount(len(points))
+	s.metrics.ReportPointProofLatency(time.Since(start))
+
+	return reply, nil
+}
+
+// parsePointsToOpen converts the points of a GetPointProof request into field elements. A point requested by index
+// is the evaluation point of the symbol at that index, i.e. the index-th power of the primitive root of unity of order
+// blobLength. The points must be distinct.
+func parsePointsToOpen(points []*pb.PointToOpen, blobLength uint32) ([]fr.Element, error) {
+	zs := make([]fr.Element, len(points))
+	seen := make(map[fr.Element]struct{}, len(points))
+	for i, point := range points {
+		switch p := point.GetPoint().(type) {
+		case *pb.PointToOpen_Index:
+			z, err := openCommitment.SymbolEvaluationPoint(p.Index, blobLength)
+			if err != nil {
+				return nil, err
+			}
+			zs[i] = z
+		case *pb.PointToOpen_Z:
+			if len(p.Z) != fr.Bytes {
+				return nil, fmt.Errorf("point must be %d bytes, got %d", fr.Bytes, len(p.Z))
+			}
+			err := zs[i].SetBytesCanonical(p.Z)
+			if err != nil {
+				return nil, fmt.Errorf("point is not a canonical field element: %w", err)
+			}
+		default:
+			return nil, fmt.Errorf("point %d has neither an index nor a field element", i)
+		}
+
+		if _, ok := seen[zs[i]]; ok {
+			return nil, fmt.Errorf("point %d is a duplicate", i)
+		}
+		seen[zs[i]] = struct{}{}
+	}
+
+	return zs, nil
+}
+
+// computePointOpening computes a KZG multi-point opening proof of the polynomial whose coefficients are the symbols of
+// a blob. A single proof is computed for all of the points, by dividing the polynomial by the polynomial that vanishes
+// at the points and committing to the quotient with one multi-scalar multiplication over the SRS.
+func computePointOpening(blob []byte, zs []fr.Element, g1SRS []bn254.G1Affine) (*pb.GetPointProofReply, error) {
+	coeffs, err := rs.ToFrArray(blob)
+	if err != nil {
+		return nil, fmt.Errorf("error converting blob to field elements: %w", err)
+	}
+
+	proof, values, err := openCommitment.ComputeMultiPointKzgProof(coeffs, zs, g1SRS)
+	if err != nil {
+		return nil, fmt.Errorf("error computing proof: %w", err)
+	}
+
+	evaluations := make([]*pb.PointEvaluation, len(zs))
+	for i := range zs {
+		zBytes := zs[i].Bytes()
+		valueBytes := values[i].Bytes()
+		evaluations[i] = &pb.PointEvaluation{
+			Z:     zBytes[:],
+			Value: valueBytes[:],
+		}
+	}
+	proofBytes := proof.Bytes()
+
+	return &pb.GetPointProofReply{
+		Evaluations: evaluations,
+		Proof:       proofBytes[:],
+	}, nil
+}
+
 func (s *Server) validateGetChunksRequest(request *pb.GetChunksRequest) error {
 	if request == nil {
 		return api.NewErrorInvalidArg("request is nil")
@@ -249,17 +421,129 @@ func (s *Server) GetChunks(ctx context.Context, request *pb.GetChunksRequest) (*
 		ctx, cancel = context.WithTimeout(ctx, s.config.Timeouts.GetChunksTimeout)
 		defer cancel()
 	}
-	err := s.validateGetChunksRequest(request)
+
+	mMap, finish, err := s.beginChunksRequest(ctx, request, start)
 	if err != nil {
 		return nil, err
 	}
+	defer finish()
+
+	finishedFetchingMetadata := time.Now()
+
+	frames, err := s.chunkProvider.GetFrames(ctx, mMap)
+	if err != nil {
+		return nil, api.NewErrorInternal(fmt.Sprintf("error fetching frames: %v", err))
+	}
+
+	bytesToSend, err := gatherChunkDataToSend(frames, request)
+	if err != nil {
+		return nil, api.NewErrorInternal(fmt.Sprintf("error gathering chunk data: %v", err))
+	}
+
+	s.metrics.ReportChunkDataLatency(time.Since(finishedFetchingMetadata))
+	s.metrics.ReportChunkLatency(time.Since(start))
+
+	return &pb.GetChunksReply{
+		Data: bytesToSend,
+	}, nil
+}
+
+// StreamChunks retrieves chunks from blobs stored by the relay, sending each requested bundle to the client as soon
+// as the frames for its blob are available.
+func (s *Server) StreamChunks(streamRequest *pb.StreamChunksRequest, stream pb.Relay_StreamChunksServer) error {
+	start := time.Now()
+	ctx := stream.Context()
+
+	if s.config.Timeouts.StreamChunksTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.config.Timeouts.StreamChunksTimeout)
+		defer cancel()
+	}
+
+	if streamRequest == nil {
+		return api.NewErrorInvalidArg("request is nil")
+	}
+	request := streamRequest.GetRequest()
+
+	mMap, finish, err := s.beginChunksRequest(ctx, request, start)
+	if err != nil {
+		return err
+	}
+	defer finish()
+
+	finishedFetchingMetadata := time.Now()
+
+	// A blob may be referenced by more than one chunk request.
+	requestIndices := make(map[v2.BlobKey][]int, len(mMap))
+	for i, chunkRequest := range request.ChunkRequests {
+		var key v2.BlobKey
+		if chunkRequest.GetByIndex() != nil {
+			key = v2.BlobKey(chunkRequest.GetByIndex().GetBlobKey())
+		} else {
+			key = v2.BlobKey(chunkRequest.GetByRange().GetBlobKey())
+		}
+		requestIndices[key] = append(requestIndices[key], i)
+	}
+
+	results, err := s.chunkProvider.StreamFrames(ctx, mMap)
+	if err != nil {
+		return api.NewErrorInternal(fmt.Sprintf("error fetching frames: %v", err))
+	}
+
+	for range mMap {
+		var result *framesResult
+		select {
+		case result = <-results:
+		case <-ctx.Done():
+			return api.NewErrorInternal(fmt.Sprintf("error fetching frames: %v", ctx.Err()))
+		}
+		if result.err != nil {
+			return api.NewErrorInternal(
+				fmt.Sprintf("error fetching frames for blob %s: %v", result.key.Hex(), result.err))
+		}
+
+		frames := frameMap{result.key: result.data}
+		for _, index := range requestIndices[result.key] {
+			bundle, err := selectChunkData(frames, request.ChunkRequests[index], request.GetBundleEncodingFormat())
+			if err != nil {
+				return api.NewErrorInternal(fmt.Sprintf("error gathering chunk data: %v", err))
+			}
+
+			err = stream.Send(&pb.StreamChunksReply{
+				RequestIndex: uint32(index),
+				Data:         bundle,
+			})
+			if err != nil {
+				return fmt.Errorf("error sending chunk data: %w", err)
+			}
+		}
+	}
+
+	s.metrics.ReportChunkDataLatency(time.Since(finishedFetchingMetadata))
+	s.metrics.ReportChunkLatency(time.Since(start))
+
+	return nil
+}
+
+// beginChunksRequest performs the work shared by GetChunks and StreamChunks before any chunk data is fetched:
+// the request is validated, authenticated, and rate limited, and the metadata for the requested blobs is fetched.
+// If no error is returned, the caller must call the returned function once it is done serving the request.
+func (s *Server) beginChunksRequest(
+	ctx context.Context,
+	request *pb.GetChunksRequest,
+	start time.Time) (metadataMap, func(), error) {
+
+	err := s.validateGetChunksRequest(request)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	s.metrics.ReportChunkKeyCount(len(request.ChunkRequests))
 
 	if s.authenticator != nil {
 		client, ok := peer.FromContext(ctx)
 		if !ok {
-			return nil, api.NewErrorInvalidArg("could not get peer information")
+			return nil, nil, api.NewErrorInvalidArg("could not get peer information")
 		}
 		clientAddress := client.Addr.String()
 
@@ -267,14 +551,14 @@ func (s *Server) GetChunks(ctx context.Context, request *pb.GetChunksRequest) (*
 		if err != nil {
 			s.metrics.ReportChunkAuthFailure()
 			s.logger.Debug("rejected GetChunks request", "client", clientAddress)
-			return nil, api.NewErrorInvalidArg(fmt.Sprintf("auth failed: %v", err))
+			return nil, nil, api.NewErrorInvalidArg(fmt.Sprintf("auth failed: %v", err))
 		}
 
 		timestamp := time.Unix(int64(request.Timestamp), 0)
 		err = s.replayGuardian.VerifyRequest(hash, timestamp)
 		if err != nil {
 			s.metrics.ReportChunkAuthFailure()
-			return nil, api.NewErrorInvalidArg(fmt.Sprintf("failed to verify request: %v", err))
+			return nil, nil, api.NewErrorInvalidArg(fmt.Sprintf("failed to verify request: %v", err))
 		}
 
 		s.logger.Debug("received authenticated GetChunks request", "client", clientAddress)
@@ -288,9 +572,28 @@ func (s *Server) GetChunks(ctx context.Context, request *pb.GetChunksRequest) (*
 	clientID := string(request.OperatorId)
 	err = s.chunkRateLimiter.BeginGetChunkOperation(time.Now(), clientID)
 	if err != nil {
-		return nil, api.NewErrorResourceExhausted(fmt.Sprintf("rate limit exceeded: %v", err))
+		return nil, nil, api.NewErrorResourceExhausted(fmt.Sprintf("rate limit exceeded: %v", err))
+	}
+	finish := func() {
+		s.chunkRateLimiter.FinishGetChunkOperation(clientID)
 	}
-	defer s.chunkRateLimiter.FinishGetChunkOperation(clientID)
+
+	mMap, err := s.fetchChunksRequestMetadata(ctx, request, clientID, finishedAuthenticating)
+	if err != nil {
+		finish()
+		return nil, nil, err
+	}
+
+	return mMap, finish, nil
+}
+
+// fetchChunksRequestMetadata fetches the metadata for the blobs in a GetChunks request and reserves the bandwidth
+// needed to serve the request.
+func (s *Server) fetchChunksRequestMetadata(
+	ctx context.Context,
+	request *pb.GetChunksRequest,
+	clientID string,
+	finishedAuthenticating time.Time) (metadataMap, error) {
 
 	// keys might contain duplicate keys
 	keys, err := getKeysFromChunkRequest(request)
@@ -321,22 +624,7 @@ func (s *Server) GetChunks(ctx context.Context, request *pb.GetChunksRequest) (*
 	}
 	s.metrics.ReportGetChunksBandwidthUsage(requiredBandwidth)
 
-	frames, err := s.chunkProvider.GetFrames(ctx, mMap)
-	if err != nil {
-		return nil, api.NewErrorInternal(fmt.Sprintf("error fetching frames: %v", err))
-	}
-
-	bytesToSend, err := gatherChunkDataToSend(frames, request)
-	if err != nil {
-		return nil, api.NewErrorInternal(fmt.Sprintf("error gathering chunk data: %v", err))
-	}
-
-	s.metrics.ReportChunkDataLatency(time.Since(finishedFetchingMetadata))
-	s.metrics.ReportChunkLatency(time.Since(start))
-
-	return &pb.GetChunksReply{
-		Data: bytesToSend,
-	}, nil
+	return mMap, nil
 }
 
 // getKeysFromChunkRequest gathers a slice of blob keys from a GetChunks request.
@@ -372,22 +660,9 @@ func gatherChunkDataToSend(
 	bytesToSend := make([][]byte, 0, len(request.ChunkRequests))
 
 	for _, chunkRequest := range request.ChunkRequests {
-		var framesSubset *core.ChunksData
-		var err error
-
-		if chunkRequest.GetByIndex() != nil {
-			framesSubset, err = selectFrameSubsetByIndex(chunkRequest.GetByIndex(), frames)
-		} else {
-			framesSubset, err = selectFrameSubsetByRange(chunkRequest.GetByRange(), frames)
-		}
-
+		subsetBytes, err := selectChunkData(frames, chunkRequest, request.GetBundleEncodingFormat())
 		if err != nil {
-			return nil, fmt.Errorf("error selecting frame subset: %v", err)
-		}
-
-		subsetBytes, err := framesSubset.FlattenToBundle()
-		if err != nil {
-			return nil, fmt.Errorf("error serializing frame subset: %v", err)
+			return nil, err
 		}
 
 		bytesToSend = append(bytesToSend, subsetBytes)
@@ -396,6 +671,38 @@ func gatherChunkDataToSend(
 	return bytesToSend, nil
 }
 
+// selectChunkData selects the frames requested by a single chunk request and serializes them into a bundle in the
+// requested format. Bundles are serialized in the GNARK format unless another supported format is requested.
+func selectChunkData(
+	frames map[v2.BlobKey]*core.ChunksData,
+	chunkRequest *pb.ChunkRequest,
+	format pb.BundleEncodingFormat) ([]byte, error) {
+	var framesSubset *core.ChunksData
+	var err error
+
+	if chunkRequest.GetByIndex() != nil {
+		framesSubset, err = selectFrameSubsetByIndex(chunkRequest.GetByIndex(), frames)
+	} else {
+		framesSubset, err = selectFrameSubsetByRange(chunkRequest.GetByRange(), frames)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error selecting frame subset: %v", err)
+	}
+
+	var subsetBytes []byte
+	if format == pb.BundleEncodingFormat_GNARK_COMPRESSED {
+		subsetBytes, err = framesSubset.FlattenToCompressedBundle()
+	} else {
+		subsetBytes, err = framesSubset.FlattenToBundle()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error serializing frame subset: %v", err)
+	}
+
+	return subsetBytes, nil
+}
+
 // selectFrameSubsetByRange selects a subset of frames from a BinaryFrames object based on a range
 func selectFrameSubsetByRange(
 	request *pb.ChunkRequestByRange,
@@ -522,6 +829,21 @@ func buildInsufficientGetChunksBandwidthError(
 		blobCount, chunkCount, requiredBandwidth, originalError))
 }
 
+// GetRelayStatus returns the current load of the relay.
+func (s *Server) GetRelayStatus(context.Context, *pb.GetRelayStatusRequest) (*pb.GetRelayStatusReply, error) {
+	blobCacheHits, blobCacheMisses := s.blobProvider.CacheStats()
+	chunkCacheHits, chunkCacheMisses := s.chunkProvider.CacheStats()
+
+	return &pb.GetRelayStatusReply{
+		BlobOperationUtilization:  s.blobRateLimiter.Utilization(),
+		ChunkOperationUtilization: s.chunkRateLimiter.Utilization(),
+		BlobCacheHits:             blobCacheHits,
+		BlobCacheMisses:           blobCacheMisses,
+		ChunkCacheHits:            chunkCacheHits,
+		ChunkCacheMisses:          chunkCacheMisses,
+	}, nil
+}
+
 // Start starts the server listening for requests. This method will block until the server is stopped.
 func (s *Server) Start(ctx context.Context) error {
 	// Start metrics server if enabled
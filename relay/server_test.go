@@ -3,6 +3,9 @@ package relay
 import (
 	"context"
 	"encoding/binary"
+	"errors"
+	"io"
+	"math/big"
 	"testing"
 	"time"
 
@@ -15,8 +18,13 @@ import (
 	coremock "github.com/Layr-Labs/eigenda/core/mock"
 	v2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/rs"
+	"github.com/Layr-Labs/eigenda/encoding/utils/codec"
+	"github.com/Layr-Labs/eigenda/encoding/utils/openCommitment"
 	"github.com/Layr-Labs/eigenda/relay/auth"
 	"github.com/Layr-Labs/eigenda/relay/limiter"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
 	"github.com/docker/go-units"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
@@ -59,6 +67,7 @@ func defaultConfig() *Config {
 		Timeouts: TimeoutConfig{
 			GetBlobTimeout:                 10 * time.Second,
 			GetChunksTimeout:               10 * time.Second,
+			StreamChunksTimeout:            10 * time.Second,
 			InternalGetMetadataTimeout:     10 * time.Second,
 			InternalGetBlobTimeout:         10 * time.Second,
 			InternalGetProofsTimeout:       10 * time.Second,
@@ -114,6 +123,54 @@ func getChunks(
 	return response, err
 }
 
+// streamChunks sends a StreamChunks request and collects the replies. The i-th element of the result is the bundle
+// for the i-th chunk request.
+func streamChunks(
+	t *testing.T,
+	random *random.TestRandom,
+	operatorKeys map[uint32]*core.KeyPair,
+	request *pb.GetChunksRequest) ([][]byte, error) {
+
+	// Choose a random operator to send this request as. Operator IDs are expected to be sequential starting at 0.
+	operatorID := random.Uint32() % uint32(len(operatorKeys))
+	operatorIDBytes := make([]byte, 32)
+	binary.BigEndian.PutUint32(operatorIDBytes[24:], operatorID)
+	request.OperatorId = operatorIDBytes
+	signature, err := auth.SignGetChunksRequest(operatorKeys[operatorID], request)
+	require.NoError(t, err)
+	request.OperatorSignature = signature
+
+	var opts []grpc.DialOption
+	opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+
+	conn, err := grpc.NewClient("0.0.0.0:50051", opts...)
+	require.NoError(t, err)
+	defer func() {
+		err = conn.Close()
+		require.NoError(t, err)
+	}()
+
+	client := pb.NewRelayClient(conn)
+	stream, err := client.StreamChunks(context.Background(), &pb.StreamChunksRequest{Request: request})
+	if err != nil {
+		return nil, err
+	}
+
+	bundles := make([][]byte, len(request.ChunkRequests))
+	for {
+		reply, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return bundles, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		require.Less(t, int(reply.RequestIndex), len(bundles))
+		require.Nil(t, bundles[reply.RequestIndex], "bundle sent more than once")
+		bundles[reply.RequestIndex] = reply.Data
+	}
+}
+
 func TestReadWriteBlobs(t *testing.T) {
 	rand := random.NewTestRandom()
 
@@ -765,6 +822,163 @@ func TestBatchedReadWriteChunks(t *testing.T) {
 	}
 }
 
+func TestStreamChunks(t *testing.T) {
+	rand := random.NewTestRandom()
+
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	require.NoError(t, err)
+
+	setup(t)
+	defer teardown()
+
+	// These are used to write data to S3/dynamoDB
+	metadataStore := buildMetadataStore(t)
+	chunkReader, chunkWriter := buildChunkStore(t, logger)
+
+	operatorCount := rand.Intn(3) + 1
+	operatorKeys := make(map[uint32]*core.KeyPair)
+	operatorInfo := make(map[core.OperatorID]*core.IndexedOperatorInfo)
+	for i := 0; i < operatorCount; i++ {
+		keypair, err := rand.BLS()
+		require.NoError(t, err)
+		operatorKeys[uint32(i)] = keypair
+
+		var operatorID core.OperatorID
+		binary.BigEndian.PutUint32(operatorID[24:], uint32(i))
+		operatorInfo[operatorID] = &core.IndexedOperatorInfo{
+			PubkeyG1: keypair.GetPubKeyG1(),
+			PubkeyG2: keypair.GetPubKeyG2(),
+		}
+	}
+
+	ics := &coremock.MockIndexedChainState{}
+	blockNumber := uint(rand.Uint32())
+	ics.Mock.On("GetCurrentBlockNumber").Return(blockNumber, nil)
+	ics.Mock.On("GetIndexedOperators", blockNumber).Return(operatorInfo, nil)
+
+	// This is the server used to read it back
+	config := defaultConfig()
+	chainReader := newMockChainReader()
+	server, err := NewServer(
+		context.Background(),
+		prometheus.NewRegistry(),
+		logger,
+		config,
+		metadataStore,
+		nil, /* not used in this test */
+		chunkReader,
+		chainReader,
+		ics)
+	server.replayGuardian = replay.NewNoOpReplayGuardian() // disable replay protection
+	require.NoError(t, err)
+
+	go func() {
+		err = server.Start(context.Background())
+		require.NoError(t, err)
+	}()
+	defer func() {
+		err = server.Stop()
+		require.NoError(t, err)
+	}()
+
+	expectedData := make(map[v2.BlobKey][]*encoding.Frame)
+
+	blobCount := 10
+	for i := 0; i < blobCount; i++ {
+		header, _, chunks := randomBlobChunks(t)
+
+		blobKey, err := header.BlobKey()
+		require.NoError(t, err)
+		expectedData[blobKey] = chunks
+
+		coeffs, chunkProofs := disassembleFrames(chunks)
+		err = chunkWriter.PutFrameProofs(context.Background(), blobKey, chunkProofs)
+		require.NoError(t, err)
+		fragmentInfo, err := chunkWriter.PutFrameCoefficients(context.Background(), blobKey, coeffs)
+		require.NoError(t, err)
+
+		err = metadataStore.PutBlobCertificate(
+			context.Background(),
+			&v2.BlobCertificate{
+				BlobHeader: header,
+			},
+			&encoding.FragmentInfo{
+				TotalChunkSizeBytes: fragmentInfo.TotalChunkSizeBytes,
+				FragmentSizeBytes:   fragmentInfo.FragmentSizeBytes,
+			})
+		require.NoError(t, err)
+	}
+
+	keys := make([]v2.BlobKey, 0, blobCount)
+	for key := range expectedData {
+		keys = append(keys, key)
+	}
+
+	// Request every blob by range, and the first frame of every blob by index. Each blob is referenced twice.
+	requestedChunks := make([]*pb.ChunkRequest, 0, 2*len(keys))
+	for _, key := range keys {
+		boundKey := key
+		requestedChunks = append(requestedChunks, &pb.ChunkRequest{
+			Request: &pb.ChunkRequest_ByRange{
+				ByRange: &pb.ChunkRequestByRange{
+					BlobKey:    boundKey[:],
+					StartIndex: 0,
+					EndIndex:   uint32(len(expectedData[key])),
+				},
+			},
+		})
+	}
+	for _, key := range keys {
+		boundKey := key
+		requestedChunks = append(requestedChunks, &pb.ChunkRequest{
+			Request: &pb.ChunkRequest_ByIndex{
+				ByIndex: &pb.ChunkRequestByIndex{
+					BlobKey:      boundKey[:],
+					ChunkIndices: []uint32{0},
+				},
+			},
+		})
+	}
+	request := &pb.GetChunksRequest{
+		ChunkRequests: requestedChunks,
+		Timestamp:     uint32(time.Now().Unix()),
+	}
+
+	bundles, err := streamChunks(t, rand, operatorKeys, request)
+	require.NoError(t, err)
+	require.Equal(t, len(requestedChunks), len(bundles))
+
+	for keyIndex, key := range keys {
+		data := expectedData[key]
+
+		bundle, err := core.Bundle{}.Deserialize(bundles[keyIndex])
+		require.NoError(t, err)
+		require.Equal(t, len(data), len(bundle))
+		for frameIndex, frame := range bundle {
+			require.Equal(t, data[frameIndex], frame)
+		}
+
+		bundle, err = core.Bundle{}.Deserialize(bundles[len(keys)+keyIndex])
+		require.NoError(t, err)
+		require.Equal(t, 1, len(bundle))
+		require.Equal(t, data[0], bundle[0])
+	}
+
+	// Requests with a bad signature are rejected before anything is streamed.
+	request = &pb.GetChunksRequest{
+		ChunkRequests: requestedChunks[:1],
+		Timestamp:     uint32(time.Now().Unix()),
+	}
+	otherKeys := map[uint32]*core.KeyPair{}
+	for operatorID := range operatorKeys {
+		keypair, err := rand.BLS()
+		require.NoError(t, err)
+		otherKeys[operatorID] = keypair
+	}
+	_, err = streamChunks(t, rand, otherKeys, request)
+	require.Error(t, err)
+}
+
 func TestReadWriteChunksWithSharding(t *testing.T) {
 	rand := random.NewTestRandom()
 
@@ -1275,3 +1489,144 @@ func TestBatchedReadWriteChunksWithSharding(t *testing.T) {
 		}
 	}
 }
+
+func TestComputePointOpening(t *testing.T) {
+	rand := random.NewTestRandom()
+
+	// a small SRS with a known secret, so that the test does not need the SRS files
+	var tau fr.Element
+	_, err := tau.SetRandom()
+	require.NoError(t, err)
+	_, _, g1Gen, g2Gen := bn254.Generators()
+	blobLength := uint32(8)
+	g1SRS := make([]bn254.G1Affine, blobLength)
+	g2SRS := make([]bn254.G2Affine, blobLength)
+	var power fr.Element
+	var powerBig big.Int
+	power.SetOne()
+	for i := range g1SRS {
+		g1SRS[i].ScalarMultiplication(&g1Gen, power.BigInt(&powerBig))
+		g2SRS[i].ScalarMultiplication(&g2Gen, power.BigInt(&powerBig))
+		power.Mul(&power, &tau)
+	}
+
+	blob := codec.ConvertByPaddingEmptyByte(rand.Bytes(int(blobLength) * 31))
+	coeffs, err := rs.ToFrArray(blob)
+	require.NoError(t, err)
+	commitment, err := openCommitment.CommitInLagrange(coeffs, g1SRS)
+	require.NoError(t, err)
+
+	var z fr.Element
+	_, err = z.SetRandom()
+	require.NoError(t, err)
+	zBytes := z.Bytes()
+	points := []*pb.PointToOpen{
+		{Point: &pb.PointToOpen_Index{Index: 0}},
+		{Point: &pb.PointToOpen_Index{Index: blobLength - 1}},
+		{Point: &pb.PointToOpen_Z{Z: zBytes[:]}},
+	}
+	zs, err := parsePointsToOpen(points, blobLength)
+	require.NoError(t, err)
+	require.True(t, zs[0].IsOne())
+	require.Equal(t, z, zs[2])
+
+	reply, err := computePointOpening(blob, zs, g1SRS)
+	require.NoError(t, err)
+	require.Len(t, reply.GetEvaluations(), len(points))
+	values := make([]fr.Element, len(points))
+	for i, evaluation := range reply.GetEvaluations() {
+		var pointFr fr.Element
+		require.NoError(t, pointFr.SetBytesCanonical(evaluation.GetZ()))
+		require.Equal(t, zs[i], pointFr)
+		require.NoError(t, values[i].SetBytesCanonical(evaluation.GetValue()))
+	}
+	var proof bn254.G1Affine
+	_, err = proof.SetBytes(reply.GetProof())
+	require.NoError(t, err)
+
+	// a single proof opens the blob at all of the points
+	err = openCommitment.VerifyMultiPointKzgProof(*commitment, proof, values, zs, g1SRS, g2SRS)
+	require.NoError(t, err)
+
+	// the value at the evaluation point of index 0, i.e. 1, is the sum of the coefficients
+	var sum fr.Element
+	for i := range coeffs {
+		sum.Add(&sum, &coeffs[i])
+	}
+	require.Equal(t, sum, values[0])
+
+	// invalid points are rejected
+	_, err = parsePointsToOpen([]*pb.PointToOpen{{Point: &pb.PointToOpen_Index{Index: blobLength}}}, blobLength)
+	require.Error(t, err)
+	_, err = parsePointsToOpen([]*pb.PointToOpen{{Point: &pb.PointToOpen_Index{Index: 0}}}, blobLength-1)
+	require.Error(t, err)
+	_, err = parsePointsToOpen([]*pb.PointToOpen{{Point: &pb.PointToOpen_Z{Z: zBytes[:16]}}}, blobLength)
+	require.Error(t, err)
+	nonCanonical := make([]byte, fr.Bytes)
+	for i := range nonCanonical {
+		nonCanonical[i] = 0xff
+	}
+	_, err = parsePointsToOpen([]*pb.PointToOpen{{Point: &pb.PointToOpen_Z{Z: nonCanonical}}}, blobLength)
+	require.Error(t, err)
+	_, err = parsePointsToOpen([]*pb.PointToOpen{{}}, blobLength)
+	require.Error(t, err)
+
+	// the same point may not be opened twice, even if it is requested once by index and once as a field element
+	one := zs[0].Bytes()
+	_, err = parsePointsToOpen([]*pb.PointToOpen{
+		{Point: &pb.PointToOpen_Index{Index: 0}},
+		{Point: &pb.PointToOpen_Z{Z: one[:]}},
+	}, blobLength)
+	require.Error(t, err)
+}
+
+func TestSelectChunkDataFormats(t *testing.T) {
+	rand := random.NewTestRandom()
+
+	_, _, g1Gen, _ := bn254.Generators()
+	frames := make([]*encoding.Frame, 8)
+	for i := range frames {
+		frames[i] = &encoding.Frame{Coeffs: make([]fr.Element, 16)}
+		frames[i].Proof.ScalarMultiplication(&g1Gen, big.NewInt(int64(i+1)))
+		for j := range frames[i].Coeffs {
+			_, err := frames[i].Coeffs[j].SetRandom()
+			require.NoError(t, err)
+		}
+	}
+	chunksData, err := new(core.ChunksData).FromFrames(frames)
+	require.NoError(t, err)
+
+	blobKey := v2.BlobKey(rand.Bytes(32))
+	allFrames := map[v2.BlobKey]*core.ChunksData{blobKey: chunksData}
+	chunkRequest := &pb.ChunkRequest{
+		Request: &pb.ChunkRequest_ByRange{
+			ByRange: &pb.ChunkRequestByRange{
+				BlobKey:    blobKey[:],
+				StartIndex: 2,
+				EndIndex:   6,
+			},
+		},
+	}
+
+	// clients that don't request a format get GNARK bundles
+	gnarkBundle, err := selectChunkData(allFrames, chunkRequest, pb.BundleEncodingFormat_GNARK)
+	require.NoError(t, err)
+	defaultBundle, err := selectChunkData(allFrames, chunkRequest, pb.BundleEncodingFormat_DEFAULT)
+	require.NoError(t, err)
+	require.Equal(t, gnarkBundle, defaultBundle)
+
+	compressedBundle, err := selectChunkData(allFrames, chunkRequest, pb.BundleEncodingFormat_GNARK_COMPRESSED)
+	require.NoError(t, err)
+	require.Equal(t, 8+4*encoding.GnarkCompressedChunkSize(16), len(compressedBundle))
+	require.Less(t, len(compressedBundle), len(gnarkBundle))
+
+	for _, bundleBytes := range [][]byte{gnarkBundle, compressedBundle} {
+		bundle, err := new(core.Bundle).Deserialize(bundleBytes)
+		require.NoError(t, err)
+		require.Len(t, bundle, 4)
+		for i, frame := range bundle {
+			require.True(t, frame.Proof.Equal(&frames[2+i].Proof))
+			require.Equal(t, frames[2+i].Coeffs, frame.Coeffs)
+		}
+	}
+}
@@ -8,9 +8,16 @@ type TimeoutConfig struct {
 	// The maximum time permitted for a GetChunks GRPC to complete. If zero then no timeout is enforced.
 	GetChunksTimeout time.Duration
 
+	// The maximum time permitted for a StreamChunks GRPC to complete, including the time spent sending all bundles
+	// to the client. If zero then no timeout is enforced.
+	StreamChunksTimeout time.Duration
+
 	// The maximum time permitted for a GetBlob GRPC to complete. If zero then no timeout is enforced.
 	GetBlobTimeout time.Duration
 
+	// The maximum time permitted for a GetPointProof GRPC to complete. If zero then no timeout is enforced.
+	GetPointProofTimeout time.Duration
+
 	// The maximum time permitted for a single request to the metadata store to fetch the metadata
 	// for an individual blob.
 	InternalGetMetadataTimeout time.Duration
@@ -77,12 +77,19 @@ func NewTestClient(
 
 	// Construct the disperser client
 
-	privateKey, err := loadPrivateKey(config.KeyPath, config.KeyVar)
-	if err != nil {
-		return nil, fmt.Errorf("failed to load private key: %w", err)
+	signerConfig := config.Signer
+	var privateKey string
+	if (signerConfig.KMS.KeyID == "" || signerConfig.KMS.Disable) && signerConfig.RemoteSignerURL == "" {
+		// Without a KMS key or a remote signer, dispersal requests are signed with the account's private key
+		key, err := loadPrivateKey(config.KeyPath, config.KeyVar)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load private key: %w", err)
+		}
+		privateKey = key
+		signerConfig.PrivateKeyHex = key
 	}
 
-	signer, err := auth.NewLocalBlobRequestSigner(privateKey)
+	signer, err := auth.NewBlobRequestSigner(context.Background(), signerConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create signer: %w", err)
 	}
@@ -464,7 +471,8 @@ func (c *TestClient) GetCertBuilder() *clients.CertBuilder {
 	return c.certBuilder
 }
 
-// GetPrivateKey returns the test client's private key.
+// GetPrivateKey returns the test client's private key, or an empty string if requests are signed with a KMS key or
+// a remote signer.
 func (c *TestClient) GetPrivateKey() string {
 	return c.privateKey
 }
@@ -3,6 +3,8 @@ package client
 import (
 	"fmt"
 	"path"
+
+	auth "github.com/Layr-Labs/eigenda/core/auth/v2"
 )
 
 // TestClientConfig is the configuration for the test client.
@@ -18,6 +20,9 @@ type TestClientConfig struct {
 	//
 	// This is used if KeyPath is not set.
 	KeyVar string
+	// Describes a KMS key or a remote signer that signs dispersal requests for the account paying for dispersals.
+	// If one is set, KeyPath and KeyVar are not used and the private key is never loaded.
+	Signer auth.BlobRequestSignerConfig
 	// The disperser's hostname (url or IP address)
 	DisperserHostname string
 	// The disperser's port